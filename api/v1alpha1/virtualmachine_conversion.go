@@ -841,6 +841,10 @@ func restore_v1alpha4_VirtualMachineGroupName(dst, src *vmopv1.VirtualMachine) {
 	dst.Spec.GroupName = src.Spec.GroupName
 }
 
+func restore_v1alpha4_VirtualMachineImageChannel(dst, src *vmopv1.VirtualMachine) {
+	dst.Spec.ImageChannel = src.Spec.ImageChannel
+}
+
 func restore_v1alpha4_VirtualMachineCryptoSpec(dst, src *vmopv1.VirtualMachine) {
 	dst.Spec.Crypto = src.Spec.Crypto
 }
@@ -924,6 +928,16 @@ func restore_v1alpha4_VirtualMachineBootstrapSpec(
 	}
 }
 
+func restore_v1alpha4_VirtualMachineBootstrapCustomizationGeneration(dst, src *vmopv1.VirtualMachine) {
+	if src.Spec.Bootstrap == nil || src.Spec.Bootstrap.CustomizationGeneration == 0 {
+		return
+	}
+	if dst.Spec.Bootstrap == nil {
+		dst.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{}
+	}
+	dst.Spec.Bootstrap.CustomizationGeneration = src.Spec.Bootstrap.CustomizationGeneration
+}
+
 func restore_v1alpha4_VirtualMachineNetworkSpec(
 	dst, src *vmopv1.VirtualMachine) {
 
@@ -1272,6 +1286,7 @@ func (src *VirtualMachine) ConvertTo(dstRaw ctrlconversion.Hub) error {
 
 	restore_v1alpha4_VirtualMachineImage(dst, restored)
 	restore_v1alpha4_VirtualMachineBootstrapSpec(dst, restored)
+	restore_v1alpha4_VirtualMachineBootstrapCustomizationGeneration(dst, restored)
 	restore_v1alpha4_VirtualMachineNetworkSpec(dst, restored)
 	restore_v1alpha4_VirtualMachineReadinessProbeSpec(dst, restored)
 	restore_v1alpha4_VirtualMachineBiosUUID(dst, restored)
@@ -1284,6 +1299,7 @@ func (src *VirtualMachine) ConvertTo(dstRaw ctrlconversion.Hub) error {
 	restore_v1alpha4_VirtualMachineBootOptions(dst, restored)
 	restore_v1alpha4_VirtualMachineAffinitySpec(dst, restored)
 	restore_v1alpha4_VirtualMachineGroupName(dst, restored)
+	restore_v1alpha4_VirtualMachineImageChannel(dst, restored)
 
 	// END RESTORE
 
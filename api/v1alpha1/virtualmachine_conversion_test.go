@@ -114,6 +114,7 @@ func TestVirtualMachineConversion(t *testing.T) {
 								Name: "primary",
 							},
 							GuestDeviceName: "eth10",
+							MacAddress:      "00:50:56:00:00:01",
 						},
 						{
 							Name: "ncp-interface",
@@ -1878,6 +1879,45 @@ func TestVirtualMachineConversion(t *testing.T) {
 		hubSpokeHub(g, &hub, &vmopv1a1.VirtualMachine{})
 	})
 
+	t.Run("VirtualMachine hub-spoke-hub with customization generation", func(t *testing.T) {
+		g := NewWithT(t)
+		hub := vmopv1.VirtualMachine{
+			Spec: vmopv1.VirtualMachineSpec{
+				Bootstrap: &vmopv1.VirtualMachineBootstrapSpec{
+					CustomizationGeneration: 2,
+				},
+			},
+			Status: vmopv1.VirtualMachineStatus{
+				ObservedCustomizationGeneration: 1,
+			},
+		}
+		hubSpokeHub(g, &hub, &vmopv1a1.VirtualMachine{})
+	})
+
+	t.Run("VirtualMachine hub-spoke-hub with image channel", func(t *testing.T) {
+		g := NewWithT(t)
+		hub := vmopv1.VirtualMachine{
+			Spec: vmopv1.VirtualMachineSpec{
+				Image: &vmopv1.VirtualMachineImageRef{
+					Kind: "VirtualMachineImage",
+					Name: "vmi-123",
+				},
+				ImageChannel: "my-channel",
+			},
+			Status: vmopv1.VirtualMachineStatus{
+				Image: &vmopv1.VirtualMachineImageRefStatus{
+					VirtualMachineImageRef: vmopv1.VirtualMachineImageRef{
+						Kind: "VirtualMachineImage",
+						Name: "vmi-123",
+					},
+					Version: "1.0.0",
+					Channel: "my-channel",
+				},
+			},
+		}
+		hubSpokeHub(g, &hub, &vmopv1a1.VirtualMachine{})
+	})
+
 	t.Run("VirtualMachine status.storage", func(t *testing.T) {
 		t.Run("hub-spoke-hub", func(t *testing.T) {
 			g := NewWithT(t)
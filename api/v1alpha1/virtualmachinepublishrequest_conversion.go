@@ -5,21 +5,52 @@
 package v1alpha1
 
 import (
+	apiconversion "k8s.io/apimachinery/pkg/conversion"
 	ctrlconversion "sigs.k8s.io/controller-runtime/pkg/conversion"
 
+	"github.com/vmware-tanzu/vm-operator/api/utilconversion"
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
 )
 
+func Convert_v1alpha4_VirtualMachinePublishRequestTargetLocation_To_v1alpha1_VirtualMachinePublishRequestTargetLocation(
+	in *vmopv1.VirtualMachinePublishRequestTargetLocation, out *VirtualMachinePublishRequestTargetLocation, s apiconversion.Scope) error {
+	return autoConvert_v1alpha4_VirtualMachinePublishRequestTargetLocation_To_v1alpha1_VirtualMachinePublishRequestTargetLocation(in, out, s)
+}
+
+func restore_v1alpha4_VirtualMachinePublishRequestTargetLocationOCI(dst, src *vmopv1.VirtualMachinePublishRequest) {
+	dst.Spec.Target.Location.OCI = src.Spec.Target.Location.OCI
+	if dst.Status.TargetRef != nil && src.Status.TargetRef != nil {
+		dst.Status.TargetRef.Location.OCI = src.Status.TargetRef.Location.OCI
+	}
+}
+
 // ConvertTo converts this VirtualMachinePublishRequest to the Hub version.
 func (src *VirtualMachinePublishRequest) ConvertTo(dstRaw ctrlconversion.Hub) error {
 	dst := dstRaw.(*vmopv1.VirtualMachinePublishRequest)
-	return Convert_v1alpha1_VirtualMachinePublishRequest_To_v1alpha4_VirtualMachinePublishRequest(src, dst, nil)
+	if err := Convert_v1alpha1_VirtualMachinePublishRequest_To_v1alpha4_VirtualMachinePublishRequest(src, dst, nil); err != nil {
+		return err
+	}
+
+	// Manually restore data.
+	restored := &vmopv1.VirtualMachinePublishRequest{}
+	if ok, err := utilconversion.UnmarshalData(src, restored); err != nil || !ok {
+		return err
+	}
+
+	restore_v1alpha4_VirtualMachinePublishRequestTargetLocationOCI(dst, restored)
+
+	return nil
 }
 
 // ConvertFrom converts the hub version to this VirtualMachinePublishRequest.
 func (dst *VirtualMachinePublishRequest) ConvertFrom(srcRaw ctrlconversion.Hub) error {
 	src := srcRaw.(*vmopv1.VirtualMachinePublishRequest)
-	return Convert_v1alpha4_VirtualMachinePublishRequest_To_v1alpha1_VirtualMachinePublishRequest(src, dst, nil)
+	if err := Convert_v1alpha4_VirtualMachinePublishRequest_To_v1alpha1_VirtualMachinePublishRequest(src, dst, nil); err != nil {
+		return err
+	}
+
+	// Preserve Hub data on down-conversion except for metadata
+	return utilconversion.MarshalData(src, dst)
 }
 
 // ConvertTo converts this VirtualMachinePublishRequestList to the Hub version.
@@ -377,11 +377,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1alpha4.VirtualMachinePublishRequestTargetLocation)(nil), (*VirtualMachinePublishRequestTargetLocation)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1alpha4_VirtualMachinePublishRequestTargetLocation_To_v1alpha1_VirtualMachinePublishRequestTargetLocation(a.(*v1alpha4.VirtualMachinePublishRequestTargetLocation), b.(*VirtualMachinePublishRequestTargetLocation), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*VirtualMachineResourceSpec)(nil), (*v1alpha4.VirtualMachineResourceSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha1_VirtualMachineResourceSpec_To_v1alpha4_VirtualMachineResourceSpec(a.(*VirtualMachineResourceSpec), b.(*v1alpha4.VirtualMachineResourceSpec), scope)
 	}); err != nil {
@@ -552,6 +547,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1alpha4.VirtualMachinePublishRequestTargetLocation)(nil), (*VirtualMachinePublishRequestTargetLocation)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha4_VirtualMachinePublishRequestTargetLocation_To_v1alpha1_VirtualMachinePublishRequestTargetLocation(a.(*v1alpha4.VirtualMachinePublishRequestTargetLocation), b.(*VirtualMachinePublishRequestTargetLocation), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1alpha4.VirtualMachineReadinessProbeSpec)(nil), (*Probe)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha4_VirtualMachineReadinessProbeSpec_To_v1alpha1_Probe(a.(*v1alpha4.VirtualMachineReadinessProbeSpec), b.(*Probe), scope)
 	}); err != nil {
@@ -1392,6 +1392,7 @@ func autoConvert_v1alpha1_VirtualMachineImageSpec_To_v1alpha4_VirtualMachineImag
 
 func autoConvert_v1alpha4_VirtualMachineImageSpec_To_v1alpha1_VirtualMachineImageSpec(in *v1alpha4.VirtualMachineImageSpec, out *VirtualMachineImageSpec, s conversion.Scope) error {
 	// WARNING: in.ProviderRef requires manual conversion: inconvertible types (*github.com/vmware-tanzu/vm-operator/api/v1alpha4/common.LocalObjectRef vs github.com/vmware-tanzu/vm-operator/api/v1alpha1.ContentProviderReference)
+	// WARNING: in.OCI requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -1430,6 +1431,8 @@ func autoConvert_v1alpha4_VirtualMachineImageStatus_To_v1alpha1_VirtualMachineIm
 	// WARNING: in.Disks requires manual conversion: does not exist in peer-type
 	// WARNING: in.ProviderContentVersion requires manual conversion: does not exist in peer-type
 	// WARNING: in.ProviderItemID requires manual conversion: does not exist in peer-type
+	// WARNING: in.Fingerprint requires manual conversion: does not exist in peer-type
+	// WARNING: in.FingerprintContentVersion requires manual conversion: does not exist in peer-type
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]Condition, len(*in))
@@ -1619,7 +1622,15 @@ func Convert_v1alpha4_VirtualMachinePublishRequestSpec_To_v1alpha1_VirtualMachin
 
 func autoConvert_v1alpha1_VirtualMachinePublishRequestStatus_To_v1alpha4_VirtualMachinePublishRequestStatus(in *VirtualMachinePublishRequestStatus, out *v1alpha4.VirtualMachinePublishRequestStatus, s conversion.Scope) error {
 	out.SourceRef = (*v1alpha4.VirtualMachinePublishRequestSource)(unsafe.Pointer(in.SourceRef))
-	out.TargetRef = (*v1alpha4.VirtualMachinePublishRequestTarget)(unsafe.Pointer(in.TargetRef))
+	if in.TargetRef != nil {
+		in, out := &in.TargetRef, &out.TargetRef
+		*out = new(v1alpha4.VirtualMachinePublishRequestTarget)
+		if err := Convert_v1alpha1_VirtualMachinePublishRequestTarget_To_v1alpha4_VirtualMachinePublishRequestTarget(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.TargetRef = nil
+	}
 	out.CompletionTime = in.CompletionTime
 	out.StartTime = in.StartTime
 	out.Attempts = in.Attempts
@@ -1647,7 +1658,15 @@ func Convert_v1alpha1_VirtualMachinePublishRequestStatus_To_v1alpha4_VirtualMach
 
 func autoConvert_v1alpha4_VirtualMachinePublishRequestStatus_To_v1alpha1_VirtualMachinePublishRequestStatus(in *v1alpha4.VirtualMachinePublishRequestStatus, out *VirtualMachinePublishRequestStatus, s conversion.Scope) error {
 	out.SourceRef = (*VirtualMachinePublishRequestSource)(unsafe.Pointer(in.SourceRef))
-	out.TargetRef = (*VirtualMachinePublishRequestTarget)(unsafe.Pointer(in.TargetRef))
+	if in.TargetRef != nil {
+		in, out := &in.TargetRef, &out.TargetRef
+		*out = new(VirtualMachinePublishRequestTarget)
+		if err := Convert_v1alpha4_VirtualMachinePublishRequestTarget_To_v1alpha1_VirtualMachinePublishRequestTarget(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.TargetRef = nil
+	}
 	out.CompletionTime = in.CompletionTime
 	out.StartTime = in.StartTime
 	out.Attempts = in.Attempts
@@ -1741,14 +1760,10 @@ func autoConvert_v1alpha4_VirtualMachinePublishRequestTargetLocation_To_v1alpha1
 	out.Name = in.Name
 	out.APIVersion = in.APIVersion
 	out.Kind = in.Kind
+	// WARNING: in.OCI requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1alpha4_VirtualMachinePublishRequestTargetLocation_To_v1alpha1_VirtualMachinePublishRequestTargetLocation is an autogenerated conversion function.
-func Convert_v1alpha4_VirtualMachinePublishRequestTargetLocation_To_v1alpha1_VirtualMachinePublishRequestTargetLocation(in *v1alpha4.VirtualMachinePublishRequestTargetLocation, out *VirtualMachinePublishRequestTargetLocation, s conversion.Scope) error {
-	return autoConvert_v1alpha4_VirtualMachinePublishRequestTargetLocation_To_v1alpha1_VirtualMachinePublishRequestTargetLocation(in, out, s)
-}
-
 func autoConvert_v1alpha1_VirtualMachineResourceSpec_To_v1alpha4_VirtualMachineResourceSpec(in *VirtualMachineResourceSpec, out *v1alpha4.VirtualMachineResourceSpec, s conversion.Scope) error {
 	out.Cpu = in.Cpu
 	out.Memory = in.Memory
@@ -2061,6 +2076,7 @@ func autoConvert_v1alpha4_VirtualMachineSpec_To_v1alpha1_VirtualMachineSpec(in *
 	// WARNING: in.Cdrom requires manual conversion: does not exist in peer-type
 	// WARNING: in.Image requires manual conversion: does not exist in peer-type
 	out.ImageName = in.ImageName
+	// WARNING: in.ImageChannel requires manual conversion: does not exist in peer-type
 	out.ClassName = in.ClassName
 	// WARNING: in.Class requires manual conversion: does not exist in peer-type
 	// WARNING: in.Affinity requires manual conversion: does not exist in peer-type
@@ -2147,6 +2163,7 @@ func autoConvert_v1alpha1_VirtualMachineStatus_To_v1alpha4_VirtualMachineStatus(
 
 func autoConvert_v1alpha4_VirtualMachineStatus_To_v1alpha1_VirtualMachineStatus(in *v1alpha4.VirtualMachineStatus, out *VirtualMachineStatus, s conversion.Scope) error {
 	// WARNING: in.Class requires manual conversion: does not exist in peer-type
+	// WARNING: in.Image requires manual conversion: does not exist in peer-type
 	// WARNING: in.NodeName requires manual conversion: does not exist in peer-type
 	out.PowerState = VirtualMachinePowerState(in.PowerState)
 	if in.Conditions != nil {
@@ -2179,6 +2196,7 @@ func autoConvert_v1alpha4_VirtualMachineStatus_To_v1alpha1_VirtualMachineStatus(
 	out.ChangeBlockTracking = (*bool)(unsafe.Pointer(in.ChangeBlockTracking))
 	out.Zone = in.Zone
 	out.LastRestartTime = (*v1.Time)(unsafe.Pointer(in.LastRestartTime))
+	// WARNING: in.ObservedCustomizationGeneration requires manual conversion: does not exist in peer-type
 	out.HardwareVersion = in.HardwareVersion
 	// WARNING: in.Storage requires manual conversion: does not exist in peer-type
 	// WARNING: in.TaskID requires manual conversion: does not exist in peer-type
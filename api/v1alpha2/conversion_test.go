@@ -258,8 +258,17 @@ func overrideVirtualMachineImageFieldsFuncs(codecs runtimeserializer.CodecFactor
 			c.Fuzz(vmiStatus)
 
 			// Since only VMOP updates the CVMI/VMI's we didn't bother with conversion
-			// when adding this field.
+			// when adding these fields.
 			vmiStatus.Disks = nil
+			vmiStatus.Fingerprint = ""
+			vmiStatus.FingerprintContentVersion = ""
+		},
+		func(vmiSpec *vmopv1.VirtualMachineImageSpec, c fuzz.Continue) {
+			c.Fuzz(vmiSpec)
+
+			// Since only VMOP creates the CVMI/VMI's from OCI artifacts we
+			// didn't bother with conversion when adding this field.
+			vmiSpec.OCI = nil
 		},
 	}
 }
@@ -31,6 +31,24 @@ func Convert_v1alpha4_VirtualMachineNetworkSpec_To_v1alpha2_VirtualMachineNetwor
 	return autoConvert_v1alpha4_VirtualMachineNetworkSpec_To_v1alpha2_VirtualMachineNetworkSpec(in, out, s)
 }
 
+func Convert_v1alpha4_VirtualMachineNetworkInterfaceSpec_To_v1alpha2_VirtualMachineNetworkInterfaceSpec(
+	in *vmopv1.VirtualMachineNetworkInterfaceSpec, out *VirtualMachineNetworkInterfaceSpec, s apiconversion.Scope) error {
+
+	return autoConvert_v1alpha4_VirtualMachineNetworkInterfaceSpec_To_v1alpha2_VirtualMachineNetworkInterfaceSpec(in, out, s)
+}
+
+func Convert_v1alpha4_VirtualMachineNetworkConfigInterfaceStatus_To_v1alpha2_VirtualMachineNetworkConfigInterfaceStatus(
+	in *vmopv1.VirtualMachineNetworkConfigInterfaceStatus, out *VirtualMachineNetworkConfigInterfaceStatus, s apiconversion.Scope) error {
+
+	return autoConvert_v1alpha4_VirtualMachineNetworkConfigInterfaceStatus_To_v1alpha2_VirtualMachineNetworkConfigInterfaceStatus(in, out, s)
+}
+
+func Convert_v1alpha4_VirtualMachineBootstrapSpec_To_v1alpha2_VirtualMachineBootstrapSpec(
+	in *vmopv1.VirtualMachineBootstrapSpec, out *VirtualMachineBootstrapSpec, s apiconversion.Scope) error {
+
+	return autoConvert_v1alpha4_VirtualMachineBootstrapSpec_To_v1alpha2_VirtualMachineBootstrapSpec(in, out, s)
+}
+
 func Convert_v1alpha4_VirtualMachineSpec_To_v1alpha2_VirtualMachineSpec(
 	in *vmopv1.VirtualMachineSpec, out *VirtualMachineSpec, s apiconversion.Scope) error {
 
@@ -99,6 +117,25 @@ func Convert_v1alpha2_VirtualMachineStatus_To_v1alpha4_VirtualMachineStatus(
 	return nil
 }
 
+func Convert_common_LocalObjectRef_To_v1alpha4_VirtualMachineImageRefStatus(
+	in *vmopv1a2common.LocalObjectRef, out *vmopv1.VirtualMachineImageRefStatus, s apiconversion.Scope) error {
+
+	out.Kind = in.Kind
+	out.Name = in.Name
+
+	return nil
+}
+
+func Convert_v1alpha4_VirtualMachineImageRefStatus_To_common_LocalObjectRef(
+	in *vmopv1.VirtualMachineImageRefStatus, out *vmopv1a2common.LocalObjectRef, s apiconversion.Scope) error {
+
+	out.APIVersion = vmopv1.GroupVersion.String()
+	out.Kind = in.Kind
+	out.Name = in.Name
+
+	return nil
+}
+
 func Convert_v1alpha4_VirtualMachineVolumeStatus_To_v1alpha2_VirtualMachineVolumeStatus(
 	in *vmopv1.VirtualMachineVolumeStatus, out *VirtualMachineVolumeStatus, s apiconversion.Scope) error {
 
@@ -155,6 +192,10 @@ func restore_v1alpha4_VirtualMachineGroupName(dst, src *vmopv1.VirtualMachine) {
 	dst.Spec.GroupName = src.Spec.GroupName
 }
 
+func restore_v1alpha4_VirtualMachineImageChannel(dst, src *vmopv1.VirtualMachine) {
+	dst.Spec.ImageChannel = src.Spec.ImageChannel
+}
+
 func restore_v1alpha4_VirtualMachineCryptoSpec(dst, src *vmopv1.VirtualMachine) {
 	dst.Spec.Crypto = src.Spec.Crypto
 }
@@ -281,6 +322,32 @@ func restore_v1alpha4_VirtualMachineBootstrapCloudInitInstanceID(
 	dst.Spec.Bootstrap.CloudInit.InstanceID = iid
 }
 
+func restore_v1alpha4_VirtualMachineBootstrapCloudInitCloudConfig(dst, src *vmopv1.VirtualMachine) {
+	if bs := src.Spec.Bootstrap; bs != nil {
+		if ci := bs.CloudInit; ci != nil && ci.CloudConfig != nil {
+			// Only restore these values if dst still has a CloudConfig.
+			if dst.Spec.Bootstrap != nil && dst.Spec.Bootstrap.CloudInit != nil &&
+				dst.Spec.Bootstrap.CloudInit.CloudConfig != nil {
+
+				dstCC, srcCC := dst.Spec.Bootstrap.CloudInit.CloudConfig, ci.CloudConfig
+				dstCC.BootCmd = srcCC.BootCmd
+				dstCC.Packages = srcCC.Packages
+				dstCC.PackageUpdate = srcCC.PackageUpdate
+				dstCC.PackageUpgrade = srcCC.PackageUpgrade
+				dstCC.PackageRebootIfRequired = srcCC.PackageRebootIfRequired
+				dstCC.Apt = srcCC.Apt
+				dstCC.YumRepos = srcCC.YumRepos
+				dstCC.NTP = srcCC.NTP
+				dstCC.CACerts = srcCC.CACerts
+				dstCC.DiskSetup = srcCC.DiskSetup
+				dstCC.FSSetup = srcCC.FSSetup
+				dstCC.Mounts = srcCC.Mounts
+				dstCC.PowerState = srcCC.PowerState
+			}
+		}
+	}
+}
+
 func restore_v1alpha4_VirtualMachineBootstrapCloudInitWaitOnNetwork(dst, src *vmopv1.VirtualMachine) {
 	if bs := src.Spec.Bootstrap; bs != nil {
 		if ci := bs.CloudInit; ci != nil {
@@ -315,6 +382,108 @@ func restore_v1alpha4_VirtualMachineAffinitySpec(dst, src *vmopv1.VirtualMachine
 	dst.Spec.Affinity = src.Spec.Affinity
 }
 
+func restore_v1alpha4_VirtualMachineNetworkInterfaceAddressesFromPools(dst, src *vmopv1.VirtualMachine) {
+	if src.Spec.Network == nil || dst.Spec.Network == nil {
+		return
+	}
+
+	for i := range dst.Spec.Network.Interfaces {
+		dstIface := &dst.Spec.Network.Interfaces[i]
+		for j := range src.Spec.Network.Interfaces {
+			if srcIface := &src.Spec.Network.Interfaces[j]; srcIface.Name == dstIface.Name {
+				dstIface.AddressesFromPools = srcIface.AddressesFromPools
+				break
+			}
+		}
+	}
+}
+
+func restore_v1alpha4_VirtualMachineNetworkInterfaceType(dst, src *vmopv1.VirtualMachine) {
+	if src.Spec.Network == nil || dst.Spec.Network == nil {
+		return
+	}
+
+	for i := range dst.Spec.Network.Interfaces {
+		dstIface := &dst.Spec.Network.Interfaces[i]
+		for j := range src.Spec.Network.Interfaces {
+			if srcIface := &src.Spec.Network.Interfaces[j]; srcIface.Name == dstIface.Name {
+				dstIface.Type = srcIface.Type
+				dstIface.SRIOV = srcIface.SRIOV
+				dstIface.DVX = srcIface.DVX
+				break
+			}
+		}
+	}
+}
+
+func restore_v1alpha4_VirtualMachineNetworkInterfaceQoS(dst, src *vmopv1.VirtualMachine) {
+	if src.Spec.Network == nil || dst.Spec.Network == nil {
+		return
+	}
+
+	for i := range dst.Spec.Network.Interfaces {
+		dstIface := &dst.Spec.Network.Interfaces[i]
+		for j := range src.Spec.Network.Interfaces {
+			if srcIface := &src.Spec.Network.Interfaces[j]; srcIface.Name == dstIface.Name {
+				dstIface.QoS = srcIface.QoS
+				dstIface.TrafficRules = srcIface.TrafficRules
+				break
+			}
+		}
+	}
+}
+
+func restore_v1alpha4_VirtualMachineNetworkInterfaceMacAddress(dst, src *vmopv1.VirtualMachine) {
+	if src.Spec.Network == nil || dst.Spec.Network == nil {
+		return
+	}
+
+	for i := range dst.Spec.Network.Interfaces {
+		dstIface := &dst.Spec.Network.Interfaces[i]
+		for j := range src.Spec.Network.Interfaces {
+			if srcIface := &src.Spec.Network.Interfaces[j]; srcIface.Name == dstIface.Name {
+				dstIface.MacAddress = srcIface.MacAddress
+				break
+			}
+		}
+	}
+}
+
+func restore_v1alpha4_VirtualMachineBootstrapIgnition(dst, src *vmopv1.VirtualMachine) {
+	if src.Spec.Bootstrap == nil || src.Spec.Bootstrap.Ignition == nil {
+		return
+	}
+	if dst.Spec.Bootstrap == nil {
+		dst.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{}
+	}
+	dst.Spec.Bootstrap.Ignition = src.Spec.Bootstrap.Ignition
+}
+
+func restore_v1alpha4_VirtualMachineBootstrapCustomizationGeneration(dst, src *vmopv1.VirtualMachine) {
+	if src.Spec.Bootstrap == nil || src.Spec.Bootstrap.CustomizationGeneration == 0 {
+		return
+	}
+	if dst.Spec.Bootstrap == nil {
+		dst.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{}
+	}
+	dst.Spec.Bootstrap.CustomizationGeneration = src.Spec.Bootstrap.CustomizationGeneration
+}
+
+func restore_v1alpha4_VirtualMachineNetworkGuestDevices(dst, src *vmopv1.VirtualMachine) {
+	srcNet := src.Spec.Network
+	if srcNet == nil ||
+		(len(srcNet.Bonds) == 0 && len(srcNet.VLANs) == 0 && len(srcNet.Bridges) == 0) {
+
+		return
+	}
+	if dst.Spec.Network == nil {
+		dst.Spec.Network = &vmopv1.VirtualMachineNetworkSpec{}
+	}
+	dst.Spec.Network.Bonds = srcNet.Bonds
+	dst.Spec.Network.VLANs = srcNet.VLANs
+	dst.Spec.Network.Bridges = srcNet.Bridges
+}
+
 // ConvertTo converts this VirtualMachine to the Hub version.
 func (src *VirtualMachine) ConvertTo(dstRaw ctrlconversion.Hub) error {
 	dst := dstRaw.(*vmopv1.VirtualMachine)
@@ -343,6 +512,15 @@ func (src *VirtualMachine) ConvertTo(dstRaw ctrlconversion.Hub) error {
 	restore_v1alpha4_VirtualMachineBootOptions(dst, restored)
 	restore_v1alpha4_VirtualMachineAffinitySpec(dst, restored)
 	restore_v1alpha4_VirtualMachineGroupName(dst, restored)
+	restore_v1alpha4_VirtualMachineNetworkInterfaceAddressesFromPools(dst, restored)
+	restore_v1alpha4_VirtualMachineNetworkInterfaceType(dst, restored)
+	restore_v1alpha4_VirtualMachineNetworkInterfaceQoS(dst, restored)
+	restore_v1alpha4_VirtualMachineNetworkInterfaceMacAddress(dst, restored)
+	restore_v1alpha4_VirtualMachineBootstrapIgnition(dst, restored)
+	restore_v1alpha4_VirtualMachineBootstrapCloudInitCloudConfig(dst, restored)
+	restore_v1alpha4_VirtualMachineBootstrapCustomizationGeneration(dst, restored)
+	restore_v1alpha4_VirtualMachineNetworkGuestDevices(dst, restored)
+	restore_v1alpha4_VirtualMachineImageChannel(dst, restored)
 
 	// END RESTORE
 
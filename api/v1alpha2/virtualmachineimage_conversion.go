@@ -16,6 +16,11 @@ func Convert_v1alpha4_VirtualMachineImageStatus_To_v1alpha2_VirtualMachineImageS
 	return autoConvert_v1alpha4_VirtualMachineImageStatus_To_v1alpha2_VirtualMachineImageStatus(in, out, s)
 }
 
+func Convert_v1alpha4_VirtualMachineImageSpec_To_v1alpha2_VirtualMachineImageSpec(
+	in *vmopv1.VirtualMachineImageSpec, out *VirtualMachineImageSpec, s apiconversion.Scope) error {
+	return autoConvert_v1alpha4_VirtualMachineImageSpec_To_v1alpha2_VirtualMachineImageSpec(in, out, s)
+}
+
 // ConvertTo converts this VirtualMachineImage to the Hub version.
 func (src *VirtualMachineImage) ConvertTo(dstRaw ctrlconversion.Hub) error {
 	dst := dstRaw.(*vmopv1.VirtualMachineImage)
@@ -14,13 +14,15 @@ import (
 	unsafe "unsafe"
 
 	v1alpha2cloudinit "github.com/vmware-tanzu/vm-operator/api/v1alpha2/cloudinit"
-	v1alpha2common "github.com/vmware-tanzu/vm-operator/api/v1alpha2/common"
+	conversionv1alpha2 "github.com/vmware-tanzu/vm-operator/api/v1alpha2/cloudinit/conversion/v1alpha2"
+	conversionv1alpha4 "github.com/vmware-tanzu/vm-operator/api/v1alpha2/cloudinit/conversion/v1alpha4"
+	common "github.com/vmware-tanzu/vm-operator/api/v1alpha2/common"
 	v1alpha2sysprep "github.com/vmware-tanzu/vm-operator/api/v1alpha2/sysprep"
-	conversionv1alpha2 "github.com/vmware-tanzu/vm-operator/api/v1alpha2/sysprep/conversion/v1alpha2"
-	conversionv1alpha4 "github.com/vmware-tanzu/vm-operator/api/v1alpha2/sysprep/conversion/v1alpha4"
+	sysprepconversionv1alpha2 "github.com/vmware-tanzu/vm-operator/api/v1alpha2/sysprep/conversion/v1alpha2"
+	sysprepconversionv1alpha4 "github.com/vmware-tanzu/vm-operator/api/v1alpha2/sysprep/conversion/v1alpha4"
 	v1alpha4 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
 	cloudinit "github.com/vmware-tanzu/vm-operator/api/v1alpha4/cloudinit"
-	common "github.com/vmware-tanzu/vm-operator/api/v1alpha4/common"
+	v1alpha4common "github.com/vmware-tanzu/vm-operator/api/v1alpha4/common"
 	sysprep "github.com/vmware-tanzu/vm-operator/api/v1alpha4/sysprep"
 	resource "k8s.io/apimachinery/pkg/api/resource"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -255,11 +257,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1alpha4.VirtualMachineBootstrapSpec)(nil), (*VirtualMachineBootstrapSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1alpha4_VirtualMachineBootstrapSpec_To_v1alpha2_VirtualMachineBootstrapSpec(a.(*v1alpha4.VirtualMachineBootstrapSpec), b.(*VirtualMachineBootstrapSpec), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*VirtualMachineBootstrapSysprepSpec)(nil), (*v1alpha4.VirtualMachineBootstrapSysprepSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_VirtualMachineBootstrapSysprepSpec_To_v1alpha4_VirtualMachineBootstrapSysprepSpec(a.(*VirtualMachineBootstrapSysprepSpec), b.(*v1alpha4.VirtualMachineBootstrapSysprepSpec), scope)
 	}); err != nil {
@@ -395,11 +392,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1alpha4.VirtualMachineImageSpec)(nil), (*VirtualMachineImageSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1alpha4_VirtualMachineImageSpec_To_v1alpha2_VirtualMachineImageSpec(a.(*v1alpha4.VirtualMachineImageSpec), b.(*VirtualMachineImageSpec), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*VirtualMachineImageStatus)(nil), (*v1alpha4.VirtualMachineImageStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_VirtualMachineImageStatus_To_v1alpha4_VirtualMachineImageStatus(a.(*VirtualMachineImageStatus), b.(*v1alpha4.VirtualMachineImageStatus), scope)
 	}); err != nil {
@@ -455,11 +447,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1alpha4.VirtualMachineNetworkConfigInterfaceStatus)(nil), (*VirtualMachineNetworkConfigInterfaceStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1alpha4_VirtualMachineNetworkConfigInterfaceStatus_To_v1alpha2_VirtualMachineNetworkConfigInterfaceStatus(a.(*v1alpha4.VirtualMachineNetworkConfigInterfaceStatus), b.(*VirtualMachineNetworkConfigInterfaceStatus), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*VirtualMachineNetworkConfigStatus)(nil), (*v1alpha4.VirtualMachineNetworkConfigStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_VirtualMachineNetworkConfigStatus_To_v1alpha4_VirtualMachineNetworkConfigStatus(a.(*VirtualMachineNetworkConfigStatus), b.(*v1alpha4.VirtualMachineNetworkConfigStatus), scope)
 	}); err != nil {
@@ -555,11 +542,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1alpha4.VirtualMachineNetworkInterfaceSpec)(nil), (*VirtualMachineNetworkInterfaceSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1alpha4_VirtualMachineNetworkInterfaceSpec_To_v1alpha2_VirtualMachineNetworkInterfaceSpec(a.(*v1alpha4.VirtualMachineNetworkInterfaceSpec), b.(*VirtualMachineNetworkInterfaceSpec), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*VirtualMachineNetworkInterfaceStatus)(nil), (*v1alpha4.VirtualMachineNetworkInterfaceStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_VirtualMachineNetworkInterfaceStatus_To_v1alpha4_VirtualMachineNetworkInterfaceStatus(a.(*VirtualMachineNetworkInterfaceStatus), b.(*v1alpha4.VirtualMachineNetworkInterfaceStatus), scope)
 	}); err != nil {
@@ -680,11 +662,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1alpha4.VirtualMachinePublishRequestTargetLocation)(nil), (*VirtualMachinePublishRequestTargetLocation)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1alpha4_VirtualMachinePublishRequestTargetLocation_To_v1alpha2_VirtualMachinePublishRequestTargetLocation(a.(*v1alpha4.VirtualMachinePublishRequestTargetLocation), b.(*VirtualMachinePublishRequestTargetLocation), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*VirtualMachineReadinessProbeSpec)(nil), (*v1alpha4.VirtualMachineReadinessProbeSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_VirtualMachineReadinessProbeSpec_To_v1alpha4_VirtualMachineReadinessProbeSpec(a.(*VirtualMachineReadinessProbeSpec), b.(*v1alpha4.VirtualMachineReadinessProbeSpec), scope)
 	}); err != nil {
@@ -880,6 +857,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*common.LocalObjectRef)(nil), (*v1alpha4.VirtualMachineImageRefStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_common_LocalObjectRef_To_v1alpha4_VirtualMachineImageRefStatus(a.(*common.LocalObjectRef), b.(*v1alpha4.VirtualMachineImageRefStatus), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*VirtualMachineStatus)(nil), (*v1alpha4.VirtualMachineStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha2_VirtualMachineStatus_To_v1alpha4_VirtualMachineStatus(a.(*VirtualMachineStatus), b.(*v1alpha4.VirtualMachineStatus), scope)
 	}); err != nil {
@@ -900,6 +882,21 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1alpha4.VirtualMachineBootstrapSpec)(nil), (*VirtualMachineBootstrapSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha4_VirtualMachineBootstrapSpec_To_v1alpha2_VirtualMachineBootstrapSpec(a.(*v1alpha4.VirtualMachineBootstrapSpec), b.(*VirtualMachineBootstrapSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1alpha4.VirtualMachineImageRefStatus)(nil), (*common.LocalObjectRef)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha4_VirtualMachineImageRefStatus_To_common_LocalObjectRef(a.(*v1alpha4.VirtualMachineImageRefStatus), b.(*common.LocalObjectRef), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1alpha4.VirtualMachineImageSpec)(nil), (*VirtualMachineImageSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha4_VirtualMachineImageSpec_To_v1alpha2_VirtualMachineImageSpec(a.(*v1alpha4.VirtualMachineImageSpec), b.(*VirtualMachineImageSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1alpha4.VirtualMachineImageStatus)(nil), (*VirtualMachineImageStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha4_VirtualMachineImageStatus_To_v1alpha2_VirtualMachineImageStatus(a.(*v1alpha4.VirtualMachineImageStatus), b.(*VirtualMachineImageStatus), scope)
 	}); err != nil {
@@ -910,11 +907,26 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1alpha4.VirtualMachineNetworkConfigInterfaceStatus)(nil), (*VirtualMachineNetworkConfigInterfaceStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha4_VirtualMachineNetworkConfigInterfaceStatus_To_v1alpha2_VirtualMachineNetworkConfigInterfaceStatus(a.(*v1alpha4.VirtualMachineNetworkConfigInterfaceStatus), b.(*VirtualMachineNetworkConfigInterfaceStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1alpha4.VirtualMachineNetworkInterfaceSpec)(nil), (*VirtualMachineNetworkInterfaceSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha4_VirtualMachineNetworkInterfaceSpec_To_v1alpha2_VirtualMachineNetworkInterfaceSpec(a.(*v1alpha4.VirtualMachineNetworkInterfaceSpec), b.(*VirtualMachineNetworkInterfaceSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1alpha4.VirtualMachineNetworkSpec)(nil), (*VirtualMachineNetworkSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha4_VirtualMachineNetworkSpec_To_v1alpha2_VirtualMachineNetworkSpec(a.(*v1alpha4.VirtualMachineNetworkSpec), b.(*VirtualMachineNetworkSpec), scope)
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1alpha4.VirtualMachinePublishRequestTargetLocation)(nil), (*VirtualMachinePublishRequestTargetLocation)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha4_VirtualMachinePublishRequestTargetLocation_To_v1alpha2_VirtualMachinePublishRequestTargetLocation(a.(*v1alpha4.VirtualMachinePublishRequestTargetLocation), b.(*VirtualMachinePublishRequestTargetLocation), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1alpha4.VirtualMachineSpec)(nil), (*VirtualMachineSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha4_VirtualMachineSpec_To_v1alpha2_VirtualMachineSpec(a.(*v1alpha4.VirtualMachineSpec), b.(*VirtualMachineSpec), scope)
 	}); err != nil {
@@ -1443,8 +1455,16 @@ func Convert_v1alpha4_VirtualMachineAdvancedSpec_To_v1alpha2_VirtualMachineAdvan
 }
 
 func autoConvert_v1alpha2_VirtualMachineBootstrapCloudInitSpec_To_v1alpha4_VirtualMachineBootstrapCloudInitSpec(in *VirtualMachineBootstrapCloudInitSpec, out *v1alpha4.VirtualMachineBootstrapCloudInitSpec, s conversion.Scope) error {
-	out.CloudConfig = (*cloudinit.CloudConfig)(unsafe.Pointer(in.CloudConfig))
-	out.RawCloudConfig = (*common.SecretKeySelector)(unsafe.Pointer(in.RawCloudConfig))
+	if in.CloudConfig != nil {
+		in, out := &in.CloudConfig, &out.CloudConfig
+		*out = new(cloudinit.CloudConfig)
+		if err := conversionv1alpha2.Convert_cloudinit_CloudConfig_To_cloudinit_CloudConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CloudConfig = nil
+	}
+	out.RawCloudConfig = (*v1alpha4common.SecretKeySelector)(unsafe.Pointer(in.RawCloudConfig))
 	out.SSHAuthorizedKeys = *(*[]string)(unsafe.Pointer(&in.SSHAuthorizedKeys))
 	out.UseGlobalNameserversAsDefault = (*bool)(unsafe.Pointer(in.UseGlobalNameserversAsDefault))
 	out.UseGlobalSearchDomainsAsDefault = (*bool)(unsafe.Pointer(in.UseGlobalSearchDomainsAsDefault))
@@ -1458,8 +1478,16 @@ func Convert_v1alpha2_VirtualMachineBootstrapCloudInitSpec_To_v1alpha4_VirtualMa
 
 func autoConvert_v1alpha4_VirtualMachineBootstrapCloudInitSpec_To_v1alpha2_VirtualMachineBootstrapCloudInitSpec(in *v1alpha4.VirtualMachineBootstrapCloudInitSpec, out *VirtualMachineBootstrapCloudInitSpec, s conversion.Scope) error {
 	// WARNING: in.InstanceID requires manual conversion: does not exist in peer-type
-	out.CloudConfig = (*v1alpha2cloudinit.CloudConfig)(unsafe.Pointer(in.CloudConfig))
-	out.RawCloudConfig = (*v1alpha2common.SecretKeySelector)(unsafe.Pointer(in.RawCloudConfig))
+	if in.CloudConfig != nil {
+		in, out := &in.CloudConfig, &out.CloudConfig
+		*out = new(v1alpha2cloudinit.CloudConfig)
+		if err := conversionv1alpha4.Convert_cloudinit_CloudConfig_To_cloudinit_CloudConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CloudConfig = nil
+	}
+	out.RawCloudConfig = (*common.SecretKeySelector)(unsafe.Pointer(in.RawCloudConfig))
 	out.SSHAuthorizedKeys = *(*[]string)(unsafe.Pointer(&in.SSHAuthorizedKeys))
 	out.UseGlobalNameserversAsDefault = (*bool)(unsafe.Pointer(in.UseGlobalNameserversAsDefault))
 	out.UseGlobalSearchDomainsAsDefault = (*bool)(unsafe.Pointer(in.UseGlobalSearchDomainsAsDefault))
@@ -1540,25 +1568,22 @@ func autoConvert_v1alpha4_VirtualMachineBootstrapSpec_To_v1alpha2_VirtualMachine
 		out.Sysprep = nil
 	}
 	out.VAppConfig = (*VirtualMachineBootstrapVAppConfigSpec)(unsafe.Pointer(in.VAppConfig))
+	// WARNING: in.Ignition requires manual conversion: does not exist in peer-type
+	// WARNING: in.CustomizationGeneration requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1alpha4_VirtualMachineBootstrapSpec_To_v1alpha2_VirtualMachineBootstrapSpec is an autogenerated conversion function.
-func Convert_v1alpha4_VirtualMachineBootstrapSpec_To_v1alpha2_VirtualMachineBootstrapSpec(in *v1alpha4.VirtualMachineBootstrapSpec, out *VirtualMachineBootstrapSpec, s conversion.Scope) error {
-	return autoConvert_v1alpha4_VirtualMachineBootstrapSpec_To_v1alpha2_VirtualMachineBootstrapSpec(in, out, s)
-}
-
 func autoConvert_v1alpha2_VirtualMachineBootstrapSysprepSpec_To_v1alpha4_VirtualMachineBootstrapSysprepSpec(in *VirtualMachineBootstrapSysprepSpec, out *v1alpha4.VirtualMachineBootstrapSysprepSpec, s conversion.Scope) error {
 	if in.Sysprep != nil {
 		in, out := &in.Sysprep, &out.Sysprep
 		*out = new(sysprep.Sysprep)
-		if err := conversionv1alpha2.Convert_sysprep_Sysprep_To_sysprep_Sysprep(*in, *out, s); err != nil {
+		if err := sysprepconversionv1alpha2.Convert_sysprep_Sysprep_To_sysprep_Sysprep(*in, *out, s); err != nil {
 			return err
 		}
 	} else {
 		out.Sysprep = nil
 	}
-	out.RawSysprep = (*common.SecretKeySelector)(unsafe.Pointer(in.RawSysprep))
+	out.RawSysprep = (*v1alpha4common.SecretKeySelector)(unsafe.Pointer(in.RawSysprep))
 	return nil
 }
 
@@ -1571,13 +1596,13 @@ func autoConvert_v1alpha4_VirtualMachineBootstrapSysprepSpec_To_v1alpha2_Virtual
 	if in.Sysprep != nil {
 		in, out := &in.Sysprep, &out.Sysprep
 		*out = new(v1alpha2sysprep.Sysprep)
-		if err := conversionv1alpha4.Convert_sysprep_Sysprep_To_sysprep_Sysprep(*in, *out, s); err != nil {
+		if err := sysprepconversionv1alpha4.Convert_sysprep_Sysprep_To_sysprep_Sysprep(*in, *out, s); err != nil {
 			return err
 		}
 	} else {
 		out.Sysprep = nil
 	}
-	out.RawSysprep = (*v1alpha2common.SecretKeySelector)(unsafe.Pointer(in.RawSysprep))
+	out.RawSysprep = (*common.SecretKeySelector)(unsafe.Pointer(in.RawSysprep))
 	return nil
 }
 
@@ -1587,7 +1612,7 @@ func Convert_v1alpha4_VirtualMachineBootstrapSysprepSpec_To_v1alpha2_VirtualMach
 }
 
 func autoConvert_v1alpha2_VirtualMachineBootstrapVAppConfigSpec_To_v1alpha4_VirtualMachineBootstrapVAppConfigSpec(in *VirtualMachineBootstrapVAppConfigSpec, out *v1alpha4.VirtualMachineBootstrapVAppConfigSpec, s conversion.Scope) error {
-	out.Properties = *(*[]common.KeyValueOrSecretKeySelectorPair)(unsafe.Pointer(&in.Properties))
+	out.Properties = *(*[]v1alpha4common.KeyValueOrSecretKeySelectorPair)(unsafe.Pointer(&in.Properties))
 	out.RawProperties = in.RawProperties
 	return nil
 }
@@ -1598,7 +1623,7 @@ func Convert_v1alpha2_VirtualMachineBootstrapVAppConfigSpec_To_v1alpha4_VirtualM
 }
 
 func autoConvert_v1alpha4_VirtualMachineBootstrapVAppConfigSpec_To_v1alpha2_VirtualMachineBootstrapVAppConfigSpec(in *v1alpha4.VirtualMachineBootstrapVAppConfigSpec, out *VirtualMachineBootstrapVAppConfigSpec, s conversion.Scope) error {
-	out.Properties = *(*[]v1alpha2common.KeyValueOrSecretKeySelectorPair)(unsafe.Pointer(&in.Properties))
+	out.Properties = *(*[]common.KeyValueOrSecretKeySelectorPair)(unsafe.Pointer(&in.Properties))
 	out.RawProperties = in.RawProperties
 	return nil
 }
@@ -1933,7 +1958,7 @@ func Convert_v1alpha4_VirtualMachineImageProductInfo_To_v1alpha2_VirtualMachineI
 }
 
 func autoConvert_v1alpha2_VirtualMachineImageSpec_To_v1alpha4_VirtualMachineImageSpec(in *VirtualMachineImageSpec, out *v1alpha4.VirtualMachineImageSpec, s conversion.Scope) error {
-	out.ProviderRef = (*common.LocalObjectRef)(unsafe.Pointer(in.ProviderRef))
+	out.ProviderRef = (*v1alpha4common.LocalObjectRef)(unsafe.Pointer(in.ProviderRef))
 	return nil
 }
 
@@ -1943,15 +1968,11 @@ func Convert_v1alpha2_VirtualMachineImageSpec_To_v1alpha4_VirtualMachineImageSpe
 }
 
 func autoConvert_v1alpha4_VirtualMachineImageSpec_To_v1alpha2_VirtualMachineImageSpec(in *v1alpha4.VirtualMachineImageSpec, out *VirtualMachineImageSpec, s conversion.Scope) error {
-	out.ProviderRef = (*v1alpha2common.LocalObjectRef)(unsafe.Pointer(in.ProviderRef))
+	out.ProviderRef = (*common.LocalObjectRef)(unsafe.Pointer(in.ProviderRef))
+	// WARNING: in.OCI requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1alpha4_VirtualMachineImageSpec_To_v1alpha2_VirtualMachineImageSpec is an autogenerated conversion function.
-func Convert_v1alpha4_VirtualMachineImageSpec_To_v1alpha2_VirtualMachineImageSpec(in *v1alpha4.VirtualMachineImageSpec, out *VirtualMachineImageSpec, s conversion.Scope) error {
-	return autoConvert_v1alpha4_VirtualMachineImageSpec_To_v1alpha2_VirtualMachineImageSpec(in, out, s)
-}
-
 func autoConvert_v1alpha2_VirtualMachineImageStatus_To_v1alpha4_VirtualMachineImageStatus(in *VirtualMachineImageStatus, out *v1alpha4.VirtualMachineImageStatus, s conversion.Scope) error {
 	out.Name = in.Name
 	out.Capabilities = *(*[]string)(unsafe.Pointer(&in.Capabilities))
@@ -1961,7 +1982,7 @@ func autoConvert_v1alpha2_VirtualMachineImageStatus_To_v1alpha4_VirtualMachineIm
 		return err
 	}
 	out.OVFProperties = *(*[]v1alpha4.OVFProperty)(unsafe.Pointer(&in.OVFProperties))
-	out.VMwareSystemProperties = *(*[]common.KeyValuePair)(unsafe.Pointer(&in.VMwareSystemProperties))
+	out.VMwareSystemProperties = *(*[]v1alpha4common.KeyValuePair)(unsafe.Pointer(&in.VMwareSystemProperties))
 	if err := Convert_v1alpha2_VirtualMachineImageProductInfo_To_v1alpha4_VirtualMachineImageProductInfo(&in.ProductInfo, &out.ProductInfo, s); err != nil {
 		return err
 	}
@@ -1985,13 +2006,15 @@ func autoConvert_v1alpha4_VirtualMachineImageStatus_To_v1alpha2_VirtualMachineIm
 		return err
 	}
 	out.OVFProperties = *(*[]OVFProperty)(unsafe.Pointer(&in.OVFProperties))
-	out.VMwareSystemProperties = *(*[]v1alpha2common.KeyValuePair)(unsafe.Pointer(&in.VMwareSystemProperties))
+	out.VMwareSystemProperties = *(*[]common.KeyValuePair)(unsafe.Pointer(&in.VMwareSystemProperties))
 	if err := Convert_v1alpha4_VirtualMachineImageProductInfo_To_v1alpha2_VirtualMachineImageProductInfo(&in.ProductInfo, &out.ProductInfo, s); err != nil {
 		return err
 	}
 	// WARNING: in.Disks requires manual conversion: does not exist in peer-type
 	out.ProviderContentVersion = in.ProviderContentVersion
 	out.ProviderItemID = in.ProviderItemID
+	// WARNING: in.Fingerprint requires manual conversion: does not exist in peer-type
+	// WARNING: in.FingerprintContentVersion requires manual conversion: does not exist in peer-type
 	out.Conditions = *(*[]v1.Condition)(unsafe.Pointer(&in.Conditions))
 	// WARNING: in.Type requires manual conversion: does not exist in peer-type
 	return nil
@@ -2159,14 +2182,11 @@ func autoConvert_v1alpha4_VirtualMachineNetworkConfigInterfaceStatus_To_v1alpha2
 	} else {
 		out.DNS = nil
 	}
+	// WARNING: in.QoS requires manual conversion: does not exist in peer-type
+	// WARNING: in.TrafficRules requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1alpha4_VirtualMachineNetworkConfigInterfaceStatus_To_v1alpha2_VirtualMachineNetworkConfigInterfaceStatus is an autogenerated conversion function.
-func Convert_v1alpha4_VirtualMachineNetworkConfigInterfaceStatus_To_v1alpha2_VirtualMachineNetworkConfigInterfaceStatus(in *v1alpha4.VirtualMachineNetworkConfigInterfaceStatus, out *VirtualMachineNetworkConfigInterfaceStatus, s conversion.Scope) error {
-	return autoConvert_v1alpha4_VirtualMachineNetworkConfigInterfaceStatus_To_v1alpha2_VirtualMachineNetworkConfigInterfaceStatus(in, out, s)
-}
-
 func autoConvert_v1alpha2_VirtualMachineNetworkConfigStatus_To_v1alpha4_VirtualMachineNetworkConfigStatus(in *VirtualMachineNetworkConfigStatus, out *v1alpha4.VirtualMachineNetworkConfigStatus, s conversion.Scope) error {
 	if in.Interfaces != nil {
 		in, out := &in.Interfaces, &out.Interfaces
@@ -2226,7 +2246,7 @@ func Convert_v1alpha4_VirtualMachineNetworkConfigStatus_To_v1alpha2_VirtualMachi
 }
 
 func autoConvert_v1alpha2_VirtualMachineNetworkDHCPOptionsStatus_To_v1alpha4_VirtualMachineNetworkDHCPOptionsStatus(in *VirtualMachineNetworkDHCPOptionsStatus, out *v1alpha4.VirtualMachineNetworkDHCPOptionsStatus, s conversion.Scope) error {
-	out.Config = *(*[]common.KeyValuePair)(unsafe.Pointer(&in.Config))
+	out.Config = *(*[]v1alpha4common.KeyValuePair)(unsafe.Pointer(&in.Config))
 	out.Enabled = in.Enabled
 	return nil
 }
@@ -2237,7 +2257,7 @@ func Convert_v1alpha2_VirtualMachineNetworkDHCPOptionsStatus_To_v1alpha4_Virtual
 }
 
 func autoConvert_v1alpha4_VirtualMachineNetworkDHCPOptionsStatus_To_v1alpha2_VirtualMachineNetworkDHCPOptionsStatus(in *v1alpha4.VirtualMachineNetworkDHCPOptionsStatus, out *VirtualMachineNetworkDHCPOptionsStatus, s conversion.Scope) error {
-	out.Config = *(*[]v1alpha2common.KeyValuePair)(unsafe.Pointer(&in.Config))
+	out.Config = *(*[]common.KeyValuePair)(unsafe.Pointer(&in.Config))
 	out.Enabled = in.Enabled
 	return nil
 }
@@ -2357,7 +2377,7 @@ func autoConvert_v1alpha2_VirtualMachineNetworkIPStackStatus_To_v1alpha4_Virtual
 	out.DHCP = (*v1alpha4.VirtualMachineNetworkDHCPStatus)(unsafe.Pointer(in.DHCP))
 	out.DNS = (*v1alpha4.VirtualMachineNetworkDNSStatus)(unsafe.Pointer(in.DNS))
 	out.IPRoutes = *(*[]v1alpha4.VirtualMachineNetworkIPRouteStatus)(unsafe.Pointer(&in.IPRoutes))
-	out.KernelConfig = *(*[]common.KeyValuePair)(unsafe.Pointer(&in.KernelConfig))
+	out.KernelConfig = *(*[]v1alpha4common.KeyValuePair)(unsafe.Pointer(&in.KernelConfig))
 	return nil
 }
 
@@ -2370,7 +2390,7 @@ func autoConvert_v1alpha4_VirtualMachineNetworkIPStackStatus_To_v1alpha2_Virtual
 	out.DHCP = (*VirtualMachineNetworkDHCPStatus)(unsafe.Pointer(in.DHCP))
 	out.DNS = (*VirtualMachineNetworkDNSStatus)(unsafe.Pointer(in.DNS))
 	out.IPRoutes = *(*[]VirtualMachineNetworkIPRouteStatus)(unsafe.Pointer(&in.IPRoutes))
-	out.KernelConfig = *(*[]v1alpha2common.KeyValuePair)(unsafe.Pointer(&in.KernelConfig))
+	out.KernelConfig = *(*[]common.KeyValuePair)(unsafe.Pointer(&in.KernelConfig))
 	return nil
 }
 
@@ -2433,7 +2453,7 @@ func Convert_v1alpha4_VirtualMachineNetworkInterfaceIPStatus_To_v1alpha2_Virtual
 
 func autoConvert_v1alpha2_VirtualMachineNetworkInterfaceSpec_To_v1alpha4_VirtualMachineNetworkInterfaceSpec(in *VirtualMachineNetworkInterfaceSpec, out *v1alpha4.VirtualMachineNetworkInterfaceSpec, s conversion.Scope) error {
 	out.Name = in.Name
-	out.Network = (*common.PartialObjectRef)(unsafe.Pointer(in.Network))
+	out.Network = (*v1alpha4common.PartialObjectRef)(unsafe.Pointer(in.Network))
 	out.GuestDeviceName = in.GuestDeviceName
 	out.Addresses = *(*[]string)(unsafe.Pointer(&in.Addresses))
 	out.DHCP4 = in.DHCP4
@@ -2454,9 +2474,14 @@ func Convert_v1alpha2_VirtualMachineNetworkInterfaceSpec_To_v1alpha4_VirtualMach
 
 func autoConvert_v1alpha4_VirtualMachineNetworkInterfaceSpec_To_v1alpha2_VirtualMachineNetworkInterfaceSpec(in *v1alpha4.VirtualMachineNetworkInterfaceSpec, out *VirtualMachineNetworkInterfaceSpec, s conversion.Scope) error {
 	out.Name = in.Name
-	out.Network = (*v1alpha2common.PartialObjectRef)(unsafe.Pointer(in.Network))
+	out.Network = (*common.PartialObjectRef)(unsafe.Pointer(in.Network))
 	out.GuestDeviceName = in.GuestDeviceName
+	// WARNING: in.MacAddress requires manual conversion: does not exist in peer-type
+	// WARNING: in.Type requires manual conversion: does not exist in peer-type
+	// WARNING: in.SRIOV requires manual conversion: does not exist in peer-type
+	// WARNING: in.DVX requires manual conversion: does not exist in peer-type
 	out.Addresses = *(*[]string)(unsafe.Pointer(&in.Addresses))
+	// WARNING: in.AddressesFromPools requires manual conversion: does not exist in peer-type
 	out.DHCP4 = in.DHCP4
 	out.DHCP6 = in.DHCP6
 	out.Gateway4 = in.Gateway4
@@ -2465,14 +2490,11 @@ func autoConvert_v1alpha4_VirtualMachineNetworkInterfaceSpec_To_v1alpha2_Virtual
 	out.Nameservers = *(*[]string)(unsafe.Pointer(&in.Nameservers))
 	out.Routes = *(*[]VirtualMachineNetworkRouteSpec)(unsafe.Pointer(&in.Routes))
 	out.SearchDomains = *(*[]string)(unsafe.Pointer(&in.SearchDomains))
+	// WARNING: in.QoS requires manual conversion: does not exist in peer-type
+	// WARNING: in.TrafficRules requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1alpha4_VirtualMachineNetworkInterfaceSpec_To_v1alpha2_VirtualMachineNetworkInterfaceSpec is an autogenerated conversion function.
-func Convert_v1alpha4_VirtualMachineNetworkInterfaceSpec_To_v1alpha2_VirtualMachineNetworkInterfaceSpec(in *v1alpha4.VirtualMachineNetworkInterfaceSpec, out *VirtualMachineNetworkInterfaceSpec, s conversion.Scope) error {
-	return autoConvert_v1alpha4_VirtualMachineNetworkInterfaceSpec_To_v1alpha2_VirtualMachineNetworkInterfaceSpec(in, out, s)
-}
-
 func autoConvert_v1alpha2_VirtualMachineNetworkInterfaceStatus_To_v1alpha4_VirtualMachineNetworkInterfaceStatus(in *VirtualMachineNetworkInterfaceStatus, out *v1alpha4.VirtualMachineNetworkInterfaceStatus, s conversion.Scope) error {
 	out.Name = in.Name
 	out.DeviceKey = in.DeviceKey
@@ -2548,7 +2570,17 @@ func autoConvert_v1alpha2_VirtualMachineNetworkSpec_To_v1alpha4_VirtualMachineNe
 	out.Disabled = in.Disabled
 	out.Nameservers = *(*[]string)(unsafe.Pointer(&in.Nameservers))
 	out.SearchDomains = *(*[]string)(unsafe.Pointer(&in.SearchDomains))
-	out.Interfaces = *(*[]v1alpha4.VirtualMachineNetworkInterfaceSpec)(unsafe.Pointer(&in.Interfaces))
+	if in.Interfaces != nil {
+		in, out := &in.Interfaces, &out.Interfaces
+		*out = make([]v1alpha4.VirtualMachineNetworkInterfaceSpec, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha2_VirtualMachineNetworkInterfaceSpec_To_v1alpha4_VirtualMachineNetworkInterfaceSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Interfaces = nil
+	}
 	return nil
 }
 
@@ -2563,7 +2595,20 @@ func autoConvert_v1alpha4_VirtualMachineNetworkSpec_To_v1alpha2_VirtualMachineNe
 	out.Disabled = in.Disabled
 	out.Nameservers = *(*[]string)(unsafe.Pointer(&in.Nameservers))
 	out.SearchDomains = *(*[]string)(unsafe.Pointer(&in.SearchDomains))
-	out.Interfaces = *(*[]VirtualMachineNetworkInterfaceSpec)(unsafe.Pointer(&in.Interfaces))
+	if in.Interfaces != nil {
+		in, out := &in.Interfaces, &out.Interfaces
+		*out = make([]VirtualMachineNetworkInterfaceSpec, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha4_VirtualMachineNetworkInterfaceSpec_To_v1alpha2_VirtualMachineNetworkInterfaceSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Interfaces = nil
+	}
+	// WARNING: in.Bonds requires manual conversion: does not exist in peer-type
+	// WARNING: in.VLANs requires manual conversion: does not exist in peer-type
+	// WARNING: in.Bridges requires manual conversion: does not exist in peer-type
 	return nil
 }
 
@@ -2647,7 +2692,17 @@ func Convert_v1alpha4_VirtualMachinePublishRequest_To_v1alpha2_VirtualMachinePub
 
 func autoConvert_v1alpha2_VirtualMachinePublishRequestList_To_v1alpha4_VirtualMachinePublishRequestList(in *VirtualMachinePublishRequestList, out *v1alpha4.VirtualMachinePublishRequestList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
-	out.Items = *(*[]v1alpha4.VirtualMachinePublishRequest)(unsafe.Pointer(&in.Items))
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]v1alpha4.VirtualMachinePublishRequest, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha2_VirtualMachinePublishRequest_To_v1alpha4_VirtualMachinePublishRequest(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
 	return nil
 }
 
@@ -2658,7 +2713,17 @@ func Convert_v1alpha2_VirtualMachinePublishRequestList_To_v1alpha4_VirtualMachin
 
 func autoConvert_v1alpha4_VirtualMachinePublishRequestList_To_v1alpha2_VirtualMachinePublishRequestList(in *v1alpha4.VirtualMachinePublishRequestList, out *VirtualMachinePublishRequestList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
-	out.Items = *(*[]VirtualMachinePublishRequest)(unsafe.Pointer(&in.Items))
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VirtualMachinePublishRequest, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha4_VirtualMachinePublishRequest_To_v1alpha2_VirtualMachinePublishRequest(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
 	return nil
 }
 
@@ -2725,7 +2790,15 @@ func Convert_v1alpha4_VirtualMachinePublishRequestSpec_To_v1alpha2_VirtualMachin
 
 func autoConvert_v1alpha2_VirtualMachinePublishRequestStatus_To_v1alpha4_VirtualMachinePublishRequestStatus(in *VirtualMachinePublishRequestStatus, out *v1alpha4.VirtualMachinePublishRequestStatus, s conversion.Scope) error {
 	out.SourceRef = (*v1alpha4.VirtualMachinePublishRequestSource)(unsafe.Pointer(in.SourceRef))
-	out.TargetRef = (*v1alpha4.VirtualMachinePublishRequestTarget)(unsafe.Pointer(in.TargetRef))
+	if in.TargetRef != nil {
+		in, out := &in.TargetRef, &out.TargetRef
+		*out = new(v1alpha4.VirtualMachinePublishRequestTarget)
+		if err := Convert_v1alpha2_VirtualMachinePublishRequestTarget_To_v1alpha4_VirtualMachinePublishRequestTarget(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.TargetRef = nil
+	}
 	out.CompletionTime = in.CompletionTime
 	out.StartTime = in.StartTime
 	out.Attempts = in.Attempts
@@ -2743,7 +2816,15 @@ func Convert_v1alpha2_VirtualMachinePublishRequestStatus_To_v1alpha4_VirtualMach
 
 func autoConvert_v1alpha4_VirtualMachinePublishRequestStatus_To_v1alpha2_VirtualMachinePublishRequestStatus(in *v1alpha4.VirtualMachinePublishRequestStatus, out *VirtualMachinePublishRequestStatus, s conversion.Scope) error {
 	out.SourceRef = (*VirtualMachinePublishRequestSource)(unsafe.Pointer(in.SourceRef))
-	out.TargetRef = (*VirtualMachinePublishRequestTarget)(unsafe.Pointer(in.TargetRef))
+	if in.TargetRef != nil {
+		in, out := &in.TargetRef, &out.TargetRef
+		*out = new(VirtualMachinePublishRequestTarget)
+		if err := Convert_v1alpha4_VirtualMachinePublishRequestTarget_To_v1alpha2_VirtualMachinePublishRequestTarget(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.TargetRef = nil
+	}
 	out.CompletionTime = in.CompletionTime
 	out.StartTime = in.StartTime
 	out.Attempts = in.Attempts
@@ -2827,14 +2908,10 @@ func autoConvert_v1alpha4_VirtualMachinePublishRequestTargetLocation_To_v1alpha2
 	out.Name = in.Name
 	out.APIVersion = in.APIVersion
 	out.Kind = in.Kind
+	// WARNING: in.OCI requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1alpha4_VirtualMachinePublishRequestTargetLocation_To_v1alpha2_VirtualMachinePublishRequestTargetLocation is an autogenerated conversion function.
-func Convert_v1alpha4_VirtualMachinePublishRequestTargetLocation_To_v1alpha2_VirtualMachinePublishRequestTargetLocation(in *v1alpha4.VirtualMachinePublishRequestTargetLocation, out *VirtualMachinePublishRequestTargetLocation, s conversion.Scope) error {
-	return autoConvert_v1alpha4_VirtualMachinePublishRequestTargetLocation_To_v1alpha2_VirtualMachinePublishRequestTargetLocation(in, out, s)
-}
-
 func autoConvert_v1alpha2_VirtualMachineReadinessProbeSpec_To_v1alpha4_VirtualMachineReadinessProbeSpec(in *VirtualMachineReadinessProbeSpec, out *v1alpha4.VirtualMachineReadinessProbeSpec, s conversion.Scope) error {
 	out.TCPSocket = (*v1alpha4.TCPSocketAction)(unsafe.Pointer(in.TCPSocket))
 	out.GuestHeartbeat = (*v1alpha4.GuestHeartbeatAction)(unsafe.Pointer(in.GuestHeartbeat))
@@ -3187,6 +3264,7 @@ func autoConvert_v1alpha4_VirtualMachineSpec_To_v1alpha2_VirtualMachineSpec(in *
 	// WARNING: in.Cdrom requires manual conversion: does not exist in peer-type
 	// WARNING: in.Image requires manual conversion: does not exist in peer-type
 	out.ImageName = in.ImageName
+	// WARNING: in.ImageChannel requires manual conversion: does not exist in peer-type
 	out.ClassName = in.ClassName
 	// WARNING: in.Class requires manual conversion: does not exist in peer-type
 	// WARNING: in.Affinity requires manual conversion: does not exist in peer-type
@@ -3231,8 +3309,16 @@ func autoConvert_v1alpha4_VirtualMachineSpec_To_v1alpha2_VirtualMachineSpec(in *
 }
 
 func autoConvert_v1alpha2_VirtualMachineStatus_To_v1alpha4_VirtualMachineStatus(in *VirtualMachineStatus, out *v1alpha4.VirtualMachineStatus, s conversion.Scope) error {
-	// WARNING: in.Image requires manual conversion: does not exist in peer-type
-	out.Class = (*common.LocalObjectRef)(unsafe.Pointer(in.Class))
+	if in.Image != nil {
+		in, out := &in.Image, &out.Image
+		*out = new(v1alpha4.VirtualMachineImageRefStatus)
+		if err := Convert_common_LocalObjectRef_To_v1alpha4_VirtualMachineImageRefStatus(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Image = nil
+	}
+	out.Class = (*v1alpha4common.LocalObjectRef)(unsafe.Pointer(in.Class))
 	// WARNING: in.Host requires manual conversion: does not exist in peer-type
 	out.PowerState = v1alpha4.VirtualMachinePowerState(in.PowerState)
 	out.Conditions = *(*[]v1.Condition)(unsafe.Pointer(&in.Conditions))
@@ -3267,7 +3353,16 @@ func autoConvert_v1alpha2_VirtualMachineStatus_To_v1alpha4_VirtualMachineStatus(
 }
 
 func autoConvert_v1alpha4_VirtualMachineStatus_To_v1alpha2_VirtualMachineStatus(in *v1alpha4.VirtualMachineStatus, out *VirtualMachineStatus, s conversion.Scope) error {
-	out.Class = (*v1alpha2common.LocalObjectRef)(unsafe.Pointer(in.Class))
+	out.Class = (*common.LocalObjectRef)(unsafe.Pointer(in.Class))
+	if in.Image != nil {
+		in, out := &in.Image, &out.Image
+		*out = new(common.LocalObjectRef)
+		if err := Convert_v1alpha4_VirtualMachineImageRefStatus_To_common_LocalObjectRef(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Image = nil
+	}
 	// WARNING: in.NodeName requires manual conversion: does not exist in peer-type
 	out.PowerState = VirtualMachinePowerState(in.PowerState)
 	out.Conditions = *(*[]v1.Condition)(unsafe.Pointer(&in.Conditions))
@@ -3298,6 +3393,7 @@ func autoConvert_v1alpha4_VirtualMachineStatus_To_v1alpha2_VirtualMachineStatus(
 	out.ChangeBlockTracking = (*bool)(unsafe.Pointer(in.ChangeBlockTracking))
 	out.Zone = in.Zone
 	out.LastRestartTime = (*v1.Time)(unsafe.Pointer(in.LastRestartTime))
+	// WARNING: in.ObservedCustomizationGeneration requires manual conversion: does not exist in peer-type
 	out.HardwareVersion = in.HardwareVersion
 	// WARNING: in.Storage requires manual conversion: does not exist in peer-type
 	// WARNING: in.TaskID requires manual conversion: does not exist in peer-type
@@ -278,8 +278,17 @@ func overrideVirtualMachineImageFieldsFuncs(codecs runtimeserializer.CodecFactor
 			c.Fuzz(vmiStatus)
 
 			// Since only VMOP updates the CVMI/VMI's we didn't bother with conversion
-			// when adding this field.
+			// when adding these fields.
 			vmiStatus.Disks = nil
+			vmiStatus.Fingerprint = ""
+			vmiStatus.FingerprintContentVersion = ""
+		},
+		func(vmiSpec *vmopv1.VirtualMachineImageSpec, c fuzz.Continue) {
+			c.Fuzz(vmiSpec)
+
+			// Since only VMOP creates the CVMI/VMI's from OCI artifacts we
+			// didn't bother with conversion when adding this field.
+			vmiSpec.OCI = nil
 		},
 	}
 }
@@ -293,6 +302,9 @@ func overrideVirtualMachineImageCacheFieldsFuncs(codecs runtimeserializer.CodecF
 				for j := range status.Locations[i].Files {
 					status.Locations[i].Files[j].Type = ""
 				}
+				// Since only VMOP updates the VMI cache objects we didn't
+				// bother with conversion when adding this field.
+				status.Locations[i].LastUsedTime = nil
 			}
 		},
 	}
@@ -83,6 +83,36 @@ func Convert_v1alpha4_VirtualMachineBootstrapCloudInitSpec_To_v1alpha3_VirtualMa
 	return autoConvert_v1alpha4_VirtualMachineBootstrapCloudInitSpec_To_v1alpha3_VirtualMachineBootstrapCloudInitSpec(in, out, s)
 }
 
+func Convert_v1alpha4_VirtualMachineNetworkInterfaceSpec_To_v1alpha3_VirtualMachineNetworkInterfaceSpec(
+	in *vmopv1.VirtualMachineNetworkInterfaceSpec, out *VirtualMachineNetworkInterfaceSpec, s apiconversion.Scope) error {
+
+	return autoConvert_v1alpha4_VirtualMachineNetworkInterfaceSpec_To_v1alpha3_VirtualMachineNetworkInterfaceSpec(in, out, s)
+}
+
+func Convert_v1alpha4_VirtualMachineNetworkSpec_To_v1alpha3_VirtualMachineNetworkSpec(
+	in *vmopv1.VirtualMachineNetworkSpec, out *VirtualMachineNetworkSpec, s apiconversion.Scope) error {
+
+	return autoConvert_v1alpha4_VirtualMachineNetworkSpec_To_v1alpha3_VirtualMachineNetworkSpec(in, out, s)
+}
+
+func Convert_v1alpha4_VirtualMachineNetworkConfigInterfaceStatus_To_v1alpha3_VirtualMachineNetworkConfigInterfaceStatus(
+	in *vmopv1.VirtualMachineNetworkConfigInterfaceStatus, out *VirtualMachineNetworkConfigInterfaceStatus, s apiconversion.Scope) error {
+
+	return autoConvert_v1alpha4_VirtualMachineNetworkConfigInterfaceStatus_To_v1alpha3_VirtualMachineNetworkConfigInterfaceStatus(in, out, s)
+}
+
+func Convert_v1alpha4_VirtualMachineCdromSpec_To_v1alpha3_VirtualMachineCdromSpec(
+	in *vmopv1.VirtualMachineCdromSpec, out *VirtualMachineCdromSpec, s apiconversion.Scope) error {
+
+	return autoConvert_v1alpha4_VirtualMachineCdromSpec_To_v1alpha3_VirtualMachineCdromSpec(in, out, s)
+}
+
+func Convert_v1alpha4_VirtualMachineBootstrapSpec_To_v1alpha3_VirtualMachineBootstrapSpec(
+	in *vmopv1.VirtualMachineBootstrapSpec, out *VirtualMachineBootstrapSpec, s apiconversion.Scope) error {
+
+	return autoConvert_v1alpha4_VirtualMachineBootstrapSpec_To_v1alpha3_VirtualMachineBootstrapSpec(in, out, s)
+}
+
 func Convert_v1alpha4_VirtualMachineSpec_To_v1alpha3_VirtualMachineSpec(
 	in *vmopv1.VirtualMachineSpec, out *VirtualMachineSpec, s apiconversion.Scope) error {
 
@@ -117,6 +147,10 @@ func restore_v1alpha4_VirtualMachineGroupName(dst, src *vmopv1.VirtualMachine) {
 	dst.Spec.GroupName = src.Spec.GroupName
 }
 
+func restore_v1alpha4_VirtualMachineImageChannel(dst, src *vmopv1.VirtualMachine) {
+	dst.Spec.ImageChannel = src.Spec.ImageChannel
+}
+
 func restore_v1alpha4_VirtualMachinePromoteDisksMode(dst, src *vmopv1.VirtualMachine) {
 	dst.Spec.PromoteDisksMode = src.Spec.PromoteDisksMode
 }
@@ -129,6 +163,120 @@ func restore_v1alpha4_VirtualMachineAffinitySpec(dst, src *vmopv1.VirtualMachine
 	dst.Spec.Affinity = src.Spec.Affinity
 }
 
+func restore_v1alpha4_VirtualMachineNetworkInterfaceAddressesFromPools(dst, src *vmopv1.VirtualMachine) {
+	if src.Spec.Network == nil || dst.Spec.Network == nil {
+		return
+	}
+
+	for i := range dst.Spec.Network.Interfaces {
+		dstIface := &dst.Spec.Network.Interfaces[i]
+		for j := range src.Spec.Network.Interfaces {
+			if srcIface := &src.Spec.Network.Interfaces[j]; srcIface.Name == dstIface.Name {
+				dstIface.AddressesFromPools = srcIface.AddressesFromPools
+				break
+			}
+		}
+	}
+}
+
+func restore_v1alpha4_VirtualMachineNetworkInterfaceType(dst, src *vmopv1.VirtualMachine) {
+	if src.Spec.Network == nil || dst.Spec.Network == nil {
+		return
+	}
+
+	for i := range dst.Spec.Network.Interfaces {
+		dstIface := &dst.Spec.Network.Interfaces[i]
+		for j := range src.Spec.Network.Interfaces {
+			if srcIface := &src.Spec.Network.Interfaces[j]; srcIface.Name == dstIface.Name {
+				dstIface.Type = srcIface.Type
+				dstIface.SRIOV = srcIface.SRIOV
+				dstIface.DVX = srcIface.DVX
+				break
+			}
+		}
+	}
+}
+
+func restore_v1alpha4_VirtualMachineNetworkInterfaceQoS(dst, src *vmopv1.VirtualMachine) {
+	if src.Spec.Network == nil || dst.Spec.Network == nil {
+		return
+	}
+
+	for i := range dst.Spec.Network.Interfaces {
+		dstIface := &dst.Spec.Network.Interfaces[i]
+		for j := range src.Spec.Network.Interfaces {
+			if srcIface := &src.Spec.Network.Interfaces[j]; srcIface.Name == dstIface.Name {
+				dstIface.QoS = srcIface.QoS
+				dstIface.TrafficRules = srcIface.TrafficRules
+				break
+			}
+		}
+	}
+}
+
+func restore_v1alpha4_VirtualMachineNetworkInterfaceMacAddress(dst, src *vmopv1.VirtualMachine) {
+	if src.Spec.Network == nil || dst.Spec.Network == nil {
+		return
+	}
+
+	for i := range dst.Spec.Network.Interfaces {
+		dstIface := &dst.Spec.Network.Interfaces[i]
+		for j := range src.Spec.Network.Interfaces {
+			if srcIface := &src.Spec.Network.Interfaces[j]; srcIface.Name == dstIface.Name {
+				dstIface.MacAddress = srcIface.MacAddress
+				break
+			}
+		}
+	}
+}
+
+func restore_v1alpha4_VirtualMachineCdromDisconnectAfterBootstrap(dst, src *vmopv1.VirtualMachine) {
+	for i := range dst.Spec.Cdrom {
+		dstCdrom := &dst.Spec.Cdrom[i]
+		for j := range src.Spec.Cdrom {
+			if srcCdrom := &src.Spec.Cdrom[j]; srcCdrom.Name == dstCdrom.Name {
+				dstCdrom.DisconnectAfterBootstrap = srcCdrom.DisconnectAfterBootstrap
+				break
+			}
+		}
+	}
+}
+
+func restore_v1alpha4_VirtualMachineBootstrapIgnition(dst, src *vmopv1.VirtualMachine) {
+	if src.Spec.Bootstrap == nil || src.Spec.Bootstrap.Ignition == nil {
+		return
+	}
+	if dst.Spec.Bootstrap == nil {
+		dst.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{}
+	}
+	dst.Spec.Bootstrap.Ignition = src.Spec.Bootstrap.Ignition
+}
+
+func restore_v1alpha4_VirtualMachineBootstrapCustomizationGeneration(dst, src *vmopv1.VirtualMachine) {
+	if src.Spec.Bootstrap == nil || src.Spec.Bootstrap.CustomizationGeneration == 0 {
+		return
+	}
+	if dst.Spec.Bootstrap == nil {
+		dst.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{}
+	}
+	dst.Spec.Bootstrap.CustomizationGeneration = src.Spec.Bootstrap.CustomizationGeneration
+}
+
+func restore_v1alpha4_VirtualMachineNetworkGuestDevices(dst, src *vmopv1.VirtualMachine) {
+	srcNet := src.Spec.Network
+	if srcNet == nil ||
+		(len(srcNet.Bonds) == 0 && len(srcNet.VLANs) == 0 && len(srcNet.Bridges) == 0) {
+
+		return
+	}
+	if dst.Spec.Network == nil {
+		dst.Spec.Network = &vmopv1.VirtualMachineNetworkSpec{}
+	}
+	dst.Spec.Network.Bonds = srcNet.Bonds
+	dst.Spec.Network.VLANs = srcNet.VLANs
+	dst.Spec.Network.Bridges = srcNet.Bridges
+}
+
 // ConvertTo converts this VirtualMachine to the Hub version.
 func (src *VirtualMachine) ConvertTo(dstRaw ctrlconversion.Hub) error {
 	dst := dstRaw.(*vmopv1.VirtualMachine)
@@ -149,6 +297,16 @@ func (src *VirtualMachine) ConvertTo(dstRaw ctrlconversion.Hub) error {
 	restore_v1alpha4_VirtualMachineBootOptions(dst, restored)
 	restore_v1alpha4_VirtualMachineAffinitySpec(dst, restored)
 	restore_v1alpha4_VirtualMachineGroupName(dst, restored)
+	restore_v1alpha4_VirtualMachineNetworkInterfaceAddressesFromPools(dst, restored)
+	restore_v1alpha4_VirtualMachineNetworkInterfaceType(dst, restored)
+	restore_v1alpha4_VirtualMachineNetworkInterfaceQoS(dst, restored)
+	restore_v1alpha4_VirtualMachineNetworkInterfaceMacAddress(dst, restored)
+	restore_v1alpha4_VirtualMachineCdromDisconnectAfterBootstrap(dst, restored)
+	restore_v1alpha4_VirtualMachineBootstrapIgnition(dst, restored)
+	restore_v1alpha4_VirtualMachineBootstrapCloudInitCloudConfig(dst, restored)
+	restore_v1alpha4_VirtualMachineBootstrapCustomizationGeneration(dst, restored)
+	restore_v1alpha4_VirtualMachineNetworkGuestDevices(dst, restored)
+	restore_v1alpha4_VirtualMachineImageChannel(dst, restored)
 
 	// END RESTORE
 
@@ -180,6 +338,32 @@ func (dst *VirtualMachineList) ConvertFrom(srcRaw ctrlconversion.Hub) error {
 	return Convert_v1alpha4_VirtualMachineList_To_v1alpha3_VirtualMachineList(src, dst, nil)
 }
 
+func restore_v1alpha4_VirtualMachineBootstrapCloudInitCloudConfig(dst, src *vmopv1.VirtualMachine) {
+	if bs := src.Spec.Bootstrap; bs != nil {
+		if ci := bs.CloudInit; ci != nil && ci.CloudConfig != nil {
+			// Only restore these values if dst still has a CloudConfig.
+			if dst.Spec.Bootstrap != nil && dst.Spec.Bootstrap.CloudInit != nil &&
+				dst.Spec.Bootstrap.CloudInit.CloudConfig != nil {
+
+				dstCC, srcCC := dst.Spec.Bootstrap.CloudInit.CloudConfig, ci.CloudConfig
+				dstCC.BootCmd = srcCC.BootCmd
+				dstCC.Packages = srcCC.Packages
+				dstCC.PackageUpdate = srcCC.PackageUpdate
+				dstCC.PackageUpgrade = srcCC.PackageUpgrade
+				dstCC.PackageRebootIfRequired = srcCC.PackageRebootIfRequired
+				dstCC.Apt = srcCC.Apt
+				dstCC.YumRepos = srcCC.YumRepos
+				dstCC.NTP = srcCC.NTP
+				dstCC.CACerts = srcCC.CACerts
+				dstCC.DiskSetup = srcCC.DiskSetup
+				dstCC.FSSetup = srcCC.FSSetup
+				dstCC.Mounts = srcCC.Mounts
+				dstCC.PowerState = srcCC.PowerState
+			}
+		}
+	}
+}
+
 func restore_v1alpha4_VirtualMachineBootstrapCloudInitWaitOnNetwork(dst, src *vmopv1.VirtualMachine) {
 	if bs := src.Spec.Bootstrap; bs != nil {
 		if ci := bs.CloudInit; ci != nil {
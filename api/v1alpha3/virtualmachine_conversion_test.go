@@ -113,6 +113,7 @@ func TestVirtualMachineConversion(t *testing.T) {
 										Name: "primary",
 									},
 									GuestDeviceName: "eth10",
+									MacAddress:      "00:50:56:00:00:01",
 								},
 								{
 									Name: "ncp-interface",
@@ -440,6 +441,56 @@ func TestVirtualMachineConversion(t *testing.T) {
 					},
 				},
 			},
+			{
+				name: "spec.bootstrap.ignition",
+				hub: &vmopv1.VirtualMachine{
+					Spec: vmopv1.VirtualMachineSpec{
+						Bootstrap: &vmopv1.VirtualMachineBootstrapSpec{
+							Ignition: &vmopv1.VirtualMachineBootstrapIgnitionSpec{
+								RawConfig: &vmopv1common.SecretKeySelector{
+									Name: "ignition-secret",
+									Key:  "config",
+								},
+							},
+						},
+					},
+				},
+			},
+			{
+				name: "spec.bootstrap.customizationGeneration",
+				hub: &vmopv1.VirtualMachine{
+					Spec: vmopv1.VirtualMachineSpec{
+						Bootstrap: &vmopv1.VirtualMachineBootstrapSpec{
+							CustomizationGeneration: 2,
+						},
+					},
+					Status: vmopv1.VirtualMachineStatus{
+						ObservedCustomizationGeneration: 1,
+					},
+				},
+			},
+			{
+				name: "spec.imageChannel and status.image",
+				hub: &vmopv1.VirtualMachine{
+					Spec: vmopv1.VirtualMachineSpec{
+						Image: &vmopv1.VirtualMachineImageRef{
+							Kind: "VirtualMachineImage",
+							Name: "vmi-123",
+						},
+						ImageChannel: "my-channel",
+					},
+					Status: vmopv1.VirtualMachineStatus{
+						Image: &vmopv1.VirtualMachineImageRefStatus{
+							VirtualMachineImageRef: vmopv1.VirtualMachineImageRef{
+								Kind: "VirtualMachineImage",
+								Name: "vmi-123",
+							},
+							Version: "1.0.0",
+							Channel: "my-channel",
+						},
+					},
+				},
+			},
 		}
 
 		for i := range testCases {
@@ -36,6 +36,12 @@ func Convert_v1alpha4_VirtualMachineImageCacheFileStatus_To_v1alpha3_VirtualMach
 	return nil
 }
 
+func Convert_v1alpha4_VirtualMachineImageCacheLocationStatus_To_v1alpha3_VirtualMachineImageCacheLocationStatus(
+	in *vmopv1.VirtualMachineImageCacheLocationStatus, out *VirtualMachineImageCacheLocationStatus, s apiconversion.Scope) error {
+
+	return autoConvert_v1alpha4_VirtualMachineImageCacheLocationStatus_To_v1alpha3_VirtualMachineImageCacheLocationStatus(in, out, s)
+}
+
 // ConvertTo converts this VirtualMachineImageCache to the Hub version.
 func (i *VirtualMachineImageCache) ConvertTo(dstRaw ctrlconversion.Hub) error {
 	dst := dstRaw.(*vmopv1.VirtualMachineImageCache)
@@ -14,9 +14,11 @@ import (
 	unsafe "unsafe"
 
 	v1alpha3cloudinit "github.com/vmware-tanzu/vm-operator/api/v1alpha3/cloudinit"
+	conversionv1alpha3 "github.com/vmware-tanzu/vm-operator/api/v1alpha3/cloudinit/conversion/v1alpha3"
+	conversionv1alpha4 "github.com/vmware-tanzu/vm-operator/api/v1alpha3/cloudinit/conversion/v1alpha4"
 	v1alpha3common "github.com/vmware-tanzu/vm-operator/api/v1alpha3/common"
-	conversionv1alpha3 "github.com/vmware-tanzu/vm-operator/api/v1alpha3/common/conversion/v1alpha3"
-	conversionv1alpha4 "github.com/vmware-tanzu/vm-operator/api/v1alpha3/common/conversion/v1alpha4"
+	commonconversionv1alpha3 "github.com/vmware-tanzu/vm-operator/api/v1alpha3/common/conversion/v1alpha3"
+	commonconversionv1alpha4 "github.com/vmware-tanzu/vm-operator/api/v1alpha3/common/conversion/v1alpha4"
 	v1alpha3sysprep "github.com/vmware-tanzu/vm-operator/api/v1alpha3/sysprep"
 	v1alpha4 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
 	cloudinit "github.com/vmware-tanzu/vm-operator/api/v1alpha4/cloudinit"
@@ -265,11 +267,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1alpha4.VirtualMachineBootstrapSpec)(nil), (*VirtualMachineBootstrapSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1alpha4_VirtualMachineBootstrapSpec_To_v1alpha3_VirtualMachineBootstrapSpec(a.(*v1alpha4.VirtualMachineBootstrapSpec), b.(*VirtualMachineBootstrapSpec), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*VirtualMachineBootstrapSysprepSpec)(nil), (*v1alpha4.VirtualMachineBootstrapSysprepSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_VirtualMachineBootstrapSysprepSpec_To_v1alpha4_VirtualMachineBootstrapSysprepSpec(a.(*VirtualMachineBootstrapSysprepSpec), b.(*v1alpha4.VirtualMachineBootstrapSysprepSpec), scope)
 	}); err != nil {
@@ -295,11 +292,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1alpha4.VirtualMachineCdromSpec)(nil), (*VirtualMachineCdromSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1alpha4_VirtualMachineCdromSpec_To_v1alpha3_VirtualMachineCdromSpec(a.(*v1alpha4.VirtualMachineCdromSpec), b.(*VirtualMachineCdromSpec), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*VirtualMachineClass)(nil), (*v1alpha4.VirtualMachineClass)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_VirtualMachineClass_To_v1alpha4_VirtualMachineClass(a.(*VirtualMachineClass), b.(*v1alpha4.VirtualMachineClass), scope)
 	}); err != nil {
@@ -435,11 +427,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1alpha4.VirtualMachineImageCacheLocationStatus)(nil), (*VirtualMachineImageCacheLocationStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1alpha4_VirtualMachineImageCacheLocationStatus_To_v1alpha3_VirtualMachineImageCacheLocationStatus(a.(*v1alpha4.VirtualMachineImageCacheLocationStatus), b.(*VirtualMachineImageCacheLocationStatus), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*VirtualMachineImageCacheOVFStatus)(nil), (*v1alpha4.VirtualMachineImageCacheOVFStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_VirtualMachineImageCacheOVFStatus_To_v1alpha4_VirtualMachineImageCacheOVFStatus(a.(*VirtualMachineImageCacheOVFStatus), b.(*v1alpha4.VirtualMachineImageCacheOVFStatus), scope)
 	}); err != nil {
@@ -525,21 +512,11 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1alpha4.VirtualMachineImageSpec)(nil), (*VirtualMachineImageSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1alpha4_VirtualMachineImageSpec_To_v1alpha3_VirtualMachineImageSpec(a.(*v1alpha4.VirtualMachineImageSpec), b.(*VirtualMachineImageSpec), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*VirtualMachineImageStatus)(nil), (*v1alpha4.VirtualMachineImageStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_VirtualMachineImageStatus_To_v1alpha4_VirtualMachineImageStatus(a.(*VirtualMachineImageStatus), b.(*v1alpha4.VirtualMachineImageStatus), scope)
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1alpha4.VirtualMachineImageStatus)(nil), (*VirtualMachineImageStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1alpha4_VirtualMachineImageStatus_To_v1alpha3_VirtualMachineImageStatus(a.(*v1alpha4.VirtualMachineImageStatus), b.(*VirtualMachineImageStatus), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*VirtualMachineList)(nil), (*v1alpha4.VirtualMachineList)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_VirtualMachineList_To_v1alpha4_VirtualMachineList(a.(*VirtualMachineList), b.(*v1alpha4.VirtualMachineList), scope)
 	}); err != nil {
@@ -595,11 +572,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1alpha4.VirtualMachineNetworkConfigInterfaceStatus)(nil), (*VirtualMachineNetworkConfigInterfaceStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1alpha4_VirtualMachineNetworkConfigInterfaceStatus_To_v1alpha3_VirtualMachineNetworkConfigInterfaceStatus(a.(*v1alpha4.VirtualMachineNetworkConfigInterfaceStatus), b.(*VirtualMachineNetworkConfigInterfaceStatus), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*VirtualMachineNetworkConfigStatus)(nil), (*v1alpha4.VirtualMachineNetworkConfigStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_VirtualMachineNetworkConfigStatus_To_v1alpha4_VirtualMachineNetworkConfigStatus(a.(*VirtualMachineNetworkConfigStatus), b.(*v1alpha4.VirtualMachineNetworkConfigStatus), scope)
 	}); err != nil {
@@ -695,11 +667,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1alpha4.VirtualMachineNetworkInterfaceSpec)(nil), (*VirtualMachineNetworkInterfaceSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1alpha4_VirtualMachineNetworkInterfaceSpec_To_v1alpha3_VirtualMachineNetworkInterfaceSpec(a.(*v1alpha4.VirtualMachineNetworkInterfaceSpec), b.(*VirtualMachineNetworkInterfaceSpec), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*VirtualMachineNetworkInterfaceStatus)(nil), (*v1alpha4.VirtualMachineNetworkInterfaceStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_VirtualMachineNetworkInterfaceStatus_To_v1alpha4_VirtualMachineNetworkInterfaceStatus(a.(*VirtualMachineNetworkInterfaceStatus), b.(*v1alpha4.VirtualMachineNetworkInterfaceStatus), scope)
 	}); err != nil {
@@ -735,11 +702,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1alpha4.VirtualMachineNetworkSpec)(nil), (*VirtualMachineNetworkSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1alpha4_VirtualMachineNetworkSpec_To_v1alpha3_VirtualMachineNetworkSpec(a.(*v1alpha4.VirtualMachineNetworkSpec), b.(*VirtualMachineNetworkSpec), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*VirtualMachineNetworkStatus)(nil), (*v1alpha4.VirtualMachineNetworkStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_VirtualMachineNetworkStatus_To_v1alpha4_VirtualMachineNetworkStatus(a.(*VirtualMachineNetworkStatus), b.(*v1alpha4.VirtualMachineNetworkStatus), scope)
 	}); err != nil {
@@ -825,11 +787,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1alpha4.VirtualMachinePublishRequestTargetLocation)(nil), (*VirtualMachinePublishRequestTargetLocation)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1alpha4_VirtualMachinePublishRequestTargetLocation_To_v1alpha3_VirtualMachinePublishRequestTargetLocation(a.(*v1alpha4.VirtualMachinePublishRequestTargetLocation), b.(*VirtualMachinePublishRequestTargetLocation), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*VirtualMachineReadinessProbeSpec)(nil), (*v1alpha4.VirtualMachineReadinessProbeSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_VirtualMachineReadinessProbeSpec_To_v1alpha4_VirtualMachineReadinessProbeSpec(a.(*VirtualMachineReadinessProbeSpec), b.(*v1alpha4.VirtualMachineReadinessProbeSpec), scope)
 	}); err != nil {
@@ -865,11 +822,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1alpha4.VirtualMachineReplicaSetSpec)(nil), (*VirtualMachineReplicaSetSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1alpha4_VirtualMachineReplicaSetSpec_To_v1alpha3_VirtualMachineReplicaSetSpec(a.(*v1alpha4.VirtualMachineReplicaSetSpec), b.(*VirtualMachineReplicaSetSpec), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*VirtualMachineReplicaSetStatus)(nil), (*v1alpha4.VirtualMachineReplicaSetStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha3_VirtualMachineReplicaSetStatus_To_v1alpha4_VirtualMachineReplicaSetStatus(a.(*VirtualMachineReplicaSetStatus), b.(*v1alpha4.VirtualMachineReplicaSetStatus), scope)
 	}); err != nil {
@@ -1110,11 +1062,61 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1alpha4.VirtualMachineBootstrapSpec)(nil), (*VirtualMachineBootstrapSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha4_VirtualMachineBootstrapSpec_To_v1alpha3_VirtualMachineBootstrapSpec(a.(*v1alpha4.VirtualMachineBootstrapSpec), b.(*VirtualMachineBootstrapSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1alpha4.VirtualMachineCdromSpec)(nil), (*VirtualMachineCdromSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha4_VirtualMachineCdromSpec_To_v1alpha3_VirtualMachineCdromSpec(a.(*v1alpha4.VirtualMachineCdromSpec), b.(*VirtualMachineCdromSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1alpha4.VirtualMachineImageCacheFileStatus)(nil), (*VirtualMachineImageCacheFileStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha4_VirtualMachineImageCacheFileStatus_To_v1alpha3_VirtualMachineImageCacheFileStatus(a.(*v1alpha4.VirtualMachineImageCacheFileStatus), b.(*VirtualMachineImageCacheFileStatus), scope)
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1alpha4.VirtualMachineImageCacheLocationStatus)(nil), (*VirtualMachineImageCacheLocationStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha4_VirtualMachineImageCacheLocationStatus_To_v1alpha3_VirtualMachineImageCacheLocationStatus(a.(*v1alpha4.VirtualMachineImageCacheLocationStatus), b.(*VirtualMachineImageCacheLocationStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1alpha4.VirtualMachineImageSpec)(nil), (*VirtualMachineImageSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha4_VirtualMachineImageSpec_To_v1alpha3_VirtualMachineImageSpec(a.(*v1alpha4.VirtualMachineImageSpec), b.(*VirtualMachineImageSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1alpha4.VirtualMachineImageStatus)(nil), (*VirtualMachineImageStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha4_VirtualMachineImageStatus_To_v1alpha3_VirtualMachineImageStatus(a.(*v1alpha4.VirtualMachineImageStatus), b.(*VirtualMachineImageStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1alpha4.VirtualMachineNetworkConfigInterfaceStatus)(nil), (*VirtualMachineNetworkConfigInterfaceStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha4_VirtualMachineNetworkConfigInterfaceStatus_To_v1alpha3_VirtualMachineNetworkConfigInterfaceStatus(a.(*v1alpha4.VirtualMachineNetworkConfigInterfaceStatus), b.(*VirtualMachineNetworkConfigInterfaceStatus), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1alpha4.VirtualMachineNetworkInterfaceSpec)(nil), (*VirtualMachineNetworkInterfaceSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha4_VirtualMachineNetworkInterfaceSpec_To_v1alpha3_VirtualMachineNetworkInterfaceSpec(a.(*v1alpha4.VirtualMachineNetworkInterfaceSpec), b.(*VirtualMachineNetworkInterfaceSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1alpha4.VirtualMachineNetworkSpec)(nil), (*VirtualMachineNetworkSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha4_VirtualMachineNetworkSpec_To_v1alpha3_VirtualMachineNetworkSpec(a.(*v1alpha4.VirtualMachineNetworkSpec), b.(*VirtualMachineNetworkSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1alpha4.VirtualMachinePublishRequestTargetLocation)(nil), (*VirtualMachinePublishRequestTargetLocation)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha4_VirtualMachinePublishRequestTargetLocation_To_v1alpha3_VirtualMachinePublishRequestTargetLocation(a.(*v1alpha4.VirtualMachinePublishRequestTargetLocation), b.(*VirtualMachinePublishRequestTargetLocation), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1alpha4.VirtualMachineReplicaSetSpec)(nil), (*VirtualMachineReplicaSetSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1alpha4_VirtualMachineReplicaSetSpec_To_v1alpha3_VirtualMachineReplicaSetSpec(a.(*v1alpha4.VirtualMachineReplicaSetSpec), b.(*VirtualMachineReplicaSetSpec), scope)
+	}); err != nil {
+		return err
+	}
 	if err := s.AddConversionFunc((*v1alpha4.VirtualMachineSpec)(nil), (*VirtualMachineSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha4_VirtualMachineSpec_To_v1alpha3_VirtualMachineSpec(a.(*v1alpha4.VirtualMachineSpec), b.(*VirtualMachineSpec), scope)
 	}); err != nil {
@@ -1172,7 +1174,17 @@ func Convert_v1alpha4_ClusterVirtualMachineImage_To_v1alpha3_ClusterVirtualMachi
 
 func autoConvert_v1alpha3_ClusterVirtualMachineImageList_To_v1alpha4_ClusterVirtualMachineImageList(in *ClusterVirtualMachineImageList, out *v1alpha4.ClusterVirtualMachineImageList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
-	out.Items = *(*[]v1alpha4.ClusterVirtualMachineImage)(unsafe.Pointer(&in.Items))
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]v1alpha4.ClusterVirtualMachineImage, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha3_ClusterVirtualMachineImage_To_v1alpha4_ClusterVirtualMachineImage(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
 	return nil
 }
 
@@ -1183,7 +1195,17 @@ func Convert_v1alpha3_ClusterVirtualMachineImageList_To_v1alpha4_ClusterVirtualM
 
 func autoConvert_v1alpha4_ClusterVirtualMachineImageList_To_v1alpha3_ClusterVirtualMachineImageList(in *v1alpha4.ClusterVirtualMachineImageList, out *ClusterVirtualMachineImageList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
-	out.Items = *(*[]ClusterVirtualMachineImage)(unsafe.Pointer(&in.Items))
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterVirtualMachineImage, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha4_ClusterVirtualMachineImage_To_v1alpha3_ClusterVirtualMachineImage(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
 	return nil
 }
 
@@ -1634,7 +1656,15 @@ func Convert_v1alpha4_VirtualMachineAdvancedSpec_To_v1alpha3_VirtualMachineAdvan
 
 func autoConvert_v1alpha3_VirtualMachineBootstrapCloudInitSpec_To_v1alpha4_VirtualMachineBootstrapCloudInitSpec(in *VirtualMachineBootstrapCloudInitSpec, out *v1alpha4.VirtualMachineBootstrapCloudInitSpec, s conversion.Scope) error {
 	out.InstanceID = in.InstanceID
-	out.CloudConfig = (*cloudinit.CloudConfig)(unsafe.Pointer(in.CloudConfig))
+	if in.CloudConfig != nil {
+		in, out := &in.CloudConfig, &out.CloudConfig
+		*out = new(cloudinit.CloudConfig)
+		if err := conversionv1alpha3.Convert_cloudinit_CloudConfig_To_cloudinit_CloudConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CloudConfig = nil
+	}
 	out.RawCloudConfig = (*common.SecretKeySelector)(unsafe.Pointer(in.RawCloudConfig))
 	out.SSHAuthorizedKeys = *(*[]string)(unsafe.Pointer(&in.SSHAuthorizedKeys))
 	out.UseGlobalNameserversAsDefault = (*bool)(unsafe.Pointer(in.UseGlobalNameserversAsDefault))
@@ -1649,7 +1679,15 @@ func Convert_v1alpha3_VirtualMachineBootstrapCloudInitSpec_To_v1alpha4_VirtualMa
 
 func autoConvert_v1alpha4_VirtualMachineBootstrapCloudInitSpec_To_v1alpha3_VirtualMachineBootstrapCloudInitSpec(in *v1alpha4.VirtualMachineBootstrapCloudInitSpec, out *VirtualMachineBootstrapCloudInitSpec, s conversion.Scope) error {
 	out.InstanceID = in.InstanceID
-	out.CloudConfig = (*v1alpha3cloudinit.CloudConfig)(unsafe.Pointer(in.CloudConfig))
+	if in.CloudConfig != nil {
+		in, out := &in.CloudConfig, &out.CloudConfig
+		*out = new(v1alpha3cloudinit.CloudConfig)
+		if err := conversionv1alpha4.Convert_cloudinit_CloudConfig_To_cloudinit_CloudConfig(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CloudConfig = nil
+	}
 	out.RawCloudConfig = (*v1alpha3common.SecretKeySelector)(unsafe.Pointer(in.RawCloudConfig))
 	out.SSHAuthorizedKeys = *(*[]string)(unsafe.Pointer(&in.SSHAuthorizedKeys))
 	out.UseGlobalNameserversAsDefault = (*bool)(unsafe.Pointer(in.UseGlobalNameserversAsDefault))
@@ -1715,14 +1753,11 @@ func autoConvert_v1alpha4_VirtualMachineBootstrapSpec_To_v1alpha3_VirtualMachine
 	out.LinuxPrep = (*VirtualMachineBootstrapLinuxPrepSpec)(unsafe.Pointer(in.LinuxPrep))
 	out.Sysprep = (*VirtualMachineBootstrapSysprepSpec)(unsafe.Pointer(in.Sysprep))
 	out.VAppConfig = (*VirtualMachineBootstrapVAppConfigSpec)(unsafe.Pointer(in.VAppConfig))
+	// WARNING: in.Ignition requires manual conversion: does not exist in peer-type
+	// WARNING: in.CustomizationGeneration requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1alpha4_VirtualMachineBootstrapSpec_To_v1alpha3_VirtualMachineBootstrapSpec is an autogenerated conversion function.
-func Convert_v1alpha4_VirtualMachineBootstrapSpec_To_v1alpha3_VirtualMachineBootstrapSpec(in *v1alpha4.VirtualMachineBootstrapSpec, out *VirtualMachineBootstrapSpec, s conversion.Scope) error {
-	return autoConvert_v1alpha4_VirtualMachineBootstrapSpec_To_v1alpha3_VirtualMachineBootstrapSpec(in, out, s)
-}
-
 func autoConvert_v1alpha3_VirtualMachineBootstrapSysprepSpec_To_v1alpha4_VirtualMachineBootstrapSysprepSpec(in *VirtualMachineBootstrapSysprepSpec, out *v1alpha4.VirtualMachineBootstrapSysprepSpec, s conversion.Scope) error {
 	out.Sysprep = (*sysprep.Sysprep)(unsafe.Pointer(in.Sysprep))
 	out.RawSysprep = (*common.SecretKeySelector)(unsafe.Pointer(in.RawSysprep))
@@ -1789,14 +1824,10 @@ func autoConvert_v1alpha4_VirtualMachineCdromSpec_To_v1alpha3_VirtualMachineCdro
 	}
 	out.Connected = (*bool)(unsafe.Pointer(in.Connected))
 	out.AllowGuestControl = (*bool)(unsafe.Pointer(in.AllowGuestControl))
+	// WARNING: in.DisconnectAfterBootstrap requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1alpha4_VirtualMachineCdromSpec_To_v1alpha3_VirtualMachineCdromSpec is an autogenerated conversion function.
-func Convert_v1alpha4_VirtualMachineCdromSpec_To_v1alpha3_VirtualMachineCdromSpec(in *v1alpha4.VirtualMachineCdromSpec, out *VirtualMachineCdromSpec, s conversion.Scope) error {
-	return autoConvert_v1alpha4_VirtualMachineCdromSpec_To_v1alpha3_VirtualMachineCdromSpec(in, out, s)
-}
-
 func autoConvert_v1alpha3_VirtualMachineClass_To_v1alpha4_VirtualMachineClass(in *VirtualMachineClass, out *v1alpha4.VirtualMachineClass, s conversion.Scope) error {
 	out.ObjectMeta = in.ObjectMeta
 	if err := Convert_v1alpha3_VirtualMachineClassSpec_To_v1alpha4_VirtualMachineClassSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -2221,15 +2252,11 @@ func autoConvert_v1alpha4_VirtualMachineImageCacheLocationStatus_To_v1alpha3_Vir
 	} else {
 		out.Files = nil
 	}
+	// WARNING: in.LastUsedTime requires manual conversion: does not exist in peer-type
 	out.Conditions = *(*[]v1.Condition)(unsafe.Pointer(&in.Conditions))
 	return nil
 }
 
-// Convert_v1alpha4_VirtualMachineImageCacheLocationStatus_To_v1alpha3_VirtualMachineImageCacheLocationStatus is an autogenerated conversion function.
-func Convert_v1alpha4_VirtualMachineImageCacheLocationStatus_To_v1alpha3_VirtualMachineImageCacheLocationStatus(in *v1alpha4.VirtualMachineImageCacheLocationStatus, out *VirtualMachineImageCacheLocationStatus, s conversion.Scope) error {
-	return autoConvert_v1alpha4_VirtualMachineImageCacheLocationStatus_To_v1alpha3_VirtualMachineImageCacheLocationStatus(in, out, s)
-}
-
 func autoConvert_v1alpha3_VirtualMachineImageCacheOVFStatus_To_v1alpha4_VirtualMachineImageCacheOVFStatus(in *VirtualMachineImageCacheOVFStatus, out *v1alpha4.VirtualMachineImageCacheOVFStatus, s conversion.Scope) error {
 	out.ConfigMapName = in.ConfigMapName
 	out.ProviderVersion = in.ProviderVersion
@@ -2344,7 +2371,17 @@ func Convert_v1alpha4_VirtualMachineImageDiskInfo_To_v1alpha3_VirtualMachineImag
 
 func autoConvert_v1alpha3_VirtualMachineImageList_To_v1alpha4_VirtualMachineImageList(in *VirtualMachineImageList, out *v1alpha4.VirtualMachineImageList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
-	out.Items = *(*[]v1alpha4.VirtualMachineImage)(unsafe.Pointer(&in.Items))
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]v1alpha4.VirtualMachineImage, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha3_VirtualMachineImage_To_v1alpha4_VirtualMachineImage(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
 	return nil
 }
 
@@ -2355,7 +2392,17 @@ func Convert_v1alpha3_VirtualMachineImageList_To_v1alpha4_VirtualMachineImageLis
 
 func autoConvert_v1alpha4_VirtualMachineImageList_To_v1alpha3_VirtualMachineImageList(in *v1alpha4.VirtualMachineImageList, out *VirtualMachineImageList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
-	out.Items = *(*[]VirtualMachineImage)(unsafe.Pointer(&in.Items))
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VirtualMachineImage, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha4_VirtualMachineImage_To_v1alpha3_VirtualMachineImage(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
 	return nil
 }
 
@@ -2448,14 +2495,10 @@ func Convert_v1alpha3_VirtualMachineImageSpec_To_v1alpha4_VirtualMachineImageSpe
 
 func autoConvert_v1alpha4_VirtualMachineImageSpec_To_v1alpha3_VirtualMachineImageSpec(in *v1alpha4.VirtualMachineImageSpec, out *VirtualMachineImageSpec, s conversion.Scope) error {
 	out.ProviderRef = (*v1alpha3common.LocalObjectRef)(unsafe.Pointer(in.ProviderRef))
+	// WARNING: in.OCI requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1alpha4_VirtualMachineImageSpec_To_v1alpha3_VirtualMachineImageSpec is an autogenerated conversion function.
-func Convert_v1alpha4_VirtualMachineImageSpec_To_v1alpha3_VirtualMachineImageSpec(in *v1alpha4.VirtualMachineImageSpec, out *VirtualMachineImageSpec, s conversion.Scope) error {
-	return autoConvert_v1alpha4_VirtualMachineImageSpec_To_v1alpha3_VirtualMachineImageSpec(in, out, s)
-}
-
 func autoConvert_v1alpha3_VirtualMachineImageStatus_To_v1alpha4_VirtualMachineImageStatus(in *VirtualMachineImageStatus, out *v1alpha4.VirtualMachineImageStatus, s conversion.Scope) error {
 	out.Name = in.Name
 	out.Capabilities = *(*[]string)(unsafe.Pointer(&in.Capabilities))
@@ -2498,16 +2541,13 @@ func autoConvert_v1alpha4_VirtualMachineImageStatus_To_v1alpha3_VirtualMachineIm
 	out.Disks = *(*[]VirtualMachineImageDiskInfo)(unsafe.Pointer(&in.Disks))
 	out.ProviderContentVersion = in.ProviderContentVersion
 	out.ProviderItemID = in.ProviderItemID
+	// WARNING: in.Fingerprint requires manual conversion: does not exist in peer-type
+	// WARNING: in.FingerprintContentVersion requires manual conversion: does not exist in peer-type
 	out.Conditions = *(*[]v1.Condition)(unsafe.Pointer(&in.Conditions))
 	out.Type = in.Type
 	return nil
 }
 
-// Convert_v1alpha4_VirtualMachineImageStatus_To_v1alpha3_VirtualMachineImageStatus is an autogenerated conversion function.
-func Convert_v1alpha4_VirtualMachineImageStatus_To_v1alpha3_VirtualMachineImageStatus(in *v1alpha4.VirtualMachineImageStatus, out *VirtualMachineImageStatus, s conversion.Scope) error {
-	return autoConvert_v1alpha4_VirtualMachineImageStatus_To_v1alpha3_VirtualMachineImageStatus(in, out, s)
-}
-
 func autoConvert_v1alpha3_VirtualMachineList_To_v1alpha4_VirtualMachineList(in *VirtualMachineList, out *v1alpha4.VirtualMachineList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
 	if in.Items != nil {
@@ -2660,16 +2700,23 @@ func autoConvert_v1alpha4_VirtualMachineNetworkConfigInterfaceStatus_To_v1alpha3
 	out.Name = in.Name
 	out.IP = (*VirtualMachineNetworkConfigInterfaceIPStatus)(unsafe.Pointer(in.IP))
 	out.DNS = (*VirtualMachineNetworkConfigDNSStatus)(unsafe.Pointer(in.DNS))
+	// WARNING: in.QoS requires manual conversion: does not exist in peer-type
+	// WARNING: in.TrafficRules requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1alpha4_VirtualMachineNetworkConfigInterfaceStatus_To_v1alpha3_VirtualMachineNetworkConfigInterfaceStatus is an autogenerated conversion function.
-func Convert_v1alpha4_VirtualMachineNetworkConfigInterfaceStatus_To_v1alpha3_VirtualMachineNetworkConfigInterfaceStatus(in *v1alpha4.VirtualMachineNetworkConfigInterfaceStatus, out *VirtualMachineNetworkConfigInterfaceStatus, s conversion.Scope) error {
-	return autoConvert_v1alpha4_VirtualMachineNetworkConfigInterfaceStatus_To_v1alpha3_VirtualMachineNetworkConfigInterfaceStatus(in, out, s)
-}
-
 func autoConvert_v1alpha3_VirtualMachineNetworkConfigStatus_To_v1alpha4_VirtualMachineNetworkConfigStatus(in *VirtualMachineNetworkConfigStatus, out *v1alpha4.VirtualMachineNetworkConfigStatus, s conversion.Scope) error {
-	out.Interfaces = *(*[]v1alpha4.VirtualMachineNetworkConfigInterfaceStatus)(unsafe.Pointer(&in.Interfaces))
+	if in.Interfaces != nil {
+		in, out := &in.Interfaces, &out.Interfaces
+		*out = make([]v1alpha4.VirtualMachineNetworkConfigInterfaceStatus, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha3_VirtualMachineNetworkConfigInterfaceStatus_To_v1alpha4_VirtualMachineNetworkConfigInterfaceStatus(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Interfaces = nil
+	}
 	out.DNS = (*v1alpha4.VirtualMachineNetworkConfigDNSStatus)(unsafe.Pointer(in.DNS))
 	return nil
 }
@@ -2680,7 +2727,17 @@ func Convert_v1alpha3_VirtualMachineNetworkConfigStatus_To_v1alpha4_VirtualMachi
 }
 
 func autoConvert_v1alpha4_VirtualMachineNetworkConfigStatus_To_v1alpha3_VirtualMachineNetworkConfigStatus(in *v1alpha4.VirtualMachineNetworkConfigStatus, out *VirtualMachineNetworkConfigStatus, s conversion.Scope) error {
-	out.Interfaces = *(*[]VirtualMachineNetworkConfigInterfaceStatus)(unsafe.Pointer(&in.Interfaces))
+	if in.Interfaces != nil {
+		in, out := &in.Interfaces, &out.Interfaces
+		*out = make([]VirtualMachineNetworkConfigInterfaceStatus, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha4_VirtualMachineNetworkConfigInterfaceStatus_To_v1alpha3_VirtualMachineNetworkConfigInterfaceStatus(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Interfaces = nil
+	}
 	out.DNS = (*VirtualMachineNetworkConfigDNSStatus)(unsafe.Pointer(in.DNS))
 	return nil
 }
@@ -2921,7 +2978,12 @@ func autoConvert_v1alpha4_VirtualMachineNetworkInterfaceSpec_To_v1alpha3_Virtual
 	out.Name = in.Name
 	out.Network = (*v1alpha3common.PartialObjectRef)(unsafe.Pointer(in.Network))
 	out.GuestDeviceName = in.GuestDeviceName
+	// WARNING: in.MacAddress requires manual conversion: does not exist in peer-type
+	// WARNING: in.Type requires manual conversion: does not exist in peer-type
+	// WARNING: in.SRIOV requires manual conversion: does not exist in peer-type
+	// WARNING: in.DVX requires manual conversion: does not exist in peer-type
 	out.Addresses = *(*[]string)(unsafe.Pointer(&in.Addresses))
+	// WARNING: in.AddressesFromPools requires manual conversion: does not exist in peer-type
 	out.DHCP4 = in.DHCP4
 	out.DHCP6 = in.DHCP6
 	out.Gateway4 = in.Gateway4
@@ -2930,14 +2992,11 @@ func autoConvert_v1alpha4_VirtualMachineNetworkInterfaceSpec_To_v1alpha3_Virtual
 	out.Nameservers = *(*[]string)(unsafe.Pointer(&in.Nameservers))
 	out.Routes = *(*[]VirtualMachineNetworkRouteSpec)(unsafe.Pointer(&in.Routes))
 	out.SearchDomains = *(*[]string)(unsafe.Pointer(&in.SearchDomains))
+	// WARNING: in.QoS requires manual conversion: does not exist in peer-type
+	// WARNING: in.TrafficRules requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1alpha4_VirtualMachineNetworkInterfaceSpec_To_v1alpha3_VirtualMachineNetworkInterfaceSpec is an autogenerated conversion function.
-func Convert_v1alpha4_VirtualMachineNetworkInterfaceSpec_To_v1alpha3_VirtualMachineNetworkInterfaceSpec(in *v1alpha4.VirtualMachineNetworkInterfaceSpec, out *VirtualMachineNetworkInterfaceSpec, s conversion.Scope) error {
-	return autoConvert_v1alpha4_VirtualMachineNetworkInterfaceSpec_To_v1alpha3_VirtualMachineNetworkInterfaceSpec(in, out, s)
-}
-
 func autoConvert_v1alpha3_VirtualMachineNetworkInterfaceStatus_To_v1alpha4_VirtualMachineNetworkInterfaceStatus(in *VirtualMachineNetworkInterfaceStatus, out *v1alpha4.VirtualMachineNetworkInterfaceStatus, s conversion.Scope) error {
 	out.Name = in.Name
 	out.DeviceKey = in.DeviceKey
@@ -3014,7 +3073,17 @@ func autoConvert_v1alpha3_VirtualMachineNetworkSpec_To_v1alpha4_VirtualMachineNe
 	out.Disabled = in.Disabled
 	out.Nameservers = *(*[]string)(unsafe.Pointer(&in.Nameservers))
 	out.SearchDomains = *(*[]string)(unsafe.Pointer(&in.SearchDomains))
-	out.Interfaces = *(*[]v1alpha4.VirtualMachineNetworkInterfaceSpec)(unsafe.Pointer(&in.Interfaces))
+	if in.Interfaces != nil {
+		in, out := &in.Interfaces, &out.Interfaces
+		*out = make([]v1alpha4.VirtualMachineNetworkInterfaceSpec, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha3_VirtualMachineNetworkInterfaceSpec_To_v1alpha4_VirtualMachineNetworkInterfaceSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Interfaces = nil
+	}
 	return nil
 }
 
@@ -3029,17 +3098,33 @@ func autoConvert_v1alpha4_VirtualMachineNetworkSpec_To_v1alpha3_VirtualMachineNe
 	out.Disabled = in.Disabled
 	out.Nameservers = *(*[]string)(unsafe.Pointer(&in.Nameservers))
 	out.SearchDomains = *(*[]string)(unsafe.Pointer(&in.SearchDomains))
-	out.Interfaces = *(*[]VirtualMachineNetworkInterfaceSpec)(unsafe.Pointer(&in.Interfaces))
+	if in.Interfaces != nil {
+		in, out := &in.Interfaces, &out.Interfaces
+		*out = make([]VirtualMachineNetworkInterfaceSpec, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha4_VirtualMachineNetworkInterfaceSpec_To_v1alpha3_VirtualMachineNetworkInterfaceSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Interfaces = nil
+	}
+	// WARNING: in.Bonds requires manual conversion: does not exist in peer-type
+	// WARNING: in.VLANs requires manual conversion: does not exist in peer-type
+	// WARNING: in.Bridges requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1alpha4_VirtualMachineNetworkSpec_To_v1alpha3_VirtualMachineNetworkSpec is an autogenerated conversion function.
-func Convert_v1alpha4_VirtualMachineNetworkSpec_To_v1alpha3_VirtualMachineNetworkSpec(in *v1alpha4.VirtualMachineNetworkSpec, out *VirtualMachineNetworkSpec, s conversion.Scope) error {
-	return autoConvert_v1alpha4_VirtualMachineNetworkSpec_To_v1alpha3_VirtualMachineNetworkSpec(in, out, s)
-}
-
 func autoConvert_v1alpha3_VirtualMachineNetworkStatus_To_v1alpha4_VirtualMachineNetworkStatus(in *VirtualMachineNetworkStatus, out *v1alpha4.VirtualMachineNetworkStatus, s conversion.Scope) error {
-	out.Config = (*v1alpha4.VirtualMachineNetworkConfigStatus)(unsafe.Pointer(in.Config))
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = new(v1alpha4.VirtualMachineNetworkConfigStatus)
+		if err := Convert_v1alpha3_VirtualMachineNetworkConfigStatus_To_v1alpha4_VirtualMachineNetworkConfigStatus(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Config = nil
+	}
 	out.HostName = in.HostName
 	out.Interfaces = *(*[]v1alpha4.VirtualMachineNetworkInterfaceStatus)(unsafe.Pointer(&in.Interfaces))
 	out.IPStacks = *(*[]v1alpha4.VirtualMachineNetworkIPStackStatus)(unsafe.Pointer(&in.IPStacks))
@@ -3054,7 +3139,15 @@ func Convert_v1alpha3_VirtualMachineNetworkStatus_To_v1alpha4_VirtualMachineNetw
 }
 
 func autoConvert_v1alpha4_VirtualMachineNetworkStatus_To_v1alpha3_VirtualMachineNetworkStatus(in *v1alpha4.VirtualMachineNetworkStatus, out *VirtualMachineNetworkStatus, s conversion.Scope) error {
-	out.Config = (*VirtualMachineNetworkConfigStatus)(unsafe.Pointer(in.Config))
+	if in.Config != nil {
+		in, out := &in.Config, &out.Config
+		*out = new(VirtualMachineNetworkConfigStatus)
+		if err := Convert_v1alpha4_VirtualMachineNetworkConfigStatus_To_v1alpha3_VirtualMachineNetworkConfigStatus(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Config = nil
+	}
 	out.HostName = in.HostName
 	out.Interfaces = *(*[]VirtualMachineNetworkInterfaceStatus)(unsafe.Pointer(&in.Interfaces))
 	out.IPStacks = *(*[]VirtualMachineNetworkIPStackStatus)(unsafe.Pointer(&in.IPStacks))
@@ -3102,7 +3195,17 @@ func Convert_v1alpha4_VirtualMachinePublishRequest_To_v1alpha3_VirtualMachinePub
 
 func autoConvert_v1alpha3_VirtualMachinePublishRequestList_To_v1alpha4_VirtualMachinePublishRequestList(in *VirtualMachinePublishRequestList, out *v1alpha4.VirtualMachinePublishRequestList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
-	out.Items = *(*[]v1alpha4.VirtualMachinePublishRequest)(unsafe.Pointer(&in.Items))
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]v1alpha4.VirtualMachinePublishRequest, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha3_VirtualMachinePublishRequest_To_v1alpha4_VirtualMachinePublishRequest(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
 	return nil
 }
 
@@ -3113,7 +3216,17 @@ func Convert_v1alpha3_VirtualMachinePublishRequestList_To_v1alpha4_VirtualMachin
 
 func autoConvert_v1alpha4_VirtualMachinePublishRequestList_To_v1alpha3_VirtualMachinePublishRequestList(in *v1alpha4.VirtualMachinePublishRequestList, out *VirtualMachinePublishRequestList, s conversion.Scope) error {
 	out.ListMeta = in.ListMeta
-	out.Items = *(*[]VirtualMachinePublishRequest)(unsafe.Pointer(&in.Items))
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VirtualMachinePublishRequest, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha4_VirtualMachinePublishRequest_To_v1alpha3_VirtualMachinePublishRequest(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Items = nil
+	}
 	return nil
 }
 
@@ -3180,7 +3293,15 @@ func Convert_v1alpha4_VirtualMachinePublishRequestSpec_To_v1alpha3_VirtualMachin
 
 func autoConvert_v1alpha3_VirtualMachinePublishRequestStatus_To_v1alpha4_VirtualMachinePublishRequestStatus(in *VirtualMachinePublishRequestStatus, out *v1alpha4.VirtualMachinePublishRequestStatus, s conversion.Scope) error {
 	out.SourceRef = (*v1alpha4.VirtualMachinePublishRequestSource)(unsafe.Pointer(in.SourceRef))
-	out.TargetRef = (*v1alpha4.VirtualMachinePublishRequestTarget)(unsafe.Pointer(in.TargetRef))
+	if in.TargetRef != nil {
+		in, out := &in.TargetRef, &out.TargetRef
+		*out = new(v1alpha4.VirtualMachinePublishRequestTarget)
+		if err := Convert_v1alpha3_VirtualMachinePublishRequestTarget_To_v1alpha4_VirtualMachinePublishRequestTarget(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.TargetRef = nil
+	}
 	out.CompletionTime = in.CompletionTime
 	out.StartTime = in.StartTime
 	out.Attempts = in.Attempts
@@ -3198,7 +3319,15 @@ func Convert_v1alpha3_VirtualMachinePublishRequestStatus_To_v1alpha4_VirtualMach
 
 func autoConvert_v1alpha4_VirtualMachinePublishRequestStatus_To_v1alpha3_VirtualMachinePublishRequestStatus(in *v1alpha4.VirtualMachinePublishRequestStatus, out *VirtualMachinePublishRequestStatus, s conversion.Scope) error {
 	out.SourceRef = (*VirtualMachinePublishRequestSource)(unsafe.Pointer(in.SourceRef))
-	out.TargetRef = (*VirtualMachinePublishRequestTarget)(unsafe.Pointer(in.TargetRef))
+	if in.TargetRef != nil {
+		in, out := &in.TargetRef, &out.TargetRef
+		*out = new(VirtualMachinePublishRequestTarget)
+		if err := Convert_v1alpha4_VirtualMachinePublishRequestTarget_To_v1alpha3_VirtualMachinePublishRequestTarget(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.TargetRef = nil
+	}
 	out.CompletionTime = in.CompletionTime
 	out.StartTime = in.StartTime
 	out.Attempts = in.Attempts
@@ -3282,14 +3411,10 @@ func autoConvert_v1alpha4_VirtualMachinePublishRequestTargetLocation_To_v1alpha3
 	out.Name = in.Name
 	out.APIVersion = in.APIVersion
 	out.Kind = in.Kind
+	// WARNING: in.OCI requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1alpha4_VirtualMachinePublishRequestTargetLocation_To_v1alpha3_VirtualMachinePublishRequestTargetLocation is an autogenerated conversion function.
-func Convert_v1alpha4_VirtualMachinePublishRequestTargetLocation_To_v1alpha3_VirtualMachinePublishRequestTargetLocation(in *v1alpha4.VirtualMachinePublishRequestTargetLocation, out *VirtualMachinePublishRequestTargetLocation, s conversion.Scope) error {
-	return autoConvert_v1alpha4_VirtualMachinePublishRequestTargetLocation_To_v1alpha3_VirtualMachinePublishRequestTargetLocation(in, out, s)
-}
-
 func autoConvert_v1alpha3_VirtualMachineReadinessProbeSpec_To_v1alpha4_VirtualMachineReadinessProbeSpec(in *VirtualMachineReadinessProbeSpec, out *v1alpha4.VirtualMachineReadinessProbeSpec, s conversion.Scope) error {
 	out.TCPSocket = (*v1alpha4.TCPSocketAction)(unsafe.Pointer(in.TCPSocket))
 	out.GuestHeartbeat = (*v1alpha4.GuestHeartbeatAction)(unsafe.Pointer(in.GuestHeartbeat))
@@ -3414,14 +3539,10 @@ func autoConvert_v1alpha4_VirtualMachineReplicaSetSpec_To_v1alpha3_VirtualMachin
 	if err := Convert_v1alpha4_VirtualMachineTemplateSpec_To_v1alpha3_VirtualMachineTemplateSpec(&in.Template, &out.Template, s); err != nil {
 		return err
 	}
+	// WARNING: in.ImageRolloutPolicy requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1alpha4_VirtualMachineReplicaSetSpec_To_v1alpha3_VirtualMachineReplicaSetSpec is an autogenerated conversion function.
-func Convert_v1alpha4_VirtualMachineReplicaSetSpec_To_v1alpha3_VirtualMachineReplicaSetSpec(in *v1alpha4.VirtualMachineReplicaSetSpec, out *VirtualMachineReplicaSetSpec, s conversion.Scope) error {
-	return autoConvert_v1alpha4_VirtualMachineReplicaSetSpec_To_v1alpha3_VirtualMachineReplicaSetSpec(in, out, s)
-}
-
 func autoConvert_v1alpha3_VirtualMachineReplicaSetStatus_To_v1alpha4_VirtualMachineReplicaSetStatus(in *VirtualMachineReplicaSetStatus, out *v1alpha4.VirtualMachineReplicaSetStatus, s conversion.Scope) error {
 	out.Replicas = in.Replicas
 	out.FullyLabeledReplicas = in.FullyLabeledReplicas
@@ -3731,7 +3852,17 @@ func Convert_v1alpha4_VirtualMachineSetResourcePolicyStatus_To_v1alpha3_VirtualM
 }
 
 func autoConvert_v1alpha3_VirtualMachineSpec_To_v1alpha4_VirtualMachineSpec(in *VirtualMachineSpec, out *v1alpha4.VirtualMachineSpec, s conversion.Scope) error {
-	out.Cdrom = *(*[]v1alpha4.VirtualMachineCdromSpec)(unsafe.Pointer(&in.Cdrom))
+	if in.Cdrom != nil {
+		in, out := &in.Cdrom, &out.Cdrom
+		*out = make([]v1alpha4.VirtualMachineCdromSpec, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha3_VirtualMachineCdromSpec_To_v1alpha4_VirtualMachineCdromSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Cdrom = nil
+	}
 	out.Image = (*v1alpha4.VirtualMachineImageRef)(unsafe.Pointer(in.Image))
 	out.ImageName = in.ImageName
 	out.ClassName = in.ClassName
@@ -3746,7 +3877,15 @@ func autoConvert_v1alpha3_VirtualMachineSpec_To_v1alpha4_VirtualMachineSpec(in *
 	} else {
 		out.Bootstrap = nil
 	}
-	out.Network = (*v1alpha4.VirtualMachineNetworkSpec)(unsafe.Pointer(in.Network))
+	if in.Network != nil {
+		in, out := &in.Network, &out.Network
+		*out = new(v1alpha4.VirtualMachineNetworkSpec)
+		if err := Convert_v1alpha3_VirtualMachineNetworkSpec_To_v1alpha4_VirtualMachineNetworkSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Network = nil
+	}
 	out.PowerState = v1alpha4.VirtualMachinePowerState(in.PowerState)
 	out.PowerOffMode = v1alpha4.VirtualMachinePowerOpMode(in.PowerOffMode)
 	out.SuspendMode = v1alpha4.VirtualMachinePowerOpMode(in.SuspendMode)
@@ -3769,9 +3908,20 @@ func Convert_v1alpha3_VirtualMachineSpec_To_v1alpha4_VirtualMachineSpec(in *Virt
 }
 
 func autoConvert_v1alpha4_VirtualMachineSpec_To_v1alpha3_VirtualMachineSpec(in *v1alpha4.VirtualMachineSpec, out *VirtualMachineSpec, s conversion.Scope) error {
-	out.Cdrom = *(*[]VirtualMachineCdromSpec)(unsafe.Pointer(&in.Cdrom))
+	if in.Cdrom != nil {
+		in, out := &in.Cdrom, &out.Cdrom
+		*out = make([]VirtualMachineCdromSpec, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha4_VirtualMachineCdromSpec_To_v1alpha3_VirtualMachineCdromSpec(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Cdrom = nil
+	}
 	out.Image = (*VirtualMachineImageRef)(unsafe.Pointer(in.Image))
 	out.ImageName = in.ImageName
+	// WARNING: in.ImageChannel requires manual conversion: does not exist in peer-type
 	out.ClassName = in.ClassName
 	// WARNING: in.Class requires manual conversion: does not exist in peer-type
 	// WARNING: in.Affinity requires manual conversion: does not exist in peer-type
@@ -3786,7 +3936,15 @@ func autoConvert_v1alpha4_VirtualMachineSpec_To_v1alpha3_VirtualMachineSpec(in *
 	} else {
 		out.Bootstrap = nil
 	}
-	out.Network = (*VirtualMachineNetworkSpec)(unsafe.Pointer(in.Network))
+	if in.Network != nil {
+		in, out := &in.Network, &out.Network
+		*out = new(VirtualMachineNetworkSpec)
+		if err := Convert_v1alpha4_VirtualMachineNetworkSpec_To_v1alpha3_VirtualMachineNetworkSpec(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Network = nil
+	}
 	out.PowerState = VirtualMachinePowerState(in.PowerState)
 	out.PowerOffMode = VirtualMachinePowerOpMode(in.PowerOffMode)
 	out.SuspendMode = VirtualMachinePowerOpMode(in.SuspendMode)
@@ -3813,7 +3971,15 @@ func autoConvert_v1alpha3_VirtualMachineStatus_To_v1alpha4_VirtualMachineStatus(
 	out.PowerState = v1alpha4.VirtualMachinePowerState(in.PowerState)
 	out.Conditions = *(*[]v1.Condition)(unsafe.Pointer(&in.Conditions))
 	out.Crypto = (*v1alpha4.VirtualMachineCryptoStatus)(unsafe.Pointer(in.Crypto))
-	out.Network = (*v1alpha4.VirtualMachineNetworkStatus)(unsafe.Pointer(in.Network))
+	if in.Network != nil {
+		in, out := &in.Network, &out.Network
+		*out = new(v1alpha4.VirtualMachineNetworkStatus)
+		if err := Convert_v1alpha3_VirtualMachineNetworkStatus_To_v1alpha4_VirtualMachineNetworkStatus(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Network = nil
+	}
 	out.UniqueID = in.UniqueID
 	out.BiosUUID = in.BiosUUID
 	out.InstanceUUID = in.InstanceUUID
@@ -3846,11 +4012,20 @@ func autoConvert_v1alpha3_VirtualMachineStatus_To_v1alpha4_VirtualMachineStatus(
 
 func autoConvert_v1alpha4_VirtualMachineStatus_To_v1alpha3_VirtualMachineStatus(in *v1alpha4.VirtualMachineStatus, out *VirtualMachineStatus, s conversion.Scope) error {
 	out.Class = (*v1alpha3common.LocalObjectRef)(unsafe.Pointer(in.Class))
+	// WARNING: in.Image requires manual conversion: does not exist in peer-type
 	// WARNING: in.NodeName requires manual conversion: does not exist in peer-type
 	out.PowerState = VirtualMachinePowerState(in.PowerState)
 	out.Conditions = *(*[]v1.Condition)(unsafe.Pointer(&in.Conditions))
 	out.Crypto = (*VirtualMachineCryptoStatus)(unsafe.Pointer(in.Crypto))
-	out.Network = (*VirtualMachineNetworkStatus)(unsafe.Pointer(in.Network))
+	if in.Network != nil {
+		in, out := &in.Network, &out.Network
+		*out = new(VirtualMachineNetworkStatus)
+		if err := Convert_v1alpha4_VirtualMachineNetworkStatus_To_v1alpha3_VirtualMachineNetworkStatus(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Network = nil
+	}
 	out.UniqueID = in.UniqueID
 	out.BiosUUID = in.BiosUUID
 	out.InstanceUUID = in.InstanceUUID
@@ -3868,6 +4043,7 @@ func autoConvert_v1alpha4_VirtualMachineStatus_To_v1alpha3_VirtualMachineStatus(
 	out.ChangeBlockTracking = (*bool)(unsafe.Pointer(in.ChangeBlockTracking))
 	out.Zone = in.Zone
 	out.LastRestartTime = (*v1.Time)(unsafe.Pointer(in.LastRestartTime))
+	// WARNING: in.ObservedCustomizationGeneration requires manual conversion: does not exist in peer-type
 	out.HardwareVersion = in.HardwareVersion
 	if in.Storage != nil {
 		in, out := &in.Storage, &out.Storage
@@ -3939,7 +4115,7 @@ func Convert_v1alpha4_VirtualMachineTemplate_To_v1alpha3_VirtualMachineTemplate(
 }
 
 func autoConvert_v1alpha3_VirtualMachineTemplateSpec_To_v1alpha4_VirtualMachineTemplateSpec(in *VirtualMachineTemplateSpec, out *v1alpha4.VirtualMachineTemplateSpec, s conversion.Scope) error {
-	if err := conversionv1alpha3.Convert_common_ObjectMeta_To_common_ObjectMeta(&in.ObjectMeta, &out.ObjectMeta, s); err != nil {
+	if err := commonconversionv1alpha3.Convert_common_ObjectMeta_To_common_ObjectMeta(&in.ObjectMeta, &out.ObjectMeta, s); err != nil {
 		return err
 	}
 	if err := Convert_v1alpha3_VirtualMachineSpec_To_v1alpha4_VirtualMachineSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -3954,7 +4130,7 @@ func Convert_v1alpha3_VirtualMachineTemplateSpec_To_v1alpha4_VirtualMachineTempl
 }
 
 func autoConvert_v1alpha4_VirtualMachineTemplateSpec_To_v1alpha3_VirtualMachineTemplateSpec(in *v1alpha4.VirtualMachineTemplateSpec, out *VirtualMachineTemplateSpec, s conversion.Scope) error {
-	if err := conversionv1alpha4.Convert_common_ObjectMeta_To_common_ObjectMeta(&in.ObjectMeta, &out.ObjectMeta, s); err != nil {
+	if err := commonconversionv1alpha4.Convert_common_ObjectMeta_To_common_ObjectMeta(&in.ObjectMeta, &out.ObjectMeta, s); err != nil {
 		return err
 	}
 	if err := Convert_v1alpha4_VirtualMachineSpec_To_v1alpha3_VirtualMachineSpec(&in.Spec, &out.Spec, s); err != nil {
@@ -69,6 +69,415 @@ type CloudConfig struct {
 	// already been started. On non-systemd systems, a restart will be attempted
 	// regardless of the service state.
 	SSHPwdAuth *bool `json:"ssh_pwauth,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+
+	// BootCmd allows running one or more commands very early in the boot
+	// process, on every boot. The entries in this list adhere to the same
+	// formats as RunCmd.
+	BootCmd json.RawMessage `json:"bootcmd,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+
+	// Packages is a list of packages to install on the guest.
+	// The entries in this list can adhere to two, different formats:
+	//
+	// Format 1 -- a string that contains the name of the package, ex.
+	//
+	//     packages:
+	//     - nginx
+	//
+	// Format 2 -- a list of the name and version of the package, ex.
+	//
+	//     packages:
+	//     - - nginx
+	//       - "1.24.0"
+	Packages json.RawMessage `json:"packages,omitempty"`
+
+	// +optional
+
+	// PackageUpdate may be set to true to update the guest's package database
+	// on first boot.
+	//
+	// Please note the package database is always updated if Packages is not
+	// empty.
+	PackageUpdate *bool `json:"package_update,omitempty"`
+
+	// +optional
+
+	// PackageUpgrade may be set to true to upgrade the guest's installed
+	// packages on first boot.
+	PackageUpgrade *bool `json:"package_upgrade,omitempty"`
+
+	// +optional
+
+	// PackageRebootIfRequired may be set to true to reboot the guest if it is
+	// required after packages are upgraded or installed.
+	PackageRebootIfRequired *bool `json:"package_reboot_if_required,omitempty"`
+
+	// +optional
+
+	// Apt configures the APT package manager on Debian-based guests.
+	Apt *Apt `json:"apt,omitempty"`
+
+	// +optional
+	// +listType=map
+	// +listMapKey=id
+
+	// YumRepos allows adding repositories to the YUM package manager on
+	// RHEL-based guests.
+	YumRepos []YumRepo `json:"yum_repos,omitempty"`
+
+	// +optional
+
+	// NTP configures the guest's NTP client.
+	NTP *NTP `json:"ntp,omitempty"`
+
+	// +optional
+
+	// CACerts allows adding trusted CA certificates to the guest.
+	CACerts *CACerts `json:"ca_certs,omitempty"`
+
+	// +optional
+	// +listType=map
+	// +listMapKey=device
+
+	// DiskSetup allows partitioning the guest's disks.
+	DiskSetup []DiskSetup `json:"disk_setup,omitempty"`
+
+	// +optional
+
+	// FSSetup allows creating filesystems on the guest's disks and partitions.
+	FSSetup []FSSetup `json:"fs_setup,omitempty"`
+
+	// +optional
+
+	// Mounts is a list of mount points to add to the guest's /etc/fstab.
+	// Each entry is a list of the values of the fields from /etc/fstab, ex.
+	//
+	//     mounts:
+	//     - - /dev/sdb1
+	//       - /data
+	//       - ext4
+	//       - defaults,nofail
+	//       - "0"
+	//       - "2"
+	//
+	// Please note at least the first two values must be specified. The
+	// remaining values default to those from Cloud-Init's mount_default_fields.
+	Mounts [][]string `json:"mounts,omitempty"`
+
+	// +optional
+
+	// PowerState allows powering off or rebooting the guest after Cloud-Init
+	// has finished.
+	PowerState *PowerState `json:"power_state,omitempty"`
+}
+
+// Apt is a CloudConfig apt data structure.
+type Apt struct {
+	// +optional
+
+	// PreserveSourcesList may be set to true to preserve the guest's existing
+	// sources list instead of generating a new one.
+	PreserveSourcesList *bool `json:"preserve_sources_list,omitempty"`
+
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+
+	// Sources is a list of additional APT sources.
+	Sources []AptSource `json:"sources,omitempty"`
+}
+
+// AptSource is an additional source for the APT package manager.
+type AptSource struct {
+	// +optional
+
+	// Filename is the name of the file in /etc/apt/sources.list.d to which
+	// the source is written.
+	//
+	// Defaults to the value of the Name field with a ".list" suffix.
+	Filename string `json:"filename,omitempty"`
+
+	// +optional
+
+	// Key is a raw PGP key used to verify the source.
+	Key string `json:"key,omitempty"`
+
+	// +optional
+
+	// KeyID is the ID of a PGP key to import from KeyServer and used to verify
+	// the source.
+	KeyID string `json:"keyid,omitempty"`
+
+	// +optional
+
+	// KeyServer is the server from which the key specified by KeyID is
+	// imported.
+	KeyServer string `json:"keyserver,omitempty"`
+
+	// Name is the unique name of the source.
+	Name string `json:"name"`
+
+	// +optional
+
+	// Source is the sources.list entry for the source, ex.
+	// "deb http://archive.example.com $RELEASE main".
+	//
+	// When omitted, only the key is imported.
+	Source string `json:"source,omitempty"`
+}
+
+// YumRepo is a CloudConfig yum_repos data structure.
+type YumRepo struct {
+	// +optional
+
+	// BaseURL is the URL of the repository.
+	//
+	// Please note one of BaseURL, MetaLink or MirrorList is required.
+	BaseURL string `json:"baseurl,omitempty"`
+
+	// +optional
+
+	// Enabled may be set to false to disable the repository.
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// +optional
+
+	// GPGCheck may be set to true to verify the repository's packages.
+	GPGCheck *bool `json:"gpgcheck,omitempty"`
+
+	// +optional
+
+	// GPGKey is the URL of the key used to verify the repository's packages.
+	GPGKey string `json:"gpgkey,omitempty"`
+
+	// +kubebuilder:validation:Pattern="^[0-9a-zA-Z:._-]+$"
+
+	// ID is the unique ID of the repository, used when writing
+	// /etc/yum.repos.d/<id>.repo.
+	ID string `json:"id"`
+
+	// +optional
+
+	// MetaLink is the URL of a metalink file for the repository.
+	MetaLink string `json:"metalink,omitempty"`
+
+	// +optional
+
+	// MirrorList is the URL of a file that contains a list of the
+	// repository's base URLs.
+	MirrorList string `json:"mirrorlist,omitempty"`
+
+	// +optional
+
+	// Name is the human-readable name of the repository.
+	//
+	// Defaults to the value of the ID field.
+	Name string `json:"name,omitempty"`
+}
+
+// NTP is a CloudConfig ntp data structure.
+type NTP struct {
+	// +optional
+
+	// Enabled may be set to false to prevent the NTP client from being
+	// configured or installed.
+	Enabled *bool `json:"enabled,omitempty"`
+
+	// +optional
+
+	// NTPClient is the name of the NTP client to configure, ex. chrony, ntp,
+	// openntpd, ntpdate or systemd-timesyncd.
+	//
+	// When omitted the guest's preferred client is used.
+	NTPClient string `json:"ntp_client,omitempty"`
+
+	// +optional
+
+	// Pools is a list of NTP pools.
+	Pools []string `json:"pools,omitempty"`
+
+	// +optional
+
+	// Servers is a list of NTP servers.
+	Servers []string `json:"servers,omitempty"`
+}
+
+// CACerts is a CloudConfig ca_certs data structure.
+type CACerts struct {
+	// +optional
+
+	// RemoveDefaults may be set to true to remove the guest's default trusted
+	// CA certificates.
+	RemoveDefaults *bool `json:"remove_defaults,omitempty"`
+
+	// +optional
+
+	// Trusted is a list of CA certificates to add to the guest's trust store.
+	Trusted []CACert `json:"trusted,omitempty"`
+}
+
+// CACert describes a PEM-encoded CA certificate from a Secret resource, a
+// ConfigMap resource, or a value directly in this object.
+//
+// Please note exactly one of the fields must be specified.
+type CACert struct {
+	// +optional
+
+	// ConfigMap is specified to reference a certificate from a ConfigMap
+	// resource.
+	ConfigMap *vmopv1common.ConfigMapKeySelector `json:"configMap,omitempty"`
+
+	// +optional
+
+	// Secret is specified to reference a certificate from a Secret resource.
+	Secret *vmopv1common.SecretKeySelector `json:"secret,omitempty"`
+
+	// +optional
+
+	// Value is used to directly specify a certificate.
+	Value string `json:"value,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=mbr;gpt
+
+// DiskSetupTableType specifies the type of a disk's partition table.
+type DiskSetupTableType string
+
+const (
+	DiskSetupTableTypeMBR DiskSetupTableType = "mbr"
+	DiskSetupTableTypeGPT DiskSetupTableType = "gpt"
+)
+
+// DiskSetup is a CloudConfig disk_setup data structure.
+type DiskSetup struct {
+	// Device is the path or alias of the disk to partition, ex. /dev/sdb.
+	Device string `json:"device"`
+
+	// +optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:pruning:PreserveUnknownFields
+
+	// Layout describes the partitions to create on the disk.
+	// The value for this field can adhere to two, different formats:
+	//
+	// Format 1 -- a boolean, where true creates a single partition that spans
+	//             the entire disk, ex.
+	//
+	//     layout: true
+	//
+	// Format 2 -- a list of partitions, where each partition is either the
+	//             percentage of the disk it uses or a list of the percentage
+	//             and the partition type, ex.
+	//
+	//     layout:
+	//     - 33
+	//     - - 66
+	//       - 82
+	Layout json.RawMessage `json:"layout,omitempty"`
+
+	// +optional
+
+	// Overwrite may be set to true to partition the disk even if it already
+	// has a partition table or filesystem.
+	//
+	// Please note this is dangerous and can lead to data loss.
+	Overwrite *bool `json:"overwrite,omitempty"`
+
+	// +optional
+	// +kubebuilder:default=mbr
+
+	// TableType is the type of partition table to create.
+	TableType DiskSetupTableType `json:"table_type,omitempty"`
+}
+
+// FSSetup is a CloudConfig fs_setup data structure.
+type FSSetup struct {
+	// Device is the path or alias of the device on which to create the
+	// filesystem, ex. /dev/sdb or ephemeral0.1.
+	Device string `json:"device"`
+
+	// +optional
+
+	// ExtraOpts is a list of additional options to pass to the command that
+	// creates the filesystem.
+	ExtraOpts []string `json:"extra_opts,omitempty"`
+
+	// Filesystem is the type of filesystem to create, ex. ext4 or xfs.
+	Filesystem string `json:"filesystem"`
+
+	// +optional
+
+	// Label is the filesystem's label.
+	Label string `json:"label,omitempty"`
+
+	// +optional
+
+	// Overwrite may be set to true to create the filesystem even if one
+	// already exists.
+	//
+	// Please note this is dangerous and can lead to data loss.
+	Overwrite *bool `json:"overwrite,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Pattern="^([1-9]|auto|any|none)$"
+
+	// Partition is the number of the partition on which to create the
+	// filesystem, or one of "auto", "any" or "none".
+	Partition string `json:"partition,omitempty"`
+
+	// +optional
+
+	// ReplaceFS is the type of an existing filesystem that may be replaced
+	// when Partition is "auto" or "any".
+	ReplaceFS string `json:"replace_fs,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=poweroff;reboot;halt
+
+// PowerStateMode specifies the power state to which the guest transitions.
+type PowerStateMode string
+
+const (
+	PowerStateModePowerOff PowerStateMode = "poweroff"
+	PowerStateModeReboot   PowerStateMode = "reboot"
+	PowerStateModeHalt     PowerStateMode = "halt"
+)
+
+// PowerState is a CloudConfig power_state data structure.
+type PowerState struct {
+	// +optional
+	// +kubebuilder:validation:Pattern="^(now|[0-9]+)$"
+
+	// Delay is either "now" or the number of minutes to wait after Cloud-Init
+	// has finished before changing the power state, ex. "5".
+	//
+	// Defaults to "now".
+	Delay string `json:"delay,omitempty"`
+
+	// +optional
+
+	// Message is an optional message to display to logged in users prior to
+	// the power state change.
+	Message string `json:"message,omitempty"`
+
+	// Mode is the power state to which the guest transitions.
+	Mode PowerStateMode `json:"mode"`
+
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+
+	// Timeout is the number of seconds to wait for Cloud-Init to finish before
+	// changing the power state.
+	//
+	// Defaults to 30.
+	Timeout *int64 `json:"timeout,omitempty"`
 }
 
 // User is a CloudConfig user data structure.
@@ -13,6 +13,98 @@ import (
 	"github.com/vmware-tanzu/vm-operator/api/v1alpha4/common"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Apt) DeepCopyInto(out *Apt) {
+	*out = *in
+	if in.PreserveSourcesList != nil {
+		in, out := &in.PreserveSourcesList, &out.PreserveSourcesList
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Sources != nil {
+		in, out := &in.Sources, &out.Sources
+		*out = make([]AptSource, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Apt.
+func (in *Apt) DeepCopy() *Apt {
+	if in == nil {
+		return nil
+	}
+	out := new(Apt)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AptSource) DeepCopyInto(out *AptSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AptSource.
+func (in *AptSource) DeepCopy() *AptSource {
+	if in == nil {
+		return nil
+	}
+	out := new(AptSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CACert) DeepCopyInto(out *CACert) {
+	*out = *in
+	if in.ConfigMap != nil {
+		in, out := &in.ConfigMap, &out.ConfigMap
+		*out = new(common.ConfigMapKeySelector)
+		**out = **in
+	}
+	if in.Secret != nil {
+		in, out := &in.Secret, &out.Secret
+		*out = new(common.SecretKeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CACert.
+func (in *CACert) DeepCopy() *CACert {
+	if in == nil {
+		return nil
+	}
+	out := new(CACert)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CACerts) DeepCopyInto(out *CACerts) {
+	*out = *in
+	if in.RemoveDefaults != nil {
+		in, out := &in.RemoveDefaults, &out.RemoveDefaults
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Trusted != nil {
+		in, out := &in.Trusted, &out.Trusted
+		*out = make([]CACert, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CACerts.
+func (in *CACerts) DeepCopy() *CACerts {
+	if in == nil {
+		return nil
+	}
+	out := new(CACerts)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CloudConfig) DeepCopyInto(out *CloudConfig) {
 	*out = *in
@@ -40,6 +132,83 @@ func (in *CloudConfig) DeepCopyInto(out *CloudConfig) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.BootCmd != nil {
+		in, out := &in.BootCmd, &out.BootCmd
+		*out = make(json.RawMessage, len(*in))
+		copy(*out, *in)
+	}
+	if in.Packages != nil {
+		in, out := &in.Packages, &out.Packages
+		*out = make(json.RawMessage, len(*in))
+		copy(*out, *in)
+	}
+	if in.PackageUpdate != nil {
+		in, out := &in.PackageUpdate, &out.PackageUpdate
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PackageUpgrade != nil {
+		in, out := &in.PackageUpgrade, &out.PackageUpgrade
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PackageRebootIfRequired != nil {
+		in, out := &in.PackageRebootIfRequired, &out.PackageRebootIfRequired
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Apt != nil {
+		in, out := &in.Apt, &out.Apt
+		*out = new(Apt)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.YumRepos != nil {
+		in, out := &in.YumRepos, &out.YumRepos
+		*out = make([]YumRepo, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NTP != nil {
+		in, out := &in.NTP, &out.NTP
+		*out = new(NTP)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CACerts != nil {
+		in, out := &in.CACerts, &out.CACerts
+		*out = new(CACerts)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DiskSetup != nil {
+		in, out := &in.DiskSetup, &out.DiskSetup
+		*out = make([]DiskSetup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.FSSetup != nil {
+		in, out := &in.FSSetup, &out.FSSetup
+		*out = make([]FSSetup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Mounts != nil {
+		in, out := &in.Mounts, &out.Mounts
+		*out = make([][]string, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = make([]string, len(*in))
+				copy(*out, *in)
+			}
+		}
+	}
+	if in.PowerState != nil {
+		in, out := &in.PowerState, &out.PowerState
+		*out = new(PowerState)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CloudConfig.
@@ -52,6 +221,106 @@ func (in *CloudConfig) DeepCopy() *CloudConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *DiskSetup) DeepCopyInto(out *DiskSetup) {
+	*out = *in
+	if in.Layout != nil {
+		in, out := &in.Layout, &out.Layout
+		*out = make(json.RawMessage, len(*in))
+		copy(*out, *in)
+	}
+	if in.Overwrite != nil {
+		in, out := &in.Overwrite, &out.Overwrite
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DiskSetup.
+func (in *DiskSetup) DeepCopy() *DiskSetup {
+	if in == nil {
+		return nil
+	}
+	out := new(DiskSetup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FSSetup) DeepCopyInto(out *FSSetup) {
+	*out = *in
+	if in.ExtraOpts != nil {
+		in, out := &in.ExtraOpts, &out.ExtraOpts
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Overwrite != nil {
+		in, out := &in.Overwrite, &out.Overwrite
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FSSetup.
+func (in *FSSetup) DeepCopy() *FSSetup {
+	if in == nil {
+		return nil
+	}
+	out := new(FSSetup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NTP) DeepCopyInto(out *NTP) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.Pools != nil {
+		in, out := &in.Pools, &out.Pools
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Servers != nil {
+		in, out := &in.Servers, &out.Servers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NTP.
+func (in *NTP) DeepCopy() *NTP {
+	if in == nil {
+		return nil
+	}
+	out := new(NTP)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PowerState) DeepCopyInto(out *PowerState) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(int64)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PowerState.
+func (in *PowerState) DeepCopy() *PowerState {
+	if in == nil {
+		return nil
+	}
+	out := new(PowerState)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *User) DeepCopyInto(out *User) {
 	*out = *in
@@ -196,3 +465,28 @@ func (in *WriteFile) DeepCopy() *WriteFile {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *YumRepo) DeepCopyInto(out *YumRepo) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.GPGCheck != nil {
+		in, out := &in.GPGCheck, &out.GPGCheck
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new YumRepo.
+func (in *YumRepo) DeepCopy() *YumRepo {
+	if in == nil {
+		return nil
+	}
+	out := new(YumRepo)
+	in.DeepCopyInto(out)
+	return out
+}
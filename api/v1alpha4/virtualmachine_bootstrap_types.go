@@ -81,6 +81,38 @@ type VirtualMachineBootstrapSpec struct {
 	// This bootstrap provider may not be used in conjunction with the CloudInit
 	// bootstrap provider.
 	VAppConfig *VirtualMachineBootstrapVAppConfigSpec `json:"vAppConfig,omitempty"`
+
+	// +optional
+
+	// Ignition may be used to bootstrap guests that use Ignition, such as
+	// Fedora CoreOS and Flatcar Container Linux.
+	//
+	// The guest's networking stack is configured in the initramfs with kernel
+	// arguments that are generated from the VM's network configuration.
+	//
+	// Please note this bootstrap provider may not be used in conjunction with
+	// the other bootstrap providers.
+	Ignition *VirtualMachineBootstrapIgnitionSpec `json:"ignition,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+
+	// CustomizationGeneration may be incremented to re-customize the guest of
+	// an existing VM, ex. to change its IP addresses or host name.
+	//
+	// When this value is greater than status.observedCustomizationGeneration,
+	// the VM is powered off, the bootstrap provider is re-run with the VM's
+	// current bootstrap and network configuration, and the VM is powered on
+	// again if spec.powerState is PoweredOn. The progress of the guest
+	// customization is reported by the GuestCustomization condition.
+	//
+	// Please note this value may not be decreased. Changes to other bootstrap
+	// fields are only allowed while the VM is powered on if this value is
+	// incremented as part of the same update.
+	//
+	// When the CloudInit bootstrap provider is used, this value is appended
+	// to the Cloud-Init instance ID so Cloud-Init runs again in the guest.
+	CustomizationGeneration int64 `json:"customizationGeneration,omitempty"`
 }
 
 // VirtualMachineBootstrapCloudInitSpec describes the CloudInit configuration
@@ -156,6 +188,33 @@ type VirtualMachineBootstrapCloudInitSpec struct {
 	WaitOnNetwork6 *bool `json:"waitOnNetwork6,omitempty"`
 }
 
+// VirtualMachineBootstrapIgnitionSpec describes the Ignition configuration
+// used to bootstrap the VM.
+type VirtualMachineBootstrapIgnitionSpec struct {
+	// +optional
+
+	// Config is an inline Ignition config, specified as either JSON or YAML,
+	// used to bootstrap the VM.
+	//
+	// A Butane config with the fcos or flatcar variant may also be specified
+	// and is translated to an Ignition config. Butane features that require
+	// local files, ex. storage.trees, are not supported.
+	//
+	// Please note this field and RawConfig are mutually exclusive.
+	Config string `json:"config,omitempty"`
+
+	// +optional
+
+	// RawConfig describes a key in a Secret resource that contains the
+	// Ignition config used to bootstrap the VM.
+	//
+	// The Ignition or Butane config specified by the key may be plain-text,
+	// base64-encoded, or gzipped and base64-encoded.
+	//
+	// Please note this field and Config are mutually exclusive.
+	RawConfig *vmopv1common.SecretKeySelector `json:"rawConfig,omitempty"`
+}
+
 // VirtualMachineBootstrapLinuxPrepSpec describes the LinuxPrep configuration
 // used to bootstrap the VM.
 type VirtualMachineBootstrapLinuxPrepSpec struct {
@@ -5,6 +5,7 @@
 package v1alpha4
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	vmopv1common "github.com/vmware-tanzu/vm-operator/api/v1alpha4/common"
@@ -25,6 +26,181 @@ type VirtualMachineNetworkRouteSpec struct {
 	Metric int32 `json:"metric,omitempty"`
 }
 
+// +kubebuilder:validation:Enum=vmxnet3;e1000e;sriov;dvx
+
+// VirtualMachineNetworkInterfaceType describes the type of the virtual network
+// adapter for a VM's network interface.
+type VirtualMachineNetworkInterfaceType string
+
+const (
+	// VirtualMachineNetworkInterfaceTypeVmxnet3 is the paravirtualized vmxnet3
+	// network adapter.
+	VirtualMachineNetworkInterfaceTypeVmxnet3 VirtualMachineNetworkInterfaceType = "vmxnet3"
+
+	// VirtualMachineNetworkInterfaceTypeE1000e is the emulated Intel 82574
+	// network adapter.
+	VirtualMachineNetworkInterfaceTypeE1000e VirtualMachineNetworkInterfaceType = "e1000e"
+
+	// VirtualMachineNetworkInterfaceTypeSRIOV is an SR-IOV passthrough network
+	// adapter backed by a virtual function of a physical network adapter.
+	VirtualMachineNetworkInterfaceTypeSRIOV VirtualMachineNetworkInterfaceType = "sriov"
+
+	// VirtualMachineNetworkInterfaceTypeDVX is a DirectPath network adapter
+	// that uses Device Virtualization Extensions (DVX).
+	VirtualMachineNetworkInterfaceTypeDVX VirtualMachineNetworkInterfaceType = "dvx"
+)
+
+// VirtualMachineNetworkInterfaceSRIOVSpec describes the SR-IOV configuration
+// of a VM's network interface.
+type VirtualMachineNetworkInterfaceSRIOVSpec struct {
+	// +optional
+
+	// PhysicalFunction is the PCI ID, ex. 0000:3b:00.1, of the physical
+	// network adapter whose virtual function backs this interface.
+	//
+	// If omitted, a physical function is automatically assigned from the
+	// SR-IOV device pool associated with the interface's network when the VM
+	// is powered on.
+	PhysicalFunction string `json:"physicalFunction,omitempty"`
+
+	// +optional
+
+	// AllowGuestOSMTUChange indicates whether the guest OS may change the MTU
+	// of this interface.
+	AllowGuestOSMTUChange *bool `json:"allowGuestOSMTUChange,omitempty"`
+}
+
+// VirtualMachineNetworkInterfaceDVXSpec describes the DVX configuration of a
+// VM's network interface.
+type VirtualMachineNetworkInterfaceDVXSpec struct {
+	// DeviceClass is the name of the DVX device class that backs this
+	// interface.
+	DeviceClass string `json:"deviceClass"`
+
+	// +optional
+	// +listType=map
+	// +listMapKey=key
+
+	// ConfigParams is an optional list of configuration parameters passed to
+	// the DVX device.
+	ConfigParams []vmopv1common.KeyValuePair `json:"configParams,omitempty"`
+}
+
+// VirtualMachineNetworkInterfaceQoS describes the bandwidth allocation of a
+// VM's network interface.
+//
+// Please note the allocation is enforced only when Network I/O Control is
+// enabled on the distributed switch to which the interface is connected.
+type VirtualMachineNetworkInterfaceQoS struct {
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+
+	// Reservation is the guaranteed bandwidth of the interface in Mbits/sec.
+	Reservation *int64 `json:"reservation,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+
+	// Limit is the maximum bandwidth of the interface in Mbits/sec. If
+	// omitted, the bandwidth is unlimited.
+	Limit *int64 `json:"limit,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+
+	// Shares is the relative priority of the interface when the physical
+	// uplink is contended. If omitted, the normal level of shares is used.
+	Shares *int32 `json:"shares,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=Allow;Deny
+
+// VirtualMachineNetworkInterfaceTrafficRuleAction describes the action taken
+// for the traffic that matches a rule.
+type VirtualMachineNetworkInterfaceTrafficRuleAction string
+
+const (
+	// VirtualMachineNetworkInterfaceTrafficRuleActionAllow allows the traffic.
+	VirtualMachineNetworkInterfaceTrafficRuleActionAllow VirtualMachineNetworkInterfaceTrafficRuleAction = "Allow"
+
+	// VirtualMachineNetworkInterfaceTrafficRuleActionDeny drops the traffic.
+	VirtualMachineNetworkInterfaceTrafficRuleActionDeny VirtualMachineNetworkInterfaceTrafficRuleAction = "Deny"
+)
+
+// +kubebuilder:validation:Enum=Ingress;Egress;Both
+
+// VirtualMachineNetworkInterfaceTrafficRuleDirection describes the direction
+// of the traffic to which a rule applies.
+type VirtualMachineNetworkInterfaceTrafficRuleDirection string
+
+const (
+	// VirtualMachineNetworkInterfaceTrafficRuleDirectionIngress is the traffic
+	// received by the interface.
+	VirtualMachineNetworkInterfaceTrafficRuleDirectionIngress VirtualMachineNetworkInterfaceTrafficRuleDirection = "Ingress"
+
+	// VirtualMachineNetworkInterfaceTrafficRuleDirectionEgress is the traffic
+	// sent by the interface.
+	VirtualMachineNetworkInterfaceTrafficRuleDirectionEgress VirtualMachineNetworkInterfaceTrafficRuleDirection = "Egress"
+
+	// VirtualMachineNetworkInterfaceTrafficRuleDirectionBoth is the traffic
+	// received and sent by the interface.
+	VirtualMachineNetworkInterfaceTrafficRuleDirectionBoth VirtualMachineNetworkInterfaceTrafficRuleDirection = "Both"
+)
+
+// +kubebuilder:validation:Enum=TCP;UDP;ICMP
+
+// VirtualMachineNetworkInterfaceTrafficRuleProtocol describes the IP protocol
+// to which a rule applies.
+type VirtualMachineNetworkInterfaceTrafficRuleProtocol string
+
+const (
+	VirtualMachineNetworkInterfaceTrafficRuleProtocolTCP  VirtualMachineNetworkInterfaceTrafficRuleProtocol = "TCP"
+	VirtualMachineNetworkInterfaceTrafficRuleProtocolUDP  VirtualMachineNetworkInterfaceTrafficRuleProtocol = "UDP"
+	VirtualMachineNetworkInterfaceTrafficRuleProtocolICMP VirtualMachineNetworkInterfaceTrafficRuleProtocol = "ICMP"
+)
+
+// VirtualMachineNetworkInterfaceTrafficRule describes a rule that allows or
+// denies the traffic of a VM's network interface.
+type VirtualMachineNetworkInterfaceTrafficRule struct {
+	// Action is the action taken for the traffic that matches this rule.
+	Action VirtualMachineNetworkInterfaceTrafficRuleAction `json:"action"`
+
+	// +optional
+
+	// Direction is the direction of the traffic to which this rule applies.
+	// If omitted, the rule applies to the traffic in both directions.
+	Direction VirtualMachineNetworkInterfaceTrafficRuleDirection `json:"direction,omitempty"`
+
+	// +optional
+
+	// SourceCIDR is the IP4 or IP6 network, ex. 192.168.0.0/24, from which
+	// the traffic originates. If omitted, any source matches.
+	SourceCIDR string `json:"sourceCIDR,omitempty"`
+
+	// +optional
+
+	// DestinationCIDR is the IP4 or IP6 network, ex. 192.168.0.0/24, to which
+	// the traffic is sent. If omitted, any destination matches.
+	DestinationCIDR string `json:"destinationCIDR,omitempty"`
+
+	// +optional
+
+	// Protocol is the IP protocol of the traffic. If omitted, any protocol
+	// matches.
+	Protocol VirtualMachineNetworkInterfaceTrafficRuleProtocol `json:"protocol,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=65535
+
+	// DestinationPort is the TCP or UDP port to which the traffic is sent. If
+	// omitted, any port matches.
+	//
+	// Please note this field requires Protocol to be TCP or UDP.
+	DestinationPort *int32 `json:"destinationPort,omitempty"`
+}
+
 // VirtualMachineNetworkInterfaceSpec describes the desired state of a VM's
 // network interface.
 type VirtualMachineNetworkInterfaceSpec struct {
@@ -59,6 +235,42 @@ type VirtualMachineNetworkInterfaceSpec struct {
 
 	// +optional
 
+	// MacAddress describes the MAC address of this interface's network
+	// adapter, ex. 00:50:56:00:00:01.
+	//
+	// If omitted, the MAC address assigned by the network provider is used
+	// when there is one, otherwise the MAC address is generated by vSphere.
+	//
+	// Please note a MAC address assigned by the network provider takes
+	// precedence over this field, and this field may only be set by
+	// privileged users.
+	MacAddress string `json:"macAddress,omitempty"`
+
+	// +optional
+
+	// Type is the type of the virtual network adapter for this interface.
+	//
+	// If omitted, the network adapter from the VM Class is used when one is
+	// available for this interface, otherwise a vmxnet3 adapter is created.
+	Type VirtualMachineNetworkInterfaceType `json:"type,omitempty"`
+
+	// +optional
+
+	// SRIOV describes the SR-IOV configuration for this interface.
+	//
+	// Please note this field may only be set when Type is sriov.
+	SRIOV *VirtualMachineNetworkInterfaceSRIOVSpec `json:"sriov,omitempty"`
+
+	// +optional
+
+	// DVX describes the DVX configuration for this interface.
+	//
+	// Please note this field is required when Type is dvx, and may not be set
+	// otherwise.
+	DVX *VirtualMachineNetworkInterfaceDVXSpec `json:"dvx,omitempty"`
+
+	// +optional
+
 	// Addresses is an optional list of IP4 or IP6 addresses to assign to this
 	// interface.
 	//
@@ -72,6 +284,25 @@ type VirtualMachineNetworkInterfaceSpec struct {
 	// to true or IP6 addresses if DHCP6 is set to true.
 	Addresses []string `json:"addresses,omitempty"`
 
+	// +optional
+	// +kubebuilder:validation:MaxItems=2
+
+	// AddressesFromPools is an optional list of references to IP pools from
+	// which IP4 and/or IP6 addresses are allocated for this interface.
+	//
+	// For each referenced pool, an IPAddressClaim that adheres to the Cluster
+	// API IPAM contract is created in the VM's namespace. The address and
+	// gateway from the resulting IPAddress are assigned to this interface.
+	// The claims are released when the VM is deleted.
+	//
+	// Please note this field is only supported with the following network
+	// providers: VSPHERE_NETWORK and NAMED.
+	//
+	// Please note this field is mutually exclusive with the Addresses field.
+	//
+	// Please note this field may not be used if DHCP4 or DHCP6 is set to true.
+	AddressesFromPools []corev1.TypedLocalObjectReference `json:"addressesFromPools,omitempty"`
+
 	// +optional
 
 	// DHCP4 indicates whether or not this interface uses DHCP for IP4
@@ -172,6 +403,193 @@ type VirtualMachineNetworkInterfaceSpec struct {
 	// or true, if search domains is not provided, the global search domains
 	// will be used instead.
 	SearchDomains []string `json:"searchDomains,omitempty"`
+
+	// +optional
+
+	// QoS describes the bandwidth allocation of this interface.
+	//
+	// When omitted, the interface keeps the bandwidth allocation specified by
+	// the VM class, if any. When a QoS is removed from the interface, the
+	// interface's bandwidth allocation is reset to the defaults.
+	QoS *VirtualMachineNetworkInterfaceQoS `json:"qos,omitempty"`
+
+	// +optional
+
+	// TrafficRules is an optional, ordered list of rules that allow or deny
+	// the traffic of this interface. The first rule that matches the traffic
+	// determines the action taken.
+	//
+	// Please note this field is only supported with the following network
+	// providers: VSPHERE_NETWORK.
+	TrafficRules []VirtualMachineNetworkInterfaceTrafficRule `json:"trafficRules,omitempty"`
+}
+
+// +kubebuilder:validation:Enum=balance-rr;active-backup;balance-xor;broadcast;"802.3ad";balance-tlb;balance-alb
+
+// VirtualMachineNetworkBondMode describes the bonding mode of a guest-side
+// bond interface.
+type VirtualMachineNetworkBondMode string
+
+const (
+	VirtualMachineNetworkBondModeBalanceRR    VirtualMachineNetworkBondMode = "balance-rr"
+	VirtualMachineNetworkBondModeActiveBackup VirtualMachineNetworkBondMode = "active-backup"
+	VirtualMachineNetworkBondModeBalanceXOR   VirtualMachineNetworkBondMode = "balance-xor"
+	VirtualMachineNetworkBondModeBroadcast    VirtualMachineNetworkBondMode = "broadcast"
+	VirtualMachineNetworkBondMode8023AD       VirtualMachineNetworkBondMode = "802.3ad"
+	VirtualMachineNetworkBondModeBalanceTLB   VirtualMachineNetworkBondMode = "balance-tlb"
+	VirtualMachineNetworkBondModeBalanceALB   VirtualMachineNetworkBondMode = "balance-alb"
+)
+
+// VirtualMachineNetworkGuestDeviceIPSpec describes the IP configuration of a
+// guest-side device, such as a bond, VLAN, or bridge.
+type VirtualMachineNetworkGuestDeviceIPSpec struct {
+	// +optional
+
+	// Addresses is an optional list of IP4 or IP6 addresses to assign to this
+	// device.
+	//
+	// Please note IP4 and IP6 addresses must include the network prefix length,
+	// ex. 192.168.0.10/24 or 2001:db8:101::a/64.
+	//
+	// Please note this field may not contain IP4 addresses if DHCP4 is set
+	// to true or IP6 addresses if DHCP6 is set to true.
+	Addresses []string `json:"addresses,omitempty"`
+
+	// +optional
+
+	// DHCP4 indicates whether or not this device uses DHCP for IP4 networking.
+	DHCP4 bool `json:"dhcp4,omitempty"`
+
+	// +optional
+
+	// DHCP6 indicates whether or not this device uses DHCP for IP6 networking.
+	DHCP6 bool `json:"dhcp6,omitempty"`
+
+	// +optional
+
+	// Gateway4 is the default, IP4 gateway for this device.
+	//
+	// Please note this field is mutually exclusive with DHCP4.
+	Gateway4 string `json:"gateway4,omitempty"`
+
+	// +optional
+
+	// Gateway6 is the primary IP6 gateway for this device.
+	//
+	// Please note this field is mutually exclusive with DHCP6.
+	Gateway6 string `json:"gateway6,omitempty"`
+
+	// +optional
+
+	// MTU is the Maximum Transmission Unit size in bytes.
+	MTU *int64 `json:"mtu,omitempty"`
+
+	// +optional
+
+	// Nameservers is a list of IP4 and/or IP6 addresses used as DNS
+	// nameservers.
+	//
+	// Please note that Linux allows only three nameservers
+	// (https://linux.die.net/man/5/resolv.conf).
+	Nameservers []string `json:"nameservers,omitempty"`
+
+	// +optional
+
+	// Routes is a list of optional, static routes.
+	Routes []VirtualMachineNetworkRouteSpec `json:"routes,omitempty"`
+
+	// +optional
+
+	// SearchDomains is a list of search domains used when resolving IP
+	// addresses with DNS.
+	SearchDomains []string `json:"searchDomains,omitempty"`
+}
+
+// VirtualMachineNetworkBondSpec describes a guest-side bond interface that
+// aggregates two or more of a VM's network interfaces.
+type VirtualMachineNetworkBondSpec struct {
+	// +kubebuilder:validation:Pattern=^\w\w+$
+
+	// Name is the name of the bond device inside the guest, ex. bond0.
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:MinItems=2
+
+	// Interfaces is the list of the names of the network interfaces from
+	// spec.network.interfaces that are members of this bond.
+	//
+	// Please note the IP configuration of a member interface is ignored, since
+	// the bond is configured instead.
+	Interfaces []string `json:"interfaces"`
+
+	// +optional
+
+	// Mode is the bonding mode. If omitted, the guest's default bonding mode
+	// is used, which is typically balance-rr.
+	Mode VirtualMachineNetworkBondMode `json:"mode,omitempty"`
+
+	// +optional
+
+	// Primary is the name of the member interface that is preferred when the
+	// mode is active-backup, balance-alb or balance-tlb.
+	Primary string `json:"primary,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+
+	// MIIMonitorInterval is the interval in milliseconds at which the link
+	// state of the member interfaces is checked. A value of zero disables the
+	// monitoring.
+	MIIMonitorInterval *int64 `json:"miiMonitorInterval,omitempty"`
+
+	VirtualMachineNetworkGuestDeviceIPSpec `json:",inline"`
+}
+
+// VirtualMachineNetworkVLANSpec describes a guest-side VLAN sub-interface.
+type VirtualMachineNetworkVLANSpec struct {
+	// +kubebuilder:validation:Pattern=^\w[\w.]+$
+
+	// Name is the name of the VLAN device inside the guest, ex. eth0.100.
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=4094
+
+	// ID is the VLAN ID.
+	ID int64 `json:"id"`
+
+	// Link is the name of the network interface from spec.network.interfaces,
+	// or the name of the bond from spec.network.bonds, on which this VLAN is
+	// created.
+	Link string `json:"link"`
+
+	VirtualMachineNetworkGuestDeviceIPSpec `json:",inline"`
+}
+
+// VirtualMachineNetworkBridgeSpec describes a guest-side bridge.
+type VirtualMachineNetworkBridgeSpec struct {
+	// +kubebuilder:validation:Pattern=^\w\w+$
+
+	// Name is the name of the bridge device inside the guest, ex. br0.
+	Name string `json:"name"`
+
+	// +kubebuilder:validation:MinItems=1
+
+	// Interfaces is the list of the names of the network interfaces from
+	// spec.network.interfaces, bonds from spec.network.bonds, or VLANs from
+	// spec.network.vlans that are members of this bridge.
+	//
+	// Please note the IP configuration of a member interface is ignored, since
+	// the bridge is configured instead.
+	Interfaces []string `json:"interfaces"`
+
+	// +optional
+
+	// STP indicates whether or not the bridge uses the Spanning Tree Protocol.
+	// If omitted, the guest's default is used.
+	STP *bool `json:"stp,omitempty"`
+
+	VirtualMachineNetworkGuestDeviceIPSpec `json:",inline"`
 }
 
 // VirtualMachineNetworkSpec defines a VM's desired network configuration.
@@ -285,6 +703,41 @@ type VirtualMachineNetworkSpec struct {
 	// The maximum number of network interface allowed is 10 because a vSphere
 	// virtual machine may not have more than 10 virtual ethernet card devices.
 	Interfaces []VirtualMachineNetworkInterfaceSpec `json:"interfaces,omitempty"`
+
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+
+	// Bonds is a list of guest-side bond interfaces, each of which aggregates
+	// two or more of the network interfaces from the Interfaces field.
+	//
+	// Please note this feature is available only with the following bootstrap
+	// providers: CloudInit.
+	Bonds []VirtualMachineNetworkBondSpec `json:"bonds,omitempty"`
+
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+
+	// VLANs is a list of guest-side VLAN sub-interfaces created on top of the
+	// network interfaces from the Interfaces field or the bonds from the Bonds
+	// field.
+	//
+	// Please note this feature is available only with the following bootstrap
+	// providers: CloudInit.
+	VLANs []VirtualMachineNetworkVLANSpec `json:"vlans,omitempty"`
+
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+
+	// Bridges is a list of guest-side bridges whose members are network
+	// interfaces from the Interfaces field, bonds from the Bonds field, or
+	// VLANs from the VLANs field.
+	//
+	// Please note this feature is available only with the following bootstrap
+	// providers: CloudInit.
+	Bridges []VirtualMachineNetworkBridgeSpec `json:"bridges,omitempty"`
 }
 
 // VirtualMachineNetworkDNSStatus describes the observed state of the guest's
@@ -622,6 +1075,16 @@ type VirtualMachineNetworkConfigInterfaceStatus struct {
 
 	// DNS describes the interface's configured DNS information.
 	DNS *VirtualMachineNetworkConfigDNSStatus `json:"dns,omitempty"`
+
+	// +optional
+
+	// QoS describes the interface's configured bandwidth allocation.
+	QoS *VirtualMachineNetworkInterfaceQoS `json:"qos,omitempty"`
+
+	// +optional
+
+	// TrafficRules describes the interface's configured traffic rules.
+	TrafficRules []VirtualMachineNetworkInterfaceTrafficRule `json:"trafficRules,omitempty"`
 }
 
 // VirtualMachineNetworkIPStackStatus describes the observed state of a
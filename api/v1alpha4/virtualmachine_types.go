@@ -26,6 +26,11 @@ const (
 	// For more information please see VirtualMachineImage.Status.Ready.
 	VirtualMachineConditionImageReady = "VirtualMachineImageReady"
 
+	// VirtualMachineConditionImageCompatible indicates that a referenced
+	// VirtualMachineImage is compatible with the VM's class, boot options,
+	// and the hosts on which the VM is placed.
+	VirtualMachineConditionImageCompatible = "VirtualMachineImageCompatible"
+
 	// VirtualMachineConditionVMSetResourcePolicyReady indicates that a referenced
 	// VirtualMachineSetResourcePolicy is Ready.
 	VirtualMachineConditionVMSetResourcePolicyReady = "VirtualMachineConditionVMSetResourcePolicyReady"
@@ -58,6 +63,31 @@ const (
 	VirtualMachineClassConfigurationSynced = "VirtualMachineClassConfigurationSynced"
 )
 
+const (
+	// VirtualMachineImageHardwareVersionNotSupportedReason documents that the
+	// image's hardware version is higher than the one specified by the VM's
+	// class or supported by the hosts on which the VM is placed.
+	VirtualMachineImageHardwareVersionNotSupportedReason = "HardwareVersionNotSupported"
+
+	// VirtualMachineImageFirmwareMismatchReason documents that the firmware
+	// specified by the VM or its class is not the image's firmware.
+	VirtualMachineImageFirmwareMismatchReason = "FirmwareMismatch"
+
+	// VirtualMachineImageSecureBootRequiresEFIReason documents that secure
+	// boot is enabled for a VM that uses BIOS firmware.
+	VirtualMachineImageSecureBootRequiresEFIReason = "SecureBootRequiresEFI"
+
+	// VirtualMachineImageGuestNotSupportedForVGPUReason documents that the
+	// VM's class has vGPU devices, which may not be supported by the VM's
+	// guest operating system.
+	VirtualMachineImageGuestNotSupportedForVGPUReason = "GuestNotSupportedForVGPU"
+
+	// VirtualMachineImageVGPUCapabilityMissingReason documents that the VM's
+	// class has vGPU devices, but the image does not have the nvidia-gpu
+	// capability.
+	VirtualMachineImageVGPUCapabilityMissingReason = "VGPUCapabilityMissing"
+)
+
 const (
 	// GuestBootstrapCondition exposes the status of guest bootstrap from within
 	// the guest OS, when available.
@@ -94,6 +124,25 @@ const (
 	GuestCustomizationFailedReason = "GuestCustomizationFailed"
 )
 
+const (
+	// GuestBootstrapSyncedCondition exposes whether the guest OS picked up the
+	// bootstrap data that changed while the VM was powered on, for example the
+	// network config after a network interface was hot added or removed.
+	GuestBootstrapSyncedCondition = "GuestBootstrapSynced"
+
+	// GuestBootstrapPendingPowerCycleReason documents that the guest is
+	// customized with the changed bootstrap data the next time the VM is
+	// powered off and on, since guest customization cannot be applied to a
+	// powered on VM.
+	GuestBootstrapPendingPowerCycleReason = "PendingPowerCycle"
+
+	// GuestBootstrapPendingRecustomizeReason documents that the changed
+	// Cloud-Init metadata was pushed to the guest, but the guest only applies
+	// it once it is re-customized with spec.bootstrap.customizationGeneration
+	// since Cloud-Init does not have hotplug network updates enabled.
+	GuestBootstrapPendingRecustomizeReason = "PendingRecustomize"
+)
+
 const (
 	// VirtualMachineToolsCondition exposes the status of VMware Tools running
 	// in the guest OS, when available.
@@ -344,6 +393,24 @@ type VirtualMachineImageRef struct {
 	Name string `json:"name"`
 }
 
+// VirtualMachineImageRefStatus describes the image from which a VM was
+// deployed.
+type VirtualMachineImageRefStatus struct {
+	VirtualMachineImageRef `json:",inline"`
+
+	// +optional
+
+	// Version describes the status.productInfo.version of the image when the
+	// VM was deployed.
+	Version string `json:"version,omitempty"`
+
+	// +optional
+
+	// Channel describes the name of the VirtualMachineImageChannel resource
+	// from which the image was resolved, if any.
+	Channel string `json:"channel,omitempty"`
+}
+
 // VirtualMachineCdromSpec describes the desired state of a CD-ROM device.
 type VirtualMachineCdromSpec struct {
 	// +kubebuilder:validation:Pattern="^[a-z0-9]{2,}$"
@@ -391,6 +458,18 @@ type VirtualMachineCdromSpec struct {
 	//
 	// Defaults to true if omitted.
 	AllowGuestControl *bool `json:"allowGuestControl,omitempty"`
+
+	// +optional
+
+	// DisconnectAfterBootstrap describes whether the CD-ROM device should be
+	// disconnected from the VM once the GuestBootstrap condition reports
+	// success.
+	//
+	// This is useful when a VM is installed from an ISO image, as it prevents
+	// the VM from booting into the installer again on subsequent restarts.
+	//
+	// Defaults to false if omitted.
+	DisconnectAfterBootstrap *bool `json:"disconnectAfterBootstrap,omitempty"`
 }
 
 // VirtualMachineCryptoSpec defines the desired state of a VirtualMachine's
@@ -720,6 +799,23 @@ type VirtualMachineSpec struct {
 
 	// +optional
 
+	// ImageChannel describes the name of a VirtualMachineImageChannel resource
+	// in the same namespace as this VM.
+	//
+	// This field may not be used with spec.imageName. When creating a new
+	// VirtualMachine, if this field is non-empty and spec.image is empty, then
+	// a mutation webhook updates the spec.image field with the reference to
+	// the image to which the channel currently resolves. If the channel does
+	// not exist or has not resolved an image, then the mutation webhook denies
+	// the request and returns an error.
+	//
+	// The channel is only resolved when the VM is created. A VM is not
+	// redeployed when its channel advances to a new image, but a
+	// VirtualMachineReplicaSet may be configured to replace such VMs.
+	ImageChannel string `json:"imageChannel,omitempty"`
+
+	// +optional
+
 	// ClassName describes the name of the VirtualMachineClass resource used to
 	// deploy this VM.
 	//
@@ -1145,6 +1241,14 @@ type VirtualMachineStatus struct {
 
 	// +optional
 
+	// Image describes the image from which the VM was deployed, including the
+	// version of the image and the channel from which it was resolved.
+	//
+	// This field is set when the VM is created and is not updated afterwards.
+	Image *VirtualMachineImageRefStatus `json:"image,omitempty"`
+
+	// +optional
+
 	// NodeName describes the observed name of the node where the VirtualMachine
 	// is scheduled.
 	NodeName string `json:"nodeName,omitempty"`
@@ -1221,6 +1325,13 @@ type VirtualMachineStatus struct {
 
 	// +optional
 
+	// ObservedCustomizationGeneration describes the value of
+	// spec.bootstrap.customizationGeneration the last time the guest was
+	// re-customized.
+	ObservedCustomizationGeneration int64 `json:"observedCustomizationGeneration,omitempty"`
+
+	// +optional
+
 	// HardwareVersion describes the VirtualMachine resource's observed
 	// hardware version.
 	//
@@ -0,0 +1,88 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha4
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vmopv1cloudinit "github.com/vmware-tanzu/vm-operator/api/v1alpha4/cloudinit"
+	vmopv1common "github.com/vmware-tanzu/vm-operator/api/v1alpha4/common"
+)
+
+// VirtualMachineBootstrapDefaultsCloudInitSpec describes the Cloud-Init
+// vendor-data used to bootstrap the VMs in a namespace.
+//
+// Cloud-Init merges the vendor-data with a VM's user-data, and when both
+// specify the same top-level key, the value from the user-data takes
+// precedence. This means a VM may always override the defaults for its
+// namespace.
+type VirtualMachineBootstrapDefaultsCloudInitSpec struct {
+	// +optional
+
+	// CloudConfig describes a subset of a Cloud-Init CloudConfig, used as the
+	// vendor-data for the VMs in the namespace.
+	//
+	// Please note this field and RawCloudConfig are mutually exclusive.
+	CloudConfig *vmopv1cloudinit.CloudConfig `json:"cloudConfig,omitempty"`
+
+	// +optional
+
+	// RawCloudConfig describes a key in a Secret resource that contains the
+	// vendor-data for the VMs in the namespace.
+	//
+	// The data specified by the key may be plain-text, base64-encoded, or
+	// gzipped and base64-encoded.
+	//
+	// Please note this field and CloudConfig are mutually exclusive.
+	RawCloudConfig *vmopv1common.SecretKeySelector `json:"rawCloudConfig,omitempty"`
+}
+
+// VirtualMachineBootstrapDefaultsSpec defines the desired state of
+// VirtualMachineBootstrapDefaults.
+type VirtualMachineBootstrapDefaultsSpec struct {
+	// +optional
+
+	// CloudInit may be used to specify the Cloud-Init vendor-data for the VMs
+	// in the namespace that use the Cloud-Init bootstrap provider.
+	CloudInit *VirtualMachineBootstrapDefaultsCloudInitSpec `json:"cloudInit,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Namespaced,shortName=vmbsd
+// +kubebuilder:storageversion
+
+// VirtualMachineBootstrapDefaults is the schema for the
+// VirtualMachineBootstrapDefaults API and describes the bootstrap data applied
+// to the VMs in its namespace, in addition to the VMs' own bootstrap data.
+//
+// When a namespace has more than one VirtualMachineBootstrapDefaults resource,
+// their data is applied in the order of their names, and when more than one
+// resource specifies the same top-level key, the value from the resource that
+// is applied last takes precedence.
+//
+// Please note the defaults are read when a VM is bootstrapped, so changes to
+// the defaults do not affect VMs that have already been bootstrapped.
+type VirtualMachineBootstrapDefaults struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec VirtualMachineBootstrapDefaultsSpec `json:"spec,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// VirtualMachineBootstrapDefaultsList contains a list of
+// VirtualMachineBootstrapDefaults.
+type VirtualMachineBootstrapDefaultsList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VirtualMachineBootstrapDefaults `json:"items"`
+}
+
+func init() {
+	objectTypes = append(objectTypes,
+		&VirtualMachineBootstrapDefaults{},
+		&VirtualMachineBootstrapDefaultsList{})
+}
@@ -153,6 +153,43 @@ type VirtualMachineImageSpec struct {
 	// ProviderRef is a reference to the resource that contains the source of
 	// this image's information.
 	ProviderRef *vmopv1common.LocalObjectRef `json:"providerRef,omitempty"`
+
+	// +optional
+
+	// OCI describes the OCI artifact that is the source of this image, ex. a
+	// VM published to an OCI registry.
+	//
+	// When set, status.providerItemID is the reference to the artifact by
+	// digest, ex. registry.example.com/my-project/my-image@sha256:..., and
+	// status.providerContentVersion is the artifact's digest.
+	OCI *VirtualMachineImageOCISource `json:"oci,omitempty"`
+}
+
+// VirtualMachineImageOCISource describes an OCI artifact that is the source of
+// an image.
+type VirtualMachineImageOCISource struct {
+	// +kubebuilder:validation:MinLength=1
+
+	// Reference is the reference to the artifact by tag or digest, ex.
+	// registry.example.com/my-project/my-image:v1 or
+	// registry.example.com/my-project/my-image@sha256:...
+	Reference string `json:"reference"`
+
+	// +optional
+
+	// CredentialsSecretName is the name of a Secret that contains the
+	// credentials used to pull from the registry.
+	//
+	// The Secret must be in the same namespace as a VirtualMachineImage or in
+	// VM Operator's namespace for a ClusterVirtualMachineImage, and must be of
+	// type kubernetes.io/dockerconfigjson or kubernetes.io/basic-auth.
+	CredentialsSecretName string `json:"credentialsSecretName,omitempty"`
+
+	// +optional
+
+	// Insecure indicates the registry is accessed over plain HTTP instead of
+	// HTTPS.
+	Insecure bool `json:"insecure,omitempty"`
 }
 
 // VirtualMachineImageStatus defines the observed state of VirtualMachineImage.
@@ -239,6 +276,31 @@ type VirtualMachineImageStatus struct {
 	// corresponding Content Library item.
 	ProviderItemID string `json:"providerItemID,omitempty"`
 
+	// +optional
+
+	// Fingerprint describes the content of this image, ex. sha256:<hex>.
+	//
+	// If the provider of this image is a Content Library, the fingerprint is
+	// computed from the checksums of the item's OVF descriptor, disks, and
+	// NVRAM file.
+	// Images with the same fingerprint have the same content, for example the
+	// same OVF in multiple libraries, and are considered duplicates of one
+	// another.
+	//
+	// The fingerprint is empty if the provider does not report a SHA256 or
+	// SHA512 checksum for each of the image's files, ex. a Content Library
+	// that reports the default SHA1 checksums. Images without a fingerprint
+	// are never considered duplicates.
+	Fingerprint string `json:"fingerprint,omitempty"`
+
+	// +optional
+
+	// FingerprintContentVersion describes the content version from the
+	// provider item for which the fingerprint was computed, even if the
+	// fingerprint is empty. The fingerprint is not computed again until the
+	// content version changes.
+	FingerprintContentVersion string `json:"fingerprintContentVersion,omitempty"`
+
 	// +optional
 	// +listType=map
 	// +listMapKey=type
@@ -248,7 +310,8 @@ type VirtualMachineImageStatus struct {
 
 	// +optional
 	//
-	// Type describes the content library item type (OVF or ISO) of the image.
+	// Type describes the content library item type (OVF or ISO) of the image,
+	// or OCI for an image backed by an OCI artifact.
 	Type string `json:"type,omitempty"`
 }
 
@@ -135,6 +135,16 @@ type VirtualMachineImageCacheLocationStatus struct {
 
 	// +optional
 
+	// LastUsedTime describes the last time the files cached at this location
+	// were used to deploy a VM. The files are considered used when they are
+	// first cached.
+	//
+	// This field is used to evict the files that have not been used recently
+	// from the cache.
+	LastUsedTime *metav1.Time `json:"lastUsedTime,omitempty"`
+
+	// +optional
+
 	// Conditions describes any conditions associated with this cache location.
 	//
 	// Generally this should just include the ReadyType condition.
@@ -0,0 +1,239 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha4
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// VirtualMachineImageCachePolicyConditionImagesReady indicates the
+	// policy's images were selected.
+	VirtualMachineImageCachePolicyConditionImagesReady = "VirtualMachineImageCachePolicyImagesReady"
+
+	// VirtualMachineImageCachePolicyConditionTargetsReady indicates the
+	// policy's targets were resolved to datastores.
+	VirtualMachineImageCachePolicyConditionTargetsReady = "VirtualMachineImageCachePolicyTargetsReady"
+
+	// VirtualMachineImageCachePolicyConditionFilesReady indicates the files
+	// for the policy's images are cached on all of the policy's datastores.
+	VirtualMachineImageCachePolicyConditionFilesReady = "VirtualMachineImageCachePolicyFilesReady"
+)
+
+// VirtualMachineImageCachePolicyTargetSpec describes where the images selected
+// by a policy are cached.
+//
+// The datastores selected by Zones and StorageClasses are intersected when
+// both fields are specified, and the datastores from the Datastores field are
+// always included.
+//
+// A VirtualMachineImageCachePolicy must specify at least one storage class,
+// may only specify the storage classes and zones available to its namespace,
+// and may not specify datastores.
+type VirtualMachineImageCachePolicyTargetSpec struct {
+	// +optional
+
+	// Zones describes the names of the zones whose datastores should be used
+	// to cache the images.
+	//
+	// If StorageClasses is specified and Zones is not, then the datastores
+	// from all of the zones are considered, or, for a
+	// VirtualMachineImageCachePolicy, the datastores from all of the zones
+	// available to its namespace.
+	Zones []string `json:"zones,omitempty"`
+
+	// +optional
+
+	// StorageClasses describes the names of the storage classes whose
+	// compatible datastores should be used to cache the images.
+	StorageClasses []string `json:"storageClasses,omitempty"`
+
+	// +optional
+
+	// Datastores describes the managed object IDs of the datastores that
+	// should be used to cache the images.
+	Datastores []string `json:"datastores,omitempty"`
+}
+
+// VirtualMachineImageCachePolicySpec defines the desired state of
+// VirtualMachineImageCachePolicy and ClusterVirtualMachineImageCachePolicy.
+type VirtualMachineImageCachePolicySpec struct {
+	// +optional
+
+	// ImageNames describes the names of the images to cache.
+	//
+	// For a VirtualMachineImageCachePolicy, the names refer to
+	// VirtualMachineImage resources in the same namespace as the policy. For a
+	// ClusterVirtualMachineImageCachePolicy, the names refer to
+	// ClusterVirtualMachineImage resources.
+	ImageNames []string `json:"imageNames,omitempty"`
+
+	// +optional
+
+	// ImageSelector describes a label selector used to select the images to
+	// cache, in addition to those specified by ImageNames.
+	ImageSelector *metav1.LabelSelector `json:"imageSelector,omitempty"`
+
+	// Target describes where the images are cached.
+	Target VirtualMachineImageCachePolicyTargetSpec `json:"target"`
+
+	// +optional
+	// +kubebuilder:default=4
+	// +kubebuilder:validation:Minimum=1
+
+	// MaxConcurrentCopies describes the maximum number of locations for which
+	// images may be copied at the same time. The limit applies to the
+	// locations of all of the cached images, including those cached by other
+	// policies, so the policy does not start copying images to another
+	// location while the limit is reached.
+	MaxConcurrentCopies int32 `json:"maxConcurrentCopies,omitempty"`
+}
+
+// VirtualMachineImageCachePolicyLocationStatus describes a location where a
+// policy's image is cached.
+type VirtualMachineImageCachePolicyLocationStatus struct {
+	// DatacenterID describes the ID of the datacenter to which the image is
+	// cached.
+	DatacenterID string `json:"datacenterID"`
+
+	// DatastoreID describes the ID of the datastore to which the image is
+	// cached.
+	DatastoreID string `json:"datastoreID"`
+
+	// +optional
+
+	// Ready is true if the image's files are cached at this location.
+	Ready bool `json:"ready,omitempty"`
+}
+
+// VirtualMachineImageCachePolicyImageStatus describes the observed state of an
+// image selected by a policy.
+type VirtualMachineImageCachePolicyImageStatus struct {
+	// Name describes the name of the image.
+	Name string `json:"name"`
+
+	// +optional
+
+	// CacheName describes the name of the VirtualMachineImageCache resource
+	// used to cache the image.
+	CacheName string `json:"cacheName,omitempty"`
+
+	// +optional
+	// +listType=map
+	// +listMapKey=datacenterID
+	// +listMapKey=datastoreID
+
+	// Locations describes the locations where the image is cached.
+	Locations []VirtualMachineImageCachePolicyLocationStatus `json:"locations,omitempty"`
+}
+
+// VirtualMachineImageCachePolicyStatus defines the observed state of
+// VirtualMachineImageCachePolicy and ClusterVirtualMachineImageCachePolicy.
+type VirtualMachineImageCachePolicyStatus struct {
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+
+	// Images describes the observed state of the images selected by the
+	// policy.
+	Images []VirtualMachineImageCachePolicyImageStatus `json:"images,omitempty"`
+
+	// +optional
+
+	// ObservedGeneration describes the value of the metadata.generation field
+	// the last time the policy was reconciled.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// +optional
+
+	// Conditions describes any conditions associated with this policy.
+	//
+	// Generally this should just include the ReadyType condition, which will
+	// only be True once the policy's images are cached on all of the policy's
+	// datastores.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Namespaced,shortName=vmicp;vmicachepolicy
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// VirtualMachineImageCachePolicy is the schema for the
+// virtualmachineimagecachepolicies API and describes the
+// VirtualMachineImage resources in its namespace that are cached ahead of
+// the VMs deployed from them.
+type VirtualMachineImageCachePolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VirtualMachineImageCachePolicySpec   `json:"spec,omitempty"`
+	Status VirtualMachineImageCachePolicyStatus `json:"status,omitempty"`
+}
+
+func (i VirtualMachineImageCachePolicy) GetConditions() []metav1.Condition {
+	return i.Status.Conditions
+}
+
+func (i *VirtualMachineImageCachePolicy) SetConditions(conditions []metav1.Condition) {
+	i.Status.Conditions = conditions
+}
+
+// +kubebuilder:object:root=true
+
+// VirtualMachineImageCachePolicyList contains a list of
+// VirtualMachineImageCachePolicy.
+type VirtualMachineImageCachePolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VirtualMachineImageCachePolicy `json:"items"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Cluster,shortName=cvmicp;clustervmicachepolicy
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// ClusterVirtualMachineImageCachePolicy is the schema for the
+// clustervirtualmachineimagecachepolicies API and describes the
+// ClusterVirtualMachineImage resources that are cached ahead of the VMs
+// deployed from them.
+type ClusterVirtualMachineImageCachePolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VirtualMachineImageCachePolicySpec   `json:"spec,omitempty"`
+	Status VirtualMachineImageCachePolicyStatus `json:"status,omitempty"`
+}
+
+func (i ClusterVirtualMachineImageCachePolicy) GetConditions() []metav1.Condition {
+	return i.Status.Conditions
+}
+
+func (i *ClusterVirtualMachineImageCachePolicy) SetConditions(conditions []metav1.Condition) {
+	i.Status.Conditions = conditions
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterVirtualMachineImageCachePolicyList contains a list of
+// ClusterVirtualMachineImageCachePolicy.
+type ClusterVirtualMachineImageCachePolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterVirtualMachineImageCachePolicy `json:"items"`
+}
+
+func init() {
+	objectTypes = append(objectTypes,
+		&VirtualMachineImageCachePolicy{},
+		&VirtualMachineImageCachePolicyList{},
+		&ClusterVirtualMachineImageCachePolicy{},
+		&ClusterVirtualMachineImageCachePolicyList{})
+}
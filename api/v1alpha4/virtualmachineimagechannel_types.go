@@ -0,0 +1,165 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha4
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// VirtualMachineImageChannelConditionImageResolved indicates the channel
+	// resolved an image that matches its selector.
+	VirtualMachineImageChannelConditionImageResolved = "VirtualMachineImageChannelImageResolved"
+
+	// VirtualMachineImageChannelNoMatchingImageReason documents that none of
+	// the ready images match the channel's selector.
+	VirtualMachineImageChannelNoMatchingImageReason = "NoMatchingImage"
+)
+
+// VirtualMachineImageChannelSelector describes the images that are candidates
+// for a channel. All of the specified fields must match an image for it to be
+// selected.
+type VirtualMachineImageChannelSelector struct {
+	// +optional
+
+	// Product describes the value of an image's status.productInfo.product.
+	Product string `json:"product,omitempty"`
+
+	// +optional
+
+	// Vendor describes the value of an image's status.productInfo.vendor.
+	Vendor string `json:"vendor,omitempty"`
+
+	// +optional
+
+	// Version describes a semantic version range that an image's
+	// status.productInfo.version must be in, ex. ">=1.2.0 <2.0.0".
+	//
+	// Versions that are not strict semantic versions, ex. "1.2", are treated
+	// as if the missing components are zero.
+	Version string `json:"version,omitempty"`
+
+	// +optional
+
+	// OSID describes the value of an image's status.osInfo.id.
+	OSID string `json:"osID,omitempty"`
+
+	// +optional
+
+	// OSType describes the value of an image's status.osInfo.type.
+	OSType string `json:"osType,omitempty"`
+
+	// +optional
+
+	// OSVersion describes the value of an image's status.osInfo.version.
+	OSVersion string `json:"osVersion,omitempty"`
+
+	// +optional
+
+	// LabelSelector describes a label selector an image's labels must match.
+	LabelSelector *metav1.LabelSelector `json:"labelSelector,omitempty"`
+}
+
+// VirtualMachineImageChannelSpec defines the desired state of
+// VirtualMachineImageChannel.
+type VirtualMachineImageChannelSpec struct {
+	// +optional
+	// +kubebuilder:default=VirtualMachineImage
+	// +kubebuilder:validation:Enum=VirtualMachineImage;ClusterVirtualMachineImage
+
+	// ImageKind describes the kind of image selected by the channel, either
+	// VirtualMachineImage resources in the same namespace as the channel or
+	// ClusterVirtualMachineImage resources.
+	ImageKind string `json:"imageKind,omitempty"`
+
+	// Selector describes the images that are candidates for the channel.
+	//
+	// The channel resolves to the ready image with the highest
+	// status.productInfo.version among the candidates. Images whose version
+	// is not a semantic version are only selected if no candidate has one.
+	Selector VirtualMachineImageChannelSelector `json:"selector"`
+}
+
+// VirtualMachineImageChannelStatus defines the observed state of
+// VirtualMachineImageChannel.
+type VirtualMachineImageChannelStatus struct {
+	// +optional
+
+	// Image describes the reference to the image to which the channel
+	// currently resolves.
+	Image *VirtualMachineImageRef `json:"image,omitempty"`
+
+	// +optional
+
+	// Version describes the status.productInfo.version of the image to which
+	// the channel currently resolves.
+	Version string `json:"version,omitempty"`
+
+	// +optional
+
+	// LastUpdateTime describes the last time the channel advanced to a
+	// different image.
+	LastUpdateTime *metav1.Time `json:"lastUpdateTime,omitempty"`
+
+	// +optional
+
+	// ObservedGeneration describes the value of the metadata.generation field
+	// the last time the channel was reconciled.
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// +optional
+
+	// Conditions describes any conditions associated with this channel.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Namespaced,shortName=vmichan;vmimagechannel
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Image",type="string",JSONPath=".status.image.name"
+// +kubebuilder:printcolumn:name="Version",type="string",JSONPath=".status.version"
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// VirtualMachineImageChannel is the schema for the
+// virtualmachineimagechannels API and describes a stable name that resolves
+// to the latest VirtualMachineImage or ClusterVirtualMachineImage that
+// matches a selector.
+//
+// A VirtualMachine may be deployed from a channel by specifying the
+// channel's name in spec.imageChannel, and a VirtualMachineReplicaSet may
+// replace its VMs when the channel advances to a new image.
+type VirtualMachineImageChannel struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VirtualMachineImageChannelSpec   `json:"spec,omitempty"`
+	Status VirtualMachineImageChannelStatus `json:"status,omitempty"`
+}
+
+func (i VirtualMachineImageChannel) GetConditions() []metav1.Condition {
+	return i.Status.Conditions
+}
+
+func (i *VirtualMachineImageChannel) SetConditions(conditions []metav1.Condition) {
+	i.Status.Conditions = conditions
+}
+
+// +kubebuilder:object:root=true
+
+// VirtualMachineImageChannelList contains a list of
+// VirtualMachineImageChannel.
+type VirtualMachineImageChannelList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VirtualMachineImageChannel `json:"items"`
+}
+
+func init() {
+	objectTypes = append(objectTypes,
+		&VirtualMachineImageChannel{},
+		&VirtualMachineImageChannelList{})
+}
@@ -0,0 +1,186 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha4
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// VirtualMachineImageUploadConditionTargetValid is the Type for a
+	// VirtualMachineImageUpload resource's status condition.
+	//
+	// The condition's status is set to true only when the content library to
+	// which the OVA is uploaded exists, is writable, and does not already
+	// have an item with the upload's item name.
+	VirtualMachineImageUploadConditionTargetValid = "TargetValid"
+
+	// VirtualMachineImageUploadConditionUploaded is the Type for a
+	// VirtualMachineImageUpload resource's status condition.
+	//
+	// The condition's status is set to true only when the OVA has been
+	// received, its manifest checksums have been verified, and its files have
+	// been uploaded to the content library item.
+	VirtualMachineImageUploadConditionUploaded = "Uploaded"
+
+	// VirtualMachineImageUploadConditionImageReady is the Type for a
+	// VirtualMachineImageUpload resource's status condition.
+	//
+	// The condition's status is set to true only when the VirtualMachineImage
+	// for the uploaded library item exists and is ready.
+	VirtualMachineImageUploadConditionImageReady = "ImageReady"
+
+	// VirtualMachineImageUploadConditionComplete is the Type for a
+	// VirtualMachineImageUpload resource's status condition.
+	//
+	// The condition's status is set to true only when all other conditions
+	// present on the resource have a truthy status.
+	VirtualMachineImageUploadConditionComplete = "Complete"
+)
+
+// Condition.Reason for Conditions related to VirtualMachineImageUpload.
+//
+// Please note, the TargetValid and Uploaded conditions also use the reasons
+// that are defined for the VirtualMachinePublishRequest conditions of the
+// same name.
+const (
+	// UploadURLExpiredReason documents that the upload URL expired before the
+	// OVA was uploaded.
+	UploadURLExpiredReason = "UploadURLExpired"
+
+	// UploadInvalidOVAReason documents that the uploaded OVA could not be
+	// read or that its files do not match the checksums in its manifest.
+	UploadInvalidOVAReason = "InvalidOVA"
+
+	// UploadTimedOutReason documents that the OVA was not received before the
+	// upload timed out.
+	UploadTimedOutReason = "UploadTimedOut"
+
+	// UploadServerNotConfiguredReason documents that an upload URL may not be
+	// issued since the external URL of the image upload server is not
+	// configured.
+	UploadServerNotConfiguredReason = "UploadServerNotConfigured"
+)
+
+// VirtualMachineImageUploadSpec defines the desired state of a
+// VirtualMachineImageUpload.
+type VirtualMachineImageUploadSpec struct {
+	// Library is the name of the ContentLibrary resource in the namespace
+	// for the writable content library in which the uploaded image is
+	// created.
+	Library string `json:"library"`
+
+	// +optional
+
+	// ItemName is the name of the content library item created for the
+	// uploaded image.
+	//
+	// Defaults to the name of the VirtualMachineImageUpload.
+	ItemName string `json:"itemName,omitempty"`
+
+	// +optional
+
+	// Description is the description of the content library item created for
+	// the uploaded image.
+	Description string `json:"description,omitempty"`
+}
+
+// VirtualMachineImageUploadStatus defines the observed state of a
+// VirtualMachineImageUpload.
+type VirtualMachineImageUploadStatus struct {
+	// +optional
+
+	// UploadURL is the URL to which the OVA is uploaded with an HTTP PUT
+	// request. The URL may be used only once and only until ExpirationTime.
+	UploadURL string `json:"uploadURL,omitempty"`
+
+	// +optional
+
+	// ExpirationTime is the time after which the upload URL may no longer be
+	// used.
+	ExpirationTime metav1.Time `json:"expirationTime,omitempty"`
+
+	// +optional
+
+	// LibraryItemID is the ID of the content library item created for the
+	// uploaded image.
+	LibraryItemID string `json:"libraryItemID,omitempty"`
+
+	// +optional
+
+	// ImageName is the name of the VirtualMachineImage resource for the
+	// uploaded image.
+	ImageName string `json:"imageName,omitempty"`
+
+	// +optional
+
+	// CompletionTime represents the time when the upload was completed.
+	CompletionTime metav1.Time `json:"completionTime,omitempty"`
+
+	// +optional
+
+	// Ready is set to true only when the image has been uploaded and its
+	// VirtualMachineImage is ready.
+	//
+	// Readiness is determined by waiting until there is status condition
+	// Type=Complete and ensuring it and all other status conditions present
+	// have a Status=True. The conditions present will be:
+	//
+	//   * TargetValid
+	//   * Uploaded
+	//   * ImageReady
+	//   * Complete
+	Ready bool `json:"ready,omitempty"`
+
+	// +optional
+
+	// Conditions is a list of the latest, available observations of the
+	// upload's current state.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Namespaced,shortName=vmiupload
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Library",type="string",JSONPath=".spec.library"
+// +kubebuilder:printcolumn:name="Image",type="string",JSONPath=".status.imageName"
+// +kubebuilder:printcolumn:name="Ready",type="boolean",JSONPath=".status.ready"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// VirtualMachineImageUpload defines the information necessary to upload an
+// OVA into a content library in the namespace as a VirtualMachineImage.
+type VirtualMachineImageUpload struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VirtualMachineImageUploadSpec   `json:"spec,omitempty"`
+	Status VirtualMachineImageUploadStatus `json:"status,omitempty"`
+}
+
+func (vmiUpload *VirtualMachineImageUpload) GetConditions() []metav1.Condition {
+	return vmiUpload.Status.Conditions
+}
+
+func (vmiUpload *VirtualMachineImageUpload) SetConditions(conditions []metav1.Condition) {
+	vmiUpload.Status.Conditions = conditions
+}
+
+// +kubebuilder:object:root=true
+
+// VirtualMachineImageUploadList contains a list of VirtualMachineImageUpload
+// resources.
+type VirtualMachineImageUploadList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VirtualMachineImageUpload `json:"items"`
+}
+
+func init() {
+	objectTypes = append(objectTypes,
+		&VirtualMachineImageUpload{},
+		&VirtualMachineImageUploadList{},
+	)
+}
@@ -0,0 +1,351 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1alpha4
+
+import (
+	"encoding/json"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vmopv1common "github.com/vmware-tanzu/vm-operator/api/v1alpha4/common"
+)
+
+const (
+	// VirtualMachineImportRequestConditionSourceValid is the Type for a
+	// VirtualMachineImportRequest resource's status condition.
+	//
+	// The condition's status is set to true only when the vSphere VM to be
+	// imported has been found and may be imported, and the VirtualMachine
+	// resource for it may be created.
+	VirtualMachineImportRequestConditionSourceValid = "SourceValid"
+
+	// VirtualMachineImportRequestConditionRelocated is the Type for a
+	// VirtualMachineImportRequest resource's status condition.
+	//
+	// The condition's status is set to true only when the vSphere VM has been
+	// moved into the namespace's folder and resource pool and is managed by
+	// VM Operator.
+	VirtualMachineImportRequestConditionRelocated = "Relocated"
+
+	// VirtualMachineImportRequestConditionVolumesRegistered is the Type for a
+	// VirtualMachineImportRequest resource's status condition.
+	//
+	// The condition's status is set to true only when each of the vSphere
+	// VM's data disks has been registered as a PersistentVolumeClaim.
+	VirtualMachineImportRequestConditionVolumesRegistered = "VolumesRegistered"
+
+	// VirtualMachineImportRequestConditionVirtualMachineCreated is the Type for
+	// a VirtualMachineImportRequest resource's status condition.
+	//
+	// The condition's status is set to true only when the VirtualMachine
+	// resource for the vSphere VM has been created.
+	VirtualMachineImportRequestConditionVirtualMachineCreated = "VirtualMachineCreated"
+
+	// VirtualMachineImportRequestConditionComplete is the Type for a
+	// VirtualMachineImportRequest resource's status condition.
+	//
+	// The condition's status is set to true only when all other conditions
+	// present on the resource have a truthy status.
+	VirtualMachineImportRequestConditionComplete = "Complete"
+)
+
+// Condition.Reason for Conditions related to VirtualMachineImportRequest.
+const (
+	// SourceVirtualMachineNotFoundReason documents that the vSphere VM to be
+	// imported does not exist.
+	SourceVirtualMachineNotFoundReason = "SourceVirtualMachineNotFound"
+
+	// SourceVirtualMachineAlreadyManagedReason documents that the vSphere VM to
+	// be imported is already managed by VM Operator or is a template.
+	SourceVirtualMachineAlreadyManagedReason = "SourceVirtualMachineAlreadyManaged"
+
+	// SourceVirtualMachineZoneNotFoundReason documents that the vSphere VM to
+	// be imported is not on a cluster that belongs to a zone.
+	SourceVirtualMachineZoneNotFoundReason = "SourceVirtualMachineZoneNotFound"
+
+	// SourceVirtualMachineNotPoweredOffReason documents that the vSphere VM to
+	// be imported is not powered off.
+	SourceVirtualMachineNotPoweredOffReason = "SourceVirtualMachineNotPoweredOff"
+
+	// SourceNetworkNotMappedReason documents that one or more of the vSphere
+	// VM's network adapters are connected to a vSphere network that is not in
+	// the request's network mappings.
+	SourceNetworkNotMappedReason = "SourceNetworkNotMapped"
+
+	// TargetVirtualMachineInvalidReason documents that the VirtualMachine
+	// resource for the imported VM may not be created.
+	TargetVirtualMachineInvalidReason = "TargetVirtualMachineInvalid"
+
+	// TargetVirtualMachineAlreadyExistsReason documents that a VirtualMachine
+	// resource with the target name already exists and was not created by the
+	// VirtualMachineImportRequest.
+	TargetVirtualMachineAlreadyExistsReason = "TargetVirtualMachineAlreadyExists"
+
+	// TargetVirtualMachineClassAlreadyExistsReason documents that a
+	// VirtualMachineClass resource with the target name already exists and was
+	// not created by the VirtualMachineImportRequest.
+	TargetVirtualMachineClassAlreadyExistsReason = "TargetVirtualMachineClassAlreadyExists"
+
+	// VolumeRegistrationPendingReason documents that one or more of the
+	// vSphere VM's data disks have not been registered yet.
+	VolumeRegistrationPendingReason = "VolumeRegistrationPending"
+
+	// VolumeRegistrationFailedReason documents that one or more of the
+	// vSphere VM's data disks failed to be registered.
+	VolumeRegistrationFailedReason = "VolumeRegistrationFailed"
+)
+
+// VirtualMachineImportRequestSource describes the vSphere VM to be imported.
+// Exactly one of the fields must be specified.
+type VirtualMachineImportRequestSource struct {
+	// +optional
+
+	// ID describes the managed object ID of the vSphere VM, ex. vm-42.
+	ID string `json:"id,omitempty"`
+
+	// +optional
+
+	// InventoryPath describes the vSphere inventory path of the VM, ex.
+	// /my-datacenter/vm/my-folder/my-vm.
+	InventoryPath string `json:"inventoryPath,omitempty"`
+}
+
+// VirtualMachineImportRequestNetworkMapping describes the network to which a
+// vSphere VM's network adapters are connected after the VM is imported.
+type VirtualMachineImportRequestNetworkMapping struct {
+	// Source describes the name of the vSphere network, ex. a distributed port
+	// group, to which the network adapters are connected.
+	Source string `json:"source"`
+
+	// +optional
+
+	// Network describes the network resource in the namespace to which the
+	// network adapters are connected after the VM is imported. If omitted,
+	// the namespace's default network is used.
+	Network *vmopv1common.PartialObjectRef `json:"network,omitempty"`
+}
+
+// VirtualMachineImportRequestSpec defines the desired state of a
+// VirtualMachineImportRequest.
+type VirtualMachineImportRequestSpec struct {
+	// Source describes the vSphere VM to be imported.
+	Source VirtualMachineImportRequestSource `json:"source"`
+
+	// +optional
+
+	// TargetName describes the name of the VirtualMachine resource created
+	// for the imported VM.
+	//
+	// Defaults to the name of the VirtualMachineImportRequest.
+	TargetName string `json:"targetName,omitempty"`
+
+	// +optional
+
+	// StorageClass describes the name of the StorageClass set on the
+	// VirtualMachine resource created for the imported VM.
+	StorageClass string `json:"storageClass,omitempty"`
+
+	// +optional
+	// +listType=map
+	// +listMapKey=source
+
+	// NetworkMappings describe the network resources to which the VM's
+	// network adapters are connected, by the name of the vSphere network to
+	// which they were connected before the VM was imported.
+	//
+	// Each vSphere network to which one of the VM's network adapters is
+	// connected must be in this list, otherwise the VM may not be imported.
+	NetworkMappings []VirtualMachineImportRequestNetworkMapping `json:"networkMappings,omitempty"`
+}
+
+// VirtualMachineImportRequestDiskStatus describes a data disk of the vSphere
+// VM that is registered as a PersistentVolumeClaim.
+type VirtualMachineImportRequestDiskStatus struct {
+	// DiskURLPath describes the URL of the disk's backing file.
+	DiskURLPath string `json:"diskURLPath"`
+
+	// +optional
+
+	// Capacity describes the capacity of the disk.
+	Capacity *resource.Quantity `json:"capacity,omitempty"`
+
+	// ClaimName describes the name of the PersistentVolumeClaim to which the
+	// disk is registered.
+	ClaimName string `json:"claimName"`
+
+	// +optional
+
+	// Registered is true when the disk has been registered.
+	Registered bool `json:"registered,omitempty"`
+}
+
+// VirtualMachineImportRequestNetworkStatus describes a network adapter of the
+// vSphere VM.
+type VirtualMachineImportRequestNetworkStatus struct {
+	// Name describes the name of the interface on the imported VM.
+	Name string `json:"name"`
+
+	// +optional
+
+	// Network describes the name of the vSphere network to which the network
+	// adapter was connected.
+	Network string `json:"network,omitempty"`
+
+	// +optional
+
+	// MacAddress describes the MAC address of the network adapter.
+	MacAddress string `json:"macAddress,omitempty"`
+}
+
+// VirtualMachineImportRequestSourceStatus describes the observed state of the
+// vSphere VM being imported.
+type VirtualMachineImportRequestSourceStatus struct {
+	// ID describes the managed object ID of the vSphere VM.
+	ID string `json:"id"`
+
+	// +optional
+
+	// Name describes the name of the vSphere VM.
+	Name string `json:"name,omitempty"`
+
+	// +optional
+
+	// InstanceUUID describes the instance UUID of the vSphere VM.
+	InstanceUUID string `json:"instanceUUID,omitempty"`
+
+	// +optional
+
+	// BiosUUID describes the BIOS UUID of the vSphere VM.
+	BiosUUID string `json:"biosUUID,omitempty"`
+
+	// +optional
+
+	// Zone describes the zone of the cluster on which the vSphere VM is
+	// running.
+	Zone string `json:"zone,omitempty"`
+
+	// +optional
+
+	// PowerState describes the power state of the vSphere VM when it was
+	// found.
+	PowerState VirtualMachinePowerState `json:"powerState,omitempty"`
+
+	// +optional
+
+	// Disks describe the vSphere VM's data disks, which are all of its disks
+	// other than the boot disk.
+	Disks []VirtualMachineImportRequestDiskStatus `json:"disks,omitempty"`
+
+	// +optional
+
+	// Networks describe the vSphere VM's network adapters.
+	Networks []VirtualMachineImportRequestNetworkStatus `json:"networks,omitempty"`
+
+	// +optional
+	// +kubebuilder:validation:Schemaless
+	// +kubebuilder:validation:Type=object
+	// +kubebuilder:pruning:PreserveUnknownFields
+
+	// ConfigSpec describes the vSphere VM's CPU and memory configuration. It
+	// is used as the ConfigSpec of the VirtualMachineClass created for the
+	// imported VM.
+	// The contents of this field are the VirtualMachineConfigSpec data object
+	// (https://bit.ly/3HDtiRu) marshaled to JSON using the discriminator
+	// field "_typeName" to preserve type information.
+	ConfigSpec json.RawMessage `json:"configSpec,omitempty"`
+}
+
+// VirtualMachineImportRequestStatus defines the observed state of a
+// VirtualMachineImportRequest.
+type VirtualMachineImportRequestStatus struct {
+	// +optional
+
+	// Source describes the observed state of the vSphere VM being imported.
+	Source *VirtualMachineImportRequestSourceStatus `json:"source,omitempty"`
+
+	// +optional
+
+	// VirtualMachineRef describes the VirtualMachine resource created for the
+	// imported VM.
+	VirtualMachineRef *vmopv1common.LocalObjectRef `json:"virtualMachineRef,omitempty"`
+
+	// +optional
+
+	// StartTime represents the time when the request was acknowledged by
+	// the VM import controller.
+	StartTime metav1.Time `json:"startTime,omitempty"`
+
+	// +optional
+
+	// CompletionTime represents the time when the request was completed.
+	CompletionTime metav1.Time `json:"completionTime,omitempty"`
+
+	// +optional
+
+	// Ready is set to true only when the VM has been imported.
+	//
+	// Readiness is determined by waiting until there is status condition
+	// Type=Complete and ensuring it and all other status conditions present
+	// have a Status=True. The conditions present will be:
+	//
+	//   * SourceValid
+	//   * Relocated
+	//   * VolumesRegistered
+	//   * VirtualMachineCreated
+	//   * Complete
+	Ready bool `json:"ready,omitempty"`
+
+	// +optional
+
+	// Conditions is a list of the latest, available observations of the
+	// request's current state.
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:scope=Namespaced,shortName=vmimport
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Source",type="string",JSONPath=".status.source.id"
+// +kubebuilder:printcolumn:name="VirtualMachine",type="string",JSONPath=".status.virtualMachineRef.name"
+// +kubebuilder:printcolumn:name="Ready",type="boolean",JSONPath=".status.ready"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// VirtualMachineImportRequest defines the information necessary to import an
+// existing vSphere VM that is not managed by VM Operator as a VirtualMachine
+// resource.
+type VirtualMachineImportRequest struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   VirtualMachineImportRequestSpec   `json:"spec,omitempty"`
+	Status VirtualMachineImportRequestStatus `json:"status,omitempty"`
+}
+
+func (vmimport *VirtualMachineImportRequest) GetConditions() []metav1.Condition {
+	return vmimport.Status.Conditions
+}
+
+func (vmimport *VirtualMachineImportRequest) SetConditions(conditions []metav1.Condition) {
+	vmimport.Status.Conditions = conditions
+}
+
+// +kubebuilder:object:root=true
+
+// VirtualMachineImportRequestList contains a list of
+// VirtualMachineImportRequest resources.
+type VirtualMachineImportRequestList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []VirtualMachineImportRequest `json:"items"`
+}
+
+func init() {
+	objectTypes = append(objectTypes,
+		&VirtualMachineImportRequest{},
+		&VirtualMachineImportRequestList{},
+	)
+}
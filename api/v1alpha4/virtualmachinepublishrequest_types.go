@@ -57,6 +57,14 @@ const (
 	// the VirtualMachinePublishRequest hasn't been created.
 	SourceVirtualMachineNotCreatedReason = "SourceVirtualMachineNotCreated"
 
+	// SourceVirtualMachineSnapshotNotExistReason documents that the source
+	// VM snapshot of the VirtualMachinePublishRequest doesn't exist.
+	SourceVirtualMachineSnapshotNotExistReason = "SourceVirtualMachineSnapshotNotExist"
+
+	// SourceVirtualMachineSnapshotNotReadyReason documents that the source
+	// VM snapshot of the VirtualMachinePublishRequest isn't ready.
+	SourceVirtualMachineSnapshotNotReadyReason = "SourceVirtualMachineSnapshotNotReady"
+
 	// TargetContentLibraryNotExistReason documents that the target content
 	// library of the VirtualMachinePublishRequest doesn't exist.
 	TargetContentLibraryNotExistReason = "TargetContentLibraryNotExist"
@@ -69,6 +77,11 @@ const (
 	// library of the VirtualMachinePublishRequest isn't ready.
 	TargetContentLibraryNotReadyReason = "TargetContentLibraryNotReady"
 
+	// TargetOCIRegistryInvalidReason documents that the target OCI registry
+	// of the VirtualMachinePublishRequest is invalid, ex. the repository or
+	// credentials are missing.
+	TargetOCIRegistryInvalidReason = "TargetOCIRegistryInvalid"
+
 	// TargetItemAlreadyExistsReason documents that an item with the same name
 	// as the VirtualMachinePublishRequest's target item name exists in
 	// the target content library.
@@ -108,8 +121,28 @@ const (
 	ImageUnavailableReason = "ImageUnavailable"
 )
 
+const (
+	// VirtualMachinePublishRequestSourceKindVirtualMachine is the kind of a
+	// publication source that is a VirtualMachine resource.
+	VirtualMachinePublishRequestSourceKindVirtualMachine = "VirtualMachine"
+
+	// VirtualMachinePublishRequestSourceKindVirtualMachineSnapshot is the kind
+	// of a publication source that is a VirtualMachineSnapshot resource.
+	VirtualMachinePublishRequestSourceKindVirtualMachineSnapshot = "VirtualMachineSnapshot"
+)
+
+const (
+	// VirtualMachinePublishRequestTargetLocationKindContentLibrary is the kind
+	// of a publication target that is a ContentLibrary resource.
+	VirtualMachinePublishRequestTargetLocationKindContentLibrary = "ContentLibrary"
+
+	// VirtualMachinePublishRequestTargetLocationKindOCIRegistry is the kind of
+	// a publication target that is a repository in an OCI registry.
+	VirtualMachinePublishRequestTargetLocationKindOCIRegistry = "OCIRegistry"
+)
+
 // VirtualMachinePublishRequestSource is the source of a publication request,
-// typically a VirtualMachine resource.
+// typically a VirtualMachine or VirtualMachineSnapshot resource.
 type VirtualMachinePublishRequestSource struct {
 	// +optional
 
@@ -129,6 +162,11 @@ type VirtualMachinePublishRequestSource struct {
 	// +kubebuilder:default=VirtualMachine
 
 	// Kind is the kind of referenced object.
+	//
+	// If the value is VirtualMachineSnapshot, then the VM referenced by the
+	// snapshot is published from the snapshot's disks instead of from the
+	// VM's current state. This allows an image to be published from a
+	// known-good point in time while the VM keeps running.
 	Kind string `json:"kind,omitempty"`
 }
 
@@ -180,7 +218,60 @@ type VirtualMachinePublishRequestTargetLocation struct {
 	// +kubebuilder:default=ContentLibrary
 
 	// Kind is the kind of referenced object.
+	//
+	// If the value is OCIRegistry, then the VM is published to the OCI
+	// registry repository described by spec.target.location.oci, and the
+	// name and API version of the location are ignored.
 	Kind string `json:"kind,omitempty"`
+
+	// +optional
+
+	// OCI describes the OCI registry repository to which the VM is
+	// published.
+	//
+	// This field is required when spec.target.location.kind is OCIRegistry.
+	OCI *VirtualMachinePublishRequestTargetOCIRegistry `json:"oci,omitempty"`
+}
+
+// VirtualMachinePublishRequestTargetOCIRegistry describes a repository in an
+// OCI registry to which a VM is published.
+//
+// The VM is exported as an OVF and pushed to the repository as an OCI
+// artifact. The OVF descriptor and each of the VM's disks are layers of the
+// artifact, and information about the OVF is recorded in the annotations of
+// the artifact's manifest.
+type VirtualMachinePublishRequestTargetOCIRegistry struct {
+	// +kubebuilder:validation:MinLength=1
+
+	// Repository is the repository to which the VM is published, ex.
+	// registry.example.com/my-project/my-image.
+	Repository string `json:"repository"`
+
+	// +optional
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9_][a-zA-Z0-9._-]{0,127}$`
+
+	// Tag is the tag of the published artifact.
+	//
+	// If omitted then the controller uses spec.target.item.name as the tag.
+	Tag string `json:"tag,omitempty"`
+
+	// +optional
+
+	// CredentialsSecretName is the name of a Secret in the same namespace as
+	// the VirtualMachinePublishRequest that contains the credentials used to
+	// push to the registry.
+	//
+	// The Secret must be of type kubernetes.io/dockerconfigjson or
+	// kubernetes.io/basic-auth.
+	//
+	// If omitted then the artifact is pushed anonymously.
+	CredentialsSecretName string `json:"credentialsSecretName,omitempty"`
+
+	// +optional
+
+	// Insecure indicates the registry is accessed over plain HTTP instead of
+	// HTTPS.
+	Insecure bool `json:"insecure,omitempty"`
 }
 
 // VirtualMachinePublishRequestTarget is the target of a publication request,
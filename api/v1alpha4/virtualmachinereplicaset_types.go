@@ -40,6 +40,30 @@ const (
 
 	// ScalingDownReason documents a VirtualMachineReplicaSet is decreasing the number of replicas.
 	ScalingDownReason = "ScalingDown"
+
+	// ImageUpToDateCondition documents that the virtual machines controlled by
+	// the VirtualMachineReplicaSet were deployed from the image to which the
+	// template's image channel currently resolves.
+	ImageUpToDateCondition = "ImageUpToDate"
+
+	// ImageRolloutInProgressReason documents a VirtualMachineReplicaSet is
+	// replacing virtual machines deployed from an older image.
+	ImageRolloutInProgressReason = "ImageRolloutInProgress"
+
+	// ImageChannelNotReadyReason documents a VirtualMachineReplicaSet could
+	// not get the image to which the template's image channel resolves.
+	ImageChannelNotReadyReason = "ImageChannelNotReady"
+)
+
+const (
+	// VirtualMachineReplicaSetImageRolloutPolicyNone indicates virtual
+	// machines are not replaced when the template's image channel advances.
+	VirtualMachineReplicaSetImageRolloutPolicyNone = "None"
+
+	// VirtualMachineReplicaSetImageRolloutPolicyReplace indicates virtual
+	// machines deployed from an older image are replaced, one at a time, when
+	// the template's image channel advances.
+	VirtualMachineReplicaSetImageRolloutPolicyReplace = "Replace"
 )
 
 const (
@@ -96,6 +120,19 @@ type VirtualMachineReplicaSetSpec struct {
 	// Template is the object that describes the virtual machine that will be
 	// created if insufficient replicas are detected.
 	Template VirtualMachineTemplateSpec `json:"template,omitempty"`
+
+	// +optional
+	// +kubebuilder:default=None
+	// +kubebuilder:validation:Enum=None;Replace
+	//
+	// ImageRolloutPolicy defines what happens to existing replicas when the
+	// image channel specified by the template's spec.imageChannel advances to
+	// a new image.
+	//
+	// If None, replicas are not replaced. If Replace, replicas deployed from
+	// an older image are deleted one at a time and replaced by replicas
+	// deployed from the channel's current image.
+	ImageRolloutPolicy string `json:"imageRolloutPolicy,omitempty"`
 }
 
 // VirtualMachineReplicaSetStatus represents the observed state of a
@@ -13,6 +13,7 @@ import (
 	"github.com/vmware-tanzu/vm-operator/api/v1alpha4/cloudinit"
 	"github.com/vmware-tanzu/vm-operator/api/v1alpha4/common"
 	"github.com/vmware-tanzu/vm-operator/api/v1alpha4/sysprep"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
@@ -44,6 +45,65 @@ func (in *ClusterVirtualMachineImage) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterVirtualMachineImageCachePolicy) DeepCopyInto(out *ClusterVirtualMachineImageCachePolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterVirtualMachineImageCachePolicy.
+func (in *ClusterVirtualMachineImageCachePolicy) DeepCopy() *ClusterVirtualMachineImageCachePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterVirtualMachineImageCachePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterVirtualMachineImageCachePolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterVirtualMachineImageCachePolicyList) DeepCopyInto(out *ClusterVirtualMachineImageCachePolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ClusterVirtualMachineImageCachePolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterVirtualMachineImageCachePolicyList.
+func (in *ClusterVirtualMachineImageCachePolicyList) DeepCopy() *ClusterVirtualMachineImageCachePolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterVirtualMachineImageCachePolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ClusterVirtualMachineImageCachePolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterVirtualMachineImageList) DeepCopyInto(out *ClusterVirtualMachineImageList) {
 	*out = *in
@@ -738,6 +798,129 @@ func (in *VirtualMachineBootstrapCloudInitSpec) DeepCopy() *VirtualMachineBootst
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineBootstrapDefaults) DeepCopyInto(out *VirtualMachineBootstrapDefaults) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineBootstrapDefaults.
+func (in *VirtualMachineBootstrapDefaults) DeepCopy() *VirtualMachineBootstrapDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineBootstrapDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineBootstrapDefaults) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineBootstrapDefaultsCloudInitSpec) DeepCopyInto(out *VirtualMachineBootstrapDefaultsCloudInitSpec) {
+	*out = *in
+	if in.CloudConfig != nil {
+		in, out := &in.CloudConfig, &out.CloudConfig
+		*out = new(cloudinit.CloudConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RawCloudConfig != nil {
+		in, out := &in.RawCloudConfig, &out.RawCloudConfig
+		*out = new(common.SecretKeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineBootstrapDefaultsCloudInitSpec.
+func (in *VirtualMachineBootstrapDefaultsCloudInitSpec) DeepCopy() *VirtualMachineBootstrapDefaultsCloudInitSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineBootstrapDefaultsCloudInitSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineBootstrapDefaultsList) DeepCopyInto(out *VirtualMachineBootstrapDefaultsList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VirtualMachineBootstrapDefaults, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineBootstrapDefaultsList.
+func (in *VirtualMachineBootstrapDefaultsList) DeepCopy() *VirtualMachineBootstrapDefaultsList {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineBootstrapDefaultsList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineBootstrapDefaultsList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineBootstrapDefaultsSpec) DeepCopyInto(out *VirtualMachineBootstrapDefaultsSpec) {
+	*out = *in
+	if in.CloudInit != nil {
+		in, out := &in.CloudInit, &out.CloudInit
+		*out = new(VirtualMachineBootstrapDefaultsCloudInitSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineBootstrapDefaultsSpec.
+func (in *VirtualMachineBootstrapDefaultsSpec) DeepCopy() *VirtualMachineBootstrapDefaultsSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineBootstrapDefaultsSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineBootstrapIgnitionSpec) DeepCopyInto(out *VirtualMachineBootstrapIgnitionSpec) {
+	*out = *in
+	if in.RawConfig != nil {
+		in, out := &in.RawConfig, &out.RawConfig
+		*out = new(common.SecretKeySelector)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineBootstrapIgnitionSpec.
+func (in *VirtualMachineBootstrapIgnitionSpec) DeepCopy() *VirtualMachineBootstrapIgnitionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineBootstrapIgnitionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineBootstrapLinuxPrepSpec) DeepCopyInto(out *VirtualMachineBootstrapLinuxPrepSpec) {
 	*out = *in
@@ -781,6 +964,11 @@ func (in *VirtualMachineBootstrapSpec) DeepCopyInto(out *VirtualMachineBootstrap
 		*out = new(VirtualMachineBootstrapVAppConfigSpec)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Ignition != nil {
+		in, out := &in.Ignition, &out.Ignition
+		*out = new(VirtualMachineBootstrapIgnitionSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineBootstrapSpec.
@@ -854,6 +1042,11 @@ func (in *VirtualMachineCdromSpec) DeepCopyInto(out *VirtualMachineCdromSpec) {
 		*out = new(bool)
 		**out = **in
 	}
+	if in.DisconnectAfterBootstrap != nil {
+		in, out := &in.DisconnectAfterBootstrap, &out.DisconnectAfterBootstrap
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineCdromSpec.
@@ -1463,6 +1656,10 @@ func (in *VirtualMachineImageCacheLocationStatus) DeepCopyInto(out *VirtualMachi
 		*out = make([]VirtualMachineImageCacheFileStatus, len(*in))
 		copy(*out, *in)
 	}
+	if in.LastUsedTime != nil {
+		in, out := &in.LastUsedTime, &out.LastUsedTime
+		*out = (*in).DeepCopy()
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -1498,110 +1695,78 @@ func (in *VirtualMachineImageCacheOVFStatus) DeepCopy() *VirtualMachineImageCach
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineImageCacheSpec) DeepCopyInto(out *VirtualMachineImageCacheSpec) {
+func (in *VirtualMachineImageCachePolicy) DeepCopyInto(out *VirtualMachineImageCachePolicy) {
 	*out = *in
-	if in.Locations != nil {
-		in, out := &in.Locations, &out.Locations
-		*out = make([]VirtualMachineImageCacheLocationSpec, len(*in))
-		copy(*out, *in)
-	}
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageCacheSpec.
-func (in *VirtualMachineImageCacheSpec) DeepCopy() *VirtualMachineImageCacheSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageCachePolicy.
+func (in *VirtualMachineImageCachePolicy) DeepCopy() *VirtualMachineImageCachePolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineImageCacheSpec)
+	out := new(VirtualMachineImageCachePolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineImageCacheStatus) DeepCopyInto(out *VirtualMachineImageCacheStatus) {
-	*out = *in
-	if in.Locations != nil {
-		in, out := &in.Locations, &out.Locations
-		*out = make([]VirtualMachineImageCacheLocationStatus, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-	if in.OVF != nil {
-		in, out := &in.OVF, &out.OVF
-		*out = new(VirtualMachineImageCacheOVFStatus)
-		**out = **in
-	}
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageCacheStatus.
-func (in *VirtualMachineImageCacheStatus) DeepCopy() *VirtualMachineImageCacheStatus {
-	if in == nil {
-		return nil
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineImageCachePolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
 	}
-	out := new(VirtualMachineImageCacheStatus)
-	in.DeepCopyInto(out)
-	return out
+	return nil
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineImageDiskInfo) DeepCopyInto(out *VirtualMachineImageDiskInfo) {
+func (in *VirtualMachineImageCachePolicyImageStatus) DeepCopyInto(out *VirtualMachineImageCachePolicyImageStatus) {
 	*out = *in
-	if in.Capacity != nil {
-		in, out := &in.Capacity, &out.Capacity
-		x := (*in).DeepCopy()
-		*out = &x
-	}
-	if in.Size != nil {
-		in, out := &in.Size, &out.Size
-		x := (*in).DeepCopy()
-		*out = &x
+	if in.Locations != nil {
+		in, out := &in.Locations, &out.Locations
+		*out = make([]VirtualMachineImageCachePolicyLocationStatus, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageDiskInfo.
-func (in *VirtualMachineImageDiskInfo) DeepCopy() *VirtualMachineImageDiskInfo {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageCachePolicyImageStatus.
+func (in *VirtualMachineImageCachePolicyImageStatus) DeepCopy() *VirtualMachineImageCachePolicyImageStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineImageDiskInfo)
+	out := new(VirtualMachineImageCachePolicyImageStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineImageList) DeepCopyInto(out *VirtualMachineImageList) {
+func (in *VirtualMachineImageCachePolicyList) DeepCopyInto(out *VirtualMachineImageCachePolicyList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]VirtualMachineImage, len(*in))
+		*out = make([]VirtualMachineImageCachePolicy, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageList.
-func (in *VirtualMachineImageList) DeepCopy() *VirtualMachineImageList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageCachePolicyList.
+func (in *VirtualMachineImageCachePolicyList) DeepCopy() *VirtualMachineImageCachePolicyList {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineImageList)
+	out := new(VirtualMachineImageCachePolicyList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *VirtualMachineImageList) DeepCopyObject() runtime.Object {
+func (in *VirtualMachineImageCachePolicyList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -1609,104 +1774,793 @@ func (in *VirtualMachineImageList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineImageOSInfo) DeepCopyInto(out *VirtualMachineImageOSInfo) {
+func (in *VirtualMachineImageCachePolicyLocationStatus) DeepCopyInto(out *VirtualMachineImageCachePolicyLocationStatus) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageOSInfo.
-func (in *VirtualMachineImageOSInfo) DeepCopy() *VirtualMachineImageOSInfo {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageCachePolicyLocationStatus.
+func (in *VirtualMachineImageCachePolicyLocationStatus) DeepCopy() *VirtualMachineImageCachePolicyLocationStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineImageOSInfo)
+	out := new(VirtualMachineImageCachePolicyLocationStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineImageProductInfo) DeepCopyInto(out *VirtualMachineImageProductInfo) {
+func (in *VirtualMachineImageCachePolicySpec) DeepCopyInto(out *VirtualMachineImageCachePolicySpec) {
 	*out = *in
+	if in.ImageNames != nil {
+		in, out := &in.ImageNames, &out.ImageNames
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ImageSelector != nil {
+		in, out := &in.ImageSelector, &out.ImageSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+	in.Target.DeepCopyInto(&out.Target)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageProductInfo.
-func (in *VirtualMachineImageProductInfo) DeepCopy() *VirtualMachineImageProductInfo {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageCachePolicySpec.
+func (in *VirtualMachineImageCachePolicySpec) DeepCopy() *VirtualMachineImageCachePolicySpec {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineImageProductInfo)
+	out := new(VirtualMachineImageCachePolicySpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineImageRef) DeepCopyInto(out *VirtualMachineImageRef) {
+func (in *VirtualMachineImageCachePolicyStatus) DeepCopyInto(out *VirtualMachineImageCachePolicyStatus) {
 	*out = *in
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make([]VirtualMachineImageCachePolicyImageStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageRef.
-func (in *VirtualMachineImageRef) DeepCopy() *VirtualMachineImageRef {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageCachePolicyStatus.
+func (in *VirtualMachineImageCachePolicyStatus) DeepCopy() *VirtualMachineImageCachePolicyStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineImageRef)
+	out := new(VirtualMachineImageCachePolicyStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineImageSpec) DeepCopyInto(out *VirtualMachineImageSpec) {
+func (in *VirtualMachineImageCachePolicyTargetSpec) DeepCopyInto(out *VirtualMachineImageCachePolicyTargetSpec) {
 	*out = *in
-	if in.ProviderRef != nil {
-		in, out := &in.ProviderRef, &out.ProviderRef
-		*out = new(common.LocalObjectRef)
-		**out = **in
+	if in.Zones != nil {
+		in, out := &in.Zones, &out.Zones
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.StorageClasses != nil {
+		in, out := &in.StorageClasses, &out.StorageClasses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Datastores != nil {
+		in, out := &in.Datastores, &out.Datastores
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageCachePolicyTargetSpec.
+func (in *VirtualMachineImageCachePolicyTargetSpec) DeepCopy() *VirtualMachineImageCachePolicyTargetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineImageCachePolicyTargetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineImageCacheSpec) DeepCopyInto(out *VirtualMachineImageCacheSpec) {
+	*out = *in
+	if in.Locations != nil {
+		in, out := &in.Locations, &out.Locations
+		*out = make([]VirtualMachineImageCacheLocationSpec, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageCacheSpec.
+func (in *VirtualMachineImageCacheSpec) DeepCopy() *VirtualMachineImageCacheSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineImageCacheSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineImageCacheStatus) DeepCopyInto(out *VirtualMachineImageCacheStatus) {
+	*out = *in
+	if in.Locations != nil {
+		in, out := &in.Locations, &out.Locations
+		*out = make([]VirtualMachineImageCacheLocationStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.OVF != nil {
+		in, out := &in.OVF, &out.OVF
+		*out = new(VirtualMachineImageCacheOVFStatus)
+		**out = **in
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageCacheStatus.
+func (in *VirtualMachineImageCacheStatus) DeepCopy() *VirtualMachineImageCacheStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineImageCacheStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineImageChannel) DeepCopyInto(out *VirtualMachineImageChannel) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageChannel.
+func (in *VirtualMachineImageChannel) DeepCopy() *VirtualMachineImageChannel {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineImageChannel)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineImageChannel) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineImageChannelList) DeepCopyInto(out *VirtualMachineImageChannelList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VirtualMachineImageChannel, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageChannelList.
+func (in *VirtualMachineImageChannelList) DeepCopy() *VirtualMachineImageChannelList {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineImageChannelList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineImageChannelList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineImageChannelSelector) DeepCopyInto(out *VirtualMachineImageChannelSelector) {
+	*out = *in
+	if in.LabelSelector != nil {
+		in, out := &in.LabelSelector, &out.LabelSelector
+		*out = new(v1.LabelSelector)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageChannelSelector.
+func (in *VirtualMachineImageChannelSelector) DeepCopy() *VirtualMachineImageChannelSelector {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineImageChannelSelector)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineImageChannelSpec) DeepCopyInto(out *VirtualMachineImageChannelSpec) {
+	*out = *in
+	in.Selector.DeepCopyInto(&out.Selector)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageChannelSpec.
+func (in *VirtualMachineImageChannelSpec) DeepCopy() *VirtualMachineImageChannelSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineImageChannelSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineImageChannelStatus) DeepCopyInto(out *VirtualMachineImageChannelStatus) {
+	*out = *in
+	if in.Image != nil {
+		in, out := &in.Image, &out.Image
+		*out = new(VirtualMachineImageRef)
+		**out = **in
+	}
+	if in.LastUpdateTime != nil {
+		in, out := &in.LastUpdateTime, &out.LastUpdateTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageChannelStatus.
+func (in *VirtualMachineImageChannelStatus) DeepCopy() *VirtualMachineImageChannelStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineImageChannelStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineImageDiskInfo) DeepCopyInto(out *VirtualMachineImageDiskInfo) {
+	*out = *in
+	if in.Capacity != nil {
+		in, out := &in.Capacity, &out.Capacity
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Size != nil {
+		in, out := &in.Size, &out.Size
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageDiskInfo.
+func (in *VirtualMachineImageDiskInfo) DeepCopy() *VirtualMachineImageDiskInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineImageDiskInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineImageList) DeepCopyInto(out *VirtualMachineImageList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VirtualMachineImage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageList.
+func (in *VirtualMachineImageList) DeepCopy() *VirtualMachineImageList {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineImageList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineImageList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineImageOCISource) DeepCopyInto(out *VirtualMachineImageOCISource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageOCISource.
+func (in *VirtualMachineImageOCISource) DeepCopy() *VirtualMachineImageOCISource {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineImageOCISource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineImageOSInfo) DeepCopyInto(out *VirtualMachineImageOSInfo) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageOSInfo.
+func (in *VirtualMachineImageOSInfo) DeepCopy() *VirtualMachineImageOSInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineImageOSInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineImageProductInfo) DeepCopyInto(out *VirtualMachineImageProductInfo) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageProductInfo.
+func (in *VirtualMachineImageProductInfo) DeepCopy() *VirtualMachineImageProductInfo {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineImageProductInfo)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineImageRef) DeepCopyInto(out *VirtualMachineImageRef) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageRef.
+func (in *VirtualMachineImageRef) DeepCopy() *VirtualMachineImageRef {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineImageRef)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineImageRefStatus) DeepCopyInto(out *VirtualMachineImageRefStatus) {
+	*out = *in
+	out.VirtualMachineImageRef = in.VirtualMachineImageRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageRefStatus.
+func (in *VirtualMachineImageRefStatus) DeepCopy() *VirtualMachineImageRefStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineImageRefStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineImageSpec) DeepCopyInto(out *VirtualMachineImageSpec) {
+	*out = *in
+	if in.ProviderRef != nil {
+		in, out := &in.ProviderRef, &out.ProviderRef
+		*out = new(common.LocalObjectRef)
+		**out = **in
+	}
+	if in.OCI != nil {
+		in, out := &in.OCI, &out.OCI
+		*out = new(VirtualMachineImageOCISource)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageSpec.
+func (in *VirtualMachineImageSpec) DeepCopy() *VirtualMachineImageSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineImageSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineImageStatus) DeepCopyInto(out *VirtualMachineImageStatus) {
+	*out = *in
+	if in.Capabilities != nil {
+		in, out := &in.Capabilities, &out.Capabilities
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.HardwareVersion != nil {
+		in, out := &in.HardwareVersion, &out.HardwareVersion
+		*out = new(int32)
+		**out = **in
+	}
+	out.OSInfo = in.OSInfo
+	if in.OVFProperties != nil {
+		in, out := &in.OVFProperties, &out.OVFProperties
+		*out = make([]OVFProperty, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VMwareSystemProperties != nil {
+		in, out := &in.VMwareSystemProperties, &out.VMwareSystemProperties
+		*out = make([]common.KeyValuePair, len(*in))
+		copy(*out, *in)
+	}
+	out.ProductInfo = in.ProductInfo
+	if in.Disks != nil {
+		in, out := &in.Disks, &out.Disks
+		*out = make([]VirtualMachineImageDiskInfo, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageStatus.
+func (in *VirtualMachineImageStatus) DeepCopy() *VirtualMachineImageStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineImageStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineImageUpload) DeepCopyInto(out *VirtualMachineImageUpload) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageUpload.
+func (in *VirtualMachineImageUpload) DeepCopy() *VirtualMachineImageUpload {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineImageUpload)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineImageUpload) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineImageUploadList) DeepCopyInto(out *VirtualMachineImageUploadList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VirtualMachineImageUpload, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageUploadList.
+func (in *VirtualMachineImageUploadList) DeepCopy() *VirtualMachineImageUploadList {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineImageUploadList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineImageUploadList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineImageUploadSpec) DeepCopyInto(out *VirtualMachineImageUploadSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageUploadSpec.
+func (in *VirtualMachineImageUploadSpec) DeepCopy() *VirtualMachineImageUploadSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineImageUploadSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineImageUploadStatus) DeepCopyInto(out *VirtualMachineImageUploadStatus) {
+	*out = *in
+	in.ExpirationTime.DeepCopyInto(&out.ExpirationTime)
+	in.CompletionTime.DeepCopyInto(&out.CompletionTime)
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageUploadStatus.
+func (in *VirtualMachineImageUploadStatus) DeepCopy() *VirtualMachineImageUploadStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineImageUploadStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineImportRequest) DeepCopyInto(out *VirtualMachineImportRequest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImportRequest.
+func (in *VirtualMachineImportRequest) DeepCopy() *VirtualMachineImportRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineImportRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineImportRequest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineImportRequestDiskStatus) DeepCopyInto(out *VirtualMachineImportRequestDiskStatus) {
+	*out = *in
+	if in.Capacity != nil {
+		in, out := &in.Capacity, &out.Capacity
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImportRequestDiskStatus.
+func (in *VirtualMachineImportRequestDiskStatus) DeepCopy() *VirtualMachineImportRequestDiskStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineImportRequestDiskStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineImportRequestList) DeepCopyInto(out *VirtualMachineImportRequestList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]VirtualMachineImportRequest, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImportRequestList.
+func (in *VirtualMachineImportRequestList) DeepCopy() *VirtualMachineImportRequestList {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineImportRequestList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *VirtualMachineImportRequestList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineImportRequestNetworkMapping) DeepCopyInto(out *VirtualMachineImportRequestNetworkMapping) {
+	*out = *in
+	if in.Network != nil {
+		in, out := &in.Network, &out.Network
+		*out = new(common.PartialObjectRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImportRequestNetworkMapping.
+func (in *VirtualMachineImportRequestNetworkMapping) DeepCopy() *VirtualMachineImportRequestNetworkMapping {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineImportRequestNetworkMapping)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineImportRequestNetworkStatus) DeepCopyInto(out *VirtualMachineImportRequestNetworkStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImportRequestNetworkStatus.
+func (in *VirtualMachineImportRequestNetworkStatus) DeepCopy() *VirtualMachineImportRequestNetworkStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineImportRequestNetworkStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineImportRequestSource) DeepCopyInto(out *VirtualMachineImportRequestSource) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImportRequestSource.
+func (in *VirtualMachineImportRequestSource) DeepCopy() *VirtualMachineImportRequestSource {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineImportRequestSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineImportRequestSourceStatus) DeepCopyInto(out *VirtualMachineImportRequestSourceStatus) {
+	*out = *in
+	if in.Disks != nil {
+		in, out := &in.Disks, &out.Disks
+		*out = make([]VirtualMachineImportRequestDiskStatus, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Networks != nil {
+		in, out := &in.Networks, &out.Networks
+		*out = make([]VirtualMachineImportRequestNetworkStatus, len(*in))
+		copy(*out, *in)
+	}
+	if in.ConfigSpec != nil {
+		in, out := &in.ConfigSpec, &out.ConfigSpec
+		*out = make(json.RawMessage, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageSpec.
-func (in *VirtualMachineImageSpec) DeepCopy() *VirtualMachineImageSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImportRequestSourceStatus.
+func (in *VirtualMachineImportRequestSourceStatus) DeepCopy() *VirtualMachineImportRequestSourceStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineImageSpec)
+	out := new(VirtualMachineImportRequestSourceStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *VirtualMachineImageStatus) DeepCopyInto(out *VirtualMachineImageStatus) {
+func (in *VirtualMachineImportRequestSpec) DeepCopyInto(out *VirtualMachineImportRequestSpec) {
 	*out = *in
-	if in.Capabilities != nil {
-		in, out := &in.Capabilities, &out.Capabilities
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.HardwareVersion != nil {
-		in, out := &in.HardwareVersion, &out.HardwareVersion
-		*out = new(int32)
-		**out = **in
-	}
-	out.OSInfo = in.OSInfo
-	if in.OVFProperties != nil {
-		in, out := &in.OVFProperties, &out.OVFProperties
-		*out = make([]OVFProperty, len(*in))
+	out.Source = in.Source
+	if in.NetworkMappings != nil {
+		in, out := &in.NetworkMappings, &out.NetworkMappings
+		*out = make([]VirtualMachineImportRequestNetworkMapping, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
-	if in.VMwareSystemProperties != nil {
-		in, out := &in.VMwareSystemProperties, &out.VMwareSystemProperties
-		*out = make([]common.KeyValuePair, len(*in))
-		copy(*out, *in)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImportRequestSpec.
+func (in *VirtualMachineImportRequestSpec) DeepCopy() *VirtualMachineImportRequestSpec {
+	if in == nil {
+		return nil
 	}
-	out.ProductInfo = in.ProductInfo
-	if in.Disks != nil {
-		in, out := &in.Disks, &out.Disks
-		*out = make([]VirtualMachineImageDiskInfo, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	out := new(VirtualMachineImportRequestSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineImportRequestStatus) DeepCopyInto(out *VirtualMachineImportRequestStatus) {
+	*out = *in
+	if in.Source != nil {
+		in, out := &in.Source, &out.Source
+		*out = new(VirtualMachineImportRequestSourceStatus)
+		(*in).DeepCopyInto(*out)
 	}
+	if in.VirtualMachineRef != nil {
+		in, out := &in.VirtualMachineRef, &out.VirtualMachineRef
+		*out = new(common.LocalObjectRef)
+		**out = **in
+	}
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	in.CompletionTime.DeepCopyInto(&out.CompletionTime)
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -1716,12 +2570,12 @@ func (in *VirtualMachineImageStatus) DeepCopyInto(out *VirtualMachineImageStatus
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImageStatus.
-func (in *VirtualMachineImageStatus) DeepCopy() *VirtualMachineImageStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineImportRequestStatus.
+func (in *VirtualMachineImportRequestStatus) DeepCopy() *VirtualMachineImportRequestStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(VirtualMachineImageStatus)
+	out := new(VirtualMachineImportRequestStatus)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -1758,6 +2612,58 @@ func (in *VirtualMachineList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineNetworkBondSpec) DeepCopyInto(out *VirtualMachineNetworkBondSpec) {
+	*out = *in
+	if in.Interfaces != nil {
+		in, out := &in.Interfaces, &out.Interfaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MIIMonitorInterval != nil {
+		in, out := &in.MIIMonitorInterval, &out.MIIMonitorInterval
+		*out = new(int64)
+		**out = **in
+	}
+	in.VirtualMachineNetworkGuestDeviceIPSpec.DeepCopyInto(&out.VirtualMachineNetworkGuestDeviceIPSpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkBondSpec.
+func (in *VirtualMachineNetworkBondSpec) DeepCopy() *VirtualMachineNetworkBondSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineNetworkBondSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineNetworkBridgeSpec) DeepCopyInto(out *VirtualMachineNetworkBridgeSpec) {
+	*out = *in
+	if in.Interfaces != nil {
+		in, out := &in.Interfaces, &out.Interfaces
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.STP != nil {
+		in, out := &in.STP, &out.STP
+		*out = new(bool)
+		**out = **in
+	}
+	in.VirtualMachineNetworkGuestDeviceIPSpec.DeepCopyInto(&out.VirtualMachineNetworkGuestDeviceIPSpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkBridgeSpec.
+func (in *VirtualMachineNetworkBridgeSpec) DeepCopy() *VirtualMachineNetworkBridgeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineNetworkBridgeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineNetworkConfigDHCPOptionsStatus) DeepCopyInto(out *VirtualMachineNetworkConfigDHCPOptionsStatus) {
 	*out = *in
@@ -1861,6 +2767,18 @@ func (in *VirtualMachineNetworkConfigInterfaceStatus) DeepCopyInto(out *VirtualM
 		*out = new(VirtualMachineNetworkConfigDNSStatus)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.QoS != nil {
+		in, out := &in.QoS, &out.QoS
+		*out = new(VirtualMachineNetworkInterfaceQoS)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TrafficRules != nil {
+		in, out := &in.TrafficRules, &out.TrafficRules
+		*out = make([]VirtualMachineNetworkInterfaceTrafficRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkConfigInterfaceStatus.
@@ -1962,6 +2880,46 @@ func (in *VirtualMachineNetworkDNSStatus) DeepCopy() *VirtualMachineNetworkDNSSt
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineNetworkGuestDeviceIPSpec) DeepCopyInto(out *VirtualMachineNetworkGuestDeviceIPSpec) {
+	*out = *in
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MTU != nil {
+		in, out := &in.MTU, &out.MTU
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Nameservers != nil {
+		in, out := &in.Nameservers, &out.Nameservers
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Routes != nil {
+		in, out := &in.Routes, &out.Routes
+		*out = make([]VirtualMachineNetworkRouteSpec, len(*in))
+		copy(*out, *in)
+	}
+	if in.SearchDomains != nil {
+		in, out := &in.SearchDomains, &out.SearchDomains
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkGuestDeviceIPSpec.
+func (in *VirtualMachineNetworkGuestDeviceIPSpec) DeepCopy() *VirtualMachineNetworkGuestDeviceIPSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineNetworkGuestDeviceIPSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineNetworkIPRouteGatewayStatus) DeepCopyInto(out *VirtualMachineNetworkIPRouteGatewayStatus) {
 	*out = *in
@@ -2028,6 +2986,26 @@ func (in *VirtualMachineNetworkIPStackStatus) DeepCopy() *VirtualMachineNetworkI
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineNetworkInterfaceDVXSpec) DeepCopyInto(out *VirtualMachineNetworkInterfaceDVXSpec) {
+	*out = *in
+	if in.ConfigParams != nil {
+		in, out := &in.ConfigParams, &out.ConfigParams
+		*out = make([]common.KeyValuePair, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkInterfaceDVXSpec.
+func (in *VirtualMachineNetworkInterfaceDVXSpec) DeepCopy() *VirtualMachineNetworkInterfaceDVXSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineNetworkInterfaceDVXSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineNetworkInterfaceIPAddrStatus) DeepCopyInto(out *VirtualMachineNetworkInterfaceIPAddrStatus) {
 	*out = *in
@@ -2076,6 +3054,56 @@ func (in *VirtualMachineNetworkInterfaceIPStatus) DeepCopy() *VirtualMachineNetw
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineNetworkInterfaceQoS) DeepCopyInto(out *VirtualMachineNetworkInterfaceQoS) {
+	*out = *in
+	if in.Reservation != nil {
+		in, out := &in.Reservation, &out.Reservation
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Limit != nil {
+		in, out := &in.Limit, &out.Limit
+		*out = new(int64)
+		**out = **in
+	}
+	if in.Shares != nil {
+		in, out := &in.Shares, &out.Shares
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkInterfaceQoS.
+func (in *VirtualMachineNetworkInterfaceQoS) DeepCopy() *VirtualMachineNetworkInterfaceQoS {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineNetworkInterfaceQoS)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineNetworkInterfaceSRIOVSpec) DeepCopyInto(out *VirtualMachineNetworkInterfaceSRIOVSpec) {
+	*out = *in
+	if in.AllowGuestOSMTUChange != nil {
+		in, out := &in.AllowGuestOSMTUChange, &out.AllowGuestOSMTUChange
+		*out = new(bool)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkInterfaceSRIOVSpec.
+func (in *VirtualMachineNetworkInterfaceSRIOVSpec) DeepCopy() *VirtualMachineNetworkInterfaceSRIOVSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineNetworkInterfaceSRIOVSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineNetworkInterfaceSpec) DeepCopyInto(out *VirtualMachineNetworkInterfaceSpec) {
 	*out = *in
@@ -2084,11 +3112,28 @@ func (in *VirtualMachineNetworkInterfaceSpec) DeepCopyInto(out *VirtualMachineNe
 		*out = new(common.PartialObjectRef)
 		**out = **in
 	}
+	if in.SRIOV != nil {
+		in, out := &in.SRIOV, &out.SRIOV
+		*out = new(VirtualMachineNetworkInterfaceSRIOVSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.DVX != nil {
+		in, out := &in.DVX, &out.DVX
+		*out = new(VirtualMachineNetworkInterfaceDVXSpec)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Addresses != nil {
 		in, out := &in.Addresses, &out.Addresses
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.AddressesFromPools != nil {
+		in, out := &in.AddressesFromPools, &out.AddressesFromPools
+		*out = make([]corev1.TypedLocalObjectReference, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.MTU != nil {
 		in, out := &in.MTU, &out.MTU
 		*out = new(int64)
@@ -2109,6 +3154,18 @@ func (in *VirtualMachineNetworkInterfaceSpec) DeepCopyInto(out *VirtualMachineNe
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.QoS != nil {
+		in, out := &in.QoS, &out.QoS
+		*out = new(VirtualMachineNetworkInterfaceQoS)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TrafficRules != nil {
+		in, out := &in.TrafficRules, &out.TrafficRules
+		*out = make([]VirtualMachineNetworkInterfaceTrafficRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkInterfaceSpec.
@@ -2146,6 +3203,26 @@ func (in *VirtualMachineNetworkInterfaceStatus) DeepCopy() *VirtualMachineNetwor
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineNetworkInterfaceTrafficRule) DeepCopyInto(out *VirtualMachineNetworkInterfaceTrafficRule) {
+	*out = *in
+	if in.DestinationPort != nil {
+		in, out := &in.DestinationPort, &out.DestinationPort
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkInterfaceTrafficRule.
+func (in *VirtualMachineNetworkInterfaceTrafficRule) DeepCopy() *VirtualMachineNetworkInterfaceTrafficRule {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineNetworkInterfaceTrafficRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineNetworkRouteSpec) DeepCopyInto(out *VirtualMachineNetworkRouteSpec) {
 	*out = *in
@@ -2201,6 +3278,27 @@ func (in *VirtualMachineNetworkSpec) DeepCopyInto(out *VirtualMachineNetworkSpec
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Bonds != nil {
+		in, out := &in.Bonds, &out.Bonds
+		*out = make([]VirtualMachineNetworkBondSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.VLANs != nil {
+		in, out := &in.VLANs, &out.VLANs
+		*out = make([]VirtualMachineNetworkVLANSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Bridges != nil {
+		in, out := &in.Bridges, &out.Bridges
+		*out = make([]VirtualMachineNetworkBridgeSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkSpec.
@@ -2247,6 +3345,22 @@ func (in *VirtualMachineNetworkStatus) DeepCopy() *VirtualMachineNetworkStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachineNetworkVLANSpec) DeepCopyInto(out *VirtualMachineNetworkVLANSpec) {
+	*out = *in
+	in.VirtualMachineNetworkGuestDeviceIPSpec.DeepCopyInto(&out.VirtualMachineNetworkGuestDeviceIPSpec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachineNetworkVLANSpec.
+func (in *VirtualMachineNetworkVLANSpec) DeepCopy() *VirtualMachineNetworkVLANSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachineNetworkVLANSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachinePlacementStatus) DeepCopyInto(out *VirtualMachinePlacementStatus) {
 	*out = *in
@@ -2347,7 +3461,7 @@ func (in *VirtualMachinePublishRequestSource) DeepCopy() *VirtualMachinePublishR
 func (in *VirtualMachinePublishRequestSpec) DeepCopyInto(out *VirtualMachinePublishRequestSpec) {
 	*out = *in
 	out.Source = in.Source
-	out.Target = in.Target
+	in.Target.DeepCopyInto(&out.Target)
 	if in.TTLSecondsAfterFinished != nil {
 		in, out := &in.TTLSecondsAfterFinished, &out.TTLSecondsAfterFinished
 		*out = new(int64)
@@ -2376,7 +3490,7 @@ func (in *VirtualMachinePublishRequestStatus) DeepCopyInto(out *VirtualMachinePu
 	if in.TargetRef != nil {
 		in, out := &in.TargetRef, &out.TargetRef
 		*out = new(VirtualMachinePublishRequestTarget)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	in.CompletionTime.DeepCopyInto(&out.CompletionTime)
 	in.StartTime.DeepCopyInto(&out.StartTime)
@@ -2404,7 +3518,7 @@ func (in *VirtualMachinePublishRequestStatus) DeepCopy() *VirtualMachinePublishR
 func (in *VirtualMachinePublishRequestTarget) DeepCopyInto(out *VirtualMachinePublishRequestTarget) {
 	*out = *in
 	out.Item = in.Item
-	out.Location = in.Location
+	in.Location.DeepCopyInto(&out.Location)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachinePublishRequestTarget.
@@ -2435,6 +3549,11 @@ func (in *VirtualMachinePublishRequestTargetItem) DeepCopy() *VirtualMachinePubl
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachinePublishRequestTargetLocation) DeepCopyInto(out *VirtualMachinePublishRequestTargetLocation) {
 	*out = *in
+	if in.OCI != nil {
+		in, out := &in.OCI, &out.OCI
+		*out = new(VirtualMachinePublishRequestTargetOCIRegistry)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachinePublishRequestTargetLocation.
@@ -2447,6 +3566,21 @@ func (in *VirtualMachinePublishRequestTargetLocation) DeepCopy() *VirtualMachine
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *VirtualMachinePublishRequestTargetOCIRegistry) DeepCopyInto(out *VirtualMachinePublishRequestTargetOCIRegistry) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new VirtualMachinePublishRequestTargetOCIRegistry.
+func (in *VirtualMachinePublishRequestTargetOCIRegistry) DeepCopy() *VirtualMachinePublishRequestTargetOCIRegistry {
+	if in == nil {
+		return nil
+	}
+	out := new(VirtualMachinePublishRequestTargetOCIRegistry)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *VirtualMachineReadinessProbeSpec) DeepCopyInto(out *VirtualMachineReadinessProbeSpec) {
 	*out = *in
@@ -3041,6 +4175,11 @@ func (in *VirtualMachineStatus) DeepCopyInto(out *VirtualMachineStatus) {
 		*out = new(common.LocalObjectRef)
 		**out = **in
 	}
+	if in.Image != nil {
+		in, out := &in.Image, &out.Image
+		*out = new(VirtualMachineImageRefStatus)
+		**out = **in
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
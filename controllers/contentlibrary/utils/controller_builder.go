@@ -331,6 +331,12 @@ func (r *Reconciler) ReconcileNormal(
 				vmiStatus); syncErr == nil {
 
 				pkgcnd.MarkTrue(vmiStatus, vmopv1.ReadyConditionType)
+
+				r.syncImageFingerprint(
+					ctx,
+					logger,
+					*cliStatus,
+					vmiStatus)
 			}
 
 			didSync = true
@@ -507,6 +513,50 @@ func (r *Reconciler) syncImageContent(
 	return err
 }
 
+// syncImageFingerprint syncs the VirtualMachineImage fingerprint from the
+// checksums of the library item's files. The fingerprint is only recomputed if
+// the content version of the image changed since it was last computed, even if
+// the fingerprint is empty, ex. when the files do not have strong checksums.
+func (r *Reconciler) syncImageFingerprint(
+	ctx context.Context,
+	logger logr.Logger,
+	cliStatus imgregv1a1.ContentLibraryItemStatus,
+	vmiStatus *vmopv1.VirtualMachineImageStatus) {
+
+	if cliStatus.Type != imgregv1a1.ContentLibraryItemTypeOvf {
+		vmiStatus.Fingerprint = ""
+		vmiStatus.FingerprintContentVersion = ""
+		return
+	}
+
+	if vmiStatus.FingerprintContentVersion == vmiStatus.ProviderContentVersion {
+		return
+	}
+
+	// The content changed, so the fingerprint is stale.
+	vmiStatus.Fingerprint = ""
+	vmiStatus.FingerprintContentVersion = ""
+
+	files, err := r.VMProvider.GetContentLibraryItemFiles(
+		ctx, vmiStatus.ProviderItemID)
+	if err != nil {
+		// The fingerprint is not required to deploy the image, so do not fail
+		// the reconcile. The fingerprint is computed on the next reconcile.
+		logger.Error(err, "Failed to get library item files for fingerprint")
+		return
+	}
+
+	vmiStatus.Fingerprint = imgutil.Fingerprint(files)
+	vmiStatus.FingerprintContentVersion = vmiStatus.ProviderContentVersion
+
+	if vmiStatus.Fingerprint == "" {
+		logger.V(4).Info(
+			"Image has no fingerprint since its files do not all have a " +
+				"SHA256 or SHA512 checksum, so it is never considered a " +
+				"duplicate of another image")
+	}
+}
+
 // GetAppropriateFinalizers returns the finalizers for this type of object.
 func GetAppropriateFinalizers(obj client.Object) (string, string) {
 	if obj.GetNamespace() != "" {
@@ -12,6 +12,7 @@ import (
 	"github.com/go-logr/logr"
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	"github.com/vmware/govmomi/vapi/library"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -30,6 +31,7 @@ import (
 	pkgerr "github.com/vmware-tanzu/vm-operator/pkg/errors"
 	providerfake "github.com/vmware-tanzu/vm-operator/pkg/providers/fake"
 	pkgutil "github.com/vmware-tanzu/vm-operator/pkg/util"
+	imgutil "github.com/vmware-tanzu/vm-operator/pkg/util/image"
 	"github.com/vmware-tanzu/vm-operator/pkg/util/ovfcache"
 	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
 	"github.com/vmware-tanzu/vm-operator/test/builder"
@@ -432,6 +434,158 @@ var _ = Describe("Reconcile",
 							Expect(vmiStatus.Firmware).To(Equal(firmwareValue))
 						})
 					})
+
+					When("Library item files have checksums", func() {
+
+						var (
+							files      []library.File
+							filesCalls int
+						)
+
+						BeforeEach(func() {
+							filesCalls = 0
+							files = []library.File{
+								{
+									Name:     "dummy.ovf",
+									Checksum: &library.Checksum{Algorithm: "SHA256", Checksum: "aaaa"},
+								},
+								{
+									Name:     "dummy-disk1.vmdk",
+									Checksum: &library.Checksum{Algorithm: "SHA256", Checksum: "bbbb"},
+								},
+							}
+							fakeVMProvider.GetContentLibraryItemFilesFn = func(_ context.Context, itemID string) ([]library.File, error) {
+								Expect(itemID).To(Equal(string(cliSpec.UUID)))
+								filesCalls++
+								return files, nil
+							}
+						})
+
+						It("should set the image fingerprint", func() {
+							_, err := reconciler.Reconcile(context.Background(), req)
+							Expect(err).ToNot(HaveOccurred())
+
+							_, _, vmiStatus := getVMI(ctx, req.Namespace, vmiName)
+							Expect(vmiStatus.Fingerprint).To(Equal(imgutil.Fingerprint(files)))
+							Expect(vmiStatus.Fingerprint).ToNot(BeEmpty())
+							Expect(vmiStatus.FingerprintContentVersion).To(Equal(cliStatus.ContentVersion))
+						})
+
+						When("Library item files do not have strong checksums", func() {
+
+							BeforeEach(func() {
+								for i := range files {
+									files[i].Checksum.Algorithm = "SHA1"
+								}
+							})
+
+							It("should not get the files again for the same content version", func() {
+								_, err := reconciler.Reconcile(context.Background(), req)
+								Expect(err).ToNot(HaveOccurred())
+
+								_, _, vmiStatus := getVMI(ctx, req.Namespace, vmiName)
+								Expect(vmiStatus.Fingerprint).To(BeEmpty())
+								Expect(vmiStatus.FingerprintContentVersion).To(Equal(cliStatus.ContentVersion))
+								Expect(filesCalls).To(Equal(1))
+
+								_, err = reconciler.Reconcile(context.Background(), req)
+								Expect(err).ToNot(HaveOccurred())
+
+								_, _, vmiStatus = getVMI(ctx, req.Namespace, vmiName)
+								Expect(vmiStatus.Fingerprint).To(BeEmpty())
+								Expect(filesCalls).To(Equal(1))
+							})
+						})
+
+						When("Image resource has a fingerprint and is up-to-date", func() {
+
+							JustBeforeEach(func() {
+								newVMI(
+									ctx,
+									req.Namespace,
+									vmiName,
+									vmopv1.VirtualMachineImageStatus{
+										ProviderContentVersion:    cliStatus.ContentVersion,
+										Fingerprint:               "sha256:existing",
+										FingerprintContentVersion: cliStatus.ContentVersion,
+									})
+							})
+
+							It("should not update the image fingerprint", func() {
+								_, err := reconciler.Reconcile(context.Background(), req)
+								Expect(err).ToNot(HaveOccurred())
+
+								_, _, vmiStatus := getVMI(ctx, req.Namespace, vmiName)
+								Expect(vmiStatus.Fingerprint).To(Equal("sha256:existing"))
+								Expect(filesCalls).To(BeZero())
+							})
+						})
+
+						When("Image resource has an empty fingerprint for its content version", func() {
+
+							JustBeforeEach(func() {
+								newVMI(
+									ctx,
+									req.Namespace,
+									vmiName,
+									vmopv1.VirtualMachineImageStatus{
+										ProviderContentVersion:    cliStatus.ContentVersion,
+										FingerprintContentVersion: cliStatus.ContentVersion,
+									})
+							})
+
+							It("should not compute the image fingerprint again", func() {
+								_, err := reconciler.Reconcile(context.Background(), req)
+								Expect(err).ToNot(HaveOccurred())
+
+								_, _, vmiStatus := getVMI(ctx, req.Namespace, vmiName)
+								Expect(vmiStatus.Fingerprint).To(BeEmpty())
+								Expect(filesCalls).To(BeZero())
+							})
+						})
+
+						When("Image resource has a fingerprint and is not up-to-date", func() {
+
+							JustBeforeEach(func() {
+								newVMI(
+									ctx,
+									req.Namespace,
+									vmiName,
+									vmopv1.VirtualMachineImageStatus{
+										ProviderContentVersion:    "stale",
+										Fingerprint:               "sha256:stale",
+										FingerprintContentVersion: "stale",
+									})
+							})
+
+							It("should update the image fingerprint", func() {
+								_, err := reconciler.Reconcile(context.Background(), req)
+								Expect(err).ToNot(HaveOccurred())
+
+								_, _, vmiStatus := getVMI(ctx, req.Namespace, vmiName)
+								Expect(vmiStatus.Fingerprint).To(Equal(imgutil.Fingerprint(files)))
+							})
+
+							When("Getting the library item files returns an error", func() {
+
+								BeforeEach(func() {
+									fakeVMProvider.GetContentLibraryItemFilesFn = func(_ context.Context, _ string) ([]library.File, error) {
+										return nil, errors.New("files-error")
+									}
+								})
+
+								It("should clear the stale fingerprint without failing", func() {
+									_, err := reconciler.Reconcile(context.Background(), req)
+									Expect(err).ToNot(HaveOccurred())
+
+									_, _, vmiStatus := getVMI(ctx, req.Namespace, vmiName)
+									Expect(vmiStatus.Fingerprint).To(BeEmpty())
+									Expect(vmiStatus.FingerprintContentVersion).To(BeEmpty())
+									Expect(pkgcnd.IsTrue(vmiStatus, vmopv1.ReadyConditionType)).To(BeTrue())
+								})
+							})
+						})
+					})
 				})
 
 				When("Image resource is created and already up-to-date and Status.Disk is not empty", func() {
@@ -16,7 +16,11 @@ import (
 	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachine"
 	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineclass"
 	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachinegroup"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineimage"
 	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineimagecache"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineimagechannel"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineimageupload"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineimportrequest"
 	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachinepublishrequest"
 	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachinereplicaset"
 	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineservice"
@@ -56,6 +60,15 @@ func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr manager.Manager) err
 	if err := virtualmachinepublishrequest.AddToManager(ctx, mgr); err != nil {
 		return fmt.Errorf("failed to initialize VirtualMachinePublishRequest controller: %w", err)
 	}
+	if err := virtualmachineimage.AddToManager(ctx, mgr); err != nil {
+		return fmt.Errorf("failed to initialize VirtualMachineImage controllers: %w", err)
+	}
+	if err := virtualmachineimagechannel.AddToManager(ctx, mgr); err != nil {
+		return fmt.Errorf("failed to initialize VirtualMachineImageChannel controller: %w", err)
+	}
+	if err := virtualmachineimageupload.AddToManager(ctx, mgr); err != nil {
+		return fmt.Errorf("failed to initialize VirtualMachineImageUpload controller: %w", err)
+	}
 
 	if pkgcfg.FromContext(ctx).Features.K8sWorkloadMgmtAPI {
 		if err := virtualmachinereplicaset.AddToManager(ctx, mgr); err != nil {
@@ -80,6 +93,12 @@ func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr manager.Manager) err
 		return fmt.Errorf("failed to initialize VirtualMachineSnapshot controller: %w", err)
 	}
 
+	if pkgcfg.FromContext(ctx).Features.VMImportNewNet {
+		if err := virtualmachineimportrequest.AddToManager(ctx, mgr); err != nil {
+			return fmt.Errorf("failed to initialize VirtualMachineImportRequest controller: %w", err)
+		}
+	}
+
 	if pkgcfg.FromContext(ctx).Features.VMGroups {
 		if err := virtualmachinegroup.AddToManager(ctx, mgr); err != nil {
 			return fmt.Errorf("failed to initialize VMG controller: %w", err)
@@ -0,0 +1,237 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachineimage
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-logr/logr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	pkgcond "github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/patch"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/contentlibrary"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/oci"
+)
+
+// ImageTypeOCI is the status.type of an image backed by an OCI artifact. It
+// is distinct from the content library item types because the image cannot
+// be deployed from or cached like a content library item.
+const ImageTypeOCI = "OCI"
+
+// SkipNameValidation is used for testing to allow multiple controllers with the
+// same name since Controller-Runtime has a global singleton registry to
+// prevent controllers with the same name, even if attached to different
+// managers.
+var SkipNameValidation *bool
+
+// AddToManager adds the controllers for the VirtualMachineImage and
+// ClusterVirtualMachineImage resources backed by OCI artifacts to the
+// provided manager.
+func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr manager.Manager) error {
+	for _, controlledType := range []ctrlclient.Object{
+		&vmopv1.VirtualMachineImage{},
+		&vmopv1.ClusterVirtualMachineImage{},
+	} {
+		var (
+			controlledTypeName  = reflect.TypeOf(controlledType).Elem().Name()
+			controllerNameShort = fmt.Sprintf("%s-oci-controller", strings.ToLower(controlledTypeName))
+		)
+
+		r := NewReconciler(
+			ctx,
+			mgr.GetClient(),
+			ctx.Logger.WithName("controllers").WithName(controlledTypeName),
+			controlledTypeName)
+
+		if err := ctrl.NewControllerManagedBy(mgr).
+			Named(controllerNameShort).
+			For(controlledType).
+			WithEventFilter(predicate.NewPredicateFuncs(hasOCISource)).
+			WithOptions(controller.Options{
+				MaxConcurrentReconciles: ctx.MaxConcurrentReconciles,
+				SkipNameValidation:      SkipNameValidation,
+			}).
+			Complete(r); err != nil {
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+// hasOCISource returns true if the object is an image backed by an OCI
+// artifact.
+func hasOCISource(obj ctrlclient.Object) bool {
+	switch o := obj.(type) {
+	case *vmopv1.VirtualMachineImage:
+		return o.Spec.OCI != nil
+	case *vmopv1.ClusterVirtualMachineImage:
+		return o.Spec.OCI != nil
+	}
+	return false
+}
+
+// NewReconciler returns a new reconciler for images backed by OCI artifacts.
+func NewReconciler(
+	ctx context.Context,
+	client ctrlclient.Client,
+	logger logr.Logger,
+	kind string) *Reconciler {
+
+	return &Reconciler{
+		Context: ctx,
+		Client:  client,
+		Logger:  logger,
+		Kind:    kind,
+	}
+}
+
+// Reconciler reconciles a VirtualMachineImage or ClusterVirtualMachineImage
+// backed by an OCI artifact by updating the image's status from the OVF
+// descriptor in the artifact.
+type Reconciler struct {
+	ctrlclient.Client
+	Context context.Context
+	Logger  logr.Logger
+	Kind    string
+}
+
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineimages,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineimages/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=clustervirtualmachineimages,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=clustervirtualmachineimages/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+
+func (r *Reconciler) Reconcile(
+	ctx context.Context,
+	req ctrl.Request) (_ ctrl.Result, reterr error) {
+
+	ctx = pkgcfg.JoinContext(ctx, r.Context)
+
+	var (
+		obj       ctrlclient.Object
+		spec      *vmopv1.VirtualMachineImageSpec
+		status    *vmopv1.VirtualMachineImageStatus
+		namespace string
+	)
+
+	if req.Namespace != "" {
+		var o vmopv1.VirtualMachineImage
+		if err := r.Get(ctx, req.NamespacedName, &o); err != nil {
+			return ctrl.Result{}, ctrlclient.IgnoreNotFound(err)
+		}
+		obj, spec, status, namespace = &o, &o.Spec, &o.Status, o.Namespace
+	} else {
+		var o vmopv1.ClusterVirtualMachineImage
+		if err := r.Get(ctx, req.NamespacedName, &o); err != nil {
+			return ctrl.Result{}, ctrlclient.IgnoreNotFound(err)
+		}
+		// The credentials for a cluster image are read from VM Operator's
+		// namespace.
+		obj, spec, status, namespace = &o, &o.Spec, &o.Status, pkgcfg.FromContext(ctx).PodNamespace
+	}
+
+	if spec.OCI == nil || !obj.GetDeletionTimestamp().IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	logger := r.Logger.WithValues("name", req.NamespacedName, "reference", spec.OCI.Reference)
+
+	patchHelper, err := patch.NewHelper(obj, r.Client)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf(
+			"failed to init patch helper for %s: %w", req.NamespacedName, err)
+	}
+	defer func() {
+		if err := patchHelper.Patch(ctx, obj); err != nil {
+			if reterr == nil {
+				reterr = err
+			}
+			logger.Error(err, "patch failed")
+		}
+	}()
+
+	if err := r.ReconcileNormal(ctx, obj, *spec.OCI, status, namespace); err != nil {
+		logger.Error(err, "Failed to sync image from OCI artifact")
+		pkgcond.MarkFalse(
+			status,
+			vmopv1.ReadyConditionType,
+			vmopv1.VirtualMachineImageNotSyncedReason,
+			"%s", err.Error())
+		return ctrl.Result{}, err
+	}
+
+	pkgcond.MarkTrue(status, vmopv1.ReadyConditionType)
+	logger.Info("Successfully synced image from OCI artifact",
+		"contentVersion", status.ProviderContentVersion)
+
+	return ctrl.Result{}, nil
+}
+
+// ReconcileNormal updates the status of the image from the OVF descriptor in
+// the OCI artifact.
+func (r *Reconciler) ReconcileNormal(
+	ctx context.Context,
+	obj ctrlclient.Object,
+	src vmopv1.VirtualMachineImageOCISource,
+	status *vmopv1.VirtualMachineImageStatus,
+	namespace string) error {
+
+	ref, err := oci.ParseReference(src.Reference)
+	if err != nil {
+		return err
+	}
+
+	// An artifact referenced by digest never changes, so there is no need to
+	// get it again once the image is synced.
+	if ref.Digest != "" &&
+		ref.Digest == status.ProviderContentVersion &&
+		pkgcond.IsTrue(status, vmopv1.ReadyConditionType) {
+
+		return nil
+	}
+
+	creds, err := oci.GetCredentials(
+		ctx,
+		r.Client,
+		namespace,
+		src.CredentialsSecretName,
+		ref.Registry)
+	if err != nil {
+		return err
+	}
+
+	env, manifest, desc, err := oci.GetOVF(
+		ctx,
+		oci.NewClient(creds, src.Insecure),
+		ref)
+	if err != nil {
+		return err
+	}
+
+	contentlibrary.UpdateVmiWithOvfEnvelope(obj, *env)
+
+	status.Name = manifest.Annotations[oci.AnnotationOVFName]
+	if status.Name == "" {
+		status.Name = obj.GetName()
+	}
+	status.Type = ImageTypeOCI
+	status.ProviderItemID = ref.WithDigest(desc.Digest).String()
+	status.ProviderContentVersion = desc.Digest
+
+	return nil
+}
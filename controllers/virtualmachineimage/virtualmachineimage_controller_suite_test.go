@@ -0,0 +1,24 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachineimage_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineimage"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+)
+
+func TestVirtualMachineImageController(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "VirtualMachineImage Controller Test Suite")
+}
+
+var _ = BeforeSuite(func() {
+	virtualmachineimage.SkipNameValidation = ptr.To(true)
+})
@@ -0,0 +1,245 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachineimage_test
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineimage"
+	pkgcond "github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/oci"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+const ovfDescriptor = `<?xml version="1.0" encoding="UTF-8"?>
+<Envelope xmlns="http://schemas.dmtf.org/ovf/envelope/1" xmlns:ovf="http://schemas.dmtf.org/ovf/envelope/1" xmlns:vmw="http://www.vmware.com/schema/ovf">
+  <References>
+    <File ovf:href="my-image-disk-0.vmdk" ovf:id="file1"/>
+  </References>
+  <DiskSection>
+    <Info>Virtual disk information</Info>
+    <Disk ovf:capacity="10" ovf:capacityAllocationUnits="byte * 2^30" ovf:diskId="vmdisk1" ovf:fileRef="file1"/>
+  </DiskSection>
+  <VirtualSystem ovf:id="my-image">
+    <Info>A virtual machine</Info>
+    <OperatingSystemSection ovf:id="94" vmw:osType="ubuntu64Guest">
+      <Info>The kind of installed guest operating system</Info>
+    </OperatingSystemSection>
+  </VirtualSystem>
+</Envelope>
+`
+
+var _ = Describe(
+	"Reconcile",
+	Label(
+		testlabels.Controller,
+	),
+	func() {
+
+		const (
+			namespace    = "my-namespace"
+			podNamespace = "vmop-system"
+		)
+
+		var (
+			ctx          context.Context
+			registry     *builder.OCIRegistry
+			client       ctrlclient.Client
+			objs         []ctrlclient.Object
+			digest       string
+			reconcileErr error
+		)
+
+		BeforeEach(func() {
+			ctx = pkgcfg.WithConfig(pkgcfg.Config{PodNamespace: podNamespace})
+			registry = builder.NewOCIRegistry()
+			objs = nil
+
+			ref, err := oci.ParseReference(registry.Host() + "/my-project/my-image:v1")
+			Expect(err).ToNot(HaveOccurred())
+			desc, err := oci.PushOVF(
+				ctx,
+				oci.NewClient(oci.Credentials{}, true),
+				ref,
+				[]byte(ovfDescriptor),
+				nil,
+				nil)
+			Expect(err).ToNot(HaveOccurred())
+			digest = desc.Digest
+		})
+
+		AfterEach(func() {
+			registry.Close()
+		})
+
+		reconcile := func(obj ctrlclient.Object) {
+			client = builder.NewFakeClient(append(objs, obj)...)
+			reconciler := virtualmachineimage.NewReconciler(
+				ctx,
+				client,
+				logr.Discard(),
+				"")
+			_, reconcileErr = reconciler.Reconcile(
+				ctx,
+				ctrl.Request{NamespacedName: ctrlclient.ObjectKeyFromObject(obj)})
+		}
+
+		assertSynced := func(status vmopv1.VirtualMachineImageStatus) {
+			ExpectWithOffset(1, pkgcond.IsTrue(&status, vmopv1.ReadyConditionType)).To(BeTrue())
+			ExpectWithOffset(1, status.Name).To(Equal("my-image"))
+			ExpectWithOffset(1, status.Type).To(Equal(virtualmachineimage.ImageTypeOCI))
+			ExpectWithOffset(1, status.ProviderItemID).To(Equal(
+				registry.Host() + "/my-project/my-image@" + digest))
+			ExpectWithOffset(1, status.ProviderContentVersion).To(Equal(digest))
+			ExpectWithOffset(1, status.OSInfo.Type).To(Equal("ubuntu64Guest"))
+			ExpectWithOffset(1, status.Disks).To(HaveLen(1))
+		}
+
+		When("the image is namespaced", func() {
+			var vmi *vmopv1.VirtualMachineImage
+
+			BeforeEach(func() {
+				vmi = &vmopv1.VirtualMachineImage{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: namespace,
+						Name:      "vmi-1",
+					},
+					Spec: vmopv1.VirtualMachineImageSpec{
+						OCI: &vmopv1.VirtualMachineImageOCISource{
+							Reference: registry.Host() + "/my-project/my-image:v1",
+							Insecure:  true,
+						},
+					},
+				}
+			})
+
+			getImage := func() vmopv1.VirtualMachineImage {
+				var obj vmopv1.VirtualMachineImage
+				ExpectWithOffset(1, client.Get(
+					ctx, ctrlclient.ObjectKeyFromObject(vmi), &obj)).To(Succeed())
+				return obj
+			}
+
+			It("should sync the image from the artifact", func() {
+				reconcile(vmi)
+				Expect(reconcileErr).ToNot(HaveOccurred())
+				assertSynced(getImage().Status)
+			})
+
+			When("the image references the artifact by digest", func() {
+				BeforeEach(func() {
+					vmi.Spec.OCI.Reference = registry.Host() + "/my-project/my-image@" + digest
+				})
+				It("should sync the image from the artifact", func() {
+					reconcile(vmi)
+					Expect(reconcileErr).ToNot(HaveOccurred())
+					assertSynced(getImage().Status)
+				})
+			})
+
+			When("the registry requires credentials", func() {
+				BeforeEach(func() {
+					registry.Username, registry.Password = "my-user", "my-pass"
+					vmi.Spec.OCI.CredentialsSecretName = "my-secret"
+				})
+
+				When("the secret exists", func() {
+					BeforeEach(func() {
+						objs = append(objs, &corev1.Secret{
+							ObjectMeta: metav1.ObjectMeta{
+								Namespace: namespace,
+								Name:      "my-secret",
+							},
+							Type: corev1.SecretTypeBasicAuth,
+							Data: map[string][]byte{
+								corev1.BasicAuthUsernameKey: []byte("my-user"),
+								corev1.BasicAuthPasswordKey: []byte("my-pass"),
+							},
+						})
+					})
+					It("should sync the image from the artifact", func() {
+						reconcile(vmi)
+						Expect(reconcileErr).ToNot(HaveOccurred())
+						assertSynced(getImage().Status)
+					})
+				})
+
+				When("the secret does not exist", func() {
+					It("should mark the image as not synced", func() {
+						reconcile(vmi)
+						Expect(reconcileErr).To(HaveOccurred())
+						obj := getImage()
+						Expect(pkgcond.IsFalse(&obj, vmopv1.ReadyConditionType)).To(BeTrue())
+						Expect(pkgcond.GetReason(&obj, vmopv1.ReadyConditionType)).To(
+							Equal(vmopv1.VirtualMachineImageNotSyncedReason))
+					})
+				})
+			})
+
+			When("the artifact does not exist", func() {
+				BeforeEach(func() {
+					vmi.Spec.OCI.Reference = registry.Host() + "/my-project/my-image:v2"
+				})
+				It("should mark the image as not synced", func() {
+					reconcile(vmi)
+					Expect(reconcileErr).To(HaveOccurred())
+					Expect(oci.IsNotFound(reconcileErr)).To(BeTrue())
+					obj := getImage()
+					Expect(pkgcond.IsFalse(&obj, vmopv1.ReadyConditionType)).To(BeTrue())
+				})
+			})
+		})
+
+		When("the image is cluster-scoped", func() {
+			var cvmi *vmopv1.ClusterVirtualMachineImage
+
+			BeforeEach(func() {
+				registry.Username, registry.Password = "my-user", "my-pass"
+				cvmi = &vmopv1.ClusterVirtualMachineImage{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "vmi-1",
+					},
+					Spec: vmopv1.VirtualMachineImageSpec{
+						OCI: &vmopv1.VirtualMachineImageOCISource{
+							Reference:             registry.Host() + "/my-project/my-image:v1",
+							CredentialsSecretName: "my-secret",
+							Insecure:              true,
+						},
+					},
+				}
+				objs = append(objs, &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: podNamespace,
+						Name:      "my-secret",
+					},
+					Type: corev1.SecretTypeBasicAuth,
+					Data: map[string][]byte{
+						corev1.BasicAuthUsernameKey: []byte("my-user"),
+						corev1.BasicAuthPasswordKey: []byte("my-pass"),
+					},
+				})
+			})
+
+			It("should sync the image with credentials from the pod namespace", func() {
+				reconcile(cvmi)
+				Expect(reconcileErr).ToNot(HaveOccurred())
+
+				var obj vmopv1.ClusterVirtualMachineImage
+				Expect(client.Get(ctx, ctrlclient.ObjectKeyFromObject(cvmi), &obj)).To(Succeed())
+				assertSynced(obj.Status)
+			})
+		})
+	})
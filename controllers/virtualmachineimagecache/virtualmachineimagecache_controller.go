@@ -74,7 +74,20 @@ func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr manager.Manager) err
 		newSRIClientFn: newCacheStorageURIsClientOrDefault(ctx),
 	}
 
-	return ctrl.NewControllerManagedBy(mgr).
+	if pkgcfg.FromContext(ctx).ImageCacheGC.Enabled {
+		// Add the garbage collector explicitly as a runnable in order to
+		// receive a Start() event.
+		if err := mgr.Add(NewImageCacheGC(
+			ctx,
+			mgr.GetClient(),
+			r.Logger,
+			ctx.VMProvider)); err != nil {
+
+			return err
+		}
+	}
+
+	if err := ctrl.NewControllerManagedBy(mgr).
 		For(controlledType).
 		WithOptions(controller.Options{
 			SkipNameValidation: SkipNameValidation,
@@ -82,7 +95,12 @@ func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr manager.Manager) err
 		WatchesRawSource(source.Channel(
 			cource.FromContextWithBuffer(ctx, "VirtualMachineImageCache", 100),
 			&handler.EnqueueRequestForObject{})).
-		Complete(r)
+		Complete(r); err != nil {
+
+		return err
+	}
+
+	return addPolicyControllersToManager(ctx, mgr)
 }
 
 // reconciler reconciles a VirtualMachineImageCache object.
@@ -139,8 +157,12 @@ func (r *reconciler) ReconcileNormal(
 	ctx context.Context,
 	obj *vmopv1.VirtualMachineImageCache) (retErr error) {
 
-	// Reset the version status so it is constructed from scratch each time.
+	// Reset the version status so it is constructed from scratch each time,
+	// except for the time each location was last used, which cannot be
+	// reconstructed.
+	lastUsedTimes := getLocationLastUsedTimes(obj)
 	obj.Status = vmopv1.VirtualMachineImageCacheStatus{}
+	defer setLocationLastUsedTimes(obj, lastUsedTimes)
 
 	// If the reconcile failed with an error, then make sure it is reflected in
 	// the object's Ready condition.
@@ -510,6 +532,42 @@ func reconcileLibraryItem(
 	return nil
 }
 
+type locationKey struct {
+	datacenterID string
+	datastoreID  string
+}
+
+func getLocationLastUsedTimes(
+	obj *vmopv1.VirtualMachineImageCache) map[locationKey]*metav1.Time {
+
+	out := map[locationKey]*metav1.Time{}
+	for i := range obj.Status.Locations {
+		l := obj.Status.Locations[i]
+		if l.LastUsedTime != nil {
+			out[locationKey{l.DatacenterID, l.DatastoreID}] = l.LastUsedTime
+		}
+	}
+	return out
+}
+
+// setLocationLastUsedTimes restores the time each location was last used. A
+// location that is ready but has never been used is considered used now so
+// its files are not immediately eligible for eviction.
+func setLocationLastUsedTimes(
+	obj *vmopv1.VirtualMachineImageCache,
+	lastUsedTimes map[locationKey]*metav1.Time) {
+
+	for i := range obj.Status.Locations {
+		l := &obj.Status.Locations[i]
+		if t, ok := lastUsedTimes[locationKey{l.DatacenterID, l.DatastoreID}]; ok {
+			l.LastUsedTime = t
+		} else if pkgcond.IsTrue(*l, vmopv1.ReadyConditionType) {
+			now := metav1.Now()
+			l.LastUsedTime = &now
+		}
+	}
+}
+
 type datastore struct {
 	datacenterID string
 	mo           mo.Datastore
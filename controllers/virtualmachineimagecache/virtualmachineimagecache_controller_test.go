@@ -36,6 +36,7 @@ import (
 	providerfake "github.com/vmware-tanzu/vm-operator/pkg/providers/fake"
 	clprov "github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/contentlibrary"
 	"github.com/vmware-tanzu/vm-operator/pkg/util/kube/cource"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ova"
 	vsclient "github.com/vmware-tanzu/vm-operator/pkg/util/vsphere/client"
 	clsutil "github.com/vmware-tanzu/vm-operator/pkg/util/vsphere/library"
 	"github.com/vmware-tanzu/vm-operator/test/builder"
@@ -763,6 +764,13 @@ func (m *fakeClient) ListLibraryItemStorage(
 	return nil, nil
 }
 
+func (m *fakeClient) ListLibraryItemFiles(
+	ctx context.Context,
+	itemID string) ([]library.File, error) {
+
+	return nil, nil
+}
+
 func (m *fakeClient) ResolveLibraryItemStorage(
 	ctx context.Context,
 	datacenter *object.Datacenter,
@@ -785,6 +793,14 @@ func (m *fakeClient) CreateLibraryItem(
 	return nil
 }
 
+func (m *fakeClient) CreateLibraryItemFromOVA(
+	_ context.Context,
+	_ library.Item,
+	_ *ova.Reader) (string, error) {
+
+	return "", nil
+}
+
 const ovfEnvelopeYAML = `
 diskSection:
   disk:
@@ -0,0 +1,572 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachineimagecache
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/vmware/govmomi/fault"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/mo"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	"github.com/vmware-tanzu/vm-operator/pkg/metrics"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers"
+	clsutil "github.com/vmware-tanzu/vm-operator/pkg/util/vsphere/library"
+)
+
+var (
+	// LocationRemovedPollInterval and LocationRemovedTimeout are vars so tests
+	// can change them.
+	LocationRemovedPollInterval = 1 * time.Second
+	LocationRemovedTimeout      = 2 * time.Minute
+)
+
+// ImageCacheGC periodically evicts the files cached by
+// VirtualMachineImageCache resources from datastores.
+type ImageCacheGC struct {
+	client     ctrlclient.Client
+	logger     logr.Logger
+	vmProvider providers.VirtualMachineProviderInterface
+	metrics    *metrics.VMICacheMetrics
+	namespace  string
+	config     pkgcfg.ImageCacheGC
+}
+
+// NewImageCacheGC returns a new ImageCacheGC configured from the ImageCacheGC
+// section of the config in the provided context.
+func NewImageCacheGC(
+	ctx context.Context,
+	client ctrlclient.Client,
+	logger logr.Logger,
+	vmProvider providers.VirtualMachineProviderInterface) *ImageCacheGC {
+
+	cfg := pkgcfg.FromContext(ctx)
+
+	return &ImageCacheGC{
+		client:     client,
+		logger:     logger.WithName("gc"),
+		vmProvider: vmProvider,
+		metrics:    metrics.NewVMICacheMetrics(),
+		namespace:  cfg.PodNamespace,
+		config:     cfg.ImageCacheGC,
+	}
+}
+
+// NeedLeaderElection returns true so only the leader evicts files.
+func (gc *ImageCacheGC) NeedLeaderElection() bool {
+	return true
+}
+
+// Start runs the garbage collection at the configured interval until the
+// provided context is cancelled.
+func (gc *ImageCacheGC) Start(ctx context.Context) error {
+	gc.logger.Info("Starting image cache garbage collection",
+		"interval", gc.config.Interval,
+		"dryRun", gc.config.DryRun)
+
+	ticker := time.NewTicker(gc.config.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := gc.Run(ctx); err != nil {
+				gc.logger.Error(err, "Image cache garbage collection failed")
+			}
+		}
+	}
+}
+
+// currentLocation is a location of a VirtualMachineImageCache resource that
+// has the files for the resource's current provider version.
+type currentLocation struct {
+	key      ctrlclient.ObjectKey
+	location vmopv1.VirtualMachineImageCacheLocationSpec
+	lastUsed time.Time
+	pinned   bool
+}
+
+// Run evicts the cached files selected by the configured policy from each of
+// the accessible datastores in the datacenter.
+func (gc *ImageCacheGC) Run(ctx context.Context) error {
+	ctx = logr.NewContext(ctx, gc.logger)
+
+	c, err := gc.vmProvider.VSphereClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get vSphere client: %w", err)
+	}
+
+	var (
+		vimClient  = c.VimClient()
+		datacenter = c.Datacenter()
+	)
+
+	var list vmopv1.VirtualMachineImageCacheList
+	if err := gc.client.List(
+		ctx,
+		&list,
+		ctrlclient.InNamespace(gc.namespace)); err != nil {
+
+		return fmt.Errorf("failed to list image cache resources: %w", err)
+	}
+
+	datastores, err := getAllDatastores(ctx, vimClient, datacenter)
+	if err != nil {
+		return err
+	}
+
+	pinned, err := gc.getPinnedLocations(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Get the cache directories for the current version of each item.
+	currentLocations := map[string]currentLocation{}
+	for i := range list.Items {
+		obj := list.Items[i]
+		if obj.Spec.ProviderID == "" || obj.Spec.ProviderVersion == "" {
+			continue
+		}
+		for j := range obj.Spec.Locations {
+			l := obj.Spec.Locations[j]
+			ds, ok := datastores[l.DatastoreID]
+			if !ok {
+				continue
+			}
+			cl := currentLocation{
+				key:      ctrlclient.ObjectKeyFromObject(&obj),
+				location: l,
+				pinned: pinned[pinnedLocation{
+					cacheName:    obj.Name,
+					datacenterID: l.DatacenterID,
+					datastoreID:  l.DatastoreID,
+				}],
+			}
+			for k := range obj.Status.Locations {
+				s := obj.Status.Locations[k]
+				if s.DatacenterID == l.DatacenterID &&
+					s.DatastoreID == l.DatastoreID &&
+					s.LastUsedTime != nil {
+
+					cl.lastUsed = s.LastUsedTime.Time
+				}
+			}
+			dir := clsutil.GetCacheDirForLibraryItem(
+				getTopLevelCacheDir(ds.Name),
+				obj.Spec.ProviderID,
+				obj.Spec.ProviderVersion)
+			currentLocations[dir] = cl
+		}
+	}
+
+	for _, ds := range datastores {
+		if !ds.Summary.Accessible {
+			continue
+		}
+		if err := gc.runForDatastore(
+			ctx,
+			vimClient,
+			datacenter,
+			ds,
+			currentLocations); err != nil {
+
+			gc.logger.Error(err, "Failed to evict cached files",
+				"datastore", ds.Name)
+		}
+	}
+
+	return nil
+}
+
+type pinnedLocation struct {
+	cacheName    string
+	datacenterID string
+	datastoreID  string
+}
+
+// getPinnedLocations returns the image cache locations targeted by the
+// VirtualMachineImageCachePolicy and ClusterVirtualMachineImageCachePolicy
+// resources.
+func (gc *ImageCacheGC) getPinnedLocations(
+	ctx context.Context) (map[pinnedLocation]bool, error) {
+
+	var statuses []vmopv1.VirtualMachineImageCachePolicyStatus
+
+	var list vmopv1.VirtualMachineImageCachePolicyList
+	if err := gc.client.List(ctx, &list); err != nil {
+		return nil, fmt.Errorf("failed to list image cache policies: %w", err)
+	}
+	for i := range list.Items {
+		statuses = append(statuses, list.Items[i].Status)
+	}
+
+	var clusterList vmopv1.ClusterVirtualMachineImageCachePolicyList
+	if err := gc.client.List(ctx, &clusterList); err != nil {
+		return nil, fmt.Errorf(
+			"failed to list cluster image cache policies: %w", err)
+	}
+	for i := range clusterList.Items {
+		statuses = append(statuses, clusterList.Items[i].Status)
+	}
+
+	out := map[pinnedLocation]bool{}
+	for i := range statuses {
+		for _, img := range statuses[i].Images {
+			for _, l := range img.Locations {
+				out[pinnedLocation{
+					cacheName:    img.CacheName,
+					datacenterID: l.DatacenterID,
+					datastoreID:  l.DatastoreID,
+				}] = true
+			}
+		}
+	}
+	return out, nil
+}
+
+func (gc *ImageCacheGC) runForDatastore(
+	ctx context.Context,
+	vimClient *vim25.Client,
+	datacenter *object.Datacenter,
+	ds mo.Datastore,
+	currentLocations map[string]currentLocation) error {
+
+	logger := gc.logger.WithValues("datastore", ds.Name)
+	tld := getTopLevelCacheDir(ds.Name)
+
+	// Get the cache directories on the datastore.
+	results, err := searchTopLevelCacheDir(ctx, vimClient, ds, tld)
+	if err != nil {
+		return err
+	}
+	entries := clsutil.GetItemCacheEntries(tld, results)
+	if len(entries) == 0 {
+		return nil
+	}
+
+	// Get the cache directories used as parents by the datastore's VMs.
+	references, ok, err := getParentDiskDirs(ctx, vimClient, ds.Vm)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		// The parent disks of a VM without a config are not known, so any of
+		// the cache directories may be in use.
+		logger.Info("Skipping eviction as a VM on the datastore has no config")
+		return nil
+	}
+
+	for i := range entries {
+		e := &entries[i]
+		if cl, ok := currentLocations[e.Dir]; ok {
+			e.Current = true
+			e.LastUsed = cl.lastUsed
+			e.Pinned = cl.pinned
+		}
+		e.References = references[e.Dir]
+		if e.References > 0 {
+			gc.metrics.RegisterReferences(logger, ds.Name, e.Dir, e.References)
+		} else {
+			gc.metrics.DeleteReferences(logger, ds.Name, e.Dir)
+		}
+	}
+
+	evictions := clsutil.SelectItemCacheEvictions(
+		time.Now(),
+		clsutil.ItemCacheGCPolicy{
+			MaxUnusedAge:        gc.config.MaxUnusedAge,
+			MinUnusedAge:        gc.config.MinUnusedAge,
+			MaxItems:            gc.config.MaxImagesPerDatastore,
+			MinFreeSpacePercent: gc.config.MinFreeSpacePercent,
+		},
+		entries,
+		clsutil.DatastoreSpace{
+			Capacity:  ds.Summary.Capacity,
+			FreeSpace: ds.Summary.FreeSpace,
+		})
+
+	fileManager := object.NewFileManager(vimClient)
+
+	for i := range evictions {
+		e := evictions[i]
+
+		logger.Info("Evicting cached files",
+			"dir", e.Dir,
+			"itemID", e.ItemID,
+			"reason", e.Reason,
+			"size", e.Size,
+			"lastUsed", e.LastUsed,
+			"dryRun", gc.config.DryRun)
+
+		if !gc.config.DryRun {
+			// Remove the location from the image cache resource first so the
+			// files are not cached again while they are being deleted.
+			if cl, ok := currentLocations[e.Dir]; ok {
+				if err := gc.removeLocation(ctx, cl); err != nil {
+					logger.Error(err, "Failed to remove image cache location",
+						"dir", e.Dir)
+					continue
+				}
+
+				// A VM may be linked cloned from the files as long as the
+				// location is ready in the resource's status, so wait for the
+				// image cache controller to remove it.
+				if err := gc.waitForLocationRemoved(ctx, cl); err != nil {
+					logger.Error(err, "Failed to wait for image cache location to be removed",
+						"dir", e.Dir)
+					continue
+				}
+			}
+
+			// Check the files again now that no more VMs may be created from
+			// them, since a VM may have been linked cloned from them after the
+			// parent disks of the datastore's VMs were first checked.
+			inUse, err := isParentDiskDir(ctx, vimClient, ds, e.Dir)
+			if err != nil {
+				logger.Error(err, "Failed to check if cached files are in use",
+					"dir", e.Dir)
+				continue
+			}
+			if inUse {
+				logger.Info("Skipping eviction of cached files that are in use",
+					"dir", e.Dir)
+				continue
+			}
+
+			if err := deleteDir(ctx, fileManager, datacenter, e.Dir); err != nil {
+				logger.Error(err, "Failed to delete cached files", "dir", e.Dir)
+				continue
+			}
+		}
+
+		gc.metrics.RegisterGCEviction(
+			logger, ds.Name, string(e.Reason), e.Size, gc.config.DryRun)
+	}
+
+	return nil
+}
+
+func (gc *ImageCacheGC) removeLocation(
+	ctx context.Context,
+	cl currentLocation) error {
+
+	var obj vmopv1.VirtualMachineImageCache
+	if err := gc.client.Get(ctx, cl.key, &obj); err != nil {
+		return ctrlclient.IgnoreNotFound(err)
+	}
+
+	objPatch := ctrlclient.MergeFromWithOptions(
+		obj.DeepCopy(),
+		ctrlclient.MergeFromWithOptimisticLock{})
+
+	obj.Spec.Locations = slices.DeleteFunc(
+		obj.Spec.Locations,
+		func(l vmopv1.VirtualMachineImageCacheLocationSpec) bool {
+			return l == cl.location
+		})
+
+	return gc.client.Patch(ctx, &obj, objPatch)
+}
+
+// waitForLocationRemoved waits until the location removed from the image
+// cache resource's spec is no longer in its status.
+func (gc *ImageCacheGC) waitForLocationRemoved(
+	ctx context.Context,
+	cl currentLocation) error {
+
+	return wait.PollUntilContextTimeout(
+		ctx,
+		LocationRemovedPollInterval,
+		LocationRemovedTimeout,
+		true,
+		func(ctx context.Context) (bool, error) {
+			var obj vmopv1.VirtualMachineImageCache
+			if err := gc.client.Get(ctx, cl.key, &obj); err != nil {
+				return apierrors.IsNotFound(err), ctrlclient.IgnoreNotFound(err)
+			}
+			for _, l := range obj.Status.Locations {
+				if l.DatacenterID == cl.location.DatacenterID &&
+					l.DatastoreID == cl.location.DatastoreID {
+
+					return false, nil
+				}
+			}
+			return true, nil
+		})
+}
+
+// isParentDiskDir returns true if a file in the directory is used as a parent
+// by the disks of the datastore's VMs, or if that is not known.
+func isParentDiskDir(
+	ctx context.Context,
+	vimClient *vim25.Client,
+	ds mo.Datastore,
+	dir string) (bool, error) {
+
+	// Get the datastore's VMs again since more may have been created.
+	var moDS mo.Datastore
+	if err := property.DefaultCollector(vimClient).RetrieveOne(
+		ctx,
+		ds.Reference(),
+		[]string{"vm"},
+		&moDS); err != nil {
+
+		return false, fmt.Errorf("failed to get datastore properties: %w", err)
+	}
+
+	references, ok, err := getParentDiskDirs(ctx, vimClient, moDS.Vm)
+	if err != nil {
+		return false, err
+	}
+	return !ok || references[dir] > 0, nil
+}
+
+func getTopLevelCacheDir(datastoreName string) string {
+	return fmt.Sprintf("[%s] %s", datastoreName, clsutil.TopLevelCacheDirName)
+}
+
+func getAllDatastores(
+	ctx context.Context,
+	vimClient *vim25.Client,
+	datacenter *object.Datacenter) (map[string]mo.Datastore, error) {
+
+	var (
+		moDC mo.Datacenter
+		pc   = property.DefaultCollector(vimClient)
+	)
+
+	if err := pc.RetrieveOne(
+		ctx,
+		datacenter.Reference(),
+		[]string{"datastore"},
+		&moDC); err != nil {
+
+		return nil, fmt.Errorf("failed to get datacenter properties: %w", err)
+	}
+
+	if len(moDC.Datastore) == 0 {
+		return nil, nil
+	}
+
+	var moList []mo.Datastore
+	if err := pc.Retrieve(
+		ctx,
+		moDC.Datastore,
+		[]string{"name", "summary", "vm"},
+		&moList); err != nil {
+
+		return nil, fmt.Errorf("failed to get datastore properties: %w", err)
+	}
+
+	out := make(map[string]mo.Datastore, len(moList))
+	for i := range moList {
+		out[moList[i].Reference().Value] = moList[i]
+	}
+	return out, nil
+}
+
+func searchTopLevelCacheDir(
+	ctx context.Context,
+	vimClient *vim25.Client,
+	ds mo.Datastore,
+	tld string) ([]vimtypes.HostDatastoreBrowserSearchResults, error) {
+
+	browser, err := object.NewDatastore(vimClient, ds.Reference()).Browser(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get datastore browser: %w", err)
+	}
+
+	task, err := browser.SearchDatastoreSubFolders(
+		ctx,
+		tld,
+		&vimtypes.HostDatastoreBrowserSearchSpec{
+			Details: &vimtypes.FileQueryFlags{
+				FileSize:     true,
+				Modification: true,
+			},
+		})
+	if err != nil {
+		return nil, fmt.Errorf("failed to search %q: %w", tld, err)
+	}
+
+	info, err := task.WaitForResult(ctx)
+	if err != nil {
+		if fault.Is(err, &vimtypes.FileNotFound{}) {
+			// There is no top-level cache directory on the datastore.
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to search %q: %w", tld, err)
+	}
+
+	results, _ := info.Result.(vimtypes.ArrayOfHostDatastoreBrowserSearchResults)
+	return results.HostDatastoreBrowserSearchResults, nil
+}
+
+// getParentDiskDirs returns the number of disks of the VMs that use a file in
+// each directory as a parent. False is returned if any of the VMs does not
+// have a config, ex. because it is still being created, since its disks are
+// not known.
+func getParentDiskDirs(
+	ctx context.Context,
+	vimClient *vim25.Client,
+	vmRefs []vimtypes.ManagedObjectReference) (map[string]int, bool, error) {
+
+	if len(vmRefs) == 0 {
+		return nil, true, nil
+	}
+
+	var (
+		moList []mo.VirtualMachine
+		pc     = property.DefaultCollector(vimClient)
+	)
+
+	if err := pc.Retrieve(
+		ctx,
+		vmRefs,
+		[]string{"config.hardware.device"},
+		&moList); err != nil {
+
+		return nil, false, fmt.Errorf("failed to get vm properties: %w", err)
+	}
+
+	out := map[string]int{}
+	for i := range moList {
+		if moList[i].Config == nil {
+			return nil, false, nil
+		}
+		dirs := clsutil.GetParentDiskDirs(moList[i].Config.Hardware.Device)
+		for k, v := range dirs {
+			out[k] += v
+		}
+	}
+	return out, true, nil
+}
+
+func deleteDir(
+	ctx context.Context,
+	fileManager *object.FileManager,
+	datacenter *object.Datacenter,
+	dir string) error {
+
+	task, err := fileManager.DeleteDatastoreFile(ctx, dir, datacenter)
+	if err != nil {
+		return err
+	}
+	return task.Wait(ctx)
+}
@@ -0,0 +1,329 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachineimagecache_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/simulator"
+	"github.com/vmware/govmomi/vim25/mo"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineimagecache"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	providerfake "github.com/vmware-tanzu/vm-operator/pkg/providers/fake"
+	vsclient "github.com/vmware-tanzu/vm-operator/pkg/util/vsphere/client"
+	clsutil "github.com/vmware-tanzu/vm-operator/pkg/util/vsphere/library"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+var _ = Describe(
+	"ImageCacheGC",
+	Label(
+		testlabels.Controller,
+		testlabels.VCSim,
+	),
+	func() {
+
+		const (
+			itemID      = "my-item-id"
+			itemVersion = "my-item-version"
+		)
+
+		var (
+			ctx        *builder.TestContextForVCSim
+			gc         *virtualmachineimagecache.ImageCacheGC
+			dryRun     bool
+			cacheObj   *vmopv1.VirtualMachineImageCache
+			cacheDir   string
+			namespace  string
+			datastore  mo.Datastore
+			datacenter string
+		)
+
+		BeforeEach(func() {
+			dryRun = false
+			cacheObj = nil
+		})
+
+		JustBeforeEach(func() {
+			ctx = builder.NewTestContextForVCSim(
+				pkgcfg.NewContextWithDefaultConfig(),
+				builder.VCSimTestConfig{})
+
+			pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+				config.ImageCacheGC.Enabled = true
+				config.ImageCacheGC.DryRun = dryRun
+				config.ImageCacheGC.MinUnusedAge = 0
+			})
+
+			namespace = pkgcfg.FromContext(ctx).PodNamespace
+			datacenter = ctx.Datacenter.Reference().Value
+
+			Expect(ctx.Datastore.Properties(
+				ctx,
+				ctx.Datastore.Reference(),
+				[]string{"name", "vm"},
+				&datastore)).To(Succeed())
+
+			cacheDir = clsutil.GetCacheDirForLibraryItem(
+				fmt.Sprintf("[%s] %s",
+					datastore.Name, clsutil.TopLevelCacheDirName),
+				itemID,
+				itemVersion)
+
+			Expect(object.NewFileManager(ctx.VCClient.Client).MakeDirectory(
+				ctx,
+				cacheDir,
+				ctx.Datacenter,
+				true)).To(Succeed())
+
+			if cacheObj != nil {
+				cacheObj.Namespace = namespace
+				cacheObj.Spec.Locations[0].DatacenterID = datacenter
+				cacheObj.Spec.Locations[0].DatastoreID = datastore.Reference().Value
+				status := cacheObj.Status.DeepCopy()
+				Expect(ctx.Client.Create(ctx, cacheObj)).To(Succeed())
+				for i := range status.Locations {
+					status.Locations[i].DatacenterID = datacenter
+					status.Locations[i].DatastoreID = datastore.Reference().Value
+				}
+				cacheObj.Status = *status
+				Expect(ctx.Client.Status().Update(ctx, cacheObj)).To(Succeed())
+			}
+
+			provider := providerfake.NewVMProvider()
+			provider.VSphereClientFn = func(c context.Context) (*vsclient.Client, error) {
+				return vsclient.NewClient(c, ctx.VCClientConfig)
+			}
+
+			gc = virtualmachineimagecache.NewImageCacheGC(
+				ctx,
+				ctx.Client,
+				logr.Discard(),
+				provider)
+		})
+
+		AfterEach(func() {
+			ctx.AfterEach()
+			ctx = nil
+		})
+
+		cacheDirExists := func() bool {
+			var p object.DatastorePath
+			ExpectWithOffset(1, p.FromString(cacheDir)).To(BeTrue())
+			_, err := ctx.Datastore.Stat(ctx, p.Path)
+			if err != nil {
+				ExpectWithOffset(1, err).To(BeAssignableToTypeOf(
+					object.DatastoreNoSuchFileError{}))
+				return false
+			}
+			return true
+		}
+
+		getCacheLocations := func() []vmopv1.VirtualMachineImageCacheLocationSpec {
+			var obj vmopv1.VirtualMachineImageCache
+			ExpectWithOffset(1, ctx.Client.Get(
+				ctx,
+				ctrlclient.ObjectKeyFromObject(cacheObj),
+				&obj)).To(Succeed())
+			return obj.Spec.Locations
+		}
+
+		When("the cached files are superseded", func() {
+			It("should delete the files", func() {
+				Expect(cacheDirExists()).To(BeTrue())
+				Expect(gc.Run(ctx)).To(Succeed())
+				Expect(cacheDirExists()).To(BeFalse())
+			})
+
+			When("dry-run is enabled", func() {
+				BeforeEach(func() {
+					dryRun = true
+				})
+				It("should not delete the files", func() {
+					Expect(gc.Run(ctx)).To(Succeed())
+					Expect(cacheDirExists()).To(BeTrue())
+				})
+			})
+
+			When("a vm on the datastore does not have a config", func() {
+				JustBeforeEach(func() {
+					Expect(datastore.Vm).ToNot(BeEmpty())
+					sctx := ctx.SimulatorContext()
+					sctx.WithLock(
+						datastore.Vm[0],
+						func() {
+							vm := sctx.Map.Get(datastore.Vm[0]).(*simulator.VirtualMachine)
+							vm.Config = nil
+						})
+				})
+				It("should not delete the files", func() {
+					Expect(gc.Run(ctx)).To(Succeed())
+					Expect(cacheDirExists()).To(BeTrue())
+				})
+			})
+		})
+
+		When("the cached files are current", func() {
+			var (
+				onLocationRemoved func()
+				stopController    chan struct{}
+				controllerDone    chan struct{}
+			)
+
+			BeforeEach(func() {
+				onLocationRemoved = nil
+				virtualmachineimagecache.LocationRemovedPollInterval = 10 * time.Millisecond
+				cacheObj = &vmopv1.VirtualMachineImageCache{
+					ObjectMeta: metav1.ObjectMeta{
+						Name: "my-cache",
+					},
+					Spec: vmopv1.VirtualMachineImageCacheSpec{
+						ProviderID:      itemID,
+						ProviderVersion: itemVersion,
+						Locations: []vmopv1.VirtualMachineImageCacheLocationSpec{
+							{},
+						},
+					},
+					Status: vmopv1.VirtualMachineImageCacheStatus{
+						Locations: []vmopv1.VirtualMachineImageCacheLocationStatus{
+							{
+								LastUsedTime: &metav1.Time{
+									Time: time.Now().Add(-30 * 24 * time.Hour),
+								},
+							},
+						},
+					},
+				}
+			})
+
+			JustBeforeEach(func() {
+				// Simulate the image cache controller removing the locations
+				// from the status that are no longer in the spec.
+				stopController = make(chan struct{})
+				controllerDone = make(chan struct{})
+				go func() {
+					defer close(controllerDone)
+					for {
+						select {
+						case <-stopController:
+							return
+						case <-time.After(10 * time.Millisecond):
+						}
+						var obj vmopv1.VirtualMachineImageCache
+						if err := ctx.Client.Get(
+							ctx,
+							ctrlclient.ObjectKeyFromObject(cacheObj),
+							&obj); err != nil {
+
+							continue
+						}
+						if len(obj.Spec.Locations) > 0 || len(obj.Status.Locations) == 0 {
+							continue
+						}
+						if onLocationRemoved != nil {
+							onLocationRemoved()
+						}
+						obj.Status.Locations = nil
+						_ = ctx.Client.Status().Update(ctx, &obj)
+					}
+				}()
+			})
+
+			AfterEach(func() {
+				close(stopController)
+				<-controllerDone
+				virtualmachineimagecache.LocationRemovedPollInterval = time.Second
+			})
+
+			When("the files have not been used for longer than the max unused age", func() {
+				It("should delete the files and remove the location", func() {
+					Expect(gc.Run(ctx)).To(Succeed())
+					Expect(cacheDirExists()).To(BeFalse())
+					Expect(getCacheLocations()).To(BeEmpty())
+				})
+
+				When("a vm is linked cloned from the files before they are deleted", func() {
+					BeforeEach(func() {
+						onLocationRemoved = func() {
+							sctx := ctx.SimulatorContext()
+							sctx.WithLock(
+								datastore.Vm[0],
+								func() {
+									vm := sctx.Map.Get(datastore.Vm[0]).(*simulator.VirtualMachine)
+									for _, d := range vm.Config.Hardware.Device {
+										if disk, ok := d.(*vimtypes.VirtualDisk); ok {
+											backing := disk.Backing.(*vimtypes.VirtualDiskFlatVer2BackingInfo)
+											backing.Parent = &vimtypes.VirtualDiskFlatVer2BackingInfo{
+												VirtualDeviceFileBackingInfo: vimtypes.VirtualDeviceFileBackingInfo{
+													FileName: cacheDir + "/disk.vmdk",
+												},
+											}
+										}
+									}
+								})
+							onLocationRemoved = nil
+						}
+					})
+					It("should not delete the files", func() {
+						Expect(gc.Run(ctx)).To(Succeed())
+						Expect(cacheDirExists()).To(BeTrue())
+						Expect(getCacheLocations()).To(BeEmpty())
+					})
+				})
+			})
+
+			When("the files have been used recently", func() {
+				BeforeEach(func() {
+					cacheObj.Status.Locations[0].LastUsedTime.Time = time.Now()
+				})
+				It("should not delete the files", func() {
+					Expect(gc.Run(ctx)).To(Succeed())
+					Expect(cacheDirExists()).To(BeTrue())
+					Expect(getCacheLocations()).To(HaveLen(1))
+				})
+			})
+
+			When("the location is pinned by a policy", func() {
+				JustBeforeEach(func() {
+					policy := &vmopv1.ClusterVirtualMachineImageCachePolicy{
+						ObjectMeta: metav1.ObjectMeta{
+							Name: "my-policy",
+						},
+					}
+					Expect(ctx.Client.Create(ctx, policy)).To(Succeed())
+					policy.Status.Images = []vmopv1.VirtualMachineImageCachePolicyImageStatus{
+						{
+							Name:      "vmi-1",
+							CacheName: cacheObj.Name,
+							Locations: []vmopv1.VirtualMachineImageCachePolicyLocationStatus{
+								{
+									DatacenterID: datacenter,
+									DatastoreID:  datastore.Reference().Value,
+								},
+							},
+						},
+					}
+					Expect(ctx.Client.Status().Update(ctx, policy)).To(Succeed())
+				})
+				It("should not delete the files", func() {
+					Expect(gc.Run(ctx)).To(Succeed())
+					Expect(cacheDirExists()).To(BeTrue())
+					Expect(getCacheLocations()).To(HaveLen(1))
+				})
+			})
+		})
+	})
@@ -0,0 +1,735 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachineimagecache
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"github.com/vmware/govmomi/pbm"
+	pbmtypes "github.com/vmware/govmomi/pbm/types"
+	"github.com/vmware/govmomi/vim25"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+	storagev1 "k8s.io/api/storage/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	pkgcond "github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	pkgerr "github.com/vmware-tanzu/vm-operator/pkg/errors"
+	"github.com/vmware-tanzu/vm-operator/pkg/patch"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers"
+	"github.com/vmware-tanzu/vm-operator/pkg/topology"
+	pkgutil "github.com/vmware-tanzu/vm-operator/pkg/util"
+	kubeutil "github.com/vmware-tanzu/vm-operator/pkg/util/kube"
+)
+
+// policyRequeueAfter is how long to wait before reconciling a policy again
+// while its images are still being cached.
+const policyRequeueAfter = 30 * time.Second
+
+// reconcileImageCachesMu serializes the policies that add locations to image
+// cache resources, since the maximum number of concurrent copies applies to
+// the locations of all of the image cache resources.
+var reconcileImageCachesMu sync.Mutex
+
+// addPolicyControllersToManager adds the controllers for the
+// VirtualMachineImageCachePolicy and ClusterVirtualMachineImageCachePolicy
+// resources to the provided manager.
+func addPolicyControllersToManager(
+	ctx *pkgctx.ControllerManagerContext,
+	mgr manager.Manager) error {
+
+	for _, cluster := range []bool{false, true} {
+		var (
+			controlledType ctrlclient.Object = &vmopv1.VirtualMachineImageCachePolicy{}
+			imageType      ctrlclient.Object = &vmopv1.VirtualMachineImage{}
+		)
+		if cluster {
+			controlledType = &vmopv1.ClusterVirtualMachineImageCachePolicy{}
+			imageType = &vmopv1.ClusterVirtualMachineImage{}
+		}
+		controlledTypeName := reflect.TypeOf(controlledType).Elem().Name()
+
+		r := NewPolicyReconciler(
+			ctx,
+			mgr.GetClient(),
+			ctx.Logger.WithName("controllers").WithName(controlledTypeName),
+			ctx.VMProvider,
+			cluster)
+
+		if err := ctrl.NewControllerManagedBy(mgr).
+			For(controlledType).
+			Watches(
+				imageType,
+				handler.EnqueueRequestsFromMapFunc(r.imageToPolicies)).
+			WithOptions(controller.Options{
+				SkipNameValidation: SkipNameValidation,
+			}).
+			Complete(r); err != nil {
+
+			return err
+		}
+	}
+
+	return nil
+}
+
+// NewPolicyReconciler returns a new PolicyReconciler. If cluster is true,
+// the reconciler is for ClusterVirtualMachineImageCachePolicy objects,
+// otherwise it is for VirtualMachineImageCachePolicy objects.
+func NewPolicyReconciler(
+	ctx context.Context,
+	client ctrlclient.Client,
+	logger logr.Logger,
+	vmProvider providers.VirtualMachineProviderInterface,
+	cluster bool) *PolicyReconciler {
+
+	return &PolicyReconciler{
+		Context:    ctx,
+		Client:     client,
+		Logger:     logger,
+		VMProvider: vmProvider,
+		cluster:    cluster,
+	}
+}
+
+// PolicyReconciler reconciles a VirtualMachineImageCachePolicy or a
+// ClusterVirtualMachineImageCachePolicy object.
+type PolicyReconciler struct {
+	ctrlclient.Client
+	Context    context.Context
+	Logger     logr.Logger
+	VMProvider providers.VirtualMachineProviderInterface
+
+	// cluster is true if the reconciler is for
+	// ClusterVirtualMachineImageCachePolicy objects.
+	cluster bool
+}
+
+// policyObject is a VirtualMachineImageCachePolicy or a
+// ClusterVirtualMachineImageCachePolicy.
+type policyObject interface {
+	ctrlclient.Object
+	pkgcond.Setter
+}
+
+func (r *PolicyReconciler) newPolicyObject() policyObject {
+	if r.cluster {
+		return &vmopv1.ClusterVirtualMachineImageCachePolicy{}
+	}
+	return &vmopv1.VirtualMachineImageCachePolicy{}
+}
+
+func getPolicySpecAndStatus(
+	obj policyObject) (
+	*vmopv1.VirtualMachineImageCachePolicySpec,
+	*vmopv1.VirtualMachineImageCachePolicyStatus) {
+
+	switch tObj := obj.(type) {
+	case *vmopv1.VirtualMachineImageCachePolicy:
+		return &tObj.Spec, &tObj.Status
+	case *vmopv1.ClusterVirtualMachineImageCachePolicy:
+		return &tObj.Spec, &tObj.Status
+	}
+	panic(fmt.Sprintf("unexpected policy type %T", obj))
+}
+
+// imageToPolicies enqueues the policies that may select the provided image.
+func (r *PolicyReconciler) imageToPolicies(
+	ctx context.Context,
+	o ctrlclient.Object) []reconcile.Request {
+
+	var (
+		requests []reconcile.Request
+		keys     []ctrlclient.ObjectKey
+	)
+
+	if r.cluster {
+		var list vmopv1.ClusterVirtualMachineImageCachePolicyList
+		if err := r.List(ctx, &list); err != nil {
+			r.Logger.Error(err, "Failed to list policies")
+			return nil
+		}
+		for i := range list.Items {
+			keys = append(keys, ctrlclient.ObjectKeyFromObject(&list.Items[i]))
+		}
+	} else {
+		var list vmopv1.VirtualMachineImageCachePolicyList
+		if err := r.List(
+			ctx,
+			&list,
+			ctrlclient.InNamespace(o.GetNamespace())); err != nil {
+
+			r.Logger.Error(err, "Failed to list policies")
+			return nil
+		}
+		for i := range list.Items {
+			keys = append(keys, ctrlclient.ObjectKeyFromObject(&list.Items[i]))
+		}
+	}
+
+	for i := range keys {
+		requests = append(requests, reconcile.Request{NamespacedName: keys[i]})
+	}
+
+	return requests
+}
+
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineimagecachepolicies,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineimagecachepolicies/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=clustervirtualmachineimagecachepolicies,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=clustervirtualmachineimagecachepolicies/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineimages,verbs=get;list;watch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=clustervirtualmachineimages,verbs=get;list;watch
+// +kubebuilder:rbac:groups=storage.k8s.io,resources=storageclasses,verbs=get;list;watch
+
+func (r *PolicyReconciler) Reconcile(
+	ctx context.Context,
+	req ctrl.Request) (_ ctrl.Result, reterr error) {
+
+	ctx = pkgcfg.JoinContext(ctx, r.Context)
+
+	obj := r.newPolicyObject()
+	if err := r.Get(ctx, req.NamespacedName, obj); err != nil {
+		return ctrl.Result{}, ctrlclient.IgnoreNotFound(err)
+	}
+
+	logger := r.Logger.WithValues("name", req.NamespacedName)
+	ctx = logr.NewContext(ctx, logger)
+
+	patchHelper, err := patch.NewHelper(obj, r.Client)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf(
+			"failed to init patch helper for %s: %w", req.NamespacedName, err)
+	}
+	defer func() {
+		if err := patchHelper.Patch(ctx, obj); err != nil {
+			if reterr == nil {
+				reterr = err
+			}
+			logger.Error(err, "patch failed")
+		}
+	}()
+
+	if !obj.GetDeletionTimestamp().IsZero() {
+		// Noop.
+		return ctrl.Result{}, nil
+	}
+
+	return pkgerr.ResultFromError(r.ReconcileNormal(ctx, obj))
+}
+
+// policyImage is an image selected by a policy.
+type policyImage struct {
+	name        string
+	itemID      string
+	itemVersion string
+}
+
+func (r *PolicyReconciler) ReconcileNormal(
+	ctx context.Context,
+	obj policyObject) (retErr error) {
+
+	spec, status := getPolicySpecAndStatus(obj)
+	status.ObservedGeneration = obj.GetGeneration()
+
+	// If the reconcile failed with an error, then make sure it is reflected in
+	// the object's Ready condition.
+	defer func() {
+		if retErr != nil {
+			pkgcond.MarkError(
+				obj,
+				vmopv1.ReadyConditionType,
+				conditionReasonFailed,
+				retErr)
+		}
+	}()
+
+	// Get the images selected by the policy.
+	images, err := r.getImages(ctx, obj.GetNamespace(), *spec)
+	if err != nil {
+		pkgcond.MarkError(
+			obj,
+			vmopv1.VirtualMachineImageCachePolicyConditionImagesReady,
+			conditionReasonFailed,
+			err)
+		return err
+	}
+	pkgcond.MarkTrue(
+		obj,
+		vmopv1.VirtualMachineImageCachePolicyConditionImagesReady)
+
+	// Get a vSphere client.
+	c, err := r.VMProvider.VSphereClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get vSphere client: %w", err)
+	}
+
+	// Get the datastores targeted by the policy.
+	datastoreIDs, err := getPolicyDatastoreIDs(
+		ctx,
+		r.Client,
+		c.VimClient(),
+		obj.GetNamespace(),
+		spec.Target)
+	if err != nil {
+		pkgcond.MarkError(
+			obj,
+			vmopv1.VirtualMachineImageCachePolicyConditionTargetsReady,
+			conditionReasonFailed,
+			err)
+		return err
+	}
+	pkgcond.MarkTrue(
+		obj,
+		vmopv1.VirtualMachineImageCachePolicyConditionTargetsReady)
+
+	// Reconcile the image cache resources.
+	numReady, numTotal, err := r.reconcileImageCaches(
+		ctx,
+		c.Datacenter().Reference().Value,
+		datastoreIDs,
+		images,
+		*spec,
+		status)
+	if err != nil {
+		pkgcond.MarkError(
+			obj,
+			vmopv1.VirtualMachineImageCachePolicyConditionFilesReady,
+			conditionReasonFailed,
+			err)
+		return err
+	}
+
+	if numReady < numTotal {
+		pkgcond.MarkFalse(
+			obj,
+			vmopv1.VirtualMachineImageCachePolicyConditionFilesReady,
+			"Caching",
+			"%d of %d locations are cached",
+			numReady, numTotal)
+	} else {
+		pkgcond.MarkTrue(
+			obj,
+			vmopv1.VirtualMachineImageCachePolicyConditionFilesReady)
+	}
+
+	// Create the object's Ready condition based on its other conditions.
+	pkgcond.SetSummary(obj, pkgcond.WithStepCounter())
+
+	if numReady < numTotal {
+		return pkgerr.RequeueError{After: policyRequeueAfter}
+	}
+
+	return nil
+}
+
+// getImages returns the images selected by the policy. Images that are not
+// OVFs, and so cannot be cached, and images that are not yet ready are
+// ignored.
+func (r *PolicyReconciler) getImages(
+	ctx context.Context,
+	namespace string,
+	spec vmopv1.VirtualMachineImageCachePolicySpec) ([]policyImage, error) {
+
+	type image struct {
+		name   string
+		labels map[string]string
+		status vmopv1.VirtualMachineImageStatus
+	}
+
+	var all []image
+	if r.cluster {
+		var list vmopv1.ClusterVirtualMachineImageList
+		if err := r.List(ctx, &list); err != nil {
+			return nil, fmt.Errorf("failed to list images: %w", err)
+		}
+		for i := range list.Items {
+			o := list.Items[i]
+			all = append(all, image{o.Name, o.Labels, o.Status})
+		}
+	} else {
+		var list vmopv1.VirtualMachineImageList
+		if err := r.List(
+			ctx,
+			&list,
+			ctrlclient.InNamespace(namespace)); err != nil {
+
+			return nil, fmt.Errorf("failed to list images: %w", err)
+		}
+		for i := range list.Items {
+			o := list.Items[i]
+			all = append(all, image{o.Name, o.Labels, o.Status})
+		}
+	}
+
+	selector := labels.Nothing()
+	if spec.ImageSelector != nil {
+		s, err := metav1.LabelSelectorAsSelector(spec.ImageSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid image selector: %w", err)
+		}
+		selector = s
+	}
+
+	var (
+		out      []policyImage
+		notFound []string
+	)
+
+	for _, name := range spec.ImageNames {
+		if !slices.ContainsFunc(all, func(i image) bool { return i.name == name }) {
+			notFound = append(notFound, name)
+		}
+	}
+	if len(notFound) > 0 {
+		return nil, fmt.Errorf(
+			"images not found: %s", strings.Join(notFound, ", "))
+	}
+
+	for i := range all {
+		img := all[i]
+		if !slices.Contains(spec.ImageNames, img.name) &&
+			!selector.Matches(labels.Set(img.labels)) {
+
+			continue
+		}
+		if img.status.Type != "OVF" ||
+			img.status.ProviderItemID == "" ||
+			img.status.ProviderContentVersion == "" {
+
+			continue
+		}
+		out = append(out, policyImage{
+			name:        img.name,
+			itemID:      img.status.ProviderItemID,
+			itemVersion: img.status.ProviderContentVersion,
+		})
+	}
+
+	slices.SortFunc(out, func(a, b policyImage) int {
+		return strings.Compare(a.name, b.name)
+	})
+
+	return out, nil
+}
+
+// reconcileImageCaches ensures there is a VirtualMachineImageCache resource
+// for each image with a location for each datastore. Locations are only added
+// while fewer than spec.MaxConcurrentCopies locations of all of the image
+// cache resources, including those of other policies, are not yet cached. The
+// number of cached locations and the total number of locations are returned.
+func (r *PolicyReconciler) reconcileImageCaches(
+	ctx context.Context,
+	datacenterID string,
+	datastoreIDs []string,
+	images []policyImage,
+	spec vmopv1.VirtualMachineImageCachePolicySpec,
+	status *vmopv1.VirtualMachineImageCachePolicyStatus) (int, int, error) {
+
+	var (
+		numReady    int
+		numTotal    = len(images) * len(datastoreIDs)
+		maxInFlight = int(spec.MaxConcurrentCopies)
+		caches      = make([]vmopv1.VirtualMachineImageCache, len(images))
+		namespace   = pkgcfg.FromContext(ctx).PodNamespace
+	)
+
+	if maxInFlight <= 0 {
+		maxInFlight = 1
+	}
+
+	reconcileImageCachesMu.Lock()
+	defer reconcileImageCachesMu.Unlock()
+
+	// Count the locations that are being cached.
+	numInFlight, err := r.getNumCacheLocationsInFlight(ctx, namespace)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	// Get the existing image cache resources.
+	for i := range images {
+		key := ctrlclient.ObjectKey{
+			Namespace: namespace,
+			Name:      pkgutil.VMIName(images[i].itemID),
+		}
+		if err := r.Get(ctx, key, &caches[i]); err != nil {
+			if ctrlclient.IgnoreNotFound(err) != nil {
+				return 0, 0, fmt.Errorf(
+					"failed to get image cache resource %s: %w", key, err)
+			}
+			caches[i].Namespace = key.Namespace
+			caches[i].Name = key.Name
+		}
+	}
+
+	status.Images = make(
+		[]vmopv1.VirtualMachineImageCachePolicyImageStatus,
+		len(images))
+
+	for i := range images {
+		var (
+			img       = images[i]
+			obj       = &caches[i]
+			imgStatus = &status.Images[i]
+		)
+
+		imgStatus.Name = img.name
+		imgStatus.CacheName = obj.Name
+
+		// Add the locations that are not yet part of the image cache
+		// resource, so long as the maximum number of concurrent copies is not
+		// exceeded.
+		var toAdd []string
+		for _, dsID := range datastoreIDs {
+			if !hasCacheLocation(*obj, datacenterID, dsID) &&
+				numInFlight < maxInFlight {
+
+				toAdd = append(toAdd, dsID)
+				numInFlight++
+			}
+		}
+
+		if len(toAdd) > 0 || obj.Spec.ProviderVersion != img.itemVersion {
+			if _, err := controllerutil.CreateOrPatch(
+				ctx,
+				r.Client,
+				obj,
+				func() error {
+					obj.Spec.ProviderID = img.itemID
+					obj.Spec.ProviderVersion = img.itemVersion
+					for _, dsID := range toAdd {
+						obj.AddLocation(datacenterID, dsID)
+					}
+					return nil
+				}); err != nil {
+
+				return 0, 0, fmt.Errorf(
+					"failed to createOrPatch image cache resource: %w", err)
+			}
+		}
+
+		for _, dsID := range datastoreIDs {
+			if !hasCacheLocation(*obj, datacenterID, dsID) {
+				continue
+			}
+			ready := isCacheLocationReady(
+				*obj, img.itemVersion, datacenterID, dsID)
+			if ready {
+				numReady++
+			}
+			imgStatus.Locations = append(
+				imgStatus.Locations,
+				vmopv1.VirtualMachineImageCachePolicyLocationStatus{
+					DatacenterID: datacenterID,
+					DatastoreID:  dsID,
+					Ready:        ready,
+				})
+		}
+	}
+
+	return numReady, numTotal, nil
+}
+
+// getNumCacheLocationsInFlight returns the number of locations of all of the
+// image cache resources whose files are not yet cached.
+func (r *PolicyReconciler) getNumCacheLocationsInFlight(
+	ctx context.Context,
+	namespace string) (int, error) {
+
+	var list vmopv1.VirtualMachineImageCacheList
+	if err := r.List(
+		ctx,
+		&list,
+		ctrlclient.InNamespace(namespace)); err != nil {
+
+		return 0, fmt.Errorf("failed to list image cache resources: %w", err)
+	}
+
+	var n int
+	for i := range list.Items {
+		obj := list.Items[i]
+		for _, l := range obj.Spec.Locations {
+			if !isCacheLocationReady(
+				obj,
+				obj.Spec.ProviderVersion,
+				l.DatacenterID,
+				l.DatastoreID) {
+
+				n++
+			}
+		}
+	}
+	return n, nil
+}
+
+func hasCacheLocation(
+	obj vmopv1.VirtualMachineImageCache,
+	datacenterID, datastoreID string) bool {
+
+	for i := range obj.Spec.Locations {
+		l := obj.Spec.Locations[i]
+		if l.DatacenterID == datacenterID && l.DatastoreID == datastoreID {
+			return true
+		}
+	}
+	return false
+}
+
+// isCacheLocationReady returns true if the files for the specified version of
+// the image are cached at the specified location.
+func isCacheLocationReady(
+	obj vmopv1.VirtualMachineImageCache,
+	itemVersion, datacenterID, datastoreID string) bool {
+
+	if obj.Status.OVF == nil || obj.Status.OVF.ProviderVersion != itemVersion {
+		return false
+	}
+	for i := range obj.Status.Locations {
+		l := obj.Status.Locations[i]
+		if l.DatacenterID == datacenterID && l.DatastoreID == datastoreID {
+			return pkgcond.IsTrue(l, vmopv1.ReadyConditionType)
+		}
+	}
+	return false
+}
+
+// getPolicyDatastoreIDs returns the sorted IDs of the datastores targeted by a
+// policy. If the namespace is not empty, i.e. the policy is a
+// VirtualMachineImageCachePolicy, and the target does not specify any zones,
+// then only the datastores from the namespace's zones are considered.
+func getPolicyDatastoreIDs(
+	ctx context.Context,
+	k8sClient ctrlclient.Client,
+	vimClient *vim25.Client,
+	namespace string,
+	target vmopv1.VirtualMachineImageCachePolicyTargetSpec) ([]string, error) {
+
+	out := slices.Clone(target.Datastores)
+
+	if len(target.Zones) > 0 || len(target.StorageClasses) > 0 {
+
+		// Get the clusters from the zones.
+		var azs []string
+		switch {
+		case len(target.Zones) > 0:
+			azs = target.Zones
+		case namespace != "":
+			names, err := topology.GetNamespaceZoneNames(ctx, k8sClient, namespace)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get zones: %w", err)
+			}
+			azs = names
+		default:
+			list, err := topology.GetAvailabilityZones(ctx, k8sClient)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get zones: %w", err)
+			}
+			for i := range list {
+				azs = append(azs, list[i].Name)
+			}
+		}
+
+		var clusterIDs []string
+		for _, name := range azs {
+			az, err := topology.GetAvailabilityZone(ctx, k8sClient, name)
+			if err != nil {
+				return nil, fmt.Errorf("failed to get zone %q: %w", name, err)
+			}
+			if v := az.Spec.ClusterComputeResourceMoId; v != "" {
+				clusterIDs = append(clusterIDs, v)
+			}
+			clusterIDs = append(clusterIDs, az.Spec.ClusterComputeResourceMoIDs...)
+		}
+
+		// Get the storage policies from the storage classes.
+		var policyIDs []string
+		for _, name := range target.StorageClasses {
+			var obj storagev1.StorageClass
+			if err := k8sClient.Get(
+				ctx,
+				ctrlclient.ObjectKey{Name: name},
+				&obj); err != nil {
+
+				return nil, fmt.Errorf(
+					"failed to get storage class %q: %w", name, err)
+			}
+			policyID, err := kubeutil.GetStoragePolicyID(obj)
+			if err != nil {
+				return nil, err
+			}
+			policyIDs = append(policyIDs, policyID)
+		}
+
+		pbmClient, err := pbm.NewClient(ctx, vimClient)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get pbm client: %w", err)
+		}
+
+		for _, clusterID := range clusterIDs {
+			dsMap, err := pbmClient.DatastoreMap(
+				ctx,
+				vimClient,
+				vimtypes.ManagedObjectReference{
+					Type:  "ClusterComputeResource",
+					Value: clusterID,
+				})
+			if err != nil {
+				return nil, fmt.Errorf(
+					"failed to get datastores for cluster %q: %w",
+					clusterID, err)
+			}
+
+			if len(policyIDs) == 0 {
+				for _, hub := range dsMap.PlacementHub {
+					out = append(out, hub.HubId)
+				}
+				continue
+			}
+
+			for _, policyID := range policyIDs {
+				res, err := pbmClient.CheckRequirements(
+					ctx,
+					dsMap.PlacementHub,
+					nil,
+					[]pbmtypes.BasePbmPlacementRequirement{
+						&pbmtypes.PbmPlacementCapabilityProfileRequirement{
+							ProfileId: pbmtypes.PbmProfileId{
+								UniqueId: policyID,
+							},
+						},
+					})
+				if err != nil {
+					return nil, fmt.Errorf(
+						"failed to get datastores compatible with "+
+							"storage policy %q: %w", policyID, err)
+				}
+				for _, hub := range res.CompatibleDatastores() {
+					out = append(out, hub.HubId)
+				}
+			}
+		}
+	}
+
+	slices.Sort(out)
+	return slices.Compact(out), nil
+}
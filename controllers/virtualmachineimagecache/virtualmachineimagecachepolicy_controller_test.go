@@ -0,0 +1,382 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachineimagecache_test
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/vmware/govmomi/vim25/mo"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineimagecache"
+	topologyv1 "github.com/vmware-tanzu/vm-operator/external/tanzu-topology/api/v1alpha1"
+	pkgcond "github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	providerfake "github.com/vmware-tanzu/vm-operator/pkg/providers/fake"
+	pkgutil "github.com/vmware-tanzu/vm-operator/pkg/util"
+	vsclient "github.com/vmware-tanzu/vm-operator/pkg/util/vsphere/client"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+var _ = Describe(
+	"PolicyReconcile",
+	Label(
+		testlabels.Controller,
+		testlabels.VCSim,
+	),
+	func() {
+
+		const (
+			namespace   = "my-namespace"
+			itemID      = "my-item-id"
+			itemVersion = "my-item-version"
+		)
+
+		var datastoreIDs = []string{"datastore-1", "datastore-2"}
+
+		var (
+			ctx          *builder.TestContextForVCSim
+			reconciler   *virtualmachineimagecache.PolicyReconciler
+			image        *vmopv1.VirtualMachineImage
+			policy       *vmopv1.VirtualMachineImageCachePolicy
+			result       ctrl.Result
+			reconcileErr error
+		)
+
+		BeforeEach(func() {
+			image = &vmopv1.VirtualMachineImage{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: namespace,
+					Name:      "vmi-1",
+					Labels: map[string]string{
+						"prewarm": "true",
+					},
+				},
+				Status: vmopv1.VirtualMachineImageStatus{
+					Type:                   "OVF",
+					ProviderItemID:         itemID,
+					ProviderContentVersion: itemVersion,
+				},
+			}
+			policy = &vmopv1.VirtualMachineImageCachePolicy{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: namespace,
+					Name:      "my-policy",
+				},
+				Spec: vmopv1.VirtualMachineImageCachePolicySpec{
+					ImageSelector: &metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							"prewarm": "true",
+						},
+					},
+					MaxConcurrentCopies: 10,
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			ctx = builder.NewTestContextForVCSim(
+				pkgcfg.NewContextWithDefaultConfig(),
+				builder.VCSimTestConfig{},
+				image,
+				policy)
+
+			provider := providerfake.NewVMProvider()
+			provider.VSphereClientFn = func(c context.Context) (*vsclient.Client, error) {
+				return vsclient.NewClient(c, ctx.VCClientConfig)
+			}
+
+			reconciler = virtualmachineimagecache.NewPolicyReconciler(
+				ctx,
+				ctx.Client,
+				logr.Discard(),
+				provider,
+				false)
+		})
+
+		AfterEach(func() {
+			ctx.AfterEach()
+			ctx = nil
+		})
+
+		reconcile := func() {
+			result, reconcileErr = reconciler.Reconcile(
+				ctx,
+				ctrl.Request{
+					NamespacedName: ctrlclient.ObjectKeyFromObject(policy),
+				})
+		}
+
+		getPolicy := func() vmopv1.VirtualMachineImageCachePolicy {
+			var obj vmopv1.VirtualMachineImageCachePolicy
+			ExpectWithOffset(1, ctx.Client.Get(
+				ctx,
+				ctrlclient.ObjectKeyFromObject(policy),
+				&obj)).To(Succeed())
+			return obj
+		}
+
+		getCache := func() vmopv1.VirtualMachineImageCache {
+			var obj vmopv1.VirtualMachineImageCache
+			ExpectWithOffset(1, ctx.Client.Get(
+				ctx,
+				ctrlclient.ObjectKey{
+					Namespace: pkgcfg.FromContext(ctx).PodNamespace,
+					Name:      pkgutil.VMIName(itemID),
+				},
+				&obj)).To(Succeed())
+			return obj
+		}
+
+		getCacheDatastoreIDs := func(obj vmopv1.VirtualMachineImageCache) []string {
+			var out []string
+			for _, l := range obj.Spec.Locations {
+				ExpectWithOffset(1, l.DatacenterID).To(Equal(ctx.Datacenter.Reference().Value))
+				out = append(out, l.DatastoreID)
+			}
+			return out
+		}
+
+		When("the target is a list of datastores", func() {
+			JustBeforeEach(func() {
+				policy.Spec.Target.Datastores = datastoreIDs
+				Expect(ctx.Client.Update(ctx, policy)).To(Succeed())
+			})
+
+			It("should add the datastores to the image cache", func() {
+				reconcile()
+				Expect(reconcileErr).ToNot(HaveOccurred())
+				Expect(result.RequeueAfter).ToNot(BeZero())
+
+				obj := getCache()
+				Expect(obj.Spec.ProviderID).To(Equal(itemID))
+				Expect(obj.Spec.ProviderVersion).To(Equal(itemVersion))
+				Expect(getCacheDatastoreIDs(obj)).To(ConsistOf(datastoreIDs))
+
+				p := getPolicy()
+				Expect(p.Status.Images).To(HaveLen(1))
+				Expect(p.Status.Images[0].Name).To(Equal(image.Name))
+				Expect(p.Status.Images[0].CacheName).To(Equal(obj.Name))
+				Expect(p.Status.Images[0].Locations).To(HaveLen(2))
+				Expect(pkgcond.IsTrue(p, vmopv1.VirtualMachineImageCachePolicyConditionImagesReady)).To(BeTrue())
+				Expect(pkgcond.IsTrue(p, vmopv1.VirtualMachineImageCachePolicyConditionTargetsReady)).To(BeTrue())
+				Expect(pkgcond.IsFalse(p, vmopv1.VirtualMachineImageCachePolicyConditionFilesReady)).To(BeTrue())
+				Expect(pkgcond.IsFalse(p, vmopv1.ReadyConditionType)).To(BeTrue())
+			})
+
+			When("the max concurrent copies is exceeded", func() {
+				BeforeEach(func() {
+					policy.Spec.MaxConcurrentCopies = 1
+				})
+
+				It("should add the datastores one at a time", func() {
+					reconcile()
+					Expect(reconcileErr).ToNot(HaveOccurred())
+					Expect(getCacheDatastoreIDs(getCache())).To(ConsistOf(datastoreIDs[0]))
+
+					// The first location is still being cached.
+					reconcile()
+					Expect(reconcileErr).ToNot(HaveOccurred())
+					Expect(getCacheDatastoreIDs(getCache())).To(ConsistOf(datastoreIDs[0]))
+
+					// Mark the first location as cached.
+					obj := getCache()
+					obj.Status.OVF = &vmopv1.VirtualMachineImageCacheOVFStatus{
+						ProviderVersion: itemVersion,
+					}
+					obj.Status.Locations = []vmopv1.VirtualMachineImageCacheLocationStatus{
+						{
+							DatacenterID: obj.Spec.Locations[0].DatacenterID,
+							DatastoreID:  obj.Spec.Locations[0].DatastoreID,
+						},
+					}
+					pkgcond.MarkTrue(&obj.Status.Locations[0], vmopv1.ReadyConditionType)
+					Expect(ctx.Client.Status().Update(ctx, &obj)).To(Succeed())
+
+					reconcile()
+					Expect(reconcileErr).ToNot(HaveOccurred())
+					Expect(getCacheDatastoreIDs(getCache())).To(ConsistOf(datastoreIDs))
+
+					p := getPolicy()
+					Expect(p.Status.Images).To(HaveLen(1))
+					Expect(p.Status.Images[0].Locations).To(ConsistOf(
+						vmopv1.VirtualMachineImageCachePolicyLocationStatus{
+							DatacenterID: ctx.Datacenter.Reference().Value,
+							DatastoreID:  datastoreIDs[0],
+							Ready:        true,
+						},
+						vmopv1.VirtualMachineImageCachePolicyLocationStatus{
+							DatacenterID: ctx.Datacenter.Reference().Value,
+							DatastoreID:  datastoreIDs[1],
+						},
+					))
+				})
+			})
+
+			When("the max concurrent copies is exceeded by another image cache resource", func() {
+				BeforeEach(func() {
+					policy.Spec.MaxConcurrentCopies = 1
+				})
+
+				JustBeforeEach(func() {
+					Expect(ctx.Client.Create(ctx, &vmopv1.VirtualMachineImageCache{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: pkgcfg.FromContext(ctx).PodNamespace,
+							Name:      "vmi-other",
+						},
+						Spec: vmopv1.VirtualMachineImageCacheSpec{
+							ProviderID:      "other-item-id",
+							ProviderVersion: "other-item-version",
+							Locations: []vmopv1.VirtualMachineImageCacheLocationSpec{
+								{
+									DatacenterID: ctx.Datacenter.Reference().Value,
+									DatastoreID:  datastoreIDs[0],
+								},
+							},
+						},
+					})).To(Succeed())
+				})
+
+				It("should not add any datastores", func() {
+					reconcile()
+					Expect(reconcileErr).ToNot(HaveOccurred())
+					Expect(getCacheDatastoreIDs(getCache())).To(BeEmpty())
+				})
+			})
+
+			When("the locations are cached", func() {
+				It("should mark the policy as ready", func() {
+					reconcile()
+					Expect(reconcileErr).ToNot(HaveOccurred())
+
+					obj := getCache()
+					obj.Status.OVF = &vmopv1.VirtualMachineImageCacheOVFStatus{
+						ProviderVersion: itemVersion,
+					}
+					for _, l := range obj.Spec.Locations {
+						s := vmopv1.VirtualMachineImageCacheLocationStatus{
+							DatacenterID: l.DatacenterID,
+							DatastoreID:  l.DatastoreID,
+						}
+						pkgcond.MarkTrue(&s, vmopv1.ReadyConditionType)
+						obj.Status.Locations = append(obj.Status.Locations, s)
+					}
+					Expect(ctx.Client.Status().Update(ctx, &obj)).To(Succeed())
+
+					reconcile()
+					Expect(reconcileErr).ToNot(HaveOccurred())
+					Expect(result.RequeueAfter).To(BeZero())
+
+					p := getPolicy()
+					Expect(pkgcond.IsTrue(p, vmopv1.VirtualMachineImageCachePolicyConditionFilesReady)).To(BeTrue())
+					Expect(pkgcond.IsTrue(p, vmopv1.ReadyConditionType)).To(BeTrue())
+				})
+			})
+		})
+
+		When("the target is a zone", func() {
+			JustBeforeEach(func() {
+				policy.Spec.Target.Zones = []string{ctx.GetFirstZoneName()}
+				Expect(ctx.Client.Update(ctx, policy)).To(Succeed())
+			})
+
+			It("should add the datastores from the zone's clusters to the image cache", func() {
+				reconcile()
+				Expect(reconcileErr).ToNot(HaveOccurred())
+
+				var expected []string
+				for _, ccr := range ctx.GetAZClusterComputes(ctx.GetFirstZoneName()) {
+					var moCCR mo.ClusterComputeResource
+					Expect(ccr.Properties(ctx, ccr.Reference(), []string{"datastore"}, &moCCR)).To(Succeed())
+					for _, ref := range moCCR.Datastore {
+						expected = append(expected, ref.Value)
+					}
+				}
+				Expect(expected).ToNot(BeEmpty())
+				Expect(getCacheDatastoreIDs(getCache())).To(ConsistOf(expected))
+			})
+		})
+
+		When("the target is a storage class", func() {
+			var withZone bool
+
+			BeforeEach(func() {
+				withZone = true
+			})
+
+			JustBeforeEach(func() {
+				if withZone {
+					Expect(ctx.Client.Create(ctx, &topologyv1.Zone{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: namespace,
+							Name:      ctx.GetFirstZoneName(),
+						},
+					})).To(Succeed())
+				}
+				policy.Spec.Target.StorageClasses = []string{ctx.StorageClassName}
+				Expect(ctx.Client.Update(ctx, policy)).To(Succeed())
+			})
+
+			It("should add the compatible datastores from the namespace's zones to the image cache", func() {
+				reconcile()
+				Expect(reconcileErr).ToNot(HaveOccurred())
+				Expect(getCacheDatastoreIDs(getCache())).ToNot(BeEmpty())
+			})
+
+			When("the namespace does not have any zones", func() {
+				BeforeEach(func() {
+					withZone = false
+				})
+
+				It("should not cache the images", func() {
+					reconcile()
+					Expect(reconcileErr).ToNot(HaveOccurred())
+
+					p := getPolicy()
+					Expect(p.Status.Images).To(HaveLen(1))
+					Expect(p.Status.Images[0].Locations).To(BeEmpty())
+				})
+			})
+		})
+
+		When("a named image does not exist", func() {
+			BeforeEach(func() {
+				policy.Spec.ImageNames = []string{"vmi-does-not-exist"}
+			})
+
+			It("should mark the policy as not ready", func() {
+				reconcile()
+				Expect(reconcileErr).To(HaveOccurred())
+
+				p := getPolicy()
+				Expect(pkgcond.IsFalse(p, vmopv1.VirtualMachineImageCachePolicyConditionImagesReady)).To(BeTrue())
+				Expect(pkgcond.GetMessage(p, vmopv1.VirtualMachineImageCachePolicyConditionImagesReady)).To(
+					ContainSubstring("vmi-does-not-exist"))
+				Expect(pkgcond.IsFalse(p, vmopv1.ReadyConditionType)).To(BeTrue())
+			})
+		})
+
+		When("an image is not an OVF", func() {
+			BeforeEach(func() {
+				image.Status.Type = "ISO"
+			})
+
+			It("should not cache the image", func() {
+				reconcile()
+				Expect(reconcileErr).ToNot(HaveOccurred())
+
+				p := getPolicy()
+				Expect(p.Status.Images).To(BeEmpty())
+				Expect(pkgcond.IsTrue(p, vmopv1.ReadyConditionType)).To(BeTrue())
+			})
+		})
+	})
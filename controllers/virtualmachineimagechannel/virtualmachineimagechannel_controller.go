@@ -0,0 +1,392 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachineimagechannel
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/blang/semver/v4"
+	"github.com/go-logr/logr"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/patch"
+	"github.com/vmware-tanzu/vm-operator/pkg/record"
+)
+
+const (
+	vmiKind  = "VirtualMachineImage"
+	cvmiKind = "Cluster" + vmiKind
+
+	invalidSelectorReason = "InvalidSelector"
+)
+
+// AddToManager adds this package's controller to the provided manager.
+func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr manager.Manager) error {
+	var (
+		controlledType     = &vmopv1.VirtualMachineImageChannel{}
+		controlledTypeName = reflect.TypeOf(controlledType).Elem().Name()
+
+		controllerNameShort = fmt.Sprintf("%s-controller", strings.ToLower(controlledTypeName))
+		controllerNameLong  = fmt.Sprintf("%s/%s/%s", ctx.Namespace, ctx.Name, controllerNameShort)
+	)
+
+	r := NewReconciler(
+		ctx,
+		mgr.GetClient(),
+		ctrl.Log.WithName("controllers").WithName(controlledTypeName),
+		record.New(mgr.GetEventRecorderFor(controllerNameLong)),
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(controlledType).
+		Watches(
+			&vmopv1.VirtualMachineImage{},
+			handler.EnqueueRequestsFromMapFunc(r.ImageToChannels)).
+		Watches(
+			&vmopv1.ClusterVirtualMachineImage{},
+			handler.EnqueueRequestsFromMapFunc(r.ImageToChannels)).
+		WithOptions(controller.Options{MaxConcurrentReconciles: ctx.MaxConcurrentReconciles}).
+		Complete(r)
+}
+
+func NewReconciler(
+	ctx context.Context,
+	client client.Client,
+	logger logr.Logger,
+	recorder record.Recorder) *Reconciler {
+
+	return &Reconciler{
+		Context:  ctx,
+		Client:   client,
+		Logger:   logger,
+		Recorder: recorder,
+	}
+}
+
+// Reconciler reconciles a VirtualMachineImageChannel object.
+type Reconciler struct {
+	client.Client
+	Context  context.Context
+	Logger   logr.Logger
+	Recorder record.Recorder
+}
+
+// ImageToChannels enqueues the channels that may select the provided image.
+// A VirtualMachineImage may be selected by the channels in its namespace,
+// while a ClusterVirtualMachineImage may be selected by any channel.
+func (r *Reconciler) ImageToChannels(
+	ctx context.Context,
+	o client.Object) []reconcile.Request {
+
+	var opts []client.ListOption
+	if ns := o.GetNamespace(); ns != "" {
+		opts = append(opts, client.InNamespace(ns))
+	}
+
+	var list vmopv1.VirtualMachineImageChannelList
+	if err := r.List(ctx, &list, opts...); err != nil {
+		r.Logger.Error(err, "Failed to list VirtualMachineImageChannels")
+		return nil
+	}
+
+	wantKind := vmiKind
+	if o.GetNamespace() == "" {
+		wantKind = cvmiKind
+	}
+
+	var requests []reconcile.Request
+	for i := range list.Items {
+		if imageKind(&list.Items[i]) == wantKind {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: client.ObjectKeyFromObject(&list.Items[i]),
+			})
+		}
+	}
+
+	return requests
+}
+
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineimagechannels,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineimagechannels/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineimages,verbs=get;list;watch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=clustervirtualmachineimages,verbs=get;list;watch
+
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	ctx = pkgcfg.JoinContext(ctx, r.Context)
+
+	channel := &vmopv1.VirtualMachineImageChannel{}
+	if err := r.Get(ctx, req.NamespacedName, channel); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	channelCtx := &pkgctx.VirtualMachineImageChannelContext{
+		Context: ctx,
+		Logger:  r.Logger.WithValues("name", req.NamespacedName),
+		Channel: channel,
+	}
+
+	patchHelper, err := patch.NewHelper(channel, r.Client)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to init patch helper for %s: %w", channelCtx, err)
+	}
+	defer func() {
+		if err := patchHelper.Patch(ctx, channel); err != nil {
+			if reterr == nil {
+				reterr = err
+			}
+			channelCtx.Logger.Error(err, "patch failed")
+		}
+	}()
+
+	if !channel.DeletionTimestamp.IsZero() {
+		// Noop.
+		return ctrl.Result{}, nil
+	}
+
+	if err := r.ReconcileNormal(channelCtx); err != nil {
+		channelCtx.Logger.Error(err, "Failed to reconcile VirtualMachineImageChannel")
+		return ctrl.Result{}, err
+	}
+
+	return ctrl.Result{}, nil
+}
+
+func (r *Reconciler) ReconcileNormal(ctx *pkgctx.VirtualMachineImageChannelContext) error {
+	channel := ctx.Channel
+	channel.Status.ObservedGeneration = channel.Generation
+
+	defer conditions.SetSummary(channel)
+
+	sel, err := newImageSelector(channel.Spec.Selector)
+	if err != nil {
+		conditions.MarkError(
+			channel,
+			vmopv1.VirtualMachineImageChannelConditionImageResolved,
+			invalidSelectorReason,
+			err)
+		// Retrying will not fix an invalid selector.
+		return nil
+	}
+
+	candidates, err := r.getCandidateImages(ctx, channel)
+	if err != nil {
+		return err
+	}
+
+	latest := sel.latest(candidates)
+	if latest == nil {
+		if channel.Status.Image != nil {
+			ctx.Logger.Info("Channel no longer resolves to an image",
+				"image", channel.Status.Image.Name)
+		}
+		channel.Status.Image = nil
+		channel.Status.Version = ""
+		conditions.MarkFalse(
+			channel,
+			vmopv1.VirtualMachineImageChannelConditionImageResolved,
+			vmopv1.VirtualMachineImageChannelNoMatchingImageReason,
+			"No ready %s matches the selector",
+			imageKind(channel))
+		return nil
+	}
+
+	if img := channel.Status.Image; img == nil ||
+		img.Kind != latest.kind || img.Name != latest.name {
+
+		ctx.Logger.Info("Channel advanced to image",
+			"image", latest.name, "version", latest.rawVersion)
+		r.Recorder.Eventf(channel, "Advanced",
+			"Channel advanced to %s %q version %q", latest.kind, latest.name, latest.rawVersion)
+
+		now := metav1.Now()
+		channel.Status.LastUpdateTime = &now
+	}
+
+	channel.Status.Image = &vmopv1.VirtualMachineImageRef{
+		Kind: latest.kind,
+		Name: latest.name,
+	}
+	channel.Status.Version = latest.rawVersion
+	conditions.MarkTrue(channel, vmopv1.VirtualMachineImageChannelConditionImageResolved)
+
+	return nil
+}
+
+// candidateImage is a ready image that may be selected by a channel.
+type candidateImage struct {
+	kind       string
+	name       string
+	labels     map[string]string
+	created    metav1.Time
+	status     vmopv1.VirtualMachineImageStatus
+	rawVersion string
+
+	// version is nil if the image's version is not a semantic version.
+	version *semver.Version
+}
+
+func newCandidateImage(
+	kind string,
+	obj metav1.Object,
+	status vmopv1.VirtualMachineImageStatus) candidateImage {
+
+	c := candidateImage{
+		kind:       kind,
+		name:       obj.GetName(),
+		labels:     obj.GetLabels(),
+		created:    obj.GetCreationTimestamp(),
+		status:     status,
+		rawVersion: status.ProductInfo.Version,
+	}
+	if v, err := semver.ParseTolerant(c.rawVersion); err == nil {
+		c.version = &v
+	}
+	return c
+}
+
+// getCandidateImages returns the ready images of the kind selected by the
+// channel.
+func (r *Reconciler) getCandidateImages(
+	ctx *pkgctx.VirtualMachineImageChannelContext,
+	channel *vmopv1.VirtualMachineImageChannel) ([]candidateImage, error) {
+
+	var candidates []candidateImage
+
+	if imageKind(channel) == cvmiKind {
+		var list vmopv1.ClusterVirtualMachineImageList
+		if err := r.List(ctx, &list); err != nil {
+			return nil, fmt.Errorf("failed to list ClusterVirtualMachineImages: %w", err)
+		}
+		for i := range list.Items {
+			img := &list.Items[i]
+			if conditions.IsTrue(img, vmopv1.ReadyConditionType) {
+				candidates = append(candidates, newCandidateImage(cvmiKind, img, img.Status))
+			}
+		}
+		return candidates, nil
+	}
+
+	var list vmopv1.VirtualMachineImageList
+	if err := r.List(ctx, &list, client.InNamespace(channel.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list VirtualMachineImages: %w", err)
+	}
+	for i := range list.Items {
+		img := &list.Items[i]
+		if conditions.IsTrue(img, vmopv1.ReadyConditionType) {
+			candidates = append(candidates, newCandidateImage(vmiKind, img, img.Status))
+		}
+	}
+
+	return candidates, nil
+}
+
+// imageSelector matches candidate images against a channel's selector.
+type imageSelector struct {
+	spec         vmopv1.VirtualMachineImageChannelSelector
+	versionRange semver.Range
+	labels       labels.Selector
+}
+
+func newImageSelector(spec vmopv1.VirtualMachineImageChannelSelector) (imageSelector, error) {
+	sel := imageSelector{
+		spec:   spec,
+		labels: labels.Everything(),
+	}
+
+	if spec.Version != "" {
+		r, err := semver.ParseRange(spec.Version)
+		if err != nil {
+			return imageSelector{}, fmt.Errorf("invalid version range %q: %w", spec.Version, err)
+		}
+		sel.versionRange = r
+	}
+
+	if spec.LabelSelector != nil {
+		s, err := metav1.LabelSelectorAsSelector(spec.LabelSelector)
+		if err != nil {
+			return imageSelector{}, fmt.Errorf("invalid label selector: %w", err)
+		}
+		sel.labels = s
+	}
+
+	return sel, nil
+}
+
+func (s imageSelector) matches(c candidateImage) bool {
+	var (
+		product = c.status.ProductInfo
+		os      = c.status.OSInfo
+	)
+
+	switch {
+	case s.spec.Product != "" && s.spec.Product != product.Product,
+		s.spec.Vendor != "" && s.spec.Vendor != product.Vendor,
+		s.spec.OSID != "" && s.spec.OSID != os.ID,
+		s.spec.OSType != "" && s.spec.OSType != os.Type,
+		s.spec.OSVersion != "" && s.spec.OSVersion != os.Version:
+		return false
+	}
+
+	if s.versionRange != nil && (c.version == nil || !s.versionRange(*c.version)) {
+		return false
+	}
+
+	return s.labels.Matches(labels.Set(c.labels))
+}
+
+// latest returns the matching candidate with the highest version, or nil if
+// no candidate matches. Candidates with a semantic version are preferred to
+// those without one. Ties are broken by the most recently created image, and
+// then by name so the result is stable.
+func (s imageSelector) latest(candidates []candidateImage) *candidateImage {
+	var matches []candidateImage
+	for _, c := range candidates {
+		if s.matches(c) {
+			matches = append(matches, c)
+		}
+	}
+	if len(matches) == 0 {
+		return nil
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		switch {
+		case a.version != nil && b.version == nil:
+			return true
+		case a.version == nil && b.version != nil:
+			return false
+		case a.version != nil && b.version != nil && !a.version.EQ(*b.version):
+			return a.version.GT(*b.version)
+		case !a.created.Equal(&b.created):
+			return b.created.Before(&a.created)
+		}
+		return a.name < b.name
+	})
+
+	return &matches[0]
+}
+
+func imageKind(channel *vmopv1.VirtualMachineImageChannel) string {
+	if channel.Spec.ImageKind == cvmiKind {
+		return cvmiKind
+	}
+	return vmiKind
+}
@@ -0,0 +1,90 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachineimagechannel_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func intgTests() {
+	Describe(
+		"Reconcile",
+		Label(
+			testlabels.Controller,
+			testlabels.EnvTest,
+			testlabels.API,
+		),
+		intgTestsReconcile,
+	)
+}
+
+func intgTestsReconcile() {
+	var (
+		ctx     *builder.IntegrationTestContext
+		channel *vmopv1.VirtualMachineImageChannel
+	)
+
+	BeforeEach(func() {
+		ctx = suite.NewIntegrationTestContext()
+
+		channel = newChannel(ctx.Namespace, "photon")
+		channel.Spec.Selector.Product = "photon"
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+	})
+
+	createReadyImage := func(name, version string) {
+		img := readyImage(ctx.Namespace, name, "photon", version)
+		status := img.Status.DeepCopy()
+		Expect(ctx.Client.Create(ctx, img)).To(Succeed())
+		img.Status = *status
+		Expect(ctx.Client.Status().Update(ctx, img)).To(Succeed())
+	}
+
+	getChannel := func() *vmopv1.VirtualMachineImageChannel {
+		obj := &vmopv1.VirtualMachineImageChannel{}
+		if err := ctx.Client.Get(ctx, client.ObjectKeyFromObject(channel), obj); err != nil {
+			return nil
+		}
+		return obj
+	}
+
+	It("should advance the channel as newer images become ready", func() {
+		Expect(ctx.Client.Create(ctx, channel)).To(Succeed())
+
+		Eventually(func(g Gomega) {
+			obj := getChannel()
+			g.Expect(obj).ToNot(BeNil())
+			g.Expect(conditions.IsFalse(obj, vmopv1.VirtualMachineImageChannelConditionImageResolved)).To(BeTrue())
+		}).Should(Succeed())
+
+		createReadyImage("vmi-1", "5.0.0")
+		Eventually(func(g Gomega) {
+			obj := getChannel()
+			g.Expect(obj).ToNot(BeNil())
+			g.Expect(obj.Status.Image).ToNot(BeNil())
+			g.Expect(obj.Status.Image.Name).To(Equal("vmi-1"))
+		}).Should(Succeed())
+
+		createReadyImage("vmi-2", "5.1.0")
+		Eventually(func(g Gomega) {
+			obj := getChannel()
+			g.Expect(obj).ToNot(BeNil())
+			g.Expect(obj.Status.Image).ToNot(BeNil())
+			g.Expect(obj.Status.Image.Name).To(Equal("vmi-2"))
+			g.Expect(obj.Status.Version).To(Equal("5.1.0"))
+		}).Should(Succeed())
+	})
+}
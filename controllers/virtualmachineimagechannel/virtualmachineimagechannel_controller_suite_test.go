@@ -0,0 +1,62 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachineimagechannel_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineimagechannel"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	"github.com/vmware-tanzu/vm-operator/pkg/manager"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+var suite = builder.NewTestSuiteForControllerWithContext(
+	pkgcfg.NewContextWithDefaultConfig(),
+	virtualmachineimagechannel.AddToManager,
+	manager.InitializeProvidersNoopFn)
+
+func TestVirtualMachineImageChannel(t *testing.T) {
+	suite.Register(t, "VirtualMachineImageChannel controller suite", intgTests, unitTests)
+}
+
+var _ = BeforeSuite(suite.BeforeSuite)
+
+var _ = AfterSuite(suite.AfterSuite)
+
+// readyImage returns a ready VirtualMachineImage with the provided product
+// and version.
+func readyImage(namespace, name, product, version string) *vmopv1.VirtualMachineImage {
+	img := builder.DummyVirtualMachineImage(name)
+	img.Namespace = namespace
+	img.Status.ProductInfo.Product = product
+	img.Status.ProductInfo.Version = version
+	conditions.MarkTrue(img, vmopv1.ReadyConditionType)
+	return img
+}
+
+// readyClusterImage returns a ready ClusterVirtualMachineImage with the
+// provided product and version.
+func readyClusterImage(name, product, version string) *vmopv1.ClusterVirtualMachineImage {
+	img := builder.DummyClusterVirtualMachineImage(name)
+	img.Status.ProductInfo.Product = product
+	img.Status.ProductInfo.Version = version
+	conditions.MarkTrue(img, vmopv1.ReadyConditionType)
+	return img
+}
+
+func newChannel(namespace, name string) *vmopv1.VirtualMachineImageChannel {
+	return &vmopv1.VirtualMachineImageChannel{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+	}
+}
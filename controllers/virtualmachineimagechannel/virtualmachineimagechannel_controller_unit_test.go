@@ -0,0 +1,243 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachineimagechannel_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineimagechannel"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func unitTests() {
+	Describe(
+		"Reconcile",
+		Label(
+			testlabels.Controller,
+		),
+		unitTestsReconcile,
+	)
+}
+
+func unitTestsReconcile() {
+	const ns = "dummy-ns"
+
+	var (
+		initObjects []client.Object
+		ctx         *builder.UnitTestContextForController
+
+		reconciler *virtualmachineimagechannel.Reconciler
+		channel    *vmopv1.VirtualMachineImageChannel
+		channelCtx *pkgctx.VirtualMachineImageChannelContext
+	)
+
+	BeforeEach(func() {
+		channel = newChannel(ns, "photon")
+		channel.Generation = 2
+		channel.Spec.Selector.Product = "photon"
+	})
+
+	JustBeforeEach(func() {
+		ctx = suite.NewUnitTestContextForController(initObjects...)
+		reconciler = virtualmachineimagechannel.NewReconciler(
+			ctx,
+			ctx.Client,
+			ctx.Logger,
+			ctx.Recorder,
+		)
+		channelCtx = &pkgctx.VirtualMachineImageChannelContext{
+			Context: pkgcfg.NewContextWithDefaultConfig(),
+			Logger:  suite.GetLogger().WithValues("channelName", channel.Name),
+			Channel: channel,
+		}
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+		initObjects = nil
+		channelCtx = nil
+		reconciler = nil
+	})
+
+	Context("ReconcileNormal", func() {
+
+		When("there are no images", func() {
+			It("should mark the channel as not resolved", func() {
+				Expect(reconciler.ReconcileNormal(channelCtx)).To(Succeed())
+				Expect(channel.Status.ObservedGeneration).To(Equal(int64(2)))
+				Expect(channel.Status.Image).To(BeNil())
+				Expect(channel.Status.Version).To(BeEmpty())
+
+				c := conditions.Get(channel, vmopv1.VirtualMachineImageChannelConditionImageResolved)
+				Expect(c).ToNot(BeNil())
+				Expect(c.Status).To(Equal(metav1.ConditionFalse))
+				Expect(c.Reason).To(Equal(vmopv1.VirtualMachineImageChannelNoMatchingImageReason))
+				Expect(conditions.IsTrue(channel, vmopv1.ReadyConditionType)).To(BeFalse())
+			})
+		})
+
+		When("there are matching images", func() {
+			BeforeEach(func() {
+				notReady := readyImage(ns, "vmi-4", "photon", "5.1.0")
+				conditions.MarkFalse(notReady, vmopv1.ReadyConditionType, "NotReady", "")
+
+				initObjects = append(initObjects,
+					readyImage(ns, "vmi-1", "photon", "5.0.0"),
+					readyImage(ns, "vmi-2", "photon", "5.0.2"),
+					readyImage(ns, "vmi-3", "ubuntu", "24.04.0"),
+					readyImage(ns, "vmi-5", "photon", "latest"),
+					readyImage("other-ns", "vmi-6", "photon", "6.0.0"),
+					readyClusterImage("vmi-7", "photon", "7.0.0"),
+					notReady,
+				)
+			})
+
+			It("should resolve to the highest ready version in the namespace", func() {
+				Expect(reconciler.ReconcileNormal(channelCtx)).To(Succeed())
+				Expect(channel.Status.Image).To(Equal(&vmopv1.VirtualMachineImageRef{
+					Kind: "VirtualMachineImage",
+					Name: "vmi-2",
+				}))
+				Expect(channel.Status.Version).To(Equal("5.0.2"))
+				Expect(channel.Status.LastUpdateTime).ToNot(BeNil())
+				Expect(conditions.IsTrue(channel, vmopv1.VirtualMachineImageChannelConditionImageResolved)).To(BeTrue())
+				Expect(conditions.IsTrue(channel, vmopv1.ReadyConditionType)).To(BeTrue())
+			})
+
+			It("should not bump the last update time when the image is unchanged", func() {
+				Expect(reconciler.ReconcileNormal(channelCtx)).To(Succeed())
+				lastUpdate := channel.Status.LastUpdateTime.DeepCopy()
+				Expect(reconciler.ReconcileNormal(channelCtx)).To(Succeed())
+				Expect(channel.Status.LastUpdateTime).To(Equal(lastUpdate))
+			})
+
+			When("the selector has a version range", func() {
+				BeforeEach(func() {
+					channel.Spec.Selector.Version = "<5.0.1"
+				})
+				It("should resolve to the highest version in the range", func() {
+					Expect(reconciler.ReconcileNormal(channelCtx)).To(Succeed())
+					Expect(channel.Status.Image).ToNot(BeNil())
+					Expect(channel.Status.Image.Name).To(Equal("vmi-1"))
+				})
+			})
+
+			When("the selector has an invalid version range", func() {
+				BeforeEach(func() {
+					channel.Spec.Selector.Version = "not-a-range"
+				})
+				It("should mark the condition as an error", func() {
+					Expect(reconciler.ReconcileNormal(channelCtx)).To(Succeed())
+					Expect(channel.Status.Image).To(BeNil())
+					c := conditions.Get(channel, vmopv1.VirtualMachineImageChannelConditionImageResolved)
+					Expect(c).ToNot(BeNil())
+					Expect(c.Status).To(Equal(metav1.ConditionFalse))
+					Expect(c.Reason).To(Equal("InvalidSelector"))
+				})
+			})
+
+			When("the selector has a label selector", func() {
+				BeforeEach(func() {
+					for _, o := range initObjects {
+						if o.GetName() == "vmi-1" {
+							o.SetLabels(map[string]string{"track": "stable"})
+						}
+					}
+					channel.Spec.Selector.LabelSelector = &metav1.LabelSelector{
+						MatchLabels: map[string]string{"track": "stable"},
+					}
+				})
+				It("should only consider labeled images", func() {
+					Expect(reconciler.ReconcileNormal(channelCtx)).To(Succeed())
+					Expect(channel.Status.Image).ToNot(BeNil())
+					Expect(channel.Status.Image.Name).To(Equal("vmi-1"))
+				})
+			})
+
+			When("the channel selects cluster images", func() {
+				BeforeEach(func() {
+					channel.Spec.ImageKind = "ClusterVirtualMachineImage"
+				})
+				It("should resolve to the cluster image", func() {
+					Expect(reconciler.ReconcileNormal(channelCtx)).To(Succeed())
+					Expect(channel.Status.Image).To(Equal(&vmopv1.VirtualMachineImageRef{
+						Kind: "ClusterVirtualMachineImage",
+						Name: "vmi-7",
+					}))
+					Expect(channel.Status.Version).To(Equal("7.0.0"))
+				})
+			})
+
+			When("the previously resolved image no longer matches", func() {
+				BeforeEach(func() {
+					channel.Spec.Selector.Product = "centos"
+					channel.Status.Image = &vmopv1.VirtualMachineImageRef{
+						Kind: "VirtualMachineImage",
+						Name: "vmi-2",
+					}
+					channel.Status.Version = "5.0.2"
+				})
+				It("should clear the image", func() {
+					Expect(reconciler.ReconcileNormal(channelCtx)).To(Succeed())
+					Expect(channel.Status.Image).To(BeNil())
+					Expect(channel.Status.Version).To(BeEmpty())
+				})
+			})
+		})
+
+		When("only unversioned images match", func() {
+			BeforeEach(func() {
+				older := readyImage(ns, "vmi-a", "photon", "")
+				older.CreationTimestamp = metav1.Unix(100, 0)
+				newer := readyImage(ns, "vmi-b", "photon", "")
+				newer.CreationTimestamp = metav1.Unix(200, 0)
+				initObjects = append(initObjects, older, newer)
+			})
+			It("should resolve to the newest image", func() {
+				Expect(reconciler.ReconcileNormal(channelCtx)).To(Succeed())
+				Expect(channel.Status.Image).ToNot(BeNil())
+				Expect(channel.Status.Image.Name).To(Equal("vmi-b"))
+			})
+		})
+	})
+
+	Context("ImageToChannels", func() {
+		BeforeEach(func() {
+			cluster := newChannel(ns, "cluster")
+			cluster.Spec.ImageKind = "ClusterVirtualMachineImage"
+			initObjects = append(initObjects,
+				channel,
+				cluster,
+				newChannel("other-ns", "photon"),
+			)
+		})
+
+		It("should map a namespaced image to the channels in its namespace", func() {
+			reqs := reconciler.ImageToChannels(ctx, readyImage(ns, "vmi-1", "photon", "5.0.0"))
+			Expect(reqs).To(ConsistOf(reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: ns, Name: "photon"},
+			}))
+		})
+
+		It("should map a cluster image to the channels that select cluster images", func() {
+			reqs := reconciler.ImageToChannels(ctx, readyClusterImage("vmi-1", "photon", "5.0.0"))
+			Expect(reqs).To(ConsistOf(reconcile.Request{
+				NamespacedName: types.NamespacedName{Namespace: ns, Name: "cluster"},
+			}))
+		})
+	})
+}
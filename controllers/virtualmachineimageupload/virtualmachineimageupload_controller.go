@@ -0,0 +1,367 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachineimageupload
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	imgregv1a1 "github.com/vmware-tanzu/image-registry-operator-api/api/v1alpha1"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/imageupload"
+	"github.com/vmware-tanzu/vm-operator/pkg/patch"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers"
+	"github.com/vmware-tanzu/vm-operator/pkg/record"
+)
+
+const (
+	// imageReadyRequeueDelay is how long to wait before checking again
+	// whether the VirtualMachineImage for the uploaded item is ready.
+	imageReadyRequeueDelay = 10 * time.Second
+
+	// uploadTokenSize is the number of random bytes in the token of an
+	// upload URL.
+	uploadTokenSize = 32
+
+	// uploadTimeoutGracePeriod is how long to wait after an upload times out
+	// before it is marked as timed out, so the upload handler has the chance
+	// to record the outcome of the upload itself.
+	uploadTimeoutGracePeriod = 1 * time.Minute
+)
+
+// AddToManager adds this package's controller to the provided manager.
+func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr manager.Manager) error {
+	var (
+		controlledType     = &vmopv1.VirtualMachineImageUpload{}
+		controlledTypeName = reflect.TypeOf(controlledType).Elem().Name()
+
+		controllerNameShort = fmt.Sprintf("%s-controller", strings.ToLower(controlledTypeName))
+		controllerNameLong  = fmt.Sprintf("%s/%s/%s", ctx.Namespace, ctx.Name, controllerNameShort)
+	)
+
+	r := NewReconciler(
+		ctx,
+		mgr.GetClient(),
+		ctrl.Log.WithName("controllers").WithName(controlledTypeName),
+		record.New(mgr.GetEventRecorderFor(controllerNameLong)),
+		ctx.VMProvider,
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(controlledType).
+		WithOptions(controller.Options{MaxConcurrentReconciles: ctx.MaxConcurrentReconciles}).
+		Complete(r)
+}
+
+func NewReconciler(
+	ctx context.Context,
+	client client.Client,
+	logger logr.Logger,
+	recorder record.Recorder,
+	vmProvider providers.VirtualMachineProviderInterface) *Reconciler {
+
+	return &Reconciler{
+		Context:    ctx,
+		Client:     client,
+		Logger:     logger,
+		Recorder:   recorder,
+		VMProvider: vmProvider,
+	}
+}
+
+// Reconciler reconciles a VirtualMachineImageUpload object.
+type Reconciler struct {
+	client.Client
+	Context    context.Context
+	Logger     logr.Logger
+	Recorder   record.Recorder
+	VMProvider providers.VirtualMachineProviderInterface
+}
+
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineimageuploads,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineimageuploads/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineimages,verbs=get;list;watch
+// +kubebuilder:rbac:groups=imageregistry.vmware.com,resources=contentlibraries,verbs=get;list;watch
+
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	ctx = pkgcfg.JoinContext(ctx, r.Context)
+
+	vmiUpload := &vmopv1.VirtualMachineImageUpload{}
+	if err := r.Get(ctx, req.NamespacedName, vmiUpload); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	vmiUploadCtx := &pkgctx.VirtualMachineImageUploadContext{
+		Context:       ctx,
+		Logger:        r.Logger.WithValues("name", req.NamespacedName),
+		VMImageUpload: vmiUpload,
+	}
+
+	patchHelper, err := patch.NewHelper(vmiUpload, r.Client)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to init patch helper for %s: %w", vmiUploadCtx, err)
+	}
+	defer func() {
+		if err := patchHelper.Patch(ctx, vmiUpload); err != nil {
+			if reterr == nil {
+				reterr = err
+			}
+			vmiUploadCtx.Logger.Error(err, "patch failed")
+		}
+	}()
+
+	if !vmiUpload.DeletionTimestamp.IsZero() {
+		// Noop. The uploaded library item is not deleted with the resource.
+		return ctrl.Result{}, nil
+	}
+
+	return r.ReconcileNormal(vmiUploadCtx)
+}
+
+func (r *Reconciler) ReconcileNormal(ctx *pkgctx.VirtualMachineImageUploadContext) (ctrl.Result, error) {
+	vmiUpload := ctx.VMImageUpload
+
+	if conditions.IsTrue(vmiUpload, vmopv1.VirtualMachineImageUploadConditionComplete) {
+		return ctrl.Result{}, nil
+	}
+
+	ctx.Logger.Info("Reconciling VirtualMachineImageUpload")
+
+	if ok, err := r.checkIsTargetValid(ctx); !ok || err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if vmiUpload.Status.UploadURL == "" {
+		if err := r.issueUploadURL(ctx); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	if result, ok := r.checkIsUploaded(ctx); !ok {
+		return result, nil
+	}
+
+	if ok, err := r.checkIsImageReady(ctx); err != nil || !ok {
+		return ctrl.Result{RequeueAfter: imageReadyRequeueDelay}, err
+	}
+
+	conditions.MarkTrue(vmiUpload, vmopv1.VirtualMachineImageUploadConditionComplete)
+	vmiUpload.Status.Ready = true
+	vmiUpload.Status.CompletionTime = metav1.Now()
+	ctx.Logger.Info("VM image upload completed", "time", vmiUpload.Status.CompletionTime)
+	r.Recorder.Eventf(vmiUpload, "Uploaded", "Uploaded OVA as VirtualMachineImage %s",
+		vmiUpload.Status.ImageName)
+
+	return ctrl.Result{}, nil
+}
+
+// checkIsTargetValid checks that the content library to which the OVA is
+// uploaded exists, is writable and ready, and does not already have an item
+// with the upload's item name. It returns false if the OVA may not be
+// uploaded.
+func (r *Reconciler) checkIsTargetValid(ctx *pkgctx.VirtualMachineImageUploadContext) (bool, error) {
+	vmiUpload := ctx.VMImageUpload
+
+	// Once the target is valid it is not checked again, since the uploaded
+	// item is itself an item with the upload's item name.
+	if conditions.IsTrue(vmiUpload, vmopv1.VirtualMachineImageUploadConditionTargetValid) {
+		return true, nil
+	}
+
+	contentLibrary := &imgregv1a1.ContentLibrary{}
+	objKey := client.ObjectKey{Name: vmiUpload.Spec.Library, Namespace: vmiUpload.Namespace}
+	if err := r.Get(ctx, objKey, contentLibrary); err != nil {
+		ctx.Logger.Error(err, "failed to get ContentLibrary", "cl", objKey)
+		if apierrors.IsNotFound(err) {
+			conditions.MarkError(vmiUpload,
+				vmopv1.VirtualMachineImageUploadConditionTargetValid,
+				vmopv1.TargetContentLibraryNotExistReason,
+				err)
+		}
+		return false, err
+	}
+
+	if !contentLibrary.Spec.Writable {
+		err := fmt.Errorf("library %s is not writable", contentLibrary.Status.Name)
+		conditions.MarkError(vmiUpload,
+			vmopv1.VirtualMachineImageUploadConditionTargetValid,
+			vmopv1.TargetContentLibraryNotWritableReason,
+			err)
+		return false, err
+	}
+
+	isReady := false
+	for _, condition := range contentLibrary.Status.Conditions {
+		if condition.Type == imgregv1a1.ReadyCondition {
+			isReady = condition.Status == corev1.ConditionTrue
+			break
+		}
+	}
+
+	if !isReady {
+		err := fmt.Errorf("library %s is not ready", contentLibrary.Status.Name)
+		conditions.MarkError(vmiUpload,
+			vmopv1.VirtualMachineImageUploadConditionTargetValid,
+			vmopv1.TargetContentLibraryNotReadyReason,
+			err)
+		return false, err
+	}
+
+	itemName := vmiUpload.Spec.ItemName
+	if itemName == "" {
+		itemName = vmiUpload.Name
+	}
+
+	item, err := r.VMProvider.GetItemFromLibraryByName(ctx, string(contentLibrary.Spec.UUID), itemName)
+	if err != nil {
+		ctx.Logger.Error(err, "failed to find item", "cl", objKey, "item name", itemName)
+		return false, err
+	}
+
+	if item != nil {
+		// If a duplicate item name exists, give up at this point. There is no
+		// need to requeue.
+		conditions.MarkFalse(vmiUpload,
+			vmopv1.VirtualMachineImageUploadConditionTargetValid,
+			vmopv1.TargetItemAlreadyExistsReason,
+			"item with name %s already exists in the content library %s", itemName, contentLibrary.Status.Name)
+		return false, nil
+	}
+
+	conditions.MarkTrue(vmiUpload, vmopv1.VirtualMachineImageUploadConditionTargetValid)
+	return true, nil
+}
+
+// issueUploadURL sets the single-use upload URL and its expiration time. No
+// URL is issued if the external URL of the image upload server is not
+// configured.
+func (r *Reconciler) issueUploadURL(ctx *pkgctx.VirtualMachineImageUploadContext) error {
+	vmiUpload := ctx.VMImageUpload
+
+	cfg := pkgcfg.FromContext(ctx)
+	if cfg.ImageUpload.BaseURL == "" {
+		conditions.MarkFalse(vmiUpload,
+			vmopv1.VirtualMachineImageUploadConditionUploaded,
+			vmopv1.UploadServerNotConfiguredReason,
+			"The external URL of the image upload server is not configured")
+		return nil
+	}
+
+	token := make([]byte, uploadTokenSize)
+	if _, err := rand.Read(token); err != nil {
+		return fmt.Errorf("failed to generate upload token: %w", err)
+	}
+
+	vmiUpload.Status.UploadURL = imageupload.UploadURL(
+		cfg.ImageUpload.BaseURL,
+		vmiUpload.Namespace,
+		vmiUpload.Name,
+		hex.EncodeToString(token))
+	vmiUpload.Status.ExpirationTime = metav1.NewTime(time.Now().Add(cfg.ImageUpload.URLTTL))
+
+	conditions.MarkFalse(vmiUpload,
+		vmopv1.VirtualMachineImageUploadConditionUploaded,
+		vmopv1.HasNotBeenUploadedReason,
+		"Waiting for the OVA to be uploaded")
+
+	ctx.Logger.Info("Issued upload URL", "expirationTime", vmiUpload.Status.ExpirationTime)
+	return nil
+}
+
+// checkIsUploaded returns true if the OVA has been uploaded. Otherwise it
+// returns the result with which to wait for the upload. The Uploaded
+// condition is set by the upload handler, except for when the upload URL
+// expires or the upload times out, since the handler may never finish, ex.
+// if its replica of the controller manager exits.
+func (r *Reconciler) checkIsUploaded(ctx *pkgctx.VirtualMachineImageUploadContext) (ctrl.Result, bool) {
+	vmiUpload := ctx.VMImageUpload
+
+	c := conditions.Get(vmiUpload, vmopv1.VirtualMachineImageUploadConditionUploaded)
+	if c == nil {
+		return ctrl.Result{}, false
+	}
+	if c.Status == metav1.ConditionTrue {
+		return ctrl.Result{}, true
+	}
+
+	switch c.Reason {
+	case vmopv1.HasNotBeenUploadedReason:
+		if remaining := time.Until(vmiUpload.Status.ExpirationTime.Time); remaining > 0 {
+			return ctrl.Result{RequeueAfter: remaining}, false
+		}
+		conditions.MarkFalse(vmiUpload,
+			vmopv1.VirtualMachineImageUploadConditionUploaded,
+			vmopv1.UploadURLExpiredReason,
+			"The upload URL expired at %s", vmiUpload.Status.ExpirationTime)
+		r.Recorder.Warn(vmiUpload, "UploadURLExpired", "The upload URL expired before the OVA was uploaded")
+	case vmopv1.UploadingReason:
+		timeout := pkgcfg.FromContext(ctx).ImageUpload.Timeout + uploadTimeoutGracePeriod
+		if remaining := time.Until(c.LastTransitionTime.Add(timeout)); remaining > 0 {
+			return ctrl.Result{RequeueAfter: remaining}, false
+		}
+		conditions.MarkFalse(vmiUpload,
+			vmopv1.VirtualMachineImageUploadConditionUploaded,
+			vmopv1.UploadTimedOutReason,
+			"The upload did not complete within %s", pkgcfg.FromContext(ctx).ImageUpload.Timeout)
+		r.Recorder.Warn(vmiUpload, "UploadTimedOut", "The upload timed out before the OVA was received")
+	default:
+		// The upload URL is not issued, in which case the URL is issued
+		// once the image upload server is configured, or the upload failed,
+		// which is terminal.
+	}
+
+	return ctrl.Result{}, false
+}
+
+// checkIsImageReady returns true if the VirtualMachineImage for the uploaded
+// library item exists and is ready.
+func (r *Reconciler) checkIsImageReady(ctx *pkgctx.VirtualMachineImageUploadContext) (bool, error) {
+	vmiUpload := ctx.VMImageUpload
+
+	vmiList := &vmopv1.VirtualMachineImageList{}
+	if err := r.List(ctx, vmiList, client.InNamespace(vmiUpload.Namespace)); err != nil {
+		ctx.Logger.Error(err, "failed to list VirtualMachineImage")
+		return false, err
+	}
+
+	for i := range vmiList.Items {
+		vmi := &vmiList.Items[i]
+		if vmi.Status.ProviderItemID != vmiUpload.Status.LibraryItemID {
+			continue
+		}
+		if !conditions.IsTrue(vmi, vmopv1.ReadyConditionType) {
+			break
+		}
+
+		vmiUpload.Status.ImageName = vmi.Name
+		conditions.MarkTrue(vmiUpload, vmopv1.VirtualMachineImageUploadConditionImageReady)
+		ctx.Logger.Info("VirtualMachineImage is ready", "vmiName", vmi.Name)
+		return true, nil
+	}
+
+	conditions.MarkFalse(vmiUpload,
+		vmopv1.VirtualMachineImageUploadConditionImageReady,
+		vmopv1.ImageUnavailableReason,
+		"VirtualMachineImage for library item %s is not ready", vmiUpload.Status.LibraryItemID)
+	return false, nil
+}
@@ -0,0 +1,105 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachineimageupload_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func intgTests() {
+	Describe(
+		"Reconcile",
+		Label(
+			testlabels.Controller,
+			testlabels.EnvTest,
+			testlabels.API,
+		),
+		intgTestsReconcile,
+	)
+}
+
+func intgTestsReconcile() {
+	const itemID = "my-item-id"
+
+	var (
+		ctx       *builder.IntegrationTestContext
+		vmiUpload *vmopv1.VirtualMachineImageUpload
+	)
+
+	BeforeEach(func() {
+		ctx = suite.NewIntegrationTestContext()
+
+		cl := builder.DummyContentLibrary("my-cl", ctx.Namespace, "my-cl-uuid")
+		clStatus := cl.Status.DeepCopy()
+		Expect(ctx.Client.Create(ctx, cl)).To(Succeed())
+		cl.Status = *clStatus
+		Expect(ctx.Client.Status().Update(ctx, cl)).To(Succeed())
+
+		vmiUpload = newImageUpload(ctx.Namespace, "my-upload")
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		intgFakeVMProvider.Reset()
+	})
+
+	getImageUpload := func() *vmopv1.VirtualMachineImageUpload {
+		obj := &vmopv1.VirtualMachineImageUpload{}
+		if err := ctx.Client.Get(ctx, client.ObjectKeyFromObject(vmiUpload), obj); err != nil {
+			return nil
+		}
+		return obj
+	}
+
+	It("should complete once the uploaded image is ready", func() {
+		Expect(ctx.Client.Create(ctx, vmiUpload)).To(Succeed())
+
+		var obj *vmopv1.VirtualMachineImageUpload
+		Eventually(func(g Gomega) {
+			obj = getImageUpload()
+			g.Expect(obj).ToNot(BeNil())
+			g.Expect(obj.Status.UploadURL).To(HavePrefix("https://vmop.example.com/image-upload/"))
+			g.Expect(conditions.GetReason(obj, vmopv1.VirtualMachineImageUploadConditionUploaded)).
+				To(Equal(vmopv1.HasNotBeenUploadedReason))
+		}).Should(Succeed())
+
+		By("Simulate the upload of the OVA", func() {
+			obj.Status.LibraryItemID = itemID
+			conditions.MarkTrue(obj, vmopv1.VirtualMachineImageUploadConditionUploaded)
+			Expect(ctx.Client.Status().Update(ctx, obj)).To(Succeed())
+		})
+
+		Eventually(func(g Gomega) {
+			obj := getImageUpload()
+			g.Expect(obj).ToNot(BeNil())
+			g.Expect(conditions.GetReason(obj, vmopv1.VirtualMachineImageUploadConditionImageReady)).
+				To(Equal(vmopv1.ImageUnavailableReason))
+		}).Should(Succeed())
+
+		By("Simulate the VirtualMachineImage reconcile", func() {
+			vmi := builder.DummyVirtualMachineImage("vmi-0123456789abcdef0")
+			vmi.Namespace = ctx.Namespace
+			Expect(ctx.Client.Create(ctx, vmi)).To(Succeed())
+			vmi.Status.ProviderItemID = itemID
+			conditions.MarkTrue(vmi, vmopv1.ReadyConditionType)
+			Expect(ctx.Client.Status().Update(ctx, vmi)).To(Succeed())
+		})
+
+		Eventually(func(g Gomega) {
+			obj := getImageUpload()
+			g.Expect(obj).ToNot(BeNil())
+			g.Expect(obj.Status.Ready).To(BeTrue())
+			g.Expect(obj.Status.ImageName).To(Equal("vmi-0123456789abcdef0"))
+		}).Should(Succeed())
+	})
+}
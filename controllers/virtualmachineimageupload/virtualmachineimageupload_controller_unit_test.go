@@ -0,0 +1,329 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachineimageupload_test
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware/govmomi/vapi/library"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	imgregv1a1 "github.com/vmware-tanzu/image-registry-operator-api/api/v1alpha1"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineimageupload"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	providerfake "github.com/vmware-tanzu/vm-operator/pkg/providers/fake"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func unitTests() {
+	Describe(
+		"Reconcile",
+		Label(
+			testlabels.Controller,
+		),
+		unitTestsReconcile,
+	)
+}
+
+func unitTestsReconcile() {
+	const (
+		ns     = "dummy-ns"
+		itemID = "my-item-id"
+	)
+
+	var (
+		initObjects []client.Object
+		ctx         *builder.UnitTestContextForController
+
+		reconciler     *virtualmachineimageupload.Reconciler
+		fakeVMProvider *providerfake.VMProvider
+
+		cl           *imgregv1a1.ContentLibrary
+		vmiUpload    *vmopv1.VirtualMachineImageUpload
+		vmiUploadCtx *pkgctx.VirtualMachineImageUploadContext
+		existingItem *library.Item
+		baseURL      string
+	)
+
+	BeforeEach(func() {
+		cl = builder.DummyContentLibrary("my-cl", ns, "my-cl-uuid")
+		vmiUpload = newImageUpload(ns, "my-upload")
+		existingItem = nil
+		baseURL = "https://vmop.example.com"
+	})
+
+	JustBeforeEach(func() {
+		initObjects = append(initObjects, cl)
+		ctx = suite.NewUnitTestContextForController(initObjects...)
+		pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+			config.ImageUpload.BaseURL = baseURL
+			config.ImageUpload.URLTTL = time.Hour
+			config.ImageUpload.Timeout = time.Hour
+		})
+		reconciler = virtualmachineimageupload.NewReconciler(
+			ctx,
+			ctx.Client,
+			ctx.Logger,
+			ctx.Recorder,
+			ctx.VMProvider,
+		)
+		fakeVMProvider = ctx.VMProvider.(*providerfake.VMProvider)
+		fakeVMProvider.Reset()
+		fakeVMProvider.GetItemFromLibraryByNameFn = func(
+			_ context.Context, _, _ string) (*library.Item, error) {
+			return existingItem, nil
+		}
+
+		vmiUploadCtx = &pkgctx.VirtualMachineImageUploadContext{
+			Context:       ctx,
+			Logger:        ctx.Logger.WithName(vmiUpload.Name),
+			VMImageUpload: vmiUpload,
+		}
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+		initObjects = nil
+		reconciler = nil
+	})
+
+	Context("ReconcileNormal", func() {
+
+		When("the content library does not exist", func() {
+			BeforeEach(func() {
+				vmiUpload.Spec.Library = "other-cl"
+			})
+
+			It("marks the target as invalid", func() {
+				_, err := reconciler.ReconcileNormal(vmiUploadCtx)
+				Expect(err).To(HaveOccurred())
+				Expect(conditions.GetReason(vmiUpload, vmopv1.VirtualMachineImageUploadConditionTargetValid)).
+					To(Equal(vmopv1.TargetContentLibraryNotExistReason))
+				Expect(vmiUpload.Status.UploadURL).To(BeEmpty())
+			})
+		})
+
+		When("the content library is not writable", func() {
+			BeforeEach(func() {
+				cl.Spec.Writable = false
+			})
+
+			It("marks the target as invalid", func() {
+				_, err := reconciler.ReconcileNormal(vmiUploadCtx)
+				Expect(err).To(HaveOccurred())
+				Expect(conditions.GetReason(vmiUpload, vmopv1.VirtualMachineImageUploadConditionTargetValid)).
+					To(Equal(vmopv1.TargetContentLibraryNotWritableReason))
+			})
+		})
+
+		When("the content library already has an item with the item name", func() {
+			BeforeEach(func() {
+				existingItem = &library.Item{ID: "other-item-id", Name: vmiUpload.Name}
+			})
+
+			It("marks the target as invalid without an error", func() {
+				result, err := reconciler.ReconcileNormal(vmiUploadCtx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.RequeueAfter).To(BeZero())
+				Expect(conditions.GetReason(vmiUpload, vmopv1.VirtualMachineImageUploadConditionTargetValid)).
+					To(Equal(vmopv1.TargetItemAlreadyExistsReason))
+				Expect(vmiUpload.Status.UploadURL).To(BeEmpty())
+			})
+		})
+
+		When("the target is valid", func() {
+			It("issues an upload URL", func() {
+				result, err := reconciler.ReconcileNormal(vmiUploadCtx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(conditions.IsTrue(vmiUpload, vmopv1.VirtualMachineImageUploadConditionTargetValid)).To(BeTrue())
+				Expect(conditions.GetReason(vmiUpload, vmopv1.VirtualMachineImageUploadConditionUploaded)).
+					To(Equal(vmopv1.HasNotBeenUploadedReason))
+
+				Expect(vmiUpload.Status.UploadURL).To(HavePrefix(
+					"https://vmop.example.com/image-upload/" + ns + "/my-upload/"))
+				token := strings.TrimPrefix(vmiUpload.Status.UploadURL,
+					"https://vmop.example.com/image-upload/"+ns+"/my-upload/")
+				Expect(token).To(HaveLen(64))
+
+				Expect(vmiUpload.Status.ExpirationTime.Time).To(BeTemporally("~", time.Now().Add(time.Hour), time.Minute))
+				Expect(result.RequeueAfter).To(BeNumerically("~", time.Hour, time.Minute))
+			})
+
+			When("the external URL of the image upload server is not configured", func() {
+				BeforeEach(func() {
+					baseURL = ""
+				})
+
+				It("does not issue an upload URL", func() {
+					result, err := reconciler.ReconcileNormal(vmiUploadCtx)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(result.RequeueAfter).To(BeZero())
+					Expect(conditions.GetReason(vmiUpload, vmopv1.VirtualMachineImageUploadConditionUploaded)).
+						To(Equal(vmopv1.UploadServerNotConfiguredReason))
+					Expect(vmiUpload.Status.UploadURL).To(BeEmpty())
+				})
+			})
+
+			It("does not reissue the upload URL", func() {
+				_, err := reconciler.ReconcileNormal(vmiUploadCtx)
+				Expect(err).ToNot(HaveOccurred())
+				uploadURL := vmiUpload.Status.UploadURL
+
+				_, err = reconciler.ReconcileNormal(vmiUploadCtx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(vmiUpload.Status.UploadURL).To(Equal(uploadURL))
+			})
+		})
+
+		When("the upload URL has been issued", func() {
+			BeforeEach(func() {
+				conditions.MarkTrue(vmiUpload, vmopv1.VirtualMachineImageUploadConditionTargetValid)
+				vmiUpload.Status.UploadURL = "https://vmop.example.com/image-upload/" + ns + "/my-upload/token"
+				vmiUpload.Status.ExpirationTime = metav1.NewTime(time.Now().Add(time.Hour))
+				conditions.MarkFalse(vmiUpload,
+					vmopv1.VirtualMachineImageUploadConditionUploaded,
+					vmopv1.HasNotBeenUploadedReason,
+					"")
+			})
+
+			When("the upload URL has expired", func() {
+				BeforeEach(func() {
+					vmiUpload.Status.ExpirationTime = metav1.NewTime(time.Now().Add(-time.Minute))
+				})
+
+				It("marks the upload URL as expired", func() {
+					result, err := reconciler.ReconcileNormal(vmiUploadCtx)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(result.RequeueAfter).To(BeZero())
+					Expect(conditions.GetReason(vmiUpload, vmopv1.VirtualMachineImageUploadConditionUploaded)).
+						To(Equal(vmopv1.UploadURLExpiredReason))
+					Expect(vmiUpload.Status.Ready).To(BeFalse())
+				})
+			})
+
+			When("the OVA is being uploaded", func() {
+				BeforeEach(func() {
+					conditions.MarkFalse(vmiUpload,
+						vmopv1.VirtualMachineImageUploadConditionUploaded,
+						vmopv1.UploadingReason,
+						"")
+				})
+
+				It("waits for the upload to finish", func() {
+					result, err := reconciler.ReconcileNormal(vmiUploadCtx)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(result.RequeueAfter).To(BeNumerically("~", time.Hour+time.Minute, time.Minute))
+					Expect(conditions.GetReason(vmiUpload, vmopv1.VirtualMachineImageUploadConditionUploaded)).
+						To(Equal(vmopv1.UploadingReason))
+				})
+
+				When("the upload has timed out", func() {
+					BeforeEach(func() {
+						for i := range vmiUpload.Status.Conditions {
+							c := &vmiUpload.Status.Conditions[i]
+							if c.Type == vmopv1.VirtualMachineImageUploadConditionUploaded {
+								c.LastTransitionTime = metav1.NewTime(time.Now().Add(-2 * time.Hour))
+							}
+						}
+					})
+
+					It("marks the upload as timed out", func() {
+						result, err := reconciler.ReconcileNormal(vmiUploadCtx)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(result.RequeueAfter).To(BeZero())
+						Expect(conditions.GetReason(vmiUpload, vmopv1.VirtualMachineImageUploadConditionUploaded)).
+							To(Equal(vmopv1.UploadTimedOutReason))
+						Expect(vmiUpload.Status.Ready).To(BeFalse())
+					})
+				})
+			})
+
+			When("the OVA is invalid", func() {
+				BeforeEach(func() {
+					conditions.MarkFalse(vmiUpload,
+						vmopv1.VirtualMachineImageUploadConditionUploaded,
+						vmopv1.UploadInvalidOVAReason,
+						"")
+				})
+
+				It("does not complete", func() {
+					result, err := reconciler.ReconcileNormal(vmiUploadCtx)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(result.RequeueAfter).To(BeZero())
+					Expect(conditions.Has(vmiUpload, vmopv1.VirtualMachineImageUploadConditionComplete)).To(BeFalse())
+				})
+			})
+		})
+
+		When("the OVA has been uploaded", func() {
+			var vmi *vmopv1.VirtualMachineImage
+
+			BeforeEach(func() {
+				conditions.MarkTrue(vmiUpload, vmopv1.VirtualMachineImageUploadConditionTargetValid)
+				conditions.MarkTrue(vmiUpload, vmopv1.VirtualMachineImageUploadConditionUploaded)
+				vmiUpload.Status.UploadURL = "https://vmop.example.com/image-upload/" + ns + "/my-upload/token"
+				vmiUpload.Status.LibraryItemID = itemID
+
+				vmi = builder.DummyVirtualMachineImage("vmi-0123456789abcdef0")
+				vmi.Namespace = ns
+				vmi.Status.ProviderItemID = itemID
+			})
+
+			When("the image does not exist", func() {
+				It("waits for the image", func() {
+					result, err := reconciler.ReconcileNormal(vmiUploadCtx)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(result.RequeueAfter).ToNot(BeZero())
+					Expect(conditions.GetReason(vmiUpload, vmopv1.VirtualMachineImageUploadConditionImageReady)).
+						To(Equal(vmopv1.ImageUnavailableReason))
+				})
+			})
+
+			When("the image is not ready", func() {
+				BeforeEach(func() {
+					conditions.MarkFalse(vmi, vmopv1.ReadyConditionType, "NotReady", "")
+					initObjects = append(initObjects, vmi)
+				})
+
+				It("waits for the image", func() {
+					result, err := reconciler.ReconcileNormal(vmiUploadCtx)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(result.RequeueAfter).ToNot(BeZero())
+					Expect(vmiUpload.Status.ImageName).To(BeEmpty())
+				})
+			})
+
+			When("the image is ready", func() {
+				BeforeEach(func() {
+					conditions.MarkTrue(vmi, vmopv1.ReadyConditionType)
+					initObjects = append(initObjects, vmi)
+				})
+
+				It("completes", func() {
+					result, err := reconciler.ReconcileNormal(vmiUploadCtx)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(result.RequeueAfter).To(BeZero())
+					Expect(vmiUpload.Status.ImageName).To(Equal(vmi.Name))
+					Expect(conditions.IsTrue(vmiUpload, vmopv1.VirtualMachineImageUploadConditionImageReady)).To(BeTrue())
+					Expect(conditions.IsTrue(vmiUpload, vmopv1.VirtualMachineImageUploadConditionComplete)).To(BeTrue())
+					Expect(vmiUpload.Status.Ready).To(BeTrue())
+					Expect(vmiUpload.Status.CompletionTime.IsZero()).To(BeFalse())
+				})
+			})
+		})
+	})
+}
@@ -0,0 +1,595 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachineimportrequest
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	vmopv1common "github.com/vmware-tanzu/vm-operator/api/v1alpha4/common"
+	cnsregv1alpha1 "github.com/vmware-tanzu/vm-operator/external/vsphere-csi-driver/pkg/syncer/cnsoperator/apis/cnsregistervolume/v1alpha1"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/patch"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers"
+	"github.com/vmware-tanzu/vm-operator/pkg/record"
+	"github.com/vmware-tanzu/vm-operator/pkg/topology"
+	"github.com/vmware-tanzu/vm-operator/pkg/util"
+)
+
+const (
+	relocateFailedReason = "RelocateFailed"
+
+	// volumeRegistrationRequeueDelay is how long to wait before checking
+	// again whether the data disks have been registered.
+	volumeRegistrationRequeueDelay = 10 * time.Second
+)
+
+// AddToManager adds this package's controller to the provided manager.
+func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr manager.Manager) error {
+	var (
+		controlledType     = &vmopv1.VirtualMachineImportRequest{}
+		controlledTypeName = reflect.TypeOf(controlledType).Elem().Name()
+
+		controllerNameShort = fmt.Sprintf("%s-controller", strings.ToLower(controlledTypeName))
+		controllerNameLong  = fmt.Sprintf("%s/%s/%s", ctx.Namespace, ctx.Name, controllerNameShort)
+	)
+
+	r := NewReconciler(
+		ctx,
+		mgr.GetClient(),
+		ctrl.Log.WithName("controllers").WithName(controlledTypeName),
+		record.New(mgr.GetEventRecorderFor(controllerNameLong)),
+		ctx.VMProvider,
+	)
+
+	return ctrl.NewControllerManagedBy(mgr).
+		For(controlledType).
+		Owns(&cnsregv1alpha1.CnsRegisterVolume{}).
+		WithOptions(controller.Options{MaxConcurrentReconciles: ctx.MaxConcurrentReconciles}).
+		Complete(r)
+}
+
+func NewReconciler(
+	ctx context.Context,
+	client client.Client,
+	logger logr.Logger,
+	recorder record.Recorder,
+	vmProvider providers.VirtualMachineProviderInterface) *Reconciler {
+
+	return &Reconciler{
+		Context:    ctx,
+		Client:     client,
+		Logger:     logger,
+		Recorder:   recorder,
+		VMProvider: vmProvider,
+	}
+}
+
+// Reconciler reconciles a VirtualMachineImportRequest object.
+type Reconciler struct {
+	client.Client
+	Context    context.Context
+	Logger     logr.Logger
+	Recorder   record.Recorder
+	VMProvider providers.VirtualMachineProviderInterface
+}
+
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineimportrequests,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineimportrequests/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachines,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineclasses,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups=cns.vmware.com,resources=cnsregistervolumes,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=cns.vmware.com,resources=cnsregistervolumes/status,verbs=get;list
+
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	ctx = pkgcfg.JoinContext(ctx, r.Context)
+
+	vmImport := &vmopv1.VirtualMachineImportRequest{}
+	if err := r.Get(ctx, req.NamespacedName, vmImport); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	vmImportCtx := &pkgctx.VirtualMachineImportRequestContext{
+		Context:         ctx,
+		Logger:          r.Logger.WithValues("name", req.NamespacedName),
+		VMImportRequest: vmImport,
+	}
+
+	patchHelper, err := patch.NewHelper(vmImport, r.Client)
+	if err != nil {
+		return ctrl.Result{}, fmt.Errorf("failed to init patch helper for %s: %w", vmImportCtx, err)
+	}
+	defer func() {
+		if err := patchHelper.Patch(ctx, vmImport); err != nil {
+			if reterr == nil {
+				reterr = err
+			}
+			vmImportCtx.Logger.Error(err, "patch failed")
+		}
+	}()
+
+	if !vmImport.DeletionTimestamp.IsZero() {
+		// Noop. The CnsRegisterVolume resources are garbage collected.
+		return ctrl.Result{}, nil
+	}
+
+	return r.ReconcileNormal(vmImportCtx)
+}
+
+func (r *Reconciler) ReconcileNormal(ctx *pkgctx.VirtualMachineImportRequestContext) (ctrl.Result, error) {
+	vmImport := ctx.VMImportRequest
+
+	if conditions.IsTrue(vmImport, vmopv1.VirtualMachineImportRequestConditionComplete) {
+		return ctrl.Result{}, nil
+	}
+
+	ctx.Logger.Info("Reconciling VirtualMachineImportRequest")
+
+	if vmImport.Status.StartTime.IsZero() {
+		vmImport.Status.StartTime = metav1.Now()
+	}
+
+	if ok, err := r.checkIsSourceValid(ctx); !ok || err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := r.relocate(ctx); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	registered, err := r.registerVolumes(ctx)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if !registered {
+		return ctrl.Result{RequeueAfter: volumeRegistrationRequeueDelay}, nil
+	}
+
+	if ok, err := r.createVirtualMachine(ctx); !ok || err != nil {
+		return ctrl.Result{}, err
+	}
+
+	conditions.MarkTrue(vmImport, vmopv1.VirtualMachineImportRequestConditionComplete)
+	vmImport.Status.Ready = true
+	vmImport.Status.CompletionTime = metav1.Now()
+	ctx.Logger.Info("VM import request completed", "time", vmImport.Status.CompletionTime)
+	r.Recorder.Eventf(vmImport, "Imported", "Imported vSphere VM %s as VirtualMachine %s",
+		vmImport.Status.Source.ID, vmImport.Status.VirtualMachineRef.Name)
+
+	return ctrl.Result{}, nil
+}
+
+// checkIsSourceValid records the observed state of the vSphere VM to be
+// imported. It returns false if the VM may not be imported.
+func (r *Reconciler) checkIsSourceValid(ctx *pkgctx.VirtualMachineImportRequestContext) (bool, error) {
+	vmImport := ctx.VMImportRequest
+
+	// Once the VM has been relocated it is managed by VM Operator and may not
+	// be looked up as an import source again.
+	if conditions.IsTrue(vmImport, vmopv1.VirtualMachineImportRequestConditionSourceValid) {
+		return true, nil
+	}
+
+	src, err := r.VMProvider.GetVirtualMachineImportSource(ctx, vmImport)
+	if err != nil {
+		var reason string
+		switch {
+		case errors.Is(err, providers.ErrVMImportSourceNotFound):
+			reason = vmopv1.SourceVirtualMachineNotFoundReason
+		case errors.Is(err, providers.ErrVMImportSourceManaged):
+			reason = vmopv1.SourceVirtualMachineAlreadyManagedReason
+		case errors.Is(err, providers.ErrVMImportSourceNoZone):
+			reason = vmopv1.SourceVirtualMachineZoneNotFoundReason
+		case errors.Is(err, providers.ErrVMImportSourceNotPoweredOff):
+			reason = vmopv1.SourceVirtualMachineNotPoweredOffReason
+		default:
+			return false, fmt.Errorf("failed to get vm to import: %w", err)
+		}
+
+		r.markSourceInvalid(ctx, reason, err)
+		return false, nil
+	}
+
+	vmImport.Status.Source = src
+
+	if unmapped := getUnmappedNetworks(vmImport); len(unmapped) > 0 {
+		r.markSourceInvalid(ctx, vmopv1.SourceNetworkNotMappedReason,
+			fmt.Errorf("vm networks are not mapped: %s", strings.Join(unmapped, ", ")))
+		return false, nil
+	}
+
+	// The vSphere VM is not changed until it is known that the resources for
+	// the imported VM may be created.
+	if ok, err := r.checkIsTargetValid(ctx); !ok || err != nil {
+		return false, err
+	}
+
+	conditions.MarkTrue(vmImport, vmopv1.VirtualMachineImportRequestConditionSourceValid)
+
+	return true, nil
+}
+
+// checkIsTargetValid returns false if the VirtualMachine or VirtualMachineClass
+// for the imported VM already exists or may not be created.
+func (r *Reconciler) checkIsTargetValid(ctx *pkgctx.VirtualMachineImportRequestContext) (bool, error) {
+	vmImport := ctx.VMImportRequest
+
+	vmClass, err := newVirtualMachineClass(vmImport)
+	if err != nil {
+		return false, err
+	}
+	vm := newVirtualMachine(vmImport)
+
+	if err := r.Get(ctx, client.ObjectKeyFromObject(vmClass), &vmopv1.VirtualMachineClass{}); err == nil {
+		r.markSourceInvalid(ctx, vmopv1.TargetVirtualMachineClassAlreadyExistsReason,
+			fmt.Errorf("VirtualMachineClass %s already exists", vmClass.Name))
+		return false, nil
+	} else if !apierrors.IsNotFound(err) {
+		return false, fmt.Errorf("failed to get VirtualMachineClass %s: %w", vmClass.Name, err)
+	}
+
+	if err := r.Get(ctx, client.ObjectKeyFromObject(vm), &vmopv1.VirtualMachine{}); err == nil {
+		r.markSourceInvalid(ctx, vmopv1.TargetVirtualMachineAlreadyExistsReason,
+			fmt.Errorf("VirtualMachine %s already exists", vm.Name))
+		return false, nil
+	} else if !apierrors.IsNotFound(err) {
+		return false, fmt.Errorf("failed to get VirtualMachine %s: %w", vm.Name, err)
+	}
+
+	for _, obj := range []client.Object{vmClass, vm} {
+		if err := r.Create(ctx, obj, client.DryRunAll); err != nil {
+			if !apierrors.IsInvalid(err) && !apierrors.IsForbidden(err) {
+				return false, fmt.Errorf("failed to dry-run create %s: %w", obj.GetName(), err)
+			}
+			r.markSourceInvalid(ctx, vmopv1.TargetVirtualMachineInvalidReason, err)
+			return false, nil
+		}
+	}
+
+	return true, nil
+}
+
+// markSourceInvalid marks the source of the request as invalid. Retrying will
+// not fix the source, so the request is not requeued.
+func (r *Reconciler) markSourceInvalid(
+	ctx *pkgctx.VirtualMachineImportRequestContext,
+	reason string,
+	err error) {
+
+	conditions.MarkError(ctx.VMImportRequest,
+		vmopv1.VirtualMachineImportRequestConditionSourceValid, reason, err)
+	r.Recorder.EmitEvent(ctx.VMImportRequest, "SourceValid", err, true)
+}
+
+// getUnmappedNetworks returns the names of the vSphere networks to which the
+// imported VM's network adapters are connected that are not in the request's
+// network mappings.
+func getUnmappedNetworks(vmImport *vmopv1.VirtualMachineImportRequest) []string {
+	mapped := map[string]struct{}{}
+	for _, m := range vmImport.Spec.NetworkMappings {
+		mapped[m.Source] = struct{}{}
+	}
+
+	var unmapped []string
+	for _, n := range vmImport.Status.Source.Networks {
+		if _, ok := mapped[n.Network]; !ok {
+			name := n.Network
+			if name == "" {
+				name = fmt.Sprintf("<unknown network of %s>", n.Name)
+			}
+			unmapped = append(unmapped, name)
+		}
+	}
+
+	return unmapped
+}
+
+// relocate moves the vSphere VM into the namespace and marks it as managed.
+func (r *Reconciler) relocate(ctx *pkgctx.VirtualMachineImportRequestContext) error {
+	vmImport := ctx.VMImportRequest
+
+	if conditions.IsTrue(vmImport, vmopv1.VirtualMachineImportRequestConditionRelocated) {
+		return nil
+	}
+
+	if err := r.VMProvider.ImportVirtualMachine(ctx, vmImport); err != nil {
+		conditions.MarkError(vmImport,
+			vmopv1.VirtualMachineImportRequestConditionRelocated, relocateFailedReason, err)
+		return fmt.Errorf("failed to relocate vm: %w", err)
+	}
+
+	conditions.MarkTrue(vmImport, vmopv1.VirtualMachineImportRequestConditionRelocated)
+
+	return nil
+}
+
+// registerVolumes registers each of the vSphere VM's data disks as a
+// PersistentVolumeClaim. It returns true once all disks are registered.
+func (r *Reconciler) registerVolumes(ctx *pkgctx.VirtualMachineImportRequestContext) (bool, error) {
+	vmImport := ctx.VMImportRequest
+
+	if conditions.IsTrue(vmImport, vmopv1.VirtualMachineImportRequestConditionVolumesRegistered) {
+		return true, nil
+	}
+
+	var (
+		pending int
+		failed  []string
+	)
+
+	for i := range vmImport.Status.Source.Disks {
+		disk := &vmImport.Status.Source.Disks[i]
+		if disk.Registered {
+			continue
+		}
+
+		crv, err := r.getOrCreateCnsRegisterVolume(ctx, *disk)
+		if err != nil {
+			return false, err
+		}
+
+		switch {
+		case crv.Status.Registered:
+			disk.Registered = true
+		case crv.Status.Error != "":
+			failed = append(failed, fmt.Sprintf("%s: %s", disk.ClaimName, crv.Status.Error))
+		default:
+			pending++
+		}
+	}
+
+	if len(failed) > 0 {
+		conditions.MarkFalse(vmImport,
+			vmopv1.VirtualMachineImportRequestConditionVolumesRegistered,
+			vmopv1.VolumeRegistrationFailedReason,
+			"Failed to register disks: %s", strings.Join(failed, "; "))
+		return false, nil
+	}
+
+	if pending > 0 {
+		conditions.MarkFalse(vmImport,
+			vmopv1.VirtualMachineImportRequestConditionVolumesRegistered,
+			vmopv1.VolumeRegistrationPendingReason,
+			"Waiting for %d disk(s) to be registered", pending)
+		return false, nil
+	}
+
+	conditions.MarkTrue(vmImport, vmopv1.VirtualMachineImportRequestConditionVolumesRegistered)
+
+	return true, nil
+}
+
+func (r *Reconciler) getOrCreateCnsRegisterVolume(
+	ctx *pkgctx.VirtualMachineImportRequestContext,
+	disk vmopv1.VirtualMachineImportRequestDiskStatus) (*cnsregv1alpha1.CnsRegisterVolume, error) {
+
+	vmImport := ctx.VMImportRequest
+
+	crv := &cnsregv1alpha1.CnsRegisterVolume{}
+	key := client.ObjectKey{Namespace: vmImport.Namespace, Name: disk.ClaimName}
+	if err := r.Get(ctx, key, crv); err == nil {
+		return crv, nil
+	} else if !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get CnsRegisterVolume %s: %w", key, err)
+	}
+
+	crv = &cnsregv1alpha1.CnsRegisterVolume{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+		},
+		Spec: cnsregv1alpha1.CnsRegisterVolumeSpec{
+			PvcName:     disk.ClaimName,
+			AccessMode:  corev1.ReadWriteOnce,
+			DiskURLPath: disk.DiskURLPath,
+		},
+	}
+	if err := controllerutil.SetControllerReference(vmImport, crv, r.Scheme()); err != nil {
+		return nil, err
+	}
+
+	ctx.Logger.Info("Creating CnsRegisterVolume", "name", key.Name, "diskURLPath", disk.DiskURLPath)
+	if err := r.Create(ctx, crv); err != nil {
+		return nil, fmt.Errorf("failed to create CnsRegisterVolume %s: %w", key, err)
+	}
+
+	return crv, nil
+}
+
+// createVirtualMachine creates the VirtualMachineClass and VirtualMachine
+// resources for the imported VM. It returns false if either resource exists
+// with the target name and was not created for the imported VM.
+func (r *Reconciler) createVirtualMachine(ctx *pkgctx.VirtualMachineImportRequestContext) (bool, error) {
+	vmImport := ctx.VMImportRequest
+
+	vmClass, err := newVirtualMachineClass(vmImport)
+	if err != nil {
+		return false, err
+	}
+
+	existingClass := &vmopv1.VirtualMachineClass{}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(vmClass), existingClass); err == nil {
+		if !isClassForImportedVM(*existingClass, *vmClass) {
+			conditions.MarkFalse(vmImport,
+				vmopv1.VirtualMachineImportRequestConditionVirtualMachineCreated,
+				vmopv1.TargetVirtualMachineClassAlreadyExistsReason,
+				"VirtualMachineClass %s already exists", vmClass.Name)
+			return false, nil
+		}
+	} else if !apierrors.IsNotFound(err) {
+		return false, fmt.Errorf("failed to get VirtualMachineClass %s: %w", vmClass.Name, err)
+	} else {
+		ctx.Logger.Info("Creating VirtualMachineClass for imported VM", "className", vmClass.Name)
+		if err := r.Create(ctx, vmClass); err != nil {
+			return false, fmt.Errorf("failed to create VirtualMachineClass %s: %w", vmClass.Name, err)
+		}
+	}
+
+	vm := newVirtualMachine(vmImport)
+
+	existing := &vmopv1.VirtualMachine{}
+	if err := r.Get(ctx, client.ObjectKeyFromObject(vm), existing); err == nil {
+		if existing.Spec.InstanceUUID != vm.Spec.InstanceUUID {
+			conditions.MarkFalse(vmImport,
+				vmopv1.VirtualMachineImportRequestConditionVirtualMachineCreated,
+				vmopv1.TargetVirtualMachineAlreadyExistsReason,
+				"VirtualMachine %s already exists", vm.Name)
+			return false, nil
+		}
+	} else if !apierrors.IsNotFound(err) {
+		return false, fmt.Errorf("failed to get VirtualMachine %s: %w", vm.Name, err)
+	} else {
+		ctx.Logger.Info("Creating VirtualMachine for imported VM", "vmName", vm.Name)
+		if err := r.Create(ctx, vm); err != nil {
+			return false, fmt.Errorf("failed to create VirtualMachine %s: %w", vm.Name, err)
+		}
+	}
+
+	vmImport.Status.VirtualMachineRef = &vmopv1common.LocalObjectRef{
+		APIVersion: vmopv1.GroupVersion.String(),
+		Kind:       "VirtualMachine",
+		Name:       vm.Name,
+	}
+	conditions.MarkTrue(vmImport, vmopv1.VirtualMachineImportRequestConditionVirtualMachineCreated)
+
+	return true, nil
+}
+
+// isClassForImportedVM returns true if the existing VirtualMachineClass has the
+// same hardware as the one for the imported VM.
+func isClassForImportedVM(existing, vmClass vmopv1.VirtualMachineClass) bool {
+	if existing.Spec.Hardware.Cpus != vmClass.Spec.Hardware.Cpus ||
+		!existing.Spec.Hardware.Memory.Equal(vmClass.Spec.Hardware.Memory) {
+		return false
+	}
+
+	// The ConfigSpec is compared after it is unmarshaled since the API server
+	// may have reformatted the JSON.
+	existingConfigSpec, err := util.UnmarshalConfigSpecFromJSON(existing.Spec.ConfigSpec)
+	if err != nil {
+		return false
+	}
+	configSpec, err := util.UnmarshalConfigSpecFromJSON(vmClass.Spec.ConfigSpec)
+	if err != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(existingConfigSpec, configSpec)
+}
+
+// getTargetName returns the name of the resources created for the imported VM.
+func getTargetName(vmImport *vmopv1.VirtualMachineImportRequest) string {
+	if vmImport.Spec.TargetName != "" {
+		return vmImport.Spec.TargetName
+	}
+	return vmImport.Name
+}
+
+// newVirtualMachineClass returns the VirtualMachineClass resource for the
+// imported VM. Its hardware is the imported VM's CPU and memory configuration.
+func newVirtualMachineClass(vmImport *vmopv1.VirtualMachineImportRequest) (*vmopv1.VirtualMachineClass, error) {
+	src := vmImport.Status.Source
+
+	configSpec, err := util.UnmarshalConfigSpecFromJSON(src.ConfigSpec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config spec of vm to import: %w", err)
+	}
+
+	return &vmopv1.VirtualMachineClass{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      getTargetName(vmImport),
+			Namespace: vmImport.Namespace,
+		},
+		Spec: vmopv1.VirtualMachineClassSpec{
+			Hardware: vmopv1.VirtualMachineClassHardware{
+				Cpus:   int64(configSpec.NumCPUs),
+				Memory: resource.MustParse(fmt.Sprintf("%dMi", configSpec.MemoryMB)),
+			},
+			ConfigSpec: src.ConfigSpec,
+		},
+	}, nil
+}
+
+// newVirtualMachine returns the VirtualMachine resource for the imported VM.
+// The VM's class is the one returned by newVirtualMachineClass, and it has no
+// image. Each of the VM's network interfaces keeps the MAC address of the
+// network adapter, and is connected to the network to which the adapter's
+// vSphere network is mapped.
+func newVirtualMachine(vmImport *vmopv1.VirtualMachineImportRequest) *vmopv1.VirtualMachine {
+	src := vmImport.Status.Source
+	name := getTargetName(vmImport)
+
+	vm := &vmopv1.VirtualMachine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: vmImport.Namespace,
+			Annotations: map[string]string{
+				vmopv1.ImportedVMAnnotation: "",
+			},
+			Labels: map[string]string{
+				topology.KubernetesTopologyZoneLabelKey: src.Zone,
+			},
+		},
+		Spec: vmopv1.VirtualMachineSpec{
+			ClassName:    name,
+			StorageClass: vmImport.Spec.StorageClass,
+			PowerState:   src.PowerState,
+			InstanceUUID: src.InstanceUUID,
+			BiosUUID:     src.BiosUUID,
+		},
+	}
+
+	for _, disk := range src.Disks {
+		vm.Spec.Volumes = append(vm.Spec.Volumes, vmopv1.VirtualMachineVolume{
+			Name: disk.ClaimName,
+			VirtualMachineVolumeSource: vmopv1.VirtualMachineVolumeSource{
+				PersistentVolumeClaim: &vmopv1.PersistentVolumeClaimVolumeSource{
+					PersistentVolumeClaimVolumeSource: corev1.PersistentVolumeClaimVolumeSource{
+						ClaimName: disk.ClaimName,
+					},
+				},
+			},
+		})
+	}
+
+	if len(src.Networks) > 0 {
+		networks := map[string]*vmopv1common.PartialObjectRef{}
+		for _, m := range vmImport.Spec.NetworkMappings {
+			networks[m.Source] = m.Network
+		}
+
+		vm.Spec.Network = &vmopv1.VirtualMachineNetworkSpec{}
+		for _, n := range src.Networks {
+			iface := vmopv1.VirtualMachineNetworkInterfaceSpec{
+				Name:       n.Name,
+				MacAddress: n.MacAddress,
+			}
+			if ref := networks[n.Network]; ref != nil {
+				iface.Network = ref.DeepCopy()
+			}
+			vm.Spec.Network.Interfaces = append(vm.Spec.Network.Interfaces, iface)
+		}
+	}
+
+	return vm
+}
@@ -0,0 +1,110 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachineimportrequest_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	cnsregv1alpha1 "github.com/vmware-tanzu/vm-operator/external/vsphere-csi-driver/pkg/syncer/cnsoperator/apis/cnsregistervolume/v1alpha1"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func intgTests() {
+	Describe(
+		"Reconcile",
+		Label(
+			testlabels.Controller,
+			testlabels.EnvTest,
+			testlabels.API,
+		),
+		intgTestsReconcile,
+	)
+}
+
+func intgTestsReconcile() {
+	var (
+		ctx      *builder.IntegrationTestContext
+		vmImport *vmopv1.VirtualMachineImportRequest
+	)
+
+	BeforeEach(func() {
+		ctx = suite.NewIntegrationTestContext()
+
+		vmImport = newImportRequest(ctx.Namespace, "my-import")
+
+		srcStatus := newSourceStatus()
+		srcStatus.Disks = []vmopv1.VirtualMachineImportRequestDiskStatus{
+			{
+				DiskURLPath: "https://vc/folder/my-vm/my-vm_1.vmdk?dcPath=dc&dsName=ds",
+				ClaimName:   "my-import-disk-1",
+			},
+		}
+
+		intgFakeVMProvider.Lock()
+		intgFakeVMProvider.GetVirtualMachineImportSourceFn = func(
+			_ context.Context,
+			_ *vmopv1.VirtualMachineImportRequest) (*vmopv1.VirtualMachineImportRequestSourceStatus, error) {
+			return srcStatus.DeepCopy(), nil
+		}
+		intgFakeVMProvider.Unlock()
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		intgFakeVMProvider.Reset()
+	})
+
+	getImportRequest := func() *vmopv1.VirtualMachineImportRequest {
+		obj := &vmopv1.VirtualMachineImportRequest{}
+		if err := ctx.Client.Get(ctx, client.ObjectKeyFromObject(vmImport), obj); err != nil {
+			return nil
+		}
+		return obj
+	}
+
+	It("should import the VM once its disks are registered", func() {
+		Expect(ctx.Client.Create(ctx, vmImport)).To(Succeed())
+
+		crv := &cnsregv1alpha1.CnsRegisterVolume{}
+		Eventually(func(g Gomega) {
+			g.Expect(ctx.Client.Get(ctx, client.ObjectKey{Namespace: ctx.Namespace, Name: "my-import-disk-1"}, crv)).To(Succeed())
+		}).Should(Succeed())
+
+		Eventually(func(g Gomega) {
+			obj := getImportRequest()
+			g.Expect(obj).ToNot(BeNil())
+			g.Expect(conditions.GetReason(obj, vmopv1.VirtualMachineImportRequestConditionVolumesRegistered)).
+				To(Equal(vmopv1.VolumeRegistrationPendingReason))
+		}).Should(Succeed())
+
+		crv.Status.Registered = true
+		Expect(ctx.Client.Status().Update(ctx, crv)).To(Succeed())
+
+		Eventually(func(g Gomega) {
+			obj := getImportRequest()
+			g.Expect(obj).ToNot(BeNil())
+			g.Expect(obj.Status.Ready).To(BeTrue())
+			g.Expect(obj.Status.VirtualMachineRef).ToNot(BeNil())
+			g.Expect(obj.Status.VirtualMachineRef.Name).To(Equal(vmImport.Name))
+		}).Should(Succeed())
+
+		vm := &vmopv1.VirtualMachine{}
+		Expect(ctx.Client.Get(ctx, client.ObjectKey{Namespace: ctx.Namespace, Name: vmImport.Name}, vm)).To(Succeed())
+		Expect(vm.Spec.Volumes).To(HaveLen(1))
+		Expect(vm.Spec.Volumes[0].PersistentVolumeClaim.ClaimName).To(Equal("my-import-disk-1"))
+
+		vmClass := &vmopv1.VirtualMachineClass{}
+		Expect(ctx.Client.Get(ctx, client.ObjectKey{Namespace: ctx.Namespace, Name: vm.Spec.ClassName}, vmClass)).To(Succeed())
+		Expect(vmClass.Spec.Hardware.Cpus).To(BeEquivalentTo(2))
+	})
+}
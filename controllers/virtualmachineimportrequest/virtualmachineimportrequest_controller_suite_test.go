@@ -0,0 +1,69 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachineimportrequest_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlmgr "sigs.k8s.io/controller-runtime/pkg/manager"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineimportrequest"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	providerfake "github.com/vmware-tanzu/vm-operator/pkg/providers/fake"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+var intgFakeVMProvider = providerfake.NewVMProvider()
+
+var suite = builder.NewTestSuiteForControllerWithContext(
+	pkgcfg.UpdateContext(
+		pkgcfg.NewContextWithDefaultConfig(),
+		func(config *pkgcfg.Config) {
+			config.Features.VMImportNewNet = true
+		},
+	),
+	virtualmachineimportrequest.AddToManager,
+	func(ctx *pkgctx.ControllerManagerContext, _ ctrlmgr.Manager) error {
+		ctx.VMProvider = intgFakeVMProvider
+		return nil
+	})
+
+func TestVirtualMachineImportRequest(t *testing.T) {
+	suite.Register(t, "VirtualMachineImportRequest controller suite", intgTests, unitTests)
+}
+
+var _ = BeforeSuite(suite.BeforeSuite)
+
+var _ = AfterSuite(suite.AfterSuite)
+
+func newImportRequest(namespace, name string) *vmopv1.VirtualMachineImportRequest {
+	return &vmopv1.VirtualMachineImportRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: vmopv1.VirtualMachineImportRequestSpec{
+			Source: vmopv1.VirtualMachineImportRequestSource{
+				ID: "vm-42",
+			},
+		},
+	}
+}
+
+func newSourceStatus() *vmopv1.VirtualMachineImportRequestSourceStatus {
+	return &vmopv1.VirtualMachineImportRequestSourceStatus{
+		ID:           "vm-42",
+		Name:         "my-vm",
+		InstanceUUID: "instance-uuid",
+		BiosUUID:     "bios-uuid",
+		Zone:         "zone-a",
+		PowerState:   vmopv1.VirtualMachinePowerStateOff,
+		ConfigSpec:   []byte(`{"_typeName":"VirtualMachineConfigSpec","numCPUs":2,"memoryMB":4096}`),
+	}
+}
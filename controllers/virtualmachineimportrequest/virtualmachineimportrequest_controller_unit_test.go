@@ -0,0 +1,437 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachineimportrequest_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	vmopv1common "github.com/vmware-tanzu/vm-operator/api/v1alpha4/common"
+	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachineimportrequest"
+	cnsregv1alpha1 "github.com/vmware-tanzu/vm-operator/external/vsphere-csi-driver/pkg/syncer/cnsoperator/apis/cnsregistervolume/v1alpha1"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers"
+	providerfake "github.com/vmware-tanzu/vm-operator/pkg/providers/fake"
+	"github.com/vmware-tanzu/vm-operator/pkg/topology"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func unitTests() {
+	Describe(
+		"Reconcile",
+		Label(
+			testlabels.Controller,
+		),
+		unitTestsReconcile,
+	)
+}
+
+func unitTestsReconcile() {
+	const ns = "dummy-ns"
+
+	var (
+		initObjects []client.Object
+		funcs       interceptor.Funcs
+		ctx         *builder.UnitTestContextForController
+
+		reconciler     *virtualmachineimportrequest.Reconciler
+		fakeVMProvider *providerfake.VMProvider
+
+		vmImport    *vmopv1.VirtualMachineImportRequest
+		vmImportCtx *pkgctx.VirtualMachineImportRequestContext
+		srcStatus   *vmopv1.VirtualMachineImportRequestSourceStatus
+		importCalls int
+	)
+
+	BeforeEach(func() {
+		vmImport = newImportRequest(ns, "my-import")
+		srcStatus = newSourceStatus()
+		importCalls = 0
+	})
+
+	JustBeforeEach(func() {
+		ctx = suite.NewUnitTestContextForControllerWithFuncs(funcs, initObjects...)
+		reconciler = virtualmachineimportrequest.NewReconciler(
+			ctx,
+			ctx.Client,
+			ctx.Logger,
+			ctx.Recorder,
+			ctx.VMProvider,
+		)
+		fakeVMProvider = ctx.VMProvider.(*providerfake.VMProvider)
+		fakeVMProvider.Reset()
+		fakeVMProvider.GetVirtualMachineImportSourceFn = func(
+			_ context.Context,
+			_ *vmopv1.VirtualMachineImportRequest) (*vmopv1.VirtualMachineImportRequestSourceStatus, error) {
+			return srcStatus.DeepCopy(), nil
+		}
+		fakeVMProvider.ImportVirtualMachineFn = func(
+			_ context.Context,
+			_ *vmopv1.VirtualMachineImportRequest) error {
+			importCalls++
+			return nil
+		}
+
+		vmImportCtx = &pkgctx.VirtualMachineImportRequestContext{
+			Context:         ctx,
+			Logger:          ctx.Logger.WithName(vmImport.Name),
+			VMImportRequest: vmImport,
+		}
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+		initObjects = nil
+		funcs = interceptor.Funcs{}
+		reconciler = nil
+	})
+
+	getVM := func(name string) *vmopv1.VirtualMachine {
+		vm := &vmopv1.VirtualMachine{}
+		Expect(ctx.Client.Get(ctx, client.ObjectKey{Namespace: ns, Name: name}, vm)).To(Succeed())
+		return vm
+	}
+
+	expectSourceInvalid := func(expectedReason string) {
+		Expect(importCalls).To(BeZero())
+		Expect(vmImport.Status.Ready).To(BeFalse())
+
+		c := conditions.Get(vmImport, vmopv1.VirtualMachineImportRequestConditionSourceValid)
+		Expect(c).ToNot(BeNil())
+		Expect(c.Status).To(Equal(metav1.ConditionFalse))
+		Expect(c.Reason).To(Equal(expectedReason))
+	}
+
+	Context("ReconcileNormal", func() {
+
+		When("the source VM has no data disks", func() {
+			BeforeEach(func() {
+				srcStatus.Networks = []vmopv1.VirtualMachineImportRequestNetworkStatus{
+					{Name: "eth0", Network: "dvpg-1", MacAddress: "00:50:56:00:00:01"},
+					{Name: "eth1", Network: "dvpg-2", MacAddress: "00:50:56:00:00:02"},
+				}
+				vmImport.Spec.TargetName = "imported-vm"
+				vmImport.Spec.StorageClass = "my-storage-class"
+				vmImport.Spec.NetworkMappings = []vmopv1.VirtualMachineImportRequestNetworkMapping{
+					{
+						Source: "dvpg-1",
+					},
+					{
+						Source: "dvpg-2",
+						Network: &vmopv1common.PartialObjectRef{
+							TypeMeta: metav1.TypeMeta{Kind: "Network", APIVersion: "netoperator.vmware.com/v1alpha1"},
+							Name:     "my-network",
+						},
+					},
+				}
+			})
+
+			It("imports the VM and creates the VirtualMachine", func() {
+				_, err := reconciler.ReconcileNormal(vmImportCtx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(importCalls).To(Equal(1))
+
+				Expect(vmImport.Status.StartTime.IsZero()).To(BeFalse())
+				Expect(vmImport.Status.CompletionTime.IsZero()).To(BeFalse())
+				Expect(vmImport.Status.Ready).To(BeTrue())
+				Expect(vmImport.Status.Source).To(Equal(srcStatus))
+				for _, t := range []string{
+					vmopv1.VirtualMachineImportRequestConditionSourceValid,
+					vmopv1.VirtualMachineImportRequestConditionRelocated,
+					vmopv1.VirtualMachineImportRequestConditionVolumesRegistered,
+					vmopv1.VirtualMachineImportRequestConditionVirtualMachineCreated,
+					vmopv1.VirtualMachineImportRequestConditionComplete,
+				} {
+					Expect(conditions.IsTrue(vmImport, t)).To(BeTrue(), t)
+				}
+				Expect(vmImport.Status.VirtualMachineRef).ToNot(BeNil())
+				Expect(vmImport.Status.VirtualMachineRef.Name).To(Equal("imported-vm"))
+
+				vm := getVM("imported-vm")
+				Expect(vm.Annotations).To(HaveKey(vmopv1.ImportedVMAnnotation))
+				Expect(vm.Labels).To(HaveKeyWithValue(topology.KubernetesTopologyZoneLabelKey, "zone-a"))
+				Expect(vm.Spec.ClassName).To(Equal("imported-vm"))
+				Expect(vm.Spec.Image).To(BeNil())
+				Expect(vm.Spec.ImageName).To(BeEmpty())
+				Expect(vm.Spec.StorageClass).To(Equal("my-storage-class"))
+				Expect(vm.Spec.PowerState).To(Equal(vmopv1.VirtualMachinePowerStateOff))
+				Expect(vm.Spec.InstanceUUID).To(Equal("instance-uuid"))
+				Expect(vm.Spec.BiosUUID).To(Equal("bios-uuid"))
+				Expect(vm.Spec.Volumes).To(BeEmpty())
+				Expect(vm.Spec.Network).ToNot(BeNil())
+				Expect(vm.Spec.Network.Interfaces).To(HaveLen(2))
+				Expect(vm.Spec.Network.Interfaces[0].Name).To(Equal("eth0"))
+				Expect(vm.Spec.Network.Interfaces[0].MacAddress).To(Equal("00:50:56:00:00:01"))
+				Expect(vm.Spec.Network.Interfaces[0].Network).To(BeNil())
+				Expect(vm.Spec.Network.Interfaces[1].Name).To(Equal("eth1"))
+				Expect(vm.Spec.Network.Interfaces[1].MacAddress).To(Equal("00:50:56:00:00:02"))
+				Expect(vm.Spec.Network.Interfaces[1].Network).ToNot(BeNil())
+				Expect(vm.Spec.Network.Interfaces[1].Network.Name).To(Equal("my-network"))
+
+				vmClass := &vmopv1.VirtualMachineClass{}
+				Expect(ctx.Client.Get(ctx, client.ObjectKey{Namespace: ns, Name: "imported-vm"}, vmClass)).To(Succeed())
+				Expect(vmClass.Spec.Hardware.Cpus).To(BeEquivalentTo(2))
+				Expect(vmClass.Spec.Hardware.Memory.String()).To(Equal("4Gi"))
+				Expect(vmClass.Spec.ConfigSpec).To(MatchJSON(srcStatus.ConfigSpec))
+			})
+
+			When("a source network is not mapped", func() {
+				BeforeEach(func() {
+					vmImport.Spec.NetworkMappings = vmImport.Spec.NetworkMappings[1:]
+				})
+
+				It("does not import the VM", func() {
+					_, err := reconciler.ReconcileNormal(vmImportCtx)
+					Expect(err).ToNot(HaveOccurred())
+					expectSourceInvalid(vmopv1.SourceNetworkNotMappedReason)
+					Expect(conditions.Get(vmImport, vmopv1.VirtualMachineImportRequestConditionSourceValid).Message).
+						To(ContainSubstring("dvpg-1"))
+				})
+			})
+
+			It("does nothing once the request is complete", func() {
+				_, err := reconciler.ReconcileNormal(vmImportCtx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(importCalls).To(Equal(1))
+
+				fakeVMProvider.GetVirtualMachineImportSourceFn = func(
+					_ context.Context,
+					_ *vmopv1.VirtualMachineImportRequest) (*vmopv1.VirtualMachineImportRequestSourceStatus, error) {
+					return nil, providers.ErrVMImportSourceManaged
+				}
+
+				_, err = reconciler.ReconcileNormal(vmImportCtx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(importCalls).To(Equal(1))
+				Expect(vmImport.Status.Ready).To(BeTrue())
+			})
+		})
+
+		DescribeTable("the source VM may not be imported",
+			func(providerErr error, expectedReason string) {
+				fakeVMProvider.GetVirtualMachineImportSourceFn = func(
+					_ context.Context,
+					_ *vmopv1.VirtualMachineImportRequest) (*vmopv1.VirtualMachineImportRequestSourceStatus, error) {
+					return nil, providerErr
+				}
+
+				_, err := reconciler.ReconcileNormal(vmImportCtx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(vmImport.Status.Source).To(BeNil())
+				expectSourceInvalid(expectedReason)
+			},
+			Entry("not found", providers.ErrVMImportSourceNotFound, vmopv1.SourceVirtualMachineNotFoundReason),
+			Entry("already managed", providers.ErrVMImportSourceManaged, vmopv1.SourceVirtualMachineAlreadyManagedReason),
+			Entry("no zone", fmt.Errorf("%w: no az", providers.ErrVMImportSourceNoZone), vmopv1.SourceVirtualMachineZoneNotFoundReason),
+			Entry("not powered off", providers.ErrVMImportSourceNotPoweredOff, vmopv1.SourceVirtualMachineNotPoweredOffReason),
+		)
+
+		When("getting the source VM fails", func() {
+			It("returns an error", func() {
+				fakeVMProvider.GetVirtualMachineImportSourceFn = func(
+					_ context.Context,
+					_ *vmopv1.VirtualMachineImportRequest) (*vmopv1.VirtualMachineImportRequestSourceStatus, error) {
+					return nil, errors.New("fubar")
+				}
+
+				_, err := reconciler.ReconcileNormal(vmImportCtx)
+				Expect(err).To(MatchError(ContainSubstring("fubar")))
+				Expect(importCalls).To(BeZero())
+			})
+		})
+
+		When("relocating the VM fails", func() {
+			It("marks the request as not relocated", func() {
+				fakeVMProvider.ImportVirtualMachineFn = func(
+					_ context.Context,
+					_ *vmopv1.VirtualMachineImportRequest) error {
+					return errors.New("relocate failed")
+				}
+
+				_, err := reconciler.ReconcileNormal(vmImportCtx)
+				Expect(err).To(MatchError(ContainSubstring("relocate failed")))
+				Expect(conditions.IsTrue(vmImport, vmopv1.VirtualMachineImportRequestConditionSourceValid)).To(BeTrue())
+				Expect(conditions.IsFalse(vmImport, vmopv1.VirtualMachineImportRequestConditionRelocated)).To(BeTrue())
+			})
+		})
+
+		When("the source VM has data disks", func() {
+			BeforeEach(func() {
+				srcStatus.Disks = []vmopv1.VirtualMachineImportRequestDiskStatus{
+					{
+						DiskURLPath: "https://vc/folder/my-vm/my-vm_1.vmdk?dcPath=dc&dsName=ds",
+						ClaimName:   "my-import-disk-1",
+					},
+					{
+						DiskURLPath: "https://vc/folder/my-vm/my-vm_2.vmdk?dcPath=dc&dsName=ds",
+						ClaimName:   "my-import-disk-2",
+					},
+				}
+			})
+
+			getCRV := func(name string) *cnsregv1alpha1.CnsRegisterVolume {
+				crv := &cnsregv1alpha1.CnsRegisterVolume{}
+				Expect(ctx.Client.Get(ctx, client.ObjectKey{Namespace: ns, Name: name}, crv)).To(Succeed())
+				return crv
+			}
+
+			It("registers the disks before creating the VirtualMachine", func() {
+				result, err := reconciler.ReconcileNormal(vmImportCtx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.RequeueAfter).ToNot(BeZero())
+
+				c := conditions.Get(vmImport, vmopv1.VirtualMachineImportRequestConditionVolumesRegistered)
+				Expect(c).ToNot(BeNil())
+				Expect(c.Status).To(Equal(metav1.ConditionFalse))
+				Expect(c.Reason).To(Equal(vmopv1.VolumeRegistrationPendingReason))
+				Expect(vmImport.Status.VirtualMachineRef).To(BeNil())
+
+				for _, disk := range srcStatus.Disks {
+					crv := getCRV(disk.ClaimName)
+					Expect(crv.Spec.PvcName).To(Equal(disk.ClaimName))
+					Expect(crv.Spec.DiskURLPath).To(Equal(disk.DiskURLPath))
+					Expect(crv.Spec.AccessMode).To(Equal(corev1.ReadWriteOnce))
+					Expect(crv.OwnerReferences).To(HaveLen(1))
+					Expect(crv.OwnerReferences[0].Name).To(Equal(vmImport.Name))
+
+					crv.Status.Registered = true
+					Expect(ctx.Client.Status().Update(ctx, crv)).To(Succeed())
+				}
+
+				result, err = reconciler.ReconcileNormal(vmImportCtx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(result.RequeueAfter).To(BeZero())
+				Expect(importCalls).To(Equal(1))
+				Expect(vmImport.Status.Source.Disks[0].Registered).To(BeTrue())
+				Expect(vmImport.Status.Source.Disks[1].Registered).To(BeTrue())
+				Expect(vmImport.Status.Ready).To(BeTrue())
+
+				vm := getVM(vmImport.Name)
+				Expect(vm.Spec.Volumes).To(HaveLen(2))
+				for i, disk := range srcStatus.Disks {
+					Expect(vm.Spec.Volumes[i].Name).To(Equal(disk.ClaimName))
+					Expect(vm.Spec.Volumes[i].PersistentVolumeClaim).ToNot(BeNil())
+					Expect(vm.Spec.Volumes[i].PersistentVolumeClaim.ClaimName).To(Equal(disk.ClaimName))
+				}
+			})
+
+			It("marks the request as failed when a disk may not be registered", func() {
+				_, err := reconciler.ReconcileNormal(vmImportCtx)
+				Expect(err).ToNot(HaveOccurred())
+
+				crv := getCRV("my-import-disk-2")
+				crv.Status.Error = "disk is in use"
+				Expect(ctx.Client.Status().Update(ctx, crv)).To(Succeed())
+
+				_, err = reconciler.ReconcileNormal(vmImportCtx)
+				Expect(err).ToNot(HaveOccurred())
+
+				c := conditions.Get(vmImport, vmopv1.VirtualMachineImportRequestConditionVolumesRegistered)
+				Expect(c).ToNot(BeNil())
+				Expect(c.Status).To(Equal(metav1.ConditionFalse))
+				Expect(c.Reason).To(Equal(vmopv1.VolumeRegistrationFailedReason))
+				Expect(c.Message).To(ContainSubstring("disk is in use"))
+				Expect(vmImport.Status.Ready).To(BeFalse())
+			})
+		})
+
+		When("a VirtualMachine with the target name already exists", func() {
+			BeforeEach(func() {
+				vm := builder.DummyVirtualMachine()
+				vm.Namespace = ns
+				vm.Name = vmImport.Name
+				vm.Spec.InstanceUUID = "other-instance-uuid"
+				initObjects = append(initObjects, vm)
+			})
+
+			It("does not import the VM", func() {
+				_, err := reconciler.ReconcileNormal(vmImportCtx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(vmImport.Status.VirtualMachineRef).To(BeNil())
+				expectSourceInvalid(vmopv1.TargetVirtualMachineAlreadyExistsReason)
+			})
+		})
+
+		When("a VirtualMachineClass with the target name already exists", func() {
+			BeforeEach(func() {
+				vmClass := builder.DummyVirtualMachineClass(vmImport.Name)
+				vmClass.Namespace = ns
+				initObjects = append(initObjects, vmClass)
+			})
+
+			It("does not import the VM", func() {
+				_, err := reconciler.ReconcileNormal(vmImportCtx)
+				Expect(err).ToNot(HaveOccurred())
+				expectSourceInvalid(vmopv1.TargetVirtualMachineClassAlreadyExistsReason)
+			})
+		})
+
+		When("the VirtualMachine may not be created", func() {
+			BeforeEach(func() {
+				funcs.Create = func(
+					ctx context.Context,
+					client client.WithWatch,
+					obj client.Object,
+					opts ...client.CreateOption) error {
+
+					if _, ok := obj.(*vmopv1.VirtualMachine); ok {
+						return apierrors.NewForbidden(
+							vmopv1.GroupVersion.WithResource("virtualmachines").GroupResource(),
+							obj.GetName(), errors.New("denied"))
+					}
+					return client.Create(ctx, obj, opts...)
+				}
+			})
+
+			It("does not import the VM", func() {
+				_, err := reconciler.ReconcileNormal(vmImportCtx)
+				Expect(err).ToNot(HaveOccurred())
+				expectSourceInvalid(vmopv1.TargetVirtualMachineInvalidReason)
+				Expect(conditions.Get(vmImport, vmopv1.VirtualMachineImportRequestConditionSourceValid).Message).
+					To(ContainSubstring("denied"))
+			})
+		})
+
+		When("a VirtualMachine with the target name is created after the VM is relocated", func() {
+			It("does not complete the request", func() {
+				fakeVMProvider.ImportVirtualMachineFn = func(
+					_ context.Context,
+					_ *vmopv1.VirtualMachineImportRequest) error {
+
+					vm := builder.DummyVirtualMachine()
+					vm.Namespace = ns
+					vm.Name = vmImport.Name
+					vm.Spec.InstanceUUID = "other-instance-uuid"
+					Expect(ctx.Client.Create(ctx, vm)).To(Succeed())
+					return nil
+				}
+
+				_, err := reconciler.ReconcileNormal(vmImportCtx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(vmImport.Status.Ready).To(BeFalse())
+				Expect(vmImport.Status.VirtualMachineRef).To(BeNil())
+
+				c := conditions.Get(vmImport, vmopv1.VirtualMachineImportRequestConditionVirtualMachineCreated)
+				Expect(c).ToNot(BeNil())
+				Expect(c.Status).To(Equal(metav1.ConditionFalse))
+				Expect(c.Reason).To(Equal(vmopv1.TargetVirtualMachineAlreadyExistsReason))
+			})
+		})
+	})
+}
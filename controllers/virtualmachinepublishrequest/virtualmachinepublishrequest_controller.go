@@ -11,6 +11,7 @@ import (
 	"reflect"
 	"regexp"
 	"strings"
+	"sync"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
@@ -158,6 +159,9 @@ type Reconciler struct {
 	Recorder   record.Recorder
 	VMProvider providers.VirtualMachineProviderInterface
 	Metrics    *metrics.VMPublishMetrics
+
+	// ociTasks tracks the pushes to OCI registries by activation ID.
+	ociTasks sync.Map
 }
 
 func requeueResult(ctx *pkgctx.VirtualMachinePublishRequestContext) ctrl.Result {
@@ -194,8 +198,11 @@ func requeueResult(ctx *pkgctx.VirtualMachinePublishRequestContext) ctrl.Result
 // +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachinepublishrequests,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachinepublishrequests/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachines,verbs=get;list
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachinesnapshots,verbs=get;list
 // +kubebuilder:rbac:groups=imageregistry.vmware.com,resources=contentlibraries,verbs=get;list;watch
 // +kubebuilder:rbac:groups=imageregistry.vmware.com,resources=contentlibraries/status,verbs=get;
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineimages,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
 
 func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
 	ctx = pkgcfg.JoinContext(ctx, r.Context)
@@ -256,6 +263,10 @@ func (r *Reconciler) updateSourceAndTargetRef(ctx *pkgctx.VirtualMachinePublishR
 		vmPubReq.Status.SourceRef = &vmopv1.VirtualMachinePublishRequestSource{
 			Name: vmName,
 		}
+		if isSnapshotSource(vmPubReq) {
+			vmPubReq.Status.SourceRef.APIVersion = vmPubReq.Spec.Source.APIVersion
+			vmPubReq.Status.SourceRef.Kind = vmPubReq.Spec.Source.Kind
+		}
 	}
 
 	if vmPubReq.Status.TargetRef == nil {
@@ -313,6 +324,11 @@ func (r *Reconciler) publishVirtualMachine(ctx *pkgctx.VirtualMachinePublishRequ
 			return err
 		}
 
+		if isOCITarget(vmPublishReq) {
+			r.publishVirtualMachineToOCI(ctx)
+			return nil
+		}
+
 		go func() {
 			actID := getPublishRequestActID(vmPublishReq)
 			itemID, pubErr := r.VMProvider.PublishVirtualMachine(ctx, ctx.VM, vmPublishReq, ctx.ContentLibrary, actID)
@@ -362,8 +378,19 @@ func (r *Reconciler) removeVMPubResourceFromCluster(ctx *pkgctx.VirtualMachinePu
 // doesn't exist, or is not in Created phase.
 func (r *Reconciler) checkIsSourceValid(ctx *pkgctx.VirtualMachinePublishRequestContext) error {
 	vmPubReq := ctx.VMPublishRequest
+
+	vmName := vmPubReq.Status.SourceRef.Name
+	if isSnapshotSource(vmPubReq) {
+		vmSnapshot, err := r.getSourceSnapshot(ctx)
+		if err != nil {
+			return err
+		}
+		ctx.VMSnapshot = vmSnapshot
+		vmName = vmSnapshot.Spec.VMRef.Name
+	}
+
 	vm := &vmopv1.VirtualMachine{}
-	objKey := client.ObjectKey{Name: vmPubReq.Status.SourceRef.Name, Namespace: vmPubReq.Namespace}
+	objKey := client.ObjectKey{Name: vmName, Namespace: vmPubReq.Namespace}
 	err := r.Get(ctx, objKey, vm)
 	if err != nil {
 		ctx.Logger.Error(err, "failed to get VirtualMachine", "vm", objKey)
@@ -394,6 +421,10 @@ func (r *Reconciler) checkIsSourceValid(ctx *pkgctx.VirtualMachinePublishRequest
 // It is invalid if the content library doesn't exist, an item with the same name in the CL exists.
 func (r *Reconciler) checkIsTargetValid(ctx *pkgctx.VirtualMachinePublishRequestContext) error {
 	vmPubReq := ctx.VMPublishRequest
+	if isOCITarget(vmPubReq) {
+		return r.checkIsOCITargetValid(ctx)
+	}
+
 	contentLibrary := &imgregv1a1.ContentLibrary{}
 	targetLocationName := vmPubReq.Spec.Target.Location.Name
 	targetItemName := vmPubReq.Status.TargetRef.Item.Name
@@ -510,6 +541,12 @@ func (r *Reconciler) checkIsImageAvailable(ctx *pkgctx.VirtualMachinePublishRequ
 		}
 	}
 
+	if !found && isOCITarget(ctx.VMPublishRequest) {
+		if err := r.createOCIImage(ctx); err != nil {
+			return err
+		}
+	}
+
 	if !found {
 		conditions.MarkFalse(ctx.VMPublishRequest,
 			vmopv1.VirtualMachinePublishRequestConditionImageAvailable,
@@ -599,6 +636,10 @@ func (r *Reconciler) checkPubReqStatusAndShouldRepublish(ctx *pkgctx.VirtualMach
 		return false, nil
 	}
 
+	if isOCITarget(ctx.VMPublishRequest) {
+		return r.checkOCIPubReqStatusAndShouldRepublish(ctx)
+	}
+
 	actID := getPublishRequestActID(ctx.VMPublishRequest)
 	logger := ctx.Logger.WithValues("actID", actID, "descriptionID", TaskDescriptionID)
 
@@ -702,6 +743,10 @@ func (r *Reconciler) processUploadedItem(ctx *pkgctx.VirtualMachinePublishReques
 
 // getUploadedItemID returns the uploaded content library item ID.
 func (r *Reconciler) getUploadedItemID(ctx *pkgctx.VirtualMachinePublishRequestContext) (string, error) {
+	if isOCITarget(ctx.VMPublishRequest) {
+		return r.getUploadedOCIItemID(ctx)
+	}
+
 	task, err := r.getPublishRequestTask(ctx)
 	if err != nil {
 		return "", err
@@ -782,6 +827,12 @@ func (r *Reconciler) updatePublishedItemDescription(ctx *pkgctx.VirtualMachinePu
 		return nil
 	}
 
+	// The UID of the request is recorded in an annotation of an artifact in
+	// an OCI registry instead of its description.
+	if isOCITarget(ctx.VMPublishRequest) {
+		return nil
+	}
+
 	if ctx.ItemID == "" {
 		id, err := r.getUploadedItemID(ctx)
 		if err != nil {
@@ -907,6 +958,17 @@ func (r *Reconciler) ReconcileNormal(ctx *pkgctx.VirtualMachinePublishRequestCon
 		return ctrl.Result{}, err
 	}
 
+	// Record the provenance of the published image before the request is
+	// complete, since a completed request is not reconciled again.
+	recorded, err := r.recordImageProvenance(ctx)
+	if err != nil {
+		r.Recorder.EmitEvent(vmPublishReq, "RecordProvenance", err, true)
+		return ctrl.Result{}, err
+	}
+	if !recorded {
+		return requeueResult(ctx), nil
+	}
+
 	if isComplete = r.checkIsComplete(ctx); isComplete {
 		// remove VirtualMachinePublishRequest from the cluster if ttlSecondsAfterFinished is set.
 		requeueAfter, deleted, err := r.removeVMPubResourceFromCluster(ctx)
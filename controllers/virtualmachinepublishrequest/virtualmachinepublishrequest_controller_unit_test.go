@@ -6,6 +6,11 @@ package virtualmachinepublishrequest_test
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
 	"fmt"
 	"time"
 
@@ -24,11 +29,17 @@ import (
 	imgregv1a1 "github.com/vmware-tanzu/image-registry-operator-api/api/v1alpha1"
 
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	vmopv1common "github.com/vmware-tanzu/vm-operator/api/v1alpha4/common"
 	"github.com/vmware-tanzu/vm-operator/controllers/virtualmachinepublishrequest"
 	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	pkgconst "github.com/vmware-tanzu/vm-operator/pkg/constants"
 	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
 	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
 	providerfake "github.com/vmware-tanzu/vm-operator/pkg/providers/fake"
+	pkgutil "github.com/vmware-tanzu/vm-operator/pkg/util"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/image/provenance"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/oci"
 	"github.com/vmware-tanzu/vm-operator/test/builder"
 )
 
@@ -184,6 +195,98 @@ func unitTestsReconcile() {
 			})
 		})
 
+		When("Source is a VirtualMachineSnapshot", func() {
+			var vmSnapshot *vmopv1.VirtualMachineSnapshot
+
+			BeforeEach(func() {
+				vmSnapshot = &vmopv1.VirtualMachineSnapshot{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "dummy-snapshot",
+						Namespace: vm.Namespace,
+					},
+					Spec: vmopv1.VirtualMachineSnapshotSpec{
+						VMRef: &vmopv1common.LocalObjectRef{
+							APIVersion: vmopv1.GroupVersion.String(),
+							Kind:       "VirtualMachine",
+							Name:       vm.Name,
+						},
+					},
+					Status: vmopv1.VirtualMachineSnapshotStatus{
+						UniqueID: "snapshot-1",
+						Conditions: []metav1.Condition{
+							{
+								Type:   vmopv1.VirtualMachineSnapshotReadyCondition,
+								Status: metav1.ConditionTrue,
+							},
+						},
+					},
+				}
+
+				vmpub.Spec.Source = vmopv1.VirtualMachinePublishRequestSource{
+					APIVersion: vmopv1.GroupVersion.String(),
+					Kind:       vmopv1.VirtualMachinePublishRequestSourceKindVirtualMachineSnapshot,
+					Name:       vmSnapshot.Name,
+				}
+				vmpub.Spec.Target.Item.Name = ""
+			})
+
+			When("the snapshot is ready", func() {
+				BeforeEach(func() {
+					initObjects = append(initObjects, vmSnapshot)
+				})
+
+				It("publishes the VM referenced by the snapshot", func() {
+					_, err := reconciler.ReconcileNormal(vmpubCtx)
+					Expect(err).ToNot(HaveOccurred())
+
+					Eventually(func() bool {
+						return fakeVMProvider.IsPublishVMCalled()
+					}).Should(BeTrue())
+
+					Expect(conditions.IsTrue(vmpub,
+						vmopv1.VirtualMachinePublishRequestConditionSourceValid)).To(BeTrue())
+					Expect(vmpubCtx.VM.Name).To(Equal(vm.Name))
+					Expect(vmpubCtx.VMSnapshot).ToNot(BeNil())
+					Expect(vmpubCtx.VMSnapshot.Name).To(Equal(vmSnapshot.Name))
+
+					By("Should set sourceRef/targetRef")
+					Expect(vmpub.Status.SourceRef.Kind).To(Equal(vmopv1.VirtualMachinePublishRequestSourceKindVirtualMachineSnapshot))
+					Expect(vmpub.Status.SourceRef.Name).To(Equal(vmSnapshot.Name))
+					Expect(vmpub.Status.TargetRef.Item.Name).To(Equal("dummy-snapshot-image"))
+				})
+			})
+
+			When("the snapshot does not exist", func() {
+				It("returns error", func() {
+					_, err := reconciler.ReconcileNormal(vmpubCtx)
+					Expect(err).To(HaveOccurred())
+
+					c := conditions.Get(vmpub, vmopv1.VirtualMachinePublishRequestConditionSourceValid)
+					Expect(c).ToNot(BeNil())
+					Expect(c.Status).To(Equal(metav1.ConditionFalse))
+					Expect(c.Reason).To(Equal(vmopv1.SourceVirtualMachineSnapshotNotExistReason))
+				})
+			})
+
+			When("the snapshot is not ready", func() {
+				BeforeEach(func() {
+					vmSnapshot.Status = vmopv1.VirtualMachineSnapshotStatus{}
+					initObjects = append(initObjects, vmSnapshot)
+				})
+
+				It("returns error and retries", func() {
+					_, err := reconciler.ReconcileNormal(vmpubCtx)
+					Expect(err).To(HaveOccurred())
+
+					c := conditions.Get(vmpub, vmopv1.VirtualMachinePublishRequestConditionSourceValid)
+					Expect(c).ToNot(BeNil())
+					Expect(c.Status).To(Equal(metav1.ConditionFalse))
+					Expect(c.Reason).To(Equal(vmopv1.SourceVirtualMachineSnapshotNotReadyReason))
+					Expect(fakeVMProvider.IsPublishVMCalled()).To(BeFalse())
+				})
+			})
+		})
+
 		When("Target isn't valid", func() {
 			BeforeEach(func() {
 				initObjects = []client.Object{vm, vmpub}
@@ -478,6 +581,7 @@ func unitTestsReconcile() {
 							vmi.Namespace = vmpub.Namespace
 							Expect(ctx.Client.Create(ctx, vmi)).To(Succeed())
 							vmi.Status.ProviderItemID = itemID
+							vmi.Status.ProviderContentVersion = "1"
 							Expect(ctx.Client.Status().Update(ctx, vmi)).To(Succeed())
 						})
 
@@ -497,6 +601,107 @@ func unitTestsReconcile() {
 							Expect(vmpub.Status.Ready).To(BeTrue())
 						})
 
+						It("records the provenance of the image", func() {
+							fakeVMProvider.Lock()
+							fakeVMProvider.GetContentLibraryItemFilesFn = func(
+								ctx context.Context, itemID string) ([]library.File, error) {
+
+								return []library.File{
+									{Name: "dummy-item.ovf"},
+									{
+										Name:     "dummy-item-disk-0.vmdk",
+										Checksum: &library.Checksum{Algorithm: "SHA256", Checksum: "abc"},
+									},
+								}, nil
+							}
+							fakeVMProvider.Unlock()
+
+							_, err := reconciler.ReconcileNormal(vmpubCtx)
+							Expect(err).NotTo(HaveOccurred())
+
+							vmi := &vmopv1.VirtualMachineImage{}
+							Expect(ctx.Client.Get(ctx, client.ObjectKey{
+								Namespace: vmpub.Namespace,
+								Name:      "dummy-image",
+							}, vmi)).To(Succeed())
+							Expect(vmi.Annotations).ToNot(HaveKey(pkgconst.ImageProvenanceSignatureAnnotationKey))
+
+							record, err := provenance.GetFromImage(vmi)
+							Expect(err).ToNot(HaveOccurred())
+							Expect(record.PublishRequest.Name).To(Equal(vmpub.Name))
+							Expect(record.Source.VirtualMachine.Name).To(Equal(vm.Name))
+							Expect(record.Source.UniqueID).To(Equal(vm.Status.UniqueID))
+							Expect(record.Image.ProviderItemID).To(Equal(itemID))
+							Expect(record.Image.ProviderContentVersion).To(Equal("1"))
+							Expect(record.Disks).To(Equal([]provenance.Disk{
+								{Name: "dummy-item-disk-0.vmdk", Digest: "sha256:abc"},
+							}))
+						})
+
+						When("a signing key is configured", func() {
+							var key *ecdsa.PrivateKey
+
+							JustBeforeEach(func() {
+								var err error
+								key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+								Expect(err).ToNot(HaveOccurred())
+								der, err := x509.MarshalPKCS8PrivateKey(key)
+								Expect(err).ToNot(HaveOccurred())
+
+								pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+									config.ImageProvenance.SigningKeySecretName = "signing-key"
+								})
+								Expect(ctx.Client.Create(ctx, &corev1.Secret{
+									ObjectMeta: metav1.ObjectMeta{
+										Namespace: pkgcfg.FromContext(ctx).PodNamespace,
+										Name:      "signing-key",
+									},
+									Data: map[string][]byte{
+										provenance.SigningKeySecretKey: pem.EncodeToMemory(
+											&pem.Block{Type: "PRIVATE KEY", Bytes: der}),
+									},
+								})).To(Succeed())
+							})
+
+							It("signs the provenance of the image", func() {
+								_, err := reconciler.ReconcileNormal(vmpubCtx)
+								Expect(err).NotTo(HaveOccurred())
+								Expect(conditions.IsTrue(vmpub,
+									vmopv1.VirtualMachinePublishRequestConditionComplete)).To(BeTrue())
+
+								vmi := &vmopv1.VirtualMachineImage{}
+								Expect(ctx.Client.Get(ctx, client.ObjectKey{
+									Namespace: vmpub.Namespace,
+									Name:      "dummy-image",
+								}, vmi)).To(Succeed())
+
+								keys := []provenance.PublicKey{{Name: "cosign.pub", Key: &key.PublicKey}}
+								_, err = provenance.VerifyImage(vmi, vmi.Status, keys)
+								Expect(err).ToNot(HaveOccurred())
+							})
+						})
+
+						When("the image has not synced its content version", func() {
+							JustBeforeEach(func() {
+								vmi := &vmopv1.VirtualMachineImage{}
+								Expect(ctx.Client.Get(ctx, client.ObjectKey{
+									Namespace: vmpub.Namespace,
+									Name:      "dummy-image",
+								}, vmi)).To(Succeed())
+								vmi.Status.ProviderContentVersion = ""
+								Expect(ctx.Client.Status().Update(ctx, vmi)).To(Succeed())
+							})
+
+							It("requeues without completing the request", func() {
+								_, err := reconciler.ReconcileNormal(vmpubCtx)
+								Expect(err).NotTo(HaveOccurred())
+								Expect(conditions.IsTrue(vmpub,
+									vmopv1.VirtualMachinePublishRequestConditionImageAvailable)).To(BeTrue())
+								Expect(conditions.IsTrue(vmpub,
+									vmopv1.VirtualMachinePublishRequestConditionComplete)).To(BeFalse())
+							})
+						})
+
 						It("Update item description failed once", func() {
 							fakeVMProvider.Lock()
 							fakeVMProvider.UpdateContentLibraryItemFn = func(ctx context.Context,
@@ -678,5 +883,201 @@ func unitTestsReconcile() {
 				})
 			})
 		})
+
+		Context("Target is an OCI registry", func() {
+			const ovfDescriptor = `<?xml version="1.0" encoding="UTF-8"?>
+<Envelope xmlns="http://schemas.dmtf.org/ovf/envelope/1" xmlns:ovf="http://schemas.dmtf.org/ovf/envelope/1">
+  <VirtualSystem ovf:id="dummy-item">
+    <Info>A virtual machine</Info>
+  </VirtualSystem>
+</Envelope>
+`
+
+			var (
+				registry *builder.OCIRegistry
+				pushErr  error
+			)
+
+			BeforeEach(func() {
+				registry = builder.NewOCIRegistry()
+				pushErr = nil
+				vmpub.UID = "dummy-uid"
+				vmpub.Spec.Target.Location = vmopv1.VirtualMachinePublishRequestTargetLocation{
+					Kind: vmopv1.VirtualMachinePublishRequestTargetLocationKindOCIRegistry,
+					OCI: &vmopv1.VirtualMachinePublishRequestTargetOCIRegistry{
+						Repository: registry.Host() + "/my-project/my-image",
+						Insecure:   true,
+					},
+				}
+				initObjects = []client.Object{vm, vmpub}
+			})
+
+			JustBeforeEach(func() {
+				// Copy the error since a push may still be in progress when the
+				// next spec starts.
+				pushErr := pushErr
+				fakeVMProvider.PublishVirtualMachineToOCIFn = func(
+					ctx context.Context,
+					vm *vmopv1.VirtualMachine,
+					vmPub *vmopv1.VirtualMachinePublishRequest,
+					ociClient *oci.Client,
+					ref oci.Reference) (string, error) {
+
+					if pushErr != nil {
+						return "", pushErr
+					}
+					desc, err := oci.PushOVF(ctx, ociClient, ref, []byte(ovfDescriptor), nil,
+						map[string]string{oci.AnnotationPublishRequestUID: string(vmPub.UID)})
+					return desc.Digest, err
+				}
+			})
+
+			AfterEach(func() {
+				registry.Close()
+			})
+
+			getArtifactItemID := func() string {
+				data, ok := registry.GetManifest("my-project/my-image", "dummy-item")
+				Expect(ok).To(BeTrue())
+				return registry.Host() + "/my-project/my-image@" + oci.Digest(data)
+			}
+
+			It("pushes the VM and creates a VirtualMachineImage", func() {
+				_, err := reconciler.ReconcileNormal(vmpubCtx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(vmpub.Status.Attempts).To(BeEquivalentTo(1))
+				Expect(conditions.IsTrue(vmpub,
+					vmopv1.VirtualMachinePublishRequestConditionTargetValid)).To(BeTrue())
+
+				By("Uploaded is true once the push completes")
+				Eventually(func(g Gomega) {
+					_, err := reconciler.ReconcileNormal(vmpubCtx)
+					g.Expect(err).NotTo(HaveOccurred())
+					g.Expect(conditions.IsTrue(vmpub,
+						vmopv1.VirtualMachinePublishRequestConditionUploaded)).To(BeTrue())
+				}).Should(Succeed())
+				Expect(fakeVMProvider.IsPublishVMCalled()).To(BeTrue())
+
+				itemID := getArtifactItemID()
+				Expect(vmpubCtx.ItemID).To(Equal(itemID))
+
+				By("VirtualMachineImage is created for the artifact")
+				vmi := &vmopv1.VirtualMachineImage{}
+				Expect(ctx.Client.Get(ctx, client.ObjectKey{
+					Namespace: vmpub.Namespace,
+					Name:      pkgutil.VMIName(itemID),
+				}, vmi)).To(Succeed())
+				Expect(vmi.Spec.OCI).ToNot(BeNil())
+				Expect(vmi.Spec.OCI.Reference).To(Equal(itemID))
+				Expect(vmi.Spec.OCI.Insecure).To(BeTrue())
+				Expect(conditions.IsTrue(vmpub,
+					vmopv1.VirtualMachinePublishRequestConditionImageAvailable)).To(BeFalse())
+
+				By("Complete is true once the VirtualMachineImage is synced")
+				vmi.Status.ProviderItemID = itemID
+				vmi.Status.ProviderContentVersion = "1"
+				Expect(ctx.Client.Status().Update(ctx, vmi)).To(Succeed())
+
+				vmpubCtx.ItemID = ""
+				_, err = reconciler.ReconcileNormal(vmpubCtx)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(vmpub.Status.ImageName).To(Equal(vmi.Name))
+				Expect(conditions.IsTrue(vmpub,
+					vmopv1.VirtualMachinePublishRequestConditionComplete)).To(BeTrue())
+				Expect(vmpub.Status.Ready).To(BeTrue())
+
+				By("the provenance of the artifact is recorded")
+				Expect(ctx.Client.Get(ctx, client.ObjectKeyFromObject(vmi), vmi)).To(Succeed())
+				record, err := provenance.GetFromImage(vmi)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(record.Image.ProviderItemID).To(Equal(itemID))
+			})
+
+			When("the push fails", func() {
+				BeforeEach(func() {
+					pushErr = fmt.Errorf("dummy error")
+				})
+
+				It("marks Uploaded as failed and publishes the VM again", func() {
+					_, err := reconciler.ReconcileNormal(vmpubCtx)
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(func(g Gomega) {
+						_, err := reconciler.ReconcileNormal(vmpubCtx)
+						g.Expect(err).NotTo(HaveOccurred())
+						g.Expect(vmpub.Status.Attempts).To(BeEquivalentTo(2))
+					}).Should(Succeed())
+
+					c := conditions.Get(vmpub, vmopv1.VirtualMachinePublishRequestConditionUploaded)
+					Expect(c).ToNot(BeNil())
+					Expect(c.Reason).To(Equal(vmopv1.UploadFailureReason))
+					Expect(c.Message).To(Equal("dummy error"))
+				})
+			})
+
+			When("an artifact with the same tag already exists", func() {
+				JustBeforeEach(func() {
+					ref, err := oci.ParseReference(registry.Host() + "/my-project/my-image:dummy-item")
+					Expect(err).ToNot(HaveOccurred())
+					_, err = oci.PushOVF(ctx, oci.NewClient(oci.Credentials{}, true), ref,
+						[]byte(ovfDescriptor), nil, nil)
+					Expect(err).ToNot(HaveOccurred())
+				})
+
+				It("doesn't return error to skip requeue", func() {
+					_, err := reconciler.ReconcileNormal(vmpubCtx)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(fakeVMProvider.IsPublishVMCalled()).To(BeFalse())
+
+					c := conditions.Get(vmpub, vmopv1.VirtualMachinePublishRequestConditionTargetValid)
+					Expect(c).ToNot(BeNil())
+					Expect(c.Reason).To(Equal(vmopv1.TargetItemAlreadyExistsReason))
+				})
+
+				When("the artifact was pushed by a prior attempt", func() {
+					BeforeEach(func() {
+						vmpub.Status.Attempts = 1
+						vmpub.Status.LastAttemptTime = metav1.NewTime(time.Now().Add(-time.Minute))
+					})
+
+					JustBeforeEach(func() {
+						ref, err := oci.ParseReference(registry.Host() + "/my-project/my-image:dummy-item")
+						Expect(err).ToNot(HaveOccurred())
+						_, err = oci.PushOVF(ctx, oci.NewClient(oci.Credentials{}, true), ref,
+							[]byte(ovfDescriptor), nil,
+							map[string]string{oci.AnnotationPublishRequestUID: string(vmpub.UID)})
+						Expect(err).ToNot(HaveOccurred())
+					})
+
+					It("marks Uploaded as true without publishing the VM again", func() {
+						_, err := reconciler.ReconcileNormal(vmpubCtx)
+						Expect(err).NotTo(HaveOccurred())
+						Expect(fakeVMProvider.IsPublishVMCalled()).To(BeFalse())
+
+						Expect(conditions.IsTrue(vmpub,
+							vmopv1.VirtualMachinePublishRequestConditionTargetValid)).To(BeTrue())
+						Expect(conditions.IsTrue(vmpub,
+							vmopv1.VirtualMachinePublishRequestConditionUploaded)).To(BeTrue())
+						Expect(vmpubCtx.ItemID).To(Equal(getArtifactItemID()))
+					})
+				})
+			})
+
+			When("the credentials secret does not exist", func() {
+				BeforeEach(func() {
+					vmpub.Spec.Target.Location.OCI.CredentialsSecretName = "dummy-secret"
+				})
+
+				It("returns error to retry", func() {
+					_, err := reconciler.ReconcileNormal(vmpubCtx)
+					Expect(err).To(HaveOccurred())
+					Expect(fakeVMProvider.IsPublishVMCalled()).To(BeFalse())
+
+					c := conditions.Get(vmpub, vmopv1.VirtualMachinePublishRequestConditionTargetValid)
+					Expect(c).ToNot(BeNil())
+					Expect(c.Reason).To(Equal(vmopv1.TargetOCIRegistryInvalidReason))
+				})
+			})
+		})
 	})
 }
@@ -0,0 +1,276 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachinepublishrequest
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	pkgutil "github.com/vmware-tanzu/vm-operator/pkg/util"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/oci"
+)
+
+// ociPublishTask tracks a push of a VM to an OCI registry. Unlike publishing
+// to a content library, there is no vCenter task that may be queried for the
+// status of the push, so the status is tracked in memory.
+type ociPublishTask struct {
+	mu     sync.Mutex
+	done   bool
+	itemID string
+	err    error
+}
+
+func (t *ociPublishTask) complete(itemID string, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.done, t.itemID, t.err = true, itemID, err
+}
+
+func (t *ociPublishTask) result() (done bool, itemID string, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.done, t.itemID, t.err
+}
+
+// isOCITarget returns true if the VM is published to an OCI registry.
+func isOCITarget(vmPubReq *vmopv1.VirtualMachinePublishRequest) bool {
+	return vmPubReq.Spec.Target.Location.Kind ==
+		vmopv1.VirtualMachinePublishRequestTargetLocationKindOCIRegistry
+}
+
+// ociItemID returns the ID of an artifact pushed to an OCI registry, which
+// is the artifact's repository and the digest of its manifest. This is also
+// the provider item ID of the VirtualMachineImage backed by the artifact.
+func ociItemID(ref oci.Reference, digest string) string {
+	return ref.WithDigest(digest).String()
+}
+
+// getOCIClientAndReference returns the client and reference used to push the
+// VM to the OCI registry described by the target location.
+func (r *Reconciler) getOCIClientAndReference(
+	ctx *pkgctx.VirtualMachinePublishRequestContext) (*oci.Client, oci.Reference, error) {
+
+	vmPubReq := ctx.VMPublishRequest
+
+	ociSpec := vmPubReq.Spec.Target.Location.OCI
+	if ociSpec == nil {
+		return nil, oci.Reference{}, errors.New("spec.target.location.oci is required")
+	}
+
+	ref, err := oci.ParseReference(ociSpec.Repository)
+	if err != nil {
+		return nil, oci.Reference{}, err
+	}
+	if ref.Tag != "" || ref.Digest != "" {
+		return nil, oci.Reference{}, fmt.Errorf(
+			"repository %s must not include a tag or digest", ociSpec.Repository)
+	}
+
+	tag := ociSpec.Tag
+	if tag == "" {
+		tag = vmPubReq.Status.TargetRef.Item.Name
+	}
+	if !oci.ValidTag(tag) {
+		return nil, oci.Reference{}, fmt.Errorf("tag %q is invalid", tag)
+	}
+	ref = ref.WithTag(tag)
+
+	creds, err := oci.GetCredentials(
+		ctx,
+		r.Client,
+		vmPubReq.Namespace,
+		ociSpec.CredentialsSecretName,
+		ref.Registry)
+	if err != nil {
+		return nil, oci.Reference{}, err
+	}
+
+	return oci.NewClient(creds, ociSpec.Insecure), ref, nil
+}
+
+// checkIsOCITargetValid checks if the target OCI registry is valid.
+// It is invalid if the target location does not describe a valid repository
+// or tag, the credentials cannot be read, or an artifact with the same tag
+// already exists in the repository.
+func (r *Reconciler) checkIsOCITargetValid(ctx *pkgctx.VirtualMachinePublishRequestContext) error {
+	vmPubReq := ctx.VMPublishRequest
+
+	c, ref, err := r.getOCIClientAndReference(ctx)
+	if err != nil {
+		conditions.MarkError(vmPubReq,
+			vmopv1.VirtualMachinePublishRequestConditionTargetValid,
+			vmopv1.TargetOCIRegistryInvalidReason,
+			err)
+		return err
+	}
+
+	manifest, desc, err := c.GetManifest(ctx, ref)
+	if err != nil && !oci.IsNotFound(err) {
+		ctx.Logger.Error(err, "failed to get artifact", "reference", ref.String())
+		conditions.MarkError(vmPubReq,
+			vmopv1.VirtualMachinePublishRequestConditionTargetValid,
+			vmopv1.TargetOCIRegistryInvalidReason,
+			err)
+		return err
+	}
+
+	if err == nil {
+		ctx.Logger.Info("target artifact already exists in the registry",
+			"reference", ref.String())
+		// If the artifact was pushed by a prior attempt of this
+		// VirtualMachinePublishRequest, ex. the controller restarted while the
+		// push was in progress, then there is no need to push it again.
+		if vmPubReq.Status.Attempts > 0 &&
+			manifest.Annotations[oci.AnnotationPublishRequestUID] == string(vmPubReq.UID) {
+
+			ctx.Logger.Info("existing target artifact is published by this VMPubReq")
+			conditions.MarkTrue(vmPubReq, vmopv1.VirtualMachinePublishRequestConditionTargetValid)
+			conditions.MarkTrue(vmPubReq, vmopv1.VirtualMachinePublishRequestConditionUploaded)
+			ctx.ItemID = ociItemID(ref, desc.Digest)
+			return nil
+		}
+
+		conditions.MarkFalse(vmPubReq,
+			vmopv1.VirtualMachinePublishRequestConditionTargetValid,
+			vmopv1.TargetItemAlreadyExistsReason,
+			"artifact with tag %s already exists in the repository %s", ref.Tag, ref.Name())
+		return nil
+	}
+
+	ctx.OCIClient = c
+	ctx.OCIReference = ref
+	conditions.MarkTrue(vmPubReq, vmopv1.VirtualMachinePublishRequestConditionTargetValid)
+	return nil
+}
+
+// publishVirtualMachineToOCI pushes the VM to the OCI registry in the
+// background.
+func (r *Reconciler) publishVirtualMachineToOCI(ctx *pkgctx.VirtualMachinePublishRequestContext) {
+	vmPubReq := ctx.VMPublishRequest
+
+	// Store the task before starting the push so a subsequent reconcile
+	// always observes the push as in progress.
+	task := &ociPublishTask{}
+	r.ociTasks.Store(getPublishRequestActID(vmPubReq), task)
+
+	go func() {
+		var itemID string
+		digest, pubErr := r.VMProvider.PublishVirtualMachineToOCI(
+			ctx, ctx.VM, vmPubReq, ctx.OCIClient, ctx.OCIReference)
+		if pubErr != nil {
+			ctx.Logger.Error(pubErr, "failed to publish VM")
+		} else {
+			itemID = ociItemID(ctx.OCIReference, digest)
+			ctx.Logger.Info("pushed an OVF from VM", "itemID", itemID)
+		}
+		task.complete(itemID, pubErr)
+		r.Recorder.EmitEvent(vmPubReq, "Publish", pubErr, false)
+	}()
+}
+
+// checkOCIPubReqStatusAndShouldRepublish is the OCI registry analog of
+// checkPubReqStatusAndShouldRepublish.
+func (r *Reconciler) checkOCIPubReqStatusAndShouldRepublish(
+	ctx *pkgctx.VirtualMachinePublishRequestContext) (bool, error) {
+
+	actID := getPublishRequestActID(ctx.VMPublishRequest)
+
+	obj, ok := r.ociTasks.Load(actID)
+	if !ok {
+		// The push is not tracked by this controller, ex. the controller
+		// restarted while the push was in progress. Publish the VM again. If
+		// the prior push succeeded, then checkIsOCITargetValid finds the
+		// artifact and marks the request as uploaded.
+		ctx.Logger.Info("failed to find push, retry publishing this VM",
+			"actID", actID)
+		return true, nil
+	}
+
+	done, itemID, err := obj.(*ociPublishTask).result()
+	if !done {
+		ctx.Logger.V(5).Info("VM Publish is still in progress", "actID", actID)
+		conditions.MarkFalse(ctx.VMPublishRequest,
+			vmopv1.VirtualMachinePublishRequestConditionUploaded,
+			vmopv1.UploadingReason,
+			"Uploading artifact to OCI registry.")
+		return false, nil
+	}
+
+	r.ociTasks.Delete(actID)
+
+	if err != nil {
+		ctx.Logger.Error(err, "VM Publish failed, will retry this operation",
+			"actID", actID)
+		conditions.MarkFalse(ctx.VMPublishRequest,
+			vmopv1.VirtualMachinePublishRequestConditionUploaded,
+			vmopv1.UploadFailureReason,
+			"%s", err.Error())
+		return true, nil
+	}
+
+	ctx.Logger.Info("VM Publish succeeded", "itemID", itemID)
+	ctx.ItemID = itemID
+	conditions.MarkTrue(ctx.VMPublishRequest, vmopv1.VirtualMachinePublishRequestConditionUploaded)
+	return false, nil
+}
+
+// getUploadedOCIItemID returns the ID of the artifact pushed to the OCI
+// registry by this VirtualMachinePublishRequest.
+func (r *Reconciler) getUploadedOCIItemID(ctx *pkgctx.VirtualMachinePublishRequestContext) (string, error) {
+	c, ref, err := r.getOCIClientAndReference(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	manifest, desc, err := c.GetManifest(ctx, ref)
+	if err != nil {
+		return "", err
+	}
+
+	if manifest.Annotations[oci.AnnotationPublishRequestUID] != string(ctx.VMPublishRequest.UID) {
+		return "", fmt.Errorf("artifact %s is not published by this request", ref)
+	}
+
+	return ociItemID(ref, desc.Digest), nil
+}
+
+// createOCIImage creates the VirtualMachineImage backed by the artifact
+// pushed to the OCI registry. Unlike images in a content library, there is
+// no other controller that creates images for artifacts in an OCI registry.
+func (r *Reconciler) createOCIImage(ctx *pkgctx.VirtualMachinePublishRequestContext) error {
+	ociSpec := ctx.VMPublishRequest.Spec.Target.Location.OCI
+
+	vmi := &vmopv1.VirtualMachineImage{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: ctx.VMPublishRequest.Namespace,
+			Name:      pkgutil.VMIName(ctx.ItemID),
+		},
+		Spec: vmopv1.VirtualMachineImageSpec{
+			OCI: &vmopv1.VirtualMachineImageOCISource{
+				Reference:             ctx.ItemID,
+				CredentialsSecretName: ociSpec.CredentialsSecretName,
+				Insecure:              ociSpec.Insecure,
+			},
+		},
+	}
+
+	if err := r.Create(ctx, vmi); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			return nil
+		}
+		ctx.Logger.Error(err, "failed to create VirtualMachineImage", "vmiName", vmi.Name)
+		return err
+	}
+
+	ctx.Logger.Info("Created VirtualMachineImage", "vmiName", vmi.Name)
+	return nil
+}
@@ -0,0 +1,223 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachinepublishrequest
+
+import (
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	pkgconst "github.com/vmware-tanzu/vm-operator/pkg/constants"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/image/provenance"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/oci"
+)
+
+// recordImageProvenance records the provenance of the published image in the
+// annotations of its VirtualMachineImage, and signs the record if a signing
+// key is configured.
+// Returns false if the provenance cannot be recorded yet because the image
+// has not synced its content version from the provider.
+func (r *Reconciler) recordImageProvenance(ctx *pkgctx.VirtualMachinePublishRequestContext) (bool, error) {
+	vmPubReq := ctx.VMPublishRequest
+
+	if !conditions.IsTrue(vmPubReq, vmopv1.VirtualMachinePublishRequestConditionImageAvailable) {
+		return true, nil
+	}
+
+	vmi := &vmopv1.VirtualMachineImage{}
+	vmiKey := client.ObjectKey{Namespace: vmPubReq.Namespace, Name: vmPubReq.Status.ImageName}
+	if err := r.Get(ctx, vmiKey, vmi); err != nil {
+		ctx.Logger.Error(err, "failed to get VirtualMachineImage", "vmiName", vmiKey.Name)
+		return false, err
+	}
+
+	if record, err := provenance.GetFromImage(vmi); err == nil &&
+		record.PublishRequest.UID == string(vmPubReq.UID) {
+
+		return true, nil
+	}
+
+	// The record is bound to the content version of the image, so wait for
+	// the image to sync its content version from the provider.
+	if vmi.Status.ProviderContentVersion == "" {
+		ctx.Logger.Info("VirtualMachineImage has not synced its content version",
+			"vmiName", vmi.Name)
+		return false, nil
+	}
+
+	record, err := r.getImageProvenance(ctx, vmi)
+	if err != nil {
+		return false, err
+	}
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return false, err
+	}
+
+	var signature string
+	cfg := pkgcfg.FromContext(ctx)
+	if name := cfg.ImageProvenance.SigningKeySecretName; name != "" {
+		key, err := provenance.GetSigningKey(ctx, r.Client, cfg.PodNamespace, name)
+		if err != nil {
+			return false, err
+		}
+		if signature, err = provenance.Sign(key, data); err != nil {
+			return false, err
+		}
+	}
+
+	vmiPatch := client.MergeFrom(vmi.DeepCopy())
+	provenance.SetOnImage(vmi, data, signature)
+	if err := r.Patch(ctx, vmi, vmiPatch); err != nil {
+		ctx.Logger.Error(err, "failed to record image provenance", "vmiName", vmi.Name)
+		return false, err
+	}
+
+	ctx.Logger.Info("Recorded image provenance",
+		"vmiName", vmi.Name, "signed", signature != "")
+	return true, nil
+}
+
+// getImageProvenance returns the provenance record of the published image.
+func (r *Reconciler) getImageProvenance(
+	ctx *pkgctx.VirtualMachinePublishRequestContext,
+	vmi *vmopv1.VirtualMachineImage) (provenance.Record, error) {
+
+	vmPubReq := ctx.VMPublishRequest
+
+	vmName, err := r.getSourceVMName(ctx)
+	if err != nil {
+		return provenance.Record{}, err
+	}
+
+	record := provenance.Record{
+		Version: provenance.RecordVersion,
+		PublishRequest: provenance.ObjectRef{
+			Namespace: vmPubReq.Namespace,
+			Name:      vmPubReq.Name,
+			UID:       string(vmPubReq.UID),
+		},
+		RequestedBy: vmPubReq.Annotations[pkgconst.RequestedByAnnotationKey],
+		PublishTime: vmPubReq.Status.LastAttemptTime.UTC(),
+		Source: provenance.Source{
+			VirtualMachine: provenance.ObjectRef{
+				Namespace: vmPubReq.Namespace,
+				Name:      vmName,
+			},
+		},
+		Image: provenance.Image{
+			Name:                   vmi.Name,
+			ProviderItemID:         vmi.Status.ProviderItemID,
+			ProviderContentVersion: vmi.Status.ProviderContentVersion,
+		},
+	}
+
+	if isSnapshotSource(vmPubReq) {
+		record.Source.Snapshot = &provenance.ObjectRef{
+			Namespace: vmPubReq.Namespace,
+			Name:      ctx.VMSnapshot.Name,
+			UID:       string(ctx.VMSnapshot.UID),
+		}
+	}
+
+	// The source VM may be deleted once the image is published, in which
+	// case only its name is recorded.
+	vm := &vmopv1.VirtualMachine{}
+	vmKey := client.ObjectKey{Namespace: vmPubReq.Namespace, Name: vmName}
+	if err := r.Get(ctx, vmKey, vm); err != nil {
+		if !apierrors.IsNotFound(err) {
+			return provenance.Record{}, err
+		}
+	} else {
+		record.Source.VirtualMachine.UID = string(vm.UID)
+		record.Source.UniqueID = vm.Status.UniqueID
+		record.Source.InstanceUUID = vm.Spec.InstanceUUID
+		record.Source.Image = vm.Spec.Image
+		record.Source.ClassName = vm.Spec.ClassName
+	}
+
+	if isOCITarget(vmPubReq) {
+		record.Disks, err = r.getOCIDiskDigests(ctx, vmi.Status.ProviderItemID)
+	} else {
+		record.Disks, err = r.getContentLibraryDiskDigests(ctx, vmi.Status.ProviderItemID)
+	}
+	if err != nil {
+		return provenance.Record{}, err
+	}
+
+	return record, nil
+}
+
+// getContentLibraryDiskDigests returns the checksums of the disks of the
+// content library item.
+func (r *Reconciler) getContentLibraryDiskDigests(
+	ctx *pkgctx.VirtualMachinePublishRequestContext,
+	itemID string) ([]provenance.Disk, error) {
+
+	files, err := r.VMProvider.GetContentLibraryItemFiles(ctx, itemID)
+	if err != nil {
+		ctx.Logger.Error(err, "failed to get item files", "itemID", itemID)
+		return nil, err
+	}
+
+	var disks []provenance.Disk
+	for _, f := range files {
+		if !strings.EqualFold(path.Ext(f.Name), ".vmdk") {
+			continue
+		}
+		if f.Checksum == nil || f.Checksum.Checksum == "" {
+			return nil, fmt.Errorf("item %s file %s does not have a checksum", itemID, f.Name)
+		}
+		disks = append(disks, provenance.Disk{
+			Name:   f.Name,
+			Digest: strings.ToLower(f.Checksum.Algorithm) + ":" + f.Checksum.Checksum,
+		})
+	}
+
+	return disks, nil
+}
+
+// getOCIDiskDigests returns the digests of the disk layers of the artifact.
+func (r *Reconciler) getOCIDiskDigests(
+	ctx *pkgctx.VirtualMachinePublishRequestContext,
+	itemID string) ([]provenance.Disk, error) {
+
+	c, _, err := r.getOCIClientAndReference(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ref, err := oci.ParseReference(itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	manifest, _, err := c.GetManifest(ctx, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	var disks []provenance.Disk
+	for _, l := range manifest.Layers {
+		if l.MediaType != oci.MediaTypeOVFDisk {
+			continue
+		}
+		disks = append(disks, provenance.Disk{
+			Name:   l.Annotations[oci.AnnotationTitle],
+			Digest: l.Digest,
+		})
+	}
+
+	return disks, nil
+}
@@ -0,0 +1,91 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachinepublishrequest
+
+import (
+	"errors"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+)
+
+// isSnapshotSource returns true if the VM is published from a snapshot.
+func isSnapshotSource(vmPubReq *vmopv1.VirtualMachinePublishRequest) bool {
+	return vmPubReq.Spec.Source.Kind ==
+		vmopv1.VirtualMachinePublishRequestSourceKindVirtualMachineSnapshot
+}
+
+// getSourceSnapshot returns the source VirtualMachineSnapshot. The snapshot
+// must be ready, otherwise the VM cannot be published from its disks.
+func (r *Reconciler) getSourceSnapshot(
+	ctx *pkgctx.VirtualMachinePublishRequestContext) (*vmopv1.VirtualMachineSnapshot, error) {
+
+	vmPubReq := ctx.VMPublishRequest
+
+	vmSnapshot := &vmopv1.VirtualMachineSnapshot{}
+	objKey := client.ObjectKey{Name: vmPubReq.Status.SourceRef.Name, Namespace: vmPubReq.Namespace}
+	if err := r.Get(ctx, objKey, vmSnapshot); err != nil {
+		ctx.Logger.Error(err, "failed to get VirtualMachineSnapshot", "vmSnapshot", objKey)
+		if apierrors.IsNotFound(err) {
+			conditions.MarkError(vmPubReq,
+				vmopv1.VirtualMachinePublishRequestConditionSourceValid,
+				vmopv1.SourceVirtualMachineSnapshotNotExistReason,
+				err)
+		}
+		return nil, err
+	}
+
+	if vmSnapshot.Spec.VMRef == nil || vmSnapshot.Spec.VMRef.Name == "" {
+		err := errors.New("VirtualMachineSnapshot does not reference a VM")
+		conditions.MarkError(vmPubReq,
+			vmopv1.VirtualMachinePublishRequestConditionSourceValid,
+			vmopv1.SourceVirtualMachineSnapshotNotReadyReason,
+			err)
+		return nil, err
+	}
+
+	if !conditions.IsTrue(vmSnapshot, vmopv1.VirtualMachineSnapshotReadyCondition) ||
+		vmSnapshot.Status.UniqueID == "" {
+
+		err := errors.New("VirtualMachineSnapshot hasn't been created and has no uniqueID")
+		conditions.MarkError(vmPubReq,
+			vmopv1.VirtualMachinePublishRequestConditionSourceValid,
+			vmopv1.SourceVirtualMachineSnapshotNotReadyReason,
+			err)
+		return nil, err
+	}
+
+	return vmSnapshot, nil
+}
+
+// getSourceVMName returns the name of the source VM. When the source is a
+// snapshot, this is the name of the VM referenced by the snapshot.
+func (r *Reconciler) getSourceVMName(
+	ctx *pkgctx.VirtualMachinePublishRequestContext) (string, error) {
+
+	vmPubReq := ctx.VMPublishRequest
+	if !isSnapshotSource(vmPubReq) {
+		return vmPubReq.Status.SourceRef.Name, nil
+	}
+
+	if ctx.VMSnapshot == nil {
+		vmSnapshot := &vmopv1.VirtualMachineSnapshot{}
+		objKey := client.ObjectKey{Name: vmPubReq.Status.SourceRef.Name, Namespace: vmPubReq.Namespace}
+		if err := r.Get(ctx, objKey, vmSnapshot); err != nil {
+			return "", err
+		}
+		ctx.VMSnapshot = vmSnapshot
+	}
+
+	if ctx.VMSnapshot.Spec.VMRef == nil {
+		return "", errors.New("VirtualMachineSnapshot does not reference a VM")
+	}
+
+	return ctx.VMSnapshot.Spec.VMRef.Name, nil
+}
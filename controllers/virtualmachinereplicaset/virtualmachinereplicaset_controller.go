@@ -92,6 +92,9 @@ func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr manager.Manager) err
 		Watches(&vmopv1.VirtualMachine{},
 			handler.EnqueueRequestsFromMapFunc(r.VMToReplicaSets(ctx)),
 		).
+		Watches(&vmopv1.VirtualMachineImageChannel{},
+			handler.EnqueueRequestsFromMapFunc(r.ImageChannelToReplicaSets),
+		).
 		WithOptions(controller.Options{MaxConcurrentReconciles: ctx.MaxConcurrentReconciles}).
 		Complete(r)
 }
@@ -397,7 +400,7 @@ func (r *Reconciler) ReconcileNormal(ctx *pkgctx.VirtualMachineReplicaSetContext
 		return ctrl.Result{RequeueAfter: 15 * time.Second}, nil
 	}
 
-	return ctrl.Result{}, nil
+	return r.rolloutImage(ctx, filteredVMs)
 }
 
 // MustEqualValue returns true if the replica set name equals either the label
@@ -428,6 +431,12 @@ func (r *Reconciler) getNewVirtualMachine(rs *vmopv1.VirtualMachineReplicaSet) *
 
 	vm.Annotations = getAnnotationsFromReplicaSet(rs)
 
+	if vm.Spec.ImageChannel != "" {
+		// The VM's image is resolved from the channel when the VM is created.
+		vm.Spec.Image = nil
+		vm.Spec.ImageName = ""
+	}
+
 	// TODO: Propagate the VirtualMachineDeploymentNameLabel from VirtualMachineReplicaSet to VirtualMachines if it exists.
 
 	return vm
@@ -19,6 +19,7 @@ import (
 
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
 	vmopv1common "github.com/vmware-tanzu/vm-operator/api/v1alpha4/common"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
 	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
 	providerfake "github.com/vmware-tanzu/vm-operator/pkg/providers/fake"
 	"github.com/vmware-tanzu/vm-operator/test/builder"
@@ -213,6 +214,99 @@ func intgTestsReconcile() {
 			})
 		})
 
+		Context("Image rollout", func() {
+			var channel *vmopv1.VirtualMachineImageChannel
+
+			BeforeEach(func() {
+				channel = &vmopv1.VirtualMachineImageChannel{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "photon",
+						Namespace: ctx.Namespace,
+					},
+				}
+				Expect(ctx.Client.Create(ctx, channel)).To(Succeed())
+
+				rs.Spec.ImageRolloutPolicy = vmopv1.VirtualMachineReplicaSetImageRolloutPolicyReplace
+				rs.Spec.Template.Spec.ImageName = ""
+				rs.Spec.Template.Spec.ImageChannel = channel.Name
+			})
+
+			getImageUpToDate := func(g Gomega) *metav1.Condition {
+				obj := getVirtualMachineReplicaSet(ctx, rsKey)
+				g.Expect(obj).ToNot(BeNil())
+				return conditions.Get(obj, vmopv1.ImageUpToDateCondition)
+			}
+
+			setChannelImage := func(name string) {
+				channel.Status.Image = &vmopv1.VirtualMachineImageRef{
+					Kind: "VirtualMachineImage",
+					Name: name,
+				}
+				Expect(ctx.Client.Status().Update(ctx, channel)).To(Succeed())
+			}
+
+			It("Replaces replicas deployed from an older image", func() {
+				Expect(ctx.Client.Create(ctx, rs)).To(Succeed())
+				waitForReplicaSetFinalizer(ctx, rsKey)
+				ensureReplicas(ctx, rs.Spec.Selector.MatchLabels, int(*rs.Spec.Replicas))
+
+				By("channel has not resolved an image", func() {
+					Eventually(func(g Gomega) {
+						c := getImageUpToDate(g)
+						g.Expect(c).ToNot(BeNil())
+						g.Expect(c.Status).To(Equal(metav1.ConditionFalse))
+						g.Expect(c.Reason).To(Equal(vmopv1.ImageChannelNotReadyReason))
+					}).Should(Succeed())
+				})
+
+				// There is no mutation webhook in envtest, so set the image
+				// on the replicas as the webhook would have on create.
+				setChannelImage("vmi-1")
+				vmList := &vmopv1.VirtualMachineList{}
+				Expect(ctx.Client.List(ctx, vmList,
+					client.InNamespace(ctx.Namespace),
+					client.MatchingLabels(rs.Spec.Selector.MatchLabels))).To(Succeed())
+				oldVMs := map[string]struct{}{}
+				for i := range vmList.Items {
+					vm := &vmList.Items[i]
+					vm.Spec.Image = channel.Status.Image.DeepCopy()
+					Expect(ctx.Client.Update(ctx, vm)).To(Succeed())
+					oldVMs[vm.Name] = struct{}{}
+				}
+
+				By("replicas are up to date", func() {
+					Eventually(func(g Gomega) {
+						g.Expect(conditions.IsTrue(
+							getVirtualMachineReplicaSet(ctx, rsKey),
+							vmopv1.ImageUpToDateCondition)).To(BeTrue())
+					}).Should(Succeed())
+				})
+
+				By("channel advances to a new image", func() {
+					setChannelImage("vmi-2")
+					Eventually(func(g Gomega) {
+						c := getImageUpToDate(g)
+						g.Expect(c).ToNot(BeNil())
+						g.Expect(c.Reason).To(Equal(vmopv1.ImageRolloutInProgressReason))
+					}).Should(Succeed())
+
+					Eventually(func(g Gomega) int {
+						vmList := &vmopv1.VirtualMachineList{}
+						g.Expect(ctx.Client.List(ctx, vmList,
+							client.InNamespace(ctx.Namespace),
+							client.MatchingLabels(rs.Spec.Selector.MatchLabels))).To(Succeed())
+						remaining := 0
+						for i := range vmList.Items {
+							if _, ok := oldVMs[vmList.Items[i].Name]; ok {
+								remaining++
+							}
+						}
+						return remaining
+					}).Should(BeNumerically("<", len(oldVMs)))
+				})
+			})
+		})
+
 		It("Reconciles after VirtualMachineReplicaSet deletion", func() {
 			Expect(ctx.Client.Create(ctx, rs)).To(Succeed())
 			// Wait for initial reconcile.
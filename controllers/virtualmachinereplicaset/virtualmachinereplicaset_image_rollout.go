@@ -0,0 +1,165 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachinereplicaset
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	vmopv1util "github.com/vmware-tanzu/vm-operator/pkg/util/vmopv1"
+)
+
+// imageRolloutRequeueAfter is how long to wait before checking on a replica
+// that was deleted as part of an image rollout.
+var imageRolloutRequeueAfter = 15 * time.Second
+
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineimagechannels,verbs=get;list;watch
+
+// ImageChannelToReplicaSets is a mapper function to be used to enqueue
+// requests for reconciliation for VirtualMachineReplicaSets whose template
+// refers to an image channel.
+func (r *Reconciler) ImageChannelToReplicaSets(
+	ctx context.Context,
+	o client.Object) []reconcile.Request {
+
+	rsList := &vmopv1.VirtualMachineReplicaSetList{}
+	if err := r.Client.List(ctx, rsList, client.InNamespace(o.GetNamespace())); err != nil {
+		r.Logger.Error(err, "Failed to list VirtualMachineReplicaSets")
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for i := range rsList.Items {
+		rs := &rsList.Items[i]
+		if rs.Spec.Template.Spec.ImageChannel == o.GetName() {
+			requests = append(requests, ctrl.Request{
+				NamespacedName: client.ObjectKeyFromObject(rs),
+			})
+		}
+	}
+
+	return requests
+}
+
+// rolloutImage replaces, one at a time, the replicas that were not deployed
+// from the image to which the template's image channel currently resolves.
+// A replica is only replaced when the VirtualMachineReplicaSet has its
+// desired number of replicas and none of them are being deleted.
+func (r *Reconciler) rolloutImage(
+	ctx *pkgctx.VirtualMachineReplicaSetContext,
+	vms []*vmopv1.VirtualMachine) (ctrl.Result, error) {
+
+	rs := ctx.ReplicaSet
+	chanName := rs.Spec.Template.Spec.ImageChannel
+
+	if rs.Spec.ImageRolloutPolicy != vmopv1.VirtualMachineReplicaSetImageRolloutPolicyReplace ||
+		chanName == "" {
+
+		conditions.Delete(rs, vmopv1.ImageUpToDateCondition)
+		return ctrl.Result{}, nil
+	}
+
+	channel := &vmopv1.VirtualMachineImageChannel{}
+	if err := r.Client.Get(
+		ctx,
+		client.ObjectKey{Namespace: rs.Namespace, Name: chanName},
+		channel); err != nil {
+
+		if !apierrors.IsNotFound(err) {
+			return ctrl.Result{}, fmt.Errorf("failed to get VirtualMachineImageChannel %q: %w", chanName, err)
+		}
+		conditions.MarkFalse(
+			rs,
+			vmopv1.ImageUpToDateCondition,
+			vmopv1.ImageChannelNotReadyReason,
+			"VirtualMachineImageChannel %q does not exist",
+			chanName)
+		return ctrl.Result{}, nil
+	}
+
+	if channel.Status.Image == nil {
+		conditions.MarkFalse(
+			rs,
+			vmopv1.ImageUpToDateCondition,
+			vmopv1.ImageChannelNotReadyReason,
+			"VirtualMachineImageChannel %q has not resolved an image",
+			chanName)
+		return ctrl.Result{}, nil
+	}
+
+	var outdated []*vmopv1.VirtualMachine
+	for _, vm := range vms {
+		if !vm.DeletionTimestamp.IsZero() {
+			// Wait for the replica to be deleted and replaced before
+			// replacing another one.
+			conditions.MarkFalse(
+				rs,
+				vmopv1.ImageUpToDateCondition,
+				vmopv1.ImageRolloutInProgressReason,
+				"Waiting for VirtualMachine %q to be deleted",
+				vm.Name)
+			return ctrl.Result{RequeueAfter: imageRolloutRequeueAfter}, nil
+		}
+		if !vmopv1util.ImageRefsEqual(vm.Spec.Image, channel.Status.Image) {
+			outdated = append(outdated, vm)
+		}
+	}
+
+	if len(outdated) == 0 {
+		conditions.MarkTrue(rs, vmopv1.ImageUpToDateCondition)
+		return ctrl.Result{}, nil
+	}
+
+	if rs.Spec.Replicas == nil || len(vms) != int(*rs.Spec.Replicas) {
+		// Let the replica count settle before replacing any replicas.
+		conditions.MarkFalse(
+			rs,
+			vmopv1.ImageUpToDateCondition,
+			vmopv1.ImageRolloutInProgressReason,
+			"Waiting for the desired number of replicas before replacing %d outdated replicas",
+			len(outdated))
+		return ctrl.Result{RequeueAfter: imageRolloutRequeueAfter}, nil
+	}
+
+	// Replace the oldest replica first.
+	sort.SliceStable(outdated, func(i, j int) bool {
+		return outdated[i].CreationTimestamp.Before(&outdated[j].CreationTimestamp)
+	})
+	vm := outdated[0]
+
+	ctx.Logger.Info("Deleting VM to roll out image",
+		"vm", vm.Name,
+		"imageChannel", chanName,
+		"image", channel.Status.Image.Name,
+		"outdatedReplicas", len(outdated))
+
+	if err := r.Client.Delete(ctx, vm); err != nil && !apierrors.IsNotFound(err) {
+		r.Recorder.Warnf(rs, "FailedDelete", "Failed to delete VM %q: %v", vm.Name, err)
+		return ctrl.Result{}, fmt.Errorf("failed to delete VirtualMachine %q: %w", vm.Name, err)
+	}
+	r.Recorder.Eventf(rs, "SuccessfulDelete",
+		"Deleted VM %q to roll out image %q", vm.Name, channel.Status.Image.Name)
+
+	conditions.MarkFalse(
+		rs,
+		vmopv1.ImageUpToDateCondition,
+		vmopv1.ImageRolloutInProgressReason,
+		"Replacing %d of %d replicas with image %q",
+		len(outdated),
+		len(vms),
+		channel.Status.Image.Name)
+
+	return ctrl.Result{RequeueAfter: imageRolloutRequeueAfter}, nil
+}
@@ -0,0 +1,56 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ConditionType is a valid value for Condition.Type.
+type ConditionType string
+
+const (
+	// ReadyCondition defines the Ready condition type that summarizes the
+	// operational state of an object.
+	ReadyCondition ConditionType = "Ready"
+)
+
+// ConditionSeverity expresses the severity of a Condition Type failing.
+type ConditionSeverity string
+
+// Condition defines an observation of a Cluster API resource operational
+// state.
+type Condition struct {
+	// Type of condition in CamelCase or in foo.example.com/CamelCase.
+	Type ConditionType `json:"type"`
+
+	// Status of the condition, one of True, False, Unknown.
+	Status corev1.ConditionStatus `json:"status"`
+
+	// +optional
+
+	// Severity provides an explicit classification of Reason code, so the
+	// users or machines can immediately understand the current situation and
+	// act accordingly.
+	Severity ConditionSeverity `json:"severity,omitempty"`
+
+	// Last time the condition transitioned from one status to another.
+	LastTransitionTime metav1.Time `json:"lastTransitionTime"`
+
+	// +optional
+
+	// The reason for the condition's last transition in CamelCase.
+	Reason string `json:"reason,omitempty"`
+
+	// +optional
+
+	// A human readable message indicating details about the transition.
+	Message string `json:"message,omitempty"`
+}
+
+// Conditions provide observations of the operational state of a Cluster API
+// resource.
+type Conditions []Condition
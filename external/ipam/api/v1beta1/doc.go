@@ -0,0 +1,10 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+// +kubebuilder:object:generate=true
+// +groupName=ipam.cluster.x-k8s.io
+
+// Package v1beta1 contains a subset of the Cluster API IPAM contract's API
+// Schema definitions for the ipam v1beta1 API group.
+package v1beta1
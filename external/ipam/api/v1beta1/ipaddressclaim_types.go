@@ -0,0 +1,60 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package v1beta1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// IPAddressClaimSpec is the desired state of an IPAddressClaim.
+type IPAddressClaimSpec struct {
+	// PoolRef is a reference to the pool from which an IP address should be
+	// created.
+	PoolRef corev1.TypedLocalObjectReference `json:"poolRef"`
+}
+
+// IPAddressClaimStatus is the observed status of a IPAddressClaim.
+type IPAddressClaimStatus struct {
+	// +optional
+
+	// AddressRef is a reference to the address that was created for this
+	// claim.
+	AddressRef corev1.LocalObjectReference `json:"addressRef,omitempty"`
+
+	// +optional
+
+	// Conditions summarises the current state of the IPAddressClaim
+	Conditions Conditions `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=ipaddressclaims,scope=Namespaced,categories=cluster-api
+// +kubebuilder:storageversion
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Pool Name",type="string",JSONPath=".spec.poolRef.name"
+// +kubebuilder:printcolumn:name="Pool Kind",type="string",JSONPath=".spec.poolRef.kind"
+
+// IPAddressClaim is the Schema for the ipaddressclaim API.
+type IPAddressClaim struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   IPAddressClaimSpec   `json:"spec,omitempty"`
+	Status IPAddressClaimStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// IPAddressClaimList is a list of IPAddressClaims.
+type IPAddressClaimList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []IPAddressClaim `json:"items"`
+}
+
+func init() {
+	objectTypes = append(objectTypes, &IPAddressClaim{}, &IPAddressClaimList{})
+}
@@ -7,6 +7,7 @@ replace (
 	github.com/vmware-tanzu/vm-operator/external/appplatform => ./external/appplatform
 	github.com/vmware-tanzu/vm-operator/external/byok => ./external/byok
 	github.com/vmware-tanzu/vm-operator/external/capabilities => ./external/capabilities
+	github.com/vmware-tanzu/vm-operator/external/ipam => ./external/ipam
 	github.com/vmware-tanzu/vm-operator/external/ncp => ./external/ncp
 	github.com/vmware-tanzu/vm-operator/external/storage-policy-quota => ./external/storage-policy-quota
 	github.com/vmware-tanzu/vm-operator/external/tanzu-topology => ./external/tanzu-topology
@@ -15,6 +16,7 @@ replace (
 )
 
 require (
+	github.com/blang/semver/v4 v4.0.0
 	github.com/cespare/xxhash/v2 v2.3.0
 	github.com/davecgh/go-spew v1.1.2-0.20180830191138-d8f796af33cc
 	github.com/go-logr/logr v1.4.2
@@ -30,6 +32,7 @@ require (
 	github.com/vmware-tanzu/vm-operator/external/appplatform v0.0.0-00010101000000-000000000000
 	github.com/vmware-tanzu/vm-operator/external/byok v0.0.0-00010101000000-000000000000
 	github.com/vmware-tanzu/vm-operator/external/capabilities v0.0.0-00010101000000-000000000000
+	github.com/vmware-tanzu/vm-operator/external/ipam v0.0.0-00010101000000-000000000000
 	github.com/vmware-tanzu/vm-operator/external/ncp v0.0.0-00010101000000-000000000000
 	github.com/vmware-tanzu/vm-operator/external/storage-policy-quota v0.0.0-00010101000000-000000000000
 	github.com/vmware-tanzu/vm-operator/external/tanzu-topology v0.0.0-00010101000000-000000000000
@@ -51,11 +54,10 @@ require (
 	sigs.k8s.io/yaml v1.4.0
 )
 
-require k8s.io/utils v0.0.0-20241104100929-3ea5e8cea738 // indirect
+require k8s.io/utils v0.0.0-20241104100929-3ea5e8cea738
 
 require (
 	github.com/beorn7/perks v1.0.1 // indirect
-	github.com/blang/semver/v4 v4.0.0 // indirect
 	github.com/emicklei/go-restful/v3 v3.11.0 // indirect
 	github.com/evanphx/json-patch v5.6.0+incompatible // indirect
 	github.com/evanphx/json-patch/v5 v5.9.11 // indirect
@@ -153,6 +153,18 @@ type Config struct {
 	//
 	// Defaults to "wcp-vmop-sa-vc-auth".
 	VCCredsSecretName string
+
+	// ImageCacheGC contains configuration details related to the garbage
+	// collection of the files cached by VirtualMachineImageCache resources.
+	ImageCacheGC ImageCacheGC
+
+	// ImageProvenance contains configuration details related to the signing
+	// and verification of the provenance records of published images.
+	ImageProvenance ImageProvenance
+
+	// ImageUpload contains configuration details related to the upload of
+	// OVAs with VirtualMachineImageUpload resources.
+	ImageUpload ImageUpload
 }
 
 // GetMaxDeployThreadsOnProvider returns MaxDeployThreadsOnProvider if it is >0
@@ -211,6 +223,114 @@ type InstanceStorage struct {
 	SeedRequeueDuration time.Duration
 }
 
+type ImageCacheGC struct {
+	// Enabled may be set to true to enable the garbage collection of cached
+	// image files.
+	//
+	// Defaults to false.
+	Enabled bool
+
+	// DryRun may be set to true to log and report the cached image files that
+	// would be evicted without deleting them.
+	//
+	// Defaults to false.
+	DryRun bool
+
+	// Interval is how often the garbage collection runs.
+	//
+	// Defaults to 1h.
+	Interval time.Duration
+
+	// MaxUnusedAge is how long the cached files for an image may go unused
+	// before they are evicted. A value of zero disables eviction by age.
+	//
+	// Defaults to 168h.
+	MaxUnusedAge time.Duration
+
+	// MinUnusedAge is how long the cached files for an image must go unused
+	// before they may be evicted to satisfy MaxImagesPerDatastore or
+	// MinFreeSpacePercent.
+	//
+	// Defaults to 1h.
+	MinUnusedAge time.Duration
+
+	// MaxImagesPerDatastore is the maximum number of images that may be cached
+	// on a single datastore. The least recently used images are evicted first.
+	// A value of zero disables eviction by count.
+	//
+	// Defaults to 0.
+	MaxImagesPerDatastore int
+
+	// MinFreeSpacePercent is the percentage of a datastore's capacity that
+	// should remain free. While the free space is below this watermark, the
+	// least recently used images are evicted from the datastore. A value of
+	// zero disables eviction by free space.
+	//
+	// Defaults to 0.
+	MinFreeSpacePercent float64
+}
+
+type ImageProvenance struct {
+	// SigningKeySecretName is the name of the secret in the pod namespace that
+	// contains the PEM-encoded ECDSA private key used to sign the provenance
+	// records of published images. The key is read from the secret's key.pem
+	// field and must not be encrypted.
+	//
+	// If empty then the provenance records are not signed.
+	//
+	// Defaults to "".
+	SigningKeySecretName string
+
+	// PublicKeysSecretName is the name of the secret in the pod namespace that
+	// contains the PEM-encoded public keys trusted to sign the provenance
+	// records of images. Each field in the secret is a public key.
+	//
+	// Defaults to "".
+	PublicKeysSecretName string
+
+	// RequireVerifiedImages may be set to true to prevent VMs from being
+	// deployed from images that do not have a provenance record signed by one
+	// of the keys from PublicKeysSecretName.
+	//
+	// Defaults to false.
+	RequireVerifiedImages bool
+}
+
+type ImageUpload struct {
+	// BaseURL is the scheme and host at which clients outside of the cluster
+	// reach the image upload server, which receives the OVAs uploaded to the
+	// upload URLs of VirtualMachineImageUpload resources.
+	//
+	// If empty then the image upload server is not started and no upload URLs
+	// are issued.
+	//
+	// Defaults to "".
+	BaseURL string
+
+	// ServerPort is the port on which the image upload server listens.
+	//
+	// Defaults to 9879.
+	ServerPort int
+
+	// CertDir is the directory that contains the tls.crt and tls.key files
+	// served by the image upload server.
+	//
+	// Defaults to /tmp/k8s-image-upload-server/serving-certs.
+	CertDir string
+
+	// URLTTL is how long the upload URL of a VirtualMachineImageUpload may be
+	// used after it is issued.
+	//
+	// Defaults to 1h.
+	URLTTL time.Duration
+
+	// Timeout is how long an OVA may be received after the upload begins
+	// before the upload fails.
+	//
+	// Defaults to 4h.
+	Timeout time.Duration
+}
+
 type NetworkProviderType string
 
 const (
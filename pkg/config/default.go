@@ -35,6 +35,17 @@ func Default() Config {
 			PVPlacementFailedTTL: 5 * time.Minute,
 			SeedRequeueDuration:  10 * time.Second,
 		},
+		ImageCacheGC: ImageCacheGC{
+			Interval:     1 * time.Hour,
+			MaxUnusedAge: 7 * 24 * time.Hour,
+			MinUnusedAge: 1 * time.Hour,
+		},
+		ImageUpload: ImageUpload{
+			ServerPort: 9879,
+			CertDir:    "/tmp/k8s-image-upload-server/serving-certs",
+			URLTTL:     1 * time.Hour,
+			Timeout:    4 * time.Hour,
+		},
 		LeaderElectionID:             defaultPrefix + "controller-manager-runtime",
 		MaxCreateVMsOnProvider:       80,
 		MaxConcurrentReconciles:      1,
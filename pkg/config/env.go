@@ -39,6 +39,24 @@ func FromEnv() Config {
 	setFloat64(env.InstanceStorageJitterMaxFactor, &config.InstanceStorage.JitterMaxFactor)
 	setDuration(env.InstanceStorageSeedRequeueDuration, &config.InstanceStorage.SeedRequeueDuration)
 
+	setBool(env.ImageCacheGCEnabled, &config.ImageCacheGC.Enabled)
+	setBool(env.ImageCacheGCDryRun, &config.ImageCacheGC.DryRun)
+	setDuration(env.ImageCacheGCInterval, &config.ImageCacheGC.Interval)
+	setDuration(env.ImageCacheGCMaxUnusedAge, &config.ImageCacheGC.MaxUnusedAge)
+	setDuration(env.ImageCacheGCMinUnusedAge, &config.ImageCacheGC.MinUnusedAge)
+	setInt(env.ImageCacheGCMaxImagesPerDatastore, &config.ImageCacheGC.MaxImagesPerDatastore)
+	setFloat64(env.ImageCacheGCMinFreeSpacePercent, &config.ImageCacheGC.MinFreeSpacePercent)
+
+	setString(env.ImageProvenanceSigningKeySecretName, &config.ImageProvenance.SigningKeySecretName)
+	setString(env.ImageProvenancePublicKeysSecretName, &config.ImageProvenance.PublicKeysSecretName)
+	setBool(env.ImageProvenanceRequireVerifiedImages, &config.ImageProvenance.RequireVerifiedImages)
+
+	setString(env.ImageUploadBaseURL, &config.ImageUpload.BaseURL)
+	setInt(env.ImageUploadServerPort, &config.ImageUpload.ServerPort)
+	setString(env.ImageUploadCertDir, &config.ImageUpload.CertDir)
+	setDuration(env.ImageUploadURLTTL, &config.ImageUpload.URLTTL)
+	setDuration(env.ImageUploadTimeout, &config.ImageUpload.Timeout)
+
 	setBool(env.ContainerNode, &config.ContainerNode)
 	setString(env.WatchNamespace, &config.WatchNamespace)
 	setString(env.ProfilerAddr, &config.ProfilerAddr)
@@ -33,6 +33,21 @@ const (
 	InstanceStoragePVPlacementFailedTTL
 	InstanceStorageJitterMaxFactor
 	InstanceStorageSeedRequeueDuration
+	ImageCacheGCEnabled
+	ImageCacheGCDryRun
+	ImageCacheGCInterval
+	ImageCacheGCMaxUnusedAge
+	ImageCacheGCMinUnusedAge
+	ImageCacheGCMaxImagesPerDatastore
+	ImageCacheGCMinFreeSpacePercent
+	ImageProvenanceSigningKeySecretName
+	ImageProvenancePublicKeysSecretName
+	ImageProvenanceRequireVerifiedImages
+	ImageUploadBaseURL
+	ImageUploadServerPort
+	ImageUploadCertDir
+	ImageUploadURLTTL
+	ImageUploadTimeout
 	ContainerNode
 	ProfilerAddr
 	RateLimitQPS
@@ -128,6 +143,36 @@ func (n VarName) String() string {
 		return "INSTANCE_STORAGE_JITTER_MAX_FACTOR"
 	case InstanceStorageSeedRequeueDuration:
 		return "INSTANCE_STORAGE_SEED_REQUEUE_DURATION"
+	case ImageCacheGCEnabled:
+		return "IMAGE_CACHE_GC_ENABLED"
+	case ImageCacheGCDryRun:
+		return "IMAGE_CACHE_GC_DRY_RUN"
+	case ImageCacheGCInterval:
+		return "IMAGE_CACHE_GC_INTERVAL"
+	case ImageCacheGCMaxUnusedAge:
+		return "IMAGE_CACHE_GC_MAX_UNUSED_AGE"
+	case ImageCacheGCMinUnusedAge:
+		return "IMAGE_CACHE_GC_MIN_UNUSED_AGE"
+	case ImageCacheGCMaxImagesPerDatastore:
+		return "IMAGE_CACHE_GC_MAX_IMAGES_PER_DATASTORE"
+	case ImageCacheGCMinFreeSpacePercent:
+		return "IMAGE_CACHE_GC_MIN_FREE_SPACE_PERCENT"
+	case ImageProvenanceSigningKeySecretName:
+		return "IMAGE_PROVENANCE_SIGNING_KEY_SECRET_NAME"
+	case ImageProvenancePublicKeysSecretName:
+		return "IMAGE_PROVENANCE_PUBLIC_KEYS_SECRET_NAME"
+	case ImageProvenanceRequireVerifiedImages:
+		return "IMAGE_PROVENANCE_REQUIRE_VERIFIED_IMAGES"
+	case ImageUploadBaseURL:
+		return "IMAGE_UPLOAD_BASE_URL"
+	case ImageUploadServerPort:
+		return "IMAGE_UPLOAD_SERVER_PORT"
+	case ImageUploadCertDir:
+		return "IMAGE_UPLOAD_CERT_DIR"
+	case ImageUploadURLTTL:
+		return "IMAGE_UPLOAD_URL_TTL"
+	case ImageUploadTimeout:
+		return "IMAGE_UPLOAD_TIMEOUT"
 	case ContainerNode:
 		return "CONTAINER_NODE"
 	case ProfilerAddr:
@@ -109,6 +109,21 @@ var _ = Describe(
 					Expect(os.Setenv("SYNC_IMAGE_REQUEUE_DELAY", "128h")).To(Succeed())
 					Expect(os.Setenv("DEPLOYMENT_NAME", "129")).To(Succeed())
 					Expect(os.Setenv("SIGUSR2_RESTART_ENABLED", "true")).To(Succeed())
+					Expect(os.Setenv("IMAGE_CACHE_GC_ENABLED", "false")).To(Succeed())
+					Expect(os.Setenv("IMAGE_CACHE_GC_DRY_RUN", "true")).To(Succeed())
+					Expect(os.Setenv("IMAGE_CACHE_GC_INTERVAL", "130h")).To(Succeed())
+					Expect(os.Setenv("IMAGE_CACHE_GC_MAX_UNUSED_AGE", "131h")).To(Succeed())
+					Expect(os.Setenv("IMAGE_CACHE_GC_MIN_UNUSED_AGE", "132h")).To(Succeed())
+					Expect(os.Setenv("IMAGE_CACHE_GC_MAX_IMAGES_PER_DATASTORE", "133")).To(Succeed())
+					Expect(os.Setenv("IMAGE_CACHE_GC_MIN_FREE_SPACE_PERCENT", "134.0")).To(Succeed())
+					Expect(os.Setenv("IMAGE_PROVENANCE_SIGNING_KEY_SECRET_NAME", "135")).To(Succeed())
+					Expect(os.Setenv("IMAGE_PROVENANCE_PUBLIC_KEYS_SECRET_NAME", "136")).To(Succeed())
+					Expect(os.Setenv("IMAGE_PROVENANCE_REQUIRE_VERIFIED_IMAGES", "true")).To(Succeed())
+					Expect(os.Setenv("IMAGE_UPLOAD_BASE_URL", "137")).To(Succeed())
+					Expect(os.Setenv("IMAGE_UPLOAD_URL_TTL", "138h")).To(Succeed())
+					Expect(os.Setenv("IMAGE_UPLOAD_SERVER_PORT", "139")).To(Succeed())
+					Expect(os.Setenv("IMAGE_UPLOAD_CERT_DIR", "140")).To(Succeed())
+					Expect(os.Setenv("IMAGE_UPLOAD_TIMEOUT", "141h")).To(Succeed())
 				})
 				It("Should return a default config overridden by the environment", func() {
 					Expect(config).To(BeComparableTo(pkgcfg.Config{
@@ -164,6 +179,27 @@ var _ = Describe(
 						SyncImageRequeueDelay:        128 * time.Hour,
 						DeploymentName:               "129",
 						SIGUSR2RestartEnabled:        true,
+						ImageCacheGC: pkgcfg.ImageCacheGC{
+							Enabled:               false,
+							DryRun:                true,
+							Interval:              130 * time.Hour,
+							MaxUnusedAge:          131 * time.Hour,
+							MinUnusedAge:          132 * time.Hour,
+							MaxImagesPerDatastore: 133,
+							MinFreeSpacePercent:   134.0,
+						},
+						ImageProvenance: pkgcfg.ImageProvenance{
+							SigningKeySecretName:  "135",
+							PublicKeysSecretName:  "136",
+							RequireVerifiedImages: true,
+						},
+						ImageUpload: pkgcfg.ImageUpload{
+							BaseURL:    "137",
+							URLTTL:     138 * time.Hour,
+							ServerPort: 139,
+							CertDir:    "140",
+							Timeout:    141 * time.Hour,
+						},
 					}))
 				})
 			})
@@ -150,4 +150,22 @@ const (
 	// VirtualMachineClassHashAnnotationKey is the annotation key for the VM Class hash
 	// used to generate VirtualMachineClassInstances.
 	VirtualMachineClassHashAnnotationKey = "vmoperator.vmware.com/vmclass-hash"
+
+	// RequestedByAnnotationKey is applied to VirtualMachinePublishRequest
+	// objects by a mutation webhook when the object is created. The value is
+	// the name of the user that created the object.
+	//
+	// Please note, a validation webhook denies changes to this annotation by
+	// unprivileged users.
+	RequestedByAnnotationKey = "vmoperator.vmware.com.protected/requested-by"
+
+	// ImageProvenanceAnnotationKey is applied to VirtualMachineImage objects
+	// published by a VirtualMachinePublishRequest. The value is the image's
+	// provenance record in JSON.
+	ImageProvenanceAnnotationKey = "vmoperator.vmware.com/image-provenance"
+
+	// ImageProvenanceSignatureAnnotationKey is applied to VirtualMachineImage
+	// objects with a signed provenance record. The value is the base64-encoded
+	// signature of the value of the ImageProvenanceAnnotationKey annotation.
+	ImageProvenanceSignatureAnnotationKey = "vmoperator.vmware.com/image-provenance-signature"
 )
@@ -0,0 +1,26 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package context
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+)
+
+// VirtualMachineImageChannelContext is the context used for
+// VirtualMachineImageChannel controllers.
+type VirtualMachineImageChannelContext struct {
+	context.Context
+	Logger  logr.Logger
+	Channel *vmopv1.VirtualMachineImageChannel
+}
+
+func (v *VirtualMachineImageChannelContext) String() string {
+	return fmt.Sprintf("%s %s/%s", v.Channel.GroupVersionKind(), v.Channel.Namespace, v.Channel.Name)
+}
@@ -0,0 +1,26 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package context
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+)
+
+// VirtualMachineImageUploadContext is the context used for
+// VirtualMachineImageUpload controllers.
+type VirtualMachineImageUploadContext struct {
+	context.Context
+	Logger        logr.Logger
+	VMImageUpload *vmopv1.VirtualMachineImageUpload
+}
+
+func (v *VirtualMachineImageUploadContext) String() string {
+	return fmt.Sprintf("%s %s/%s", v.VMImageUpload.GroupVersionKind(), v.VMImageUpload.Namespace, v.VMImageUpload.Name)
+}
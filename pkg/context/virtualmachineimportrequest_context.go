@@ -0,0 +1,26 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package context
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+)
+
+// VirtualMachineImportRequestContext is the context used for
+// VirtualMachineImportRequest controllers.
+type VirtualMachineImportRequestContext struct {
+	context.Context
+	Logger          logr.Logger
+	VMImportRequest *vmopv1.VirtualMachineImportRequest
+}
+
+func (v *VirtualMachineImportRequestContext) String() string {
+	return fmt.Sprintf("%s %s/%s", v.VMImportRequest.GroupVersionKind(), v.VMImportRequest.Namespace, v.VMImportRequest.Name)
+}
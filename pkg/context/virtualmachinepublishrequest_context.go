@@ -13,6 +13,7 @@ import (
 	imgregv1a1 "github.com/vmware-tanzu/image-registry-operator-api/api/v1alpha1"
 
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/oci"
 )
 
 // VirtualMachinePublishRequestContext is the context used for VirtualMachinePublishRequestControllers.
@@ -21,8 +22,15 @@ type VirtualMachinePublishRequestContext struct {
 	Logger           logr.Logger
 	VMPublishRequest *vmopv1.VirtualMachinePublishRequest
 	VM               *vmopv1.VirtualMachine
-	ContentLibrary   *imgregv1a1.ContentLibrary
-	ItemID           string
+	// VMSnapshot is the snapshot from which the VM is published when the
+	// source is a VirtualMachineSnapshot.
+	VMSnapshot     *vmopv1.VirtualMachineSnapshot
+	ContentLibrary *imgregv1a1.ContentLibrary
+	ItemID         string
+	// OCIClient and OCIReference are the client and reference used to push
+	// the VM when the target location is an OCI registry.
+	OCIClient    *oci.Client
+	OCIReference oci.Reference
 	// SkipPatch indicates whether we should skip patching the object after reconcile
 	// because Status is updated separately in the publishing case due to CL API limitations.
 	SkipPatch bool
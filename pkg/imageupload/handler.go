@@ -0,0 +1,262 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package imageupload
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/util/retry"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	imgregv1a1 "github.com/vmware-tanzu/image-registry-operator-api/api/v1alpha1"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ova"
+)
+
+// Path is the path at which the Handler is registered with the image upload
+// server. The upload URL of a VirtualMachineImageUpload is the base URL
+// followed by Path, the namespace and name of the resource, and a random
+// token, ex. https://host/image-upload/my-namespace/my-upload/my-token.
+const Path = "/image-upload/"
+
+// UploadURL returns the upload URL for the VirtualMachineImageUpload with the
+// provided namespace, name, and token.
+func UploadURL(baseURL, namespace, name, token string) string {
+	return strings.TrimSuffix(baseURL, "/") + Path + namespace + "/" + name + "/" + token
+}
+
+// Handler receives the OVAs uploaded to the upload URLs of
+// VirtualMachineImageUpload resources and creates a content library item
+// from each of them.
+type Handler struct {
+	Context    context.Context
+	Client     ctrlclient.Client
+	VMProvider providers.VirtualMachineProviderInterface
+}
+
+// NewHandler returns a new Handler.
+func NewHandler(
+	ctx context.Context,
+	client ctrlclient.Client,
+	vmProvider providers.VirtualMachineProviderInterface) *Handler {
+
+	return &Handler{
+		Context:    ctx,
+		Client:     client,
+		VMProvider: vmProvider,
+	}
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		w.Header().Set("Allow", http.MethodPut)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, Path), "/")
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+
+	var (
+		ctx    = pkgcfg.JoinContext(r.Context(), h.Context)
+		key    = ctrlclient.ObjectKey{Namespace: parts[0], Name: parts[1]}
+		logger = logr.FromContextOrDiscard(h.Context).WithName("image-upload").WithValues("name", key)
+	)
+
+	vmiUpload := &vmopv1.VirtualMachineImageUpload{}
+	if err := h.Client.Get(ctx, key, vmiUpload); err != nil {
+		if apierrors.IsNotFound(err) {
+			http.Error(w, "not found", http.StatusNotFound)
+			return
+		}
+		logger.Error(err, "Failed to get VirtualMachineImageUpload")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if !isUploadURL(vmiUpload.Status.UploadURL, r.URL.Path) {
+		http.Error(w, "forbidden", http.StatusForbidden)
+		return
+	}
+
+	if !vmiUpload.Status.ExpirationTime.IsZero() &&
+		time.Now().After(vmiUpload.Status.ExpirationTime.Time) {
+
+		http.Error(w, "upload url has expired", http.StatusGone)
+		return
+	}
+
+	// Claim the upload URL so it may only be used once.
+	if err := h.patchStatus(ctx, vmiUpload, true, func(obj *vmopv1.VirtualMachineImageUpload) error {
+		if c := conditions.Get(obj, vmopv1.VirtualMachineImageUploadConditionUploaded); c == nil ||
+			c.Reason != vmopv1.HasNotBeenUploadedReason {
+
+			return errAlreadyUsed
+		}
+		conditions.MarkFalse(obj,
+			vmopv1.VirtualMachineImageUploadConditionUploaded,
+			vmopv1.UploadingReason,
+			"Receiving the OVA")
+		return nil
+	}); err != nil {
+		if errors.Is(err, errAlreadyUsed) || apierrors.IsConflict(err) {
+			http.Error(w, "upload url has already been used", http.StatusConflict)
+			return
+		}
+		logger.Error(err, "Failed to claim upload URL")
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	logger.Info("Receiving OVA")
+
+	// The upload is cancelled if it is not done before it times out, after
+	// which the Uploaded condition is marked as timed out by the controller.
+	uploadCtx, cancel := context.WithTimeout(ctx, pkgcfg.FromContext(ctx).ImageUpload.Timeout)
+	defer cancel()
+
+	itemID, err := h.createLibraryItem(uploadCtx, vmiUpload, ova.NewReader(r.Body))
+	if err != nil {
+		var (
+			reason = vmopv1.UploadFailureReason
+			code   = http.StatusInternalServerError
+		)
+		switch {
+		case errors.Is(err, ova.ErrInvalid):
+			reason = vmopv1.UploadInvalidOVAReason
+			code = http.StatusBadRequest
+		case errors.Is(uploadCtx.Err(), context.DeadlineExceeded):
+			reason = vmopv1.UploadTimedOutReason
+			code = http.StatusRequestTimeout
+		}
+
+		logger.Error(err, "Failed to upload OVA")
+		if err := h.patchStatus(ctx, vmiUpload, false, func(obj *vmopv1.VirtualMachineImageUpload) error {
+			conditions.MarkError(obj, vmopv1.VirtualMachineImageUploadConditionUploaded, reason, err)
+			return nil
+		}); err != nil {
+			logger.Error(err, "Failed to patch status")
+		}
+
+		http.Error(w, err.Error(), code)
+		return
+	}
+
+	if err := h.patchStatus(ctx, vmiUpload, false, func(obj *vmopv1.VirtualMachineImageUpload) error {
+		if c := conditions.Get(obj, vmopv1.VirtualMachineImageUploadConditionUploaded); c == nil ||
+			c.Reason != vmopv1.UploadingReason {
+
+			return errNotUploading
+		}
+		obj.Status.LibraryItemID = itemID
+		conditions.MarkTrue(obj, vmopv1.VirtualMachineImageUploadConditionUploaded)
+		return nil
+	}); err != nil {
+		logger.Error(err, "Failed to patch status", "itemID", itemID)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	logger.Info("Uploaded OVA", "itemID", itemID)
+	w.WriteHeader(http.StatusCreated)
+}
+
+var (
+	errAlreadyUsed  = errors.New("upload url has already been used")
+	errNotUploading = errors.New("upload is no longer in progress")
+)
+
+func (h *Handler) createLibraryItem(
+	ctx context.Context,
+	vmiUpload *vmopv1.VirtualMachineImageUpload,
+	ovaReader *ova.Reader) (string, error) {
+
+	cl := &imgregv1a1.ContentLibrary{}
+	key := ctrlclient.ObjectKey{Namespace: vmiUpload.Namespace, Name: vmiUpload.Spec.Library}
+	if err := h.Client.Get(ctx, key, cl); err != nil {
+		return "", fmt.Errorf("failed to get ContentLibrary %s: %w", key, err)
+	}
+
+	itemName := vmiUpload.Spec.ItemName
+	if itemName == "" {
+		itemName = vmiUpload.Name
+	}
+
+	return h.VMProvider.CreateContentLibraryItemFromOVA(
+		ctx,
+		string(cl.Spec.UUID),
+		itemName,
+		vmiUpload.Spec.Description,
+		ovaReader)
+}
+
+// patchStatus applies mutateFn to the latest version of the object and
+// patches its status. If claim is true then the patch fails with a conflict
+// if the object was changed by someone else. Otherwise the patch is retried
+// on conflict.
+func (h *Handler) patchStatus(
+	ctx context.Context,
+	vmiUpload *vmopv1.VirtualMachineImageUpload,
+	claim bool,
+	mutateFn func(obj *vmopv1.VirtualMachineImageUpload) error) error {
+
+	patchFn := func() error {
+		obj := vmiUpload.DeepCopy()
+		if err := mutateFn(obj); err != nil {
+			return err
+		}
+		if err := h.Client.Status().Patch(
+			ctx,
+			obj,
+			ctrlclient.MergeFromWithOptions(vmiUpload, ctrlclient.MergeFromWithOptimisticLock{})); err != nil {
+			return err
+		}
+		*vmiUpload = *obj
+		return nil
+	}
+
+	if claim {
+		return patchFn()
+	}
+
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		err := patchFn()
+		if apierrors.IsConflict(err) {
+			if err := h.Client.Get(ctx, ctrlclient.ObjectKeyFromObject(vmiUpload), vmiUpload); err != nil {
+				return err
+			}
+		}
+		return err
+	})
+}
+
+// isUploadURL returns true if the request path matches the path of the upload
+// URL.
+func isUploadURL(uploadURL, requestPath string) bool {
+	if uploadURL == "" {
+		return false
+	}
+	u, err := url.Parse(uploadURL)
+	if err != nil {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(u.Path), []byte(requestPath)) == 1
+}
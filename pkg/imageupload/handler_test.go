@@ -0,0 +1,274 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package imageupload_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	"github.com/vmware-tanzu/vm-operator/pkg/imageupload"
+	providerfake "github.com/vmware-tanzu/vm-operator/pkg/providers/fake"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ova"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+var _ = Describe("UploadURL", func() {
+	It("returns the upload URL", func() {
+		Expect(imageupload.UploadURL("https://host/", "my-ns", "my-upload", "my-token")).
+			To(Equal("https://host/image-upload/my-ns/my-upload/my-token"))
+	})
+})
+
+var _ = Describe("Handler", func() {
+	const (
+		ns        = "my-ns"
+		uploadURL = "https://host/image-upload/my-ns/my-upload/my-token"
+	)
+
+	var (
+		client         ctrlclient.Client
+		fakeVMProvider *providerfake.VMProvider
+		handler        *imageupload.Handler
+
+		vmiUpload *vmopv1.VirtualMachineImageUpload
+		method    string
+		path      string
+		resp      *httptest.ResponseRecorder
+
+		config         pkgcfg.ImageUpload
+		createFn       func(ctx context.Context) (string, error)
+		createErr      error
+		createdLibrary string
+		createdName    string
+	)
+
+	BeforeEach(func() {
+		vmiUpload = &vmopv1.VirtualMachineImageUpload{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-upload",
+				Namespace: ns,
+			},
+			Spec: vmopv1.VirtualMachineImageUploadSpec{
+				Library: "my-cl",
+			},
+			Status: vmopv1.VirtualMachineImageUploadStatus{
+				UploadURL:      uploadURL,
+				ExpirationTime: metav1.NewTime(time.Now().Add(time.Hour)),
+			},
+		}
+		conditions.MarkFalse(vmiUpload,
+			vmopv1.VirtualMachineImageUploadConditionUploaded,
+			vmopv1.HasNotBeenUploadedReason,
+			"")
+
+		method = http.MethodPut
+		path = "/image-upload/my-ns/my-upload/my-token"
+		config = pkgcfg.Default().ImageUpload
+		createFn = nil
+		createErr = nil
+		createdLibrary, createdName = "", ""
+	})
+
+	JustBeforeEach(func() {
+		client = builder.NewFakeClient(
+			builder.DummyContentLibrary("my-cl", ns, "my-cl-uuid"),
+			vmiUpload)
+
+		fakeVMProvider = providerfake.NewVMProvider()
+		fakeVMProvider.CreateContentLibraryItemFromOVAFn = func(
+			ctx context.Context,
+			libraryUUID, itemName, _ string,
+			_ *ova.Reader) (string, error) {
+
+			createdLibrary, createdName = libraryUUID, itemName
+			if createFn != nil {
+				return createFn(ctx)
+			}
+			if createErr != nil {
+				return "", createErr
+			}
+			return "my-item-id", nil
+		}
+
+		ctx := pkgcfg.WithContext(context.Background(), pkgcfg.Config{ImageUpload: config})
+		handler = imageupload.NewHandler(ctx, client, fakeVMProvider)
+
+		resp = httptest.NewRecorder()
+		handler.ServeHTTP(resp, httptest.NewRequest(method, path, strings.NewReader("ova")))
+	})
+
+	getImageUpload := func() *vmopv1.VirtualMachineImageUpload {
+		obj := &vmopv1.VirtualMachineImageUpload{}
+		Expect(client.Get(context.Background(), ctrlclient.ObjectKeyFromObject(vmiUpload), obj)).To(Succeed())
+		return obj
+	}
+
+	When("the OVA is uploaded", func() {
+		It("creates the library item", func() {
+			Expect(resp.Code).To(Equal(http.StatusCreated))
+			Expect(createdLibrary).To(Equal("my-cl-uuid"))
+			Expect(createdName).To(Equal("my-upload"))
+
+			obj := getImageUpload()
+			Expect(obj.Status.LibraryItemID).To(Equal("my-item-id"))
+			Expect(conditions.IsTrue(obj, vmopv1.VirtualMachineImageUploadConditionUploaded)).To(BeTrue())
+		})
+
+		When("the item name is specified", func() {
+			BeforeEach(func() {
+				vmiUpload.Spec.ItemName = "my-item"
+			})
+
+			It("creates the library item with the item name", func() {
+				Expect(resp.Code).To(Equal(http.StatusCreated))
+				Expect(createdName).To(Equal("my-item"))
+			})
+		})
+	})
+
+	When("the OVA is invalid", func() {
+		BeforeEach(func() {
+			createErr = fmt.Errorf("failed to read ova: %w", ova.ErrChecksumMismatch)
+		})
+
+		It("returns a bad request", func() {
+			Expect(resp.Code).To(Equal(http.StatusBadRequest))
+			Expect(conditions.GetReason(getImageUpload(), vmopv1.VirtualMachineImageUploadConditionUploaded)).
+				To(Equal(vmopv1.UploadInvalidOVAReason))
+		})
+	})
+
+	When("the library item may not be created", func() {
+		BeforeEach(func() {
+			createErr = errors.New("fake")
+		})
+
+		It("returns an internal server error", func() {
+			Expect(resp.Code).To(Equal(http.StatusInternalServerError))
+			Expect(conditions.GetReason(getImageUpload(), vmopv1.VirtualMachineImageUploadConditionUploaded)).
+				To(Equal(vmopv1.UploadFailureReason))
+		})
+	})
+
+	When("the upload times out", func() {
+		BeforeEach(func() {
+			config.Timeout = time.Millisecond
+			createFn = func(ctx context.Context) (string, error) {
+				<-ctx.Done()
+				return "", ctx.Err()
+			}
+		})
+
+		It("returns a request timeout", func() {
+			Expect(resp.Code).To(Equal(http.StatusRequestTimeout))
+			Expect(conditions.GetReason(getImageUpload(), vmopv1.VirtualMachineImageUploadConditionUploaded)).
+				To(Equal(vmopv1.UploadTimedOutReason))
+		})
+	})
+
+	When("the upload is marked as timed out before the library item is created", func() {
+		BeforeEach(func() {
+			createFn = func(ctx context.Context) (string, error) {
+				obj := getImageUpload()
+				conditions.MarkFalse(obj,
+					vmopv1.VirtualMachineImageUploadConditionUploaded,
+					vmopv1.UploadTimedOutReason,
+					"")
+				Expect(client.Status().Update(ctx, obj)).To(Succeed())
+				return "my-item-id", nil
+			}
+		})
+
+		It("returns an internal server error", func() {
+			Expect(resp.Code).To(Equal(http.StatusInternalServerError))
+
+			obj := getImageUpload()
+			Expect(obj.Status.LibraryItemID).To(BeEmpty())
+			Expect(conditions.GetReason(obj, vmopv1.VirtualMachineImageUploadConditionUploaded)).
+				To(Equal(vmopv1.UploadTimedOutReason))
+		})
+	})
+
+	When("the method is not PUT", func() {
+		BeforeEach(func() {
+			method = http.MethodPost
+		})
+
+		It("returns method not allowed", func() {
+			Expect(resp.Code).To(Equal(http.StatusMethodNotAllowed))
+			Expect(createdName).To(BeEmpty())
+		})
+	})
+
+	When("the path is not an upload URL", func() {
+		BeforeEach(func() {
+			path = "/image-upload/my-ns/my-upload"
+		})
+
+		It("returns not found", func() {
+			Expect(resp.Code).To(Equal(http.StatusNotFound))
+		})
+	})
+
+	When("the VirtualMachineImageUpload does not exist", func() {
+		BeforeEach(func() {
+			path = "/image-upload/my-ns/other-upload/my-token"
+		})
+
+		It("returns not found", func() {
+			Expect(resp.Code).To(Equal(http.StatusNotFound))
+		})
+	})
+
+	When("the token is wrong", func() {
+		BeforeEach(func() {
+			path = "/image-upload/my-ns/my-upload/other-token"
+		})
+
+		It("returns forbidden", func() {
+			Expect(resp.Code).To(Equal(http.StatusForbidden))
+			Expect(createdName).To(BeEmpty())
+		})
+	})
+
+	When("the upload URL has expired", func() {
+		BeforeEach(func() {
+			vmiUpload.Status.ExpirationTime = metav1.NewTime(time.Now().Add(-time.Minute))
+		})
+
+		It("returns gone", func() {
+			Expect(resp.Code).To(Equal(http.StatusGone))
+			Expect(createdName).To(BeEmpty())
+		})
+	})
+
+	When("the upload URL has already been used", func() {
+		BeforeEach(func() {
+			conditions.MarkFalse(vmiUpload,
+				vmopv1.VirtualMachineImageUploadConditionUploaded,
+				vmopv1.UploadingReason,
+				"")
+		})
+
+		It("returns conflict", func() {
+			Expect(resp.Code).To(Equal(http.StatusConflict))
+			Expect(createdName).To(BeEmpty())
+		})
+	})
+})
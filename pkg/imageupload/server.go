@@ -0,0 +1,51 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package imageupload
+
+import (
+	"context"
+
+	"github.com/go-logr/logr"
+	ctrl "sigs.k8s.io/controller-runtime"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers"
+)
+
+// AddToManager adds the image upload server to the provided manager.
+func AddToManager(
+	ctx *pkgctx.ControllerManagerContext,
+	mgr manager.Manager) error {
+
+	return mgr.Add(NewServer(
+		logr.NewContext(ctx, ctrl.Log.WithName("imageupload")),
+		pkgcfg.FromContext(ctx).ImageUpload,
+		mgr.GetClient(),
+		ctx.VMProvider))
+}
+
+// NewServer returns the image upload server, which serves the Handler on the
+// port and with the certificate from the provided config. The server is
+// separate from the webhook server so only the upload URLs are exposed
+// outside of the cluster, and it is served by every replica of the controller
+// manager, not just the leader.
+func NewServer(
+	ctx context.Context,
+	config pkgcfg.ImageUpload,
+	client ctrlclient.Client,
+	vmProvider providers.VirtualMachineProviderInterface) webhook.Server {
+
+	server := webhook.NewServer(webhook.Options{
+		Port:    config.ServerPort,
+		CertDir: config.CertDir,
+	})
+	server.Register(Path, NewHandler(ctx, client, vmProvider))
+
+	return server
+}
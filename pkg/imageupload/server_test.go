@@ -0,0 +1,34 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package imageupload_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	"github.com/vmware-tanzu/vm-operator/pkg/imageupload"
+	providerfake "github.com/vmware-tanzu/vm-operator/pkg/providers/fake"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+var _ = Describe("NewServer", func() {
+	It("returns a server that serves the handler on every replica", func() {
+		server := imageupload.NewServer(
+			pkgcfg.NewContext(),
+			pkgcfg.ImageUpload{ServerPort: 9879, CertDir: "/certs"},
+			builder.NewFakeClient(),
+			providerfake.NewVMProvider())
+
+		Expect(server.NeedLeaderElection()).To(BeFalse())
+
+		_, pattern := server.WebhookMux().Handler(
+			httptest.NewRequest(http.MethodPut, "/image-upload/my-ns/my-upload/my-token", nil))
+		Expect(pattern).To(Equal(imageupload.Path))
+	})
+})
@@ -28,6 +28,7 @@ import (
 	appv1a1 "github.com/vmware-tanzu/vm-operator/external/appplatform/api/v1alpha1"
 	byokv1 "github.com/vmware-tanzu/vm-operator/external/byok/api/v1alpha1"
 	capv1 "github.com/vmware-tanzu/vm-operator/external/capabilities/api/v1alpha1"
+	ipamv1 "github.com/vmware-tanzu/vm-operator/external/ipam/api/v1beta1"
 	ncpv1alpha1 "github.com/vmware-tanzu/vm-operator/external/ncp/api/v1alpha1"
 	spqv1 "github.com/vmware-tanzu/vm-operator/external/storage-policy-quota/api/v1alpha2"
 	topologyv1 "github.com/vmware-tanzu/vm-operator/external/tanzu-topology/api/v1alpha1"
@@ -62,6 +63,7 @@ func New(ctx context.Context, opts Options) (Manager, error) {
 	_ = spqv1.AddToScheme(opts.Scheme)
 	_ = byokv1.AddToScheme(opts.Scheme)
 	_ = capv1.AddToScheme(opts.Scheme)
+	_ = ipamv1.AddToScheme(opts.Scheme)
 	_ = appv1a1.AddToScheme(opts.Scheme)
 
 	_ = vmopapi.AddToScheme(opts.Scheme)
@@ -20,4 +20,10 @@ const (
 	// VMImage related metrics labels (from image registry service).
 	vmiNameLabel      = "vmi_name"
 	vmiNamespaceLabel = "vmi_namespace"
+
+	// VMImage cache related metrics labels.
+	datastoreLabel = "datastore"
+	dirLabel       = "dir"
+	reasonLabel    = "reason"
+	dryRunLabel    = "dry_run"
 )
@@ -0,0 +1,129 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package metrics
+
+import (
+	"strconv"
+	"sync"
+
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/go-logr/logr"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	vmiCacheMetricsOnce sync.Once
+	vmiCacheMetrics     *VMICacheMetrics
+)
+
+type VMICacheMetrics struct {
+	gcEvictions      *prometheus.CounterVec
+	gcReclaimedBytes *prometheus.CounterVec
+	gcReferences     *prometheus.GaugeVec
+}
+
+// NewVMICacheMetrics initializes a singleton and registers all the defined
+// metrics.
+func NewVMICacheMetrics() *VMICacheMetrics {
+	vmiCacheMetricsOnce.Do(func() {
+		vmiCacheMetrics = &VMICacheMetrics{
+			gcEvictions: prometheus.NewCounterVec(prometheus.CounterOpts{
+				Namespace: metricsNamespace,
+				Subsystem: "vmi_cache",
+				Name:      "gc_evictions_total",
+				Help:      "Number of cached image directories evicted by the image cache garbage collector",
+			}, []string{
+				datastoreLabel,
+				reasonLabel,
+				dryRunLabel,
+			}),
+			gcReclaimedBytes: prometheus.NewCounterVec(prometheus.CounterOpts{
+				Namespace: metricsNamespace,
+				Subsystem: "vmi_cache",
+				Name:      "gc_reclaimed_bytes_total",
+				Help:      "Number of bytes reclaimed by the image cache garbage collector",
+			}, []string{
+				datastoreLabel,
+				dryRunLabel,
+			}),
+			gcReferences: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+				Namespace: metricsNamespace,
+				Subsystem: "vmi_cache",
+				Name:      "references",
+				Help:      "Number of virtual disks that use a cached image directory as a parent",
+			}, []string{
+				datastoreLabel,
+				dirLabel,
+			}),
+		}
+
+		metrics.Registry.MustRegister(
+			vmiCacheMetrics.gcEvictions,
+			vmiCacheMetrics.gcReclaimedBytes,
+			vmiCacheMetrics.gcReferences,
+		)
+	})
+
+	return vmiCacheMetrics
+}
+
+// RegisterGCEviction registers the eviction of a cached image directory.
+func (m *VMICacheMetrics) RegisterGCEviction(
+	logger logr.Logger,
+	datastore, reason string,
+	size int64,
+	dryRun bool) {
+
+	dryRunVal := strconv.FormatBool(dryRun)
+	m.gcEvictions.With(prometheus.Labels{
+		datastoreLabel: datastore,
+		reasonLabel:    reason,
+		dryRunLabel:    dryRunVal,
+	}).Inc()
+	m.gcReclaimedBytes.With(prometheus.Labels{
+		datastoreLabel: datastore,
+		dryRunLabel:    dryRunVal,
+	}).Add(float64(size))
+
+	logger.V(5).WithValues(
+		"datastore", datastore,
+		"reason", reason,
+		"size", size,
+		"dryRun", dryRun).Info("Set metrics for image cache eviction")
+}
+
+// RegisterReferences registers the number of virtual disks that use a cached
+// image directory as a parent.
+func (m *VMICacheMetrics) RegisterReferences(
+	logger logr.Logger,
+	datastore, dir string,
+	count int) {
+
+	labels := prometheus.Labels{
+		datastoreLabel: datastore,
+		dirLabel:       dir,
+	}
+	m.gcReferences.With(labels).Set(float64(count))
+
+	logger.V(5).WithValues("labels", labels, "count", count).Info(
+		"Set metrics for image cache references")
+}
+
+// DeleteReferences deletes the references metric for a cached image
+// directory.
+func (m *VMICacheMetrics) DeleteReferences(
+	logger logr.Logger,
+	datastore, dir string) {
+
+	labels := prometheus.Labels{
+		datastoreLabel: datastore,
+		dirLabel:       dir,
+	}
+	deleted := m.gcReferences.Delete(labels)
+
+	logger.V(5).WithValues("labels", labels, "deleted", deleted).Info(
+		"Delete image cache references metrics")
+}
@@ -7,6 +7,7 @@ package fake
 import (
 	"context"
 	"fmt"
+	"strings"
 	"sync"
 
 	"github.com/vmware/govmomi/vapi/library"
@@ -19,6 +20,8 @@ import (
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
 	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
 	"github.com/vmware-tanzu/vm-operator/pkg/providers"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/oci"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ova"
 	vsclient "github.com/vmware-tanzu/vm-operator/pkg/util/vsphere/client"
 )
 
@@ -35,14 +38,23 @@ type funcs struct {
 	DeleteVirtualMachineFn              func(ctx context.Context, vm *vmopv1.VirtualMachine) error
 	PublishVirtualMachineFn             func(ctx context.Context, vm *vmopv1.VirtualMachine,
 		vmPub *vmopv1.VirtualMachinePublishRequest, cl *imgregv1a1.ContentLibrary, actID string) (string, error)
+	PublishVirtualMachineToOCIFn func(ctx context.Context, vm *vmopv1.VirtualMachine,
+		vmPub *vmopv1.VirtualMachinePublishRequest, ociClient *oci.Client, ref oci.Reference) (string, error)
 	GetVirtualMachineGuestHeartbeatFn  func(ctx context.Context, vm *vmopv1.VirtualMachine) (vmopv1.GuestHeartbeatStatus, error)
 	GetVirtualMachinePropertiesFn      func(ctx context.Context, vm *vmopv1.VirtualMachine, propertyPaths []string) (map[string]any, error)
 	GetVirtualMachineWebMKSTicketFn    func(ctx context.Context, vm *vmopv1.VirtualMachine, pubKey string) (string, error)
 	GetVirtualMachineHardwareVersionFn func(ctx context.Context, vm *vmopv1.VirtualMachine) (vimtypes.HardwareVersion, error)
+	GetVirtualMachineImportSourceFn    func(ctx context.Context,
+		vmImport *vmopv1.VirtualMachineImportRequest) (*vmopv1.VirtualMachineImportRequestSourceStatus, error)
+	ImportVirtualMachineFn func(ctx context.Context, vmImport *vmopv1.VirtualMachineImportRequest) error
 
-	GetItemFromLibraryByNameFn func(ctx context.Context, contentLibrary, itemName string) (*library.Item, error)
-	UpdateContentLibraryItemFn func(ctx context.Context, itemID, newName string, newDescription *string) error
-	SyncVirtualMachineImageFn  func(ctx context.Context, cli, vmi client.Object) error
+	GetItemFromLibraryByNameFn   func(ctx context.Context, contentLibrary, itemName string) (*library.Item, error)
+	UpdateContentLibraryItemFn   func(ctx context.Context, itemID, newName string, newDescription *string) error
+	GetContentLibraryItemFilesFn func(ctx context.Context, itemID string) ([]library.File, error)
+	SyncVirtualMachineImageFn    func(ctx context.Context, cli, vmi client.Object) error
+
+	CreateContentLibraryItemFromOVAFn func(ctx context.Context, libraryUUID, itemName, itemDescription string,
+		ovaReader *ova.Reader) (string, error)
 
 	UpdateVcPNIDFn           func(ctx context.Context, vcPNID, vcPort string) error
 	UpdateVcCredsFn          func(ctx context.Context, data map[string][]byte) error
@@ -132,6 +144,28 @@ func (s *VMProvider) PublishVirtualMachine(
 	return "dummy-id", nil
 }
 
+func (s *VMProvider) PublishVirtualMachineToOCI(
+	ctx context.Context,
+	vm *vmopv1.VirtualMachine,
+	vmPub *vmopv1.VirtualMachinePublishRequest,
+	ociClient *oci.Client,
+	ref oci.Reference) (string, error) {
+
+	_ = pkgcfg.FromContext(ctx)
+
+	s.Lock()
+	s.isPublishVMCalled = true
+	fn := s.PublishVirtualMachineToOCIFn
+	s.Unlock()
+
+	// The lock is not held while calling fn since pushing to a registry may
+	// take a while and fn may push to a test registry.
+	if fn != nil {
+		return fn(ctx, vm, vmPub, ociClient, ref)
+	}
+	return "sha256:" + strings.Repeat("0", 64), nil
+}
+
 func (s *VMProvider) GetVirtualMachineGuestHeartbeat(ctx context.Context, vm *vmopv1.VirtualMachine) (vmopv1.GuestHeartbeatStatus, error) {
 	_ = pkgcfg.FromContext(ctx)
 
@@ -180,6 +214,34 @@ func (s *VMProvider) GetVirtualMachineHardwareVersion(ctx context.Context, vm *v
 	return vimtypes.VMX15, nil
 }
 
+func (s *VMProvider) GetVirtualMachineImportSource(
+	ctx context.Context,
+	vmImport *vmopv1.VirtualMachineImportRequest) (*vmopv1.VirtualMachineImportRequestSourceStatus, error) {
+
+	_ = pkgcfg.FromContext(ctx)
+
+	s.Lock()
+	defer s.Unlock()
+	if s.GetVirtualMachineImportSourceFn != nil {
+		return s.GetVirtualMachineImportSourceFn(ctx, vmImport)
+	}
+	return &vmopv1.VirtualMachineImportRequestSourceStatus{
+		ID:         vmImport.Spec.Source.ID,
+		PowerState: vmopv1.VirtualMachinePowerStateOn,
+	}, nil
+}
+
+func (s *VMProvider) ImportVirtualMachine(ctx context.Context, vmImport *vmopv1.VirtualMachineImportRequest) error {
+	_ = pkgcfg.FromContext(ctx)
+
+	s.Lock()
+	defer s.Unlock()
+	if s.ImportVirtualMachineFn != nil {
+		return s.ImportVirtualMachineFn(ctx, vmImport)
+	}
+	return nil
+}
+
 func (s *VMProvider) CreateOrUpdateVirtualMachineSetResourcePolicy(ctx context.Context, resourcePolicy *vmopv1.VirtualMachineSetResourcePolicy) error {
 	_ = pkgcfg.FromContext(ctx)
 
@@ -273,6 +335,32 @@ func (s *VMProvider) UpdateContentLibraryItem(ctx context.Context, itemID, newNa
 	return nil
 }
 
+func (s *VMProvider) GetContentLibraryItemFiles(ctx context.Context, itemID string) ([]library.File, error) {
+	_ = pkgcfg.FromContext(ctx)
+
+	s.Lock()
+	defer s.Unlock()
+	if s.GetContentLibraryItemFilesFn != nil {
+		return s.GetContentLibraryItemFilesFn(ctx, itemID)
+	}
+	return nil, nil
+}
+
+func (s *VMProvider) CreateContentLibraryItemFromOVA(
+	ctx context.Context,
+	libraryUUID, itemName, itemDescription string,
+	ovaReader *ova.Reader) (string, error) {
+
+	_ = pkgcfg.FromContext(ctx)
+
+	s.Lock()
+	defer s.Unlock()
+	if s.CreateContentLibraryItemFromOVAFn != nil {
+		return s.CreateContentLibraryItemFromOVAFn(ctx, libraryUUID, itemName, itemDescription, ovaReader)
+	}
+	return "", nil
+}
+
 func (s *VMProvider) GetTasksByActID(ctx context.Context, actID string) (tasksInfo []vimtypes.TaskInfo, retErr error) {
 	_ = pkgcfg.FromContext(ctx)
 
@@ -15,6 +15,8 @@ import (
 	imgregv1a1 "github.com/vmware-tanzu/image-registry-operator-api/api/v1alpha1"
 
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/oci"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ova"
 	"github.com/vmware-tanzu/vm-operator/pkg/util/vsphere/client"
 )
 
@@ -28,6 +30,26 @@ var (
 	// CreateOrUpdateVirtualMachine and DeleteVirtualMachine functions when
 	// the VM is still being reconciled in a background thread.
 	ErrReconcileInProgress = errors.New("reconcile already in progress")
+
+	// ErrVMImportSourceNotFound is returned from the
+	// GetVirtualMachineImportSource function when the vSphere VM to be
+	// imported does not exist.
+	ErrVMImportSourceNotFound = errors.New("vm to import not found")
+
+	// ErrVMImportSourceManaged is returned from the
+	// GetVirtualMachineImportSource function when the vSphere VM to be
+	// imported is a template or is already managed by VM Operator.
+	ErrVMImportSourceManaged = errors.New("vm to import is a template or already managed")
+
+	// ErrVMImportSourceNoZone is returned from the
+	// GetVirtualMachineImportSource function when the vSphere VM to be
+	// imported is not on a cluster that belongs to a zone.
+	ErrVMImportSourceNoZone = errors.New("vm to import is not in a zone")
+
+	// ErrVMImportSourceNotPoweredOff is returned from the
+	// GetVirtualMachineImportSource function when the vSphere VM to be
+	// imported is not powered off.
+	ErrVMImportSourceNotPoweredOff = errors.New("vm to import is not powered off")
 )
 
 // VirtualMachineProviderInterface is a pluggable interface for VM Providers.
@@ -37,11 +59,22 @@ type VirtualMachineProviderInterface interface {
 	DeleteVirtualMachine(ctx context.Context, vm *vmopv1.VirtualMachine) error
 	PublishVirtualMachine(ctx context.Context, vm *vmopv1.VirtualMachine,
 		vmPub *vmopv1.VirtualMachinePublishRequest, cl *imgregv1a1.ContentLibrary, actID string) (string, error)
+	PublishVirtualMachineToOCI(ctx context.Context, vm *vmopv1.VirtualMachine,
+		vmPub *vmopv1.VirtualMachinePublishRequest, ociClient *oci.Client, ref oci.Reference) (string, error)
 	GetVirtualMachineGuestHeartbeat(ctx context.Context, vm *vmopv1.VirtualMachine) (vmopv1.GuestHeartbeatStatus, error)
 	GetVirtualMachineProperties(ctx context.Context, vm *vmopv1.VirtualMachine, propertyPaths []string) (map[string]any, error)
 	GetVirtualMachineWebMKSTicket(ctx context.Context, vm *vmopv1.VirtualMachine, pubKey string) (string, error)
 	GetVirtualMachineHardwareVersion(ctx context.Context, vm *vmopv1.VirtualMachine) (vimtypes.HardwareVersion, error)
 
+	// GetVirtualMachineImportSource returns the observed state of the vSphere
+	// VM to be imported by the request.
+	GetVirtualMachineImportSource(ctx context.Context,
+		vmImport *vmopv1.VirtualMachineImportRequest) (*vmopv1.VirtualMachineImportRequestSourceStatus, error)
+	// ImportVirtualMachine moves the vSphere VM described by the request's
+	// status into the namespace's folder and resource pool, detaches its data
+	// disks, and marks it as managed by VM Operator.
+	ImportVirtualMachine(ctx context.Context, vmImport *vmopv1.VirtualMachineImportRequest) error
+
 	CreateOrUpdateVirtualMachineSetResourcePolicy(ctx context.Context, resourcePolicy *vmopv1.VirtualMachineSetResourcePolicy) error
 	DeleteVirtualMachineSetResourcePolicy(ctx context.Context, resourcePolicy *vmopv1.VirtualMachineSetResourcePolicy) error
 
@@ -52,6 +85,12 @@ type VirtualMachineProviderInterface interface {
 
 	GetItemFromLibraryByName(ctx context.Context, contentLibrary, itemName string) (*library.Item, error)
 	UpdateContentLibraryItem(ctx context.Context, itemID, newName string, newDescription *string) error
+	GetContentLibraryItemFiles(ctx context.Context, itemID string) ([]library.File, error)
+	// CreateContentLibraryItemFromOVA creates an OVF library item in the
+	// content library from the files read from the OVA and returns the ID of
+	// the item.
+	CreateContentLibraryItemFromOVA(ctx context.Context, libraryUUID, itemName, itemDescription string,
+		ovaReader *ova.Reader) (string, error)
 	SyncVirtualMachineImage(ctx context.Context, cli, vmi ctrlclient.Object) error
 
 	GetTasksByActID(ctx context.Context, actID string) (tasksInfo []vimtypes.TaskInfo, retErr error)
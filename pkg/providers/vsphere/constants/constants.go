@@ -63,11 +63,31 @@ const (
 	CloudInitTypeAnnotation         = pkg.VMOperatorKey + "/cloudinit-type"
 	CloudInitTypeValueCloudInitPrep = "cloudinitprep"
 	CloudInitTypeValueGuestInfo     = "guestinfo"
+	CloudInitTypeValueNoCloud       = "nocloud"
 
-	CloudInitGuestInfoMetadata         = "guestinfo.metadata"
-	CloudInitGuestInfoMetadataEncoding = "guestinfo.metadata.encoding"
-	CloudInitGuestInfoUserdata         = "guestinfo.userdata"
-	CloudInitGuestInfoUserdataEncoding = "guestinfo.userdata.encoding"
+	// CloudInitNoCloudSeedISOFileName is the name of the NoCloud seed ISO
+	// uploaded to the VM's directory.
+	CloudInitNoCloudSeedISOFileName = "cidata.iso"
+
+	// CloudInitNoCloudSeedExtraConfigKey is the ExtraConfig key used to record
+	// the checksum of the NoCloud seed ISO and whether it has been detached
+	// from and removed after the guest booted.
+	CloudInitNoCloudSeedExtraConfigKey = "vmservice.cloudinit.nocloud.seed"
+
+	CloudInitGuestInfoMetadata           = "guestinfo.metadata"
+	CloudInitGuestInfoMetadataEncoding   = "guestinfo.metadata.encoding"
+	CloudInitGuestInfoUserdata           = "guestinfo.userdata"
+	CloudInitGuestInfoUserdataEncoding   = "guestinfo.userdata.encoding"
+	CloudInitGuestInfoVendordata         = "guestinfo.vendordata"
+	CloudInitGuestInfoVendordataEncoding = "guestinfo.vendordata.encoding"
+
+	IgnitionGuestInfoConfigData         = "guestinfo.ignition.config.data"
+	IgnitionGuestInfoConfigDataEncoding = "guestinfo.ignition.config.data.encoding"
+
+	// AfterburnGuestInfoNetworkKargs is the key read by Afterburn for the
+	// network kernel arguments used to configure the guest's network in the
+	// initramfs.
+	AfterburnGuestInfoNetworkKargs = "guestinfo.afterburn.initrd.network-kargs"
 
 	// CloudInitGuestInfoLocalIPv4Key and CloudInitGuestInfoLocalIPv6Key are the local IPs
 	// reported by the VMware datasource: https://bit.ly/3NJB534.
@@ -176,4 +196,28 @@ const (
 	V1alpha4SubnetMask = "V1alpha4_SubnetMask"
 	// V1alpha4FormatNameservers is an alias for versioned templating function V1alpha4_FormatNameservers.
 	V1alpha4FormatNameservers = "V1alpha4_FormatNameservers"
+	// V1alpha4SubnetNetwork is an alias for versioned templating function V1alpha4_SubnetNetwork.
+	V1alpha4SubnetNetwork = "V1alpha4_SubnetNetwork"
+	// V1alpha4SubnetBroadcast is an alias for versioned templating function V1alpha4_SubnetBroadcast.
+	V1alpha4SubnetBroadcast = "V1alpha4_SubnetBroadcast"
+	// V1alpha4SubnetPrefix is an alias for versioned templating function V1alpha4_SubnetPrefix.
+	V1alpha4SubnetPrefix = "V1alpha4_SubnetPrefix"
+	// V1alpha4SubnetNthHost is an alias for versioned templating function V1alpha4_SubnetNthHost.
+	V1alpha4SubnetNthHost = "V1alpha4_SubnetNthHost"
+	// V1alpha4GatewayFromNIC is an alias for versioned templating function V1alpha4_GatewayFromNIC.
+	V1alpha4GatewayFromNIC = "V1alpha4_GatewayFromNIC"
+	// V1alpha4RoutesFromNIC is an alias for versioned templating function V1alpha4_RoutesFromNIC.
+	V1alpha4RoutesFromNIC = "V1alpha4_RoutesFromNIC"
+	// V1alpha4Label is an alias for versioned templating function V1alpha4_Label.
+	V1alpha4Label = "V1alpha4_Label"
+	// V1alpha4Annotation is an alias for versioned templating function V1alpha4_Annotation.
+	V1alpha4Annotation = "V1alpha4_Annotation"
+	// V1alpha4ZoneName is an alias for versioned templating function V1alpha4_ZoneName.
+	V1alpha4ZoneName = "V1alpha4_ZoneName"
+	// V1alpha4InstanceUUID is an alias for versioned templating function V1alpha4_InstanceUUID.
+	V1alpha4InstanceUUID = "V1alpha4_InstanceUUID"
+	// V1alpha4BiosUUID is an alias for versioned templating function V1alpha4_BiosUUID.
+	V1alpha4BiosUUID = "V1alpha4_BiosUUID"
+	// V1alpha4SecretValue is an alias for versioned templating function V1alpha4_SecretValue.
+	V1alpha4SecretValue = "V1alpha4_SecretValue"
 )
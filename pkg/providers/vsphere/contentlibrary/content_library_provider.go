@@ -6,6 +6,7 @@ package contentlibrary
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/url"
@@ -26,6 +27,7 @@ import (
 
 	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
 	"github.com/vmware-tanzu/vm-operator/pkg/util"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ova"
 )
 
 type Provider interface {
@@ -39,7 +41,9 @@ type Provider interface {
 	RetrieveOvfEnvelopeByLibraryItemID(ctx context.Context, itemID string) (*ovf.Envelope, error)
 	SyncLibraryItem(ctx context.Context, item *library.Item, force bool) error
 	ListLibraryItemStorage(ctx context.Context, itemID string) ([]library.Storage, error)
+	ListLibraryItemFiles(ctx context.Context, itemID string) ([]library.File, error)
 	ResolveLibraryItemStorage(ctx context.Context, datacenter *object.Datacenter, storage []library.Storage) error
+	CreateLibraryItemFromOVA(ctx context.Context, libraryItem library.Item, ovaReader *ova.Reader) (string, error)
 
 	// TODO: Testing only. Remove these from this file.
 	CreateLibraryItem(ctx context.Context, libraryItem library.Item, path string) error
@@ -54,16 +58,6 @@ const (
 	DefaultContentLibAPIWaitSecs = 5
 )
 
-func IsSupportedDeployType(t string) bool {
-	switch t {
-	case library.ItemTypeVMTX, library.ItemTypeOVF:
-		// Keep in sync with what cloneVMFromContentLibrary() handles.
-		return true
-	default:
-		return false
-	}
-}
-
 func NewProvider(ctx context.Context, restClient *rest.Client) Provider {
 	var waitSeconds int
 	if w := pkgcfg.FromContext(ctx).ContentAPIWait; w > 0 {
@@ -337,7 +331,7 @@ func (cs *provider) generateDownloadURLForLibraryItem(
 	var fileToDownload string
 	for _, file := range files {
 		logger.V(4).Info("Library Item file", "fileName", file.Name)
-		if ext := filepath.Ext(file.Name); ext != "" && IsSupportedDeployType(ext[1:]) {
+		if ext := filepath.Ext(file.Name); ext != "" && ext[1:] == library.ItemTypeOVF {
 			fileToDownload = file.Name
 			break
 		}
@@ -403,6 +397,13 @@ func (cs *provider) ListLibraryItemStorage(
 	return cs.libMgr.ListLibraryItemStorage(ctx, itemID)
 }
 
+func (cs *provider) ListLibraryItemFiles(
+	ctx context.Context,
+	itemID string) ([]library.File, error) {
+
+	return cs.libMgr.ListLibraryItemFiles(ctx, itemID)
+}
+
 func (cs *provider) ResolveLibraryItemStorage(
 	ctx context.Context,
 	datacenter *object.Datacenter,
@@ -421,3 +422,88 @@ func (cs *provider) ResolveLibraryItemStorage(
 
 	return nil
 }
+
+// CreateLibraryItemFromOVA creates an OVF library item from the files read
+// from the OVA and returns the ID of the item. The item's update session is
+// failed and the item is deleted if any of the files cannot be uploaded or do
+// not match the checksums in the OVA's manifest.
+func (cs *provider) CreateLibraryItemFromOVA(
+	ctx context.Context,
+	libraryItem library.Item,
+	ovaReader *ova.Reader) (string, error) {
+
+	logger := log.WithValues("libraryID", libraryItem.LibraryID, "itemName", libraryItem.Name)
+	logger.Info("Creating Library Item from OVA")
+
+	libraryItem.Type = library.ItemTypeOVF
+	itemID, err := cs.libMgr.CreateLibraryItem(ctx, libraryItem)
+	if err != nil {
+		return "", fmt.Errorf("failed to create library item: %w", err)
+	}
+
+	if err := cs.uploadOVA(ctx, itemID, ovaReader); err != nil {
+		if delErr := cs.libMgr.DeleteLibraryItem(ctx, &library.Item{ID: itemID}); delErr != nil {
+			logger.Error(delErr, "failed to delete library item", "itemID", itemID)
+		}
+		return "", err
+	}
+
+	return itemID, nil
+}
+
+func (cs *provider) uploadOVA(
+	ctx context.Context,
+	itemID string,
+	ovaReader *ova.Reader) (retErr error) {
+
+	sessionID, err := cs.libMgr.CreateLibraryItemUpdateSession(ctx, library.Session{LibraryItemID: itemID})
+	if err != nil {
+		return fmt.Errorf("failed to create library item update session: %w", err)
+	}
+
+	defer func() {
+		if retErr != nil {
+			if err := cs.libMgr.FailLibraryItemUpdateSession(ctx, sessionID); err != nil {
+				log.Error(err, "failed to fail library item update session", "sessionID", sessionID)
+			}
+		}
+	}()
+
+	for {
+		f, err := ovaReader.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				break
+			}
+			return err
+		}
+
+		update, err := cs.libMgr.AddLibraryItemFile(ctx, sessionID, library.UpdateFile{
+			Name:       f.Name,
+			SourceType: "PUSH",
+			Size:       f.Size,
+			Checksum:   f.Checksum,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to add library item file %s: %w", f.Name, err)
+		}
+
+		u, err := url.Parse(update.UploadEndpoint.URI)
+		if err != nil {
+			return err
+		}
+
+		p := soap.DefaultUpload
+		p.ContentLength = f.Size
+
+		if err := cs.libMgr.Client.Upload(ctx, f, u, &p); err != nil {
+			return fmt.Errorf("failed to upload library item file %s: %w", f.Name, err)
+		}
+	}
+
+	if err := cs.libMgr.CompleteLibraryItemUpdateSession(ctx, sessionID); err != nil {
+		return fmt.Errorf("failed to complete library item update session: %w", err)
+	}
+
+	return cs.libMgr.WaitOnLibraryItemUpdateSession(ctx, sessionID, cs.retryInterval, nil)
+}
@@ -5,6 +5,11 @@
 package contentlibrary_test
 
 import (
+	"archive/tar"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
 	"os"
 	"strings"
 
@@ -15,6 +20,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/contentlibrary"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ova"
 	"github.com/vmware-tanzu/vm-operator/test/builder"
 )
 
@@ -135,5 +141,92 @@ func clTests() {
 				Expect(ovfEnvelope).To(BeNil())
 			})
 		})
+
+		Context("CreateLibraryItemFromOVA", func() {
+			const (
+				ovfData   = "<Envelope/>"
+				nvramData = "nvram-data"
+			)
+
+			var (
+				libItem library.Item
+				mfData  string
+			)
+
+			// The OVA does not include a disk since vC Sim parses the VMDK files
+			// uploaded to a library item.
+			newOVAReader := func() *ova.Reader {
+				var buf bytes.Buffer
+				tw := tar.NewWriter(&buf)
+				for _, f := range [][2]string{
+					{"my-image.ovf", ovfData},
+					{"my-image.mf", mfData},
+					{"my-image.nvram", nvramData},
+				} {
+					Expect(tw.WriteHeader(&tar.Header{
+						Name:     f[0],
+						Mode:     0600,
+						Size:     int64(len(f[1])),
+						Typeflag: tar.TypeReg,
+					})).To(Succeed())
+					_, err := tw.Write([]byte(f[1]))
+					Expect(err).ToNot(HaveOccurred())
+				}
+				Expect(tw.Close()).To(Succeed())
+				return ova.NewReader(&buf)
+			}
+
+			sha256Line := func(name, data string) string {
+				sum := sha256.Sum256([]byte(data))
+				return fmt.Sprintf("SHA256(%s)= %s\n", name, hex.EncodeToString(sum[:]))
+			}
+
+			BeforeEach(func() {
+				mfData = sha256Line("my-image.ovf", ovfData) +
+					sha256Line("my-image.nvram", nvramData)
+			})
+
+			JustBeforeEach(func() {
+				libItem = library.Item{
+					Name:      "my-uploaded-image",
+					LibraryID: ctx.LocalContentLibraryID,
+				}
+			})
+
+			It("creates the item with the files from the OVA", func() {
+				itemID, err := clProvider.CreateLibraryItemFromOVA(ctx, libItem, newOVAReader())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(itemID).ToNot(BeEmpty())
+
+				item, err := clProvider.GetLibraryItem(ctx, ctx.LocalContentLibraryID, libItem.Name, true)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(item.ID).To(Equal(itemID))
+				Expect(item.Type).To(Equal(library.ItemTypeOVF))
+
+				files, err := clProvider.ListLibraryItemFiles(ctx, itemID)
+				Expect(err).ToNot(HaveOccurred())
+				names := make([]string, len(files))
+				for i := range files {
+					names[i] = files[i].Name
+				}
+				Expect(names).To(ContainElements("my-image.ovf", "my-image.nvram"))
+			})
+
+			When("a file does not match the manifest", func() {
+				BeforeEach(func() {
+					mfData = sha256Line("my-image.ovf", ovfData) +
+						sha256Line("my-image.nvram", "other-data")
+				})
+
+				It("returns an error and deletes the item", func() {
+					_, err := clProvider.CreateLibraryItemFromOVA(ctx, libItem, newOVAReader())
+					Expect(err).To(MatchError(ova.ErrChecksumMismatch))
+
+					item, err := clProvider.GetLibraryItem(ctx, ctx.LocalContentLibraryID, libItem.Name, false)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(item).To(BeNil())
+				})
+			})
+		})
 	})
 }
@@ -5,6 +5,8 @@
 package network
 
 import (
+	"slices"
+
 	"github.com/vmware/govmomi/object"
 	"github.com/vmware/govmomi/vim25/mo"
 	vimtypes "github.com/vmware/govmomi/vim25/types"
@@ -56,6 +58,84 @@ func MapEthernetDevicesToSpecIdx(
 
 	return devKeyToSpecIdx
 }
+
+// EthCardMatchesInterfaceType returns true if the ethernet card is of the
+// interface type. Any card matches when the interface type is not set, so
+// the cards from the VM Class are used as-is.
+func EthCardMatchesInterfaceType(
+	dev vimtypes.BaseVirtualDevice,
+	interfaceType vmopv1.VirtualMachineNetworkInterfaceType) bool {
+
+	switch interfaceType {
+	case "":
+		return true
+	case vmopv1.VirtualMachineNetworkInterfaceTypeVmxnet3:
+		_, ok := dev.(*vimtypes.VirtualVmxnet3)
+		return ok
+	case vmopv1.VirtualMachineNetworkInterfaceTypeE1000e:
+		_, ok := dev.(*vimtypes.VirtualE1000e)
+		return ok
+	case vmopv1.VirtualMachineNetworkInterfaceTypeSRIOV:
+		sriovCard, ok := dev.(*vimtypes.VirtualSriovEthernetCard)
+		return ok && sriovCard.DvxBackingInfo == nil
+	case vmopv1.VirtualMachineNetworkInterfaceTypeDVX:
+		sriovCard, ok := dev.(*vimtypes.VirtualSriovEthernetCard)
+		return ok && sriovCard.DvxBackingInfo != nil
+	default:
+		return false
+	}
+}
+
+// LockMemoryReservationForSRIOV sets the ConfigSpec's
+// MemoryReservationLockedToMax when the VM has, or the ConfigSpec adds, an
+// SR-IOV or DVX ethernet card. Like PCI passthrough devices, these cards
+// require all of the VM's memory to be reserved. The config may be nil when
+// the VM is being created.
+func LockMemoryReservationForSRIOV(
+	config *vimtypes.VirtualMachineConfigInfo,
+	configSpec *vimtypes.VirtualMachineConfigSpec) {
+
+	if config != nil && config.MemoryReservationLockedToMax != nil && *config.MemoryReservationLockedToMax {
+		return
+	}
+
+	hasSRIOV := false
+
+	removed := map[int32]struct{}{}
+	for _, bdc := range configSpec.DeviceChange {
+		dc := bdc.GetVirtualDeviceConfigSpec()
+		if dc == nil || dc.Device == nil {
+			continue
+		}
+		if _, ok := dc.Device.(*vimtypes.VirtualSriovEthernetCard); !ok {
+			continue
+		}
+		switch dc.Operation {
+		case vimtypes.VirtualDeviceConfigSpecOperationRemove:
+			removed[dc.Device.GetVirtualDevice().Key] = struct{}{}
+		default:
+			hasSRIOV = true
+		}
+	}
+
+	if !hasSRIOV && config != nil {
+		for _, dev := range config.Hardware.Device {
+			if _, ok := dev.(*vimtypes.VirtualSriovEthernetCard); !ok {
+				continue
+			}
+			if _, ok := removed[dev.GetVirtualDevice().Key]; !ok {
+				hasSRIOV = true
+				break
+			}
+		}
+	}
+
+	if hasSRIOV {
+		locked := true
+		configSpec.MemoryReservationLockedToMax = &locked
+	}
+}
+
 func findMatchingEthCardForInterfaceSpec(
 	vmCtx pkgctx.VirtualMachineContext,
 	client ctrlclient.Client,
@@ -64,15 +144,24 @@ func findMatchingEthCardForInterfaceSpec(
 
 	matchingIdx := -1
 
+	// Only consider the cards that are of the interface's type.
+	candidates := ethCards.Select(func(dev vimtypes.BaseVirtualDevice) bool {
+		return EthCardMatchesInterfaceType(dev, interfaceSpec.Type)
+	})
+
 	switch pkgcfg.FromContext(vmCtx).NetworkProviderType {
 	case pkgcfg.NetworkProviderTypeVDS:
-		matchingIdx = findMatchingEthCardVDS(vmCtx, client, interfaceSpec, ethCards)
+		matchingIdx = findMatchingEthCardVDS(vmCtx, client, interfaceSpec, candidates)
 	case pkgcfg.NetworkProviderTypeNSXT:
-		matchingIdx = findMatchingEthCardNSXT(vmCtx, client, interfaceSpec, ethCards)
+		matchingIdx = findMatchingEthCardNSXT(vmCtx, client, interfaceSpec, candidates)
 	case pkgcfg.NetworkProviderTypeVPC:
-		matchingIdx = findMatchingEthCardVPC(vmCtx, client, interfaceSpec, ethCards)
+		matchingIdx = findMatchingEthCardVPC(vmCtx, client, interfaceSpec, candidates)
 	case pkgcfg.NetworkProviderTypeNamed:
-		matchingIdx = findMatchingEthCardNamed(vmCtx, client, interfaceSpec, ethCards)
+		matchingIdx = findMatchingEthCardNamed(vmCtx, client, interfaceSpec, candidates)
+	}
+
+	if matchingIdx >= 0 {
+		matchingIdx = slices.Index(ethCards, candidates[matchingIdx])
 	}
 
 	return matchingIdx
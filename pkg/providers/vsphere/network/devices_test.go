@@ -315,5 +315,148 @@ var _ = Describe("MapEthernetDevicesToSpecIdx", func() {
 				})
 			})
 		})
+
+		Context("Named", func() {
+			BeforeEach(func() {
+				pkgcfg.SetContext(vmCtx, func(config *pkgcfg.Config) {
+					config.NetworkProviderType = pkgcfg.NetworkProviderTypeNamed
+				})
+			})
+
+			Context("Matches interface type", func() {
+				BeforeEach(func() {
+					vmCtx.VM.Spec.Network.Interfaces = []vmopv1.VirtualMachineNetworkInterfaceSpec{
+						{
+							Name: "eth0",
+							Network: &vmopv1common.PartialObjectRef{
+								Name: "VM Network",
+							},
+							Type: vmopv1.VirtualMachineNetworkInterfaceTypeSRIOV,
+						},
+						{
+							Name: "eth1",
+							Network: &vmopv1common.PartialObjectRef{
+								Name: "VM Network",
+							},
+						},
+					}
+
+					backing := &vimtypes.VirtualEthernetCardNetworkBackingInfo{
+						VirtualDeviceDeviceBackingInfo: vimtypes.VirtualDeviceDeviceBackingInfo{
+							DeviceName: "VM Network",
+						},
+					}
+
+					vmxnet3 := &vimtypes.VirtualVmxnet3{}
+					vmxnet3.Key = 4000
+					vmxnet3.Backing = backing
+					sriov := &vimtypes.VirtualSriovEthernetCard{}
+					sriov.Key = 4001
+					sriov.Backing = backing
+					devices = append(devices, vmxnet3, sriov)
+				})
+
+				It("returns expected mapping", func() {
+					Expect(devKeyToIdx).To(HaveLen(2))
+					Expect(devKeyToIdx).To(HaveKeyWithValue(int32(4000), 1))
+					Expect(devKeyToIdx).To(HaveKeyWithValue(int32(4001), 0))
+				})
+			})
+		})
+	})
+})
+
+var _ = Describe("LockMemoryReservationForSRIOV", func() {
+
+	var (
+		config     *vimtypes.VirtualMachineConfigInfo
+		configSpec *vimtypes.VirtualMachineConfigSpec
+	)
+
+	BeforeEach(func() {
+		config = nil
+		configSpec = &vimtypes.VirtualMachineConfigSpec{}
+	})
+
+	JustBeforeEach(func() {
+		network.LockMemoryReservationForSRIOV(config, configSpec)
+	})
+
+	When("the ConfigSpec adds an SR-IOV card", func() {
+		BeforeEach(func() {
+			configSpec.DeviceChange = []vimtypes.BaseVirtualDeviceConfigSpec{
+				&vimtypes.VirtualDeviceConfigSpec{
+					Operation: vimtypes.VirtualDeviceConfigSpecOperationAdd,
+					Device:    &vimtypes.VirtualSriovEthernetCard{},
+				},
+			}
+		})
+
+		It("locks the memory reservation", func() {
+			Expect(configSpec.MemoryReservationLockedToMax).To(HaveValue(BeTrue()))
+		})
+
+		When("the VM's memory reservation is already locked", func() {
+			BeforeEach(func() {
+				locked := true
+				config = &vimtypes.VirtualMachineConfigInfo{
+					MemoryReservationLockedToMax: &locked,
+				}
+			})
+
+			It("does not change the memory reservation", func() {
+				Expect(configSpec.MemoryReservationLockedToMax).To(BeNil())
+			})
+		})
+	})
+
+	When("the ConfigSpec adds a vmxnet3 card", func() {
+		BeforeEach(func() {
+			configSpec.DeviceChange = []vimtypes.BaseVirtualDeviceConfigSpec{
+				&vimtypes.VirtualDeviceConfigSpec{
+					Operation: vimtypes.VirtualDeviceConfigSpecOperationAdd,
+					Device:    &vimtypes.VirtualVmxnet3{},
+				},
+			}
+		})
+
+		It("does not change the memory reservation", func() {
+			Expect(configSpec.MemoryReservationLockedToMax).To(BeNil())
+		})
+	})
+
+	When("the VM has an SR-IOV card", func() {
+		BeforeEach(func() {
+			config = &vimtypes.VirtualMachineConfigInfo{
+				Hardware: vimtypes.VirtualHardware{
+					Device: []vimtypes.BaseVirtualDevice{
+						&vimtypes.VirtualSriovEthernetCard{
+							VirtualEthernetCard: vimtypes.VirtualEthernetCard{
+								VirtualDevice: vimtypes.VirtualDevice{Key: 4000},
+							},
+						},
+					},
+				},
+			}
+		})
+
+		It("locks the memory reservation", func() {
+			Expect(configSpec.MemoryReservationLockedToMax).To(HaveValue(BeTrue()))
+		})
+
+		When("the ConfigSpec removes the card", func() {
+			BeforeEach(func() {
+				configSpec.DeviceChange = []vimtypes.BaseVirtualDeviceConfigSpec{
+					&vimtypes.VirtualDeviceConfigSpec{
+						Operation: vimtypes.VirtualDeviceConfigSpecOperationRemove,
+						Device:    config.Hardware.Device[0],
+					},
+				}
+			})
+
+			It("does not change the memory reservation", func() {
+				Expect(configSpec.MemoryReservationLockedToMax).To(BeNil())
+			})
+		})
 	})
 })
@@ -0,0 +1,221 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package network
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	ipamv1 "github.com/vmware-tanzu/vm-operator/external/ipam/api/v1beta1"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+)
+
+// IPAddressClaimName returns the name to be used for the IPAddressClaim CR
+// that allocates an address from the interface's pool at the given index.
+func IPAddressClaimName(vmName, interfaceName string, poolIdx int) string {
+	return fmt.Sprintf("%s-%s-%d", vmName, interfaceName, poolIdx)
+}
+
+// applyIPAddressClaimsToResult claims an IP address from each of the pools
+// referenced by the InterfaceSpec and uses the allocated addresses as the
+// IPConfigs for the result. Like the InterfaceSpec's Addresses field, the
+// pool addresses take precedence over any IPAM done by the network provider.
+func applyIPAddressClaimsToResult(
+	vmCtx pkgctx.VirtualMachineContext,
+	client ctrlclient.Client,
+	networkType pkgcfg.NetworkProviderType,
+	interfaceSpec *vmopv1.VirtualMachineNetworkInterfaceSpec,
+	result *NetworkInterfaceResult) error {
+
+	switch networkType {
+	case pkgcfg.NetworkProviderTypeVDS, pkgcfg.NetworkProviderTypeNamed:
+	default:
+		return fmt.Errorf("addressesFromPools is not supported for network provider %q", networkType)
+	}
+
+	ipConfigs := make([]NetworkInterfaceIPConfig, 0, len(interfaceSpec.AddressesFromPools))
+
+	for i := range interfaceSpec.AddressesFromPools {
+		ipConfig, err := createIPAddressClaim(
+			vmCtx,
+			client,
+			IPAddressClaimName(vmCtx.VM.Name, interfaceSpec.Name, i),
+			interfaceSpec.AddressesFromPools[i])
+		if err != nil {
+			return err
+		}
+
+		ipConfigs = append(ipConfigs, ipConfig)
+	}
+
+	result.IPConfigs = ipConfigs
+
+	return nil
+}
+
+func createIPAddressClaim(
+	vmCtx pkgctx.VirtualMachineContext,
+	client ctrlclient.Client,
+	name string,
+	poolRef corev1.TypedLocalObjectReference) (NetworkInterfaceIPConfig, error) {
+
+	claim := &ipamv1.IPAddressClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: vmCtx.VM.Namespace,
+		},
+	}
+
+	_, err := controllerutil.CreateOrPatch(vmCtx, client, claim, func() error {
+		if err := SetNetworkInterfaceOwnerRef(vmCtx.VM, claim, client.Scheme()); err != nil {
+			return err
+		}
+
+		if claim.Labels == nil {
+			claim.Labels = map[string]string{}
+		}
+		claim.Labels[VMNameLabel] = vmCtx.VM.Name
+
+		claim.Spec.PoolRef = poolRef
+		return nil
+	})
+
+	if err != nil {
+		return NetworkInterfaceIPConfig{}, fmt.Errorf("failed to create ip address claim %s: %w", name, err)
+	}
+
+	ipAddr, err := waitForBoundIPAddressClaim(vmCtx, client, claim.Name)
+	if err != nil {
+		return NetworkInterfaceIPConfig{}, err
+	}
+
+	return ipAddressToIPConfig(ipAddr)
+}
+
+func waitForBoundIPAddressClaim(
+	vmCtx pkgctx.VirtualMachineContext,
+	client ctrlclient.Client,
+	name string) (*ipamv1.IPAddress, error) {
+
+	claim := &ipamv1.IPAddressClaim{}
+	claimKey := types.NamespacedName{Namespace: vmCtx.VM.Namespace, Name: name}
+
+	// TODO: Watch() this type instead.
+	err := wait.PollUntilContextTimeout(vmCtx, retryInterval, RetryTimeout, true, func(_ context.Context) (bool, error) {
+		if err := client.Get(vmCtx, claimKey, claim); err != nil {
+			return false, ctrlclient.IgnoreNotFound(err)
+		}
+
+		return claim.Status.AddressRef.Name != "", nil
+	})
+
+	if err != nil {
+		if wait.Interrupted(err) {
+			// Try to return a more meaningful error when timed out.
+			for _, cond := range claim.Status.Conditions {
+				if cond.Type == ipamv1.ReadyCondition && cond.Status == corev1.ConditionFalse {
+					return nil, fmt.Errorf("ip address claim %s is not ready: %s - %s", name, cond.Reason, cond.Message)
+				}
+			}
+			return nil, fmt.Errorf("ip address claim %s is not bound yet", name)
+		}
+
+		return nil, err
+	}
+
+	ipAddr := &ipamv1.IPAddress{}
+	ipAddrKey := types.NamespacedName{Namespace: vmCtx.VM.Namespace, Name: claim.Status.AddressRef.Name}
+	if err := client.Get(vmCtx, ipAddrKey, ipAddr); err != nil {
+		return nil, fmt.Errorf("failed to get ip address %s for claim %s: %w", ipAddrKey.Name, name, err)
+	}
+
+	return ipAddr, nil
+}
+
+// ipAddressToIPConfig returns the IPConfig for the allocated IPAddress.
+func ipAddressToIPConfig(ipAddr *ipamv1.IPAddress) (NetworkInterfaceIPConfig, error) {
+	ip := net.ParseIP(ipAddr.Spec.Address)
+	if ip == nil {
+		return NetworkInterfaceIPConfig{},
+			fmt.Errorf("ip address %s has invalid address %q", ipAddr.Name, ipAddr.Spec.Address)
+	}
+
+	isIPv4 := ip.To4() != nil
+
+	bits := net.IPv6len * 8
+	if isIPv4 {
+		bits = net.IPv4len * 8
+	}
+	if ipAddr.Spec.Prefix < 0 || ipAddr.Spec.Prefix > bits {
+		return NetworkInterfaceIPConfig{},
+			fmt.Errorf("ip address %s has invalid prefix %d", ipAddr.Name, ipAddr.Spec.Prefix)
+	}
+
+	return NetworkInterfaceIPConfig{
+		IPCIDR:  fmt.Sprintf("%s/%d", ipAddr.Spec.Address, ipAddr.Spec.Prefix),
+		IsIPv4:  isIPv4,
+		Gateway: ipAddr.Spec.Gateway,
+	}, nil
+}
+
+// HasAddressesFromPools returns true if any of the VM's network interfaces
+// allocate addresses from IPAM pools.
+func HasAddressesFromPools(vm *vmopv1.VirtualMachine) bool {
+	if vm.Spec.Network == nil {
+		return false
+	}
+	for i := range vm.Spec.Network.Interfaces {
+		if len(vm.Spec.Network.Interfaces[i].AddressesFromPools) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// ReleaseIPAddressClaims deletes the IPAddressClaims created for the VM's
+// network interfaces so the addresses are returned to their pools.
+func ReleaseIPAddressClaims(
+	vmCtx pkgctx.VirtualMachineContext,
+	client ctrlclient.Client) error {
+
+	var list ipamv1.IPAddressClaimList
+	if err := client.List(
+		vmCtx,
+		&list,
+		ctrlclient.InNamespace(vmCtx.VM.Namespace),
+		ctrlclient.MatchingLabels{VMNameLabel: vmCtx.VM.Name}); err != nil {
+
+		if apimeta.IsNoMatchError(err) {
+			// The IPAM CRDs are not installed so there cannot be any claims.
+			return nil
+		}
+		return fmt.Errorf("failed to list ip address claims: %w", err)
+	}
+
+	for i := range list.Items {
+		claim := &list.Items[i]
+		if !isOwnedBy(vmCtx.VM, claim) {
+			continue
+		}
+
+		if err := client.Delete(vmCtx, claim); ctrlclient.IgnoreNotFound(err) != nil {
+			return fmt.Errorf("failed to delete ip address claim %s: %w", claim.Name, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package network
+
+import (
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+)
+
+// NetworkKargs returns the dracut network kernel arguments for the given
+// results, suitable for guests like Fedora CoreOS and Flatcar Container Linux
+// that configure the network in the initramfs before Ignition runs. See
+// https://man7.org/linux/man-pages/man7/dracut.cmdline.7.html for the format.
+// The provided global DNS servers are used along with the per-interface ones.
+func NetworkKargs(
+	results NetworkInterfaceResults,
+	hostName string,
+	dnsServers []string) (string, error) {
+
+	var (
+		kargs       []string
+		nameservers []string
+	)
+
+	for _, r := range results.Results {
+		ifName := r.GuestDeviceName
+		if ifName == "" {
+			return "", fmt.Errorf("interface %q does not have a guest device name", r.Name)
+		}
+
+		if r.MacAddress != "" {
+			kargs = append(kargs, fmt.Sprintf("ifname=%s:%s", ifName, NormalizeNetplanMac(r.MacAddress)))
+		}
+
+		var mtu string
+		if r.MTU > 0 {
+			mtu = fmt.Sprintf(":%d", r.MTU)
+		}
+
+		if r.DHCP4 {
+			kargs = append(kargs, fmt.Sprintf("ip=%s:dhcp%s", ifName, mtu))
+		}
+		if r.DHCP6 {
+			kargs = append(kargs, fmt.Sprintf("ip=%s:dhcp6%s", ifName, mtu))
+		}
+
+		for _, ipConfig := range r.IPConfigs {
+			if (ipConfig.IsIPv4 && r.DHCP4) || (!ipConfig.IsIPv4 && r.DHCP6) {
+				continue
+			}
+
+			ip, ipNet, err := net.ParseCIDR(ipConfig.IPCIDR)
+			if err != nil {
+				return "", fmt.Errorf("interface %q has invalid address %q: %w", r.Name, ipConfig.IPCIDR, err)
+			}
+
+			var (
+				addr    string
+				gateway string
+				netmask string
+			)
+
+			if ipConfig.IsIPv4 {
+				addr = ip.String()
+				netmask = net.IP(ipNet.Mask).String()
+				gateway = ipConfig.Gateway
+			} else {
+				ones, _ := ipNet.Mask.Size()
+				addr = "[" + ip.String() + "]"
+				netmask = fmt.Sprintf("%d", ones)
+				if ipConfig.Gateway != "" {
+					gateway = "[" + ipConfig.Gateway + "]"
+				}
+			}
+
+			kargs = append(kargs, fmt.Sprintf("ip=%s::%s:%s:%s:%s:none%s",
+				addr, gateway, netmask, hostName, ifName, mtu))
+		}
+
+		for _, ns := range r.Nameservers {
+			if !slices.Contains(nameservers, ns) {
+				nameservers = append(nameservers, ns)
+			}
+		}
+	}
+
+	for _, ns := range dnsServers {
+		if !slices.Contains(nameservers, ns) {
+			nameservers = append(nameservers, ns)
+		}
+	}
+
+	for _, ns := range nameservers {
+		kargs = append(kargs, "nameserver="+ns)
+	}
+
+	return strings.Join(kargs, " "), nil
+}
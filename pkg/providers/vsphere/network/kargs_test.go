@@ -0,0 +1,143 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package network_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/network"
+)
+
+var _ = Describe("NetworkKargs", func() {
+	const (
+		ifName       = "my-interface"
+		guestDevName = "eth0"
+		macAddr      = "50-8A-80-9D-28-22"
+		hostName     = "my-vm"
+		dnsServer1   = "9.9.9.9"
+		dnsServer2   = "8.8.8.8"
+	)
+
+	var (
+		results    network.NetworkInterfaceResults
+		dnsServers []string
+		kargs      string
+		err        error
+	)
+
+	BeforeEach(func() {
+		results = network.NetworkInterfaceResults{}
+		dnsServers = nil
+	})
+
+	JustBeforeEach(func() {
+		kargs, err = network.NetworkKargs(results, hostName, dnsServers)
+	})
+
+	Context("No interfaces", func() {
+		It("returns empty kargs", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(kargs).To(BeEmpty())
+		})
+	})
+
+	Context("Static IPv4/6 interface", func() {
+		BeforeEach(func() {
+			results.Results = []network.NetworkInterfaceResult{
+				{
+					Name:            ifName,
+					GuestDeviceName: guestDevName,
+					MacAddress:      macAddr,
+					MTU:             9000,
+					IPConfigs: []network.NetworkInterfaceIPConfig{
+						{
+							IPCIDR:  "192.168.1.10/24",
+							IsIPv4:  true,
+							Gateway: "192.168.1.1",
+						},
+						{
+							IPCIDR:  "fd8e:b5a0:f172:123::f/48",
+							Gateway: "fd8e:b5a0:f172:123::1",
+						},
+					},
+					Nameservers: []string{dnsServer1},
+				},
+			}
+			dnsServers = []string{dnsServer1, dnsServer2}
+		})
+
+		It("returns expected kargs", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(kargs).To(Equal(
+				"ifname=eth0:50:8a:80:9d:28:22" +
+					" ip=192.168.1.10::192.168.1.1:255.255.255.0:my-vm:eth0:none:9000" +
+					" ip=[fd8e:b5a0:f172:123::f]::[fd8e:b5a0:f172:123::1]:48:my-vm:eth0:none:9000" +
+					" nameserver=9.9.9.9 nameserver=8.8.8.8"))
+		})
+	})
+
+	Context("DHCP interface", func() {
+		BeforeEach(func() {
+			results.Results = []network.NetworkInterfaceResult{
+				{
+					Name:            ifName,
+					GuestDeviceName: guestDevName,
+					MacAddress:      macAddr,
+					DHCP4:           true,
+					DHCP6:           true,
+					IPConfigs: []network.NetworkInterfaceIPConfig{
+						{
+							IPCIDR: "192.168.1.10/24",
+							IsIPv4: true,
+						},
+					},
+				},
+			}
+		})
+
+		It("returns expected kargs", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(kargs).To(Equal("ifname=eth0:50:8a:80:9d:28:22 ip=eth0:dhcp ip=eth0:dhcp6"))
+		})
+	})
+
+	Context("Interface without guest device name", func() {
+		BeforeEach(func() {
+			results.Results = []network.NetworkInterfaceResult{
+				{
+					Name:  ifName,
+					DHCP4: true,
+				},
+			}
+		})
+
+		It("returns an error", func() {
+			Expect(err).To(MatchError(`interface "my-interface" does not have a guest device name`))
+		})
+	})
+
+	Context("Interface with invalid address", func() {
+		BeforeEach(func() {
+			results.Results = []network.NetworkInterfaceResult{
+				{
+					Name:            ifName,
+					GuestDeviceName: guestDevName,
+					IPConfigs: []network.NetworkInterfaceIPConfig{
+						{
+							IPCIDR: "192.168.1.10",
+							IsIPv4: true,
+						},
+					},
+				},
+			}
+		})
+
+		It("returns an error", func() {
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring(`has invalid address "192.168.1.10"`))
+		})
+	})
+})
@@ -5,14 +5,22 @@
 package network
 
 import (
+	"strconv"
 	"strings"
 
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
 	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/constants"
 	"github.com/vmware-tanzu/vm-operator/pkg/util/netplan"
 	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
 )
 
-func NetPlanCustomization(result NetworkInterfaceResults) (*netplan.Network, error) {
+// NetPlanCustomization returns the netplan configuration for the network
+// interfaces in the provided result, as well as for any guest-side bonds,
+// VLANs, and bridges in the provided network spec.
+func NetPlanCustomization(
+	result NetworkInterfaceResults,
+	networkSpec *vmopv1.VirtualMachineNetworkSpec) (*netplan.Network, error) {
+
 	netPlan := &netplan.Network{
 		Version:   constants.NetPlanVersion,
 		Ethernets: make(map[string]netplan.Ethernet),
@@ -98,9 +106,185 @@ func NetPlanCustomization(result NetworkInterfaceResults) (*netplan.Network, err
 		netPlan.Ethernets[r.Name] = npEth
 	}
 
+	if networkSpec != nil {
+		netPlanGuestDevices(netPlan, *networkSpec)
+	}
+
 	return netPlan, nil
 }
 
+// netPlanGuestDevices adds the guest-side bonds, VLANs, and bridges from the
+// network spec to the netplan configuration.
+func netPlanGuestDevices(netPlan *netplan.Network, networkSpec vmopv1.VirtualMachineNetworkSpec) {
+	// The IP configuration of a device that is a member of a bond or bridge
+	// is ignored since the bond or bridge is configured instead.
+	members := map[string]struct{}{}
+	for _, b := range networkSpec.Bonds {
+		for _, name := range b.Interfaces {
+			members[name] = struct{}{}
+		}
+	}
+	for _, b := range networkSpec.Bridges {
+		for _, name := range b.Interfaces {
+			members[name] = struct{}{}
+		}
+	}
+
+	ipSpecFor := func(name string, spec vmopv1.VirtualMachineNetworkGuestDeviceIPSpec) netPlanIP {
+		if _, ok := members[name]; ok {
+			return netPlanGuestDeviceIP(vmopv1.VirtualMachineNetworkGuestDeviceIPSpec{})
+		}
+		return netPlanGuestDeviceIP(spec)
+	}
+
+	for name, npEth := range netPlan.Ethernets {
+		if _, ok := members[name]; ok {
+			npEth.Addresses = nil
+			npEth.Dhcp4 = ptr.To(false)
+			npEth.Dhcp6 = ptr.To(false)
+			npEth.AcceptRa = ptr.To(false)
+			npEth.Gateway4 = nil
+			npEth.Gateway6 = nil
+			npEth.Nameservers = nil
+			npEth.Routes = nil
+			netPlan.Ethernets[name] = npEth
+		}
+	}
+
+	for _, b := range networkSpec.Bonds {
+		if netPlan.Bonds == nil {
+			netPlan.Bonds = make(map[string]netplan.Bond)
+		}
+
+		ip := ipSpecFor(b.Name, b.VirtualMachineNetworkGuestDeviceIPSpec)
+		npBond := netplan.Bond{
+			Interfaces:  b.Interfaces,
+			Addresses:   ip.addresses,
+			Dhcp4:       ip.dhcp4,
+			Dhcp6:       ip.dhcp6,
+			AcceptRa:    ip.dhcp6,
+			Gateway4:    ip.gateway4,
+			Gateway6:    ip.gateway6,
+			MTU:         b.MTU,
+			Nameservers: ip.nameservers,
+			Routes:      ip.routes,
+		}
+
+		if b.Mode != "" || b.Primary != "" || b.MIIMonitorInterval != nil {
+			npBond.Parameters = &netplan.BondParameters{}
+			if b.Mode != "" {
+				npBond.Parameters.Mode = ptr.To(netplan.BondMode(b.Mode))
+			}
+			if b.Primary != "" {
+				npBond.Parameters.Primary = ptr.To(b.Primary)
+			}
+			if b.MIIMonitorInterval != nil {
+				npBond.Parameters.MiiMonitorInterval = ptr.To(strconv.FormatInt(*b.MIIMonitorInterval, 10))
+			}
+		}
+
+		netPlan.Bonds[b.Name] = npBond
+	}
+
+	for _, v := range networkSpec.VLANs {
+		if netPlan.Vlans == nil {
+			netPlan.Vlans = make(map[string]netplan.VLAN)
+		}
+
+		ip := ipSpecFor(v.Name, v.VirtualMachineNetworkGuestDeviceIPSpec)
+		netPlan.Vlans[v.Name] = netplan.VLAN{
+			ID:          ptr.To(v.ID),
+			Link:        ptr.To(v.Link),
+			Addresses:   ip.addresses,
+			Dhcp4:       ip.dhcp4,
+			Dhcp6:       ip.dhcp6,
+			AcceptRa:    ip.dhcp6,
+			Gateway4:    ip.gateway4,
+			Gateway6:    ip.gateway6,
+			MTU:         v.MTU,
+			Nameservers: ip.nameservers,
+			Routes:      ip.routes,
+		}
+	}
+
+	for _, b := range networkSpec.Bridges {
+		if netPlan.Bridges == nil {
+			netPlan.Bridges = make(map[string]netplan.Bridge)
+		}
+
+		ip := ipSpecFor(b.Name, b.VirtualMachineNetworkGuestDeviceIPSpec)
+		npBridge := netplan.Bridge{
+			Interfaces:  b.Interfaces,
+			Addresses:   ip.addresses,
+			Dhcp4:       ip.dhcp4,
+			Dhcp6:       ip.dhcp6,
+			AcceptRa:    ip.dhcp6,
+			Gateway4:    ip.gateway4,
+			Gateway6:    ip.gateway6,
+			MTU:         b.MTU,
+			Nameservers: ip.nameservers,
+			Routes:      ip.routes,
+		}
+
+		if b.STP != nil {
+			npBridge.Parameters = &netplan.BridgeParameters{
+				Stp: b.STP,
+			}
+		}
+
+		netPlan.Bridges[b.Name] = npBridge
+	}
+}
+
+type netPlanIP struct {
+	addresses   []netplan.Address
+	dhcp4       *bool
+	dhcp6       *bool
+	gateway4    *string
+	gateway6    *string
+	nameservers *netplan.Nameserver
+	routes      []netplan.Route
+}
+
+func netPlanGuestDeviceIP(spec vmopv1.VirtualMachineNetworkGuestDeviceIPSpec) netPlanIP {
+	ip := netPlanIP{
+		dhcp4: ptr.To(spec.DHCP4),
+		dhcp6: ptr.To(spec.DHCP6),
+	}
+
+	for i := range spec.Addresses {
+		ip.addresses = append(ip.addresses, netplan.Address{String: &spec.Addresses[i]})
+	}
+	if spec.Gateway4 != "" && spec.Gateway4 != gatewayIgnored {
+		ip.gateway4 = ptr.To(spec.Gateway4)
+	}
+	if spec.Gateway6 != "" && spec.Gateway6 != gatewayIgnored {
+		ip.gateway6 = ptr.To(spec.Gateway6)
+	}
+	if len(spec.Nameservers) > 0 || len(spec.SearchDomains) > 0 {
+		ip.nameservers = &netplan.Nameserver{
+			Addresses: spec.Nameservers,
+			Search:    spec.SearchDomains,
+		}
+	}
+	for i := range spec.Routes {
+		route := spec.Routes[i]
+
+		var metric *int64
+		if route.Metric != 0 {
+			metric = ptr.To(int64(route.Metric))
+		}
+
+		ip.routes = append(ip.routes, netplan.Route{
+			To:     &route.To,
+			Metric: metric,
+			Via:    &route.Via,
+		})
+	}
+
+	return ip
+}
+
 // NormalizeNetplanMac normalizes the mac address format to one compatible with netplan.
 func NormalizeNetplanMac(mac string) string {
 	mac = strings.ReplaceAll(mac, "-", ":")
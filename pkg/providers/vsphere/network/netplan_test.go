@@ -10,6 +10,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
 	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/constants"
 	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/network"
 	"github.com/vmware-tanzu/vm-operator/pkg/util/netplan"
@@ -35,18 +36,20 @@ var _ = Describe("Netplan", func() {
 	Context("NetPlanCustomization", func() {
 
 		var (
-			results network.NetworkInterfaceResults
-			config  *netplan.Network
-			err     error
+			results     network.NetworkInterfaceResults
+			networkSpec *vmopv1.VirtualMachineNetworkSpec
+			config      *netplan.Network
+			err         error
 		)
 
 		BeforeEach(func() {
 			results = network.NetworkInterfaceResults{}
+			networkSpec = nil
 			config = nil
 		})
 
 		JustBeforeEach(func() {
-			config, err = network.NetPlanCustomization(results)
+			config, err = network.NetPlanCustomization(results, networkSpec)
 		})
 
 		Context("IPv4/6 Static adapter", func() {
@@ -213,5 +216,135 @@ var _ = Describe("Netplan", func() {
 				Expect(np.Routes).To(BeEmpty())
 			})
 		})
+
+		Context("Bonds, VLANs, and bridges", func() {
+			const (
+				ifName2      = "my-interface-2"
+				macAddr2     = "50-8A-80-9D-28-23"
+				bondName     = "bond0"
+				vlanName     = "bond0.100"
+				bridgeName   = "br0"
+				bridgeIPCIDR = "10.10.10.10/24"
+			)
+
+			BeforeEach(func() {
+				results.Results = []network.NetworkInterfaceResult{
+					{
+						IPConfigs: []network.NetworkInterfaceIPConfig{
+							{
+								IPCIDR:  ipv4CIDR,
+								IsIPv4:  true,
+								Gateway: ipv4Gateway,
+							},
+						},
+						MacAddress:  macAddr1,
+						Name:        ifName,
+						MTU:         9000,
+						Nameservers: []string{dnsServer1},
+					},
+					{
+						MacAddress: macAddr2,
+						Name:       ifName2,
+						DHCP4:      true,
+					},
+				}
+				networkSpec = &vmopv1.VirtualMachineNetworkSpec{
+					Bonds: []vmopv1.VirtualMachineNetworkBondSpec{
+						{
+							Name:               bondName,
+							Interfaces:         []string{ifName, ifName2},
+							Mode:               vmopv1.VirtualMachineNetworkBondModeActiveBackup,
+							Primary:            ifName,
+							MIIMonitorInterval: ptr.To[int64](100),
+						},
+					},
+					VLANs: []vmopv1.VirtualMachineNetworkVLANSpec{
+						{
+							Name: vlanName,
+							ID:   100,
+							Link: bondName,
+							VirtualMachineNetworkGuestDeviceIPSpec: vmopv1.VirtualMachineNetworkGuestDeviceIPSpec{
+								DHCP4: true,
+							},
+						},
+					},
+					Bridges: []vmopv1.VirtualMachineNetworkBridgeSpec{
+						{
+							Name:       bridgeName,
+							Interfaces: []string{bondName},
+							STP:        ptr.To(false),
+							VirtualMachineNetworkGuestDeviceIPSpec: vmopv1.VirtualMachineNetworkGuestDeviceIPSpec{
+								Addresses:   []string{bridgeIPCIDR},
+								Gateway4:    "10.10.10.1",
+								Nameservers: []string{dnsServer1},
+								Routes: []vmopv1.VirtualMachineNetworkRouteSpec{
+									{
+										To:     "10.20.0.0/16",
+										Via:    "10.10.10.2",
+										Metric: 10,
+									},
+								},
+							},
+						},
+					},
+				}
+			})
+
+			It("returns success", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(config).ToNot(BeNil())
+
+				By("ignoring the IP configuration of the bond members", func() {
+					Expect(config.Ethernets).To(HaveLen(2))
+					for _, name := range []string{ifName, ifName2} {
+						np := config.Ethernets[name]
+						Expect(np.Match.Macaddress).ToNot(BeNil())
+						Expect(np.Dhcp4).To(HaveValue(BeFalse()))
+						Expect(np.Dhcp6).To(HaveValue(BeFalse()))
+						Expect(np.Addresses).To(BeEmpty())
+						Expect(np.Gateway4).To(BeNil())
+						Expect(np.Nameservers).To(BeNil())
+					}
+					Expect(config.Ethernets[ifName].MTU).To(HaveValue(BeEquivalentTo(9000)))
+				})
+
+				By("rendering the bond", func() {
+					Expect(config.Bonds).To(HaveLen(1))
+					np := config.Bonds[bondName]
+					Expect(np.Interfaces).To(Equal([]string{ifName, ifName2}))
+					Expect(np.Parameters).ToNot(BeNil())
+					Expect(np.Parameters.Mode).To(HaveValue(Equal(netplan.BondMode("active-backup"))))
+					Expect(np.Parameters.Primary).To(HaveValue(Equal(ifName)))
+					Expect(np.Parameters.MiiMonitorInterval).To(HaveValue(Equal("100")))
+					// The bond is a member of the bridge.
+					Expect(np.Dhcp4).To(HaveValue(BeFalse()))
+					Expect(np.Addresses).To(BeEmpty())
+				})
+
+				By("rendering the VLAN", func() {
+					Expect(config.Vlans).To(HaveLen(1))
+					np := config.Vlans[vlanName]
+					Expect(np.ID).To(HaveValue(BeEquivalentTo(100)))
+					Expect(np.Link).To(HaveValue(Equal(bondName)))
+					Expect(np.Dhcp4).To(HaveValue(BeTrue()))
+				})
+
+				By("rendering the bridge", func() {
+					Expect(config.Bridges).To(HaveLen(1))
+					np := config.Bridges[bridgeName]
+					Expect(np.Interfaces).To(Equal([]string{bondName}))
+					Expect(np.Parameters).ToNot(BeNil())
+					Expect(np.Parameters.Stp).To(HaveValue(BeFalse()))
+					Expect(np.Dhcp4).To(HaveValue(BeFalse()))
+					Expect(np.Addresses).To(Equal([]netplan.Address{{String: ptr.To(bridgeIPCIDR)}}))
+					Expect(np.Gateway4).To(HaveValue(Equal("10.10.10.1")))
+					Expect(np.Nameservers.Addresses).To(Equal([]string{dnsServer1}))
+					Expect(np.Routes).To(HaveLen(1))
+					Expect(np.Routes[0].To).To(HaveValue(Equal("10.20.0.0/16")))
+					Expect(np.Routes[0].Via).To(HaveValue(Equal("10.10.10.2")))
+					Expect(np.Routes[0].Metric).To(HaveValue(BeEquivalentTo(10)))
+				})
+			})
+		})
 	})
 })
@@ -58,6 +58,9 @@ type NetworkInterfaceResult struct {
 
 	// Fields from the InterfaceSpec used later during customization.
 	Name            string
+	Type            vmopv1.VirtualMachineNetworkInterfaceType
+	SRIOV           *vmopv1.VirtualMachineNetworkInterfaceSRIOVSpec
+	DVX             *vmopv1.VirtualMachineNetworkInterfaceDVXSpec
 	GuestDeviceName string
 	NoIPv4          bool
 	DHCP4           bool
@@ -67,6 +70,12 @@ type NetworkInterfaceResult struct {
 	Nameservers     []string
 	SearchDomains   []string
 	Routes          []NetworkInterfaceRoute
+	QoS             *vmopv1.VirtualMachineNetworkInterfaceQoS
+	TrafficRules    []vmopv1.VirtualMachineNetworkInterfaceTrafficRule
+
+	// QoSApplied is true if the VM's status shows that a QoS was applied to
+	// the interface's ethernet card by an earlier reconcile.
+	QoSApplied bool
 }
 
 type NetworkInterfaceIPConfig struct {
@@ -135,6 +144,17 @@ func CreateAndWaitForNetworkInterfaces(
 		defaultToGlobalSearchDomains = ptr.DerefWithDefault(bootstrap.CloudInit.UseGlobalSearchDomainsAsDefault, true)
 	}
 
+	// The interfaces whose QoS is reflected in the status from an earlier
+	// reconcile.
+	qosApplied := map[string]struct{}{}
+	if vmCtx.VM.Status.Network != nil && vmCtx.VM.Status.Network.Config != nil {
+		for _, ifc := range vmCtx.VM.Status.Network.Config.Interfaces {
+			if ifc.QoS != nil {
+				qosApplied[ifc.Name] = struct{}{}
+			}
+		}
+	}
+
 	results := make([]NetworkInterfaceResult, 0, len(networkSpec.Interfaces))
 
 	for i := range networkSpec.Interfaces {
@@ -156,6 +176,10 @@ func CreateAndWaitForNetworkInterfaces(
 			err = fmt.Errorf("unsupported network provider envvar value: %q", networkType)
 		}
 
+		if err == nil && len(interfaceSpec.AddressesFromPools) > 0 {
+			err = applyIPAddressClaimsToResult(vmCtx, client, networkType, interfaceSpec, result)
+		}
+
 		if err != nil {
 			return NetworkInterfaceResults{},
 				fmt.Errorf("network interface %q error: %w", interfaceSpec.Name, err)
@@ -167,6 +191,7 @@ func CreateAndWaitForNetworkInterfaces(
 			defaultToGlobalNameservers,
 			defaultToGlobalSearchDomains,
 			result)
+		_, result.QoSApplied = qosApplied[interfaceSpec.Name]
 
 		results = append(results, *result)
 	}
@@ -237,7 +262,15 @@ func applyInterfaceSpecToResult(
 		}
 	}
 
+	if result.MacAddress == "" {
+		// A MAC address assigned by the network provider takes precedence.
+		result.MacAddress = interfaceSpec.MacAddress
+	}
+
 	result.Name = interfaceSpec.Name
+	result.Type = interfaceSpec.Type
+	result.SRIOV = interfaceSpec.SRIOV
+	result.DVX = interfaceSpec.DVX
 	result.GuestDeviceName = interfaceSpec.GuestDeviceName
 	if result.GuestDeviceName == "" {
 		result.GuestDeviceName = result.Name
@@ -264,6 +297,9 @@ func applyInterfaceSpecToResult(
 	for _, route := range interfaceSpec.Routes {
 		result.Routes = append(result.Routes, NetworkInterfaceRoute{To: route.To, Via: route.Via, Metric: route.Metric})
 	}
+
+	result.QoS = interfaceSpec.QoS
+	result.TrafficRules = interfaceSpec.TrafficRules
 }
 
 func createNamedNetworkInterface(
@@ -841,7 +877,8 @@ func ipCIDRNotation(ip string, mask string, isIPv4 bool) string {
 
 // CreateDefaultEthCard creates a default Ethernet card attached to the backing. This is used
 // when the VM Class ConfigSpec does not have a device entry for a VM Spec network interface,
-// so we need a new device.
+// so we need a new device. The type of the card is the interface's Type, or vmxnet3 if the
+// Type is not set.
 func CreateDefaultEthCard(
 	ctx context.Context,
 	result *NetworkInterfaceResult) (vimtypes.BaseVirtualDevice, error) {
@@ -851,12 +888,17 @@ func CreateDefaultEthCard(
 		return nil, fmt.Errorf("unable to get ethernet card backing info for network %v: %w", result.Backing.Reference(), err)
 	}
 
-	dev, err := object.EthernetCardTypes().CreateEthernetCard(defaultEthernetCardType, backing)
+	dev, err := object.EthernetCardTypes().CreateEthernetCard(ethernetCardTypeName(result.Type), backing)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create ethernet card network %v: %w", result.Backing.Reference(), err)
 	}
 
+	applyInterfaceTypeToEthCard(dev.(vimtypes.BaseVirtualEthernetCard), result)
+
 	ethCard := dev.(vimtypes.BaseVirtualEthernetCard).GetVirtualEthernetCard()
+	if result.QoS != nil {
+		ethCard.ResourceAllocation = EthCardResourceAllocation(result.QoS)
+	}
 	ethCard.ExternalId = result.ExternalID
 	if result.MacAddress != "" {
 		ethCard.MacAddress = result.MacAddress
@@ -868,18 +910,81 @@ func CreateDefaultEthCard(
 	return dev, nil
 }
 
+// ethernetCardTypeName returns the govmomi name of the ethernet card type for
+// the interface type. DVX devices are SR-IOV cards with a DVX backing.
+func ethernetCardTypeName(interfaceType vmopv1.VirtualMachineNetworkInterfaceType) string {
+	switch interfaceType {
+	case vmopv1.VirtualMachineNetworkInterfaceTypeE1000e:
+		return "e1000e"
+	case vmopv1.VirtualMachineNetworkInterfaceTypeSRIOV, vmopv1.VirtualMachineNetworkInterfaceTypeDVX:
+		return "sriov"
+	default:
+		return defaultEthernetCardType
+	}
+}
+
+// applyInterfaceTypeToEthCard applies the SR-IOV or DVX configuration from the
+// interface result to the ethernet card when it is an SR-IOV card.
+func applyInterfaceTypeToEthCard(
+	bEthCard vimtypes.BaseVirtualEthernetCard,
+	result *NetworkInterfaceResult) {
+
+	sriovCard, ok := bEthCard.(*vimtypes.VirtualSriovEthernetCard)
+	if !ok {
+		return
+	}
+
+	switch result.Type {
+	case vmopv1.VirtualMachineNetworkInterfaceTypeSRIOV:
+		sriovCard.DvxBackingInfo = nil
+		if s := result.SRIOV; s != nil {
+			sriovCard.AllowGuestOSMtuChange = s.AllowGuestOSMTUChange
+			if s.PhysicalFunction != "" {
+				// When not set, the physical function is automatically assigned from
+				// the SR-IOV device pool of the network during power on.
+				sriovCard.SriovBacking = &vimtypes.VirtualSriovEthernetCardSriovBackingInfo{
+					PhysicalFunctionBacking: &vimtypes.VirtualPCIPassthroughDeviceBackingInfo{
+						Id: s.PhysicalFunction,
+					},
+				}
+			}
+		}
+
+	case vmopv1.VirtualMachineNetworkInterfaceTypeDVX:
+		// The SR-IOV and DVX backings are mutually exclusive.
+		sriovCard.SriovBacking = nil
+		if d := result.DVX; d != nil {
+			dvxBacking := &vimtypes.VirtualPCIPassthroughDvxBackingInfo{
+				DeviceClass: d.DeviceClass,
+			}
+			for _, kv := range d.ConfigParams {
+				dvxBacking.ConfigParams = append(dvxBacking.ConfigParams,
+					&vimtypes.OptionValue{Key: kv.Key, Value: kv.Value})
+			}
+			sriovCard.DvxBackingInfo = dvxBacking
+		}
+	}
+}
+
 // ApplyInterfaceResultToVirtualEthCard applies the interface result from the NetOP/NCP
 // provider to an existing Ethernet device from the class ConfigSpec.
 func ApplyInterfaceResultToVirtualEthCard(
 	ctx context.Context,
-	ethCard *vimtypes.VirtualEthernetCard,
+	bEthCard vimtypes.BaseVirtualEthernetCard,
 	result *NetworkInterfaceResult) error {
 
 	backing, err := result.Backing.EthernetCardBackingInfo(ctx)
 	if err != nil {
 		return fmt.Errorf("unable to get ethernet card backing info for network %v: %w", result.NetworkID, err)
 	}
+
+	applyInterfaceTypeToEthCard(bEthCard, result)
+
+	ethCard := bEthCard.GetVirtualEthernetCard()
 	ethCard.Backing = backing
+	if result.QoS != nil {
+		ethCard.ResourceAllocation = EthCardResourceAllocation(result.QoS)
+	}
 
 	ethCard.ExternalId = result.ExternalID
 	if result.MacAddress != "" {
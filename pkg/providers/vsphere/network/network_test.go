@@ -5,6 +5,8 @@
 package network_test
 
 import (
+	"context"
+	"errors"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -12,12 +14,15 @@ import (
 
 	vimtypes "github.com/vmware/govmomi/vim25/types"
 	corev1 "k8s.io/api/core/v1"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	netopv1alpha1 "github.com/vmware-tanzu/net-operator-api/api/v1alpha1"
 	vpcv1alpha1 "github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
+	ipamv1 "github.com/vmware-tanzu/vm-operator/external/ipam/api/v1beta1"
 	ncpv1alpha1 "github.com/vmware-tanzu/vm-operator/external/ncp/api/v1alpha1"
 
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
@@ -118,6 +123,31 @@ var _ = Describe("CreateAndWaitForNetworkInterfaces", Label(testlabels.VCSim), f
 
 				Expect(result.DHCP4).To(BeTrue())
 				Expect(result.DHCP6).To(BeTrue()) // Only enabled if explicitly requested (which it is above).
+				Expect(result.QoSApplied).To(BeFalse())
+			})
+
+			When("the status shows a QoS was applied to the interface", func() {
+				BeforeEach(func() {
+					vm.Status.Network = &vmopv1.VirtualMachineNetworkStatus{
+						Config: &vmopv1.VirtualMachineNetworkConfigStatus{
+							Interfaces: []vmopv1.VirtualMachineNetworkConfigInterfaceStatus{
+								{
+									Name: "eth0",
+									QoS: &vmopv1.VirtualMachineNetworkInterfaceQoS{
+										Limit: ptr.To[int64](100),
+									},
+								},
+							},
+						},
+					}
+				})
+
+				It("returns the QoS was applied", func() {
+					Expect(err).ToNot(HaveOccurred())
+					Expect(results.Results).To(HaveLen(1))
+					Expect(results.Results[0].QoS).To(BeNil())
+					Expect(results.Results[0].QoSApplied).To(BeTrue())
+				})
 			})
 
 			Context("Overrides with provided InterfaceSpec", func() {
@@ -126,6 +156,7 @@ var _ = Describe("CreateAndWaitForNetworkInterfaces", Label(testlabels.VCSim), f
 						{
 							Name:            "my-network-interface",
 							GuestDeviceName: "eth42",
+							MacAddress:      "00:50:56:00:00:42",
 							Network:         &common.PartialObjectRef{Name: networkName},
 							Addresses: []string{
 								"172.42.1.100/24",
@@ -170,6 +201,8 @@ var _ = Describe("CreateAndWaitForNetworkInterfaces", Label(testlabels.VCSim), f
 						Expect(result.GuestDeviceName).To(Equal("eth42"))
 					})
 
+					Expect(result.MacAddress).To(Equal("00:50:56:00:00:42"))
+
 					Expect(result.DHCP4).To(BeFalse())
 					Expect(result.DHCP6).To(BeFalse())
 
@@ -252,6 +285,95 @@ var _ = Describe("CreateAndWaitForNetworkInterfaces", Label(testlabels.VCSim), f
 			})
 		})
 
+		Context("AddressesFromPools", func() {
+			const (
+				interfaceName = "eth0"
+				poolName      = "my-pool"
+			)
+
+			BeforeEach(func() {
+				network.RetryTimeout = 1 * time.Second
+
+				networkSpec.Interfaces = []vmopv1.VirtualMachineNetworkInterfaceSpec{
+					{
+						Name:    interfaceName,
+						Network: &common.PartialObjectRef{Name: networkName},
+						AddressesFromPools: []corev1.TypedLocalObjectReference{
+							{
+								APIGroup: ptr.To("ipam.cluster.x-k8s.io"),
+								Kind:     "InClusterIPPool",
+								Name:     poolName,
+							},
+						},
+					},
+				}
+			})
+
+			When("claim is not bound", func() {
+				It("returns error", func() {
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("is not bound yet"))
+
+					claim := &ipamv1.IPAddressClaim{}
+					claimName := network.IPAddressClaimName(vm.Name, interfaceName, 0)
+					Expect(ctx.Client.Get(ctx, client.ObjectKey{Name: claimName, Namespace: vm.Namespace}, claim)).To(Succeed())
+					Expect(claim.Spec.PoolRef.Name).To(Equal(poolName))
+					Expect(claim.Labels).To(HaveKeyWithValue(network.VMNameLabel, vm.Name))
+					Expect(claim.OwnerReferences).To(HaveLen(1))
+					Expect(claim.OwnerReferences[0].UID).To(Equal(vm.UID))
+				})
+			})
+
+			When("claim is bound", func() {
+				BeforeEach(func() {
+					claimName := network.IPAddressClaimName(vm.Name, interfaceName, 0)
+					initObjects = append(initObjects,
+						&ipamv1.IPAddressClaim{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      claimName,
+								Namespace: vm.Namespace,
+							},
+							Status: ipamv1.IPAddressClaimStatus{
+								AddressRef: corev1.LocalObjectReference{Name: "my-address"},
+							},
+						},
+						&ipamv1.IPAddress{
+							ObjectMeta: metav1.ObjectMeta{
+								Name:      "my-address",
+								Namespace: vm.Namespace,
+							},
+							Spec: ipamv1.IPAddressSpec{
+								ClaimRef: corev1.LocalObjectReference{Name: claimName},
+								Address:  "192.168.10.20",
+								Prefix:   24,
+								Gateway:  "192.168.10.1",
+							},
+						},
+					)
+				})
+
+				It("returns success", func() {
+					Expect(err).ToNot(HaveOccurred())
+					Expect(results.Results).To(HaveLen(1))
+
+					result := results.Results[0]
+					Expect(result.DHCP4).To(BeFalse())
+					Expect(result.IPConfigs).To(HaveLen(1))
+					Expect(result.IPConfigs[0].IPCIDR).To(Equal("192.168.10.20/24"))
+					Expect(result.IPConfigs[0].IsIPv4).To(BeTrue())
+					Expect(result.IPConfigs[0].Gateway).To(Equal("192.168.10.1"))
+
+					By("claims are released", func() {
+						Expect(network.ReleaseIPAddressClaims(vmCtx, ctx.Client)).To(Succeed())
+
+						claims := &ipamv1.IPAddressClaimList{}
+						Expect(ctx.Client.List(ctx, claims, client.InNamespace(vm.Namespace))).To(Succeed())
+						Expect(claims.Items).To(BeEmpty())
+					})
+				})
+			})
+		})
+
 		Context("network does not exist", func() {
 			BeforeEach(func() {
 				networkSpec.Interfaces = []vmopv1.VirtualMachineNetworkInterfaceSpec{
@@ -883,3 +1005,92 @@ var _ = Describe("SetNetworkInterfaceOwnerRef", func() {
 		})
 	})
 })
+
+var _ = Describe("ReleaseIPAddressClaims", func() {
+	var (
+		vm        *vmopv1.VirtualMachine
+		vmCtx     pkgctx.VirtualMachineContext
+		funcs     interceptor.Funcs
+		k8sClient client.Client
+	)
+
+	BeforeEach(func() {
+		vm = &vmopv1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "my-vm",
+				Namespace: "my-ns",
+				UID:       "my-vm-uid",
+			},
+		}
+		funcs = interceptor.Funcs{}
+	})
+
+	JustBeforeEach(func() {
+		k8sClient = builder.NewFakeClientWithInterceptors(funcs)
+		vmCtx = pkgctx.VirtualMachineContext{
+			Context: context.Background(),
+			Logger:  suite.GetLogger().WithName("network_test"),
+			VM:      vm,
+		}
+	})
+
+	When("the IPAM CRDs are not installed", func() {
+		BeforeEach(func() {
+			funcs.List = func(
+				_ context.Context,
+				_ client.WithWatch,
+				_ client.ObjectList,
+				_ ...client.ListOption) error {
+
+				return &apimeta.NoKindMatchError{
+					GroupKind: ipamv1.GroupVersion.WithKind("IPAddressClaim").GroupKind(),
+				}
+			}
+		})
+
+		It("returns success", func() {
+			Expect(network.ReleaseIPAddressClaims(vmCtx, k8sClient)).To(Succeed())
+		})
+	})
+
+	When("listing the claims fails", func() {
+		BeforeEach(func() {
+			funcs.List = func(
+				_ context.Context,
+				_ client.WithWatch,
+				_ client.ObjectList,
+				_ ...client.ListOption) error {
+
+				return errors.New("fake")
+			}
+		})
+
+		It("returns an error", func() {
+			Expect(network.ReleaseIPAddressClaims(vmCtx, k8sClient)).To(MatchError(ContainSubstring("fake")))
+		})
+	})
+
+	Context("HasAddressesFromPools", func() {
+		It("returns false when the VM has no network spec", func() {
+			Expect(network.HasAddressesFromPools(vm)).To(BeFalse())
+		})
+
+		It("returns whether an interface has addressesFromPools", func() {
+			vm.Spec.Network = &vmopv1.VirtualMachineNetworkSpec{
+				Interfaces: []vmopv1.VirtualMachineNetworkInterfaceSpec{
+					{Name: "eth0"},
+				},
+			}
+			Expect(network.HasAddressesFromPools(vm)).To(BeFalse())
+
+			vm.Spec.Network.Interfaces = append(vm.Spec.Network.Interfaces,
+				vmopv1.VirtualMachineNetworkInterfaceSpec{
+					Name: "eth1",
+					AddressesFromPools: []corev1.TypedLocalObjectReference{
+						{Kind: "InClusterIPPool", Name: "my-pool"},
+					},
+				})
+			Expect(network.HasAddressesFromPools(vm)).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,394 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package network
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/mo"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+)
+
+const (
+	// trafficFilterAgentName is the name of the DVFilter agent that enforces
+	// the traffic rules of a distributed virtual port.
+	trafficFilterAgentName = "dvfilter-generic-vmware"
+
+	// trafficRuleDescriptionPrefix is the prefix of the description of each
+	// traffic rule created from an interface's TrafficRules. The description
+	// encodes the rule so that the rules on a port can be compared with the
+	// desired rules without having to decode the rule qualifiers.
+	trafficRuleDescriptionPrefix = "vm-operator:"
+)
+
+// EthCardResourceAllocation returns the ethernet card resource allocation for
+// the interface's QoS. The defaults of the fields that are not set in the QoS
+// are used so that the allocation may be compared with the device's current
+// allocation.
+func EthCardResourceAllocation(
+	qos *vmopv1.VirtualMachineNetworkInterfaceQoS) *vimtypes.VirtualEthernetCardResourceAllocation {
+
+	ra := &vimtypes.VirtualEthernetCardResourceAllocation{
+		Reservation: ptr.To(ptr.DerefWithDefault(qos.Reservation, 0)),
+		Limit:       ptr.To(ptr.DerefWithDefault(qos.Limit, -1)),
+		Share: vimtypes.SharesInfo{
+			Level: vimtypes.SharesLevelNormal,
+		},
+	}
+
+	if qos.Shares != nil {
+		ra.Share = vimtypes.SharesInfo{
+			Level:  vimtypes.SharesLevelCustom,
+			Shares: *qos.Shares,
+		}
+	}
+
+	return ra
+}
+
+// EthCardResourceAllocationChange returns the resource allocation an ethernet
+// card should have for the interface's QoS, and true if it differs from the
+// card's current allocation. When the interface does not have a QoS, the
+// card's allocation is only reset to the defaults if it was applied from a
+// QoS that was removed from the interface, since otherwise the allocation may
+// be from the VM class.
+func EthCardResourceAllocationChange(
+	qos *vmopv1.VirtualMachineNetworkInterfaceQoS,
+	qosApplied bool,
+	current *vimtypes.VirtualEthernetCardResourceAllocation) (*vimtypes.VirtualEthernetCardResourceAllocation, bool) {
+
+	if qos == nil {
+		if !qosApplied || current == nil {
+			return nil, false
+		}
+		qos = &vmopv1.VirtualMachineNetworkInterfaceQoS{}
+	}
+
+	ra := EthCardResourceAllocation(qos)
+	return ra, !MatchEthCardResourceAllocation(ra, current)
+}
+
+// MatchEthCardResourceAllocation returns true if the current resource
+// allocation of an ethernet card is the expected allocation.
+func MatchEthCardResourceAllocation(
+	expected, current *vimtypes.VirtualEthernetCardResourceAllocation) bool {
+
+	if current == nil {
+		return false
+	}
+
+	if ptr.DerefWithDefault(current.Reservation, 0) != ptr.DerefWithDefault(expected.Reservation, 0) ||
+		ptr.DerefWithDefault(current.Limit, -1) != ptr.DerefWithDefault(expected.Limit, -1) {
+		return false
+	}
+
+	if current.Share.Level != expected.Share.Level {
+		return false
+	}
+
+	// The number of shares is implied by the level unless it is custom.
+	return expected.Share.Level != vimtypes.SharesLevelCustom ||
+		current.Share.Shares == expected.Share.Shares
+}
+
+// TrafficFilterPolicy returns the distributed virtual port filter policy for
+// the interface's traffic rules. When there are no rules, the policy is
+// inherited from the port group.
+func TrafficFilterPolicy(
+	rules []vmopv1.VirtualMachineNetworkInterfaceTrafficRule) (*vimtypes.DvsFilterPolicy, error) {
+
+	if len(rules) == 0 {
+		return &vimtypes.DvsFilterPolicy{
+			InheritablePolicy: vimtypes.InheritablePolicy{
+				Inherited: true,
+			},
+		}, nil
+	}
+
+	ruleset := &vimtypes.DvsTrafficRuleset{
+		Enabled: ptr.To(true),
+	}
+
+	for i := range rules {
+		r, err := trafficRule(int32(i+1), rules[i]) //nolint:gosec // disable G115
+		if err != nil {
+			return nil, err
+		}
+		ruleset.Rules = append(ruleset.Rules, r)
+	}
+
+	return &vimtypes.DvsFilterPolicy{
+		FilterConfig: []vimtypes.BaseDvsFilterConfig{
+			&vimtypes.DvsTrafficFilterConfig{
+				DvsFilterConfig: vimtypes.DvsFilterConfig{
+					AgentName: trafficFilterAgentName,
+				},
+				TrafficRuleset: ruleset,
+			},
+		},
+	}, nil
+}
+
+func trafficRule(
+	sequence int32,
+	rule vmopv1.VirtualMachineNetworkInterfaceTrafficRule) (vimtypes.DvsTrafficRule, error) {
+
+	qualifier := &vimtypes.DvsIpNetworkRuleQualifier{}
+
+	if rule.SourceCIDR != "" {
+		ipRange, err := cidrToIPRange(rule.SourceCIDR)
+		if err != nil {
+			return vimtypes.DvsTrafficRule{}, err
+		}
+		qualifier.SourceAddress = ipRange
+	}
+
+	if rule.DestinationCIDR != "" {
+		ipRange, err := cidrToIPRange(rule.DestinationCIDR)
+		if err != nil {
+			return vimtypes.DvsTrafficRule{}, err
+		}
+		qualifier.DestinationAddress = ipRange
+	}
+
+	switch rule.Protocol {
+	case vmopv1.VirtualMachineNetworkInterfaceTrafficRuleProtocolTCP:
+		qualifier.Protocol = &vimtypes.IntExpression{Value: 6}
+	case vmopv1.VirtualMachineNetworkInterfaceTrafficRuleProtocolUDP:
+		qualifier.Protocol = &vimtypes.IntExpression{Value: 17}
+	case vmopv1.VirtualMachineNetworkInterfaceTrafficRuleProtocolICMP:
+		qualifier.Protocol = &vimtypes.IntExpression{Value: 1}
+	}
+
+	if rule.DestinationPort != nil {
+		qualifier.DestinationIpPort = &vimtypes.DvsSingleIpPort{
+			PortNumber: *rule.DestinationPort,
+		}
+	}
+
+	var action vimtypes.BaseDvsNetworkRuleAction
+	if rule.Action == vmopv1.VirtualMachineNetworkInterfaceTrafficRuleActionDeny {
+		action = &vimtypes.DvsDropNetworkRuleAction{}
+	} else {
+		action = &vimtypes.DvsAcceptNetworkRuleAction{}
+	}
+
+	var direction vimtypes.DvsNetworkRuleDirectionType
+	switch rule.Direction {
+	case vmopv1.VirtualMachineNetworkInterfaceTrafficRuleDirectionIngress:
+		direction = vimtypes.DvsNetworkRuleDirectionTypeIncomingPackets
+	case vmopv1.VirtualMachineNetworkInterfaceTrafficRuleDirectionEgress:
+		direction = vimtypes.DvsNetworkRuleDirectionTypeOutgoingPackets
+	default:
+		direction = vimtypes.DvsNetworkRuleDirectionTypeBoth
+	}
+
+	return vimtypes.DvsTrafficRule{
+		Description: trafficRuleDescription(rule),
+		Sequence:    sequence,
+		Qualifier:   []vimtypes.BaseDvsNetworkRuleQualifier{qualifier},
+		Action:      action,
+		Direction:   string(direction),
+	}, nil
+}
+
+func trafficRuleDescription(rule vmopv1.VirtualMachineNetworkInterfaceTrafficRule) string {
+	var port string
+	if rule.DestinationPort != nil {
+		port = fmt.Sprintf("%d", *rule.DestinationPort)
+	}
+
+	return trafficRuleDescriptionPrefix + strings.Join([]string{
+		string(rule.Action),
+		string(rule.Direction),
+		rule.SourceCIDR,
+		rule.DestinationCIDR,
+		string(rule.Protocol),
+		port,
+	}, ",")
+}
+
+func cidrToIPRange(cidr string) (*vimtypes.IpRange, error) {
+	_, ipNet, err := net.ParseCIDR(cidr)
+	if err != nil {
+		return nil, fmt.Errorf("invalid traffic rule CIDR %q: %w", cidr, err)
+	}
+
+	ones, _ := ipNet.Mask.Size()
+	return &vimtypes.IpRange{
+		AddressPrefix: ipNet.IP.String(),
+		PrefixLength:  int32(ones), //nolint:gosec // disable G115
+	}, nil
+}
+
+// MatchTrafficFilterPolicy returns true if the port setting has the expected
+// filter policy.
+func MatchTrafficFilterPolicy(
+	expected *vimtypes.DvsFilterPolicy,
+	setting vimtypes.BaseDVPortSetting) bool {
+
+	var current *vimtypes.DvsFilterPolicy
+	if setting != nil {
+		current = setting.GetDVPortSetting().FilterPolicy
+	}
+
+	if expected.Inherited {
+		return current == nil || current.Inherited
+	}
+
+	if current == nil || current.Inherited {
+		return false
+	}
+
+	return slices.Equal(trafficRuleDescriptions(expected), trafficRuleDescriptions(current))
+}
+
+func trafficRuleDescriptions(policy *vimtypes.DvsFilterPolicy) []string {
+	var descriptions []string
+
+	for _, fc := range policy.FilterConfig {
+		tfc, ok := fc.(*vimtypes.DvsTrafficFilterConfig)
+		if !ok || tfc.TrafficRuleset == nil {
+			continue
+		}
+
+		rules := slices.Clone(tfc.TrafficRuleset.Rules)
+		slices.SortFunc(rules, func(a, b vimtypes.DvsTrafficRule) int {
+			return int(a.Sequence - b.Sequence)
+		})
+
+		for _, r := range rules {
+			descriptions = append(descriptions, r.Description)
+		}
+	}
+
+	return descriptions
+}
+
+// ReconcileTrafficFilters applies the traffic rules of the VM's network
+// interfaces to the distributed virtual ports to which the interfaces' ethernet
+// cards are connected. The ports of interfaces without rules are only updated
+// when rules were previously applied to them, so their filter policy is once
+// again inherited from the port group.
+func ReconcileTrafficFilters(
+	vmCtx pkgctx.VirtualMachineContext,
+	client ctrlclient.Client,
+	vimClient *vim25.Client,
+	results NetworkInterfaceResults) error {
+
+	if pkgcfg.FromContext(vmCtx).NetworkProviderType != pkgcfg.NetworkProviderTypeVDS {
+		return nil
+	}
+
+	if vmCtx.MoVM.Config == nil {
+		return nil
+	}
+
+	// The interfaces whose configured traffic rules are reflected in the
+	// status from an earlier reconcile.
+	applied := map[string]struct{}{}
+	if vmCtx.VM.Status.Network != nil && vmCtx.VM.Status.Network.Config != nil {
+		for _, ifc := range vmCtx.VM.Status.Network.Config.Interfaces {
+			if len(ifc.TrafficRules) > 0 {
+				applied[ifc.Name] = struct{}{}
+			}
+		}
+	}
+
+	devKeyToSpecIdx := MapEthernetDevicesToSpecIdx(vmCtx, client, vmCtx.MoVM)
+
+	for _, dev := range vmCtx.MoVM.Config.Hardware.Device {
+		idx, ok := devKeyToSpecIdx[dev.GetVirtualDevice().Key]
+		if !ok || idx >= len(results.Results) {
+			continue
+		}
+
+		r := &results.Results[idx]
+		if _, ok := applied[r.Name]; !ok && len(r.TrafficRules) == 0 {
+			continue
+		}
+
+		backing, ok := dev.GetVirtualDevice().Backing.(*vimtypes.VirtualEthernetCardDistributedVirtualPortBackingInfo)
+		if !ok || backing.Port.PortKey == "" {
+			// The port is assigned when the card is connected.
+			continue
+		}
+
+		if err := reconcilePortTrafficFilter(vmCtx, vimClient, backing.Port, r.TrafficRules); err != nil {
+			return fmt.Errorf("failed to reconcile traffic rules for interface %s: %w", r.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func reconcilePortTrafficFilter(
+	ctx context.Context,
+	vimClient *vim25.Client,
+	port vimtypes.DistributedVirtualSwitchPortConnection,
+	rules []vmopv1.VirtualMachineNetworkInterfaceTrafficRule) error {
+
+	policy, err := TrafficFilterPolicy(rules)
+	if err != nil {
+		return err
+	}
+
+	pgRef := vimtypes.ManagedObjectReference{
+		Type:  "DistributedVirtualPortgroup",
+		Value: port.PortgroupKey,
+	}
+
+	var pg mo.DistributedVirtualPortgroup
+	if err := property.DefaultCollector(vimClient).RetrieveOne(
+		ctx, pgRef, []string{"config.distributedVirtualSwitch"}, &pg); err != nil {
+
+		return err
+	}
+
+	if pg.Config.DistributedVirtualSwitch == nil {
+		return nil
+	}
+
+	dvs := object.NewDistributedVirtualSwitch(vimClient, *pg.Config.DistributedVirtualSwitch)
+	ports, err := dvs.FetchDVPorts(ctx, &vimtypes.DistributedVirtualSwitchPortCriteria{
+		PortKey: []string{port.PortKey},
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(ports) == 0 || MatchTrafficFilterPolicy(policy, ports[0].Config.Setting) {
+		return nil
+	}
+
+	task, err := dvs.ReconfigureDVPort(ctx, []vimtypes.DVPortConfigSpec{
+		{
+			Operation:     string(vimtypes.ConfigSpecOperationEdit),
+			Key:           port.PortKey,
+			ConfigVersion: ports[0].Config.ConfigVersion,
+			Setting: &vimtypes.DVPortSetting{
+				FilterPolicy: policy,
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return task.Wait(ctx)
+}
@@ -0,0 +1,228 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package network_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/network"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+)
+
+var _ = Describe("EthCardResourceAllocation", func() {
+
+	It("defaults the unset fields", func() {
+		ra := network.EthCardResourceAllocation(&vmopv1.VirtualMachineNetworkInterfaceQoS{})
+		Expect(ra.Reservation).To(HaveValue(BeEquivalentTo(0)))
+		Expect(ra.Limit).To(HaveValue(BeEquivalentTo(-1)))
+		Expect(ra.Share.Level).To(Equal(vimtypes.SharesLevelNormal))
+	})
+
+	It("uses custom shares", func() {
+		ra := network.EthCardResourceAllocation(&vmopv1.VirtualMachineNetworkInterfaceQoS{
+			Reservation: ptr.To[int64](100),
+			Limit:       ptr.To[int64](1000),
+			Shares:      ptr.To[int32](80),
+		})
+		Expect(ra.Reservation).To(HaveValue(BeEquivalentTo(100)))
+		Expect(ra.Limit).To(HaveValue(BeEquivalentTo(1000)))
+		Expect(ra.Share).To(Equal(vimtypes.SharesInfo{Level: vimtypes.SharesLevelCustom, Shares: 80}))
+	})
+})
+
+var _ = Describe("EthCardResourceAllocationChange", func() {
+
+	custom := &vimtypes.VirtualEthernetCardResourceAllocation{
+		Reservation: ptr.To[int64](100),
+		Limit:       ptr.To[int64](1000),
+		Share:       vimtypes.SharesInfo{Level: vimtypes.SharesLevelCustom, Shares: 80},
+	}
+
+	It("returns the allocation for the QoS when it differs", func() {
+		ra, changed := network.EthCardResourceAllocationChange(
+			&vmopv1.VirtualMachineNetworkInterfaceQoS{Limit: ptr.To[int64](500)}, false, custom)
+		Expect(changed).To(BeTrue())
+		Expect(ra.Limit).To(HaveValue(BeEquivalentTo(500)))
+	})
+
+	It("returns false when the allocation matches the QoS", func() {
+		_, changed := network.EthCardResourceAllocationChange(
+			&vmopv1.VirtualMachineNetworkInterfaceQoS{
+				Reservation: ptr.To[int64](100),
+				Limit:       ptr.To[int64](1000),
+				Shares:      ptr.To[int32](80),
+			}, true, custom)
+		Expect(changed).To(BeFalse())
+	})
+
+	When("the interface does not have a QoS", func() {
+		It("resets the allocation applied from a removed QoS to the defaults", func() {
+			ra, changed := network.EthCardResourceAllocationChange(nil, true, custom)
+			Expect(changed).To(BeTrue())
+			Expect(ra.Reservation).To(HaveValue(BeEquivalentTo(0)))
+			Expect(ra.Limit).To(HaveValue(BeEquivalentTo(-1)))
+			Expect(ra.Share.Level).To(Equal(vimtypes.SharesLevelNormal))
+		})
+
+		It("keeps the class NIC allocation when the interface has no QoS", func() {
+			ra, changed := network.EthCardResourceAllocationChange(nil, false, custom)
+			Expect(changed).To(BeFalse())
+			Expect(ra).To(BeNil())
+		})
+
+		It("returns false when the card does not have an allocation", func() {
+			_, changed := network.EthCardResourceAllocationChange(nil, true, nil)
+			Expect(changed).To(BeFalse())
+		})
+	})
+})
+
+var _ = DescribeTable("MatchEthCardResourceAllocation",
+	func(qos *vmopv1.VirtualMachineNetworkInterfaceQoS, current *vimtypes.VirtualEthernetCardResourceAllocation, match bool) {
+		Expect(network.MatchEthCardResourceAllocation(network.EthCardResourceAllocation(qos), current)).To(Equal(match))
+	},
+	Entry("no current allocation",
+		&vmopv1.VirtualMachineNetworkInterfaceQoS{},
+		nil,
+		false,
+	),
+	Entry("defaults",
+		&vmopv1.VirtualMachineNetworkInterfaceQoS{},
+		&vimtypes.VirtualEthernetCardResourceAllocation{
+			Reservation: ptr.To[int64](0),
+			Limit:       ptr.To[int64](-1),
+			Share:       vimtypes.SharesInfo{Level: vimtypes.SharesLevelNormal, Shares: 50},
+		},
+		true,
+	),
+	Entry("different limit",
+		&vmopv1.VirtualMachineNetworkInterfaceQoS{Limit: ptr.To[int64](1000)},
+		&vimtypes.VirtualEthernetCardResourceAllocation{
+			Reservation: ptr.To[int64](0),
+			Limit:       ptr.To[int64](-1),
+			Share:       vimtypes.SharesInfo{Level: vimtypes.SharesLevelNormal, Shares: 50},
+		},
+		false,
+	),
+	Entry("different custom shares",
+		&vmopv1.VirtualMachineNetworkInterfaceQoS{Shares: ptr.To[int32](80)},
+		&vimtypes.VirtualEthernetCardResourceAllocation{
+			Reservation: ptr.To[int64](0),
+			Limit:       ptr.To[int64](-1),
+			Share:       vimtypes.SharesInfo{Level: vimtypes.SharesLevelCustom, Shares: 20},
+		},
+		false,
+	),
+)
+
+var _ = Describe("TrafficFilterPolicy", func() {
+
+	var (
+		rules  []vmopv1.VirtualMachineNetworkInterfaceTrafficRule
+		policy *vimtypes.DvsFilterPolicy
+		err    error
+	)
+
+	JustBeforeEach(func() {
+		policy, err = network.TrafficFilterPolicy(rules)
+	})
+
+	AfterEach(func() {
+		rules = nil
+	})
+
+	When("there are no rules", func() {
+		It("inherits the port group policy", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(policy.Inherited).To(BeTrue())
+			Expect(policy.FilterConfig).To(BeEmpty())
+
+			Expect(network.MatchTrafficFilterPolicy(policy, nil)).To(BeTrue())
+			Expect(network.MatchTrafficFilterPolicy(policy, &vimtypes.DVPortSetting{
+				FilterPolicy: &vimtypes.DvsFilterPolicy{
+					InheritablePolicy: vimtypes.InheritablePolicy{Inherited: true},
+				},
+			})).To(BeTrue())
+		})
+	})
+
+	When("there are rules", func() {
+		BeforeEach(func() {
+			rules = []vmopv1.VirtualMachineNetworkInterfaceTrafficRule{
+				{
+					Action:          vmopv1.VirtualMachineNetworkInterfaceTrafficRuleActionAllow,
+					Direction:       vmopv1.VirtualMachineNetworkInterfaceTrafficRuleDirectionIngress,
+					SourceCIDR:      "192.168.1.0/24",
+					Protocol:        vmopv1.VirtualMachineNetworkInterfaceTrafficRuleProtocolTCP,
+					DestinationPort: ptr.To[int32](443),
+				},
+				{
+					Action: vmopv1.VirtualMachineNetworkInterfaceTrafficRuleActionDeny,
+				},
+			}
+		})
+
+		It("returns the traffic ruleset", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(policy.Inherited).To(BeFalse())
+			Expect(policy.FilterConfig).To(HaveLen(1))
+
+			fc, ok := policy.FilterConfig[0].(*vimtypes.DvsTrafficFilterConfig)
+			Expect(ok).To(BeTrue())
+			Expect(fc.TrafficRuleset).ToNot(BeNil())
+			Expect(fc.TrafficRuleset.Enabled).To(HaveValue(BeTrue()))
+			Expect(fc.TrafficRuleset.Rules).To(HaveLen(2))
+
+			r0 := fc.TrafficRuleset.Rules[0]
+			Expect(r0.Sequence).To(BeEquivalentTo(1))
+			Expect(r0.Direction).To(Equal(string(vimtypes.DvsNetworkRuleDirectionTypeIncomingPackets)))
+			Expect(r0.Action).To(BeAssignableToTypeOf(&vimtypes.DvsAcceptNetworkRuleAction{}))
+			Expect(r0.Qualifier).To(HaveLen(1))
+			q, ok := r0.Qualifier[0].(*vimtypes.DvsIpNetworkRuleQualifier)
+			Expect(ok).To(BeTrue())
+			Expect(q.SourceAddress).To(Equal(&vimtypes.IpRange{AddressPrefix: "192.168.1.0", PrefixLength: 24}))
+			Expect(q.DestinationAddress).To(BeNil())
+			Expect(q.Protocol).To(Equal(&vimtypes.IntExpression{Value: 6}))
+			Expect(q.DestinationIpPort).To(Equal(&vimtypes.DvsSingleIpPort{PortNumber: 443}))
+
+			r1 := fc.TrafficRuleset.Rules[1]
+			Expect(r1.Sequence).To(BeEquivalentTo(2))
+			Expect(r1.Direction).To(Equal(string(vimtypes.DvsNetworkRuleDirectionTypeBoth)))
+			Expect(r1.Action).To(BeAssignableToTypeOf(&vimtypes.DvsDropNetworkRuleAction{}))
+		})
+
+		It("matches the port setting with the same rules", func() {
+			Expect(network.MatchTrafficFilterPolicy(policy, nil)).To(BeFalse())
+			Expect(network.MatchTrafficFilterPolicy(policy, &vimtypes.DVPortSetting{
+				FilterPolicy: policy,
+			})).To(BeTrue())
+
+			other, err := network.TrafficFilterPolicy(rules[1:])
+			Expect(err).ToNot(HaveOccurred())
+			Expect(network.MatchTrafficFilterPolicy(policy, &vimtypes.DVPortSetting{
+				FilterPolicy: other,
+			})).To(BeFalse())
+		})
+	})
+
+	When("a rule has an invalid CIDR", func() {
+		BeforeEach(func() {
+			rules = []vmopv1.VirtualMachineNetworkInterfaceTrafficRule{
+				{
+					Action:     vmopv1.VirtualMachineNetworkInterfaceTrafficRuleActionAllow,
+					SourceCIDR: "192.168.1.1",
+				},
+			}
+		})
+
+		It("returns an error", func() {
+			Expect(err).To(MatchError(ContainSubstring(`invalid traffic rule CIDR "192.168.1.1"`)))
+		})
+	})
+})
@@ -17,6 +17,7 @@ import (
 	vpcv1alpha1 "github.com/vmware-tanzu/nsx-operator/pkg/apis/vpc/v1alpha1"
 	ncpv1alpha1 "github.com/vmware-tanzu/vm-operator/external/ncp/api/v1alpha1"
 
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
 	"github.com/vmware-tanzu/vm-operator/pkg/util/resize"
 )
 
@@ -29,12 +30,22 @@ func ReconcileNetworkInterfaces(
 	var deviceChanges []vimtypes.BaseVirtualDeviceConfigSpec
 
 	for idx, r := range results.Results {
-		matchingIdx := FindMatchingEthCard(currentEthCards, r.Device.(vimtypes.BaseVirtualEthernetCard))
+		matchingIdx := FindMatchingEthCard(currentEthCards, r.Device.(vimtypes.BaseVirtualEthernetCard), r.Type)
 		if matchingIdx >= 0 {
 			// Exact match. Claim it by removing the device from the current ethernet cards.
 			matchDev := currentEthCards[matchingIdx].(vimtypes.BaseVirtualEthernetCard).GetVirtualEthernetCard()
 			results.Results[idx].DeviceKey = matchDev.Key
 			results.Results[idx].MacAddress = matchDev.MacAddress
+
+			// The QoS can be changed, or removed, without replacing the device.
+			if ra, changed := EthCardResourceAllocationChange(r.QoS, r.QoSApplied, matchDev.ResourceAllocation); changed {
+				matchDev.ResourceAllocation = ra
+				deviceChanges = append(deviceChanges, &vimtypes.VirtualDeviceConfigSpec{
+					Device:    currentEthCards[matchingIdx],
+					Operation: vimtypes.VirtualDeviceConfigSpecOperationEdit,
+				})
+			}
+
 			currentEthCards = slices.Delete(currentEthCards, matchingIdx, matchingIdx+1)
 		} else {
 			existingIdx := findExistingEthCardForOrphanedCR(ctx, r.Name, results.OrphanedNetworkInterfaces, currentEthCards)
@@ -46,6 +57,9 @@ func ReconcileNetworkInterfaces(
 				ethDev.AddressType = r.Device.(vimtypes.BaseVirtualEthernetCard).GetVirtualEthernetCard().AddressType
 				ethDev.MacAddress = r.Device.(vimtypes.BaseVirtualEthernetCard).GetVirtualEthernetCard().MacAddress
 				ethDev.ExternalId = r.Device.(vimtypes.BaseVirtualEthernetCard).GetVirtualEthernetCard().ExternalId
+				if ra, changed := EthCardResourceAllocationChange(r.QoS, r.QoSApplied, ethDev.ResourceAllocation); changed {
+					ethDev.ResourceAllocation = ra
+				}
 
 				deviceChanges = append(deviceChanges, &vimtypes.VirtualDeviceConfigSpec{
 					Device:    editDev,
@@ -106,14 +120,29 @@ func findExistingEthCardForOrphanedCR(
 	return -1
 }
 
+// FindMatchingEthCard returns the index of the current ethernet card that
+// matches the expected ethernet card, or -1 if there is no match. When the
+// interface type is set, the current card must also be of that type and its
+// SR-IOV or DVX backing must match the expected card's.
 func FindMatchingEthCard(
 	currentEthCards object.VirtualDeviceList,
-	ethCard vimtypes.BaseVirtualEthernetCard) int {
+	ethCard vimtypes.BaseVirtualEthernetCard,
+	interfaceType vmopv1.VirtualMachineNetworkInterfaceType) int {
 
 	ethDev := ethCard.GetVirtualEthernetCard()
 	matchingIdx := -1
 
 	for idx := range currentEthCards {
+		if !EthCardMatchesInterfaceType(currentEthCards[idx], interfaceType) {
+			continue
+		}
+
+		if a, ok := ethCard.(*vimtypes.VirtualSriovEthernetCard); ok && interfaceType != "" {
+			if !matchSriovEthCardBackings(a, currentEthCards[idx].(*vimtypes.VirtualSriovEthernetCard)) {
+				continue
+			}
+		}
+
 		curDev := currentEthCards[idx].(vimtypes.BaseVirtualEthernetCard).GetVirtualEthernetCard()
 
 		if ethDev.AddressType == string(vimtypes.VirtualEthernetCardMacTypeManual) {
@@ -150,3 +179,26 @@ func FindMatchingEthCard(
 
 	return matchingIdx
 }
+
+// matchSriovEthCardBackings returns true if the SR-IOV and DVX backings of the
+// current SR-IOV card match the expected card. A physical function that is
+// not specified in the expected card matches any assigned physical function.
+func matchSriovEthCardBackings(
+	expectedCard, curCard *vimtypes.VirtualSriovEthernetCard) bool {
+
+	if eb := expectedCard.SriovBacking; eb != nil {
+		if curCard.SriovBacking == nil ||
+			!resize.MatchVirtualSriovEthernetCardSriovBackingInfo(eb, curCard.SriovBacking) {
+			return false
+		}
+	}
+
+	if eb := expectedCard.DvxBackingInfo; eb != nil {
+		if curCard.DvxBackingInfo == nil ||
+			!resize.MatchVirtualPCIPassthroughDVXBackingInfo(eb, curCard.DvxBackingInfo) {
+			return false
+		}
+	}
+
+	return true
+}
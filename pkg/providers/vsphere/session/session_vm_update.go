@@ -288,7 +288,8 @@ func (s *Session) reconcilePoweredOffOrPoweredOnVM(
 		if err := s.poweredOnReconfigure(
 			vmCtx,
 			vcVM,
-			vmCtx.MoVM.Config); err != nil {
+			vmCtx.MoVM.Config,
+			&networkResults); err != nil {
 
 			return err
 		}
@@ -331,7 +332,8 @@ func (s *Session) reconcilePoweredOffOrPoweredOnVM(
 func (s *Session) poweredOnReconfigure(
 	vmCtx pkgctx.VirtualMachineContext,
 	vcVM *object.VirtualMachine,
-	config *vimtypes.VirtualMachineConfigInfo) error {
+	config *vimtypes.VirtualMachineConfigInfo,
+	networkResults *network.NetworkInterfaceResults) error {
 
 	configSpec := &vimtypes.VirtualMachineConfigSpec{}
 
@@ -351,6 +353,21 @@ func (s *Session) poweredOnReconfigure(
 		return fmt.Errorf("update CD-ROM device connection error: %w", err)
 	}
 
+	if pkgcfg.FromContext(vmCtx).Features.MutableNetworks {
+		// Hot add and remove the ethernet cards for any interfaces that were
+		// added to or removed from the spec. The guest's network config is
+		// refreshed afterwards when the bootstrap state is reconciled.
+		currentEthCards := object.VirtualDeviceList(config.Hardware.Device).
+			SelectByType((*vimtypes.VirtualEthernetCard)(nil))
+
+		ethCardDeviceChanges, err := UpdateEthCardDeviceChanges(vmCtx, networkResults, currentEthCards)
+		if err != nil {
+			return err
+		}
+		configSpec.DeviceChange = append(configSpec.DeviceChange, ethCardDeviceChanges...)
+		network.LockMemoryReservationForSRIOV(config, configSpec)
+	}
+
 	if err := doReconfigure(
 		logr.NewContext(
 			vmCtx,
@@ -613,6 +630,7 @@ func (s *Session) getConfigSpecForPoweredOffVM(
 		return nil, false, err
 	}
 	configSpec.DeviceChange = append(configSpec.DeviceChange, ethCardDeviceChanges...)
+	network.LockMemoryReservationForSRIOV(config, configSpec)
 
 	cdromDeviceChanges, err := virtualmachine.UpdateCdromDeviceChanges(vmCtx, s.Client.RestClient(), s.K8sClient, virtualDevices)
 	if err != nil {
@@ -762,7 +780,7 @@ func (s *Session) fixupMacAddressMutableNetworks(
 			continue
 		}
 
-		matchingIdx := network.FindMatchingEthCard(networkDevices, r.Device.(vimtypes.BaseVirtualEthernetCard))
+		matchingIdx := network.FindMatchingEthCard(networkDevices, r.Device.(vimtypes.BaseVirtualEthernetCard), r.Type)
 		if matchingIdx >= 0 {
 			matchDev := networkDevices[matchingIdx].(vimtypes.BaseVirtualEthernetCard).GetVirtualEthernetCard()
 			networkResults.Results[idx].DeviceKey = matchDev.Key
@@ -976,6 +994,15 @@ func (s *Session) reconcileNetworkAndGuestCustomizationState(
 		return err
 	}
 
+	if err := network.ReconcileTrafficFilters(
+		vmCtx,
+		s.K8sClient,
+		s.Client.VimClient(),
+		networkResults); err != nil {
+
+		return err
+	}
+
 	// Get the information required to bootstrap/customize the VM. This is
 	// retrieved outside of the customize/DoBootstrap call path in order to use
 	// the information to update the VM object's status with the resolved,
@@ -993,6 +1020,10 @@ func (s *Session) reconcileNetworkAndGuestCustomizationState(
 	// network configuration.
 	vmlifecycle.UpdateNetworkStatusConfig(vmCtx.VM, bootstrapArgs)
 
+	bootstrapArgs.FileManager = vmlifecycle.NewDatastoreFileManager(
+		s.Client.VimClient(),
+		s.Client.Datacenter())
+
 	return vmlifecycle.DoBootstrap(
 		vmCtx,
 		vcVM,
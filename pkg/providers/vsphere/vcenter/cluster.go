@@ -10,6 +10,7 @@ import (
 	"github.com/vmware/govmomi/object"
 	"github.com/vmware/govmomi/property"
 	"github.com/vmware/govmomi/vim25/mo"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
 )
 
 // ClusterMinCPUFreq returns the minimum frequency across all the hosts in the cluster. This is needed to
@@ -48,3 +49,77 @@ func ClusterMinCPUFreq(ctx context.Context, cluster *object.ClusterComputeResour
 
 	return minFreq, nil
 }
+
+// ClusterMaxHardwareVersion returns the highest hardware version with which VMs
+// may be created in the cluster. Zero is returned if the cluster does not have
+// an environment browser.
+func ClusterMaxHardwareVersion(
+	ctx context.Context,
+	cluster *object.ClusterComputeResource) (vimtypes.HardwareVersion, error) {
+
+	var cr mo.ComputeResource
+	if err := cluster.Properties(ctx, cluster.Reference(), []string{"environmentBrowser"}, &cr); err != nil {
+		return 0, err
+	}
+
+	if cr.EnvironmentBrowser == nil {
+		return 0, nil
+	}
+
+	descriptors, err := object.NewEnvironmentBrowser(cluster.Client(), *cr.EnvironmentBrowser).
+		QueryConfigOptionDescriptor(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var maxVersion vimtypes.HardwareVersion
+	for i := range descriptors {
+		d := descriptors[i]
+		if d.CreateSupported == nil || !*d.CreateSupported {
+			continue
+		}
+		if v, err := vimtypes.ParseHardwareVersion(d.Key); err == nil && v > maxVersion {
+			maxVersion = v
+		}
+	}
+
+	return maxVersion, nil
+}
+
+// ClusterGuestOSDescriptor returns the descriptor of the guest OS with the
+// provided ID for the cluster. Nil is returned if the cluster does not have an
+// environment browser or the guest OS is not known to it.
+func ClusterGuestOSDescriptor(
+	ctx context.Context,
+	cluster *object.ClusterComputeResource,
+	guestID string) (*vimtypes.GuestOsDescriptor, error) {
+
+	var cr mo.ComputeResource
+	if err := cluster.Properties(ctx, cluster.Reference(), []string{"environmentBrowser"}, &cr); err != nil {
+		return nil, err
+	}
+
+	if cr.EnvironmentBrowser == nil {
+		return nil, nil
+	}
+
+	// The descriptors are filtered by the guest ID, but all of them are
+	// returned if none match.
+	option, err := object.NewEnvironmentBrowser(cluster.Client(), *cr.EnvironmentBrowser).
+		QueryConfigOption(ctx, &vimtypes.EnvironmentBrowserConfigOptionQuerySpec{
+			GuestId: []string{guestID},
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	if option != nil {
+		for i := range option.GuestOSDescriptor {
+			if option.GuestOSDescriptor[i].Id == guestID {
+				return &option.GuestOSDescriptor[i], nil
+			}
+		}
+	}
+
+	return nil, nil
+}
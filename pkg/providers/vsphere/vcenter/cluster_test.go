@@ -7,6 +7,7 @@ package vcenter_test
 import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
 
 	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/vcenter"
 	"github.com/vmware-tanzu/vm-operator/test/builder"
@@ -14,6 +15,8 @@ import (
 
 func clusterTests() {
 	Describe("ClusterMinCPUFreq", minFreq)
+	Describe("ClusterMaxHardwareVersion", maxHardwareVersion)
+	Describe("ClusterGuestOSDescriptor", guestOSDescriptor)
 }
 
 func minFreq() {
@@ -46,3 +49,63 @@ func minFreq() {
 		})
 	})
 }
+
+func maxHardwareVersion() {
+	var (
+		ctx        *builder.TestContextForVCSim
+		testConfig builder.VCSimTestConfig
+	)
+
+	BeforeEach(func() {
+		testConfig = builder.VCSimTestConfig{}
+	})
+
+	JustBeforeEach(func() {
+		ctx = suite.NewTestContextForVCSim(testConfig)
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+	})
+
+	It("returns the highest hardware version supported by the cluster", func() {
+		hv, err := vcenter.ClusterMaxHardwareVersion(ctx, ctx.GetFirstClusterFromFirstZone())
+		Expect(err).ToNot(HaveOccurred())
+		Expect(hv).To(Equal(vimtypes.MaxValidHardwareVersion))
+	})
+}
+
+func guestOSDescriptor() {
+	var (
+		ctx        *builder.TestContextForVCSim
+		testConfig builder.VCSimTestConfig
+	)
+
+	BeforeEach(func() {
+		testConfig = builder.VCSimTestConfig{}
+	})
+
+	JustBeforeEach(func() {
+		ctx = suite.NewTestContextForVCSim(testConfig)
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+	})
+
+	It("returns the descriptor of the guest OS", func() {
+		desc, err := vcenter.ClusterGuestOSDescriptor(ctx, ctx.GetFirstClusterFromFirstZone(), "ubuntu64Guest")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(desc).ToNot(BeNil())
+		Expect(desc.Id).To(Equal("ubuntu64Guest"))
+		Expect(desc.Family).To(Equal(string(vimtypes.VirtualMachineGuestOsFamilyLinuxGuest)))
+	})
+
+	It("returns nil for an unknown guest OS", func() {
+		desc, err := vcenter.ClusterGuestOSDescriptor(ctx, ctx.GetFirstClusterFromFirstZone(), "unknownGuest")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(desc).To(BeNil())
+	})
+}
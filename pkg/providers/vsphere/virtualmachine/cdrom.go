@@ -8,6 +8,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"path"
 
 	"github.com/vmware/govmomi/object"
 	"github.com/vmware/govmomi/vapi/library"
@@ -20,6 +21,7 @@ import (
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
 	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
 	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/constants"
 	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
 )
 
@@ -44,7 +46,7 @@ func UpdateCdromDeviceChanges(
 		libManager                     = library.NewManager(restClient)
 	)
 
-	for _, specCdrom := range vmCtx.VM.Spec.Cdrom {
+	for _, specCdrom := range desiredCdromSpecs(vmCtx.VM) {
 		imageRef := specCdrom.Image
 		// Sync the content library file if needed to connect the CD-ROM device.
 		syncFile := ptr.Deref(specCdrom.Connected)
@@ -102,6 +104,13 @@ func UpdateCdromDeviceChanges(
 			continue
 		}
 
+		if isNoCloudSeedCdrom(cdrom) {
+			// The Cloud-Init NoCloud seed ISO is managed by the bootstrap
+			// provider.
+			newCurDevices = append(newCurDevices, cdrom)
+			continue
+		}
+
 		deviceChanges = append(deviceChanges, &vimtypes.VirtualDeviceConfigSpec{
 			Device:    cdrom,
 			Operation: vimtypes.VirtualDeviceConfigSpecOperationRemove,
@@ -138,7 +147,7 @@ func UpdateConfigSpecCdromDeviceConnection(
 	configSpec *vimtypes.VirtualMachineConfigSpec) error {
 
 	var (
-		cdromSpec                    = vmCtx.VM.Spec.Cdrom
+		cdromSpec                    = desiredCdromSpecs(vmCtx.VM)
 		curDevices                   = object.VirtualDeviceList(config.Hardware.Device)
 		backingFileNameToCdromSpec   = make(map[string]vmopv1.VirtualMachineCdromSpec, len(cdromSpec))
 		backingFileNameToCdromDevice = make(map[string]vimtypes.BaseVirtualDevice, len(cdromSpec))
@@ -179,6 +188,39 @@ func UpdateConfigSpecCdromDeviceConnection(
 	return nil
 }
 
+// desiredCdromSpecs returns the VM's CD-ROM specs with the connection state of
+// any CD-ROM that should be disconnected after a successful guest bootstrap
+// updated to reflect that.
+func desiredCdromSpecs(vm *vmopv1.VirtualMachine) []vmopv1.VirtualMachineCdromSpec {
+	if !conditions.IsTrue(vm, vmopv1.GuestBootstrapCondition) {
+		return vm.Spec.Cdrom
+	}
+
+	cdromSpecs := make([]vmopv1.VirtualMachineCdromSpec, len(vm.Spec.Cdrom))
+	for i, c := range vm.Spec.Cdrom {
+		if ptr.Deref(c.DisconnectAfterBootstrap) {
+			c.Connected = ptr.To(false)
+		}
+		cdromSpecs[i] = c
+	}
+
+	return cdromSpecs
+}
+
+// isNoCloudSeedCdrom returns true if the CD-ROM device is backed by the
+// Cloud-Init NoCloud seed ISO in the VM's directory.
+func isNoCloudSeedCdrom(cdrom *vimtypes.VirtualCdrom) bool {
+	b, ok := cdrom.Backing.(*vimtypes.VirtualCdromIsoBackingInfo)
+	if !ok {
+		return false
+	}
+	var p object.DatastorePath
+	if !p.FromString(b.FileName) {
+		return false
+	}
+	return path.Base(p.Path) == constants.CloudInitNoCloudSeedISOFileName
+}
+
 // getBackingFileNameByImageRef returns the ISO type content library file name
 // based on the given VirtualMachineImageRef. It also syncs the content library
 // if needed to ensure the file is available for CD-ROM connection.
@@ -21,6 +21,7 @@ import (
 	imgregv1a1 "github.com/vmware-tanzu/image-registry-operator-api/api/v1alpha1"
 
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
 	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
 	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
 	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/virtualmachine"
@@ -105,6 +106,30 @@ func cdromTests() {
 				})
 			})
 
+			When("VM has the Cloud-Init NoCloud seed CD-ROM device", func() {
+
+				BeforeEach(func() {
+					vmCtx.VM.Spec.Cdrom = nil
+					curDevices = object.VirtualDeviceList{
+						&vimtypes.VirtualCdrom{
+							VirtualDevice: vimtypes.VirtualDevice{
+								Key: cdromDeviceKey1,
+								Backing: &vimtypes.VirtualCdromIsoBackingInfo{
+									VirtualDeviceFileBackingInfo: vimtypes.VirtualDeviceFileBackingInfo{
+										FileName: "[datastore1] my-vm/cidata.iso",
+									},
+								},
+								ControllerKey: ideControllerKey,
+							},
+						},
+					}
+				})
+
+				It("should not remove the CD-ROM device", func() {
+					Expect(result).To(BeEmpty())
+				})
+			})
+
 			When("VM.Spec.Cdrom adds a new CD-ROM device", func() {
 
 				BeforeEach(func() {
@@ -475,6 +500,62 @@ func cdromTests() {
 				})
 			})
 
+			When("VM.Spec.Cdrom disconnects an existing CD-ROM device after bootstrap", func() {
+
+				BeforeEach(func() {
+					vmCtx.VM.Spec.Cdrom = []vmopv1.VirtualMachineCdromSpec{
+						{
+							Name: cdromName1,
+							Image: vmopv1.VirtualMachineImageRef{
+								Name: vmiName,
+								Kind: vmiKind,
+							},
+							AllowGuestControl:        ptr.To(true),
+							Connected:                ptr.To(true),
+							DisconnectAfterBootstrap: ptr.To(true),
+						},
+					}
+					curDevices = object.VirtualDeviceList{
+						&vimtypes.VirtualCdrom{
+							VirtualDevice: vimtypes.VirtualDevice{
+								Key: cdromDeviceKey1,
+								Backing: &vimtypes.VirtualCdromIsoBackingInfo{
+									VirtualDeviceFileBackingInfo: vimtypes.VirtualDeviceFileBackingInfo{
+										FileName: vmiFileName,
+									},
+								},
+								Connectable: &vimtypes.VirtualDeviceConnectInfo{
+									AllowGuestControl: true,
+									StartConnected:    true,
+									Connected:         false,
+								},
+								ControllerKey: ideControllerKey,
+								UnitNumber:    new(int32),
+							},
+						},
+					}
+				})
+
+				When("the guest bootstrap has not succeeded", func() {
+
+					It("should not update the existing CD-ROM device", func() {
+						Expect(result).To(BeEmpty())
+					})
+				})
+
+				When("the guest bootstrap has succeeded", func() {
+
+					BeforeEach(func() {
+						conditions.MarkTrue(vmCtx.VM, vmopv1.GuestBootstrapCondition)
+					})
+
+					It("should disconnect the existing CD-ROM device", func() {
+						Expect(result).To(HaveLen(1))
+						verifyCdromDeviceConfigSpec(result[0], vimtypes.VirtualDeviceConfigSpecOperationEdit, false, false, true, ideControllerKey, 0, vmiFileName)
+					})
+				})
+			})
+
 			When("VM.spec.Cdrom replaces an existing CD-ROM device", func() {
 
 				BeforeEach(func() {
@@ -284,3 +284,68 @@ func initResourceAllocation(ap **vimtypes.ResourceAllocationInfo) {
 		a.Limit = ptr.To[int64](-1)
 	}
 }
+
+// ConfigureConfigSpecForISO updates the ConfigSpec of a VM that is deployed
+// from an ISO image. A blank, thin provisioned boot disk of the given capacity
+// is added if the ConfigSpec does not already have a disk, and the VM is set to
+// boot from the CD-ROM first. Once the boot order list is exhausted, the
+// default boot device algorithm is used, which will boot from the disk after
+// the guest OS has been installed.
+func ConfigureConfigSpecForISO(
+	configSpec *vimtypes.VirtualMachineConfigSpec,
+	bootDiskCapacityInBytes int64,
+	storageProfileID string) error {
+
+	hasDisk := util.HasDeviceChangeDeviceByType[*vimtypes.VirtualDisk](configSpec.DeviceChange)
+
+	if !hasDisk && bootDiskCapacityInBytes > 0 {
+		// Pick a temporary key that does not collide with any of the keys
+		// already in the ConfigSpec.
+		diskKey := int32(-1)
+		for i := range configSpec.DeviceChange {
+			if spec := configSpec.DeviceChange[i].GetVirtualDeviceConfigSpec(); spec != nil && spec.Device != nil {
+				if key := spec.Device.GetVirtualDevice().Key; key <= diskKey {
+					diskKey = key - 1
+				}
+			}
+		}
+
+		diskSpec := &vimtypes.VirtualDeviceConfigSpec{
+			Operation:     vimtypes.VirtualDeviceConfigSpecOperationAdd,
+			FileOperation: vimtypes.VirtualDeviceConfigSpecFileOperationCreate,
+			Device: &vimtypes.VirtualDisk{
+				CapacityInBytes: bootDiskCapacityInBytes,
+				VirtualDevice: vimtypes.VirtualDevice{
+					Key: diskKey,
+					Backing: &vimtypes.VirtualDiskFlatVer2BackingInfo{
+						DiskMode:        string(vimtypes.VirtualDiskModePersistent),
+						ThinProvisioned: ptr.To(true),
+					},
+				},
+			},
+		}
+		if storageProfileID != "" {
+			diskSpec.Profile = []vimtypes.BaseVirtualMachineProfileSpec{
+				&vimtypes.VirtualMachineDefinedProfileSpec{
+					ProfileId: storageProfileID,
+				},
+			}
+		}
+		configSpec.DeviceChange = append(configSpec.DeviceChange, diskSpec)
+
+		if err := util.EnsureDisksHaveControllers(configSpec); err != nil {
+			return err
+		}
+	}
+
+	if configSpec.BootOptions == nil {
+		configSpec.BootOptions = &vimtypes.VirtualMachineBootOptions{}
+	}
+	if len(configSpec.BootOptions.BootOrder) == 0 {
+		configSpec.BootOptions.BootOrder = []vimtypes.BaseVirtualMachineBootOptionsBootableDevice{
+			&vimtypes.VirtualMachineBootOptionsBootableCdromDevice{},
+		}
+	}
+
+	return nil
+}
@@ -661,6 +661,101 @@ var _ = Describe("ConfigSpecFromVMClassDevices", func() {
 	})
 })
 
+var _ = Describe("ConfigureConfigSpecForISO", func() {
+
+	var (
+		configSpec vimtypes.VirtualMachineConfigSpec
+		capacity   int64
+	)
+
+	BeforeEach(func() {
+		configSpec = vimtypes.VirtualMachineConfigSpec{}
+		capacity = 10 * 1024 * 1024 * 1024
+	})
+
+	JustBeforeEach(func() {
+		Expect(virtualmachine.ConfigureConfigSpecForISO(&configSpec, capacity, "my-profile-id")).To(Succeed())
+	})
+
+	It("adds a blank boot disk with a controller", func() {
+		var (
+			disk     *vimtypes.VirtualDisk
+			diskSpec *vimtypes.VirtualDeviceConfigSpec
+			ctrlKey  int32
+		)
+		for _, dc := range configSpec.DeviceChange {
+			spec := dc.GetVirtualDeviceConfigSpec()
+			switch dev := spec.Device.(type) {
+			case *vimtypes.VirtualDisk:
+				disk, diskSpec = dev, spec
+			case *vimtypes.ParaVirtualSCSIController:
+				ctrlKey = dev.Key
+			}
+		}
+
+		Expect(disk).ToNot(BeNil())
+		Expect(disk.CapacityInBytes).To(Equal(capacity))
+		Expect(disk.ControllerKey).To(Equal(ctrlKey))
+		Expect(diskSpec.Operation).To(Equal(vimtypes.VirtualDeviceConfigSpecOperationAdd))
+		Expect(diskSpec.FileOperation).To(Equal(vimtypes.VirtualDeviceConfigSpecFileOperationCreate))
+		Expect(diskSpec.Profile).To(HaveLen(1))
+		Expect(diskSpec.Profile[0].(*vimtypes.VirtualMachineDefinedProfileSpec).ProfileId).To(Equal("my-profile-id"))
+
+		backing, ok := disk.Backing.(*vimtypes.VirtualDiskFlatVer2BackingInfo)
+		Expect(ok).To(BeTrue())
+		Expect(backing.ThinProvisioned).To(HaveValue(BeTrue()))
+	})
+
+	It("sets the VM to boot from the CD-ROM first", func() {
+		Expect(configSpec.BootOptions).ToNot(BeNil())
+		Expect(configSpec.BootOptions.BootOrder).To(HaveLen(1))
+		Expect(configSpec.BootOptions.BootOrder[0]).To(BeAssignableToTypeOf(&vimtypes.VirtualMachineBootOptionsBootableCdromDevice{}))
+	})
+
+	When("the boot disk capacity is not set", func() {
+		BeforeEach(func() {
+			capacity = 0
+		})
+
+		It("does not add a disk", func() {
+			Expect(configSpec.DeviceChange).To(BeEmpty())
+			Expect(configSpec.BootOptions.BootOrder).To(HaveLen(1))
+		})
+	})
+
+	When("the ConfigSpec already has a disk", func() {
+		BeforeEach(func() {
+			configSpec.DeviceChange = []vimtypes.BaseVirtualDeviceConfigSpec{
+				&vimtypes.VirtualDeviceConfigSpec{
+					Operation: vimtypes.VirtualDeviceConfigSpecOperationAdd,
+					Device: &vimtypes.VirtualDisk{
+						VirtualDevice: vimtypes.VirtualDevice{Key: -1},
+					},
+				},
+			}
+		})
+
+		It("does not add another disk", func() {
+			Expect(configSpec.DeviceChange).To(HaveLen(1))
+		})
+	})
+
+	When("the ConfigSpec already has a boot order", func() {
+		BeforeEach(func() {
+			configSpec.BootOptions = &vimtypes.VirtualMachineBootOptions{
+				BootOrder: []vimtypes.BaseVirtualMachineBootOptionsBootableDevice{
+					&vimtypes.VirtualMachineBootOptionsBootableEthernetDevice{},
+				},
+			}
+		})
+
+		It("does not change the boot order", func() {
+			Expect(configSpec.BootOptions.BootOrder).To(HaveLen(1))
+			Expect(configSpec.BootOptions.BootOrder[0]).To(BeAssignableToTypeOf(&vimtypes.VirtualMachineBootOptionsBootableEthernetDevice{}))
+		})
+	})
+})
+
 func assertInstanceStorageDeviceChange(
 	deviceChange vimtypes.BaseVirtualDeviceConfigSpec,
 	expectedUnitNumber int32,
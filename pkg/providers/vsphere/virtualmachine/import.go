@@ -0,0 +1,279 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachine
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/property"
+	"github.com/vmware/govmomi/vim25/mo"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/pkg/util"
+)
+
+// ImportSourceProperties are the properties of a vSphere VM that are
+// retrieved when the VM is imported.
+var ImportSourceProperties = []string{
+	"name",
+	"parent",
+	"resourcePool",
+	"network",
+	"config.uuid",
+	"config.instanceUuid",
+	"config.template",
+	"config.managedBy",
+	"config.hardware",
+	"runtime.powerState",
+}
+
+// IsManagedByVMOperator returns true if the VM is already managed by VM
+// Operator.
+func IsManagedByVMOperator(moVM mo.VirtualMachine) bool {
+	return moVM.Config != nil &&
+		moVM.Config.ManagedBy != nil &&
+		moVM.Config.ManagedBy.ExtensionKey == vmopv1.ManagedByExtensionKey
+}
+
+// GetImportSourceStatus returns the observed state of a vSphere VM that is
+// imported as a VirtualMachine resource with the provided name. The status's
+// Zone field is not set.
+func GetImportSourceStatus(
+	ctx context.Context,
+	finder *find.Finder,
+	vcVM *object.VirtualMachine,
+	moVM mo.VirtualMachine,
+	targetName string) (*vmopv1.VirtualMachineImportRequestSourceStatus, error) {
+
+	if moVM.Config == nil {
+		return nil, fmt.Errorf("vm %s has no config", vcVM.Reference().Value)
+	}
+
+	status := &vmopv1.VirtualMachineImportRequestSourceStatus{
+		ID:           vcVM.Reference().Value,
+		Name:         moVM.Name,
+		InstanceUUID: moVM.Config.InstanceUuid,
+		BiosUUID:     moVM.Config.Uuid,
+		PowerState:   convertImportSourcePowerState(moVM.Runtime.PowerState),
+	}
+
+	configSpec, err := util.MarshalConfigSpecToJSON(vimtypes.VirtualMachineConfigSpec{
+		NumCPUs:           moVM.Config.Hardware.NumCPU,
+		NumCoresPerSocket: moVM.Config.Hardware.NumCoresPerSocket,
+		MemoryMB:          int64(moVM.Config.Hardware.MemoryMB),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal vm config spec: %w", err)
+	}
+	status.ConfigSpec = configSpec
+
+	devices := object.VirtualDeviceList(moVM.Config.Hardware.Device)
+
+	disks, err := getImportSourceDisks(ctx, finder, devices, targetName)
+	if err != nil {
+		return nil, err
+	}
+	status.Disks = disks
+
+	networks, err := getImportSourceNetworks(ctx, vcVM, moVM, devices)
+	if err != nil {
+		return nil, err
+	}
+	status.Networks = networks
+
+	return status, nil
+}
+
+// ImportVirtualMachine moves the vSphere VM into the provided folder and
+// resource pool, detaches its data disks without deleting their backing
+// files, and marks the VM as managed by VM Operator. Each step is skipped if it
+// was already done, so this function may be called more than once. The VM must
+// be powered off.
+func ImportVirtualMachine(
+	ctx context.Context,
+	vcVM *object.VirtualMachine,
+	moVM mo.VirtualMachine,
+	folder *object.Folder,
+	pool *object.ResourcePool) error {
+
+	logger := logr.FromContextOrDiscard(ctx)
+
+	if moVM.Config == nil {
+		return fmt.Errorf("vm %s has no config", vcVM.Reference().Value)
+	}
+
+	if moVM.Runtime.PowerState != vimtypes.VirtualMachinePowerStatePoweredOff {
+		return fmt.Errorf("vm %s is not powered off", vcVM.Reference().Value)
+	}
+
+	if moVM.Parent == nil || moVM.Parent.Value != folder.Reference().Value {
+		logger.Info("Moving imported VM into folder", "folderID", folder.Reference().Value)
+		t, err := folder.MoveInto(ctx, []vimtypes.ManagedObjectReference{vcVM.Reference()})
+		if err != nil {
+			return err
+		}
+		if err := t.Wait(ctx); err != nil {
+			return fmt.Errorf("failed to move vm into folder: %w", err)
+		}
+	}
+
+	if moVM.ResourcePool == nil || moVM.ResourcePool.Value != pool.Reference().Value {
+		logger.Info("Relocating imported VM into resource pool", "poolID", pool.Reference().Value)
+		poolRef := pool.Reference()
+		t, err := vcVM.Relocate(ctx, vimtypes.VirtualMachineRelocateSpec{Pool: &poolRef}, "")
+		if err != nil {
+			return err
+		}
+		if err := t.Wait(ctx); err != nil {
+			return fmt.Errorf("failed to relocate vm into resource pool: %w", err)
+		}
+	}
+
+	if dataDisks := getDataDisks(object.VirtualDeviceList(moVM.Config.Hardware.Device)); len(dataDisks) > 0 {
+		logger.Info("Detaching data disks from imported VM", "count", len(dataDisks))
+		if err := vcVM.RemoveDevice(ctx, true, dataDisks...); err != nil {
+			return fmt.Errorf("failed to detach data disks: %w", err)
+		}
+	}
+
+	if !IsManagedByVMOperator(moVM) {
+		logger.Info("Setting managed-by on imported VM")
+		t, err := vcVM.Reconfigure(ctx, vimtypes.VirtualMachineConfigSpec{
+			ManagedBy: &vimtypes.ManagedByInfo{
+				ExtensionKey: vmopv1.ManagedByExtensionKey,
+				Type:         vmopv1.ManagedByExtensionType,
+			},
+		})
+		if err != nil {
+			return err
+		}
+		if err := t.Wait(ctx); err != nil {
+			return fmt.Errorf("failed to set managed-by on vm: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// getDataDisks returns all of the VM's disks other than the boot disk, which
+// is the first disk.
+func getDataDisks(devices object.VirtualDeviceList) []vimtypes.BaseVirtualDevice {
+	disks := devices.SelectByType((*vimtypes.VirtualDisk)(nil))
+	if len(disks) < 2 {
+		return nil
+	}
+	return disks[1:]
+}
+
+func getImportSourceDisks(
+	ctx context.Context,
+	finder *find.Finder,
+	devices object.VirtualDeviceList,
+	targetName string) ([]vmopv1.VirtualMachineImportRequestDiskStatus, error) {
+
+	var disks []vmopv1.VirtualMachineImportRequestDiskStatus
+
+	for i, dev := range getDataDisks(devices) {
+		disk := dev.(*vimtypes.VirtualDisk)
+
+		backing, ok := disk.Backing.(vimtypes.BaseVirtualDeviceFileBackingInfo)
+		if !ok {
+			return nil, fmt.Errorf("disk %d has unsupported backing %T", disk.Key, disk.Backing)
+		}
+
+		var dsPath object.DatastorePath
+		if !dsPath.FromString(backing.GetVirtualDeviceFileBackingInfo().FileName) {
+			return nil, fmt.Errorf("disk %d has invalid file name %q",
+				disk.Key, backing.GetVirtualDeviceFileBackingInfo().FileName)
+		}
+
+		ds, err := finder.Datastore(ctx, dsPath.Datastore)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find datastore %q: %w", dsPath.Datastore, err)
+		}
+
+		disks = append(disks, vmopv1.VirtualMachineImportRequestDiskStatus{
+			DiskURLPath: ds.NewURL(dsPath.Path).String(),
+			Capacity:    resource.NewQuantity(disk.CapacityInBytes, resource.BinarySI),
+			ClaimName:   fmt.Sprintf("%s-disk-%d", targetName, i+1),
+		})
+	}
+
+	return disks, nil
+}
+
+func getImportSourceNetworks(
+	ctx context.Context,
+	vcVM *object.VirtualMachine,
+	moVM mo.VirtualMachine,
+	devices object.VirtualDeviceList) ([]vmopv1.VirtualMachineImportRequestNetworkStatus, error) {
+
+	nics := devices.SelectByType((*vimtypes.VirtualEthernetCard)(nil))
+	if len(nics) == 0 {
+		return nil, nil
+	}
+
+	// Map the MoIDs of the VM's networks to their names. The key of a
+	// distributed port group is its MoID.
+	networkNames := map[string]string{}
+	if len(moVM.Network) > 0 {
+		var content []vimtypes.ObjectContent
+		if err := property.DefaultCollector(vcVM.Client()).Retrieve(
+			ctx, moVM.Network, []string{"name"}, &content); err != nil {
+
+			return nil, fmt.Errorf("failed to get vm networks: %w", err)
+		}
+		for _, oc := range content {
+			for _, p := range oc.PropSet {
+				if name, ok := p.Val.(string); ok && p.Name == "name" {
+					networkNames[oc.Obj.Value] = name
+				}
+			}
+		}
+	}
+
+	networks := make([]vmopv1.VirtualMachineImportRequestNetworkStatus, 0, len(nics))
+	for i, dev := range nics {
+		nic := dev.(vimtypes.BaseVirtualEthernetCard).GetVirtualEthernetCard()
+
+		network := vmopv1.VirtualMachineImportRequestNetworkStatus{
+			Name:       fmt.Sprintf("eth%d", i),
+			MacAddress: nic.MacAddress,
+		}
+
+		switch b := nic.Backing.(type) {
+		case *vimtypes.VirtualEthernetCardNetworkBackingInfo:
+			network.Network = b.DeviceName
+		case *vimtypes.VirtualEthernetCardDistributedVirtualPortBackingInfo:
+			network.Network = networkNames[b.Port.PortgroupKey]
+		case *vimtypes.VirtualEthernetCardOpaqueNetworkBackingInfo:
+			network.Network = b.OpaqueNetworkId
+		}
+
+		networks = append(networks, network)
+	}
+
+	return networks, nil
+}
+
+func convertImportSourcePowerState(
+	powerState vimtypes.VirtualMachinePowerState) vmopv1.VirtualMachinePowerState {
+
+	switch powerState {
+	case vimtypes.VirtualMachinePowerStatePoweredOff:
+		return vmopv1.VirtualMachinePowerStateOff
+	case vimtypes.VirtualMachinePowerStatePoweredOn:
+		return vmopv1.VirtualMachinePowerStateOn
+	case vimtypes.VirtualMachinePowerStateSuspended:
+		return vmopv1.VirtualMachinePowerStateSuspended
+	}
+	return ""
+}
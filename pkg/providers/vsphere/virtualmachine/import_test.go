@@ -0,0 +1,149 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachine_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/mo"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/virtualmachine"
+	"github.com/vmware-tanzu/vm-operator/pkg/util"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func importTests() {
+
+	var (
+		ctx  *builder.TestContextForVCSim
+		vcVM *object.VirtualMachine
+		moVM mo.VirtualMachine
+	)
+
+	BeforeEach(func() {
+		ctx = suite.NewTestContextForVCSim(builder.VCSimTestConfig{})
+
+		var err error
+		vcVM, err = ctx.Finder.VirtualMachine(ctx, "DC0_C0_RP0_VM0")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	JustBeforeEach(func() {
+		moVM = mo.VirtualMachine{}
+		Expect(vcVM.Properties(ctx, vcVM.Reference(), virtualmachine.ImportSourceProperties, &moVM)).To(Succeed())
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+	})
+
+	Context("GetImportSourceStatus", func() {
+
+		It("Returns the VM's identity and network adapters", func() {
+			status, err := virtualmachine.GetImportSourceStatus(ctx, ctx.Finder, vcVM, moVM, "my-vm")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(status.ID).To(Equal(vcVM.Reference().Value))
+			Expect(status.Name).To(Equal("DC0_C0_RP0_VM0"))
+			Expect(status.BiosUUID).To(Equal(moVM.Config.Uuid))
+			Expect(status.InstanceUUID).To(Equal(moVM.Config.InstanceUuid))
+			Expect(status.PowerState).To(Equal(vmopv1.VirtualMachinePowerStateOn))
+			Expect(status.Disks).To(BeEmpty())
+			Expect(status.Networks).To(HaveLen(1))
+			Expect(status.Networks[0].Name).To(Equal("eth0"))
+			Expect(status.Networks[0].Network).ToNot(BeEmpty())
+			Expect(status.Networks[0].MacAddress).ToNot(BeEmpty())
+
+			configSpec, err := util.UnmarshalConfigSpecFromJSON(status.ConfigSpec)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(configSpec.NumCPUs).To(Equal(moVM.Config.Hardware.NumCPU))
+			Expect(configSpec.MemoryMB).To(Equal(int64(moVM.Config.Hardware.MemoryMB)))
+			Expect(configSpec.DeviceChange).To(BeEmpty())
+		})
+
+		When("the VM has a data disk", func() {
+			BeforeEach(func() {
+				devices, err := vcVM.Device(ctx)
+				Expect(err).ToNot(HaveOccurred())
+				controller, err := devices.FindDiskController("")
+				Expect(err).ToNot(HaveOccurred())
+				disk := devices.CreateDisk(controller, ctx.Datastore.Reference(),
+					ctx.Datastore.Path("DC0_C0_RP0_VM0/data.vmdk"))
+				disk.CapacityInKB = 1024
+				Expect(vcVM.AddDevice(ctx, disk)).To(Succeed())
+			})
+
+			It("Returns the data disk", func() {
+				status, err := virtualmachine.GetImportSourceStatus(ctx, ctx.Finder, vcVM, moVM, "my-vm")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(status.Disks).To(HaveLen(1))
+				Expect(status.Disks[0].ClaimName).To(Equal("my-vm-disk-1"))
+				Expect(status.Disks[0].DiskURLPath).To(ContainSubstring("data.vmdk"))
+				Expect(status.Disks[0].DiskURLPath).To(ContainSubstring("dsName=" + ctx.Datastore.Name()))
+				Expect(status.Disks[0].Capacity).ToNot(BeNil())
+				Expect(status.Disks[0].Registered).To(BeFalse())
+			})
+		})
+	})
+
+	Context("ImportVirtualMachine", func() {
+		var (
+			folder *object.Folder
+			pool   *object.ResourcePool
+		)
+
+		BeforeEach(func() {
+			nsInfo := ctx.CreateWorkloadNamespace()
+			folder = nsInfo.Folder
+
+			ccr, err := ctx.Finder.ClusterComputeResource(ctx, "DC0_C0")
+			Expect(err).ToNot(HaveOccurred())
+			rp, err := ccr.ResourcePool(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			pool, err = rp.Create(ctx, "import-pool", vimtypes.DefaultResourceConfigSpec())
+			Expect(err).ToNot(HaveOccurred())
+
+			t, err := vcVM.PowerOff(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(t.Wait(ctx)).To(Succeed())
+		})
+
+		It("Moves the VM and marks it as managed", func() {
+			Expect(virtualmachine.IsManagedByVMOperator(moVM)).To(BeFalse())
+			Expect(virtualmachine.ImportVirtualMachine(ctx, vcVM, moVM, folder, pool)).To(Succeed())
+
+			moVM = mo.VirtualMachine{}
+			Expect(vcVM.Properties(ctx, vcVM.Reference(), virtualmachine.ImportSourceProperties, &moVM)).To(Succeed())
+			Expect(moVM.Parent.Value).To(Equal(folder.Reference().Value))
+			Expect(moVM.ResourcePool.Value).To(Equal(pool.Reference().Value))
+			Expect(virtualmachine.IsManagedByVMOperator(moVM)).To(BeTrue())
+
+			By("Importing again is a no-op", func() {
+				Expect(virtualmachine.ImportVirtualMachine(ctx, vcVM, moVM, folder, pool)).To(Succeed())
+			})
+		})
+
+		When("the VM is powered on", func() {
+			BeforeEach(func() {
+				t, err := vcVM.PowerOn(ctx)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(t.Wait(ctx)).To(Succeed())
+			})
+
+			It("Returns an error without changing the VM", func() {
+				err := virtualmachine.ImportVirtualMachine(ctx, vcVM, moVM, folder, pool)
+				Expect(err).To(MatchError(ContainSubstring("is not powered off")))
+
+				moVM = mo.VirtualMachine{}
+				Expect(vcVM.Properties(ctx, vcVM.Reference(), virtualmachine.ImportSourceProperties, &moVM)).To(Succeed())
+				Expect(moVM.Parent.Value).ToNot(Equal(folder.Reference().Value))
+				Expect(virtualmachine.IsManagedByVMOperator(moVM)).To(BeFalse())
+			})
+		})
+	})
+}
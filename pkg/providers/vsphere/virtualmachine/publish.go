@@ -30,6 +30,20 @@ func CreateOVF(
 	cl *imgregv1a1.ContentLibrary,
 	actID string) (string, error) {
 
+	return CreateOVFFromSource(vmCtx, client, vmPubReq, cl, vmCtx.VM.Status.UniqueID, actID)
+}
+
+// CreateOVFFromSource creates an OVF in the content library from the vSphere
+// VM with the given managed object ID, which may differ from the VM in the
+// context, such as when publishing from a snapshot.
+func CreateOVFFromSource(
+	vmCtx pkgctx.VirtualMachineContext,
+	client *rest.Client,
+	vmPubReq *vmopv1.VirtualMachinePublishRequest,
+	cl *imgregv1a1.ContentLibrary,
+	sourceID string,
+	actID string) (string, error) {
+
 	// Use VM Operator specific description so that we can link published items
 	// to the vmPub if anything unexpected happened.
 	descriptionPrefix := fmt.Sprintf(itemDescriptionFormat, string(vmPubReq.UID))
@@ -40,7 +54,7 @@ func CreateOVF(
 
 	source := vcenter.ResourceID{
 		Type:  sourceVirtualMachineType,
-		Value: vmCtx.VM.Status.UniqueID,
+		Value: sourceID,
 	}
 
 	target := vcenter.LibraryTarget{
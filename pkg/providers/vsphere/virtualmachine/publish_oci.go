@@ -0,0 +1,134 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachine
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/vmware/govmomi/nfc"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/ovf"
+	"github.com/vmware/govmomi/vim25/soap"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/oci"
+)
+
+// ExportOVFToOCI exports the VM as an OVF and pushes it to the provided
+// reference as an OCI artifact. The digest of the artifact's manifest is
+// returned.
+func ExportOVFToOCI(
+	vmCtx pkgctx.VirtualMachineContext,
+	vcVM *object.VirtualMachine,
+	vmPubReq *vmopv1.VirtualMachinePublishRequest,
+	client *oci.Client,
+	ref oci.Reference) (string, error) {
+
+	vmCtx.Logger.Info("Exporting OVF from VM", "reference", ref.String())
+
+	lease, err := vcVM.Export(vmCtx)
+	if err != nil {
+		return "", fmt.Errorf("failed to export vm: %w", err)
+	}
+
+	digest, err := exportOVFToOCI(vmCtx, vcVM, vmPubReq, client, ref, lease)
+	if err != nil {
+		if abortErr := lease.Abort(vmCtx, nil); abortErr != nil {
+			vmCtx.Logger.Error(abortErr, "failed to abort export lease")
+		}
+		return "", err
+	}
+
+	return digest, nil
+}
+
+func exportOVFToOCI(
+	vmCtx pkgctx.VirtualMachineContext,
+	vcVM *object.VirtualMachine,
+	vmPubReq *vmopv1.VirtualMachinePublishRequest,
+	client *oci.Client,
+	ref oci.Reference,
+	lease *nfc.Lease) (string, error) {
+
+	info, err := lease.Wait(vmCtx, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to wait for export lease: %w", err)
+	}
+
+	updater := lease.StartUpdater(vmCtx, info)
+	defer updater.Done()
+
+	name := vmPubReq.Status.TargetRef.Item.Name
+	cdp := vimtypes.OvfCreateDescriptorParams{
+		Name:        name,
+		Description: vmPubReq.Status.TargetRef.Item.Description,
+	}
+
+	soapClient := vcVM.Client()
+	layers := make([]oci.Descriptor, 0, len(info.Items))
+	for _, item := range info.Items {
+		item.Path = name + "-" + item.Path
+
+		layer, err := oci.PushOVFFile(vmCtx, client, ref, oci.OVFFile{
+			Name:      item.Path,
+			MediaType: ovfFileMediaType(item.Path),
+			Open: func(ctx context.Context) (io.ReadCloser, error) {
+				r, _, err := soapClient.Download(ctx, item.URL, &soap.Download{
+					Progress: item,
+				})
+				return r, err
+			},
+		})
+		if err != nil {
+			return "", err
+		}
+		layers = append(layers, layer)
+
+		item.Size = layer.Size
+		cdp.OvfFiles = append(cdp.OvfFiles, item.File())
+	}
+
+	if err := lease.Complete(vmCtx); err != nil {
+		return "", fmt.Errorf("failed to complete export lease: %w", err)
+	}
+
+	res, err := ovf.NewManager(soapClient).CreateDescriptor(vmCtx, vcVM, cdp)
+	if err != nil {
+		return "", fmt.Errorf("failed to create ovf descriptor: %w", err)
+	}
+	if len(res.Error) > 0 {
+		return "", fmt.Errorf(
+			"failed to create ovf descriptor: %s",
+			res.Error[0].LocalizedMessage)
+	}
+
+	desc, err := oci.PushOVFManifest(
+		vmCtx,
+		client,
+		ref,
+		[]byte(res.OvfDescriptor),
+		layers,
+		map[string]string{
+			oci.AnnotationPublishRequestUID: string(vmPubReq.UID),
+		})
+	if err != nil {
+		return "", err
+	}
+
+	return desc.Digest, nil
+}
+
+func ovfFileMediaType(name string) string {
+	if strings.EqualFold(path.Ext(name), ".vmdk") {
+		return oci.MediaTypeOVFDisk
+	}
+	return oci.MediaTypeOVFFile
+}
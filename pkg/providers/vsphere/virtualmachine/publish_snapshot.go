@@ -0,0 +1,135 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package virtualmachine
+
+import (
+	"fmt"
+
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/mo"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/constants"
+)
+
+const (
+	snapshotCloneNamePrefix = "vmpub-"
+)
+
+// SnapshotCloneName returns the name of the temporary VM that is cloned from
+// a snapshot in order to publish it.
+func SnapshotCloneName(vmPubReq *vmopv1.VirtualMachinePublishRequest) string {
+	return snapshotCloneNamePrefix + string(vmPubReq.UID)
+}
+
+// CloneFromSnapshot creates a powered off, linked clone of the VM from the
+// snapshot with the given managed object ID. The clone is placed in the same
+// folder and resource pool as the VM so the snapshot's disks can be published
+// without changing the state of the running VM. Any clone left over from a
+// previous attempt is destroyed first.
+func CloneFromSnapshot(
+	vmCtx pkgctx.VirtualMachineContext,
+	vcVM *object.VirtualMachine,
+	vmPubReq *vmopv1.VirtualMachinePublishRequest,
+	snapshotID string) (*object.VirtualMachine, error) {
+
+	var moVM mo.VirtualMachine
+	if err := vcVM.Properties(vmCtx, vcVM.Reference(), []string{"parent", "resourcePool"}, &moVM); err != nil {
+		return nil, fmt.Errorf("failed to get VM properties: %w", err)
+	}
+	if moVM.Parent == nil || moVM.ResourcePool == nil {
+		return nil, fmt.Errorf("VM %s does not have a folder or resource pool", vcVM.Reference().Value)
+	}
+
+	folder := object.NewFolder(vcVM.Client(), *moVM.Parent)
+	cloneName := SnapshotCloneName(vmPubReq)
+
+	if err := destroyStaleSnapshotClone(vmCtx, folder, cloneName); err != nil {
+		return nil, err
+	}
+
+	cloneSpec := vimtypes.VirtualMachineCloneSpec{
+		Location: vimtypes.VirtualMachineRelocateSpec{
+			Pool:         moVM.ResourcePool,
+			DiskMoveType: string(vimtypes.VirtualMachineRelocateDiskMoveOptionsCreateNewChildDiskBacking),
+		},
+		Snapshot: &vimtypes.ManagedObjectReference{
+			Type:  "VirtualMachineSnapshot",
+			Value: snapshotID,
+		},
+		Config: &vimtypes.VirtualMachineConfigSpec{
+			// The clone must not be mistaken for a VM managed by VM Operator.
+			ExtraConfig: []vimtypes.BaseOptionValue{
+				&vimtypes.OptionValue{Key: constants.ExtraConfigVMServiceNamespacedName, Value: ""},
+			},
+		},
+		PowerOn: false,
+	}
+
+	vmCtx.Logger.Info("Cloning VM from snapshot", "snapshotID", snapshotID, "cloneName", cloneName)
+
+	t, err := vcVM.Clone(vmCtx, folder, cloneName, cloneSpec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to clone VM from snapshot: %w", err)
+	}
+
+	taskInfo, err := t.WaitForResult(vmCtx)
+	if err != nil {
+		return nil, fmt.Errorf("clone VM from snapshot task failed: %w", err)
+	}
+
+	cloneRef, ok := taskInfo.Result.(vimtypes.ManagedObjectReference)
+	if !ok {
+		return nil, fmt.Errorf("clone VM from snapshot task returned unexpected result %T", taskInfo.Result)
+	}
+
+	return object.NewVirtualMachine(vcVM.Client(), cloneRef), nil
+}
+
+// DestroySnapshotClone destroys the temporary VM created by CloneFromSnapshot.
+func DestroySnapshotClone(
+	vmCtx pkgctx.VirtualMachineContext,
+	clone *object.VirtualMachine) error {
+
+	vmCtx.Logger.Info("Destroying VM cloned from snapshot", "cloneID", clone.Reference().Value)
+
+	t, err := clone.Destroy(vmCtx)
+	if err != nil {
+		return err
+	}
+
+	return t.Wait(vmCtx)
+}
+
+func destroyStaleSnapshotClone(
+	vmCtx pkgctx.VirtualMachineContext,
+	folder *object.Folder,
+	cloneName string) error {
+
+	ref, err := object.NewSearchIndex(folder.Client()).FindChild(vmCtx, folder, cloneName)
+	if err != nil {
+		return fmt.Errorf("failed to find stale snapshot clone: %w", err)
+	}
+	if ref == nil {
+		return nil
+	}
+
+	clone, ok := ref.(*object.VirtualMachine)
+	if !ok {
+		return fmt.Errorf("object %s with clone name %q is not a VM", ref.Reference().Value, cloneName)
+	}
+
+	var moVM mo.VirtualMachine
+	if err := clone.Properties(vmCtx, clone.Reference(), []string{"runtime.powerState"}, &moVM); err != nil {
+		return err
+	}
+	if moVM.Runtime.PowerState != vimtypes.VirtualMachinePowerStatePoweredOff {
+		return fmt.Errorf("stale snapshot clone %s is not powered off", clone.Reference().Value)
+	}
+
+	return DestroySnapshotClone(vmCtx, clone)
+}
@@ -9,6 +9,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/mo"
 	vimtypes "github.com/vmware/govmomi/vim25/types"
 
 	imgregv1a1 "github.com/vmware-tanzu/image-registry-operator-api/api/v1alpha1"
@@ -77,6 +78,49 @@ func publishTests() {
 		Expect(itemID).NotTo(BeNil())
 	})
 
+	Context("Publish from a snapshot", func() {
+		var snapshotID string
+
+		BeforeEach(func() {
+			vmPub.UID = "dummy-vmpub-uid"
+
+			t, err := vcVM.CreateSnapshot(ctx, "dummy-snapshot", "", false, false)
+			Expect(err).ToNot(HaveOccurred())
+			taskInfo, err := t.WaitForResult(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			snapshotID = taskInfo.Result.(vimtypes.ManagedObjectReference).Value
+		})
+
+		It("Clones the VM from the snapshot and publishes the clone", func() {
+			clone, err := virtualmachine.CloneFromSnapshot(vmCtx, vcVM, vmPub, snapshotID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(clone).ToNot(BeNil())
+			Expect(clone.Reference().Value).ToNot(Equal(vcVM.Reference().Value))
+
+			var moVM mo.VirtualMachine
+			Expect(clone.Properties(ctx, clone.Reference(), []string{"name", "runtime.powerState"}, &moVM)).To(Succeed())
+			Expect(moVM.Name).To(Equal(virtualmachine.SnapshotCloneName(vmPub)))
+			Expect(moVM.Runtime.PowerState).To(Equal(vimtypes.VirtualMachinePowerStatePoweredOff))
+
+			itemID, err := virtualmachine.CreateOVFFromSource(vmCtx, ctx.RestClient, vmPub, cl, clone.Reference().Value, "")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(itemID).NotTo(BeNil())
+
+			Expect(virtualmachine.DestroySnapshotClone(vmCtx, clone)).To(Succeed())
+			_, err = ctx.Finder.VirtualMachine(ctx, virtualmachine.SnapshotCloneName(vmPub))
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("Replaces a stale clone from a previous attempt", func() {
+			stale, err := virtualmachine.CloneFromSnapshot(vmCtx, vcVM, vmPub, snapshotID)
+			Expect(err).ToNot(HaveOccurred())
+
+			clone, err := virtualmachine.CloneFromSnapshot(vmCtx, vcVM, vmPub, snapshotID)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(clone.Reference().Value).ToNot(Equal(stale.Reference().Value))
+		})
+	})
+
 	// TODO: update after vcsim bug is resolved.
 	// Currently if cl doesn't exist, vcsim set notFound http code
 	// but doesn't return immediately, which cause a panic error.
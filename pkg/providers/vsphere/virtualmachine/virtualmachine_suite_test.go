@@ -21,6 +21,7 @@ func vcSimTests() {
 	Describe("GuestInfo", Label(testlabels.VCSim), guestInfoTests)
 	Describe("CD-ROM", Label(testlabels.VCSim), cdromTests)
 	Describe("Snapshot", Label(testlabels.VCSim), snapShotTests)
+	Describe("Import", Label(testlabels.VCSim), importTests)
 }
 
 var suite = builder.NewTestSuite()
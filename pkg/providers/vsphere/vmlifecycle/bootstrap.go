@@ -6,6 +6,7 @@ package vmlifecycle
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"slices"
 	"strings"
@@ -18,6 +19,7 @@ import (
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
 
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
 	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
 	pkgconst "github.com/vmware-tanzu/vm-operator/pkg/constants"
 	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
@@ -48,6 +50,10 @@ type BootstrapData struct {
 
 	CloudConfig *cloudinit.CloudConfigSecretData
 	Sysprep     *sysprep.SecretData
+
+	// VendorData is the Cloud-Init vendor-data from the namespace's
+	// VirtualMachineBootstrapDefaults.
+	VendorData string
 }
 
 type TemplateRenderFunc func(string, string) string
@@ -61,6 +67,10 @@ type BootstrapArgs struct {
 	HostName         string
 	DNSServers       []string
 	SearchSuffixes   []string
+
+	// FileManager is used to manage the files in the VM's directory, such as
+	// the Cloud-Init NoCloud seed ISO.
+	FileManager DatastoreFileManager
 }
 
 var (
@@ -100,6 +110,7 @@ func DoBootstrap(
 		linuxPrep  = bootstrap.LinuxPrep
 		sysPrep    = bootstrap.Sysprep
 		vAppConfig = bootstrap.VAppConfig
+		ignition   = bootstrap.Ignition
 	)
 
 	if sysPrep != nil || vAppConfig != nil {
@@ -121,14 +132,27 @@ func DoBootstrap(
 		configSpec, customSpec, err = BootstrapSysPrep(vmCtx, config, sysPrep, vAppConfig, &bootstrapArgs)
 	case vAppConfig != nil:
 		configSpec, customSpec, err = BootstrapVAppConfig(vmCtx, config, vAppConfig, &bootstrapArgs)
+	case ignition != nil:
+		configSpec, customSpec, err = BootstrapIgnition(vmCtx, config, ignition, &bootstrapArgs)
 	}
 
 	if err != nil {
+		var vAppErr VAppPropertiesError
+		if errors.As(err, &vAppErr) {
+			conditions.MarkFalse(
+				vmCtx.VM,
+				vmopv1.VirtualMachineConditionBootstrapReady,
+				vAppErr.Reason(),
+				"%s",
+				vAppErr.Error())
+		}
 		return fmt.Errorf("failed to create bootstrap data: %w", err)
 	}
 
+	generation := customizationGeneration(vmCtx)
+
 	if configSpec != nil {
-		newHash, err := getVimTypeHash(configSpec)
+		newHash, err := getBootstrapHash(configSpec, generation)
 		if err != nil {
 			return err
 		}
@@ -142,6 +166,12 @@ func DoBootstrap(
 			if err := doReconfigure(vmCtx, vcVM, configSpec); err != nil {
 				return fmt.Errorf("bootstrap reconfigure failed: %w", err)
 			}
+			switch {
+			case vmCtx.MoVM.Runtime.PowerState == vimtypes.VirtualMachinePowerStatePoweredOff:
+				conditions.Delete(vmCtx.VM, vmopv1.GuestBootstrapSyncedCondition)
+			case cloudInit != nil:
+				markCloudInitGuestBootstrapSynced(vmCtx, cloudInit, &bootstrapArgs)
+			}
 			if vmCtx.VM.Annotations == nil {
 				vmCtx.VM.Annotations = map[string]string{}
 			}
@@ -153,20 +183,44 @@ func DoBootstrap(
 	}
 
 	if customSpec != nil {
-		newHash, err := getVimTypeHash(customSpec)
+		newHash, err := getBootstrapHash(customSpec, generation)
 		if err != nil {
 			return err
 		}
 		hashKey := pkgconst.BootstrapHashCustomSpecAnnotationKey
 		curHash := vmCtx.VM.Annotations[hashKey]
-		if newHash == curHash {
+		switch {
+		case newHash == curHash:
 			vmCtx.Logger.V(4).Info(
 				"Skipping bootstrap customize as nothing has changed")
-		} else {
+			if conditions.GetReason(vmCtx.VM, vmopv1.GuestBootstrapSyncedCondition) ==
+				vmopv1.GuestBootstrapPendingPowerCycleReason {
+
+				// The change that was pending was reverted.
+				conditions.Delete(vmCtx.VM, vmopv1.GuestBootstrapSyncedCondition)
+			}
+		case vmCtx.MoVM.Runtime.PowerState == vimtypes.VirtualMachinePowerStatePoweredOn:
+			// Guest customization cannot be applied to a powered on VM, for
+			// example after a network interface was hot added. Leave the hash
+			// as is so the customization is done the next time the VM is
+			// powered on. A VM without a hash was not customized with it, so
+			// there is nothing to report as pending.
+			if curHash != "" {
+				vmCtx.Logger.Info(
+					"Skipping bootstrap customize until the VM is power cycled")
+				conditions.MarkFalse(
+					vmCtx.VM,
+					vmopv1.GuestBootstrapSyncedCondition,
+					vmopv1.GuestBootstrapPendingPowerCycleReason,
+					"the guest is customized with the changed bootstrap data "+
+						"the next time the VM is powered off and on")
+			}
+		default:
 			vmCtx.Logger.V(4).Info("Doing bootstrap customize")
 			if err := doCustomize(vmCtx, vcVM, config, customSpec); err != nil {
 				return fmt.Errorf("bootstrap customize failed: %w", err)
 			}
+			conditions.Delete(vmCtx.VM, vmopv1.GuestBootstrapSyncedCondition)
 			if vmCtx.VM.Annotations == nil {
 				vmCtx.VM.Annotations = map[string]string{}
 			}
@@ -177,9 +231,62 @@ func DoBootstrap(
 		}
 	}
 
+	if vmCtx.MoVM.Runtime.PowerState == vimtypes.VirtualMachinePowerStatePoweredOff {
+		// The guest has been customized with the bootstrap data for this
+		// generation.
+		vmCtx.VM.Status.ObservedCustomizationGeneration = generation
+	}
+
 	return nil
 }
 
+// markCloudInitGuestBootstrapSynced updates the GuestBootstrapSynced condition
+// after the Cloud-Init metadata was pushed to a powered on VM. Cloud-Init only
+// re-reads the network config from GuestInfo when a network interface is hot
+// added or removed if hotplug network updates are enabled in the guest.
+func markCloudInitGuestBootstrapSynced(
+	vmCtx pkgctx.VirtualMachineContext,
+	cloudInitSpec *vmopv1.VirtualMachineBootstrapCloudInitSpec,
+	bsArgs *BootstrapArgs) {
+
+	if t := vmCtx.VM.Annotations[constants.CloudInitTypeAnnotation]; t == "" ||
+		t == constants.CloudInitTypeValueGuestInfo {
+
+		// An error getting the user-data was already returned when the
+		// metadata was created, so just treat it as not enabling hotplug.
+		userdata, _ := getCloudInitUserData(cloudInitSpec, bsArgs)
+		if IsCloudInitNetworkHotplugEnabled(userdata, bsArgs.VendorData) {
+			conditions.MarkTrue(vmCtx.VM, vmopv1.GuestBootstrapSyncedCondition)
+			return
+		}
+	}
+
+	vmCtx.Logger.Info("Cloud-Init metadata changed on powered on VM " +
+		"without hotplug network updates enabled")
+	conditions.MarkFalse(
+		vmCtx.VM,
+		vmopv1.GuestBootstrapSyncedCondition,
+		vmopv1.GuestBootstrapPendingRecustomizeReason,
+		"Cloud-Init does not have hotplug network updates enabled, so "+
+			"increment spec.bootstrap.customizationGeneration to apply the "+
+			"changed metadata")
+}
+
+// customizationGeneration returns the generation of the guest customization
+// that is applied to the VM. The value of spec.bootstrap.customizationGeneration
+// is only used once the VM is powered off since a running guest cannot be
+// customized again.
+func customizationGeneration(vmCtx pkgctx.VirtualMachineContext) int64 {
+	bs := vmCtx.VM.Spec.Bootstrap
+	if bs == nil {
+		return 0
+	}
+	if vmCtx.MoVM.Runtime.PowerState == vimtypes.VirtualMachinePowerStatePoweredOff {
+		return bs.CustomizationGeneration
+	}
+	return vmCtx.VM.Status.ObservedCustomizationGeneration
+}
+
 // GetBootstrapArgs returns the information used to bootstrap the VM via
 // one of the many, possible bootstrap engines.
 func GetBootstrapArgs(
@@ -366,6 +473,12 @@ var linuxGuestIDPrefixes = [...]string{
 	"vmwarePhoton",
 }
 
+// isWindowsGuest returns true if the guest ID is for a Windows guest. All of
+// the Windows guest IDs start with "win", ex. "windows2019srv_64Guest".
+func isWindowsGuest(guestID string) bool {
+	return strings.HasPrefix(guestID, "win")
+}
+
 func isLinuxGuest(guestID string) bool {
 	if guestID == "" {
 		return false
@@ -400,13 +513,15 @@ func SanitizeConfigSpec(cs vimtypes.VirtualMachineConfigSpec) vimtypes.VirtualMa
 			continue
 		}
 
-		// This is what is likely to contain any sensitive. We can expand this to vendor
-		// and metadata later if needed.
-		if optVal.Key == constants.CloudInitGuestInfoUserdata {
+		// This is what is likely to contain any sensitive. We can expand this to
+		// metadata later if needed.
+		switch optVal.Key {
+		case constants.CloudInitGuestInfoUserdata,
+			constants.CloudInitGuestInfoVendordata,
+			constants.IgnitionGuestInfoConfigData:
 			optValCopy := *optVal
 			optValCopy.Value = redacted
 			cs.ExtraConfig[i] = &optValCopy
-			break
 		}
 	}
 
@@ -473,6 +588,20 @@ func SanitizeCustomizationSpec(cs vimtypes.CustomizationSpec) vimtypes.Customiza
 	return cs
 }
 
+// getBootstrapHash returns the hash of the bootstrap data. The hash includes
+// the customization generation, if any, so the bootstrap provider is re-run
+// when the guest is re-customized, even if the bootstrap data is unchanged.
+func getBootstrapHash(obj vimtypes.AnyType, generation int64) (string, error) {
+	h, err := getVimTypeHash(obj)
+	if err != nil {
+		return "", err
+	}
+	if generation > 0 {
+		h = fmt.Sprintf("%s-%d", h, generation)
+	}
+	return h, nil
+}
+
 func getVimTypeHash(obj vimtypes.AnyType) (string, error) {
 	data, err := json.Marshal(obj)
 	if err != nil {
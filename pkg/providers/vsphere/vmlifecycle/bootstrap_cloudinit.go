@@ -31,6 +31,10 @@ type CloudInitMetadata struct {
 	Network       netplan.Network `json:"network,omitempty"`
 	PublicKeys    string          `json:"public-keys,omitempty"`
 	WaitOnNetwork *WaitOnNetwork  `json:"wait-on-network,omitempty"`
+
+	// PublicKeysData is the key from which Cloudbase-Init reads the SSH
+	// public keys.
+	PublicKeysData string `json:"public-keys-data,omitempty"`
 }
 
 type WaitOnNetwork struct {
@@ -39,9 +43,7 @@ type WaitOnNetwork struct {
 }
 
 // CloudInitUserDataSecretKeys are the Secret keys that in v1a1 we'd check for the userdata.
-// Specifically, CAPBK uses "value" for its key, while "user-data" is the preferred key nowadays.
-// The 'value' key lookup will eventually be deprecated.
-var CloudInitUserDataSecretKeys = []string{"user-data", "value"}
+var CloudInitUserDataSecretKeys = cloudinit.UserDataSecretKeys
 
 func BootStrapCloudInitInstanceID(
 	vm *vmopv1.VirtualMachine,
@@ -112,7 +114,7 @@ func BootStrapCloudInit(
 	logger := logr.FromContextOrDiscard(vmCtx)
 	logger.V(4).Info("Reconciling Cloud-Init bootstrap state")
 
-	netPlan, err := network.NetPlanCustomization(bsArgs.NetworkResults)
+	netPlan, err := network.NetPlanCustomization(bsArgs.NetworkResults, vmCtx.VM.Spec.Network)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to create NetPlan customization: %w", err)
 	}
@@ -123,22 +125,81 @@ func BootStrapCloudInit(
 	}
 
 	iid := BootStrapCloudInitInstanceID(vmCtx.VM, cloudInitSpec)
+	if generation := customizationGeneration(vmCtx); generation > 0 {
+		// Cloud-Init only runs again when the instance ID changes, so include
+		// the generation in the instance ID when the guest is re-customized.
+		iid = fmt.Sprintf("%s-%d", iid, generation)
+	}
+
+	// Windows guests are bootstrapped by Cloudbase-Init, which reads the same
+	// GuestInfo keys and NoCloud seed image as Cloud-Init.
+	isWindows := isWindowsGuest(config.GuestId)
 
-	metadata, err := GetCloudInitMetadata(
+	getMetadata := GetCloudInitMetadata
+	if isWindows {
+		getMetadata = GetCloudbaseInitMetadata
+	}
+	metadata, err := getMetadata(
 		iid, bsArgs.HostName, bsArgs.DomainName, netPlan, sshPublicKeys,
 		cloudInitSpec.WaitOnNetwork4, cloudInitSpec.WaitOnNetwork6)
 	if err != nil {
 		return nil, nil, err
 	}
 
+	userdata, err := getCloudInitUserData(cloudInitSpec, bsArgs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if !isWindows {
+		// The VM webhook warns about this, so just log it here in case the
+		// guest ID does not reflect the actual guest OS.
+		if ok, _ := cloudinit.IsCloudbaseInitUserData(userdata); ok {
+			logger.Info("Cloud-Init userdata format is only supported by Cloudbase-Init",
+				"guestID", config.GuestId)
+		}
+	}
+
+	var configSpec *vimtypes.VirtualMachineConfigSpec
+	var customSpec *vimtypes.CustomizationSpec
+
+	switch vmCtx.VM.Annotations[constants.CloudInitTypeAnnotation] {
+	case constants.CloudInitTypeValueCloudInitPrep:
+		if isWindows {
+			return nil, nil, fmt.Errorf(
+				"cloud-init type %q is not supported for Windows guests",
+				constants.CloudInitTypeValueCloudInitPrep)
+		}
+		// The CloudInitPrep customization spec does not have a field for
+		// vendor-data, so the namespace's defaults are not used.
+		configSpec, customSpec, err = GetCloudInitPrepCustSpec(vmCtx, config, metadata, userdata)
+	case constants.CloudInitTypeValueNoCloud:
+		configSpec, err = GetCloudInitNoCloudCustSpec(vmCtx, config, metadata, userdata, netPlan, bsArgs)
+	case constants.CloudInitTypeValueGuestInfo, "":
+		fallthrough
+	default:
+		configSpec, err = GetCloudInitGuestInfoCustSpec(vmCtx, config, metadata, userdata, bsArgs.VendorData)
+	}
+
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return configSpec, customSpec, nil
+}
+
+func getCloudInitUserData(
+	cloudInitSpec *vmopv1.VirtualMachineBootstrapCloudInitSpec,
+	bsArgs *BootstrapArgs) (string, error) {
+
 	var userdata string
 	if cooked := cloudInitSpec.CloudConfig; cooked != nil {
 		if bsArgs.CloudConfig == nil {
-			return nil, nil, fmt.Errorf("cloudConfigSecretData is nil")
+			return "", fmt.Errorf("cloudConfigSecretData is nil")
 		}
 		data, err := cloudinit.MarshalYAML(*cooked, *bsArgs.CloudConfig)
 		if err != nil {
-			return nil, nil, err
+			return "", err
 		}
 		userdata = data
 	} else if raw := cloudInitSpec.RawCloudConfig; raw != nil {
@@ -153,31 +214,75 @@ func BootStrapCloudInit(
 		// NOTE: The old code didn't error out if userdata wasn't found, so keep going.
 	}
 
-	var configSpec *vimtypes.VirtualMachineConfigSpec
-	var customSpec *vimtypes.CustomizationSpec
+	return userdata, nil
+}
 
-	switch vmCtx.VM.Annotations[constants.CloudInitTypeAnnotation] {
-	case constants.CloudInitTypeValueCloudInitPrep:
-		configSpec, customSpec, err = GetCloudInitPrepCustSpec(vmCtx, config, metadata, userdata)
-	case constants.CloudInitTypeValueGuestInfo, "":
-		fallthrough
-	default:
-		configSpec, err = GetCloudInitGuestInfoCustSpec(vmCtx, config, metadata, userdata)
+// IsCloudInitNetworkHotplugEnabled returns true if the Cloud-Init user-data
+// or vendor-data is a cloud-config that enables hotplug network updates with
+// updates.network.when. Cloud-Init then re-reads the network config from
+// GuestInfo when a network interface is hot added or removed.
+func IsCloudInitNetworkHotplugEnabled(userdata, vendordata string) bool {
+	for _, data := range []string{userdata, vendordata} {
+		if data == "" {
+			continue
+		}
+		plainText, err := pkgutil.TryToDecodeBase64Gzip([]byte(data))
+		if err != nil {
+			continue
+		}
+		var cloudConfig struct {
+			Updates struct {
+				Network struct {
+					When []string `json:"when"`
+				} `json:"network"`
+			} `json:"updates"`
+		}
+		if err := yaml.Unmarshal([]byte(plainText), &cloudConfig); err != nil {
+			continue
+		}
+		if slices.Contains(cloudConfig.Updates.Network.When, "hotplug") {
+			return true
+		}
 	}
+	return false
+}
 
-	if err != nil {
-		return nil, nil, err
-	}
+func GetCloudInitMetadata(
+	instanceID, hostName, domainName string,
+	netplan *netplan.Network,
+	sshPublicKeys string,
+	waitOnNetwork4, waitOnNetwork6 *bool) (string, error) {
 
-	return configSpec, customSpec, nil
+	metadata := newCloudInitMetadata(
+		instanceID, hostName, domainName, netplan, sshPublicKeys,
+		waitOnNetwork4, waitOnNetwork6)
+
+	return marshalCloudInitMetadata(metadata)
 }
 
-func GetCloudInitMetadata(
+// GetCloudbaseInitMetadata returns the metadata for a Windows guest with
+// Cloudbase-Init. The metadata is the same as for Cloud-Init, except the SSH
+// public keys are also specified with the key read by Cloudbase-Init.
+func GetCloudbaseInitMetadata(
 	instanceID, hostName, domainName string,
 	netplan *netplan.Network,
 	sshPublicKeys string,
 	waitOnNetwork4, waitOnNetwork6 *bool) (string, error) {
 
+	metadata := newCloudInitMetadata(
+		instanceID, hostName, domainName, netplan, sshPublicKeys,
+		waitOnNetwork4, waitOnNetwork6)
+	metadata.PublicKeysData = sshPublicKeys
+
+	return marshalCloudInitMetadata(metadata)
+}
+
+func newCloudInitMetadata(
+	instanceID, hostName, domainName string,
+	netplan *netplan.Network,
+	sshPublicKeys string,
+	waitOnNetwork4, waitOnNetwork6 *bool) *CloudInitMetadata {
+
 	fqdn := hostName
 	if domainName != "" {
 		fqdn = hostName + "." + domainName
@@ -206,6 +311,10 @@ func GetCloudInitMetadata(
 		metadata.WaitOnNetwork = &waitOnNetwork
 	}
 
+	return metadata
+}
+
+func marshalCloudInitMetadata(metadata *CloudInitMetadata) (string, error) {
 	metadataBytes, err := yaml.Marshal(metadata)
 	if err != nil {
 		return "", fmt.Errorf("yaml marshalling of cloud-init metadata failed: %w", err)
@@ -260,7 +369,7 @@ func GetCloudInitPrepCustSpec(
 func GetCloudInitGuestInfoCustSpec(
 	ctx context.Context,
 	config *vimtypes.VirtualMachineConfigInfo,
-	metadata, userdata string) (*vimtypes.VirtualMachineConfigSpec, error) {
+	metadata, userdata, vendordata string) (*vimtypes.VirtualMachineConfigSpec, error) {
 
 	logger := logr.FromContextOrDiscard(ctx)
 	logger.V(4).Info("Reconciling Cloud-Init GuestInfo bootstrap state")
@@ -305,6 +414,24 @@ func GetCloudInitGuestInfoCustSpec(
 			})
 	}
 
+	if vendordata != "" {
+		encodedVendordata, err := pkgutil.EncodeGzipBase64(vendordata)
+		if err != nil {
+			return nil, fmt.Errorf("encoding cloud-init vendordata failed: %w", err)
+		}
+
+		extraConfig = append(
+			extraConfig,
+			&vimtypes.OptionValue{
+				Key:   constants.CloudInitGuestInfoVendordata,
+				Value: encodedVendordata,
+			},
+			&vimtypes.OptionValue{
+				Key:   constants.CloudInitGuestInfoVendordataEncoding,
+				Value: "gzip+base64",
+			})
+	}
+
 	configSpec := &vimtypes.VirtualMachineConfigSpec{
 		ExtraConfig: pkgutil.OptionValues(config.ExtraConfig).Diff(extraConfig...),
 	}
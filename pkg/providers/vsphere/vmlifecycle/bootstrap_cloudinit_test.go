@@ -255,6 +255,46 @@ var _ = Describe("CloudInit Bootstrap", func() {
 					Expect(custSpec).To(BeNil())
 				})
 
+				When("the guest is re-customized", func() {
+					BeforeEach(func() {
+						cloudInitSpec.InstanceID = "my-instance-id"
+						vmCtx.VM.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{
+							CloudInit:               cloudInitSpec,
+							CustomizationGeneration: 2,
+						}
+						vmCtx.VM.Status.ObservedCustomizationGeneration = 1
+						vmCtx.MoVM.Runtime.PowerState = vimtypes.VirtualMachinePowerStatePoweredOff
+					})
+
+					getInstanceID := func() string {
+						extraConfig := pkgutil.OptionValues(configSpec.ExtraConfig).StringMap()
+						data, err := pkgutil.TryToDecodeBase64Gzip([]byte(extraConfig[constants.CloudInitGuestInfoMetadata]))
+						Expect(err).ToNot(HaveOccurred())
+						ciMetadata := &vmlifecycle.CloudInitMetadata{}
+						Expect(yaml.Unmarshal([]byte(data), ciMetadata)).To(Succeed())
+						return ciMetadata.InstanceID
+					}
+
+					It("Includes the generation in the instance ID", func() {
+						Expect(err).ToNot(HaveOccurred())
+						Expect(configSpec).ToNot(BeNil())
+						Expect(getInstanceID()).To(Equal("my-instance-id-2"))
+						Expect(cloudInitSpec.InstanceID).To(Equal("my-instance-id"))
+					})
+
+					When("the VM is not powered off", func() {
+						BeforeEach(func() {
+							vmCtx.MoVM.Runtime.PowerState = vimtypes.VirtualMachinePowerStatePoweredOn
+						})
+
+						It("Includes the observed generation in the instance ID", func() {
+							Expect(err).ToNot(HaveOccurred())
+							Expect(configSpec).ToNot(BeNil())
+							Expect(getInstanceID()).To(Equal("my-instance-id-1"))
+						})
+					})
+				})
+
 				Context("Via CAPBK userdata in 'value' key", func() {
 					const otherUserData = cloudInitUserdata + "CAPBK"
 
@@ -282,6 +322,61 @@ var _ = Describe("CloudInit Bootstrap", func() {
 					})
 				})
 			})
+
+			Context("With PowerShell userdata", func() {
+				const psUserData = "#ps1_sysnative\nWrite-Host hello"
+
+				BeforeEach(func() {
+					bsArgs.Data[cloudInitSpec.RawCloudConfig.Key] = psUserData
+					cloudInitSpec.SSHAuthorizedKeys = []string{"my-ssh-key"}
+				})
+
+				When("the guest is Windows", func() {
+					BeforeEach(func() {
+						configInfo.GuestId = string(vimtypes.VirtualMachineGuestOsIdentifierWindows2019srv_64Guest)
+					})
+
+					It("Returns success with the Cloudbase-Init metadata", func() {
+						Expect(err).ToNot(HaveOccurred())
+						Expect(configSpec).ToNot(BeNil())
+
+						extraConfig := pkgutil.OptionValues(configSpec.ExtraConfig).StringMap()
+						Expect(extraConfig).To(HaveLen(4))
+
+						data, err := pkgutil.TryToDecodeBase64Gzip([]byte(extraConfig[constants.CloudInitGuestInfoUserdata]))
+						Expect(err).ToNot(HaveOccurred())
+						Expect(data).To(Equal(psUserData))
+
+						data, err = pkgutil.TryToDecodeBase64Gzip([]byte(extraConfig[constants.CloudInitGuestInfoMetadata]))
+						Expect(err).ToNot(HaveOccurred())
+						ciMetadata := &vmlifecycle.CloudInitMetadata{}
+						Expect(yaml.Unmarshal([]byte(data), ciMetadata)).To(Succeed())
+						Expect(ciMetadata.PublicKeys).To(Equal("my-ssh-key"))
+						Expect(ciMetadata.PublicKeysData).To(Equal("my-ssh-key"))
+					})
+
+					When("the type is CloudInitPrep", func() {
+						BeforeEach(func() {
+							vmCtx.VM.Annotations[constants.CloudInitTypeAnnotation] = constants.CloudInitTypeValueCloudInitPrep
+						})
+
+						It("Returns an error", func() {
+							Expect(err).To(MatchError(`cloud-init type "cloudinitprep" is not supported for Windows guests`))
+						})
+					})
+				})
+
+				When("the guest is Linux", func() {
+					BeforeEach(func() {
+						configInfo.GuestId = string(vimtypes.VirtualMachineGuestOsIdentifierUbuntu64Guest)
+					})
+
+					It("Returns success", func() {
+						Expect(err).ToNot(HaveOccurred())
+						Expect(configSpec).ToNot(BeNil())
+					})
+				})
+			})
 		})
 	})
 
@@ -376,17 +471,38 @@ var _ = Describe("CloudInit Bootstrap", func() {
 			})
 		})
 
+		When("the metadata is for Cloudbase-Init", func() {
+			JustBeforeEach(func() {
+				mdYaml, err = vmlifecycle.GetCloudbaseInitMetadata(uid, hostName, domainName, netPlan, sshPublicKeys, waitOnNetwork4, waitOnNetwork6)
+			})
+			It("includes the public keys data", func() {
+				Expect(err).ToNot(HaveOccurred())
+
+				ciMetadata := &vmlifecycle.CloudInitMetadata{}
+				Expect(yaml.Unmarshal([]byte(mdYaml), ciMetadata)).To(Succeed())
+
+				Expect(ciMetadata.InstanceID).To(Equal(uid))
+				Expect(ciMetadata.PublicKeys).To(Equal(sshPublicKeys))
+				Expect(ciMetadata.PublicKeysData).To(Equal(sshPublicKeys))
+			})
+		})
+
 	})
 
 	Context("GetCloudInitGuestInfoCustSpec", func() {
 		var (
 			configSpec *vimtypes.VirtualMachineConfigSpec
+			vendorData string
 			err        error
 		)
 
+		BeforeEach(func() {
+			vendorData = ""
+		})
+
 		JustBeforeEach(func() {
 			configSpec, err = vmlifecycle.GetCloudInitGuestInfoCustSpec(
-				context.Background(), configInfo, metaData, userData)
+				context.Background(), configInfo, metaData, userData, vendorData)
 		})
 
 		Context("vAppConfig", func() {
@@ -448,6 +564,27 @@ var _ = Describe("CloudInit Bootstrap", func() {
 			})
 		})
 
+		Context("With vendordata", func() {
+			BeforeEach(func() {
+				vendorData = "#cloud-config\nhostname: vendor\n"
+			})
+
+			It("ConfigSpec.ExtraConfig to have metadata, userdata and vendordata", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(configSpec).ToNot(BeNil())
+
+				extraConfig := pkgutil.OptionValues(configSpec.ExtraConfig).StringMap()
+				Expect(extraConfig).To(HaveLen(6))
+				Expect(extraConfig).To(HaveKey(constants.CloudInitGuestInfoMetadata))
+				Expect(extraConfig).To(HaveKey(constants.CloudInitGuestInfoUserdata))
+				Expect(extraConfig).To(HaveKeyWithValue(constants.CloudInitGuestInfoVendordataEncoding, "gzip+base64"))
+
+				data, err := pkgutil.TryToDecodeBase64Gzip([]byte(extraConfig[constants.CloudInitGuestInfoVendordata]))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(data).To(Equal(vendorData))
+			})
+		})
+
 		Context("With base64-encoded userdata but no encoding specified", func() {
 			BeforeEach(func() {
 				userData = base64.StdEncoding.EncodeToString([]byte(cloudInitUserdata))
@@ -594,4 +731,37 @@ var _ = Describe("CloudInit Bootstrap", func() {
 			})
 		})
 	})
+
+	Context("IsCloudInitNetworkHotplugEnabled", func() {
+		const hotplugCloudConfig = `#cloud-config
+updates:
+  network:
+    when: ["boot", "hotplug"]
+`
+
+		It("returns true when the user-data enables hotplug", func() {
+			Expect(vmlifecycle.IsCloudInitNetworkHotplugEnabled(hotplugCloudConfig, "")).To(BeTrue())
+		})
+
+		It("returns true when the encoded user-data enables hotplug", func() {
+			data, err := pkgutil.EncodeGzipBase64(hotplugCloudConfig)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(vmlifecycle.IsCloudInitNetworkHotplugEnabled(data, "")).To(BeTrue())
+		})
+
+		It("returns true when the vendor-data enables hotplug", func() {
+			Expect(vmlifecycle.IsCloudInitNetworkHotplugEnabled(cloudInitUserdata, hotplugCloudConfig)).To(BeTrue())
+		})
+
+		It("returns false when hotplug is not enabled", func() {
+			Expect(vmlifecycle.IsCloudInitNetworkHotplugEnabled(cloudInitUserdata, "")).To(BeFalse())
+			Expect(vmlifecycle.IsCloudInitNetworkHotplugEnabled(
+				"#cloud-config\nupdates:\n  network:\n    when: [boot]\n", "")).To(BeFalse())
+		})
+
+		It("returns false when the user-data is not a cloud-config", func() {
+			Expect(vmlifecycle.IsCloudInitNetworkHotplugEnabled("#!/bin/sh\necho hotplug\n", "")).To(BeFalse())
+			Expect(vmlifecycle.IsCloudInitNetworkHotplugEnabled("", "")).To(BeFalse())
+		})
+	})
 })
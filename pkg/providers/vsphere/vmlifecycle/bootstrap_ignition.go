@@ -0,0 +1,80 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package vmlifecycle
+
+import (
+	"fmt"
+
+	"github.com/go-logr/logr"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/constants"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/network"
+	pkgutil "github.com/vmware-tanzu/vm-operator/pkg/util"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ignition"
+)
+
+func BootstrapIgnition(
+	vmCtx pkgctx.VirtualMachineContext,
+	config *vimtypes.VirtualMachineConfigInfo,
+	ignitionSpec *vmopv1.VirtualMachineBootstrapIgnitionSpec,
+	bsArgs *BootstrapArgs) (*vimtypes.VirtualMachineConfigSpec, *vimtypes.CustomizationSpec, error) {
+
+	logger := logr.FromContextOrDiscard(vmCtx)
+	logger.V(4).Info("Reconciling Ignition bootstrap state")
+
+	var data string
+	if ignitionSpec.Config != "" {
+		data = ignitionSpec.Config
+	} else if raw := ignitionSpec.RawConfig; raw != nil {
+		data = bsArgs.BootstrapData.Data[raw.Key]
+		if data == "" {
+			return nil, nil, fmt.Errorf("ignition config key %q not found in Secret %s", raw.Key, raw.Name)
+		}
+	}
+
+	kargs, err := network.NetworkKargs(bsArgs.NetworkResults, bsArgs.HostName, bsArgs.DNSServers)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create network kernel arguments: %w", err)
+	}
+
+	extraConfig := pkgutil.OptionValues{
+		&vimtypes.OptionValue{
+			Key:   constants.AfterburnGuestInfoNetworkKargs,
+			Value: kargs,
+		},
+	}
+
+	if data != "" {
+		ignitionConfig, err := ignition.ToJSON(data)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		encodedConfig, err := pkgutil.EncodeGzipBase64(ignitionConfig)
+		if err != nil {
+			return nil, nil, fmt.Errorf("encoding ignition config failed: %w", err)
+		}
+
+		extraConfig = append(
+			extraConfig,
+			&vimtypes.OptionValue{
+				Key:   constants.IgnitionGuestInfoConfigData,
+				Value: encodedConfig,
+			},
+			&vimtypes.OptionValue{
+				Key:   constants.IgnitionGuestInfoConfigDataEncoding,
+				Value: "gzip+base64",
+			})
+	}
+
+	configSpec := &vimtypes.VirtualMachineConfigSpec{
+		ExtraConfig: pkgutil.OptionValues(config.ExtraConfig).Diff(extraConfig...),
+	}
+
+	return configSpec, nil, nil
+}
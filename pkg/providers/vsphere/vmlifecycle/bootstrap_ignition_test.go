@@ -0,0 +1,152 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package vmlifecycle_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/api/v1alpha4/common"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/constants"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/network"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/vmlifecycle"
+	pkgutil "github.com/vmware-tanzu/vm-operator/pkg/util"
+)
+
+var _ = Describe("Ignition Bootstrap", func() {
+	const (
+		ignitionConfig     = `{"ignition":{"version":"3.4.0"}}`
+		ignitionConfigYAML = "ignition:\n  version: 3.4.0\n"
+	)
+
+	var (
+		bsArgs       vmlifecycle.BootstrapArgs
+		configInfo   *vimtypes.VirtualMachineConfigInfo
+		ignitionSpec *vmopv1.VirtualMachineBootstrapIgnitionSpec
+
+		configSpec *vimtypes.VirtualMachineConfigSpec
+		custSpec   *vimtypes.CustomizationSpec
+		err        error
+	)
+
+	BeforeEach(func() {
+		configInfo = &vimtypes.VirtualMachineConfigInfo{}
+		ignitionSpec = &vmopv1.VirtualMachineBootstrapIgnitionSpec{}
+		bsArgs.HostName = "my-vm"
+		bsArgs.NetworkResults.Results = []network.NetworkInterfaceResult{
+			{
+				Name:            "eth0",
+				GuestDeviceName: "eth0",
+				MacAddress:      "00:50:56:aa:bb:cc",
+				DHCP4:           true,
+			},
+		}
+	})
+
+	AfterEach(func() {
+		bsArgs = vmlifecycle.BootstrapArgs{}
+	})
+
+	JustBeforeEach(func() {
+		vmCtx := pkgctx.VirtualMachineContext{
+			Context: context.Background(),
+			Logger:  suite.GetLogger(),
+			VM: &vmopv1.VirtualMachine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "ignition-bootstrap-test",
+					Namespace: "test-ns",
+				},
+			},
+		}
+
+		configSpec, custSpec, err = vmlifecycle.BootstrapIgnition(
+			vmCtx,
+			configInfo,
+			ignitionSpec,
+			&bsArgs,
+		)
+	})
+
+	assertConfig := func() {
+		Expect(err).ToNot(HaveOccurred())
+		Expect(custSpec).To(BeNil())
+		Expect(configSpec).ToNot(BeNil())
+
+		extraConfig := pkgutil.OptionValues(configSpec.ExtraConfig).StringMap()
+		Expect(extraConfig).To(HaveLen(3))
+		Expect(extraConfig).To(HaveKeyWithValue(constants.AfterburnGuestInfoNetworkKargs,
+			"ifname=eth0:00:50:56:aa:bb:cc ip=eth0:dhcp"))
+		Expect(extraConfig).To(HaveKeyWithValue(constants.IgnitionGuestInfoConfigDataEncoding, "gzip+base64"))
+		data, err := pkgutil.TryToDecodeBase64Gzip([]byte(extraConfig[constants.IgnitionGuestInfoConfigData]))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(data).To(Equal(ignitionConfig))
+	}
+
+	Context("Inlined config", func() {
+		BeforeEach(func() {
+			ignitionSpec.Config = ignitionConfig
+		})
+
+		It("Should return valid data", assertConfig)
+	})
+
+	Context("Inlined YAML config", func() {
+		BeforeEach(func() {
+			ignitionSpec.Config = ignitionConfigYAML
+		})
+
+		It("Should convert the config to JSON", assertConfig)
+	})
+
+	Context("Raw config", func() {
+		BeforeEach(func() {
+			ignitionSpec.RawConfig = &common.SecretKeySelector{
+				Name: "my-ignition",
+				Key:  "config.ign",
+			}
+		})
+
+		When("Secret has the key", func() {
+			BeforeEach(func() {
+				bsArgs.BootstrapData.Data = map[string]string{
+					"config.ign": ignitionConfig,
+				}
+			})
+
+			It("Should return valid data", assertConfig)
+		})
+
+		When("Secret does not have the key", func() {
+			It("Should return an error", func() {
+				Expect(err).To(MatchError(`ignition config key "config.ign" not found in Secret my-ignition`))
+			})
+		})
+	})
+
+	Context("ExtraConfig already has the values", func() {
+		BeforeEach(func() {
+			ignitionSpec.Config = ignitionConfig
+			encoded, err := pkgutil.EncodeGzipBase64(ignitionConfig)
+			Expect(err).ToNot(HaveOccurred())
+			configInfo.ExtraConfig = []vimtypes.BaseOptionValue{
+				&vimtypes.OptionValue{Key: constants.AfterburnGuestInfoNetworkKargs, Value: "ifname=eth0:00:50:56:aa:bb:cc ip=eth0:dhcp"},
+				&vimtypes.OptionValue{Key: constants.IgnitionGuestInfoConfigData, Value: encoded},
+				&vimtypes.OptionValue{Key: constants.IgnitionGuestInfoConfigDataEncoding, Value: "gzip+base64"},
+			}
+		})
+
+		It("Should return no changes", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(configSpec.ExtraConfig).To(BeEmpty())
+		})
+	})
+})
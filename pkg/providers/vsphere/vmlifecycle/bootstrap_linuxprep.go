@@ -25,7 +25,11 @@ func BootStrapLinuxPrep(
 	logger := logr.FromContextOrDiscard(vmCtx)
 	logger.V(4).Info("Reconciling LinuxPrep bootstrap state")
 
-	if !vmCtx.IsOffToOn() {
+	// The customization spec is still created for a powered on VM so changes
+	// to it, such as from a hot added network interface, are reported as
+	// pending until the VM is powered on again.
+	isPoweredOn := vmCtx.MoVM.Runtime.PowerState == vimtypes.VirtualMachinePowerStatePoweredOn
+	if !vmCtx.IsOffToOn() && !isPoweredOn {
 		vmCtx.Logger.V(4).Info("Skipping LinuxPrep since VM is not powering on")
 		return nil, nil, nil
 	}
@@ -51,6 +55,10 @@ func BootStrapLinuxPrep(
 		NicSettingMap: nicSettingMap,
 	}
 
+	if isPoweredOn {
+		return nil, customSpec, nil
+	}
+
 	var configSpec *vimtypes.VirtualMachineConfigSpec
 	if vAppConfigSpec != nil {
 		configSpec = &vimtypes.VirtualMachineConfigSpec{}
@@ -0,0 +1,382 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package vmlifecycle
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	xxhash "github.com/cespare/xxhash/v2"
+	"github.com/go-logr/logr"
+	"github.com/vmware/govmomi/fault"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25"
+	"github.com/vmware/govmomi/vim25/soap"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+	"sigs.k8s.io/yaml"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/constants"
+	pkgutil "github.com/vmware-tanzu/vm-operator/pkg/util"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/cloudinit"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/netplan"
+)
+
+const (
+	// noCloudSeedDetached is appended to the seed checksum in ExtraConfig
+	// once the seed ISO is disconnected from the VM.
+	noCloudSeedDetached = "detached"
+
+	// noCloudSeedRemoved is appended to the seed checksum in ExtraConfig once
+	// the seed ISO is deleted from the datastore.
+	noCloudSeedRemoved = "removed"
+)
+
+// DatastoreFileManager manages the files in a VM's directory.
+type DatastoreFileManager interface {
+	// UploadFile uploads data to the file with the given datastore path,
+	// replacing the file if it exists.
+	UploadFile(ctx context.Context, name string, data []byte) error
+
+	// DeleteFile deletes the file with the given datastore path. No error is
+	// returned if the file does not exist.
+	DeleteFile(ctx context.Context, name string) error
+}
+
+// NewDatastoreFileManager returns a DatastoreFileManager for files on the
+// datastores in the given datacenter.
+func NewDatastoreFileManager(
+	vimClient *vim25.Client,
+	datacenter *object.Datacenter) DatastoreFileManager {
+
+	return datastoreFileManager{
+		vimClient:  vimClient,
+		datacenter: datacenter,
+	}
+}
+
+type datastoreFileManager struct {
+	vimClient  *vim25.Client
+	datacenter *object.Datacenter
+}
+
+func (m datastoreFileManager) UploadFile(
+	ctx context.Context,
+	name string,
+	data []byte) error {
+
+	var p object.DatastorePath
+	if !p.FromString(name) {
+		return fmt.Errorf("invalid datastore path %q", name)
+	}
+
+	u := object.NewDatastoreURL(
+		*m.vimClient.URL(), m.datacenter.InventoryPath, p.Datastore, p.Path)
+
+	param := soap.DefaultUpload
+	param.ContentLength = int64(len(data))
+
+	return m.vimClient.Upload(ctx, bytes.NewReader(data), u, &param)
+}
+
+func (m datastoreFileManager) DeleteFile(
+	ctx context.Context,
+	name string) error {
+
+	task, err := object.NewFileManager(m.vimClient).DeleteDatastoreFile(
+		ctx, name, m.datacenter)
+	if err != nil {
+		return err
+	}
+
+	if err := task.Wait(ctx); err != nil && !fault.Is(err, &vimtypes.FileNotFound{}) {
+		return err
+	}
+
+	return nil
+}
+
+// GetCloudInitNoCloudCustSpec returns the ConfigSpec that attaches a NoCloud
+// seed ISO with the provided data to the VM. The ISO is uploaded to the VM's
+// directory before the VM is powered on. After the guest boots, the ISO is
+// disconnected from the VM and deleted.
+//
+// The state of the seed ISO is recorded in the VM's ExtraConfig so the ISO is
+// only uploaded again if the data changes.
+func GetCloudInitNoCloudCustSpec(
+	vmCtx pkgctx.VirtualMachineContext,
+	config *vimtypes.VirtualMachineConfigInfo,
+	metadata, userdata string,
+	netPlan *netplan.Network,
+	bsArgs *BootstrapArgs) (*vimtypes.VirtualMachineConfigSpec, error) {
+
+	logger := logr.FromContextOrDiscard(vmCtx)
+	logger.V(4).Info("Reconciling Cloud-Init NoCloud bootstrap state")
+
+	if bsArgs.FileManager == nil {
+		return nil, fmt.Errorf("datastore file manager is nil")
+	}
+
+	if userdata != "" {
+		// Ensure the data is normalized first to plain-text.
+		plainText, err := pkgutil.TryToDecodeBase64Gzip([]byte(userdata))
+		if err != nil {
+			return nil, fmt.Errorf("decoding cloud-init nocloud userdata failed: %w", err)
+		}
+
+		userdata = plainText
+	}
+
+	var networkConfig string
+	if netPlan != nil && len(netPlan.Ethernets) > 0 {
+		data, err := yaml.Marshal(netPlan)
+		if err != nil {
+			return nil, fmt.Errorf("yaml marshalling of cloud-init network config failed: %w", err)
+		}
+		networkConfig = string(data)
+	}
+
+	seedISO, err := cloudinit.NoCloudSeedISO(metadata, userdata, bsArgs.VendorData, networkConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cloud-init nocloud seed iso: %w", err)
+	}
+
+	var vmPath object.DatastorePath
+	if config.Files.VmPathName == "" || !vmPath.FromString(config.Files.VmPathName) {
+		return nil, fmt.Errorf("invalid vm path name %q", config.Files.VmPathName)
+	}
+	seedPath := (&object.DatastorePath{
+		Datastore: vmPath.Datastore,
+		Path:      path.Join(path.Dir(vmPath.Path), constants.CloudInitNoCloudSeedISOFileName),
+	}).String()
+
+	var (
+		curDevices   = object.VirtualDeviceList(config.Hardware.Device)
+		seedCdrom    = getNoCloudSeedCdrom(curDevices, seedPath)
+		seedChecksum = fmt.Sprintf("%x", xxhash.Sum64(seedISO))
+		curState     = pkgutil.OptionValues(config.ExtraConfig).StringMap()[constants.CloudInitNoCloudSeedExtraConfigKey]
+		curChecksum  = curState
+		curPhase     string
+		isPoweredOn  = vmCtx.MoVM.Runtime.PowerState == vimtypes.VirtualMachinePowerStatePoweredOn
+		configSpec   = &vimtypes.VirtualMachineConfigSpec{}
+		setSeedState = func(phase string) {
+			state := seedChecksum
+			if phase != "" {
+				state += ":" + phase
+			}
+			configSpec.ExtraConfig = []vimtypes.BaseOptionValue{
+				&vimtypes.OptionValue{
+					Key:   constants.CloudInitNoCloudSeedExtraConfigKey,
+					Value: state,
+				},
+			}
+		}
+	)
+
+	if i := strings.Index(curState, ":"); i >= 0 {
+		curChecksum, curPhase = curState[:i], curState[i+1:]
+	}
+
+	switch {
+	case curChecksum != seedChecksum:
+		// The seed ISO is new or its data has changed.
+		if isPoweredOn {
+			// The seed is only read by the guest when it boots, so wait until
+			// the VM is powered off to attach the updated seed.
+			logger.V(4).Info(
+				"Skipping cloud-init nocloud seed update as the VM is powered on")
+			return nil, nil
+		}
+
+		logger.Info("Uploading cloud-init nocloud seed iso", "path", seedPath)
+		if err := bsArgs.FileManager.UploadFile(vmCtx, seedPath, seedISO); err != nil {
+			return nil, fmt.Errorf("failed to upload cloud-init nocloud seed iso: %w", err)
+		}
+
+		deviceChanges, err := attachNoCloudSeedCdrom(curDevices, seedCdrom, seedPath)
+		if err != nil {
+			return nil, err
+		}
+		configSpec.DeviceChange = deviceChanges
+		setSeedState("")
+
+	case curPhase == "":
+		// The seed ISO is attached to the VM.
+		if !isGuestBooted(vmCtx) {
+			return nil, nil
+		}
+
+		logger.Info("Detaching cloud-init nocloud seed iso", "path", seedPath)
+		configSpec.DeviceChange = detachNoCloudSeedCdrom(seedCdrom, isPoweredOn)
+		setSeedState(noCloudSeedDetached)
+
+	case curPhase == noCloudSeedDetached:
+		// The seed ISO is no longer in use by the VM.
+		logger.Info("Deleting cloud-init nocloud seed iso", "path", seedPath)
+		if err := bsArgs.FileManager.DeleteFile(vmCtx, seedPath); err != nil {
+			return nil, fmt.Errorf("failed to delete cloud-init nocloud seed iso: %w", err)
+		}
+
+		if !isPoweredOn {
+			configSpec.DeviceChange = detachNoCloudSeedCdrom(seedCdrom, false)
+		}
+		setSeedState(noCloudSeedRemoved)
+
+	default:
+		// The seed ISO was deleted. A disconnected CD-ROM is left on VMs that
+		// were powered on at the time, so remove it once the VM is powered
+		// off.
+		if seedCdrom == nil || isPoweredOn {
+			return nil, nil
+		}
+
+		configSpec.DeviceChange = detachNoCloudSeedCdrom(seedCdrom, false)
+	}
+
+	return configSpec, nil
+}
+
+func getNoCloudSeedCdrom(
+	curDevices object.VirtualDeviceList,
+	seedPath string) *vimtypes.VirtualCdrom {
+
+	for _, d := range curDevices.SelectByType((*vimtypes.VirtualCdrom)(nil)) {
+		cdrom := d.(*vimtypes.VirtualCdrom)
+		if b, ok := cdrom.Backing.(*vimtypes.VirtualCdromIsoBackingInfo); ok && b.FileName == seedPath {
+			return cdrom
+		}
+	}
+	return nil
+}
+
+// attachNoCloudSeedCdrom returns the device changes that add a CD-ROM backed
+// by the seed ISO, or that ensure an existing one is connected at power on.
+func attachNoCloudSeedCdrom(
+	curDevices object.VirtualDeviceList,
+	seedCdrom *vimtypes.VirtualCdrom,
+	seedPath string) ([]vimtypes.BaseVirtualDeviceConfigSpec, error) {
+
+	if seedCdrom != nil {
+		if c := seedCdrom.Connectable; c != nil && c.StartConnected {
+			return nil, nil
+		}
+		seedCdrom.Connectable = &vimtypes.VirtualDeviceConnectInfo{
+			StartConnected: true,
+		}
+		return []vimtypes.BaseVirtualDeviceConfigSpec{
+			&vimtypes.VirtualDeviceConfigSpec{
+				Device:    seedCdrom,
+				Operation: vimtypes.VirtualDeviceConfigSpecOperationEdit,
+			},
+		}, nil
+	}
+
+	seedCdrom = &vimtypes.VirtualCdrom{
+		VirtualDevice: vimtypes.VirtualDevice{
+			Key: curDevices.NewKey(),
+			Backing: &vimtypes.VirtualCdromIsoBackingInfo{
+				VirtualDeviceFileBackingInfo: vimtypes.VirtualDeviceFileBackingInfo{
+					FileName: seedPath,
+				},
+			},
+			Connectable: &vimtypes.VirtualDeviceConnectInfo{
+				StartConnected: true,
+			},
+		},
+	}
+
+	deviceChanges, err := ensureCdromHasController(curDevices, seedCdrom)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(deviceChanges, &vimtypes.VirtualDeviceConfigSpec{
+		Device:    seedCdrom,
+		Operation: vimtypes.VirtualDeviceConfigSpecOperationAdd,
+	}), nil
+}
+
+// detachNoCloudSeedCdrom returns the device changes that remove the CD-ROM
+// backed by the seed ISO. CD-ROMs on IDE controllers cannot be removed from a
+// powered on VM, so the CD-ROM is disconnected instead.
+func detachNoCloudSeedCdrom(
+	seedCdrom *vimtypes.VirtualCdrom,
+	isPoweredOn bool) []vimtypes.BaseVirtualDeviceConfigSpec {
+
+	if seedCdrom == nil {
+		return nil
+	}
+
+	if !isPoweredOn {
+		return []vimtypes.BaseVirtualDeviceConfigSpec{
+			&vimtypes.VirtualDeviceConfigSpec{
+				Device:    seedCdrom,
+				Operation: vimtypes.VirtualDeviceConfigSpecOperationRemove,
+			},
+		}
+	}
+
+	seedCdrom.Connectable = &vimtypes.VirtualDeviceConnectInfo{}
+	return []vimtypes.BaseVirtualDeviceConfigSpec{
+		&vimtypes.VirtualDeviceConfigSpec{
+			Device:    seedCdrom,
+			Operation: vimtypes.VirtualDeviceConfigSpecOperationEdit,
+		},
+	}
+}
+
+// ensureCdromHasController assigns the CD-ROM to a free IDE channel, or to a
+// SATA controller if there is none, adding a SATA controller if necessary.
+func ensureCdromHasController(
+	curDevices object.VirtualDeviceList,
+	cdrom *vimtypes.VirtualCdrom) ([]vimtypes.BaseVirtualDeviceConfigSpec, error) {
+
+	if ide := curDevices.PickController((*vimtypes.VirtualIDEController)(nil)); ide != nil {
+		curDevices.AssignController(cdrom, ide)
+		return nil, nil
+	}
+
+	if sata := curDevices.PickController((*vimtypes.VirtualSATAController)(nil)); sata != nil {
+		curDevices.AssignController(cdrom, sata)
+		return nil, nil
+	}
+
+	pci := curDevices.PickController((*vimtypes.VirtualPCIController)(nil))
+	if pci == nil {
+		return nil, fmt.Errorf("no pci controller found for new sata controller")
+	}
+
+	// Include the CD-ROM so the new controller is not assigned the same key.
+	sata, err := append(curDevices, cdrom).CreateSATAController()
+	if err != nil {
+		return nil, err
+	}
+	curDevices.AssignController(sata, pci)
+	curDevices = append(curDevices, sata)
+	curDevices.AssignController(cdrom, sata.(vimtypes.BaseVirtualController))
+
+	return []vimtypes.BaseVirtualDeviceConfigSpec{
+		&vimtypes.VirtualDeviceConfigSpec{
+			Device:    sata,
+			Operation: vimtypes.VirtualDeviceConfigSpecOperationAdd,
+		},
+	}, nil
+}
+
+// isGuestBooted returns true if the guest has reported its bootstrap status or
+// VMware Tools is running in the guest.
+func isGuestBooted(vmCtx pkgctx.VirtualMachineContext) bool {
+	if conditions.IsTrue(vmCtx.VM, vmopv1.GuestBootstrapCondition) {
+		return true
+	}
+	guest := vmCtx.MoVM.Guest
+	return guest != nil &&
+		guest.ToolsRunningStatus == string(vimtypes.VirtualMachineToolsRunningStatusGuestToolsRunning)
+}
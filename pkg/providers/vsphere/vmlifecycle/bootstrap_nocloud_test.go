@@ -0,0 +1,310 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package vmlifecycle_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware/govmomi/vim25/mo"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/constants"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/vmlifecycle"
+	pkgutil "github.com/vmware-tanzu/vm-operator/pkg/util"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/netplan"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+)
+
+type fakeDatastoreFileManager struct {
+	files     map[string][]byte
+	deleted   []string
+	uploadErr error
+}
+
+func (m *fakeDatastoreFileManager) UploadFile(_ context.Context, name string, data []byte) error {
+	if m.uploadErr != nil {
+		return m.uploadErr
+	}
+	m.files[name] = data
+	return nil
+}
+
+func (m *fakeDatastoreFileManager) DeleteFile(_ context.Context, name string) error {
+	delete(m.files, name)
+	m.deleted = append(m.deleted, name)
+	return nil
+}
+
+var _ = Describe("GetCloudInitNoCloudCustSpec", func() {
+	const (
+		metadata = "instance-id: my-vm"
+		userdata = "#cloud-config"
+		seedPath = "[datastore1] my-vm/cidata.iso"
+	)
+
+	var (
+		vmCtx       pkgctx.VirtualMachineContext
+		configInfo  *vimtypes.VirtualMachineConfigInfo
+		netPlan     *netplan.Network
+		fileManager *fakeDatastoreFileManager
+		bsArgs      vmlifecycle.BootstrapArgs
+
+		configSpec *vimtypes.VirtualMachineConfigSpec
+		err        error
+	)
+
+	seedState := func() string {
+		return pkgutil.OptionValues(configSpec.ExtraConfig).StringMap()[constants.CloudInitNoCloudSeedExtraConfigKey]
+	}
+
+	BeforeEach(func() {
+		vmCtx = pkgctx.VirtualMachineContext{
+			Context: context.Background(),
+			Logger:  suite.GetLogger(),
+			VM: &vmopv1.VirtualMachine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "my-vm",
+					Namespace: "test-ns",
+				},
+			},
+			MoVM: mo.VirtualMachine{
+				Runtime: vimtypes.VirtualMachineRuntimeInfo{
+					PowerState: vimtypes.VirtualMachinePowerStatePoweredOff,
+				},
+			},
+		}
+
+		configInfo = &vimtypes.VirtualMachineConfigInfo{
+			Files: vimtypes.VirtualMachineFileInfo{
+				VmPathName: "[datastore1] my-vm/my-vm.vmx",
+			},
+			Hardware: vimtypes.VirtualHardware{
+				Device: []vimtypes.BaseVirtualDevice{
+					&vimtypes.VirtualIDEController{
+						VirtualController: vimtypes.VirtualController{
+							VirtualDevice: vimtypes.VirtualDevice{Key: 200},
+						},
+					},
+				},
+			},
+		}
+
+		netPlan = &netplan.Network{
+			Version: constants.NetPlanVersion,
+			Ethernets: map[string]netplan.Ethernet{
+				"eth0": {
+					Dhcp4: ptr.To(true),
+				},
+			},
+		}
+
+		fileManager = &fakeDatastoreFileManager{
+			files: map[string][]byte{},
+		}
+		bsArgs = vmlifecycle.BootstrapArgs{
+			FileManager: fileManager,
+		}
+	})
+
+	JustBeforeEach(func() {
+		configSpec, err = vmlifecycle.GetCloudInitNoCloudCustSpec(
+			vmCtx, configInfo, metadata, userdata, netPlan, &bsArgs)
+	})
+
+	// applyConfigSpec updates the config info with the ExtraConfig and devices
+	// from the ConfigSpec.
+	applyConfigSpec := func() {
+		if len(configSpec.ExtraConfig) > 0 {
+			configInfo.ExtraConfig = configSpec.ExtraConfig
+		}
+		devices := configInfo.Hardware.Device
+		for _, dc := range configSpec.DeviceChange {
+			spec := dc.GetVirtualDeviceConfigSpec()
+			switch spec.Operation {
+			case vimtypes.VirtualDeviceConfigSpecOperationAdd:
+				devices = append(devices, spec.Device)
+			case vimtypes.VirtualDeviceConfigSpecOperationRemove:
+				for i := range devices {
+					if devices[i].GetVirtualDevice().Key == spec.Device.GetVirtualDevice().Key {
+						devices = append(devices[:i], devices[i+1:]...)
+						break
+					}
+				}
+			}
+		}
+		configInfo.Hardware.Device = devices
+	}
+
+	When("the seed iso has not been attached", func() {
+		It("uploads the seed iso and attaches it", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(configSpec).ToNot(BeNil())
+
+			Expect(fileManager.files).To(HaveKey(seedPath))
+			seedISO := fileManager.files[seedPath]
+			Expect(bytes.Contains(seedISO, []byte(metadata))).To(BeTrue())
+			Expect(bytes.Contains(seedISO, []byte(userdata))).To(BeTrue())
+			Expect(bytes.Contains(seedISO, []byte("dhcp4: true"))).To(BeTrue())
+
+			Expect(seedState()).ToNot(BeEmpty())
+			Expect(seedState()).ToNot(ContainSubstring(":"))
+
+			Expect(configSpec.DeviceChange).To(HaveLen(1))
+			spec := configSpec.DeviceChange[0].GetVirtualDeviceConfigSpec()
+			Expect(spec.Operation).To(Equal(vimtypes.VirtualDeviceConfigSpecOperationAdd))
+			cdrom, ok := spec.Device.(*vimtypes.VirtualCdrom)
+			Expect(ok).To(BeTrue())
+			Expect(cdrom.ControllerKey).To(BeEquivalentTo(200))
+			Expect(cdrom.Connectable.StartConnected).To(BeTrue())
+			backing, ok := cdrom.Backing.(*vimtypes.VirtualCdromIsoBackingInfo)
+			Expect(ok).To(BeTrue())
+			Expect(backing.FileName).To(Equal(seedPath))
+		})
+
+		When("the VM has no IDE or SATA controller", func() {
+			BeforeEach(func() {
+				configInfo.Hardware.Device = []vimtypes.BaseVirtualDevice{
+					&vimtypes.VirtualPCIController{
+						VirtualController: vimtypes.VirtualController{
+							VirtualDevice: vimtypes.VirtualDevice{Key: 100},
+						},
+					},
+				}
+			})
+
+			It("adds a SATA controller", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(configSpec.DeviceChange).To(HaveLen(2))
+				sata, ok := configSpec.DeviceChange[0].GetVirtualDeviceConfigSpec().Device.(*vimtypes.VirtualAHCIController)
+				Expect(ok).To(BeTrue())
+				Expect(sata.ControllerKey).To(BeEquivalentTo(100))
+				cdrom, ok := configSpec.DeviceChange[1].GetVirtualDeviceConfigSpec().Device.(*vimtypes.VirtualCdrom)
+				Expect(ok).To(BeTrue())
+				Expect(cdrom.ControllerKey).To(Equal(sata.Key))
+				Expect(cdrom.Key).ToNot(Equal(sata.Key))
+			})
+		})
+
+		When("the VM is powered on", func() {
+			BeforeEach(func() {
+				vmCtx.MoVM.Runtime.PowerState = vimtypes.VirtualMachinePowerStatePoweredOn
+			})
+
+			It("does nothing", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(configSpec).To(BeNil())
+				Expect(fileManager.files).To(BeEmpty())
+			})
+		})
+
+		When("the upload fails", func() {
+			BeforeEach(func() {
+				fileManager.uploadErr = errors.New("upload failed")
+			})
+
+			It("returns an error", func() {
+				Expect(err).To(MatchError("failed to upload cloud-init nocloud seed iso: upload failed"))
+			})
+		})
+	})
+
+	When("the seed iso is attached", func() {
+		JustBeforeEach(func() {
+			Expect(err).ToNot(HaveOccurred())
+			applyConfigSpec()
+			vmCtx.MoVM.Runtime.PowerState = vimtypes.VirtualMachinePowerStatePoweredOn
+		})
+
+		It("does nothing until the guest has booted", func() {
+			configSpec, err = vmlifecycle.GetCloudInitNoCloudCustSpec(
+				vmCtx, configInfo, metadata, userdata, netPlan, &bsArgs)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(configSpec).To(BeNil())
+		})
+
+		It("disconnects and deletes the seed iso after the guest has booted", func() {
+			conditions.MarkTrue(vmCtx.VM, vmopv1.GuestBootstrapCondition)
+
+			By("disconnecting the seed iso", func() {
+				configSpec, err = vmlifecycle.GetCloudInitNoCloudCustSpec(
+					vmCtx, configInfo, metadata, userdata, netPlan, &bsArgs)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(configSpec).ToNot(BeNil())
+				Expect(seedState()).To(HaveSuffix(":detached"))
+				Expect(configSpec.DeviceChange).To(HaveLen(1))
+				spec := configSpec.DeviceChange[0].GetVirtualDeviceConfigSpec()
+				Expect(spec.Operation).To(Equal(vimtypes.VirtualDeviceConfigSpecOperationEdit))
+				Expect(spec.Device.GetVirtualDevice().Connectable.StartConnected).To(BeFalse())
+				applyConfigSpec()
+			})
+
+			By("deleting the seed iso", func() {
+				configSpec, err = vmlifecycle.GetCloudInitNoCloudCustSpec(
+					vmCtx, configInfo, metadata, userdata, netPlan, &bsArgs)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(configSpec).ToNot(BeNil())
+				Expect(seedState()).To(HaveSuffix(":removed"))
+				Expect(configSpec.DeviceChange).To(BeEmpty())
+				Expect(fileManager.deleted).To(ConsistOf(seedPath))
+				Expect(fileManager.files).To(BeEmpty())
+				applyConfigSpec()
+			})
+
+			By("removing the CD-ROM once the VM is powered off", func() {
+				configSpec, err = vmlifecycle.GetCloudInitNoCloudCustSpec(
+					vmCtx, configInfo, metadata, userdata, netPlan, &bsArgs)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(configSpec).To(BeNil())
+
+				vmCtx.MoVM.Runtime.PowerState = vimtypes.VirtualMachinePowerStatePoweredOff
+				configSpec, err = vmlifecycle.GetCloudInitNoCloudCustSpec(
+					vmCtx, configInfo, metadata, userdata, netPlan, &bsArgs)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(configSpec).ToNot(BeNil())
+				Expect(configSpec.DeviceChange).To(HaveLen(1))
+				spec := configSpec.DeviceChange[0].GetVirtualDeviceConfigSpec()
+				Expect(spec.Operation).To(Equal(vimtypes.VirtualDeviceConfigSpecOperationRemove))
+				applyConfigSpec()
+			})
+
+			By("not attaching the seed iso again", func() {
+				configSpec, err = vmlifecycle.GetCloudInitNoCloudCustSpec(
+					vmCtx, configInfo, metadata, userdata, netPlan, &bsArgs)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(configSpec).To(BeNil())
+				Expect(fileManager.files).To(BeEmpty())
+			})
+		})
+	})
+
+	When("the VM path is invalid", func() {
+		BeforeEach(func() {
+			configInfo.Files.VmPathName = ""
+		})
+
+		It("returns an error", func() {
+			Expect(err).To(MatchError(`invalid vm path name ""`))
+		})
+	})
+
+	When("the file manager is nil", func() {
+		BeforeEach(func() {
+			bsArgs.FileManager = nil
+		})
+
+		It("returns an error", func() {
+			Expect(err).To(MatchError("datastore file manager is nil"))
+		})
+	})
+})
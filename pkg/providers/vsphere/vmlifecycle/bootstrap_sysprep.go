@@ -27,7 +27,11 @@ func BootstrapSysPrep(
 	logger := logr.FromContextOrDiscard(vmCtx)
 	logger.V(4).Info("Reconciling Sysprep bootstrap state")
 
-	if !vmCtx.IsOffToOn() {
+	// The customization spec is still created for a powered on VM so changes
+	// to it, such as from a hot added network interface, are reported as
+	// pending until the VM is powered on again.
+	isPoweredOn := vmCtx.MoVM.Runtime.PowerState == vimtypes.VirtualMachinePowerStatePoweredOn
+	if !vmCtx.IsOffToOn() && !isPoweredOn {
 		vmCtx.Logger.V(4).Info("Skipping Sysprep since VM is not powering on")
 		return nil, nil, nil
 	}
@@ -83,6 +87,10 @@ func BootstrapSysPrep(
 		NicSettingMap: nicSettingMap,
 	}
 
+	if isPoweredOn {
+		return nil, customSpec, nil
+	}
+
 	var configSpec *vimtypes.VirtualMachineConfigSpec
 	if vAppConfigSpec != nil {
 		configSpec = &vimtypes.VirtualMachineConfigSpec{}
@@ -13,6 +13,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/api/v1alpha4/common"
 	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
 	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/constants"
 	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/network"
@@ -238,6 +239,82 @@ var _ = Describe("TemplateVMMetadata", func() {
 
 	})
 
+	Context("v1alpha4 subnet, network and VM functions", func() {
+		BeforeEach(func() {
+			vm.Labels = map[string]string{"my-label": "my-label-value"}
+			vm.Annotations = map[string]string{"my-annotation": "my-annotation-value"}
+			vm.Spec.InstanceUUID = "my-instance-uuid"
+			vm.Spec.BiosUUID = "my-bios-uuid"
+			vm.Status.Zone = "my-zone"
+			vm.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{
+				VAppConfig: &vmopv1.VirtualMachineBootstrapVAppConfigSpec{
+					Properties: []common.KeyValueOrSecretKeySelectorPair{
+						{
+							Key: "my-key",
+							Value: common.ValueOrSecretKeySelector{
+								From: &common.SecretKeySelector{
+									Name: "my-secret",
+									Key:  "my-secret-key",
+								},
+							},
+						},
+					},
+				},
+			}
+			bsArgs.VAppExData = map[string]map[string]string{
+				"my-secret": {
+					"my-secret-key":   "my-secret-value",
+					"my-other-secret": "my-other-secret-value",
+				},
+			}
+			bsArgs.NetworkResults.Results[1].Routes = []network.NetworkInterfaceRoute{
+				{
+					To:     "10.0.0.0/8",
+					Via:    gateway2,
+					Metric: 42,
+				},
+			}
+		})
+
+		DescribeTable("renders",
+			func(str, expected string) {
+				fn := vmlifecycle.GetTemplateRenderFunc(vmCtx, bsArgs)
+				out := fn("", str)
+				Expect(out).To(Equal(expected))
+			},
+			Entry("subnet_network", "{{ "+constants.V1alpha4SubnetNetwork+" \"192.168.1.37/26\" }}", "192.168.1.0"),
+			Entry("subnet_network_ipv6", "{{ "+constants.V1alpha4SubnetNetwork+" \"fd00::1234/64\" }}", "fd00::"),
+			Entry("subnet_broadcast", "{{ "+constants.V1alpha4SubnetBroadcast+" \"192.168.1.37/26\" }}", "192.168.1.63"),
+			Entry("subnet_prefix", "{{ "+constants.V1alpha4SubnetPrefix+" "+constants.V1alpha4FirstIP+" }}", "24"),
+			Entry("subnet_nth_host", "{{ "+constants.V1alpha4SubnetNthHost+" \"192.168.1.37/24\" 1 }}", "192.168.1.1"),
+			Entry("subnet_nth_host_negative", "{{ "+constants.V1alpha4SubnetNthHost+" \"192.168.1.37/24\" -2 }}", "192.168.1.254"),
+			Entry("subnet_nth_host_ipv6", "{{ "+constants.V1alpha4SubnetNthHost+" \"fd00::1234/64\" 10 }}", "fd00::a"),
+			Entry("gateway", "{{ "+constants.V1alpha4GatewayFromNIC+" 1 }}", gateway2),
+			Entry("routes", "{{ range "+constants.V1alpha4RoutesFromNIC+" 1 }}{{ .To }} via {{ .Via }} metric {{ .Metric }}{{ end }}", "10.0.0.0/8 via "+gateway2+" metric 42"),
+			Entry("no_routes", "{{ len ("+constants.V1alpha4RoutesFromNIC+" 0) }}", "0"),
+			Entry("label", "{{ "+constants.V1alpha4Label+" \"my-label\" }}", "my-label-value"),
+			Entry("annotation", "{{ "+constants.V1alpha4Annotation+" \"my-annotation\" }}", "my-annotation-value"),
+			Entry("zone", "{{ "+constants.V1alpha4ZoneName+" }}", "my-zone"),
+			Entry("instance_uuid", "{{ "+constants.V1alpha4InstanceUUID+" }}", "my-instance-uuid"),
+			Entry("bios_uuid", "{{ "+constants.V1alpha4BiosUUID+" }}", "my-bios-uuid"),
+			Entry("secret_value", "{{ "+constants.V1alpha4SecretValue+" \"my-secret\" \"my-other-secret\" }}", "my-other-secret-value"),
+		)
+
+		DescribeTable("returns the original text",
+			func(str string) {
+				fn := vmlifecycle.GetTemplateRenderFunc(vmCtx, bsArgs)
+				out := fn("", str)
+				Expect(out).To(Equal(str))
+			},
+			Entry("subnet_broadcast_ipv6", "{{ "+constants.V1alpha4SubnetBroadcast+" \"fd00::1234/64\" }}"),
+			Entry("subnet_nth_host_out_of_range", "{{ "+constants.V1alpha4SubnetNthHost+" \"192.168.1.37/24\" 256 }}"),
+			Entry("gateway_out_of_range", "{{ "+constants.V1alpha4GatewayFromNIC+" 5 }}"),
+			Entry("missing_label", "{{ "+constants.V1alpha4Label+" \"not-a-label\" }}"),
+			Entry("secret_not_referenced", "{{ "+constants.V1alpha4SecretValue+" \"not-my-secret\" \"my-secret-key\" }}"),
+			Entry("secret_missing_key", "{{ "+constants.V1alpha4SecretValue+" \"my-secret\" \"not-my-key\" }}"),
+		)
+	})
+
 	Context("Invalid template names", func() {
 		DescribeTable("returns the original text",
 			func(str string) {
@@ -6,14 +6,59 @@ package vmlifecycle
 
 import (
 	"errors"
+	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/go-logr/logr"
 	vimtypes "github.com/vmware/govmomi/vim25/types"
 
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
 	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/vapp"
 )
 
+const (
+	// VAppPropertiesInvalidReason is the reason used when one or more vApp
+	// property values do not match the type of the property.
+	VAppPropertiesInvalidReason = "InvalidVAppProperties"
+
+	// VAppPropertiesMissingReason is the reason used when one or more
+	// user-configurable vApp properties that do not have a default value and
+	// do not allow an empty value are not set.
+	VAppPropertiesMissingReason = "MissingVAppProperties"
+)
+
+// VAppPropertiesError is returned when the vApp property values do not match
+// the types of the VM's vApp properties.
+type VAppPropertiesError struct {
+	// Invalid is a list of the invalid properties and why they are invalid.
+	Invalid []string
+
+	// Missing is a list of the keys of the required properties that are not
+	// set.
+	Missing []string
+}
+
+func (e VAppPropertiesError) Error() string {
+	var msgs []string
+	if len(e.Invalid) > 0 {
+		msgs = append(msgs, "invalid vApp properties: "+strings.Join(e.Invalid, "; "))
+	}
+	if len(e.Missing) > 0 {
+		msgs = append(msgs, "missing required vApp properties: "+strings.Join(e.Missing, ", "))
+	}
+	return strings.Join(msgs, ", ")
+}
+
+// Reason returns the condition reason for the error.
+func (e VAppPropertiesError) Reason() string {
+	if len(e.Invalid) > 0 {
+		return VAppPropertiesInvalidReason
+	}
+	return VAppPropertiesMissingReason
+}
+
 func BootstrapVAppConfig(
 	vmCtx pkgctx.VirtualMachineContext,
 	config *vimtypes.VirtualMachineConfigInfo,
@@ -74,9 +119,58 @@ func GetOVFVAppConfigForConfigSpec(
 		}
 	}
 
+	if err := ValidateVAppProperties(vAppData, vAppConfigInfo.Property); err != nil {
+		return nil, err
+	}
+
 	return GetMergedvAppConfigSpec(vAppData, vAppConfigInfo.Property), nil
 }
 
+// ValidateVAppProperties returns a VAppPropertiesError if any of the provided
+// key/value fields do not match the type of the VM's user-configurable vApp
+// property with the same key, or if a user-configurable property without a
+// default value that does not allow an empty value would not have a value.
+// Properties with types that cannot be parsed are not validated.
+func ValidateVAppProperties(inProps map[string]string, vmProps []vimtypes.VAppPropertyInfo) error {
+	var vErr VAppPropertiesError
+
+	for _, vmProp := range vmProps {
+		if vmProp.UserConfigurable == nil || !*vmProp.UserConfigurable {
+			continue
+		}
+
+		propType, err := vapp.ParsePropertyType(vmProp.Type)
+		if err != nil {
+			continue
+		}
+
+		value, found := inProps[vmProp.Id]
+		if !found {
+			value = vmProp.Value
+		}
+
+		if value == "" && !propType.AllowsEmptyValue() {
+			if vmProp.DefaultValue == "" {
+				vErr.Missing = append(vErr.Missing, vmProp.Id)
+			}
+			continue
+		}
+
+		if err := propType.Validate(value); err != nil {
+			vErr.Invalid = append(vErr.Invalid, fmt.Sprintf("%s: %s", vmProp.Id, err))
+		}
+	}
+
+	if len(vErr.Invalid) == 0 && len(vErr.Missing) == 0 {
+		return nil
+	}
+
+	sort.Strings(vErr.Invalid)
+	sort.Strings(vErr.Missing)
+
+	return vErr
+}
+
 // GetMergedvAppConfigSpec prepares a vApp VmConfigSpec which will set the provided key/value fields.
 // Only fields marked userConfigurable and pre-existing on the VM (ie. originated from the OVF Image)
 // will be set, and all others will be ignored.
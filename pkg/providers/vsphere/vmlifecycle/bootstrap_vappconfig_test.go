@@ -5,6 +5,7 @@
 package vmlifecycle_test
 
 import (
+	"errors"
 	"strings"
 
 	. "github.com/onsi/ginkgo/v2"
@@ -209,9 +210,137 @@ var _ = Describe("VAppConfig Bootstrap", func() {
 				})
 			})
 		})
+
+		Context("Typed properties", func() {
+			BeforeEach(func() {
+				configInfo.VAppConfig.GetVmConfigInfo().Property = []vimtypes.VAppPropertyInfo{
+					{
+						Id:               "port",
+						Type:             "int(1..65535)",
+						UserConfigurable: ptr.To(true),
+					},
+					{
+						Id:               "size",
+						Type:             `string["small","large"]`,
+						DefaultValue:     "small",
+						UserConfigurable: ptr.To(true),
+					},
+				}
+				bsArgs.VAppData["port"] = "443"
+			})
+
+			It("Expected VAppConfig", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(baseVMConfigSpec).ToNot(BeNil())
+
+				vmCs := baseVMConfigSpec.GetVmConfigSpec()
+				Expect(vmCs).ToNot(BeNil())
+				Expect(vmCs.Property).To(HaveLen(1))
+				Expect(vmCs.Property[0].Info.Id).To(Equal("port"))
+				Expect(vmCs.Property[0].Info.Value).To(Equal("443"))
+			})
+
+			When("a value does not match the type", func() {
+				BeforeEach(func() {
+					bsArgs.VAppData["port"] = "https"
+					bsArgs.VAppData["size"] = "medium"
+				})
+
+				It("Should return an error", func() {
+					var vAppErr vmlifecycle.VAppPropertiesError
+					Expect(errors.As(err, &vAppErr)).To(BeTrue())
+					Expect(vAppErr.Reason()).To(Equal(vmlifecycle.VAppPropertiesInvalidReason))
+					Expect(vAppErr.Invalid).To(Equal([]string{
+						"port: must be an integer",
+						"size: must be one of small, large",
+					}))
+					Expect(baseVMConfigSpec).To(BeNil())
+				})
+			})
+
+			When("a required value is missing", func() {
+				BeforeEach(func() {
+					delete(bsArgs.VAppData, "port")
+				})
+
+				It("Should return an error", func() {
+					var vAppErr vmlifecycle.VAppPropertiesError
+					Expect(errors.As(err, &vAppErr)).To(BeTrue())
+					Expect(vAppErr.Reason()).To(Equal(vmlifecycle.VAppPropertiesMissingReason))
+					Expect(vAppErr.Missing).To(Equal([]string{"port"}))
+					Expect(err).To(MatchError("missing required vApp properties: port"))
+				})
+			})
+		})
 	})
 })
 
+var _ = Describe("ValidateVAppProperties", func() {
+
+	DescribeTable("returns expected error",
+		func(inProps map[string]string, vmProps []vimtypes.VAppPropertyInfo, expectedErr string) {
+			err := vmlifecycle.ValidateVAppProperties(inProps, vmProps)
+			if expectedErr == "" {
+				Expect(err).ToNot(HaveOccurred())
+			} else {
+				Expect(err).To(MatchError(expectedErr))
+			}
+		},
+		Entry("no error for untyped props",
+			map[string]string{"one-id": "anything"},
+			[]vimtypes.VAppPropertyInfo{
+				{Id: "one-id", UserConfigurable: ptr.To(true)},
+			},
+			"",
+		),
+		Entry("no error for non UserConfigurable props",
+			map[string]string{"one-id": "not-a-number"},
+			[]vimtypes.VAppPropertyInfo{
+				{Id: "one-id", Type: "int", UserConfigurable: ptr.To(false)},
+				{Id: "two-id", Type: "int"},
+			},
+			"",
+		),
+		Entry("no error for props with a default value",
+			map[string]string{},
+			[]vimtypes.VAppPropertyInfo{
+				{Id: "one-id", Type: "boolean", DefaultValue: "True", UserConfigurable: ptr.To(true)},
+			},
+			"",
+		),
+		Entry("no error for props with an existing value",
+			map[string]string{},
+			[]vimtypes.VAppPropertyInfo{
+				{Id: "one-id", Type: "ip", Value: "192.168.1.10", UserConfigurable: ptr.To(true)},
+				{Id: "two-id", Type: "real", Value: "1.5", UserConfigurable: ptr.To(true)},
+			},
+			"",
+		),
+		Entry("no error for props that allow an empty value",
+			map[string]string{},
+			[]vimtypes.VAppPropertyInfo{
+				{Id: "one-id", Type: "string", UserConfigurable: ptr.To(true)},
+				{Id: "two-id", Type: "password", UserConfigurable: ptr.To(true)},
+				{Id: "three-id", Type: "ip", UserConfigurable: ptr.To(true)},
+			},
+			"",
+		),
+		Entry("error for invalid and missing props",
+			map[string]string{
+				"one-id": "192.168.1.300",
+				"two-id": "yes",
+			},
+			[]vimtypes.VAppPropertyInfo{
+				{Id: "one-id", Type: "ip", UserConfigurable: ptr.To(true)},
+				{Id: "two-id", Type: "boolean", UserConfigurable: ptr.To(true)},
+				{Id: "three-id", Type: "password(8..)", UserConfigurable: ptr.To(true)},
+			},
+			"invalid vApp properties: one-id: must be an IPv4 or IPv6 address; two-id: must be one of True, False, "+
+				"missing required vApp properties: three-id",
+		),
+	)
+})
+
 var _ = Describe("GetMergedvAppConfigSpec", func() {
 
 	DescribeTable("returns expected props",
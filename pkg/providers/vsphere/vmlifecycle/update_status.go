@@ -472,8 +472,8 @@ func MarkBootstrapCondition(
 
 	status, reason, msg, ok := util.GetBootstrapConditionValues(extraConfig)
 	if !ok {
-		conditions.MarkUnknown(
-			vm, vmopv1.GuestBootstrapCondition, "NoBootstrapStatus", "")
+		// Fallback to the status reported by Cloud-Init itself.
+		markCloudInitBootstrapCondition(vm, extraConfig)
 		return
 	}
 	if status {
@@ -488,6 +488,29 @@ func MarkBootstrapCondition(
 	}
 }
 
+func markCloudInitBootstrapCondition(
+	vm *vmopv1.VirtualMachine,
+	extraConfig map[string]string) {
+
+	status, reason, msg, ok := util.GetCloudInitBootstrapConditionValues(extraConfig)
+	if !ok {
+		conditions.MarkUnknown(
+			vm, vmopv1.GuestBootstrapCondition, "NoBootstrapStatus", "")
+		return
+	}
+
+	switch status {
+	case metav1.ConditionTrue:
+		c := conditions.TrueCondition(vmopv1.GuestBootstrapCondition)
+		c.Message = msg
+		conditions.Set(vm, c)
+	case metav1.ConditionFalse:
+		conditions.MarkFalse(vm, vmopv1.GuestBootstrapCondition, reason, "%s", msg)
+	default:
+		conditions.MarkUnknown(vm, vmopv1.GuestBootstrapCondition, reason, "%s", msg)
+	}
+}
+
 func MarkVMClassConfigurationSynced(
 	ctx context.Context,
 	vm *vmopv1.VirtualMachine,
@@ -671,6 +694,10 @@ func UpdateNetworkStatusConfig(vm *vmopv1.VirtualMachine, args BootstrapArgs) {
 			slices.Sort(ifc.IP.Addresses)
 		}
 
+		// Update the bandwidth allocation and traffic rules.
+		ifc.QoS = r.QoS
+		ifc.TrafficRules = r.TrafficRules
+
 		// Only append the interface config if it is not empty.
 		if !reflect.DeepEqual(ifc, emptyIfaceConfig) {
 			// Do not assign the name until the very end so as to not disrupt
@@ -1921,6 +1921,68 @@ var _ = Describe("VSphere Bootstrap Status to VM Status Condition", func() {
 				})
 			})
 		})
+		Context("cloud-init condition", func() {
+			When("cloud-init is running", func() {
+				BeforeEach(func() {
+					extraConfig = map[string]string{
+						util.GuestInfoCloudInitStatus: `{"v1": {"stage": "init", "init": {"start": 1700000000.0}}}`,
+					}
+				})
+				It("sets condition unknown", func() {
+					expectedConditions := []metav1.Condition{
+						*conditions.UnknownCondition(
+							vmopv1.GuestBootstrapCondition,
+							util.CloudInitRunningReason,
+							"cloud-init is running stage init"),
+					}
+					Expect(vm.Status.Conditions).To(conditions.MatchConditions(expectedConditions))
+				})
+			})
+			When("cloud-init succeeded", func() {
+				BeforeEach(func() {
+					extraConfig = map[string]string{
+						util.GuestInfoCloudInitResult: `{"v1": {"errors": []}}`,
+					}
+				})
+				It("sets condition true", func() {
+					expectedConditions := []metav1.Condition{
+						*conditions.TrueCondition(vmopv1.GuestBootstrapCondition),
+					}
+					expectedConditions[0].Message = "cloud-init finished"
+					Expect(vm.Status.Conditions).To(conditions.MatchConditions(expectedConditions))
+				})
+			})
+			When("a cloud-init module failed", func() {
+				BeforeEach(func() {
+					extraConfig = map[string]string{
+						util.GuestInfoCloudInitResult: `{"v1": {"errors": ["('write_files', TypeError('bad'))"]}}`,
+					}
+				})
+				It("sets condition false", func() {
+					expectedConditions := []metav1.Condition{
+						*conditions.FalseCondition(
+							vmopv1.GuestBootstrapCondition,
+							util.CloudInitModuleFailedReason,
+							"module cc_write_files failed: TypeError('bad')"),
+					}
+					Expect(vm.Status.Conditions).To(conditions.MatchConditions(expectedConditions))
+				})
+			})
+			When("there is also a bootstrap condition", func() {
+				BeforeEach(func() {
+					extraConfig = map[string]string{
+						util.GuestInfoBootstrapCondition: "true",
+						util.GuestInfoCloudInitResult:    `{"v1": {"errors": ["no datasource found"]}}`,
+					}
+				})
+				It("prefers the bootstrap condition", func() {
+					expectedConditions := []metav1.Condition{
+						*conditions.TrueCondition(vmopv1.GuestBootstrapCondition),
+					}
+					Expect(vm.Status.Conditions).To(conditions.MatchConditions(expectedConditions))
+				})
+			})
+		})
 	})
 })
 
@@ -44,6 +44,7 @@ import (
 	"github.com/vmware-tanzu/vm-operator/pkg/record"
 	"github.com/vmware-tanzu/vm-operator/pkg/topology"
 	"github.com/vmware-tanzu/vm-operator/pkg/util"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ova"
 	"github.com/vmware-tanzu/vm-operator/pkg/util/ovfcache"
 	vsclient "github.com/vmware-tanzu/vm-operator/pkg/util/vsphere/client"
 )
@@ -365,6 +366,43 @@ func (vs *vSphereVMProvider) UpdateContentLibraryItem(ctx context.Context, itemI
 	return contentLibraryProvider.UpdateLibraryItem(ctx, itemID, newName, newDescription)
 }
 
+// GetContentLibraryItemFiles returns the files of the specified content
+// library item.
+func (vs *vSphereVMProvider) GetContentLibraryItemFiles(ctx context.Context, itemID string) ([]library.File, error) {
+	log.V(4).Info("Get Content Library Item Files", "itemID", itemID)
+
+	client, err := vs.getVcClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	contentLibraryProvider := contentlibrary.NewProvider(ctx, client.RestClient())
+	return contentLibraryProvider.ListLibraryItemFiles(ctx, itemID)
+}
+
+// CreateContentLibraryItemFromOVA creates an OVF library item in the content
+// library from the files read from the OVA and returns the ID of the item.
+func (vs *vSphereVMProvider) CreateContentLibraryItemFromOVA(
+	ctx context.Context,
+	libraryUUID, itemName, itemDescription string,
+	ovaReader *ova.Reader) (string, error) {
+
+	log.V(4).Info("Create Content Library Item from OVA",
+		"UUID", libraryUUID, "item name", itemName)
+
+	client, err := vs.getVcClient(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	contentLibraryProvider := contentlibrary.NewProvider(ctx, client.RestClient())
+	return contentLibraryProvider.CreateLibraryItemFromOVA(ctx, library.Item{
+		Name:        itemName,
+		Description: &itemDescription,
+		LibraryID:   libraryUUID,
+	}, ovaReader)
+}
+
 func (vs *vSphereVMProvider) getOpID(vm *vmopv1.VirtualMachine, operation string) string {
 	const charset = "0123456789abcdef"
 
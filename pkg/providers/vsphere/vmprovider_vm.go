@@ -61,6 +61,7 @@ import (
 	pkgutil "github.com/vmware-tanzu/vm-operator/pkg/util"
 	kubeutil "github.com/vmware-tanzu/vm-operator/pkg/util/kube"
 	"github.com/vmware-tanzu/vm-operator/pkg/util/kube/cource"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/oci"
 	vmopv1util "github.com/vmware-tanzu/vm-operator/pkg/util/vmopv1"
 	vmutil "github.com/vmware-tanzu/vm-operator/pkg/util/vsphere/vm"
 	vmconfcrypto "github.com/vmware-tanzu/vm-operator/pkg/vmconfig/crypto"
@@ -75,6 +76,7 @@ var (
 	ErrBootstrapCustomize     = vmlifecycle.ErrBootstrapCustomize
 	ErrReconfigure            = session.ErrReconfigure
 	ErrRestart                = pkgerr.NoRequeueNoErr("restarted vm")
+	ErrRecustomizePowerOff    = pkgerr.NoRequeueNoErr("powered off vm to re-customize")
 	ErrUpgradeHardwareVersion = session.ErrUpgradeHardwareVersion
 	ErrIsPaused               = pkgerr.NoRequeueNoErr("is paused")
 	ErrHasTask                = pkgerr.NoRequeueNoErr("has outstanding task")
@@ -307,12 +309,21 @@ func (vs *vSphereVMProvider) DeleteVirtualMachine(
 	vcVM, err := vs.getVM(vmCtx, client, false)
 	if err != nil {
 		return err
-	} else if vcVM == nil {
-		// VM does not exist.
-		return nil
 	}
 
-	return virtualmachine.DeleteVirtualMachine(vmCtx, vcVM)
+	if vcVM != nil {
+		if err := virtualmachine.DeleteVirtualMachine(vmCtx, vcVM); err != nil {
+			return err
+		}
+	}
+
+	if network.HasAddressesFromPools(vm) {
+		// Return any addresses allocated from IPAM pools now instead of
+		// waiting for the claims to be garbage collected after the VM is gone.
+		return network.ReleaseIPAddressClaims(vmCtx, vs.k8sClient)
+	}
+
+	return nil
 }
 
 func (vs *vSphereVMProvider) PublishVirtualMachine(
@@ -336,12 +347,105 @@ func (vs *vSphereVMProvider) PublishVirtualMachine(
 		return "", fmt.Errorf("failed to get vCenter client: %w", err)
 	}
 
-	itemID, err := virtualmachine.CreateOVF(vmCtx, client.RestClient(), vmPub, cl, actID)
+	if !isPublishFromSnapshot(vmPub) {
+		itemID, err := virtualmachine.CreateOVF(vmCtx, client.RestClient(), vmPub, cl, actID)
+		if err != nil {
+			return "", err
+		}
+
+		return itemID, nil
+	}
+
+	clone, err := vs.cloneVMFromPublishSnapshot(vmCtx, client, vmPub)
+	if err != nil {
+		return "", err
+	}
+	defer vs.destroyPublishSnapshotClone(vmCtx, clone)
+
+	return virtualmachine.CreateOVFFromSource(vmCtx, client.RestClient(), vmPub, cl, clone.Reference().Value, actID)
+}
+
+func (vs *vSphereVMProvider) PublishVirtualMachineToOCI(
+	ctx context.Context,
+	vm *vmopv1.VirtualMachine,
+	vmPub *vmopv1.VirtualMachinePublishRequest,
+	ociClient *oci.Client,
+	ref oci.Reference) (string, error) {
+
+	vmCtx := pkgctx.VirtualMachineContext{
+		Context: context.WithValue(ctx, vimtypes.ID{}, vs.getOpID(vm, "publishToOCI")),
+		Logger: log.WithValues("vmName", vm.NamespacedName()).
+			WithValues("vmPubName", fmt.Sprintf("%s/%s", vmPub.Namespace, vmPub.Name)),
+		VM: vm,
+	}
+
+	client, err := vs.getVcClient(vmCtx)
+	if err != nil {
+		return "", fmt.Errorf("failed to get vCenter client: %w", err)
+	}
+
+	if !isPublishFromSnapshot(vmPub) {
+		vcVM, err := vs.getVM(vmCtx, client, true)
+		if err != nil {
+			return "", err
+		}
+
+		return virtualmachine.ExportOVFToOCI(vmCtx, vcVM, vmPub, ociClient, ref)
+	}
+
+	clone, err := vs.cloneVMFromPublishSnapshot(vmCtx, client, vmPub)
 	if err != nil {
 		return "", err
 	}
+	defer vs.destroyPublishSnapshotClone(vmCtx, clone)
 
-	return itemID, nil
+	return virtualmachine.ExportOVFToOCI(vmCtx, clone, vmPub, ociClient, ref)
+}
+
+// isPublishFromSnapshot returns true if the VM is published from a snapshot.
+func isPublishFromSnapshot(vmPub *vmopv1.VirtualMachinePublishRequest) bool {
+	return vmPub.Spec.Source.Kind ==
+		vmopv1.VirtualMachinePublishRequestSourceKindVirtualMachineSnapshot
+}
+
+// cloneVMFromPublishSnapshot creates a temporary linked clone of the VM from
+// the snapshot that is the source of the publish request.
+func (vs *vSphereVMProvider) cloneVMFromPublishSnapshot(
+	vmCtx pkgctx.VirtualMachineContext,
+	client *vcclient.Client,
+	vmPub *vmopv1.VirtualMachinePublishRequest) (*object.VirtualMachine, error) {
+
+	var (
+		vmSnapshot vmopv1.VirtualMachineSnapshot
+		key        = ctrlclient.ObjectKey{
+			Name:      vmPub.Status.SourceRef.Name,
+			Namespace: vmPub.Namespace,
+		}
+	)
+
+	if err := vs.k8sClient.Get(vmCtx, key, &vmSnapshot); err != nil {
+		return nil, fmt.Errorf("failed to get VirtualMachineSnapshot: %w", err)
+	}
+	if vmSnapshot.Status.UniqueID == "" {
+		return nil, fmt.Errorf("VirtualMachineSnapshot %s has no uniqueID", key)
+	}
+
+	vcVM, err := vs.getVM(vmCtx, client, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return virtualmachine.CloneFromSnapshot(vmCtx, vcVM, vmPub, vmSnapshot.Status.UniqueID)
+}
+
+func (vs *vSphereVMProvider) destroyPublishSnapshotClone(
+	vmCtx pkgctx.VirtualMachineContext,
+	clone *object.VirtualMachine) {
+
+	if err := virtualmachine.DestroySnapshotClone(vmCtx, clone); err != nil {
+		vmCtx.Logger.Error(err, "failed to destroy VM cloned from snapshot",
+			"cloneID", clone.Reference().Value)
+	}
 }
 
 func (vs *vSphereVMProvider) GetVirtualMachineGuestHeartbeat(
@@ -809,6 +913,16 @@ func (vs *vSphereVMProvider) updateVirtualMachine(
 		reconcileErr = getReconcileErr("backup state", reconcileErr, err)
 	}
 
+	//
+	// Reconcile re-customization
+	//
+	if err := vs.reconcileRecustomize(vmCtx, vcVM); err != nil {
+		if pkgerr.IsNoRequeueError(err) {
+			return errOrReconcileErr(reconcileErr, err)
+		}
+		return getReconcileErr("re-customize", reconcileErr, err)
+	}
+
 	//
 	// Reconcile config
 	//
@@ -966,6 +1080,73 @@ func (vs *vSphereVMProvider) reconcileBackupState(
 	return virtualmachine.BackupVirtualMachine(backupOpts)
 }
 
+// reconcileRecustomize prepares the VM to be re-customized when the value of
+// spec.bootstrap.customizationGeneration is greater than the value of
+// status.observedCustomizationGeneration. A powered on VM is first powered
+// off. Once the VM is powered off, the bootstrap hashes no longer match since
+// they include the customization generation, so the bootstrap provider is
+// re-run by the reconcile config step with the VM's current bootstrap and
+// network configuration. The observed generation is updated once the bootstrap
+// provider succeeds. The VM is then powered on by the reconcile power state
+// step if its desired power state is powered on.
+func (vs *vSphereVMProvider) reconcileRecustomize(
+	vmCtx pkgctx.VirtualMachineContext,
+	vcVM *object.VirtualMachine) error {
+
+	bs := vmCtx.VM.Spec.Bootstrap
+	if bs == nil || bs.CustomizationGeneration <= vmCtx.VM.Status.ObservedCustomizationGeneration {
+		return nil
+	}
+
+	generation := bs.CustomizationGeneration
+	logger := vmCtx.Logger.WithValues(
+		"customizationGeneration", generation,
+		"observedCustomizationGeneration", vmCtx.VM.Status.ObservedCustomizationGeneration)
+
+	switch vmCtx.MoVM.Runtime.PowerState {
+	case vimtypes.VirtualMachinePowerStatePoweredOn:
+		if isVMPaused(vmCtx) {
+			return ErrIsPaused
+		}
+		if vmCtx.VM.Status.TaskID != "" {
+			return ErrHasTask
+		}
+
+		logger.Info("Powering off VM to re-customize guest")
+		pkgcnd.MarkFalse(
+			vmCtx.VM,
+			vmopv1.GuestCustomizationCondition,
+			vmopv1.GuestCustomizationPendingReason,
+			"powering off the VM to re-customize the guest at generation %d",
+			generation)
+
+		if err := res.NewVMFromObject(vcVM).SetPowerState(
+			logr.NewContext(vmCtx, vmCtx.Logger),
+			vmopv1.VirtualMachinePowerStateOn,
+			vmopv1.VirtualMachinePowerStateOff,
+			vmCtx.VM.Spec.PowerOffMode); err != nil {
+
+			return fmt.Errorf("failed to power off vm to re-customize: %w", err)
+		}
+
+		return ErrRecustomizePowerOff
+
+	case vimtypes.VirtualMachinePowerStatePoweredOff:
+		logger.Info("Re-customizing guest")
+		pkgcnd.MarkFalse(
+			vmCtx.VM,
+			vmopv1.GuestCustomizationCondition,
+			vmopv1.GuestCustomizationPendingReason,
+			"re-customizing the guest at generation %d",
+			generation)
+
+	default:
+		logger.Info("Skipping re-customize as VM is suspended")
+	}
+
+	return nil
+}
+
 func (vs *vSphereVMProvider) reconcilePowerState(
 	vmCtx pkgctx.VirtualMachineContext,
 	vcVM *object.VirtualMachine) error {
@@ -1404,6 +1585,24 @@ func (vs *vSphereVMProvider) vmCreateGetSourceFilePaths(
 		itemVersion  = createArgs.ImageStatus.ProviderContentVersion
 	)
 
+	// If the image's files are not cached at the datastore selected for the
+	// VM, but the files of a duplicate image from another library are, then
+	// use the duplicate image's cached files.
+	if dupItemID, dupItemVersion, ok := GetCachedDuplicateImage(
+		vmCtx,
+		vs.k8sClient,
+		createArgs.ImageStatus,
+		datacenterID,
+		datastoreID); ok {
+
+		vmCtx.Logger.Info("Using cached files from duplicate image",
+			"itemID", itemID,
+			"duplicateItemID", dupItemID,
+			"datastoreID", datastoreID)
+
+		itemID, itemVersion = dupItemID, dupItemVersion
+	}
+
 	// Create/patch/get the VirtualMachineImageCache resource.
 	obj := vmopv1.VirtualMachineImageCache{
 		ObjectMeta: metav1.ObjectMeta{
@@ -1445,6 +1644,10 @@ func (vs *vSphereVMProvider) vmCreateGetSourceFilePaths(
 						// The location has the cached files.
 						vmCtx.Logger.Info("got source files", "files", l.Files)
 
+						// Record the files were used so they are not evicted
+						// from the cache.
+						vs.vmCreateGetSourceFilePathsMarkUsed(vmCtx, obj, i)
+
 						// Update the createArgs.DiskPaths with the paths from
 						// the cached files slice.
 						for i := range l.Files {
@@ -1477,6 +1680,42 @@ func (vs *vSphereVMProvider) vmCreateGetSourceFilePaths(
 	}
 }
 
+// vmiCacheLastUsedTimeResolution is how stale the last used time of a cached
+// location may be before it is updated. This prevents patching the image cache
+// resource every time a VM is deployed from it.
+const vmiCacheLastUsedTimeResolution = 10 * time.Minute
+
+// vmCreateGetSourceFilePathsMarkUsed updates the time the files at the
+// specified location were last used. This is best-effort since the VM may
+// still be created from the files, and a failure is only logged.
+func (vs *vSphereVMProvider) vmCreateGetSourceFilePathsMarkUsed(
+	vmCtx pkgctx.VirtualMachineContext,
+	obj vmopv1.VirtualMachineImageCache,
+	locationIndex int) {
+
+	l := &obj.Status.Locations[locationIndex]
+	if t := l.LastUsedTime; t != nil &&
+		time.Since(t.Time) < vmiCacheLastUsedTimeResolution {
+
+		return
+	}
+
+	objPatch := ctrlclient.MergeFromWithOptions(
+		obj.DeepCopy(),
+		ctrlclient.MergeFromWithOptimisticLock{})
+
+	now := metav1.Now()
+	l.LastUsedTime = &now
+
+	if err := vs.k8sClient.Status().Patch(vmCtx, &obj, objPatch); err != nil {
+		vmCtx.Logger.Error(err,
+			"Failed to patch image cache resource last used time",
+			"name", obj.Name,
+			"datacenterID", l.DatacenterID,
+			"datastoreID", l.DatastoreID)
+	}
+}
+
 // vmCreateGetSourceFilePathsVerify verifies the provided file(s) are still
 // available. If not, a reconcile request is enqueued for the VMI cache object.
 func (vs *vSphereVMProvider) vmCreateGetSourceFilePathsVerify(
@@ -1535,6 +1774,74 @@ func (vs *vSphereVMProvider) vmCreateIsReady(
 		}
 	}
 
+	return vs.vmCreateCheckImageCompatibility(vmCtx, vcClient, createArgs)
+}
+
+// vmCreateCheckImageCompatibility checks that the VM's image is compatible
+// with the VM's class, boot options, and the hosts in the cluster in which
+// the VM is placed so incompatibilities are reported before they surface as
+// faults from vCenter.
+func (vs *vSphereVMProvider) vmCreateCheckImageCompatibility(
+	vmCtx pkgctx.VirtualMachineContext,
+	vcClient *vcclient.Client,
+	createArgs *VMCreateArgs) error {
+
+	if vmCtx.VM.Spec.Image == nil {
+		return nil
+	}
+
+	var (
+		maxHardwareVersion vimtypes.HardwareVersion
+		getGuestOS         vmopv1util.GuestOSDescriptorFn
+	)
+	if createArgs.ClusterMoRef.Value != "" {
+		cluster := object.NewClusterComputeResource(vcClient.VimClient(), createArgs.ClusterMoRef)
+		hv, err := vcenter.ClusterMaxHardwareVersion(vmCtx, cluster)
+		if err != nil {
+			// The hardware version is still checked against the class.
+			vmCtx.Logger.Error(err, "Failed to get max hardware version of cluster",
+				"cluster", createArgs.ClusterMoRef.Value)
+		}
+		maxHardwareVersion = hv
+
+		getGuestOS = func(guestID string) *vimtypes.GuestOsDescriptor {
+			guestOS, err := vcenter.ClusterGuestOSDescriptor(vmCtx, cluster, guestID)
+			if err != nil {
+				// The guest OS is still checked using its ID.
+				vmCtx.Logger.Error(err, "Failed to get guest OS descriptor",
+					"cluster", createArgs.ClusterMoRef.Value, "guestID", guestID)
+			}
+			return guestOS
+		}
+	}
+
+	issues := vmopv1util.CheckImageCompatibility(
+		*vmCtx.VM,
+		createArgs.VMClass.Spec,
+		createArgs.ImageStatus,
+		maxHardwareVersion,
+		getGuestOS)
+
+	for _, w := range issues.Warnings() {
+		vmCtx.Logger.Info("Image may not be compatible with VM", "reason", w.Reason, "message", w.Message)
+	}
+
+	if fatal := issues.Fatal(); len(fatal) > 0 {
+		msgs := make([]string, len(fatal))
+		for i := range fatal {
+			msgs[i] = fatal[i].Message
+		}
+		msg := strings.Join(msgs, "; ")
+		pkgcnd.MarkFalse(
+			vmCtx.VM,
+			vmopv1.VirtualMachineConditionImageCompatible,
+			fatal[0].Reason,
+			"%s", msg)
+		return fmt.Errorf("image is not compatible with VM: %s", msg)
+	}
+
+	pkgcnd.MarkTrue(vmCtx.VM, vmopv1.VirtualMachineConditionImageCompatible)
+
 	return nil
 }
 
@@ -1635,6 +1942,16 @@ func (vs *vSphereVMProvider) vmCreateGetPrereqs(
 		}
 	}
 
+	if img := vmCtx.VM.Spec.Image; img != nil {
+		// Record the image, and the channel it came from, that the VM is
+		// being deployed from.
+		vmCtx.VM.Status.Image = &vmopv1.VirtualMachineImageRefStatus{
+			VirtualMachineImageRef: *img,
+			Version:                createArgs.ImageStatus.ProductInfo.Version,
+			Channel:                vmCtx.VM.Spec.ImageChannel,
+		}
+	}
+
 	return createArgs, nil
 }
 
@@ -1661,6 +1978,16 @@ func (vs *vSphereVMProvider) vmCreateGetVirtualMachineImage(
 		return err
 	}
 
+	if imageSpec.OCI != nil {
+		err := fmt.Errorf("deploying a VM from an image backed by an OCI artifact is not supported")
+		pkgcnd.MarkError(vmCtx.VM, vmopv1.VirtualMachineConditionImageReady, "NotSupported", err)
+		return err
+	}
+
+	if err := VerifyVirtualMachineImageProvenance(vmCtx, vs.k8sClient, imageObj, imageStatus); err != nil {
+		return err
+	}
+
 	createArgs.ImageObj = imageObj
 	createArgs.ImageSpec = imageSpec
 	createArgs.ImageStatus = imageStatus
@@ -1898,6 +2225,10 @@ func (vs *vSphereVMProvider) vmCreateGenConfigSpec(
 		return err
 	}
 
+	if err := vs.vmCreateGenConfigSpecISO(vmCtx, createArgs); err != nil {
+		return err
+	}
+
 	if err := vs.vmCreateGenConfigSpecZipNetworkInterfaces(vmCtx, createArgs); err != nil {
 		return err
 	}
@@ -2088,6 +2419,28 @@ func (vs *vSphereVMProvider) vmCreateGenConfigSpecChangeBootDiskSize(
 	return nil
 }
 
+// vmCreateGenConfigSpecISO adds a blank boot disk, sized by the VM's boot disk
+// capacity, to VMs deployed from an ISO image and sets them to boot from the
+// CD-ROM first so the guest OS may be installed from the image.
+func (vs *vSphereVMProvider) vmCreateGenConfigSpecISO(
+	vmCtx pkgctx.VirtualMachineContext,
+	createArgs *VMCreateArgs) error {
+
+	if createArgs.ImageStatus.Type != string(imgregv1a1.ContentLibraryItemTypeIso) {
+		return nil
+	}
+
+	var capacity int64
+	if advanced := vmCtx.VM.Spec.Advanced; advanced != nil && advanced.BootDiskCapacity != nil {
+		capacity = advanced.BootDiskCapacity.Value()
+	}
+
+	return virtualmachine.ConfigureConfigSpecForISO(
+		&createArgs.ConfigSpec,
+		capacity,
+		createArgs.StorageProfileID)
+}
+
 func (vs *vSphereVMProvider) vmCreateGenConfigSpecZipNetworkInterfaces(
 	vmCtx pkgctx.VirtualMachineContext,
 	createArgs *VMCreateArgs) error {
@@ -2107,12 +2460,24 @@ func (vs *vSphereVMProvider) vmCreateGenConfigSpecZipNetworkInterfaces(
 		}
 
 		device := spec.Device
-		ethCard := device.(vimtypes.BaseVirtualEthernetCard).GetVirtualEthernetCard()
 
 		if resultsIdx < len(createArgs.NetworkResults.Results) {
-			err := network.ApplyInterfaceResultToVirtualEthCard(vmCtx, ethCard, &createArgs.NetworkResults.Results[resultsIdx])
-			if err != nil {
-				return err
+			result := &createArgs.NetworkResults.Results[resultsIdx]
+
+			if network.EthCardMatchesInterfaceType(device, result.Type) {
+				err := network.ApplyInterfaceResultToVirtualEthCard(vmCtx, device.(vimtypes.BaseVirtualEthernetCard), result)
+				if err != nil {
+					return err
+				}
+			} else {
+				// The interface requires a different type of device than the one in
+				// the ConfigSpec so replace it, keeping the device key.
+				ethCardDev, err := network.CreateDefaultEthCard(vmCtx, result)
+				if err != nil {
+					return err
+				}
+				ethCardDev.GetVirtualDevice().Key = device.GetVirtualDevice().Key
+				spec.Device = ethCardDev
 			}
 			resultsIdx++
 
@@ -2148,6 +2513,8 @@ func (vs *vSphereVMProvider) vmCreateGenConfigSpecZipNetworkInterfaces(
 		})
 	}
 
+	network.LockMemoryReservationForSRIOV(nil, &createArgs.ConfigSpec)
+
 	return nil
 }
 
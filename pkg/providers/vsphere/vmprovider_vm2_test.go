@@ -12,6 +12,8 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/mo"
 	vimtypes "github.com/vmware/govmomi/vim25/types"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -28,6 +30,7 @@ import (
 	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
 	"github.com/vmware-tanzu/vm-operator/pkg/providers"
 	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/constants"
 	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/network"
 	"github.com/vmware-tanzu/vm-operator/pkg/util"
 	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
@@ -253,9 +256,10 @@ func vmE2ETests() {
 		interfaceName1 = "eth1"
 		networkName2   = "my-network-2"
 
-		bsCloudInit = "cloudInit"
-		bsSysprep   = "sysPrep"
-		bsLinuxPrep = "linuxPrep"
+		bsCloudInit        = "cloudInit"
+		bsCloudInitHotplug = "cloudInitHotplug"
+		bsSysprep          = "sysPrep"
+		bsLinuxPrep        = "linuxPrep"
 	)
 
 	var (
@@ -539,6 +543,191 @@ func vmE2ETests() {
 			Entry("VPC with Sysprep", builder.NetworkEnvVPC, bsSysprep),
 		)
 
+		DescribeTableSubtree("Simulate hot add/remove of network interfaces on powered on VM",
+			func(networkEnv builder.NetworkEnv, bootstrap string) {
+				var np fakeNetworkProvider
+
+				BeforeEach(func() {
+					testConfig.WithNetworkEnv = networkEnv
+
+					switch networkEnv {
+					case builder.NetworkEnvVDS:
+						np = vdsNetworkProvider{}
+					case builder.NetworkEnvNSXT:
+						np = nsxtNetworkProvider{}
+					case builder.NetworkEnvVPC:
+						np = vpcNetworkProvider{}
+					}
+				})
+
+				JustBeforeEach(func() {
+					switch bootstrap {
+					case bsCloudInit, bsCloudInitHotplug:
+						if bootstrap == bsCloudInitHotplug {
+							cloudInitSecret.Data["user-data"] = []byte(
+								"#cloud-config\nupdates:\n  network:\n    when: [boot, hotplug]\n")
+						}
+						Expect(ctx.Client.Create(ctx, cloudInitSecret)).To(Succeed())
+						vm.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{
+							CloudInit: &vmopv1.VirtualMachineBootstrapCloudInitSpec{
+								RawCloudConfig: &common.SecretKeySelector{
+									Name: cloudInitSecret.Name,
+								},
+							},
+						}
+					case bsLinuxPrep:
+						vm.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{
+							LinuxPrep: &vmopv1.VirtualMachineBootstrapLinuxPrepSpec{},
+						}
+					}
+
+					vm.Spec.Network = &vmopv1.VirtualMachineNetworkSpec{
+						Interfaces: []vmopv1.VirtualMachineNetworkInterfaceSpec{
+							{
+								Name: interfaceName0,
+								Network: &common.PartialObjectRef{
+									Name: networkName0,
+								},
+							},
+						},
+					}
+
+					if networkEnv == builder.NetworkEnvVPC {
+						vm.Spec.Network.Interfaces[0].Network.Kind = "Subnet"
+						vm.Spec.Network.Interfaces[0].Network.APIVersion = "crd.nsx.vmware.com/v1alpha1"
+					}
+				})
+
+				It("DoIt", func() {
+					err := createOrUpdateVM(ctx, vmProvider, vm)
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("network interface is not ready yet"))
+
+					By("simulate successful network provider reconcile", func() {
+						np.simulateInterfaceReconcile(ctx, vm, networkName0, interfaceName0, 0)
+					})
+
+					Expect(createOrUpdateVM(ctx, vmProvider, vm)).To(Succeed())
+
+					Expect(vm.Status.UniqueID).ToNot(BeEmpty())
+					vcVM := ctx.GetVMFromMoID(vm.Status.UniqueID)
+					Expect(vcVM.PowerState(ctx)).To(Equal(vimtypes.VirtualMachinePowerStatePoweredOn))
+
+					getGuestInfoMetadata := func() string {
+						var o mo.VirtualMachine
+						Expect(vcVM.Properties(ctx, vcVM.Reference(), []string{"config.extraConfig"}, &o)).To(Succeed())
+						val, _ := object.OptionValueList(o.Config.ExtraConfig).GetString(constants.CloudInitGuestInfoMetadata)
+						return val
+					}
+					origMetadata := getGuestInfoMetadata()
+					if bootstrap != bsLinuxPrep {
+						Expect(origMetadata).ToNot(BeEmpty())
+					}
+					Expect(conditions.Get(vm, vmopv1.GuestBootstrapSyncedCondition)).To(BeNil())
+
+					By("add network interface", func() {
+						vm.Spec.Network.Interfaces = append(vm.Spec.Network.Interfaces, vm.Spec.Network.Interfaces[0])
+						vm.Spec.Network.Interfaces[1].Name = interfaceName1
+						vm.Spec.Network.Interfaces[1].Network = ptr.To(*vm.Spec.Network.Interfaces[1].Network)
+						vm.Spec.Network.Interfaces[1].Network.Name = networkName1
+
+						err = createOrUpdateVM(ctx, vmProvider, vm)
+						Expect(err).To(HaveOccurred())
+						Expect(err.Error()).To(ContainSubstring("network interface is not ready yet"))
+					})
+
+					By("simulate successful network provider reconcile on added interface", func() {
+						np.simulateInterfaceReconcile(ctx, vm, networkName1, interfaceName1, 1)
+						Expect(createOrUpdateVM(ctx, vmProvider, vm)).To(Succeed())
+					})
+
+					By("added interface is hot added", func() {
+						Expect(vcVM.PowerState(ctx)).To(Equal(vimtypes.VirtualMachinePowerStatePoweredOn))
+
+						devList, err := vcVM.Device(ctx)
+						Expect(err).ToNot(HaveOccurred())
+						l := devList.SelectByType(&vimtypes.VirtualEthernetCard{})
+						Expect(l).To(HaveLen(2))
+						np.assertEthernetCard(ctx, l[1], 1)
+					})
+
+					By("guest network config is refreshed", func() {
+						Expect(vm.Status.Network).ToNot(BeNil())
+						Expect(vm.Status.Network.Config).ToNot(BeNil())
+						Expect(vm.Status.Network.Config.Interfaces).To(HaveLen(2))
+
+						switch bootstrap {
+						case bsCloudInit:
+							Expect(getGuestInfoMetadata()).ToNot(Equal(origMetadata))
+							c := conditions.Get(vm, vmopv1.GuestBootstrapSyncedCondition)
+							Expect(c).ToNot(BeNil())
+							Expect(c.Status).To(Equal(metav1.ConditionFalse))
+							Expect(c.Reason).To(Equal(vmopv1.GuestBootstrapPendingRecustomizeReason))
+						case bsCloudInitHotplug:
+							Expect(getGuestInfoMetadata()).ToNot(Equal(origMetadata))
+							Expect(conditions.IsTrue(vm, vmopv1.GuestBootstrapSyncedCondition)).To(BeTrue())
+						case bsLinuxPrep:
+							c := conditions.Get(vm, vmopv1.GuestBootstrapSyncedCondition)
+							Expect(c).ToNot(BeNil())
+							Expect(c.Status).To(Equal(metav1.ConditionFalse))
+							Expect(c.Reason).To(Equal(vmopv1.GuestBootstrapPendingPowerCycleReason))
+						}
+					})
+
+					if bootstrap == bsLinuxPrep {
+						By("guest is customized when the VM is power cycled", func() {
+							vm.Spec.PowerState = vmopv1.VirtualMachinePowerStateOff
+							Expect(createOrUpdateVM(ctx, vmProvider, vm)).To(Succeed())
+							Expect(vcVM.PowerState(ctx)).To(Equal(vimtypes.VirtualMachinePowerStatePoweredOff))
+							Expect(conditions.IsFalse(vm, vmopv1.GuestBootstrapSyncedCondition)).To(BeTrue())
+
+							vm.Spec.PowerState = vmopv1.VirtualMachinePowerStateOn
+							Expect(createOrUpdateVM(ctx, vmProvider, vm)).To(Succeed())
+							Expect(vcVM.PowerState(ctx)).To(Equal(vimtypes.VirtualMachinePowerStatePoweredOn))
+							Expect(conditions.Get(vm, vmopv1.GuestBootstrapSyncedCondition)).To(BeNil())
+						})
+					}
+
+					By("remove added network interface", func() {
+						vm.Spec.Network.Interfaces = vm.Spec.Network.Interfaces[:1]
+						Expect(createOrUpdateVM(ctx, vmProvider, vm)).To(Succeed())
+					})
+
+					By("interface is hot removed", func() {
+						Expect(vcVM.PowerState(ctx)).To(Equal(vimtypes.VirtualMachinePowerStatePoweredOn))
+
+						devList, err := vcVM.Device(ctx)
+						Expect(err).ToNot(HaveOccurred())
+						l := devList.SelectByType(&vimtypes.VirtualEthernetCard{})
+						Expect(l).To(HaveLen(1))
+						np.assertEthernetCard(ctx, l[0], 0)
+
+						np.assertNetworkInterfacesDNE(ctx, vm, networkName1, interfaceName1)
+
+						Expect(vm.Status.Network.Config.Interfaces).To(HaveLen(1))
+					})
+
+					By("removed interface is reported as pending in the guest", func() {
+						switch bootstrap {
+						case bsCloudInit:
+							Expect(conditions.GetReason(vm, vmopv1.GuestBootstrapSyncedCondition)).To(
+								Equal(vmopv1.GuestBootstrapPendingRecustomizeReason))
+						case bsCloudInitHotplug:
+							Expect(conditions.IsTrue(vm, vmopv1.GuestBootstrapSyncedCondition)).To(BeTrue())
+						case bsLinuxPrep:
+							Expect(conditions.GetReason(vm, vmopv1.GuestBootstrapSyncedCondition)).To(
+								Equal(vmopv1.GuestBootstrapPendingPowerCycleReason))
+						}
+					})
+				})
+			},
+			Entry("VDS with CloudInit", builder.NetworkEnvVDS, bsCloudInit),
+			Entry("NSX-T with CloudInit", builder.NetworkEnvNSXT, bsCloudInit),
+			Entry("VPC with CloudInit", builder.NetworkEnvVPC, bsCloudInit),
+			Entry("VDS with CloudInit hotplug", builder.NetworkEnvVDS, bsCloudInitHotplug),
+			Entry("VDS with LinuxPrep", builder.NetworkEnvVDS, bsLinuxPrep),
+		)
+
 		DescribeTableSubtree("Simulate VM power off/on with network interface edits",
 			func(networkEnv builder.NetworkEnv, bootstrap string) {
 				var np fakeNetworkProvider
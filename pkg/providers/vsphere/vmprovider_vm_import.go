@@ -0,0 +1,160 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package vsphere
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	"github.com/vmware/govmomi/fault"
+	"github.com/vmware/govmomi/find"
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/mo"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers"
+	vcclient "github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/client"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/vcenter"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/virtualmachine"
+	"github.com/vmware-tanzu/vm-operator/pkg/topology"
+)
+
+func (vs *vSphereVMProvider) GetVirtualMachineImportSource(
+	ctx context.Context,
+	vmImport *vmopv1.VirtualMachineImportRequest) (*vmopv1.VirtualMachineImportRequestSourceStatus, error) {
+
+	ctx = logr.NewContext(ctx, log.WithValues(
+		"vmImportName", fmt.Sprintf("%s/%s", vmImport.Namespace, vmImport.Name)))
+
+	client, err := vs.getVcClient(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get vCenter client: %w", err)
+	}
+
+	vcVM, moVM, err := getImportSourceVM(ctx, client, vmImport.Spec.Source)
+	if err != nil {
+		return nil, err
+	}
+
+	if moVM.Config == nil || moVM.Config.Template || virtualmachine.IsManagedByVMOperator(moVM) {
+		return nil, providers.ErrVMImportSourceManaged
+	}
+
+	if moVM.Runtime.PowerState != vimtypes.VirtualMachinePowerStatePoweredOff {
+		return nil, providers.ErrVMImportSourceNotPoweredOff
+	}
+
+	targetName := vmImport.Spec.TargetName
+	if targetName == "" {
+		targetName = vmImport.Name
+	}
+
+	status, err := virtualmachine.GetImportSourceStatus(ctx, client.Finder(), vcVM, moVM, targetName)
+	if err != nil {
+		return nil, err
+	}
+
+	if moVM.ResourcePool == nil {
+		return nil, fmt.Errorf("%w: vm has no resource pool", providers.ErrVMImportSourceNoZone)
+	}
+
+	ccrRef, err := vcenter.GetResourcePoolOwnerMoRef(ctx, client.VimClient(), moVM.ResourcePool.Value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cluster of vm: %w", err)
+	}
+
+	zoneName, err := topology.LookupZoneForClusterMoID(ctx, vs.k8sClient, ccrRef.Value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", providers.ErrVMImportSourceNoZone, err)
+	}
+	status.Zone = zoneName
+
+	return status, nil
+}
+
+func (vs *vSphereVMProvider) ImportVirtualMachine(
+	ctx context.Context,
+	vmImport *vmopv1.VirtualMachineImportRequest) error {
+
+	ctx = logr.NewContext(ctx, log.WithValues(
+		"vmImportName", fmt.Sprintf("%s/%s", vmImport.Namespace, vmImport.Name)))
+
+	src := vmImport.Status.Source
+	if src == nil || src.ID == "" || src.Zone == "" {
+		return errors.New("import request status does not describe the vm to import")
+	}
+
+	client, err := vs.getVcClient(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get vCenter client: %w", err)
+	}
+
+	vcVM, moVM, err := getImportSourceVM(ctx, client, vmopv1.VirtualMachineImportRequestSource{ID: src.ID})
+	if err != nil {
+		return err
+	}
+
+	folderMoID, rpMoID, err := topology.GetNamespaceFolderAndRPMoID(ctx, vs.k8sClient, src.Zone, vmImport.Namespace)
+	if err != nil {
+		return fmt.Errorf("failed to get namespace folder and resource pool: %w", err)
+	}
+
+	folder, err := vcenter.GetFolderByMoID(ctx, client.Finder(), folderMoID)
+	if err != nil {
+		return fmt.Errorf("failed to get namespace folder: %w", err)
+	}
+
+	pool, err := vcenter.GetResourcePoolByMoID(ctx, client.Finder(), rpMoID)
+	if err != nil {
+		return fmt.Errorf("failed to get namespace resource pool: %w", err)
+	}
+
+	return virtualmachine.ImportVirtualMachine(ctx, vcVM, moVM, folder, pool)
+}
+
+// getImportSourceVM returns the vSphere VM described by the import request's
+// source along with the properties needed to import it.
+func getImportSourceVM(
+	ctx context.Context,
+	client *vcclient.Client,
+	source vmopv1.VirtualMachineImportRequestSource) (*object.VirtualMachine, mo.VirtualMachine, error) {
+
+	var (
+		vcVM *object.VirtualMachine
+		moVM mo.VirtualMachine
+	)
+
+	switch {
+	case source.ID != "":
+		vcVM = object.NewVirtualMachine(client.VimClient(), vimtypes.ManagedObjectReference{
+			Type:  "VirtualMachine",
+			Value: source.ID,
+		})
+	case source.InventoryPath != "":
+		var err error
+		if vcVM, err = client.Finder().VirtualMachine(ctx, source.InventoryPath); err != nil {
+			var notFoundErr *find.NotFoundError
+			if errors.As(err, &notFoundErr) {
+				return nil, moVM, providers.ErrVMImportSourceNotFound
+			}
+			return nil, moVM, fmt.Errorf("failed to find vm %q: %w", source.InventoryPath, err)
+		}
+	default:
+		return nil, moVM, errors.New("import request source does not specify a vm")
+	}
+
+	if err := vcVM.Properties(ctx, vcVM.Reference(), virtualmachine.ImportSourceProperties, &moVM); err != nil {
+		var f *vimtypes.ManagedObjectNotFound
+		if _, ok := fault.As(err, &f); ok {
+			return nil, moVM, providers.ErrVMImportSourceNotFound
+		}
+		return nil, moVM, fmt.Errorf("failed to get vm properties: %w", err)
+	}
+
+	return vcVM, moVM, nil
+}
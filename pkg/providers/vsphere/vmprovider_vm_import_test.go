@@ -0,0 +1,126 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package vsphere_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware/govmomi/object"
+	"github.com/vmware/govmomi/vim25/mo"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func vmImportTests() {
+
+	var (
+		ctx        *builder.TestContextForVCSim
+		nsInfo     builder.WorkloadNamespaceInfo
+		vmProvider providers.VirtualMachineProviderInterface
+		vcVM       *object.VirtualMachine
+		vmImport   *vmopv1.VirtualMachineImportRequest
+	)
+
+	BeforeEach(func() {
+		ctx = suite.NewTestContextForVCSim(builder.VCSimTestConfig{})
+		vmProvider = vsphere.NewVSphereVMProviderFromClient(ctx, ctx.Client, ctx.Recorder)
+		nsInfo = ctx.CreateWorkloadNamespace()
+
+		var err error
+		vcVM, err = ctx.Finder.VirtualMachine(ctx, "DC0_C0_RP0_VM0")
+		Expect(err).ToNot(HaveOccurred())
+		t, err := vcVM.PowerOff(ctx)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(t.Wait(ctx)).To(Succeed())
+
+		vmImport = &vmopv1.VirtualMachineImportRequest{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "import-me",
+				Namespace: nsInfo.Namespace,
+			},
+			Spec: vmopv1.VirtualMachineImportRequestSpec{
+				Source: vmopv1.VirtualMachineImportRequestSource{
+					ID: vcVM.Reference().Value,
+				},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+	})
+
+	Context("GetVirtualMachineImportSource", func() {
+
+		It("Returns the VM by its ID", func() {
+			status, err := vmProvider.GetVirtualMachineImportSource(ctx, vmImport)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(status.ID).To(Equal(vcVM.Reference().Value))
+			Expect(status.Name).To(Equal("DC0_C0_RP0_VM0"))
+			Expect(status.Zone).To(BeElementOf(ctx.ZoneNames))
+			Expect(status.PowerState).To(Equal(vmopv1.VirtualMachinePowerStateOff))
+		})
+
+		It("Returns an error when the VM is not powered off", func() {
+			t, err := vcVM.PowerOn(ctx)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(t.Wait(ctx)).To(Succeed())
+
+			_, err = vmProvider.GetVirtualMachineImportSource(ctx, vmImport)
+			Expect(err).To(MatchError(providers.ErrVMImportSourceNotPoweredOff))
+		})
+
+		It("Returns the VM by its inventory path", func() {
+			vmImport.Spec.Source = vmopv1.VirtualMachineImportRequestSource{
+				InventoryPath: vcVM.InventoryPath,
+			}
+			status, err := vmProvider.GetVirtualMachineImportSource(ctx, vmImport)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(status.ID).To(Equal(vcVM.Reference().Value))
+		})
+
+		It("Returns an error when the VM ID does not exist", func() {
+			vmImport.Spec.Source.ID = "vm-does-not-exist"
+			_, err := vmProvider.GetVirtualMachineImportSource(ctx, vmImport)
+			Expect(err).To(MatchError(providers.ErrVMImportSourceNotFound))
+		})
+
+		It("Returns an error when the VM inventory path does not exist", func() {
+			vmImport.Spec.Source = vmopv1.VirtualMachineImportRequestSource{
+				InventoryPath: "/DC0/vm/does-not-exist",
+			}
+			_, err := vmProvider.GetVirtualMachineImportSource(ctx, vmImport)
+			Expect(err).To(MatchError(providers.ErrVMImportSourceNotFound))
+		})
+	})
+
+	Context("ImportVirtualMachine", func() {
+
+		It("Imports the VM into the namespace", func() {
+			status, err := vmProvider.GetVirtualMachineImportSource(ctx, vmImport)
+			Expect(err).ToNot(HaveOccurred())
+			vmImport.Status.Source = status
+
+			Expect(vmProvider.ImportVirtualMachine(ctx, vmImport)).To(Succeed())
+
+			var moVM mo.VirtualMachine
+			Expect(vcVM.Properties(ctx, vcVM.Reference(), []string{"parent", "resourcePool"}, &moVM)).To(Succeed())
+			Expect(moVM.Parent.Value).To(Equal(nsInfo.Folder.Reference().Value))
+			nsRP := ctx.GetResourcePoolForNamespace(nsInfo.Namespace, status.Zone, "")
+			Expect(moVM.ResourcePool.Value).To(Equal(nsRP.Reference().Value))
+
+			By("The VM may no longer be imported", func() {
+				_, err := vmProvider.GetVirtualMachineImportSource(ctx, vmImport)
+				Expect(err).To(MatchError(providers.ErrVMImportSourceManaged))
+			})
+		})
+	})
+}
@@ -674,6 +674,24 @@ func vmTests() {
 					Expect(ethDevice.ResourceAllocation.Reservation).ToNot(BeNil())
 					Expect(*ethDevice.ResourceAllocation.Reservation).To(Equal(*ethCard.ResourceAllocation.Reservation))
 				})
+
+				It("Keeps the class NIC allocation when the interface has no QoS", func() {
+					Expect(vm.Spec.Network.Interfaces[0].QoS).To(BeNil())
+
+					// Reconcile the VM again now that it exists.
+					Expect(createOrUpdateVM(ctx, vmProvider, vm)).To(Succeed())
+
+					var o mo.VirtualMachine
+					Expect(vcVM.Properties(ctx, vcVM.Reference(), nil, &o)).To(Succeed())
+
+					l := object.VirtualDeviceList(o.Config.Hardware.Device).SelectByType(&vimtypes.VirtualEthernetCard{})
+					Expect(l).To(HaveLen(1))
+
+					ethDevice := l[0].(vimtypes.BaseVirtualEthernetCard).GetVirtualEthernetCard()
+					Expect(ethDevice.ResourceAllocation).ToNot(BeNil())
+					Expect(ethDevice.ResourceAllocation.Reservation).To(HaveValue(Equal(*ethCard.ResourceAllocation.Reservation)))
+					Expect(ethDevice.DeviceInfo).To(Equal(ethCard.VirtualDevice.DeviceInfo))
+				})
 			})
 
 			Context("ConfigSpec does not specify any network interfaces", func() {
@@ -1350,6 +1368,26 @@ func vmTests() {
 				})
 			})
 
+			When("the image is backed by an OCI artifact", func() {
+				JustBeforeEach(func() {
+					var img vmopv1.ClusterVirtualMachineImage
+					Expect(ctx.Client.Get(ctx, client.ObjectKey{Name: vm.Spec.Image.Name}, &img)).To(Succeed())
+					img.Spec.OCI = &vmopv1.VirtualMachineImageOCISource{
+						Reference: "registry.example.com/my-project/my-image:v1",
+					}
+					Expect(ctx.Client.Update(ctx, &img)).To(Succeed())
+				})
+				It("should fail", func() {
+					err := createOrUpdateVM(ctx, vmProvider, vm)
+					Expect(err).To(HaveOccurred())
+					Expect(err.Error()).To(ContainSubstring("deploying a VM from an image backed by an OCI artifact is not supported"))
+					c := conditions.Get(vm, vmopv1.VirtualMachineConditionImageReady)
+					Expect(c).ToNot(BeNil())
+					Expect(c.Status).To(Equal(metav1.ConditionFalse))
+					Expect(c.Reason).To(Equal("NotSupported"))
+				})
+			})
+
 			It("Basic VM", func() {
 				vcVM, err := createOrUpdateAndGetVcVM(ctx, vmProvider, vm)
 				Expect(err).ToNot(HaveOccurred())
@@ -1371,6 +1409,10 @@ func vmTests() {
 					Expect(vm.Status.Class.Name).To(Equal(vm.Spec.ClassName))
 					Expect(vm.Status.Class.APIVersion).To(Equal(vmopv1.GroupVersion.String()))
 
+					Expect(vm.Status.Image).ToNot(BeNil())
+					Expect(vm.Status.Image.VirtualMachineImageRef).To(Equal(*vm.Spec.Image))
+					Expect(vm.Status.Image.Channel).To(BeEmpty())
+
 					Expect(conditions.IsTrue(vm, vmopv1.VirtualMachineConditionClassReady)).To(BeTrue())
 					Expect(conditions.IsTrue(vm, vmopv1.VirtualMachineConditionImageReady)).To(BeTrue())
 					Expect(conditions.IsTrue(vm, vmopv1.VirtualMachineConditionStorageReady)).To(BeTrue())
@@ -3179,6 +3221,30 @@ func vmTests() {
 					Expect(path.Datastore).NotTo(BeEmpty())
 				})
 			})
+
+			Context("boot disk capacity is specified", func() {
+				BeforeEach(func() {
+					vm.Spec.Advanced = &vmopv1.VirtualMachineAdvancedSpec{
+						BootDiskCapacity: ptr.To(resource.MustParse("10Gi")),
+					}
+				})
+
+				It("creates a blank boot disk and boots from the CD-ROM first", func() {
+					vcVM, err := createOrUpdateAndGetVcVM(ctx, vmProvider, vm)
+					Expect(err).ToNot(HaveOccurred())
+
+					var o mo.VirtualMachine
+					Expect(vcVM.Properties(ctx, vcVM.Reference(), []string{"config"}, &o)).To(Succeed())
+
+					disks := object.VirtualDeviceList(o.Config.Hardware.Device).SelectByType(&vimtypes.VirtualDisk{})
+					Expect(disks).To(HaveLen(1))
+					Expect(disks[0].(*vimtypes.VirtualDisk).CapacityInBytes).To(BeEquivalentTo(10 * 1024 * 1024 * 1024))
+
+					Expect(o.Config.BootOptions).ToNot(BeNil())
+					Expect(o.Config.BootOptions.BootOrder).ToNot(BeEmpty())
+					Expect(o.Config.BootOptions.BootOrder[0]).To(BeAssignableToTypeOf(&vimtypes.VirtualMachineBootOptionsBootableCdromDevice{}))
+				})
+			})
 		})
 
 		Context("Power states", func() {
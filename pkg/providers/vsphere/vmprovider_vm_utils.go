@@ -26,6 +26,7 @@ import (
 	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/vmlifecycle"
 	"github.com/vmware-tanzu/vm-operator/pkg/util"
 	"github.com/vmware-tanzu/vm-operator/pkg/util/cloudinit"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/image/provenance"
 	"github.com/vmware-tanzu/vm-operator/pkg/util/kube"
 	"github.com/vmware-tanzu/vm-operator/pkg/util/paused"
 	vmopv1util "github.com/vmware-tanzu/vm-operator/pkg/util/vmopv1"
@@ -264,6 +265,168 @@ func GetVirtualMachineImageSpecAndStatus(
 	return obj, spec, status, nil
 }
 
+// VerifyVirtualMachineImageProvenance returns an error if the image does not
+// have a provenance record signed by one of the trusted public keys, or the
+// record does not describe the image's current content. This is a no-op
+// unless verified images are required.
+func VerifyVirtualMachineImageProvenance(
+	vmCtx pkgctx.VirtualMachineContext,
+	k8sClient ctrlclient.Client,
+	obj ctrlclient.Object,
+	status vmopv1.VirtualMachineImageStatus) error {
+
+	cfg := pkgcfg.FromContext(vmCtx)
+	if !cfg.ImageProvenance.RequireVerifiedImages {
+		return nil
+	}
+
+	keys, err := provenance.GetPublicKeys(
+		vmCtx,
+		k8sClient,
+		cfg.PodNamespace,
+		cfg.ImageProvenance.PublicKeysSecretName)
+	if err != nil {
+		return err
+	}
+
+	record, err := provenance.VerifyImage(obj, status, keys)
+	if err != nil {
+		reason := "ProvenanceNotVerified"
+		msg := fmt.Sprintf("failed to verify image provenance: %s", err)
+		conditions.MarkFalse(vmCtx.VM, vmopv1.VirtualMachineConditionImageReady, reason, "%s", msg)
+		return fmt.Errorf("%s: %w", reason, err)
+	}
+
+	vmCtx.Logger.V(4).Info("Verified image provenance",
+		"imageName", obj.GetName(),
+		"publishRequest", record.PublishRequest.Name,
+		"requestedBy", record.RequestedBy)
+
+	return nil
+}
+
+// GetCachedDuplicateImage returns the provider item ID and content version of
+// an image with the same fingerprint as the provided image status whose files
+// are cached at the specified datastore. Duplicate images are only considered
+// if the provided image's own files are not cached at the datastore, and only
+// ready images from the VM's namespace or the cluster are considered. If
+// verified images are required, then only duplicate images whose provenance is
+// verified are considered, since the VM is created from the duplicate image's
+// files.
+//
+// False is returned if the provided image does not have a fingerprint or no
+// duplicate image is cached at the datastore.
+func GetCachedDuplicateImage(
+	vmCtx pkgctx.VirtualMachineContext,
+	k8sClient ctrlclient.Client,
+	status vmopv1.VirtualMachineImageStatus,
+	datacenterID, datastoreID string) (string, string, bool) {
+
+	if status.Fingerprint == "" {
+		return "", "", false
+	}
+
+	isCached := func(itemID, itemVersion string) bool {
+		var obj vmopv1.VirtualMachineImageCache
+		if err := k8sClient.Get(
+			vmCtx,
+			ctrlclient.ObjectKey{
+				Namespace: pkgcfg.FromContext(vmCtx).PodNamespace,
+				Name:      util.VMIName(itemID),
+			},
+			&obj); err != nil {
+
+			return false
+		}
+		if obj.Spec.ProviderVersion != itemVersion {
+			return false
+		}
+		for i := range obj.Status.Locations {
+			l := obj.Status.Locations[i]
+			if l.DatacenterID == datacenterID &&
+				l.DatastoreID == datastoreID &&
+				conditions.IsTrue(l, vmopv1.ReadyConditionType) {
+
+				return true
+			}
+		}
+		return false
+	}
+
+	if isCached(status.ProviderItemID, status.ProviderContentVersion) {
+		return "", "", false
+	}
+
+	type duplicate struct {
+		obj    ctrlclient.Object
+		status vmopv1.VirtualMachineImageStatus
+	}
+	var duplicates []duplicate
+
+	var vmiList vmopv1.VirtualMachineImageList
+	if err := k8sClient.List(
+		vmCtx,
+		&vmiList,
+		ctrlclient.InNamespace(vmCtx.VM.Namespace)); err != nil {
+
+		vmCtx.Logger.Error(err, "Failed to list images for duplicates")
+		return "", "", false
+	}
+	for i := range vmiList.Items {
+		duplicates = append(duplicates, duplicate{&vmiList.Items[i], vmiList.Items[i].Status})
+	}
+
+	var cvmiList vmopv1.ClusterVirtualMachineImageList
+	if err := k8sClient.List(vmCtx, &cvmiList); err != nil {
+		vmCtx.Logger.Error(err, "Failed to list cluster images for duplicates")
+		return "", "", false
+	}
+	for i := range cvmiList.Items {
+		duplicates = append(duplicates, duplicate{&cvmiList.Items[i], cvmiList.Items[i].Status})
+	}
+
+	var (
+		cfg    = pkgcfg.FromContext(vmCtx)
+		verify = cfg.ImageProvenance.RequireVerifiedImages
+		keys   []provenance.PublicKey
+	)
+	if verify {
+		var err error
+		if keys, err = provenance.GetPublicKeys(
+			vmCtx,
+			k8sClient,
+			cfg.PodNamespace,
+			cfg.ImageProvenance.PublicKeysSecretName); err != nil {
+
+			vmCtx.Logger.Error(err, "Failed to get public keys for duplicates")
+			return "", "", false
+		}
+	}
+
+	for _, d := range duplicates {
+		if d.status.Fingerprint != status.Fingerprint ||
+			d.status.ProviderItemID == "" ||
+			d.status.ProviderItemID == status.ProviderItemID ||
+			!conditions.IsTrue(d.status, vmopv1.ReadyConditionType) {
+
+			continue
+		}
+		if verify {
+			if _, err := provenance.VerifyImage(d.obj, d.status, keys); err != nil {
+				vmCtx.Logger.V(4).Info("Skipping duplicate image with unverified provenance",
+					"imageName", d.obj.GetName(),
+					"err", err.Error())
+				continue
+			}
+		}
+		if isCached(d.status.ProviderItemID, d.status.ProviderContentVersion) {
+			return d.status.ProviderItemID, d.status.ProviderContentVersion, true
+		}
+	}
+
+	return "", "", false
+}
+
 func getSecretData(
 	vmCtx pkgctx.VirtualMachineContext,
 	k8sClient ctrlclient.Client,
@@ -355,6 +518,7 @@ func GetVirtualMachineBootstrap(
 	var vAppExData map[string]map[string]string
 	var cloudConfigSecretData *cloudinit.CloudConfigSecretData
 	var sysprepSecretData *sysprep.SecretData
+	var vendorData string
 
 	if v := bootstrapSpec.CloudInit; v != nil {
 		if cooked := v.CloudConfig; cooked != nil {
@@ -378,6 +542,14 @@ func GetVirtualMachineBootstrap(
 				return vmlifecycle.BootstrapData{}, err
 			}
 		}
+
+		var err error
+		vendorData, err = cloudinit.GetVendorData(vmCtx, k8sClient, vmCtx.VM.Namespace)
+		if err != nil {
+			reason, msg := errToConditionReasonAndMessage(err)
+			conditions.MarkFalse(vmCtx.VM, vmopv1.VirtualMachineConditionBootstrapReady, reason, "%s", msg)
+			return vmlifecycle.BootstrapData{}, err
+		}
 	} else if v := bootstrapSpec.Sysprep; v != nil {
 		if cooked := v.Sysprep; cooked != nil {
 			out, err := sysprep.GetSysprepSecretData(
@@ -400,6 +572,16 @@ func GetVirtualMachineBootstrap(
 				return vmlifecycle.BootstrapData{}, err
 			}
 		}
+	} else if v := bootstrapSpec.Ignition; v != nil {
+		if raw := v.RawConfig; raw != nil {
+			var err error
+			data, err = getSecretData(vmCtx, k8sClient, raw.Name, raw.Key, false)
+			if err != nil {
+				reason, msg := errToConditionReasonAndMessage(err)
+				conditions.MarkFalse(vmCtx.VM, vmopv1.VirtualMachineConditionBootstrapReady, reason, "%s", msg)
+				return vmlifecycle.BootstrapData{}, err
+			}
+		}
 	}
 
 	// vApp bootstrap can be used alongside LinuxPrep/Sysprep.
@@ -454,6 +636,7 @@ func GetVirtualMachineBootstrap(
 		VAppExData:  vAppExData,
 		CloudConfig: cloudConfigSecretData,
 		Sysprep:     sysprepSecretData,
+		VendorData:  vendorData,
 	}, nil
 }
 
@@ -646,6 +829,15 @@ func GetAdditionalResourcesForBackup(
 					out[i].GetObjectKind().SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("Secret"))
 				}
 				objects = append(objects, out...)
+
+				out, err = cloudinit.GetConfigMapResources(vmCtx, k8sClient, vmCtx.VM.Namespace, *cooked)
+				if err != nil {
+					return nil, err
+				}
+				for i := range out {
+					out[i].GetObjectKind().SetGroupVersionKind(corev1.SchemeGroupVersion.WithKind("ConfigMap"))
+				}
+				objects = append(objects, out...)
 			} else if raw := v.RawCloudConfig; raw != nil {
 				obj, err := getSecretOrConfigMapObject(vmCtx, k8sClient, raw.Name, true)
 				if err != nil {
@@ -672,6 +864,14 @@ func GetAdditionalResourcesForBackup(
 				}
 				objects = append(objects, obj)
 			}
+		} else if v := bootstrapSpec.Ignition; v != nil {
+			if raw := v.RawConfig; raw != nil {
+				obj, err := getSecretOrConfigMapObject(vmCtx, k8sClient, raw.Name, true)
+				if err != nil {
+					return nil, err
+				}
+				objects = append(objects, obj)
+			}
 		}
 
 		// Get bootstrap related objects from vAppConfig (can be used alongside LinuxPrep/Sysprep).
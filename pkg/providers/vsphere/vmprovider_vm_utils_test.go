@@ -5,6 +5,12 @@
 package vsphere_test
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
 	"fmt"
 	"time"
 
@@ -22,9 +28,11 @@ import (
 	"github.com/vmware-tanzu/vm-operator/api/v1alpha4/sysprep"
 	"github.com/vmware-tanzu/vm-operator/pkg/conditions"
 	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	pkgconst "github.com/vmware-tanzu/vm-operator/pkg/constants"
 	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
 	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere"
 	"github.com/vmware-tanzu/vm-operator/pkg/util"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/image/provenance"
 	vmopv1util "github.com/vmware-tanzu/vm-operator/pkg/util/vmopv1"
 	"github.com/vmware-tanzu/vm-operator/test/builder"
 )
@@ -417,6 +425,306 @@ func vmUtilTests() {
 		})
 	})
 
+	Context("VerifyVirtualMachineImageProvenance", func() {
+		const secretName = "image-provenance-public-keys"
+
+		var (
+			key    *ecdsa.PrivateKey
+			vmi    *vmopv1.VirtualMachineImage
+			secret *corev1.Secret
+		)
+
+		BeforeEach(func() {
+			var err error
+			key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+			Expect(err).ToNot(HaveOccurred())
+			der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+			Expect(err).ToNot(HaveOccurred())
+
+			pkgcfg.SetContext(vmCtx, func(config *pkgcfg.Config) {
+				config.PodNamespace = "vmop-system"
+				config.ImageProvenance.RequireVerifiedImages = true
+				config.ImageProvenance.PublicKeysSecretName = secretName
+			})
+
+			secret = &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "vmop-system",
+					Name:      secretName,
+				},
+				Data: map[string][]byte{
+					"pub.pem": pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}),
+				},
+			}
+			initObjects = append(initObjects, secret)
+
+			vmi = builder.DummyVirtualMachineImage(builder.DummyVMIName)
+			vmi.Namespace = vmCtx.VM.Namespace
+			vmi.Status.ProviderItemID = "my-item-id"
+			vmi.Status.ProviderContentVersion = "1"
+
+			data, err := json.Marshal(provenance.Record{
+				Version: provenance.RecordVersion,
+				Image: provenance.Image{
+					Name:                   vmi.Name,
+					ProviderItemID:         "my-item-id",
+					ProviderContentVersion: "1",
+				},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			sig, err := provenance.Sign(key, data)
+			Expect(err).ToNot(HaveOccurred())
+			provenance.SetOnImage(vmi, data, sig)
+		})
+
+		It("returns success when the image is verified", func() {
+			Expect(vsphere.VerifyVirtualMachineImageProvenance(vmCtx, k8sClient, vmi, vmi.Status)).To(Succeed())
+		})
+
+		When("the image is not signed", func() {
+			BeforeEach(func() {
+				delete(vmi.Annotations, pkgconst.ImageProvenanceSignatureAnnotationKey)
+			})
+
+			It("returns error and sets condition", func() {
+				err := vsphere.VerifyVirtualMachineImageProvenance(vmCtx, k8sClient, vmi, vmi.Status)
+				Expect(err).To(MatchError(provenance.ErrNotSigned))
+				c := conditions.Get(vmCtx.VM, vmopv1.VirtualMachineConditionImageReady)
+				Expect(c).ToNot(BeNil())
+				Expect(c.Status).To(Equal(metav1.ConditionFalse))
+				Expect(c.Reason).To(Equal("ProvenanceNotVerified"))
+			})
+
+			When("verified images are not required", func() {
+				BeforeEach(func() {
+					pkgcfg.SetContext(vmCtx, func(config *pkgcfg.Config) {
+						config.ImageProvenance.RequireVerifiedImages = false
+					})
+				})
+
+				It("returns success", func() {
+					Expect(vsphere.VerifyVirtualMachineImageProvenance(vmCtx, k8sClient, vmi, vmi.Status)).To(Succeed())
+				})
+			})
+		})
+
+		When("the image content was updated after it was signed", func() {
+			BeforeEach(func() {
+				vmi.Status.ProviderContentVersion = "2"
+			})
+
+			It("returns error", func() {
+				err := vsphere.VerifyVirtualMachineImageProvenance(vmCtx, k8sClient, vmi, vmi.Status)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring(`content version "1", not "2"`))
+			})
+		})
+
+		When("the public keys secret does not exist", func() {
+			BeforeEach(func() {
+				initObjects = nil
+			})
+
+			It("returns error", func() {
+				err := vsphere.VerifyVirtualMachineImageProvenance(vmCtx, k8sClient, vmi, vmi.Status)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("failed to get public keys secret"))
+			})
+		})
+	})
+
+	Context("GetCachedDuplicateImage", func() {
+		const (
+			podNamespace = "vmop-system"
+			datacenterID = "datacenter-1"
+			datastoreID  = "datastore-1"
+			fingerprint  = "sha256:fingerprint"
+		)
+
+		var (
+			imgStatus vmopv1.VirtualMachineImageStatus
+			dupCVMI   *vmopv1.ClusterVirtualMachineImage
+
+			itemID      string
+			itemVersion string
+			ok          bool
+		)
+
+		newImageStatus := func(itemID string) vmopv1.VirtualMachineImageStatus {
+			status := vmopv1.VirtualMachineImageStatus{
+				ProviderItemID:         itemID,
+				ProviderContentVersion: "v1",
+				Fingerprint:            fingerprint,
+			}
+			conditions.MarkTrue(&status, vmopv1.ReadyConditionType)
+			return status
+		}
+
+		newImageCache := func(itemID string, ready bool) *vmopv1.VirtualMachineImageCache {
+			obj := &vmopv1.VirtualMachineImageCache{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: podNamespace,
+					Name:      util.VMIName(itemID),
+				},
+				Spec: vmopv1.VirtualMachineImageCacheSpec{
+					ProviderID:      itemID,
+					ProviderVersion: "v1",
+				},
+			}
+			obj.AddLocation(datacenterID, datastoreID)
+			l := vmopv1.VirtualMachineImageCacheLocationStatus{
+				DatacenterID: datacenterID,
+				DatastoreID:  datastoreID,
+			}
+			if ready {
+				conditions.MarkTrue(&l, vmopv1.ReadyConditionType)
+			} else {
+				conditions.MarkFalse(&l, vmopv1.ReadyConditionType, "NotReady", "not ready")
+			}
+			obj.Status.Locations = []vmopv1.VirtualMachineImageCacheLocationStatus{l}
+			return obj
+		}
+
+		BeforeEach(func() {
+			pkgcfg.SetContext(vmCtx, func(config *pkgcfg.Config) {
+				config.PodNamespace = podNamespace
+			})
+
+			imgStatus = newImageStatus("item-1")
+
+			dupVMI := builder.DummyVirtualMachineImage("vmi-2")
+			dupVMI.Namespace = vmCtx.VM.Namespace
+			dupVMI.Status = newImageStatus("item-2")
+
+			dupCVMI = builder.DummyClusterVirtualMachineImage("vmi-3")
+			dupCVMI.Status = newImageStatus("item-3")
+
+			initObjects = append(initObjects,
+				dupVMI,
+				dupCVMI,
+				newImageCache("item-3", true),
+			)
+		})
+
+		JustBeforeEach(func() {
+			itemID, itemVersion, ok = vsphere.GetCachedDuplicateImage(
+				vmCtx, k8sClient, imgStatus, datacenterID, datastoreID)
+		})
+
+		It("returns the cached duplicate image", func() {
+			Expect(ok).To(BeTrue())
+			Expect(itemID).To(Equal("item-3"))
+			Expect(itemVersion).To(Equal("v1"))
+		})
+
+		When("the image is cached at the datastore", func() {
+			BeforeEach(func() {
+				initObjects = append(initObjects, newImageCache("item-1", true))
+			})
+
+			It("returns false", func() {
+				Expect(ok).To(BeFalse())
+			})
+		})
+
+		When("the image is not ready at the datastore", func() {
+			BeforeEach(func() {
+				initObjects = append(initObjects, newImageCache("item-1", false))
+			})
+
+			It("returns the cached duplicate image", func() {
+				Expect(ok).To(BeTrue())
+				Expect(itemID).To(Equal("item-3"))
+			})
+		})
+
+		When("the image does not have a fingerprint", func() {
+			BeforeEach(func() {
+				imgStatus.Fingerprint = ""
+			})
+
+			It("returns false", func() {
+				Expect(ok).To(BeFalse())
+			})
+		})
+
+		When("the duplicate image has a different fingerprint", func() {
+			BeforeEach(func() {
+				imgStatus.Fingerprint = "sha256:other"
+			})
+
+			It("returns false", func() {
+				Expect(ok).To(BeFalse())
+			})
+		})
+
+		When("the duplicate image's cache is for a different content version", func() {
+			BeforeEach(func() {
+				c := initObjects[len(initObjects)-1].(*vmopv1.VirtualMachineImageCache)
+				c.Spec.ProviderVersion = "v0"
+			})
+
+			It("returns false", func() {
+				Expect(ok).To(BeFalse())
+			})
+		})
+
+		When("verified images are required", func() {
+			const secretName = "image-provenance-public-keys"
+
+			var key *ecdsa.PrivateKey
+
+			BeforeEach(func() {
+				var err error
+				key, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+				Expect(err).ToNot(HaveOccurred())
+				der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+				Expect(err).ToNot(HaveOccurred())
+
+				pkgcfg.SetContext(vmCtx, func(config *pkgcfg.Config) {
+					config.ImageProvenance.RequireVerifiedImages = true
+					config.ImageProvenance.PublicKeysSecretName = secretName
+				})
+
+				initObjects = append(initObjects, &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: podNamespace,
+						Name:      secretName,
+					},
+					Data: map[string][]byte{
+						"pub.pem": pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}),
+					},
+				})
+			})
+
+			It("returns false since the duplicate image is not verified", func() {
+				Expect(ok).To(BeFalse())
+			})
+
+			When("the duplicate image is verified", func() {
+				BeforeEach(func() {
+					data, err := json.Marshal(provenance.Record{
+						Version: provenance.RecordVersion,
+						Image: provenance.Image{
+							Name:                   dupCVMI.Name,
+							ProviderItemID:         "item-3",
+							ProviderContentVersion: "v1",
+						},
+					})
+					Expect(err).ToNot(HaveOccurred())
+					sig, err := provenance.Sign(key, data)
+					Expect(err).ToNot(HaveOccurred())
+					provenance.SetOnImage(dupCVMI, data, sig)
+				})
+
+				It("returns the cached duplicate image", func() {
+					Expect(ok).To(BeTrue())
+					Expect(itemID).To(Equal("item-3"))
+				})
+			})
+		})
+	})
+
 	Context("GetVirtualMachineBootstrap", func() {
 		const dataName = "dummy-vm-bootstrap-data"
 		const vAppDataName = "dummy-vm-bootstrap-vapp-data"
@@ -142,6 +142,41 @@ func GetNamespaceFolderAndRPMoIDs(
 	return folderMoID, rpMoIDs, nil
 }
 
+// GetNamespaceZoneNames returns the names of the zones available to the
+// namespace.
+func GetNamespaceZoneNames(
+	ctx context.Context,
+	client ctrlclient.Client,
+	namespace string) ([]string, error) {
+
+	var names []string
+
+	if pkgcfg.FromContext(ctx).Features.WorkloadDomainIsolation {
+		zones, err := GetZones(ctx, client, namespace)
+		// If no Zones found in namespace, do not return err.
+		if err != nil && !errors.Is(err, ErrNoZones) {
+			return nil, err
+		}
+		for _, zone := range zones {
+			names = append(names, zone.Name)
+		}
+		return names, nil
+	}
+
+	availabilityZones, err := GetAvailabilityZones(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, az := range availabilityZones {
+		if _, ok := az.Spec.Namespaces[namespace]; ok {
+			names = append(names, az.Name)
+		}
+	}
+
+	return names, nil
+}
+
 // GetNamespaceFolderMoID returns the FolderMoID for the namespace.
 func GetNamespaceFolderMoID(
 	ctx context.Context,
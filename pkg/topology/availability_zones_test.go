@@ -122,6 +122,22 @@ var _ = Describe("Availability Zones and Zones", func() {
 		}
 	}
 
+	assertGetNamespaceZoneNamesSuccess := func(expected ...string) func() {
+		return func() {
+			for i := 0; i < numberOfNamespaces; i++ {
+				names, err := topology.GetNamespaceZoneNames(ctx, client, fmt.Sprintf("ns-%d", i))
+				ExpectWithOffset(1, err).ToNot(HaveOccurred())
+				ExpectWithOffset(1, names).To(ConsistOf(expected))
+			}
+		}
+	}
+
+	assertGetNamespaceZoneNamesInvalidNamespaceEmpty := func() {
+		names, err := topology.GetNamespaceZoneNames(ctx, client, "invalid")
+		ExpectWithOffset(1, err).ToNot(HaveOccurred())
+		ExpectWithOffset(1, names).To(BeEmpty())
+	}
+
 	assertGetAvailabilityZonesErrNoAvailabilityZones := func() {
 		_, err := topology.GetAvailabilityZones(ctx, client)
 		ExpectWithOffset(1, err).To(MatchError(topology.ErrNoAvailabilityZones))
@@ -301,6 +317,14 @@ var _ = Describe("Availability Zones and Zones", func() {
 					It("Should return the RP and Folder resources", assertGetNamespaceFolderAndRPMoIDsSuccess)
 				})
 			})
+			Context("GetNamespaceZoneNames", func() {
+				Context("With an invalid Namespace name", func() {
+					It("Should return no names", assertGetNamespaceZoneNamesInvalidNamespaceEmpty)
+				})
+				Context("With a valid Namespace name", func() {
+					It("Should return the AvailabilityZone names", assertGetNamespaceZoneNamesSuccess("az-0", "az-1"))
+				})
+			})
 		})
 		When("DevOps Namespaces do not exist", func() {
 			Context("GetAvailabilityZones", func() {
@@ -376,6 +400,14 @@ var _ = Describe("Availability Zones and Zones", func() {
 					It("Should return the RP and Folder resources", assertGetNamespaceFolderAndRPMoIDsSuccess)
 				})
 			})
+			Context("GetNamespaceZoneNames", func() {
+				Context("With an invalid Namespace name", func() {
+					It("Should return no names", assertGetNamespaceZoneNamesInvalidNamespaceEmpty)
+				})
+				Context("With a valid Namespace name", func() {
+					It("Should return the Zone names", assertGetNamespaceZoneNamesSuccess("zone-0", "zone-1"))
+				})
+			})
 		})
 	})
 
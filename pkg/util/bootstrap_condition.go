@@ -5,8 +5,15 @@
 package util
 
 import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
 	"strconv"
 	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // GuestInfoBootstrapCondition is the ExtraConfig key at which possible info
@@ -39,3 +46,193 @@ func GetBootstrapConditionValues(
 
 	return false, "", "", false
 }
+
+const (
+	// GuestInfoCloudInitStatus is the ExtraConfig key at which the contents of
+	// Cloud-Init's status.json file may be stored.
+	GuestInfoCloudInitStatus = "guestinfo.cloudinit.status"
+
+	// GuestInfoCloudInitResult is the ExtraConfig key at which the contents of
+	// Cloud-Init's result.json file may be stored.
+	GuestInfoCloudInitResult = "guestinfo.cloudinit.result"
+)
+
+const (
+	// CloudInitRunningReason is the reason used when Cloud-Init has not
+	// finished all of its stages.
+	CloudInitRunningReason = "CloudInitRunning"
+
+	// CloudInitModuleFailedReason is the reason used when a Cloud-Init module
+	// failed.
+	CloudInitModuleFailedReason = "CloudInitModuleFailed"
+
+	// CloudInitFailedReason is the reason used when Cloud-Init reported an
+	// error that is not attributed to a module.
+	CloudInitFailedReason = "CloudInitFailed"
+
+	// CloudInitInvalidStatusReason is the reason used when the Cloud-Init
+	// status or result could not be parsed.
+	CloudInitInvalidStatusReason = "CloudInitInvalidStatus"
+)
+
+// cloudInitStages are the names of Cloud-Init's stages in the order in which
+// they are run.
+var cloudInitStages = []string{
+	"init-local",
+	"init",
+	"modules-config",
+	"modules-final",
+}
+
+type cloudInitStage struct {
+	Start    *float64 `json:"start"`
+	Finished *float64 `json:"finished"`
+	Errors   []string `json:"errors"`
+}
+
+// cloudInitStatus is the shape of Cloud-Init's status.json file.
+type cloudInitStatus struct {
+	V1 map[string]json.RawMessage `json:"v1"`
+}
+
+// cloudInitResult is the shape of Cloud-Init's result.json file.
+type cloudInitResult struct {
+	V1 struct {
+		Errors []string `json:"errors"`
+	} `json:"v1"`
+}
+
+// cloudInitModuleErrorRx matches the errors Cloud-Init reports for a failed
+// module, ex. "('write_files', TypeError('...'))".
+var cloudInitModuleErrorRx = regexp.MustCompile(`^\('([^']+)',\s*(.*)\)$`)
+
+// GetCloudInitBootstrapConditionValues returns the bootstrap condition values
+// from the Cloud-Init status and result stored in a VM's ExtraConfig, if the
+// data is present. The values of the keys may be plain-text, base64-encoded,
+// or gzipped and base64-encoded JSON.
+//
+// The result is preferred over the status since Cloud-Init only writes the
+// result once all stages are finished.
+func GetCloudInitBootstrapConditionValues(
+	extraConfig map[string]string) (metav1.ConditionStatus, string, string, bool) {
+
+	statusVal, hasStatus := extraConfig[GuestInfoCloudInitStatus]
+	resultVal, hasResult := extraConfig[GuestInfoCloudInitResult]
+	if !hasStatus && !hasResult {
+		return "", "", "", false
+	}
+
+	var (
+		status    cloudInitStatus
+		statusErr error
+	)
+	if hasStatus {
+		statusErr = unmarshalCloudInitJSON(statusVal, &status)
+	}
+
+	if hasResult {
+		var result cloudInitResult
+		if err := unmarshalCloudInitJSON(resultVal, &result); err != nil {
+			return metav1.ConditionUnknown,
+				CloudInitInvalidStatusReason,
+				fmt.Sprintf("failed to parse cloud-init result: %s", err),
+				true
+		}
+		if errs := result.V1.Errors; len(errs) > 0 {
+			reason, msg := cloudInitErrorReasonAndMessage("", errs)
+			return metav1.ConditionFalse, reason, msg, true
+		}
+		var finished *float64
+		if statusErr == nil {
+			finished = status.stage("modules-final").Finished
+		}
+		return metav1.ConditionTrue, "", cloudInitFinishedMessage(finished), true
+	}
+
+	if statusErr != nil {
+		return metav1.ConditionUnknown,
+			CloudInitInvalidStatusReason,
+			fmt.Sprintf("failed to parse cloud-init status: %s", statusErr),
+			true
+	}
+
+	for _, name := range cloudInitStages {
+		if errs := status.stage(name).Errors; len(errs) > 0 {
+			reason, msg := cloudInitErrorReasonAndMessage(name, errs)
+			return metav1.ConditionFalse, reason, msg, true
+		}
+	}
+
+	final := status.stage("modules-final")
+	if final.Finished == nil {
+		msg := "cloud-init has not started"
+		for _, name := range cloudInitStages {
+			if s := status.stage(name); s.Start != nil && s.Finished == nil {
+				msg = fmt.Sprintf("cloud-init is running stage %s", name)
+			}
+		}
+		return metav1.ConditionUnknown, CloudInitRunningReason, msg, true
+	}
+
+	return metav1.ConditionTrue, "", cloudInitFinishedMessage(final.Finished), true
+}
+
+func (s cloudInitStatus) stage(name string) cloudInitStage {
+	var stage cloudInitStage
+	if data, ok := s.V1[name]; ok {
+		// A stage that cannot be parsed is treated as not having started.
+		_ = json.Unmarshal(data, &stage)
+	}
+	return stage
+}
+
+func unmarshalCloudInitJSON(val string, obj any) error {
+	data, err := TryToDecodeBase64Gzip([]byte(val))
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal([]byte(data), obj)
+}
+
+// cloudInitErrorReasonAndMessage returns the reason and message for the first
+// of the provided errors. If the error is from a module, the message includes
+// the module's name, ex. "module cc_write_files failed".
+func cloudInitErrorReasonAndMessage(
+	stage string,
+	errs []string) (string, string) {
+
+	var (
+		reason = CloudInitFailedReason
+		what   = "cloud-init failed"
+		detail = errs[0]
+	)
+
+	if m := cloudInitModuleErrorRx.FindStringSubmatch(errs[0]); m != nil {
+		module := m[1]
+		if !strings.HasPrefix(module, "cc_") {
+			module = "cc_" + module
+		}
+		reason = CloudInitModuleFailedReason
+		what = fmt.Sprintf("module %s failed", module)
+		detail = m[2]
+	}
+
+	if stage != "" {
+		what = fmt.Sprintf("%s in stage %s", what, stage)
+	}
+	msg := fmt.Sprintf("%s: %s", what, detail)
+	if n := len(errs) - 1; n > 0 {
+		msg = fmt.Sprintf("%s (and %d more errors)", msg, n)
+	}
+
+	return reason, msg
+}
+
+func cloudInitFinishedMessage(finished *float64) string {
+	if finished == nil {
+		return "cloud-init finished"
+	}
+	sec, frac := math.Modf(*finished)
+	ts := time.Unix(int64(sec), int64(frac*1e9)).UTC()
+	return fmt.Sprintf("cloud-init finished at %s", ts.Format(time.RFC3339))
+}
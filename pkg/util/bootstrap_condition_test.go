@@ -10,6 +10,8 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
 	"github.com/vmware-tanzu/vm-operator/pkg/util"
 )
 
@@ -140,3 +142,131 @@ var _ = DescribeTable("GetBootstrapConditionValuesTest",
 		false, "my-reason", "my,comma,delimited,message", true,
 	),
 )
+
+var _ = Describe("GetCloudInitBootstrapConditionValues", func() {
+	var (
+		extraConfig map[string]string
+		status      metav1.ConditionStatus
+		reason      string
+		msg         string
+		ok          bool
+	)
+
+	BeforeEach(func() {
+		extraConfig = map[string]string{}
+	})
+
+	JustBeforeEach(func() {
+		status, reason, msg, ok = util.GetCloudInitBootstrapConditionValues(extraConfig)
+	})
+
+	When("there is no status or result", func() {
+		It("returns not ok", func() {
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	When("the status is invalid", func() {
+		BeforeEach(func() {
+			extraConfig[util.GuestInfoCloudInitStatus] = "{"
+		})
+		It("returns unknown", func() {
+			Expect(ok).To(BeTrue())
+			Expect(status).To(Equal(metav1.ConditionUnknown))
+			Expect(reason).To(Equal(util.CloudInitInvalidStatusReason))
+			Expect(msg).To(HavePrefix("failed to parse cloud-init status: "))
+		})
+	})
+
+	When("cloud-init is running", func() {
+		BeforeEach(func() {
+			extraConfig[util.GuestInfoCloudInitStatus] = `{"v1": {
+				"stage": "modules-config",
+				"init-local": {"start": 1700000000.1, "finished": 1700000001.2, "errors": []},
+				"init": {"start": 1700000002.1, "finished": 1700000003.2, "errors": []},
+				"modules-config": {"start": 1700000004.1, "finished": null, "errors": []},
+				"modules-final": {"start": null, "finished": null, "errors": []}
+			}}`
+		})
+		It("returns unknown with the running stage", func() {
+			Expect(ok).To(BeTrue())
+			Expect(status).To(Equal(metav1.ConditionUnknown))
+			Expect(reason).To(Equal(util.CloudInitRunningReason))
+			Expect(msg).To(Equal("cloud-init is running stage modules-config"))
+		})
+	})
+
+	When("cloud-init is finished", func() {
+		BeforeEach(func() {
+			extraConfig[util.GuestInfoCloudInitStatus] = `{"v1": {
+				"stage": null,
+				"init-local": {"start": 1700000000.1, "finished": 1700000001.2, "errors": []},
+				"init": {"start": 1700000002.1, "finished": 1700000003.2, "errors": []},
+				"modules-config": {"start": 1700000004.1, "finished": 1700000005.2, "errors": []},
+				"modules-final": {"start": 1700000006.1, "finished": 1700000007.2, "errors": []}
+			}}`
+		})
+		It("returns true with the finished timestamp", func() {
+			Expect(ok).To(BeTrue())
+			Expect(status).To(Equal(metav1.ConditionTrue))
+			Expect(reason).To(BeEmpty())
+			Expect(msg).To(Equal("cloud-init finished at 2023-11-14T22:13:27Z"))
+		})
+	})
+
+	When("a module failed", func() {
+		BeforeEach(func() {
+			extraConfig[util.GuestInfoCloudInitStatus] = `{"v1": {
+				"stage": null,
+				"init-local": {"start": 1700000000.1, "finished": 1700000001.2, "errors": []},
+				"init": {"start": 1700000002.1, "finished": 1700000003.2, "errors": []},
+				"modules-config": {"start": 1700000004.1, "finished": 1700000005.2, "errors": [
+					"('write_files', TypeError('invalid permissions'))",
+					"('runcmd', RuntimeError('failed'))"
+				]},
+				"modules-final": {"start": 1700000006.1, "finished": 1700000007.2, "errors": []}
+			}}`
+		})
+		It("returns false with the module name", func() {
+			Expect(ok).To(BeTrue())
+			Expect(status).To(Equal(metav1.ConditionFalse))
+			Expect(reason).To(Equal(util.CloudInitModuleFailedReason))
+			Expect(msg).To(Equal(
+				"module cc_write_files failed in stage modules-config: " +
+					"TypeError('invalid permissions') (and 1 more errors)"))
+		})
+	})
+
+	When("there is a result", func() {
+		BeforeEach(func() {
+			extraConfig[util.GuestInfoCloudInitStatus] = `{"v1": {
+				"modules-final": {"start": 1700000006.1, "finished": 1700000007.2, "errors": []}
+			}}`
+		})
+
+		When("the result has no errors", func() {
+			BeforeEach(func() {
+				data, err := util.EncodeGzipBase64(`{"v1": {"datasource": "DataSourceVMware", "errors": []}}`)
+				Expect(err).ToNot(HaveOccurred())
+				extraConfig[util.GuestInfoCloudInitResult] = data
+			})
+			It("returns true", func() {
+				Expect(ok).To(BeTrue())
+				Expect(status).To(Equal(metav1.ConditionTrue))
+				Expect(msg).To(Equal("cloud-init finished at 2023-11-14T22:13:27Z"))
+			})
+		})
+
+		When("the result has an error that is not from a module", func() {
+			BeforeEach(func() {
+				extraConfig[util.GuestInfoCloudInitResult] = `{"v1": {"errors": ["no datasource found"]}}`
+			})
+			It("returns false", func() {
+				Expect(ok).To(BeTrue())
+				Expect(status).To(Equal(metav1.ConditionFalse))
+				Expect(reason).To(Equal(util.CloudInitFailedReason))
+				Expect(msg).To(Equal("cloud-init failed: no datasource found"))
+			})
+		})
+	})
+})
@@ -0,0 +1,1233 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package bootstraptemplate renders the Go templates that may be used in the
+// values of a VM's bootstrap data, ex. vApp properties.
+package bootstraptemplate
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math/big"
+	"net"
+	"net/netip"
+	"strconv"
+	"strings"
+	"text/template"
+
+	vmopv1a1 "github.com/vmware-tanzu/vm-operator/api/v1alpha1"
+	vmopv1a2 "github.com/vmware-tanzu/vm-operator/api/v1alpha2"
+	vmopv1a3 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/constants"
+	"github.com/vmware-tanzu/vm-operator/pkg/topology"
+)
+
+// Args are the data that is only known when the VM is bootstrapped and may be
+// referenced by a template.
+type Args struct {
+	// Data is the data from the Secret referenced by the Cloud-Init or Sysprep
+	// bootstrap spec.
+	Data map[string]string
+
+	// VAppData is the data from the Secret referenced by the vApp
+	// rawProperties.
+	VAppData map[string]string
+
+	// VAppExData is the data from the Secrets referenced by the vApp
+	// properties, keyed by the name of the Secret.
+	VAppExData map[string]map[string]string
+
+	Interfaces []Interface
+	DNSServers []string
+}
+
+// Interface is a VM's network interface.
+type Interface struct {
+	MacAddress string
+	IPConfigs  []IPConfig
+	Routes     []Route
+}
+
+// IPConfig is an IP address of a network interface.
+type IPConfig struct {
+	IPCIDR  string // IP address in CIDR notation e.g. 192.168.10.42/24
+	IsIPv4  bool
+	Gateway string
+}
+
+// Route is a route of a network interface.
+type Route struct {
+	To     string
+	Via    string
+	Metric int32
+}
+
+// NewRenderFunc returns a function that renders templates for the VM with the
+// provided args.
+func NewRenderFunc(
+	vm *vmopv1.VirtualMachine,
+	args Args) func(name, templateStr string) (string, error) {
+
+	return newRenderFunc(vm, &args, false)
+}
+
+// NewDryRunRenderFunc returns a function that renders templates for the VM
+// with placeholder data in place of the data that is only known when the VM is
+// bootstrapped, such as the IP addresses from IPAM, the values from Secrets,
+// and the VM's zone. The placeholder network data is derived from the VM's
+// spec.
+//
+// The function returns the error from parsing or executing a template so it
+// may be reported before the VM is bootstrapped.
+func NewDryRunRenderFunc(
+	vm *vmopv1.VirtualMachine) func(name, templateStr string) error {
+
+	args := dryRunArgs(vm)
+	render := newRenderFunc(vm, &args, true)
+
+	return func(name, templateStr string) error {
+		_, err := render(name, templateStr)
+		return err
+	}
+}
+
+func newRenderFunc(
+	vm *vmopv1.VirtualMachine,
+	bsArgs *Args,
+	dryRun bool) func(string, string) (string, error) {
+
+	// There is a lot of duplication here, especially since the "template" types are the same in v1a1
+	// and v1a2. We've conflated a lot of things here making this all a little nuts.
+
+	networkDevicesStatusV1A1 := toTemplateNetworkStatusV1A1(bsArgs)
+	networkStatusV1A1 := vmopv1a1.NetworkStatus{
+		Devices:     networkDevicesStatusV1A1,
+		Nameservers: bsArgs.DNSServers,
+	}
+
+	networkDevicesStatusV1A2 := toTemplateNetworkStatusV1A2(bsArgs)
+	networkStatusV1A2 := vmopv1a2.NetworkStatus{
+		Devices:     networkDevicesStatusV1A2,
+		Nameservers: bsArgs.DNSServers,
+	}
+
+	networkDevicesStatusV1A3 := toTemplateNetworkStatusV1A3(bsArgs)
+	networkStatusV1A3 := vmopv1a3.NetworkStatus{
+		Devices:     networkDevicesStatusV1A3,
+		Nameservers: bsArgs.DNSServers,
+	}
+
+	networkDevicesStatusV1A4 := toTemplateNetworkStatusV1A4(bsArgs)
+	networkStatusV1A4 := vmopv1.NetworkStatus{
+		Devices:     networkDevicesStatusV1A4,
+		Nameservers: bsArgs.DNSServers,
+	}
+
+	// Use separate deep copies of the VM to prevent issues caused by down-converting to v1a1 and v1a2.
+	// This prevents changing actual VM on next reconcile.
+	v1a1VM := &vmopv1a1.VirtualMachine{}
+	_ = v1a1VM.ConvertFrom(vm.DeepCopy())
+
+	v1a2VM := &vmopv1a2.VirtualMachine{}
+	_ = v1a2VM.ConvertFrom(vm.DeepCopy())
+
+	v1a3VM := &vmopv1a3.VirtualMachine{}
+	_ = v1a3VM.ConvertFrom(vm.DeepCopy())
+
+	templateData := struct {
+		V1alpha1 vmopv1a1.VirtualMachineTemplate
+		V1alpha2 vmopv1a2.VirtualMachineTemplate
+		V1alpha3 vmopv1a3.VirtualMachineTemplate
+		V1alpha4 vmopv1.VirtualMachineTemplate
+	}{
+		V1alpha1: vmopv1a1.VirtualMachineTemplate{
+			Net: networkStatusV1A1,
+			VM:  v1a1VM,
+		},
+		V1alpha2: vmopv1a2.VirtualMachineTemplate{
+			Net: networkStatusV1A2,
+			VM:  v1a2VM,
+		},
+		V1alpha3: vmopv1a3.VirtualMachineTemplate{
+			Net: networkStatusV1A3,
+			VM:  v1a3VM,
+		},
+		V1alpha4: vmopv1.VirtualMachineTemplate{
+			Net: networkStatusV1A4,
+			VM:  vm,
+		},
+	}
+
+	v1a1FuncMap := v1a1TemplateFunctions(networkStatusV1A1, networkDevicesStatusV1A1)
+	v1a2FuncMap := v1a2TemplateFunctions(networkStatusV1A2, networkDevicesStatusV1A2)
+	v1a3FuncMap := v1a3TemplateFunctions(networkStatusV1A3, networkDevicesStatusV1A3)
+	v1a4FuncMap := v1a4TemplateFunctions(networkStatusV1A4, networkDevicesStatusV1A4)
+
+	// Include all but could be nice to leave out newer versions if we could identify if this was
+	// created at a prior version.
+	funcMap := template.FuncMap{}
+	for k, v := range v1a1FuncMap {
+		funcMap[k] = v
+	}
+	for k, v := range v1a2FuncMap {
+		funcMap[k] = v
+	}
+	for k, v := range v1a3FuncMap {
+		funcMap[k] = v
+	}
+	for k, v := range v1a4FuncMap {
+		funcMap[k] = v
+	}
+	for k, v := range v1a4SubnetTemplateFunctions() {
+		funcMap[k] = v
+	}
+	for k, v := range v1a4VMTemplateFunctions(vm, bsArgs, dryRun) {
+		funcMap[k] = v
+	}
+
+	return func(name, templateStr string) (string, error) {
+		templ, err := template.New(name).Funcs(funcMap).Parse(templateStr)
+		if err != nil {
+			return "", fmt.Errorf("failed to parse template: %w", err)
+		}
+		var doc bytes.Buffer
+		if err := templ.Execute(&doc, &templateData); err != nil {
+			return "", fmt.Errorf("failed to execute template: %w", err)
+		}
+		return Normalize(doc.String()), nil
+	}
+}
+
+// Normalize replaces the escaped braces ("\{", "\}") that were used
+// to skip parsing.
+func Normalize(str string) string {
+	if strings.Contains(str, "\\{") || strings.Contains(str, "\\}") {
+		str = strings.ReplaceAll(str, "\\{", "{")
+		str = strings.ReplaceAll(str, "\\}", "}")
+	}
+	return str
+}
+
+func toTemplateNetworkStatusV1A1(bsArgs *Args) []vmopv1a1.NetworkDeviceStatus {
+	networkDevicesStatus := make([]vmopv1a1.NetworkDeviceStatus, 0, len(bsArgs.Interfaces))
+
+	for _, result := range bsArgs.Interfaces {
+		// When using Sysprep, the MAC address must be in the format of "-".
+		// CloudInit normalizes it again to ":" when adding it to the netplan.
+		macAddr := strings.ReplaceAll(result.MacAddress, ":", "-")
+
+		status := vmopv1a1.NetworkDeviceStatus{
+			MacAddress: macAddr,
+		}
+
+		for _, ipConfig := range result.IPConfigs {
+			// We mostly only did IPv4 before so keep that going.
+			if ipConfig.IsIPv4 {
+				if status.Gateway4 == "" {
+					status.Gateway4 = ipConfig.Gateway
+				}
+
+				status.IPAddresses = append(status.IPAddresses, ipConfig.IPCIDR)
+			}
+		}
+
+		networkDevicesStatus = append(networkDevicesStatus, status)
+	}
+
+	return networkDevicesStatus
+}
+
+func v1a1TemplateFunctions(
+	networkStatusV1A1 vmopv1a1.NetworkStatus,
+	networkDevicesStatusV1A1 []vmopv1a1.NetworkDeviceStatus) map[string]any {
+
+	// Get the first IP address from the first NIC.
+	v1alpha1FirstIP := func() (string, error) {
+		if len(networkDevicesStatusV1A1) == 0 {
+			return "", errors.New("no available network device, check with VI admin")
+		}
+		return networkDevicesStatusV1A1[0].IPAddresses[0], nil
+	}
+
+	// Get the first NIC's MAC address.
+	v1alpha1FirstNicMacAddr := func() (string, error) {
+		if len(networkDevicesStatusV1A1) == 0 {
+			return "", errors.New("no available network device, check with VI admin")
+		}
+		return networkDevicesStatusV1A1[0].MacAddress, nil
+	}
+
+	// Get the first IP address from the ith NIC.
+	// if index out of bound, throw an error and template string won't be parsed
+	v1alpha1FirstIPFromNIC := func(index int) (string, error) {
+		if len(networkDevicesStatusV1A1) == 0 {
+			return "", errors.New("no available network device, check with VI admin")
+		}
+		if index >= len(networkDevicesStatusV1A1) {
+			return "", errors.New("index out of bound")
+		}
+		return networkDevicesStatusV1A1[index].IPAddresses[0], nil
+	}
+
+	// Get all IP addresses from the ith NIC.
+	// if index out of bound, throw an error and template string won't be parsed
+	v1alpha1IPsFromNIC := func(index int) ([]string, error) {
+		if len(networkDevicesStatusV1A1) == 0 {
+			return []string{""}, errors.New("no available network device, check with VI admin")
+		}
+		if index >= len(networkDevicesStatusV1A1) {
+			return []string{""}, errors.New("index out of bound")
+		}
+		return networkDevicesStatusV1A1[index].IPAddresses, nil
+	}
+
+	// Format the first occurred count of nameservers with specific delimiter
+	// A negative count number would mean format all nameservers
+	v1alpha1FormatNameservers := func(count int, delimiter string) (string, error) {
+		var nameservers []string
+		if len(networkStatusV1A1.Nameservers) == 0 {
+			return "", errors.New("no available nameservers, check with VI admin")
+		}
+		if count < 0 || count >= len(networkStatusV1A1.Nameservers) {
+			nameservers = networkStatusV1A1.Nameservers
+			return strings.Join(nameservers, delimiter), nil
+		}
+		nameservers = networkStatusV1A1.Nameservers[:count]
+		return strings.Join(nameservers, delimiter), nil
+	}
+
+	// Get subnet mask from a CIDR notation IP address and prefix length
+	// if IP address and prefix length not valid, throw an error and template string won't be parsed
+	v1alpha1SubnetMask := func(cidr string) (string, error) {
+		_, ipv4Net, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return "", err
+		}
+		netmask := fmt.Sprintf("%d.%d.%d.%d", ipv4Net.Mask[0], ipv4Net.Mask[1], ipv4Net.Mask[2], ipv4Net.Mask[3])
+		return netmask, nil
+	}
+
+	// Format an IP address with default netmask CIDR
+	// if IP not valid, throw an error and template string won't be parsed
+	v1alpha1IP := func(IP string) (string, error) {
+		if net.ParseIP(IP) == nil {
+			return "", errors.New("input IP address not valid")
+		}
+		defaultMask := net.ParseIP(IP).DefaultMask()
+		ones, _ := defaultMask.Size()
+		expectedCidrNotation := IP + "/" + strconv.Itoa(ones)
+		return expectedCidrNotation, nil
+	}
+
+	// Format an IP address with network length(eg. /24) or decimal
+	// notation (eg. 255.255.255.0). Format an IP/CIDR with updated mask.
+	// An empty mask causes just the IP to be returned.
+	v1alpha1FormatIP := func(s string, mask string) (string, error) {
+		// Get the IP address for the input string.
+		ip, _, err := net.ParseCIDR(s)
+		if err != nil {
+			ip = net.ParseIP(s)
+			if ip == nil {
+				return "", fmt.Errorf("input IP address not valid")
+			}
+		}
+		// Store the IP as a string back into s.
+		s = ip.String()
+
+		// If no mask was provided then return just the IP.
+		if mask == "" {
+			return s, nil
+		}
+
+		// The provided mask is a network length.
+		if strings.HasPrefix(mask, "/") {
+			s += mask
+			if _, _, err := net.ParseCIDR(s); err != nil {
+				return "", err
+			}
+			return s, nil
+		}
+
+		// The provided mask is subnet mask.
+		maskIP := net.ParseIP(mask)
+		if maskIP == nil {
+			return "", fmt.Errorf("mask is an invalid IP")
+		}
+
+		maskIPBytes := maskIP.To4()
+		if len(maskIPBytes) == 0 {
+			maskIPBytes = maskIP.To16()
+		}
+
+		ipNet := net.IPNet{
+			IP:   ip,
+			Mask: net.IPMask(maskIPBytes),
+		}
+		s = ipNet.String()
+
+		// Validate the ipNet is an IP/CIDR
+		if _, _, err := net.ParseCIDR(s); err != nil {
+			return "", fmt.Errorf("invalid ip net: %s", s)
+		}
+
+		return s, nil
+	}
+
+	return template.FuncMap{
+		constants.V1alpha1FirstIP:           v1alpha1FirstIP,
+		constants.V1alpha1FirstNicMacAddr:   v1alpha1FirstNicMacAddr,
+		constants.V1alpha1FirstIPFromNIC:    v1alpha1FirstIPFromNIC,
+		constants.V1alpha1IPsFromNIC:        v1alpha1IPsFromNIC,
+		constants.V1alpha1FormatNameservers: v1alpha1FormatNameservers,
+		// These are more util function that we've conflated version namespaces.
+		constants.V1alpha1SubnetMask: v1alpha1SubnetMask,
+		constants.V1alpha1IP:         v1alpha1IP,
+		constants.V1alpha1FormatIP:   v1alpha1FormatIP,
+	}
+}
+
+func toTemplateNetworkStatusV1A2(bsArgs *Args) []vmopv1a2.NetworkDeviceStatus {
+	networkDevicesStatus := make([]vmopv1a2.NetworkDeviceStatus, 0, len(bsArgs.Interfaces))
+
+	for _, result := range bsArgs.Interfaces {
+		// When using Sysprep, the MAC address must be in the format of "-".
+		// CloudInit normalizes it again to ":" when adding it to the netplan.
+		macAddr := strings.ReplaceAll(result.MacAddress, ":", "-")
+
+		status := vmopv1a2.NetworkDeviceStatus{
+			MacAddress: macAddr,
+		}
+
+		for _, ipConfig := range result.IPConfigs {
+			// We mostly only did IPv4 before so keep that going.
+			if ipConfig.IsIPv4 {
+				if status.Gateway4 == "" {
+					status.Gateway4 = ipConfig.Gateway
+				}
+
+				status.IPAddresses = append(status.IPAddresses, ipConfig.IPCIDR)
+			}
+		}
+
+		networkDevicesStatus = append(networkDevicesStatus, status)
+	}
+
+	return networkDevicesStatus
+}
+
+// This is basically identical to v1a1TemplateFunctions.
+func v1a2TemplateFunctions(
+	networkStatusV1A2 vmopv1a2.NetworkStatus,
+	networkDevicesStatusV1A2 []vmopv1a2.NetworkDeviceStatus) map[string]any {
+
+	// Get the first IP address from the first NIC.
+	v1alpha2FirstIP := func() (string, error) {
+		if len(networkDevicesStatusV1A2) == 0 {
+			return "", errors.New("no available network device, check with VI admin")
+		}
+		return networkDevicesStatusV1A2[0].IPAddresses[0], nil
+	}
+
+	// Get the first NIC's MAC address.
+	v1alpha2FirstNicMacAddr := func() (string, error) {
+		if len(networkDevicesStatusV1A2) == 0 {
+			return "", errors.New("no available network device, check with VI admin")
+		}
+		return networkDevicesStatusV1A2[0].MacAddress, nil
+	}
+
+	// Get the first IP address from the ith NIC.
+	// if index out of bound, throw an error and template string won't be parsed
+	v1alpha2FirstIPFromNIC := func(index int) (string, error) {
+		if len(networkDevicesStatusV1A2) == 0 {
+			return "", errors.New("no available network device, check with VI admin")
+		}
+		if index >= len(networkDevicesStatusV1A2) {
+			return "", errors.New("index out of bound")
+		}
+		return networkDevicesStatusV1A2[index].IPAddresses[0], nil
+	}
+
+	// Get all IP addresses from the ith NIC.
+	// if index out of bound, throw an error and template string won't be parsed
+	v1alpha2IPsFromNIC := func(index int) ([]string, error) {
+		if len(networkDevicesStatusV1A2) == 0 {
+			return []string{""}, errors.New("no available network device, check with VI admin")
+		}
+		if index >= len(networkDevicesStatusV1A2) {
+			return []string{""}, errors.New("index out of bound")
+		}
+		return networkDevicesStatusV1A2[index].IPAddresses, nil
+	}
+
+	// Format the first occurred count of nameservers with specific delimiter
+	// A negative count number would mean format all nameservers
+	v1alpha2FormatNameservers := func(count int, delimiter string) (string, error) {
+		var nameservers []string
+		if len(networkStatusV1A2.Nameservers) == 0 {
+			return "", errors.New("no available nameservers, check with VI admin")
+		}
+		if count < 0 || count >= len(networkStatusV1A2.Nameservers) {
+			nameservers = networkStatusV1A2.Nameservers
+			return strings.Join(nameservers, delimiter), nil
+		}
+		nameservers = networkStatusV1A2.Nameservers[:count]
+		return strings.Join(nameservers, delimiter), nil
+	}
+
+	// Get subnet mask from a CIDR notation IP address and prefix length
+	// if IP address and prefix length not valid, throw an error and template string won't be parsed
+	v1alpha2SubnetMask := func(cidr string) (string, error) {
+		_, ipv4Net, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return "", err
+		}
+		netmask := fmt.Sprintf("%d.%d.%d.%d", ipv4Net.Mask[0], ipv4Net.Mask[1], ipv4Net.Mask[2], ipv4Net.Mask[3])
+		return netmask, nil
+	}
+
+	// Format an IP address with default netmask CIDR
+	// if IP not valid, throw an error and template string won't be parsed
+	v1alpha2IP := func(IP string) (string, error) {
+		if net.ParseIP(IP) == nil {
+			return "", errors.New("input IP address not valid")
+		}
+		defaultMask := net.ParseIP(IP).DefaultMask()
+		ones, _ := defaultMask.Size()
+		expectedCidrNotation := IP + "/" + strconv.Itoa(ones)
+		return expectedCidrNotation, nil
+	}
+
+	// Format an IP address with network length(eg. /24) or decimal
+	// notation (eg. 255.255.255.0). Format an IP/CIDR with updated mask.
+	// An empty mask causes just the IP to be returned.
+	v1alpha2FormatIP := func(s string, mask string) (string, error) {
+		// Get the IP address for the input string.
+		ip, _, err := net.ParseCIDR(s)
+		if err != nil {
+			ip = net.ParseIP(s)
+			if ip == nil {
+				return "", fmt.Errorf("input IP address not valid")
+			}
+		}
+		// Store the IP as a string back into s.
+		s = ip.String()
+
+		// If no mask was provided then return just the IP.
+		if mask == "" {
+			return s, nil
+		}
+
+		// The provided mask is a network length.
+		if strings.HasPrefix(mask, "/") {
+			s += mask
+			if _, _, err := net.ParseCIDR(s); err != nil {
+				return "", err
+			}
+			return s, nil
+		}
+
+		// The provided mask is subnet mask.
+		maskIP := net.ParseIP(mask)
+		if maskIP == nil {
+			return "", fmt.Errorf("mask is an invalid IP")
+		}
+
+		maskIPBytes := maskIP.To4()
+		if len(maskIPBytes) == 0 {
+			maskIPBytes = maskIP.To16()
+		}
+
+		ipNet := net.IPNet{
+			IP:   ip,
+			Mask: net.IPMask(maskIPBytes),
+		}
+		s = ipNet.String()
+
+		// Validate the ipNet is an IP/CIDR
+		if _, _, err := net.ParseCIDR(s); err != nil {
+			return "", fmt.Errorf("invalid ip net: %s", s)
+		}
+
+		return s, nil
+	}
+
+	return template.FuncMap{
+		constants.V1alpha2FirstIP:           v1alpha2FirstIP,
+		constants.V1alpha2FirstNicMacAddr:   v1alpha2FirstNicMacAddr,
+		constants.V1alpha2FirstIPFromNIC:    v1alpha2FirstIPFromNIC,
+		constants.V1alpha2IPsFromNIC:        v1alpha2IPsFromNIC,
+		constants.V1alpha2FormatNameservers: v1alpha2FormatNameservers,
+		// These are more util function that we've conflated version namespaces.
+		constants.V1alpha2SubnetMask: v1alpha2SubnetMask,
+		constants.V1alpha2IP:         v1alpha2IP,
+		constants.V1alpha2FormatIP:   v1alpha2FormatIP,
+	}
+}
+
+func toTemplateNetworkStatusV1A3(bsArgs *Args) []vmopv1a3.NetworkDeviceStatus {
+	networkDevicesStatus := make([]vmopv1a3.NetworkDeviceStatus, 0, len(bsArgs.Interfaces))
+
+	for _, result := range bsArgs.Interfaces {
+		// When using Sysprep, the MAC address must be in the format of "-".
+		// CloudInit normalizes it again to ":" when adding it to the netplan.
+		macAddr := strings.ReplaceAll(result.MacAddress, ":", "-")
+
+		status := vmopv1a3.NetworkDeviceStatus{
+			MacAddress: macAddr,
+		}
+
+		for _, ipConfig := range result.IPConfigs {
+			// We mostly only did IPv4 before so keep that going.
+			if ipConfig.IsIPv4 {
+				if status.Gateway4 == "" {
+					status.Gateway4 = ipConfig.Gateway
+				}
+
+				status.IPAddresses = append(status.IPAddresses, ipConfig.IPCIDR)
+			}
+		}
+
+		networkDevicesStatus = append(networkDevicesStatus, status)
+	}
+
+	return networkDevicesStatus
+}
+
+func toTemplateNetworkStatusV1A4(bsArgs *Args) []vmopv1.NetworkDeviceStatus {
+	networkDevicesStatus := make([]vmopv1.NetworkDeviceStatus, 0, len(bsArgs.Interfaces))
+
+	for _, result := range bsArgs.Interfaces {
+		// When using Sysprep, the MAC address must be in the format of "-".
+		// CloudInit normalizes it again to ":" when adding it to the netplan.
+		macAddr := strings.ReplaceAll(result.MacAddress, ":", "-")
+
+		status := vmopv1.NetworkDeviceStatus{
+			MacAddress: macAddr,
+		}
+
+		for _, ipConfig := range result.IPConfigs {
+			// We mostly only did IPv4 before so keep that going.
+			if ipConfig.IsIPv4 {
+				if status.Gateway4 == "" {
+					status.Gateway4 = ipConfig.Gateway
+				}
+
+				status.IPAddresses = append(status.IPAddresses, ipConfig.IPCIDR)
+			}
+		}
+
+		networkDevicesStatus = append(networkDevicesStatus, status)
+	}
+
+	return networkDevicesStatus
+}
+
+// This is basically identical to v1a2TemplateFunctions.
+func v1a3TemplateFunctions(
+	networkStatusV1A3 vmopv1a3.NetworkStatus,
+	networkDevicesStatusV1A3 []vmopv1a3.NetworkDeviceStatus) map[string]any {
+
+	// Get the first IP address from the first NIC.
+	v1alpha3FirstIP := func() (string, error) {
+		if len(networkDevicesStatusV1A3) == 0 {
+			return "", errors.New("no available network device, check with VI admin")
+		}
+		return networkDevicesStatusV1A3[0].IPAddresses[0], nil
+	}
+
+	// Get the first NIC's MAC address.
+	v1alpha3FirstNicMacAddr := func() (string, error) {
+		if len(networkDevicesStatusV1A3) == 0 {
+			return "", errors.New("no available network device, check with VI admin")
+		}
+		return networkDevicesStatusV1A3[0].MacAddress, nil
+	}
+
+	// Get the first IP address from the ith NIC.
+	// if index out of bound, throw an error and template string won't be parsed
+	v1alpha3FirstIPFromNIC := func(index int) (string, error) {
+		if len(networkDevicesStatusV1A3) == 0 {
+			return "", errors.New("no available network device, check with VI admin")
+		}
+		if index >= len(networkDevicesStatusV1A3) {
+			return "", errors.New("index out of bound")
+		}
+		return networkDevicesStatusV1A3[index].IPAddresses[0], nil
+	}
+
+	// Get all IP addresses from the ith NIC.
+	// if index out of bound, throw an error and template string won't be parsed
+	v1alpha3IPsFromNIC := func(index int) ([]string, error) {
+		if len(networkDevicesStatusV1A3) == 0 {
+			return []string{""}, errors.New("no available network device, check with VI admin")
+		}
+		if index >= len(networkDevicesStatusV1A3) {
+			return []string{""}, errors.New("index out of bound")
+		}
+		return networkDevicesStatusV1A3[index].IPAddresses, nil
+	}
+
+	// Format the first occurred count of nameservers with specific delimiter
+	// A negative count number would mean format all nameservers
+	v1alpha3FormatNameservers := func(count int, delimiter string) (string, error) {
+		var nameservers []string
+		if len(networkStatusV1A3.Nameservers) == 0 {
+			return "", errors.New("no available nameservers, check with VI admin")
+		}
+		if count < 0 || count >= len(networkStatusV1A3.Nameservers) {
+			nameservers = networkStatusV1A3.Nameservers
+			return strings.Join(nameservers, delimiter), nil
+		}
+		nameservers = networkStatusV1A3.Nameservers[:count]
+		return strings.Join(nameservers, delimiter), nil
+	}
+
+	// Get subnet mask from a CIDR notation IP address and prefix length
+	// if IP address and prefix length not valid, throw an error and template string won't be parsed
+	v1alpha3SubnetMask := func(cidr string) (string, error) {
+		_, ipv4Net, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return "", err
+		}
+		netmask := fmt.Sprintf("%d.%d.%d.%d", ipv4Net.Mask[0], ipv4Net.Mask[1], ipv4Net.Mask[2], ipv4Net.Mask[3])
+		return netmask, nil
+	}
+
+	// Format an IP address with default netmask CIDR
+	// if IP not valid, throw an error and template string won't be parsed
+	v1alpha3IP := func(IP string) (string, error) {
+		if net.ParseIP(IP) == nil {
+			return "", errors.New("input IP address not valid")
+		}
+		defaultMask := net.ParseIP(IP).DefaultMask()
+		ones, _ := defaultMask.Size()
+		expectedCidrNotation := IP + "/" + strconv.Itoa(ones)
+		return expectedCidrNotation, nil
+	}
+
+	// Format an IP address with network length(eg. /24) or decimal
+	// notation (eg. 255.255.255.0). Format an IP/CIDR with updated mask.
+	// An empty mask causes just the IP to be returned.
+	v1alpha3FormatIP := func(s string, mask string) (string, error) {
+		// Get the IP address for the input string.
+		ip, _, err := net.ParseCIDR(s)
+		if err != nil {
+			ip = net.ParseIP(s)
+			if ip == nil {
+				return "", fmt.Errorf("input IP address not valid")
+			}
+		}
+		// Store the IP as a string back into s.
+		s = ip.String()
+
+		// If no mask was provided then return just the IP.
+		if mask == "" {
+			return s, nil
+		}
+
+		// The provided mask is a network length.
+		if strings.HasPrefix(mask, "/") {
+			s += mask
+			if _, _, err := net.ParseCIDR(s); err != nil {
+				return "", err
+			}
+			return s, nil
+		}
+
+		// The provided mask is subnet mask.
+		maskIP := net.ParseIP(mask)
+		if maskIP == nil {
+			return "", fmt.Errorf("mask is an invalid IP")
+		}
+
+		maskIPBytes := maskIP.To4()
+		if len(maskIPBytes) == 0 {
+			maskIPBytes = maskIP.To16()
+		}
+
+		ipNet := net.IPNet{
+			IP:   ip,
+			Mask: net.IPMask(maskIPBytes),
+		}
+		s = ipNet.String()
+
+		// Validate the ipNet is an IP/CIDR
+		if _, _, err := net.ParseCIDR(s); err != nil {
+			return "", fmt.Errorf("invalid ip net: %s", s)
+		}
+
+		return s, nil
+	}
+
+	return template.FuncMap{
+		constants.V1alpha3FirstIP:           v1alpha3FirstIP,
+		constants.V1alpha3FirstNicMacAddr:   v1alpha3FirstNicMacAddr,
+		constants.V1alpha3FirstIPFromNIC:    v1alpha3FirstIPFromNIC,
+		constants.V1alpha3IPsFromNIC:        v1alpha3IPsFromNIC,
+		constants.V1alpha3FormatNameservers: v1alpha3FormatNameservers,
+		// These are more util function that we've conflated version namespaces.
+		constants.V1alpha3SubnetMask: v1alpha3SubnetMask,
+		constants.V1alpha3IP:         v1alpha3IP,
+		constants.V1alpha3FormatIP:   v1alpha3FormatIP,
+	}
+}
+
+// This is basically identical to v1a3TemplateFunctions.
+func v1a4TemplateFunctions(
+	networkStatusV1A4 vmopv1.NetworkStatus,
+	networkDevicesStatusV1A4 []vmopv1.NetworkDeviceStatus) map[string]any {
+
+	// Get the first IP address from the first NIC.
+	v1alpha4FirstIP := func() (string, error) {
+		if len(networkDevicesStatusV1A4) == 0 {
+			return "", errors.New("no available network device, check with VI admin")
+		}
+		return networkDevicesStatusV1A4[0].IPAddresses[0], nil
+	}
+
+	// Get the first NIC's MAC address.
+	v1alpha4FirstNicMacAddr := func() (string, error) {
+		if len(networkDevicesStatusV1A4) == 0 {
+			return "", errors.New("no available network device, check with VI admin")
+		}
+		return networkDevicesStatusV1A4[0].MacAddress, nil
+	}
+
+	// Get the first IP address from the ith NIC.
+	// if index out of bound, throw an error and template string won't be parsed
+	v1alpha4FirstIPFromNIC := func(index int) (string, error) {
+		if len(networkDevicesStatusV1A4) == 0 {
+			return "", errors.New("no available network device, check with VI admin")
+		}
+		if index >= len(networkDevicesStatusV1A4) {
+			return "", errors.New("index out of bound")
+		}
+		return networkDevicesStatusV1A4[index].IPAddresses[0], nil
+	}
+
+	// Get all IP addresses from the ith NIC.
+	// if index out of bound, throw an error and template string won't be parsed
+	v1alpha4IPsFromNIC := func(index int) ([]string, error) {
+		if len(networkDevicesStatusV1A4) == 0 {
+			return []string{""}, errors.New("no available network device, check with VI admin")
+		}
+		if index >= len(networkDevicesStatusV1A4) {
+			return []string{""}, errors.New("index out of bound")
+		}
+		return networkDevicesStatusV1A4[index].IPAddresses, nil
+	}
+
+	// Format the first occurred count of nameservers with specific delimiter
+	// A negative count number would mean format all nameservers
+	v1alpha4FormatNameservers := func(count int, delimiter string) (string, error) {
+		var nameservers []string
+		if len(networkStatusV1A4.Nameservers) == 0 {
+			return "", errors.New("no available nameservers, check with VI admin")
+		}
+		if count < 0 || count >= len(networkStatusV1A4.Nameservers) {
+			nameservers = networkStatusV1A4.Nameservers
+			return strings.Join(nameservers, delimiter), nil
+		}
+		nameservers = networkStatusV1A4.Nameservers[:count]
+		return strings.Join(nameservers, delimiter), nil
+	}
+
+	// Get subnet mask from a CIDR notation IP address and prefix length
+	// if IP address and prefix length not valid, throw an error and template string won't be parsed
+	v1alpha4SubnetMask := func(cidr string) (string, error) {
+		_, ipv4Net, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return "", err
+		}
+		netmask := fmt.Sprintf("%d.%d.%d.%d", ipv4Net.Mask[0], ipv4Net.Mask[1], ipv4Net.Mask[2], ipv4Net.Mask[3])
+		return netmask, nil
+	}
+
+	// Format an IP address with default netmask CIDR
+	// if IP not valid, throw an error and template string won't be parsed
+	v1alpha4IP := func(IP string) (string, error) {
+		if net.ParseIP(IP) == nil {
+			return "", errors.New("input IP address not valid")
+		}
+		defaultMask := net.ParseIP(IP).DefaultMask()
+		ones, _ := defaultMask.Size()
+		expectedCidrNotation := IP + "/" + strconv.Itoa(ones)
+		return expectedCidrNotation, nil
+	}
+
+	// Format an IP address with network length(eg. /24) or decimal
+	// notation (eg. 255.255.255.0). Format an IP/CIDR with updated mask.
+	// An empty mask causes just the IP to be returned.
+	v1alpha4FormatIP := func(s string, mask string) (string, error) {
+		// Get the IP address for the input string.
+		ip, _, err := net.ParseCIDR(s)
+		if err != nil {
+			ip = net.ParseIP(s)
+			if ip == nil {
+				return "", fmt.Errorf("input IP address not valid")
+			}
+		}
+		// Store the IP as a string back into s.
+		s = ip.String()
+
+		// If no mask was provided then return just the IP.
+		if mask == "" {
+			return s, nil
+		}
+
+		// The provided mask is a network length.
+		if strings.HasPrefix(mask, "/") {
+			s += mask
+			if _, _, err := net.ParseCIDR(s); err != nil {
+				return "", err
+			}
+			return s, nil
+		}
+
+		// The provided mask is subnet mask.
+		maskIP := net.ParseIP(mask)
+		if maskIP == nil {
+			return "", fmt.Errorf("mask is an invalid IP")
+		}
+
+		maskIPBytes := maskIP.To4()
+		if len(maskIPBytes) == 0 {
+			maskIPBytes = maskIP.To16()
+		}
+
+		ipNet := net.IPNet{
+			IP:   ip,
+			Mask: net.IPMask(maskIPBytes),
+		}
+		s = ipNet.String()
+
+		// Validate the ipNet is an IP/CIDR
+		if _, _, err := net.ParseCIDR(s); err != nil {
+			return "", fmt.Errorf("invalid ip net: %s", s)
+		}
+
+		return s, nil
+	}
+
+	return template.FuncMap{
+		constants.V1alpha4FirstIP:           v1alpha4FirstIP,
+		constants.V1alpha4FirstNicMacAddr:   v1alpha4FirstNicMacAddr,
+		constants.V1alpha4FirstIPFromNIC:    v1alpha4FirstIPFromNIC,
+		constants.V1alpha4IPsFromNIC:        v1alpha4IPsFromNIC,
+		constants.V1alpha4FormatNameservers: v1alpha4FormatNameservers,
+		// These are more util function that we've conflated version namespaces.
+		constants.V1alpha4SubnetMask: v1alpha4SubnetMask,
+		constants.V1alpha4IP:         v1alpha4IP,
+		constants.V1alpha4FormatIP:   v1alpha4FormatIP,
+	}
+}
+
+func v1a4SubnetTemplateFunctions() map[string]any {
+
+	// Get the network address of a CIDR, ex. 192.168.1.0 for 192.168.1.10/24.
+	v1alpha4SubnetNetwork := func(cidr string) (string, error) {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			return "", err
+		}
+		return prefix.Masked().Addr().String(), nil
+	}
+
+	// Get the broadcast address of an IPv4 CIDR, ex. 192.168.1.255 for
+	// 192.168.1.10/24.
+	v1alpha4SubnetBroadcast := func(cidr string) (string, error) {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			return "", err
+		}
+		if !prefix.Addr().Is4() {
+			return "", errors.New("broadcast address is only defined for IPv4")
+		}
+		return nthAddrInPrefix(prefix, -1)
+	}
+
+	// Get the prefix length of a CIDR, ex. 24 for 192.168.1.10/24.
+	v1alpha4SubnetPrefix := func(cidr string) (int, error) {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			return 0, err
+		}
+		return prefix.Bits(), nil
+	}
+
+	// Get the nth address of a CIDR, counting from the network address. A
+	// negative n counts back from the last address in the CIDR, ex. 192.168.1.1
+	// for 192.168.1.10/24 and 1, and 192.168.1.254 for 192.168.1.10/24 and -2.
+	v1alpha4SubnetNthHost := func(cidr string, n int) (string, error) {
+		prefix, err := netip.ParsePrefix(cidr)
+		if err != nil {
+			return "", err
+		}
+		return nthAddrInPrefix(prefix, n)
+	}
+
+	return template.FuncMap{
+		constants.V1alpha4SubnetNetwork:   v1alpha4SubnetNetwork,
+		constants.V1alpha4SubnetBroadcast: v1alpha4SubnetBroadcast,
+		constants.V1alpha4SubnetPrefix:    v1alpha4SubnetPrefix,
+		constants.V1alpha4SubnetNthHost:   v1alpha4SubnetNthHost,
+	}
+}
+
+// nthAddrInPrefix returns the nth address in the prefix, counting from the
+// network address. A negative n counts back from the last address.
+func nthAddrInPrefix(prefix netip.Prefix, n int) (string, error) {
+	var (
+		network = prefix.Masked().Addr()
+		bits    = network.BitLen()
+		size    = new(big.Int).Lsh(big.NewInt(1), uint(bits-prefix.Bits()))
+		offset  = big.NewInt(int64(n))
+	)
+
+	if n < 0 {
+		offset.Add(offset, size)
+	}
+	if offset.Sign() < 0 || offset.Cmp(size) >= 0 {
+		return "", fmt.Errorf("host %d is not in %s", n, prefix.Masked())
+	}
+
+	addr := new(big.Int).SetBytes(network.AsSlice())
+	addr.Add(addr, offset)
+
+	b := make([]byte, bits/8)
+	addr.FillBytes(b)
+	ip, _ := netip.AddrFromSlice(b)
+
+	return ip.String(), nil
+}
+
+func v1a4VMTemplateFunctions(
+	vm *vmopv1.VirtualMachine,
+	bsArgs *Args,
+	dryRun bool) map[string]any {
+
+	results := bsArgs.Interfaces
+
+	// Get the gateway of the ith NIC, preferring the IPv4 gateway.
+	v1alpha4GatewayFromNIC := func(index int) (string, error) {
+		if len(results) == 0 {
+			return "", errors.New("no available network device, check with VI admin")
+		}
+		if index < 0 || index >= len(results) {
+			return "", errors.New("index out of bound")
+		}
+		var gateway string
+		for _, ipConfig := range results[index].IPConfigs {
+			if ipConfig.Gateway == "" {
+				continue
+			}
+			if ipConfig.IsIPv4 {
+				return ipConfig.Gateway, nil
+			}
+			if gateway == "" {
+				gateway = ipConfig.Gateway
+			}
+		}
+		if gateway == "" {
+			return "", errors.New("no gateway for network device")
+		}
+		return gateway, nil
+	}
+
+	// Get the routes of the ith NIC.
+	v1alpha4RoutesFromNIC := func(index int) ([]vmopv1.VirtualMachineNetworkRouteSpec, error) {
+		if len(results) == 0 {
+			return nil, errors.New("no available network device, check with VI admin")
+		}
+		if index < 0 || index >= len(results) {
+			return nil, errors.New("index out of bound")
+		}
+		routes := make([]vmopv1.VirtualMachineNetworkRouteSpec, 0, len(results[index].Routes))
+		for _, r := range results[index].Routes {
+			routes = append(routes, vmopv1.VirtualMachineNetworkRouteSpec{
+				To:     r.To,
+				Via:    r.Via,
+				Metric: r.Metric,
+			})
+		}
+		return routes, nil
+	}
+
+	// Get the value of one of the VM's labels.
+	v1alpha4Label := func(key string) (string, error) {
+		v, ok := vm.Labels[key]
+		if !ok {
+			return "", fmt.Errorf("no label %q", key)
+		}
+		return v, nil
+	}
+
+	// Get the value of one of the VM's annotations.
+	v1alpha4Annotation := func(key string) (string, error) {
+		v, ok := vm.Annotations[key]
+		if !ok {
+			return "", fmt.Errorf("no annotation %q", key)
+		}
+		return v, nil
+	}
+
+	// Get the name of the VM's zone.
+	v1alpha4ZoneName := func() (string, error) {
+		if z := vm.Status.Zone; z != "" {
+			return z, nil
+		}
+		if z := vm.Labels[topology.KubernetesTopologyZoneLabelKey]; z != "" {
+			return z, nil
+		}
+		if dryRun {
+			// The zone is not known until the VM is placed.
+			return "", nil
+		}
+		return "", errors.New("no zone")
+	}
+
+	v1alpha4InstanceUUID := func() string {
+		return vm.Spec.InstanceUUID
+	}
+
+	v1alpha4BiosUUID := func() string {
+		return vm.Spec.BiosUUID
+	}
+
+	// Get the value of a key from a Secret referenced by the VM's bootstrap
+	// spec. Secrets that are not referenced by the VM cannot be read.
+	v1alpha4SecretValue := func(name, key string) (string, error) {
+		data, ok := templateSecretData(vm, bsArgs)[name]
+		if !ok {
+			return "", fmt.Errorf("secret %q is not referenced by the VM's bootstrap spec", name)
+		}
+		if dryRun {
+			return "", nil
+		}
+		v, ok := data[key]
+		if !ok {
+			return "", fmt.Errorf("required key %q not found in Secret %s", key, name)
+		}
+		return v, nil
+	}
+
+	return template.FuncMap{
+		constants.V1alpha4GatewayFromNIC: v1alpha4GatewayFromNIC,
+		constants.V1alpha4RoutesFromNIC:  v1alpha4RoutesFromNIC,
+		constants.V1alpha4Label:          v1alpha4Label,
+		constants.V1alpha4Annotation:     v1alpha4Annotation,
+		constants.V1alpha4ZoneName:       v1alpha4ZoneName,
+		constants.V1alpha4InstanceUUID:   v1alpha4InstanceUUID,
+		constants.V1alpha4BiosUUID:       v1alpha4BiosUUID,
+		constants.V1alpha4SecretValue:    v1alpha4SecretValue,
+	}
+}
+
+// templateSecretData returns the data of the Secrets referenced by the VM's
+// bootstrap spec, keyed by the name of the Secret.
+func templateSecretData(
+	vm *vmopv1.VirtualMachine,
+	bsArgs *Args) map[string]map[string]string {
+
+	out := map[string]map[string]string{}
+
+	bs := vm.Spec.Bootstrap
+	if bs == nil {
+		return out
+	}
+
+	if bs.CloudInit != nil && bs.CloudInit.RawCloudConfig != nil {
+		out[bs.CloudInit.RawCloudConfig.Name] = bsArgs.Data
+	}
+	if bs.Sysprep != nil && bs.Sysprep.RawSysprep != nil {
+		out[bs.Sysprep.RawSysprep.Name] = bsArgs.Data
+	}
+	if vApp := bs.VAppConfig; vApp != nil {
+		if vApp.RawProperties != "" {
+			out[vApp.RawProperties] = bsArgs.VAppData
+		}
+		for _, p := range vApp.Properties {
+			if from := p.Value.From; from != nil {
+				out[from.Name] = bsArgs.VAppExData[from.Name]
+			}
+		}
+	}
+
+	return out
+}
+
+// dryRunArgs returns the args used to render templates before a VM is
+// bootstrapped. The network results are derived from the VM's
+// spec, with placeholder addresses from the documentation range 192.0.2.0/24
+// for interfaces that do not have static addresses.
+func dryRunArgs(vm *vmopv1.VirtualMachine) Args {
+	var bsArgs Args
+
+	netSpec := vm.Spec.Network
+	if netSpec == nil || netSpec.Disabled {
+		return bsArgs
+	}
+
+	bsArgs.DNSServers = netSpec.Nameservers
+
+	for i, iface := range netSpec.Interfaces {
+		result := Interface{
+			MacAddress: fmt.Sprintf("00:50:56:00:00:%02x", i),
+		}
+
+		for _, addr := range iface.Addresses {
+			prefix, err := netip.ParsePrefix(addr)
+			if err != nil {
+				continue
+			}
+			ipConfig := IPConfig{
+				IPCIDR: addr,
+				IsIPv4: prefix.Addr().Is4(),
+			}
+			if ipConfig.IsIPv4 {
+				ipConfig.Gateway = iface.Gateway4
+			} else {
+				ipConfig.Gateway = iface.Gateway6
+			}
+			if ipConfig.Gateway == "None" {
+				ipConfig.Gateway = ""
+			}
+			result.IPConfigs = append(result.IPConfigs, ipConfig)
+		}
+
+		if len(result.IPConfigs) == 0 {
+			result.IPConfigs = []IPConfig{
+				{
+					IPCIDR:  fmt.Sprintf("192.0.2.%d/24", 10+i),
+					IsIPv4:  true,
+					Gateway: "192.0.2.1",
+				},
+			}
+		}
+
+		for _, r := range iface.Routes {
+			result.Routes = append(result.Routes, Route{
+				To:     r.To,
+				Via:    r.Via,
+				Metric: r.Metric,
+			})
+		}
+
+		if len(bsArgs.DNSServers) == 0 {
+			bsArgs.DNSServers = iface.Nameservers
+		}
+
+		bsArgs.Interfaces = append(bsArgs.Interfaces, result)
+	}
+
+	if len(bsArgs.DNSServers) == 0 {
+		bsArgs.DNSServers = []string{"192.0.2.53"}
+	}
+
+	return bsArgs
+}
@@ -0,0 +1,115 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package bootstraptemplate_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/api/v1alpha4/common"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/constants"
+	"github.com/vmware-tanzu/vm-operator/pkg/topology"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/bootstraptemplate"
+)
+
+var _ = Describe("NewDryRunRenderFunc", func() {
+
+	const (
+		ip2Cidr  = "192.168.10.48/24"
+		gateway2 = "192.168.10.1"
+	)
+
+	var (
+		vm *vmopv1.VirtualMachine
+	)
+
+	BeforeEach(func() {
+		vm = &vmopv1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "dummy-vm",
+				Namespace: "dummy-ns",
+			},
+			Spec: vmopv1.VirtualMachineSpec{
+				Bootstrap: &vmopv1.VirtualMachineBootstrapSpec{
+					VAppConfig: &vmopv1.VirtualMachineBootstrapVAppConfigSpec{
+						Properties: []common.KeyValueOrSecretKeySelectorPair{
+							{
+								Key: "my-key",
+								Value: common.ValueOrSecretKeySelector{
+									From: &common.SecretKeySelector{
+										Name: "my-secret",
+										Key:  "my-secret-key",
+									},
+								},
+							},
+						},
+					},
+				},
+				Network: &vmopv1.VirtualMachineNetworkSpec{
+					Interfaces: []vmopv1.VirtualMachineNetworkInterfaceSpec{
+						{
+							Name: "eth0",
+						},
+						{
+							Name:      "eth1",
+							Addresses: []string{ip2Cidr},
+							Gateway4:  gateway2,
+						},
+					},
+				},
+			},
+		}
+	})
+
+	DescribeTable("renders with placeholder data",
+		func(str string, expectedErr string) {
+			err := bootstraptemplate.NewDryRunRenderFunc(vm)("my-key", str)
+			if expectedErr == "" {
+				Expect(err).ToNot(HaveOccurred())
+			} else {
+				Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+			}
+		},
+		Entry("first_ip", "{{ "+constants.V1alpha4FirstIP+" }}", ""),
+		Entry("gateway", "{{ "+constants.V1alpha4GatewayFromNIC+" 1 }}", ""),
+		Entry("zone", "{{ "+constants.V1alpha4ZoneName+" }}", ""),
+		Entry("secret_value", "{{ "+constants.V1alpha4SecretValue+" \"my-secret\" \"not-my-key\" }}", ""),
+		Entry("nic_out_of_range", "{{ "+constants.V1alpha4FirstIPFromNIC+" 2 }}", "index out of bound"),
+		Entry("unknown_function", "{{ V1alpha4_NotAFunction }}", `function "V1alpha4_NotAFunction" not defined`),
+		Entry("secret_not_referenced", "{{ "+constants.V1alpha4SecretValue+" \"not-my-secret\" \"my-secret-key\" }}", "is not referenced"),
+	)
+})
+
+var _ = Describe("NewRenderFunc", func() {
+
+	var (
+		vm *vmopv1.VirtualMachine
+	)
+
+	BeforeEach(func() {
+		vm = &vmopv1.VirtualMachine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "dummy-vm",
+				Namespace: "dummy-ns",
+			},
+		}
+	})
+
+	It("returns an error if the zone is not known", func() {
+		_, err := bootstraptemplate.NewRenderFunc(vm, bootstraptemplate.Args{})(
+			"my-key", "{{ "+constants.V1alpha4ZoneName+" }}")
+		Expect(err).To(MatchError(ContainSubstring("no zone")))
+	})
+
+	It("returns the zone from the zone label", func() {
+		vm.Labels = map[string]string{topology.KubernetesTopologyZoneLabelKey: "my-zone"}
+		out, err := bootstraptemplate.NewRenderFunc(vm, bootstraptemplate.Args{})(
+			"my-key", "{{ "+constants.V1alpha4ZoneName+" }}")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(out).To(Equal("my-zone"))
+	})
+})
@@ -0,0 +1,48 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudinit
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/vmware-tanzu/vm-operator/pkg/util"
+)
+
+// UserDataSecretKeys are the Secret keys that in v1a1 we'd check for the
+// userdata. Specifically, CAPBK uses "value" for its key, while "user-data" is
+// the preferred key nowadays. The 'value' key lookup will eventually be
+// deprecated.
+var UserDataSecretKeys = []string{"user-data", "value"}
+
+// cloudbaseInitUserDataHeaders are the headers of the user data formats that
+// are only supported by Cloudbase-Init on Windows guests.
+var cloudbaseInitUserDataHeaders = []string{
+	"#ps1",
+	"#ps1_sysnative",
+	"#ps1_x86",
+	"rem cmd",
+}
+
+// IsCloudbaseInitUserData returns true if the userdata is in a format that is
+// only supported by Cloudbase-Init, ex. a PowerShell script that starts with
+// "#ps1_sysnative".
+func IsCloudbaseInitUserData(userdata string) (bool, error) {
+	if userdata == "" {
+		return false, nil
+	}
+
+	// Ensure the data is normalized first to plain-text.
+	plainText, err := util.TryToDecodeBase64Gzip([]byte(userdata))
+	if err != nil {
+		return false, fmt.Errorf("decoding cloud-init userdata failed: %w", err)
+	}
+
+	header, _, _ := strings.Cut(plainText, "\n")
+	header = strings.ToLower(strings.TrimSpace(header))
+
+	return slices.Contains(cloudbaseInitUserDataHeaders, header), nil
+}
@@ -0,0 +1,28 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudinit_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware-tanzu/vm-operator/pkg/util/cloudinit"
+)
+
+var _ = DescribeTable("IsCloudbaseInitUserData",
+	func(userdata string, expected bool) {
+		ok, err := cloudinit.IsCloudbaseInitUserData(userdata)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ok).To(Equal(expected))
+	},
+	Entry("empty", "", false),
+	Entry("cloud-config", "#cloud-config\nhostname: my-vm", false),
+	Entry("shell script", "#!/bin/sh\necho hello", false),
+	Entry("ps1", "#ps1\nWrite-Host hello", true),
+	Entry("ps1_sysnative", "#ps1_sysnative\r\nWrite-Host hello", true),
+	Entry("ps1_x86", "#PS1_X86\nWrite-Host hello", true),
+	Entry("cmd", "rem cmd\necho hello", true),
+	Entry("base64-encoded ps1_sysnative", "I3BzMV9zeXNuYXRpdmUKV3JpdGUtSG9zdCBoZWxsbw==", true),
+)
@@ -28,11 +28,24 @@ import (
 // prior to marshalling the structure to YAML and set inline within the struct
 // itself.
 type cloudConfig struct {
-	Timezone   string              `json:"timezone,omitempty" yaml:"timezone,omitempty"`
-	Users      *cloudConfigUsers   `json:"users,omitempty" yaml:"users,omitempty"`
-	RunCmd     []cloudConfigRunCmd `json:"runcmd,omitempty" yaml:"runcmd,omitempty"`
-	WriteFiles []writeFile         `json:"write_files,omitempty" yaml:"write_files,omitempty"`
-	SSHPwdAuth *bool               `json:"ssh_pwauth,omitempty" yaml:"ssh_pwauth,omitempty"`
+	Timezone                string               `json:"timezone,omitempty" yaml:"timezone,omitempty"`
+	Users                   *cloudConfigUsers    `json:"users,omitempty" yaml:"users,omitempty"`
+	RunCmd                  []cloudConfigRunCmd  `json:"runcmd,omitempty" yaml:"runcmd,omitempty"`
+	WriteFiles              []writeFile          `json:"write_files,omitempty" yaml:"write_files,omitempty"`
+	SSHPwdAuth              *bool                `json:"ssh_pwauth,omitempty" yaml:"ssh_pwauth,omitempty"`
+	BootCmd                 []cloudConfigRunCmd  `json:"bootcmd,omitempty" yaml:"bootcmd,omitempty"`
+	Packages                json.RawMessage      `json:"packages,omitempty" yaml:"packages,omitempty"`
+	PackageUpdate           *bool                `json:"package_update,omitempty" yaml:"package_update,omitempty"`
+	PackageUpgrade          *bool                `json:"package_upgrade,omitempty" yaml:"package_upgrade,omitempty"`
+	PackageRebootIfRequired *bool                `json:"package_reboot_if_required,omitempty" yaml:"package_reboot_if_required,omitempty"`
+	Apt                     *apt                 `json:"apt,omitempty" yaml:"apt,omitempty"`
+	YumRepos                map[string]yumRepo   `json:"yum_repos,omitempty" yaml:"yum_repos,omitempty"`
+	NTP                     *cloudinit.NTP       `json:"ntp,omitempty" yaml:"ntp,omitempty"`
+	CACerts                 *caCerts             `json:"ca_certs,omitempty" yaml:"ca_certs,omitempty"`
+	DiskSetup               map[string]diskSetup `json:"disk_setup,omitempty" yaml:"disk_setup,omitempty"`
+	FSSetup                 []cloudinit.FSSetup  `json:"fs_setup,omitempty" yaml:"fs_setup,omitempty"`
+	Mounts                  [][]string           `json:"mounts,omitempty" yaml:"mounts,omitempty"`
+	PowerState              *powerState          `json:"power_state,omitempty" yaml:"power_state,omitempty"`
 }
 
 type cloudConfigUsers struct {
@@ -81,6 +94,49 @@ type writeFile struct {
 	Permissions string `json:"permissions,omitempty" yaml:"permissions,omitempty"`
 }
 
+type apt struct {
+	PreserveSourcesList *bool                `json:"preserve_sources_list,omitempty" yaml:"preserve_sources_list,omitempty"`
+	Sources             map[string]aptSource `json:"sources,omitempty" yaml:"sources,omitempty"`
+}
+
+type aptSource struct {
+	Filename  string `json:"filename,omitempty" yaml:"filename,omitempty"`
+	Key       string `json:"key,omitempty" yaml:"key,omitempty"`
+	KeyID     string `json:"keyid,omitempty" yaml:"keyid,omitempty"`
+	KeyServer string `json:"keyserver,omitempty" yaml:"keyserver,omitempty"`
+	Source    string `json:"source,omitempty" yaml:"source,omitempty"`
+}
+
+type yumRepo struct {
+	BaseURL    string `json:"baseurl,omitempty" yaml:"baseurl,omitempty"`
+	Enabled    *bool  `json:"enabled,omitempty" yaml:"enabled,omitempty"`
+	GPGCheck   *bool  `json:"gpgcheck,omitempty" yaml:"gpgcheck,omitempty"`
+	GPGKey     string `json:"gpgkey,omitempty" yaml:"gpgkey,omitempty"`
+	MetaLink   string `json:"metalink,omitempty" yaml:"metalink,omitempty"`
+	MirrorList string `json:"mirrorlist,omitempty" yaml:"mirrorlist,omitempty"`
+	Name       string `json:"name,omitempty" yaml:"name,omitempty"`
+}
+
+type caCerts struct {
+	RemoveDefaults *bool    `json:"remove_defaults,omitempty" yaml:"remove_defaults,omitempty"`
+	Trusted        []string `json:"trusted,omitempty" yaml:"trusted,omitempty"`
+}
+
+type diskSetup struct {
+	Layout    json.RawMessage `json:"layout,omitempty" yaml:"layout,omitempty"`
+	Overwrite *bool           `json:"overwrite,omitempty" yaml:"overwrite,omitempty"`
+	TableType string          `json:"table_type,omitempty" yaml:"table_type,omitempty"`
+}
+
+// powerState differs from the API type in that the delay is a number unless
+// it is "now".
+type powerState struct {
+	Delay   any    `json:"delay,omitempty" yaml:"delay,omitempty"`
+	Message string `json:"message,omitempty" yaml:"message,omitempty"`
+	Mode    string `json:"mode" yaml:"mode"`
+	Timeout *int64 `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+}
+
 const emptyYAMLObject = "{}\n"
 
 // MarshalYAML marshals the provided CloudConfig and secret data to a valid,
@@ -105,31 +161,11 @@ func MarshalYAML(
 	}
 
 	if l := len(in.RunCmd); l > 0 {
-		var rawCommands []json.RawMessage
-		if err := json.Unmarshal(in.RunCmd, &rawCommands); err != nil {
+		cmds, err := unmarshalRunCmds(in.RunCmd)
+		if err != nil {
 			return "", err
 		}
-
-		out.RunCmd = make([]cloudConfigRunCmd, len(rawCommands))
-		for i := range rawCommands {
-
-			// First try to unmarshal the value into a string. If that does
-			// not work, try unmarshaling the data into a list of strings.
-			if err := json.Unmarshal(
-				rawCommands[i],
-				&out.RunCmd[i].singleString); err != nil {
-
-				out.RunCmd[i].singleString = ""
-
-				if err := json.Unmarshal(
-					rawCommands[i],
-					&out.RunCmd[i].listOfStrings); err != nil {
-
-					return "", err
-
-				}
-			}
-		}
+		out.RunCmd = cmds
 	}
 
 	if l := len(in.WriteFiles); l > 0 {
@@ -161,6 +197,91 @@ func MarshalYAML(
 		out.SSHPwdAuth = in.SSHPwdAuth
 	}
 
+	if l := len(in.BootCmd); l > 0 {
+		cmds, err := unmarshalRunCmds(in.BootCmd)
+		if err != nil {
+			return "", err
+		}
+		out.BootCmd = cmds
+	}
+
+	out.Packages = in.Packages
+	out.PackageUpdate = in.PackageUpdate
+	out.PackageUpgrade = in.PackageUpgrade
+	out.PackageRebootIfRequired = in.PackageRebootIfRequired
+
+	if v := in.Apt; v != nil {
+		out.Apt = &apt{PreserveSourcesList: v.PreserveSourcesList}
+		if l := len(v.Sources); l > 0 {
+			out.Apt.Sources = make(map[string]aptSource, l)
+			for i := range v.Sources {
+				out.Apt.Sources[v.Sources[i].Name] = aptSource{
+					Filename:  v.Sources[i].Filename,
+					Key:       v.Sources[i].Key,
+					KeyID:     v.Sources[i].KeyID,
+					KeyServer: v.Sources[i].KeyServer,
+					Source:    v.Sources[i].Source,
+				}
+			}
+		}
+	}
+
+	if l := len(in.YumRepos); l > 0 {
+		out.YumRepos = make(map[string]yumRepo, l)
+		for i := range in.YumRepos {
+			out.YumRepos[in.YumRepos[i].ID] = yumRepo{
+				BaseURL:    in.YumRepos[i].BaseURL,
+				Enabled:    in.YumRepos[i].Enabled,
+				GPGCheck:   in.YumRepos[i].GPGCheck,
+				GPGKey:     in.YumRepos[i].GPGKey,
+				MetaLink:   in.YumRepos[i].MetaLink,
+				MirrorList: in.YumRepos[i].MirrorList,
+				Name:       in.YumRepos[i].Name,
+			}
+		}
+	}
+
+	out.NTP = in.NTP
+
+	if v := in.CACerts; v != nil {
+		out.CACerts = &caCerts{RemoveDefaults: v.RemoveDefaults}
+		if l := len(v.Trusted); l > 0 {
+			out.CACerts.Trusted = make([]string, l)
+			for i := range v.Trusted {
+				// The certificates are derived from Secret and ConfigMap
+				// resources, otherwise they are specified directly.
+				if i < len(secret.CACerts) {
+					out.CACerts.Trusted[i] = secret.CACerts[i]
+				} else {
+					out.CACerts.Trusted[i] = v.Trusted[i].Value
+				}
+			}
+		}
+	}
+
+	if l := len(in.DiskSetup); l > 0 {
+		out.DiskSetup = make(map[string]diskSetup, l)
+		for i := range in.DiskSetup {
+			out.DiskSetup[in.DiskSetup[i].Device] = diskSetup{
+				Layout:    in.DiskSetup[i].Layout,
+				Overwrite: in.DiskSetup[i].Overwrite,
+				TableType: string(in.DiskSetup[i].TableType),
+			}
+		}
+	}
+
+	out.FSSetup = in.FSSetup
+	out.Mounts = in.Mounts
+
+	if v := in.PowerState; v != nil {
+		out.PowerState = &powerState{
+			Delay:   delayOrNow(v.Delay),
+			Message: v.Message,
+			Mode:    string(v.Mode),
+			Timeout: v.Timeout,
+		}
+	}
+
 	var w1 bytes.Buffer
 	fmt.Fprintln(&w1, "## template: jinja")
 	fmt.Fprintln(&w1, "#cloud-config")
@@ -190,6 +311,48 @@ func MarshalYAML(
 	return data, nil
 }
 
+func unmarshalRunCmds(in json.RawMessage) ([]cloudConfigRunCmd, error) {
+	var rawCommands []json.RawMessage
+	if err := json.Unmarshal(in, &rawCommands); err != nil {
+		return nil, err
+	}
+
+	out := make([]cloudConfigRunCmd, len(rawCommands))
+	for i := range rawCommands {
+
+		// First try to unmarshal the value into a string. If that does
+		// not work, try unmarshaling the data into a list of strings.
+		if err := json.Unmarshal(
+			rawCommands[i],
+			&out[i].singleString); err != nil {
+
+			out[i].singleString = ""
+
+			if err := json.Unmarshal(
+				rawCommands[i],
+				&out[i].listOfStrings); err != nil {
+
+				return nil, err
+
+			}
+		}
+	}
+
+	return out, nil
+}
+
+// delayOrNow returns the provided power state delay as an integer unless it
+// is "now". An empty value is returned as nil.
+func delayOrNow(in string) any {
+	if in == "" {
+		return nil
+	}
+	if i, err := strconv.Atoi(in); err == nil {
+		return i
+	}
+	return in
+}
+
 func copyUser(
 	in cloudinit.User,
 	out *user,
@@ -337,5 +500,58 @@ func GetSecretResources(
 		}
 	}
 
+	if in.CACerts != nil {
+		for i := range in.CACerts.Trusted {
+			if v := in.CACerts.Trusted[i].Secret; v != nil {
+				s, err := util.GetSecretResource(
+					ctx,
+					k8sClient,
+					secretNamespace,
+					v.Name)
+				if err != nil {
+					return nil, err
+				}
+				captureSecret(s, v.Name)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// GetConfigMapResources returns a list of the ConfigMap resources referenced
+// by the provided CloudConfig.
+func GetConfigMapResources(
+	ctx context.Context,
+	k8sClient ctrlclient.Client,
+	configMapNamespace string,
+	in cloudinit.CloudConfig) ([]ctrlclient.Object, error) {
+
+	if in.CACerts == nil {
+		return nil, nil
+	}
+
+	uniqueConfigMaps := map[string]struct{}{}
+	var result []ctrlclient.Object
+
+	for i := range in.CACerts.Trusted {
+		if v := in.CACerts.Trusted[i].ConfigMap; v != nil {
+			// Only return the ConfigMap if it has not already been captured.
+			if _, ok := uniqueConfigMaps[v.Name]; ok {
+				continue
+			}
+			cm, err := util.GetConfigMapResource(
+				ctx,
+				k8sClient,
+				configMapNamespace,
+				v.Name)
+			if err != nil {
+				return nil, err
+			}
+			result = append(result, cm)
+			uniqueConfigMaps[v.Name] = struct{}{}
+		}
+	}
+
 	return result, nil
 }
@@ -25,6 +25,11 @@ type CloudConfigSecretData struct {
 	// WriteFiles is a map where the key is the file's Path and the value is
 	// the file's contents.
 	WriteFiles map[string]string
+
+	// CACerts is a list of the trusted CA certificates in the same order as
+	// the CloudConfig's CACerts.Trusted list, including the ones specified
+	// directly in the CloudConfig.
+	CACerts []string
 }
 
 type CloudConfigUserSecretData struct {
@@ -77,6 +82,22 @@ func GetCloudConfigSecretData(
 		}
 	}
 
+	if in.CACerts != nil {
+		if l := len(in.CACerts.Trusted); l > 0 {
+			result.CACerts = make([]string, l)
+			for i := range in.CACerts.Trusted {
+				if err := getDataForCACert(
+					ctx,
+					k8sClient,
+					secretNamespace,
+					in.CACerts.Trusted[i],
+					&result.CACerts[i]); err != nil {
+					return CloudConfigSecretData{}, err
+				}
+			}
+		}
+	}
+
 	return result, nil
 }
 
@@ -148,3 +169,31 @@ func getSecretDataForWriteFile(
 		sks.Name, sks.Key,
 		out)
 }
+
+func getDataForCACert(
+	ctx context.Context,
+	k8sClient ctrlclient.Client,
+	namespace string,
+	in cloudinit.CACert,
+	out *string) error {
+
+	switch {
+	case in.Secret != nil:
+		return util.GetSecretData(
+			ctx,
+			k8sClient,
+			namespace,
+			in.Secret.Name, in.Secret.Key,
+			out)
+	case in.ConfigMap != nil:
+		return util.GetConfigMapData(
+			ctx,
+			k8sClient,
+			namespace,
+			in.ConfigMap.Name, in.ConfigMap.Key,
+			out)
+	default:
+		*out = in.Value
+		return nil
+	}
+}
@@ -320,4 +320,87 @@ var _ = Describe("CloudConfig GetCloudConfigSecretData", func() {
 				cloudinit.CloudConfigUserSecretData{Passwd: "password"}))
 		})
 	})
+
+	When("CloudConfig has CA certs", func() {
+		BeforeEach(func() {
+			cloudConfig = vmopv1cloudinit.CloudConfig{
+				CACerts: &vmopv1cloudinit.CACerts{
+					Trusted: []vmopv1cloudinit.CACert{
+						{
+							Value: "inline-cert",
+						},
+						{
+							Secret: &common.SecretKeySelector{
+								Name: "my-certs",
+								Key:  "ca.crt",
+							},
+						},
+						{
+							ConfigMap: &common.ConfigMapKeySelector{
+								Name: "my-certs",
+								Key:  "ca.crt",
+							},
+						},
+					},
+				},
+			}
+		})
+		When("The secret and configmap do not exist", func() {
+			It("Should return an error", func() {
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal(`secrets "my-certs" not found`))
+			})
+		})
+		When("The secret exists but the configmap does not", func() {
+			BeforeEach(func() {
+				initialObjects = []ctrlclient.Object{
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: secretNamespace,
+							Name:      "my-certs",
+						},
+						Data: map[string][]byte{
+							"ca.crt": []byte("secret-cert"),
+						},
+					},
+				}
+			})
+			It("Should return an error", func() {
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal(`configmaps "my-certs" not found`))
+			})
+		})
+		When("The secret and configmap exist", func() {
+			BeforeEach(func() {
+				initialObjects = []ctrlclient.Object{
+					&corev1.Secret{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: secretNamespace,
+							Name:      "my-certs",
+						},
+						Data: map[string][]byte{
+							"ca.crt": []byte("secret-cert"),
+						},
+					},
+					&corev1.ConfigMap{
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: secretNamespace,
+							Name:      "my-certs",
+						},
+						Data: map[string]string{
+							"ca.crt": "configmap-cert",
+						},
+					},
+				}
+			})
+			It("Should return valid data", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(cloudConfigSecretData.CACerts).To(Equal([]string{
+					"inline-cert",
+					"secret-cert",
+					"configmap-cert",
+				}))
+			})
+		})
+	})
 })
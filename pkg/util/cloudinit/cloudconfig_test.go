@@ -17,6 +17,7 @@ import (
 	vmopv1cloudinit "github.com/vmware-tanzu/vm-operator/api/v1alpha4/cloudinit"
 	"github.com/vmware-tanzu/vm-operator/api/v1alpha4/common"
 	"github.com/vmware-tanzu/vm-operator/pkg/util/cloudinit"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
 )
 
 var _ = Describe("CloudConfig MarshalYAML", func() {
@@ -353,6 +354,126 @@ var _ = Describe("CloudConfig MarshalYAML", func() {
 		})
 	})
 
+	When("CloudConfig has packages, repositories, storage and power state", func() {
+		BeforeEach(func() {
+			cloudConfig = vmopv1cloudinit.CloudConfig{
+				BootCmd:                 []byte(`["echo boot",["mkdir","-p","/data"]]`),
+				Packages:                []byte(`["nginx",["curl","8.5.0"]]`),
+				PackageUpdate:           ptr.To(true),
+				PackageUpgrade:          ptr.To(false),
+				PackageRebootIfRequired: ptr.To(true),
+				Apt: &vmopv1cloudinit.Apt{
+					PreserveSourcesList: ptr.To(true),
+					Sources: []vmopv1cloudinit.AptSource{
+						{
+							Name:      "example",
+							Source:    "deb http://archive.example.com $RELEASE main",
+							KeyID:     "ABCDEF01",
+							KeyServer: "keyserver.example.com",
+						},
+					},
+				},
+				YumRepos: []vmopv1cloudinit.YumRepo{
+					{
+						ID:       "example",
+						Name:     "Example",
+						BaseURL:  "https://yum.example.com/el9",
+						Enabled:  ptr.To(true),
+						GPGCheck: ptr.To(true),
+						GPGKey:   "https://yum.example.com/key.asc",
+					},
+				},
+				NTP: &vmopv1cloudinit.NTP{
+					Enabled:   ptr.To(true),
+					NTPClient: "chrony",
+					Servers:   []string{"ntp1.example.com"},
+					Pools:     []string{"pool.example.com"},
+				},
+				CACerts: &vmopv1cloudinit.CACerts{
+					RemoveDefaults: ptr.To(true),
+					Trusted: []vmopv1cloudinit.CACert{
+						{
+							Value: "inline-cert",
+						},
+						{
+							Secret: &common.SecretKeySelector{
+								Name: "my-certs",
+								Key:  "ca.crt",
+							},
+						},
+						{
+							ConfigMap: &common.ConfigMapKeySelector{
+								Name: "my-certs",
+								Key:  "ca.crt",
+							},
+						},
+					},
+				},
+				DiskSetup: []vmopv1cloudinit.DiskSetup{
+					{
+						Device:    "/dev/sdb",
+						TableType: vmopv1cloudinit.DiskSetupTableTypeGPT,
+						Layout:    []byte(`[50,[50,82]]`),
+						Overwrite: ptr.To(false),
+					},
+				},
+				FSSetup: []vmopv1cloudinit.FSSetup{
+					{
+						Device:     "/dev/sdb",
+						Partition:  "1",
+						Filesystem: "ext4",
+						Label:      "data",
+						ExtraOpts:  []string{"-E", "lazy_itable_init"},
+					},
+					{
+						Device:     "/dev/sdb",
+						Partition:  "auto",
+						Filesystem: "swap",
+					},
+				},
+				Mounts: [][]string{
+					{"/dev/sdb1", "/data", "ext4", "defaults,nofail", "0", "2"},
+				},
+				PowerState: &vmopv1cloudinit.PowerState{
+					Mode:    vmopv1cloudinit.PowerStateModeReboot,
+					Delay:   "5",
+					Message: "Rebooting",
+					Timeout: ptr.To[int64](60),
+				},
+			}
+			cloudConfigSecretData = cloudinit.CloudConfigSecretData{
+				CACerts: []string{
+					"inline-cert",
+					"secret-cert",
+					"configmap-cert",
+				},
+			}
+		})
+		It("Should return user data", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(data).To(Equal(cloudConfigWithModules))
+		})
+
+		When("The power state delay is now", func() {
+			BeforeEach(func() {
+				cloudConfig.PowerState.Delay = "now"
+			})
+			It("Should return user data", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(data).To(ContainSubstring("  delay: now\n"))
+			})
+		})
+
+		When("The packages are not a list", func() {
+			BeforeEach(func() {
+				cloudConfig.Packages = []byte(`"nginx"`)
+			})
+			It("Should return an error", func() {
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
 })
 
 var _ = Describe("CloudConfig GetSecretResources", func() {
@@ -509,6 +630,88 @@ var _ = Describe("CloudConfig GetSecretResources", func() {
 	})
 })
 
+var _ = Describe("CloudConfig GetConfigMapResources", func() {
+	var (
+		err                error
+		ctx                context.Context
+		k8sClient          ctrlclient.Client
+		initialObjects     []ctrlclient.Object
+		configMapNamespace string
+		cloudConfig        vmopv1cloudinit.CloudConfig
+		configMapResources []ctrlclient.Object
+	)
+
+	BeforeEach(func() {
+		err = nil
+		initialObjects = nil
+		ctx = context.Background()
+		configMapNamespace = "default"
+		cloudConfig = vmopv1cloudinit.CloudConfig{
+			CACerts: &vmopv1cloudinit.CACerts{
+				Trusted: []vmopv1cloudinit.CACert{
+					{
+						Secret: &common.SecretKeySelector{
+							Name: "my-secret-certs",
+							Key:  "ca.crt",
+						},
+					},
+					{
+						ConfigMap: &common.ConfigMapKeySelector{
+							Name: "my-certs",
+							Key:  "ca1.crt",
+						},
+					},
+					{
+						ConfigMap: &common.ConfigMapKeySelector{
+							Name: "my-certs",
+							Key:  "ca2.crt",
+						},
+					},
+				},
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		k8sClient = fake.NewClientBuilder().WithObjects(initialObjects...).Build()
+		configMapResources, err = cloudinit.GetConfigMapResources(
+			ctx,
+			k8sClient,
+			configMapNamespace,
+			cloudConfig)
+	})
+
+	When("The configmap does not exist", func() {
+		It("Should return an error", func() {
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal(`configmaps "my-certs" not found`))
+		})
+	})
+
+	When("The configmap exists", func() {
+		BeforeEach(func() {
+			initialObjects = []ctrlclient.Object{
+				&corev1.ConfigMap{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: configMapNamespace,
+						Name:      "my-certs",
+					},
+					Data: map[string]string{
+						"ca1.crt": "cert1",
+						"ca2.crt": "cert2",
+					},
+				},
+			}
+		})
+		It("Should return the configmap once", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(configMapResources).To(HaveLen(1))
+			Expect(configMapResources[0].GetNamespace()).To(Equal(configMapNamespace))
+			Expect(configMapResources[0].GetName()).To(Equal("my-certs"))
+		})
+	})
+})
+
 const cloudConfigWithNoDefaultUser = `## template: jinja
 #cloud-config
 
@@ -660,3 +863,78 @@ write_files:
   path: /doc
   permissions: "0644"
 `
+
+const cloudConfigWithModules = `## template: jinja
+#cloud-config
+
+apt:
+  preserve_sources_list: true
+  sources:
+    example:
+      keyid: ABCDEF01
+      keyserver: keyserver.example.com
+      source: deb http://archive.example.com $RELEASE main
+bootcmd:
+- echo boot
+- - mkdir
+  - -p
+  - /data
+ca_certs:
+  remove_defaults: true
+  trusted:
+  - inline-cert
+  - secret-cert
+  - configmap-cert
+disk_setup:
+  /dev/sdb:
+    layout:
+    - 50
+    - - 50
+      - 82
+    overwrite: false
+    table_type: gpt
+fs_setup:
+- device: /dev/sdb
+  extra_opts:
+  - -E
+  - lazy_itable_init
+  filesystem: ext4
+  label: data
+  partition: "1"
+- device: /dev/sdb
+  filesystem: swap
+  partition: auto
+mounts:
+- - /dev/sdb1
+  - /data
+  - ext4
+  - defaults,nofail
+  - "0"
+  - "2"
+ntp:
+  enabled: true
+  ntp_client: chrony
+  pools:
+  - pool.example.com
+  servers:
+  - ntp1.example.com
+package_reboot_if_required: true
+package_update: true
+package_upgrade: false
+packages:
+- nginx
+- - curl
+  - 8.5.0
+power_state:
+  delay: 5
+  message: Rebooting
+  mode: reboot
+  timeout: 60
+yum_repos:
+  example:
+    baseurl: https://yum.example.com/el9
+    enabled: true
+    gpgcheck: true
+    gpgkey: https://yum.example.com/key.asc
+    name: Example
+`
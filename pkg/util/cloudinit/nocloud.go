@@ -0,0 +1,63 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudinit
+
+import (
+	"github.com/vmware-tanzu/vm-operator/pkg/util/iso9660"
+)
+
+const (
+	// NoCloudVolumeID is the volume label the NoCloud datasource looks for
+	// when searching for a seed image.
+	NoCloudVolumeID = "cidata"
+
+	// NoCloudMetadataFileName is the name of the file in the seed image that
+	// contains the instance metadata.
+	NoCloudMetadataFileName = "meta-data"
+
+	// NoCloudUserdataFileName is the name of the file in the seed image that
+	// contains the user data.
+	NoCloudUserdataFileName = "user-data"
+
+	// NoCloudVendordataFileName is the name of the file in the seed image that
+	// contains the vendor data.
+	NoCloudVendordataFileName = "vendor-data"
+
+	// NoCloudNetworkConfigFileName is the name of the file in the seed image
+	// that contains the network configuration.
+	NoCloudNetworkConfigFileName = "network-config"
+)
+
+// NoCloudSeedISO returns an ISO image that may be used as a seed for the
+// cloud-init NoCloud datasource. The vendor data and network configuration are
+// omitted from the image if empty. For more information please refer to
+// https://cloudinit.readthedocs.io/en/latest/reference/datasources/nocloud.html.
+func NoCloudSeedISO(metadata, userdata, vendordata, networkConfig string) ([]byte, error) {
+	img, err := iso9660.NewImage(NoCloudVolumeID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The NoCloud datasource requires both the meta-data and user-data files,
+	// even if they are empty.
+	if err := img.AddFile(NoCloudMetadataFileName, []byte(metadata)); err != nil {
+		return nil, err
+	}
+	if err := img.AddFile(NoCloudUserdataFileName, []byte(userdata)); err != nil {
+		return nil, err
+	}
+	if vendordata != "" {
+		if err := img.AddFile(NoCloudVendordataFileName, []byte(vendordata)); err != nil {
+			return nil, err
+		}
+	}
+	if networkConfig != "" {
+		if err := img.AddFile(NoCloudNetworkConfigFileName, []byte(networkConfig)); err != nil {
+			return nil, err
+		}
+	}
+
+	return img.Bytes(), nil
+}
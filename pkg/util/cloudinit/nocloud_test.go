@@ -0,0 +1,36 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudinit_test
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware-tanzu/vm-operator/pkg/util/cloudinit"
+)
+
+var _ = Describe("NoCloudSeedISO", func() {
+	It("includes the metadata, userdata, vendordata and network config", func() {
+		data, err := cloudinit.NoCloudSeedISO("instance-id: my-vm", "#cloud-config", "#cloud-config\nhostname: vendor", "version: 2")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(bytes.Contains(data, []byte("instance-id: my-vm"))).To(BeTrue())
+		Expect(bytes.Contains(data, []byte("#cloud-config"))).To(BeTrue())
+		Expect(bytes.Contains(data, []byte("hostname: vendor"))).To(BeTrue())
+		Expect(bytes.Contains(data, []byte("version: 2"))).To(BeTrue())
+		Expect(bytes.Contains(data, []byte("VENDOR_D.;1"))).To(BeTrue())
+		Expect(bytes.Contains(data, []byte("NETWORK_.;1"))).To(BeTrue())
+	})
+
+	It("omits an empty vendordata and network config", func() {
+		data, err := cloudinit.NoCloudSeedISO("instance-id: my-vm", "", "", "")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(bytes.Contains(data, []byte("META_DAT.;1"))).To(BeTrue())
+		Expect(bytes.Contains(data, []byte("USER_DAT.;1"))).To(BeTrue())
+		Expect(bytes.Contains(data, []byte("VENDOR_D.;1"))).To(BeFalse())
+		Expect(bytes.Contains(data, []byte("NETWORK_.;1"))).To(BeFalse())
+	})
+})
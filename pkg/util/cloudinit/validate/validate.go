@@ -19,6 +19,10 @@ const (
 	invalidRunCmd           = "value must be a list"
 	invalidRunCmdElement    = "value must be a string or list of strings"
 	invalidWriteFileContent = "value must be a string, multi-line string, or SecretKeySelector"
+	invalidPackages         = "value must be a list"
+	invalidPackagesElement  = "value must be a string or a list of a package name and version"
+	invalidDiskLayout       = "value must be a boolean or a list of partition sizes or pairs of partition size and type"
+	invalidCACert           = "exactly one of configMap, secret, or value must be specified"
 )
 
 // CloudConfigJSONRawMessage returns any errors encountered when validating the
@@ -37,6 +41,10 @@ func CloudConfigJSONRawMessage(
 
 	allErrs = append(allErrs, validateRunCmds(fieldPath, in.RunCmd)...)
 	allErrs = append(allErrs, validateWriteFiles(fieldPath, in.WriteFiles)...)
+	allErrs = append(allErrs, validateBootCmds(fieldPath, in.BootCmd)...)
+	allErrs = append(allErrs, validatePackages(fieldPath, in.Packages)...)
+	allErrs = append(allErrs, validateCACerts(fieldPath, in.CACerts)...)
+	allErrs = append(allErrs, validateDiskSetup(fieldPath, in.DiskSetup)...)
 
 	return allErrs
 }
@@ -45,14 +53,26 @@ func validateRunCmds(
 	fieldPath *field.Path,
 	in json.RawMessage) field.ErrorList {
 
+	return validateCmds(fieldPath.Child("runcmds"), in)
+}
+
+func validateBootCmds(
+	fieldPath *field.Path,
+	in json.RawMessage) field.ErrorList {
+
+	return validateCmds(fieldPath.Child("bootcmd"), in)
+}
+
+func validateCmds(
+	fieldPath *field.Path,
+	in json.RawMessage) field.ErrorList {
+
 	if len(in) == 0 {
 		return nil
 	}
 
 	var allErrs field.ErrorList
 
-	fieldPath = fieldPath.Child("runcmds")
-
 	var rawCommands []json.RawMessage
 	if err := json.Unmarshal(in, &rawCommands); err != nil {
 		allErrs = append(
@@ -133,6 +153,170 @@ func validateWriteFiles(
 	return allErrs
 }
 
+func validatePackages(
+	fieldPath *field.Path,
+	in json.RawMessage) field.ErrorList {
+
+	if len(in) == 0 {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+
+	fieldPath = fieldPath.Child("packages")
+
+	var rawPackages []json.RawMessage
+	if err := json.Unmarshal(in, &rawPackages); err != nil {
+		allErrs = append(
+			allErrs,
+			field.Invalid(
+				fieldPath,
+				string(in),
+				invalidPackages))
+		return allErrs
+	}
+
+	for i := range rawPackages {
+		fieldPath := fieldPath.Index(i)
+
+		// First try to unmarshal the value into a string. If that does
+		// not work, try unmarshaling the data into a list of the package's
+		// name and version.
+		var singleString string
+		if err := json.Unmarshal(
+			rawPackages[i],
+			&singleString); err != nil {
+
+			var nameAndVersion []string
+			if err := json.Unmarshal(
+				rawPackages[i],
+				&nameAndVersion); err != nil || len(nameAndVersion) != 2 {
+
+				allErrs = append(
+					allErrs,
+					field.Invalid(
+						fieldPath,
+						string(rawPackages[i]),
+						invalidPackagesElement))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+func validateCACerts(
+	fieldPath *field.Path,
+	in *cloudinit.CACerts) field.ErrorList {
+
+	if in == nil {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+
+	fieldPath = fieldPath.Child("ca_certs", "trusted")
+
+	for i := range in.Trusted {
+		var n int
+		if in.Trusted[i].ConfigMap != nil {
+			n++
+		}
+		if in.Trusted[i].Secret != nil {
+			n++
+		}
+		if in.Trusted[i].Value != "" {
+			n++
+		}
+		if n != 1 {
+			allErrs = append(
+				allErrs,
+				field.Invalid(
+					fieldPath.Index(i),
+					in.Trusted[i],
+					invalidCACert))
+		}
+	}
+
+	return allErrs
+}
+
+func validateDiskSetup(
+	fieldPath *field.Path,
+	in []cloudinit.DiskSetup) field.ErrorList {
+
+	var allErrs field.ErrorList
+
+	fieldPath = fieldPath.Child("disk_setup")
+
+	for i := range in {
+		layout := in[i].Layout
+		if len(layout) == 0 {
+			continue
+		}
+
+		fieldPath := fieldPath.Key(in[i].Device).Child("layout")
+
+		// First try to unmarshal the value into a boolean. If that does
+		// not work, try unmarshaling the data into a list of partitions.
+		var singleBool bool
+		if err := json.Unmarshal(layout, &singleBool); err == nil {
+			continue
+		}
+
+		var rawPartitions []json.RawMessage
+		if err := json.Unmarshal(layout, &rawPartitions); err != nil {
+			allErrs = append(
+				allErrs,
+				field.Invalid(
+					fieldPath,
+					string(layout),
+					invalidDiskLayout))
+			continue
+		}
+
+		for j := range rawPartitions {
+			if !isDiskLayoutPartition(rawPartitions[j]) {
+				allErrs = append(
+					allErrs,
+					field.Invalid(
+						fieldPath.Index(j),
+						string(rawPartitions[j]),
+						invalidDiskLayout))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// isDiskLayoutPartition returns true if the provided data is either a
+// partition size or a pair of a partition size and partition type.
+func isDiskLayoutPartition(in json.RawMessage) bool {
+	var size int
+	if err := json.Unmarshal(in, &size); err == nil {
+		return true
+	}
+
+	var sizeAndType []json.RawMessage
+	if err := json.Unmarshal(in, &sizeAndType); err != nil ||
+		len(sizeAndType) != 2 {
+
+		return false
+	}
+	if err := json.Unmarshal(sizeAndType[0], &size); err != nil {
+		return false
+	}
+
+	// The partition type may be a number, ex. 82, or a string, ex. "8300".
+	var typeNum int
+	if err := json.Unmarshal(sizeAndType[1], &typeNum); err == nil {
+		return true
+	}
+	var typeStr string
+	return json.Unmarshal(sizeAndType[1], &typeStr) == nil
+}
+
 // CloudConfigYAML returns an error if the provided CloudConfig YAML is not
 // valid according to the CloudConfig schema.
 //
@@ -116,6 +116,111 @@ var _ = Describe("Validate CloudConfigJSONRawMessage", func() {
 			Expect(errs).To(HaveLen(0))
 		})
 	})
+
+	When("The CloudConfig has valid bootcmds, packages, CA certs and disk layouts", func() {
+		BeforeEach(func() {
+			cloudConfig.BootCmd = []byte(`["echo boot",["mkdir","-p","/data"]]`)
+			cloudConfig.Packages = []byte(`["nginx",["curl","8.5.0"]]`)
+			cloudConfig.CACerts = &vmopv1cloudinit.CACerts{
+				Trusted: []vmopv1cloudinit.CACert{
+					{
+						Value: "inline-cert",
+					},
+					{
+						ConfigMap: &common.ConfigMapKeySelector{
+							Name: "my-certs",
+							Key:  "ca.crt",
+						},
+					},
+				},
+			}
+			cloudConfig.DiskSetup = []vmopv1cloudinit.DiskSetup{
+				{
+					Device: "/dev/sdb",
+					Layout: []byte(`true`),
+				},
+				{
+					Device: "/dev/sdc",
+					Layout: []byte(`[33,[33,82],[34,"8300"]]`),
+				},
+			}
+		})
+		It("Should not return any errors", func() {
+			Expect(errs).To(HaveLen(0))
+		})
+	})
+
+	When("The CloudConfig's second bootcmd is an invalid value", func() {
+		BeforeEach(func() {
+			cloudConfig.BootCmd = []byte(`["echo boot",{"foo":"bar"}]`)
+		})
+		It("Should return a single error", func() {
+			Expect(errs).To(HaveLen(1))
+			Expect(errs.ToAggregate().Error()).To(Equal(
+				`spec.bootstrap.cloudInit.cloudConfig.bootcmd[1]: Invalid value: "{\"foo\":\"bar\"}": value must be a string or list of strings`))
+		})
+	})
+
+	When("The CloudConfig packages value is invalid", func() {
+		BeforeEach(func() {
+			cloudConfig.Packages = []byte(`"nginx"`)
+		})
+		It("Should return a single error", func() {
+			Expect(errs).To(HaveLen(1))
+			Expect(errs.ToAggregate().Error()).To(Equal(
+				`spec.bootstrap.cloudInit.cloudConfig.packages: Invalid value: "\"nginx\"": value must be a list`))
+		})
+	})
+
+	When("The CloudConfig's second package has too many values", func() {
+		BeforeEach(func() {
+			cloudConfig.Packages = []byte(`["nginx",["curl","8.5.0","extra"]]`)
+		})
+		It("Should return a single error", func() {
+			Expect(errs).To(HaveLen(1))
+			Expect(errs.ToAggregate().Error()).To(Equal(
+				`spec.bootstrap.cloudInit.cloudConfig.packages[1]: Invalid value: "[\"curl\",\"8.5.0\",\"extra\"]": value must be a string or a list of a package name and version`))
+		})
+	})
+
+	When("The CloudConfig has a CA cert with more than one source", func() {
+		BeforeEach(func() {
+			cloudConfig.CACerts = &vmopv1cloudinit.CACerts{
+				Trusted: []vmopv1cloudinit.CACert{
+					{
+						Value: "inline-cert",
+						Secret: &common.SecretKeySelector{
+							Name: "my-certs",
+							Key:  "ca.crt",
+						},
+					},
+					{},
+				},
+			}
+		})
+		It("Should return two errors", func() {
+			Expect(errs).To(HaveLen(2))
+			Expect(errs[0].Field).To(Equal("spec.bootstrap.cloudInit.cloudConfig.ca_certs.trusted[0]"))
+			Expect(errs[0].Detail).To(Equal("exactly one of configMap, secret, or value must be specified"))
+			Expect(errs[1].Field).To(Equal("spec.bootstrap.cloudInit.cloudConfig.ca_certs.trusted[1]"))
+		})
+	})
+
+	When("The CloudConfig has an invalid disk layout", func() {
+		BeforeEach(func() {
+			cloudConfig.DiskSetup = []vmopv1cloudinit.DiskSetup{
+				{
+					Device: "/dev/sdb",
+					Layout: []byte(`[50,[50,82,1]]`),
+				},
+			}
+		})
+		It("Should return a single error", func() {
+			Expect(errs).To(HaveLen(1))
+			Expect(errs.ToAggregate().Error()).To(Equal(
+				`spec.bootstrap.cloudInit.cloudConfig.disk_setup[/dev/sdb].layout[1]: Invalid value: "[50,82,1]": value must be a boolean or a list of partition sizes or pairs of partition size and type`))
+		})
+	})
 })
 
 var _ = Describe("Validate CloudConfigYAML", func() {
@@ -0,0 +1,150 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudinit
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"mime/multipart"
+	"net/textproto"
+	"slices"
+	"strings"
+
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/pkg/util"
+)
+
+// vendorDataBoundary is the boundary between the parts of multi-part
+// vendor-data. The boundary is constant so the same defaults always result in
+// the same vendor-data.
+const vendorDataBoundary = "==VM-OPERATOR-VENDOR-DATA=="
+
+// GetVendorData returns the Cloud-Init vendor-data from the
+// VirtualMachineBootstrapDefaults resources in the provided namespace. An
+// empty string is returned if there are no defaults.
+//
+// When there is more than one resource with Cloud-Init defaults, the
+// vendor-data is a multi-part MIME document with a part for each resource, in
+// the order of the resources' names. Cloud-Init merges the parts in order, so
+// the value of a key from a later part takes precedence over the same key from
+// an earlier part.
+func GetVendorData(
+	ctx context.Context,
+	k8sClient ctrlclient.Client,
+	namespace string) (string, error) {
+
+	var list vmopv1.VirtualMachineBootstrapDefaultsList
+	if err := k8sClient.List(
+		ctx,
+		&list,
+		ctrlclient.InNamespace(namespace)); err != nil {
+
+		return "", err
+	}
+
+	slices.SortFunc(list.Items, func(a, b vmopv1.VirtualMachineBootstrapDefaults) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	var parts []string
+	for i := range list.Items {
+		part, err := getVendorDataPart(ctx, k8sClient, list.Items[i])
+		if err != nil {
+			return "", fmt.Errorf(
+				"failed to get vendor-data from %s/%s: %w",
+				namespace, list.Items[i].Name, err)
+		}
+		if part != "" {
+			parts = append(parts, part)
+		}
+	}
+
+	return MarshalVendorData(parts...)
+}
+
+func getVendorDataPart(
+	ctx context.Context,
+	k8sClient ctrlclient.Client,
+	obj vmopv1.VirtualMachineBootstrapDefaults) (string, error) {
+
+	ci := obj.Spec.CloudInit
+	if ci == nil {
+		return "", nil
+	}
+
+	if cooked := ci.CloudConfig; cooked != nil {
+		secretData, err := GetCloudConfigSecretData(
+			ctx,
+			k8sClient,
+			obj.Namespace,
+			*cooked)
+		if err != nil {
+			return "", err
+		}
+		return MarshalYAML(*cooked, secretData)
+	}
+
+	if raw := ci.RawCloudConfig; raw != nil {
+		var data string
+		if err := util.GetSecretData(
+			ctx,
+			k8sClient,
+			obj.Namespace,
+			raw.Name, raw.Key,
+			&data); err != nil {
+
+			return "", err
+		}
+
+		// Ensure the data is plain-text so it may be combined with other
+		// parts.
+		return util.TryToDecodeBase64Gzip([]byte(data))
+	}
+
+	return "", nil
+}
+
+// MarshalVendorData returns the provided parts as vendor-data. A single part is
+// returned as-is, while multiple parts are returned as a multi-part MIME
+// document. The content type of each part is text/plain, which causes
+// Cloud-Init to infer the part's type from its content, ex. "#cloud-config".
+func MarshalVendorData(parts ...string) (string, error) {
+	switch len(parts) {
+	case 0:
+		return "", nil
+	case 1:
+		return parts[0], nil
+	}
+
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	if err := w.SetBoundary(vendorDataBoundary); err != nil {
+		return "", err
+	}
+
+	fmt.Fprintf(&buf, "Content-Type: multipart/mixed; boundary=%q\r\n", vendorDataBoundary)
+	fmt.Fprint(&buf, "MIME-Version: 1.0\r\n\r\n")
+
+	for i := range parts {
+		pw, err := w.CreatePart(textproto.MIMEHeader{
+			"Content-Type": {`text/plain; charset="utf-8"`},
+		})
+		if err != nil {
+			return "", err
+		}
+		if _, err := pw.Write([]byte(parts[i])); err != nil {
+			return "", err
+		}
+	}
+
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
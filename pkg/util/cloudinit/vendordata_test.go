@@ -0,0 +1,143 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package cloudinit_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	vmopv1cloudinit "github.com/vmware-tanzu/vm-operator/api/v1alpha4/cloudinit"
+	"github.com/vmware-tanzu/vm-operator/api/v1alpha4/common"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/cloudinit"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+var _ = Describe("GetVendorData", func() {
+	const namespace = "my-namespace"
+
+	var (
+		ctx            context.Context
+		initialObjects []ctrlclient.Object
+		vendorData     string
+		err            error
+	)
+
+	newDefaults := func(name string, spec vmopv1.VirtualMachineBootstrapDefaultsCloudInitSpec) *vmopv1.VirtualMachineBootstrapDefaults {
+		return &vmopv1.VirtualMachineBootstrapDefaults{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      name,
+			},
+			Spec: vmopv1.VirtualMachineBootstrapDefaultsSpec{
+				CloudInit: &spec,
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		initialObjects = nil
+	})
+
+	JustBeforeEach(func() {
+		k8sClient := builder.NewFakeClient(initialObjects...)
+		vendorData, err = cloudinit.GetVendorData(ctx, k8sClient, namespace)
+	})
+
+	When("there are no defaults", func() {
+		It("returns empty vendor-data", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(vendorData).To(BeEmpty())
+		})
+	})
+
+	When("there are defaults in another namespace", func() {
+		BeforeEach(func() {
+			obj := newDefaults("defaults", vmopv1.VirtualMachineBootstrapDefaultsCloudInitSpec{
+				CloudConfig: &vmopv1cloudinit.CloudConfig{Timezone: "UTC"},
+			})
+			obj.Namespace = "other-namespace"
+			initialObjects = append(initialObjects, obj)
+		})
+		It("returns empty vendor-data", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(vendorData).To(BeEmpty())
+		})
+	})
+
+	When("there is a single CloudConfig default", func() {
+		BeforeEach(func() {
+			initialObjects = append(initialObjects,
+				newDefaults("defaults", vmopv1.VirtualMachineBootstrapDefaultsCloudInitSpec{
+					CloudConfig: &vmopv1cloudinit.CloudConfig{Timezone: "UTC"},
+				}))
+		})
+		It("returns the cloud-config as-is", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(vendorData).To(Equal("## template: jinja\n#cloud-config\n\ntimezone: UTC\n"))
+		})
+	})
+
+	When("there are multiple defaults", func() {
+		BeforeEach(func() {
+			initialObjects = append(initialObjects,
+				newDefaults("b-defaults", vmopv1.VirtualMachineBootstrapDefaultsCloudInitSpec{
+					RawCloudConfig: &common.SecretKeySelector{
+						Name: "my-vendor-data",
+						Key:  "vendor-data",
+					},
+				}),
+				newDefaults("a-defaults", vmopv1.VirtualMachineBootstrapDefaultsCloudInitSpec{
+					CloudConfig: &vmopv1cloudinit.CloudConfig{Timezone: "UTC"},
+				}),
+				&corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Namespace: namespace,
+						Name:      "my-vendor-data",
+					},
+					Data: map[string][]byte{
+						"vendor-data": []byte("#cloud-config\nhostname: vendor\n"),
+					},
+				})
+		})
+		It("returns a multi-part document ordered by name", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(vendorData).To(Equal(
+				"Content-Type: multipart/mixed; boundary=\"==VM-OPERATOR-VENDOR-DATA==\"\r\n" +
+					"MIME-Version: 1.0\r\n\r\n" +
+					"--==VM-OPERATOR-VENDOR-DATA==\r\n" +
+					"Content-Type: text/plain; charset=\"utf-8\"\r\n\r\n" +
+					"## template: jinja\n#cloud-config\n\ntimezone: UTC\n" +
+					"\r\n--==VM-OPERATOR-VENDOR-DATA==\r\n" +
+					"Content-Type: text/plain; charset=\"utf-8\"\r\n\r\n" +
+					"#cloud-config\nhostname: vendor\n" +
+					"\r\n--==VM-OPERATOR-VENDOR-DATA==--\r\n"))
+		})
+	})
+
+	When("the raw cloud-config secret does not exist", func() {
+		BeforeEach(func() {
+			initialObjects = append(initialObjects,
+				newDefaults("defaults", vmopv1.VirtualMachineBootstrapDefaultsCloudInitSpec{
+					RawCloudConfig: &common.SecretKeySelector{
+						Name: "my-vendor-data",
+						Key:  "vendor-data",
+					},
+				}))
+		})
+		It("returns an error", func() {
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(Equal(
+				`failed to get vendor-data from my-namespace/defaults: secrets "my-vendor-data" not found`))
+		})
+	})
+})
@@ -0,0 +1,46 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package util
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+func GetConfigMapData(
+	ctx context.Context,
+	k8sClient ctrlclient.Client,
+	configMapNamespace, configMapName, configMapKey string,
+	out *string) error {
+
+	configMap, err := GetConfigMapResource(ctx, k8sClient, configMapNamespace, configMapName)
+	if err != nil {
+		return err
+	}
+	data := configMap.Data[configMapKey]
+	if len(data) == 0 {
+		return fmt.Errorf(
+			"no data found for key %q for configmap %s/%s",
+			configMapKey, configMapNamespace, configMapName)
+	}
+	*out = data
+	return nil
+}
+
+func GetConfigMapResource(
+	ctx context.Context,
+	k8sClient ctrlclient.Client,
+	configMapNamespace, configMapName string) (*corev1.ConfigMap, error) {
+
+	var configMap corev1.ConfigMap
+	key := ctrlclient.ObjectKey{Name: configMapName, Namespace: configMapNamespace}
+	if err := k8sClient.Get(ctx, key, &configMap); err != nil {
+		return nil, err
+	}
+	return &configMap, nil
+}
@@ -0,0 +1,201 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package ignition
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strings"
+)
+
+// butaneIgnitionVersions maps the supported Butane variants and versions to
+// the version of the Ignition config they are translated to.
+var butaneIgnitionVersions = map[string]map[string]string{
+	"fcos": {
+		"1.0.0": "3.0.0",
+		"1.1.0": "3.1.0",
+		"1.2.0": "3.2.0",
+		"1.3.0": "3.2.0",
+		"1.4.0": "3.3.0",
+		"1.5.0": "3.4.0",
+	},
+	"flatcar": {
+		"1.0.0": "3.3.0",
+		"1.1.0": "3.4.0",
+	},
+}
+
+// butaneSugarKeys are the Butane fields that do not have an Ignition
+// equivalent and require a full Butane translation.
+var butaneSugarKeys = []string{
+	"boot_device",
+	"grub",
+	"openshift",
+}
+
+// butaneKeyExceptions are the Butane keys whose Ignition key is not the
+// camel-cased Butane key.
+var butaneKeyExceptions = map[string]string{
+	"size_mib":  "sizeMiB",
+	"start_mib": "startMiB",
+}
+
+// translateButane translates a Butane config to an Ignition config. Butane
+// configs are a superset of Ignition configs, so the translation converts the
+// Butane keys to their Ignition equivalents and inlined file contents to data
+// URLs. An error is returned if the config uses a Butane feature that cannot
+// be translated without access to local files or variant-specific sugar,
+// ex. storage.trees or boot_device.
+func translateButane(c map[string]any) (map[string]any, error) {
+	variant, _ := c["variant"].(string)
+	version, _ := c["version"].(string)
+
+	versions, ok := butaneIgnitionVersions[variant]
+	if !ok {
+		return nil, fmt.Errorf("unsupported butane variant %q", variant)
+	}
+	ignitionVersion, ok := versions[version]
+	if !ok {
+		return nil, fmt.Errorf("unsupported butane version %q for variant %q", version, variant)
+	}
+
+	for _, k := range butaneSugarKeys {
+		if _, ok := c[k]; ok {
+			return nil, fmt.Errorf("butane field %s is not supported", k)
+		}
+	}
+
+	delete(c, "variant")
+	delete(c, "version")
+
+	if storage, ok := c["storage"].(map[string]any); ok {
+		if _, ok := storage["trees"]; ok {
+			return nil, fmt.Errorf("butane field storage.trees is not supported")
+		}
+		for _, fs := range asList(storage["filesystems"]) {
+			if _, ok := fs["with_mount_units"]; ok {
+				return nil, fmt.Errorf(
+					"butane field storage.filesystems.with_mount_units is not supported")
+			}
+		}
+		for i, f := range asList(storage["files"]) {
+			if err := translateButaneResource(f["contents"]); err != nil {
+				return nil, fmt.Errorf("storage.files[%d].contents: %w", i, err)
+			}
+			for j, a := range asList(f["append"]) {
+				if err := translateButaneResource(a); err != nil {
+					return nil, fmt.Errorf("storage.files[%d].append[%d]: %w", i, j, err)
+				}
+			}
+		}
+	}
+
+	ign, _ := c["ignition"].(map[string]any)
+	if ign == nil {
+		ign = map[string]any{}
+	}
+	if cfg, ok := ign["config"].(map[string]any); ok {
+		if err := translateButaneResource(cfg["replace"]); err != nil {
+			return nil, fmt.Errorf("ignition.config.replace: %w", err)
+		}
+		for i, m := range asList(cfg["merge"]) {
+			if err := translateButaneResource(m); err != nil {
+				return nil, fmt.Errorf("ignition.config.merge[%d]: %w", i, err)
+			}
+		}
+	}
+	if sec, ok := ign["security"].(map[string]any); ok {
+		if tls, ok := sec["tls"].(map[string]any); ok {
+			for i, ca := range asList(tls["certificate_authorities"]) {
+				if err := translateButaneResource(ca); err != nil {
+					return nil, fmt.Errorf(
+						"ignition.security.tls.certificate_authorities[%d]: %w", i, err)
+				}
+			}
+		}
+	}
+
+	out, _ := butaneKeysToIgnition(c).(map[string]any)
+	ign, _ = out["ignition"].(map[string]any)
+	if ign == nil {
+		ign = map[string]any{}
+		out["ignition"] = ign
+	}
+	ign["version"] = ignitionVersion
+
+	return out, nil
+}
+
+// translateButaneResource replaces the inline contents of a Butane resource
+// with a data URL source.
+func translateButaneResource(obj any) error {
+	r, ok := obj.(map[string]any)
+	if !ok {
+		return nil
+	}
+	if _, ok := r["local"]; ok {
+		return fmt.Errorf("butane field local is not supported")
+	}
+	inline, ok := r["inline"]
+	if !ok {
+		return nil
+	}
+	if _, ok := r["source"]; ok {
+		return fmt.Errorf("inline and source are mutually exclusive")
+	}
+	s, ok := inline.(string)
+	if !ok {
+		return fmt.Errorf("inline must be a string")
+	}
+	delete(r, "inline")
+	r["source"] = "data:;base64," + base64.StdEncoding.EncodeToString([]byte(s))
+	return nil
+}
+
+// butaneKeysToIgnition returns a copy of the object with the snake-cased
+// Butane keys converted to camel-cased Ignition keys. All of the keys in an
+// Ignition config are field names, so every key is converted.
+func butaneKeysToIgnition(obj any) any {
+	switch o := obj.(type) {
+	case map[string]any:
+		out := make(map[string]any, len(o))
+		for k, v := range o {
+			out[butaneKeyToIgnition(k)] = butaneKeysToIgnition(v)
+		}
+		return out
+	case []any:
+		out := make([]any, len(o))
+		for i := range o {
+			out[i] = butaneKeysToIgnition(o[i])
+		}
+		return out
+	default:
+		return obj
+	}
+}
+
+func butaneKeyToIgnition(k string) string {
+	if v, ok := butaneKeyExceptions[k]; ok {
+		return v
+	}
+	parts := strings.Split(k, "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
+
+func asList(obj any) []map[string]any {
+	l, _ := obj.([]any)
+	out := make([]map[string]any, 0, len(l))
+	for i := range l {
+		if m, ok := l[i].(map[string]any); ok {
+			out = append(out, m)
+		}
+	}
+	return out
+}
@@ -0,0 +1,87 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package ignition
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"sigs.k8s.io/yaml"
+
+	pkgutil "github.com/vmware-tanzu/vm-operator/pkg/util"
+)
+
+// ErrMissingVersion is returned when the config does not specify the Ignition
+// config version.
+var ErrMissingVersion = errors.New("ignition.version is required")
+
+// config is the subset of an Ignition config used to validate it.
+type config struct {
+	Ignition struct {
+		Version string `json:"version,omitempty"`
+	} `json:"ignition"`
+}
+
+// ToJSON returns the Ignition config as JSON from the provided data, which may
+// be plain-text, base64-encoded, or gzipped and base64-encoded, and either JSON
+// or YAML. A Butane config is translated to the equivalent Ignition config.
+func ToJSON(data string) (string, error) {
+	plainText, err := pkgutil.TryToDecodeBase64Gzip([]byte(data))
+	if err != nil {
+		return "", fmt.Errorf("failed to decode ignition config: %w", err)
+	}
+
+	// JSON is a subset of YAML, so this handles both formats.
+	jsonData, err := yaml.YAMLToJSON([]byte(plainText))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse ignition config: %w", err)
+	}
+
+	var obj map[string]any
+	if err := json.Unmarshal(jsonData, &obj); err != nil {
+		return "", fmt.Errorf("failed to parse ignition config: %w", err)
+	}
+	if _, ok := obj["variant"]; !ok {
+		return string(jsonData), nil
+	}
+
+	// Butane configs specify a variant and are translated to Ignition.
+	obj, err = translateButane(obj)
+	if err != nil {
+		return "", fmt.Errorf("failed to translate butane config: %w", err)
+	}
+	if jsonData, err = json.Marshal(obj); err != nil {
+		return "", fmt.Errorf("failed to translate butane config: %w", err)
+	}
+
+	return string(jsonData), nil
+}
+
+// Validate returns an error if the provided data is not a valid Ignition or
+// Butane config.
+func Validate(data string) error {
+	jsonData, err := ToJSON(data)
+	if err != nil {
+		return err
+	}
+
+	var c config
+	if err := json.Unmarshal([]byte(jsonData), &c); err != nil {
+		return fmt.Errorf("failed to parse ignition config: %w", err)
+	}
+
+	if c.Ignition.Version == "" {
+		return ErrMissingVersion
+	}
+
+	major, _, _ := strings.Cut(c.Ignition.Version, ".")
+	if major != "2" && major != "3" {
+		return fmt.Errorf("unsupported ignition.version %q", c.Ignition.Version)
+	}
+
+	return nil
+}
@@ -0,0 +1,102 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package ignition_test
+
+import (
+	"encoding/base64"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ignition"
+)
+
+var _ = Describe("Validate", func() {
+	DescribeTable("configs",
+		func(data string, expectedErr string) {
+			err := ignition.Validate(data)
+			if expectedErr == "" {
+				Expect(err).ToNot(HaveOccurred())
+			} else {
+				Expect(err).To(MatchError(ContainSubstring(expectedErr)))
+			}
+		},
+		Entry("json", `{"ignition":{"version":"3.4.0"}}`, ""),
+		Entry("yaml", "ignition:\n  version: 3.4.0\n", ""),
+		Entry("spec 2 json", `{"ignition":{"version":"2.3.0"}}`, ""),
+		Entry("base64", base64.StdEncoding.EncodeToString([]byte(`{"ignition":{"version":"3.4.0"}}`)), ""),
+		Entry("butane", "variant: fcos\nversion: 1.5.0\n", ""),
+		Entry("butane flatcar", "variant: flatcar\nversion: 1.0.0\n", ""),
+		Entry("butane unsupported variant", "variant: rhcos\nversion: 1.0.0\n", `unsupported butane variant "rhcos"`),
+		Entry("butane unsupported version", "variant: fcos\nversion: 9.0.0\n", `unsupported butane version "9.0.0"`),
+		Entry("butane local file", "variant: fcos\nversion: 1.5.0\nstorage:\n  files:\n  - path: /a\n    contents:\n      local: a\n", "local is not supported"),
+		Entry("butane trees", "variant: fcos\nversion: 1.5.0\nstorage:\n  trees:\n  - local: a\n", "storage.trees is not supported"),
+		Entry("butane sugar", "variant: fcos\nversion: 1.5.0\nboot_device:\n  mirror:\n    devices: [/dev/sda]\n", "boot_device is not supported"),
+		Entry("missing version", `{"ignition":{}}`, ignition.ErrMissingVersion.Error()),
+		Entry("unsupported version", `{"ignition":{"version":"1.0.0"}}`, `unsupported ignition.version "1.0.0"`),
+		Entry("invalid", `{"ignition":`, "failed to parse ignition config"),
+	)
+})
+
+var _ = Describe("ToJSON", func() {
+	It("returns an Ignition config as JSON", func() {
+		Expect(ignition.ToJSON("ignition:\n  version: 3.4.0\n")).To(MatchJSON(`{"ignition":{"version":"3.4.0"}}`))
+	})
+
+	It("translates a Butane config", func() {
+		const butane = `variant: flatcar
+version: 1.0.0
+passwd:
+  users:
+  - name: core
+    ssh_authorized_keys:
+    - ssh-ed25519 AAAA
+storage:
+  files:
+  - path: /etc/hostname
+    mode: 0644
+    contents:
+      inline: my-vm
+  disks:
+  - device: /dev/sdb
+    wipe_table: true
+    partitions:
+    - label: data
+      size_mib: 1024
+systemd:
+  units:
+  - name: hello.service
+    enabled: true
+    contents: |
+      [Service]
+      ExecStart=/usr/bin/echo hello
+`
+		Expect(ignition.ToJSON(butane)).To(MatchJSON(`{
+  "ignition": {"version": "3.3.0"},
+  "passwd": {
+    "users": [{"name": "core", "sshAuthorizedKeys": ["ssh-ed25519 AAAA"]}]
+  },
+  "storage": {
+    "files": [{
+      "path": "/etc/hostname",
+      "mode": 420,
+      "contents": {"source": "data:;base64,bXktdm0="}
+    }],
+    "disks": [{
+      "device": "/dev/sdb",
+      "wipeTable": true,
+      "partitions": [{"label": "data", "sizeMiB": 1024}]
+    }]
+  },
+  "systemd": {
+    "units": [{
+      "name": "hello.service",
+      "enabled": true,
+      "contents": "[Service]\nExecStart=/usr/bin/echo hello\n"
+    }]
+  }
+}`))
+	})
+})
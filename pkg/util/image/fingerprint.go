@@ -0,0 +1,74 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package image
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/vmware/govmomi/vapi/library"
+)
+
+// FingerprintPrefix is the prefix of an image's fingerprint.
+const FingerprintPrefix = "sha256:"
+
+// Fingerprint returns the fingerprint of an image from the files of the
+// Content Library item from which the image is derived. The fingerprint is
+// computed from the checksums of the item's OVF descriptor and of all of the
+// files that are cached when the image is deployed, i.e. its disks and NVRAM
+// file, so the same OVF in two libraries has the same fingerprint regardless
+// of the libraries, items, or file names.
+//
+// An empty string is returned if the files do not include an OVF descriptor
+// or if any of the above files do not have a SHA256 or SHA512 checksum, since
+// a weaker checksum may not be trusted to identify the same content.
+func Fingerprint(files []library.File) string {
+	var (
+		hasOVF  bool
+		entries []string
+	)
+
+	for _, f := range files {
+		var kind string
+		switch strings.ToLower(path.Ext(f.Name)) {
+		case ".ovf":
+			kind = "ovf"
+			hasOVF = true
+		case ".vmdk":
+			kind = "disk"
+		case ".nvram":
+			kind = "nvram"
+		default:
+			continue
+		}
+		if f.Checksum == nil || f.Checksum.Checksum == "" {
+			return ""
+		}
+
+		// The Content Library API defaults to SHA1 if the algorithm is not
+		// specified, and neither SHA1 nor MD5 are collision resistant.
+		algorithm := strings.ToUpper(f.Checksum.Algorithm)
+		if algorithm != "SHA256" && algorithm != "SHA512" {
+			return ""
+		}
+
+		entries = append(entries, strings.ToLower(
+			kind+":"+algorithm+":"+f.Checksum.Checksum))
+	}
+
+	if !hasOVF {
+		return ""
+	}
+
+	// Sort the entries so the fingerprint does not depend on the order in
+	// which the files are listed.
+	slices.Sort(entries)
+
+	h := sha256.Sum256([]byte(strings.Join(entries, "\n")))
+	return FingerprintPrefix + hex.EncodeToString(h[:])
+}
@@ -0,0 +1,162 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package image_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware/govmomi/vapi/library"
+
+	imgutil "github.com/vmware-tanzu/vm-operator/pkg/util/image"
+)
+
+var _ = Describe("Fingerprint", func() {
+	var (
+		files       []library.File
+		fingerprint string
+	)
+
+	BeforeEach(func() {
+		files = []library.File{
+			{
+				Name:     "photon.ovf",
+				Checksum: &library.Checksum{Algorithm: "SHA256", Checksum: "aaaa"},
+			},
+			{
+				Name:     "photon-disk1.vmdk",
+				Checksum: &library.Checksum{Algorithm: "SHA256", Checksum: "bbbb"},
+			},
+			{
+				Name:     "photon-disk2.vmdk",
+				Checksum: &library.Checksum{Algorithm: "SHA256", Checksum: "cccc"},
+			},
+			{
+				Name: "photon.mf",
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		fingerprint = imgutil.Fingerprint(files)
+	})
+
+	It("returns a fingerprint", func() {
+		Expect(fingerprint).To(HavePrefix(imgutil.FingerprintPrefix))
+		Expect(fingerprint).To(HaveLen(len(imgutil.FingerprintPrefix) + 64))
+	})
+
+	When("the files are in a different order and have different names", func() {
+		It("returns the same fingerprint", func() {
+			other := []library.File{
+				{
+					Name:     "disk-1.vmdk",
+					Checksum: &library.Checksum{Algorithm: "sha256", Checksum: "CCCC"},
+				},
+				{
+					Name:     "descriptor.OVF",
+					Checksum: &library.Checksum{Algorithm: "SHA256", Checksum: "aaaa"},
+				},
+				{
+					Name:     "disk-0.vmdk",
+					Checksum: &library.Checksum{Algorithm: "SHA256", Checksum: "bbbb"},
+				},
+			}
+			Expect(imgutil.Fingerprint(other)).To(Equal(fingerprint))
+		})
+	})
+
+	When("a disk has a different checksum", func() {
+		It("returns a different fingerprint", func() {
+			files[2].Checksum = &library.Checksum{Algorithm: "SHA256", Checksum: "dddd"}
+			Expect(imgutil.Fingerprint(files)).ToNot(Equal(fingerprint))
+		})
+	})
+
+	When("the OVF descriptor has a different checksum", func() {
+		It("returns a different fingerprint", func() {
+			files[0].Checksum = &library.Checksum{Algorithm: "SHA256", Checksum: "dddd"}
+			Expect(imgutil.Fingerprint(files)).ToNot(Equal(fingerprint))
+		})
+	})
+
+	When("there is an NVRAM file", func() {
+		BeforeEach(func() {
+			files = append(files, library.File{
+				Name:     "photon.nvram",
+				Checksum: &library.Checksum{Algorithm: "SHA256", Checksum: "eeee"},
+			})
+		})
+
+		It("returns a fingerprint that depends on the NVRAM file", func() {
+			Expect(fingerprint).To(HavePrefix(imgutil.FingerprintPrefix))
+			files[len(files)-1].Checksum = &library.Checksum{Algorithm: "SHA256", Checksum: "ffff"}
+			Expect(imgutil.Fingerprint(files)).ToNot(Equal(fingerprint))
+		})
+
+		When("the NVRAM file does not have a checksum", func() {
+			BeforeEach(func() {
+				files[len(files)-1].Checksum = nil
+			})
+			It("returns an empty string", func() {
+				Expect(fingerprint).To(BeEmpty())
+			})
+		})
+	})
+
+	When("a file has a SHA512 checksum", func() {
+		BeforeEach(func() {
+			files[1].Checksum = &library.Checksum{Algorithm: "SHA512", Checksum: "bbbb"}
+		})
+		It("returns a fingerprint", func() {
+			Expect(fingerprint).To(HavePrefix(imgutil.FingerprintPrefix))
+		})
+	})
+
+	When("a file has a SHA1 checksum", func() {
+		BeforeEach(func() {
+			files[1].Checksum = &library.Checksum{Algorithm: "SHA1", Checksum: "bbbb"}
+		})
+		It("returns an empty string", func() {
+			Expect(fingerprint).To(BeEmpty())
+		})
+	})
+
+	When("a file's checksum does not specify an algorithm", func() {
+		BeforeEach(func() {
+			files[0].Checksum = &library.Checksum{Checksum: "aaaa"}
+		})
+		It("returns an empty string since the algorithm defaults to SHA1", func() {
+			Expect(fingerprint).To(BeEmpty())
+		})
+	})
+
+	When("a disk does not have a checksum", func() {
+		BeforeEach(func() {
+			files[1].Checksum = nil
+		})
+		It("returns an empty string", func() {
+			Expect(fingerprint).To(BeEmpty())
+		})
+	})
+
+	When("there is no OVF descriptor", func() {
+		BeforeEach(func() {
+			files = files[1:]
+		})
+		It("returns an empty string", func() {
+			Expect(fingerprint).To(BeEmpty())
+		})
+	})
+
+	When("there are no files", func() {
+		BeforeEach(func() {
+			files = nil
+		})
+		It("returns an empty string", func() {
+			Expect(fingerprint).To(BeEmpty())
+		})
+	})
+})
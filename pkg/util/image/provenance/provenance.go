@@ -0,0 +1,181 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package provenance
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	pkgconst "github.com/vmware-tanzu/vm-operator/pkg/constants"
+)
+
+// RecordVersion is the version of the schema of a provenance record.
+const RecordVersion = "v1"
+
+var (
+	// ErrNoRecord is returned when an image does not have a provenance record.
+	ErrNoRecord = errors.New("image does not have a provenance record")
+
+	// ErrNotSigned is returned when an image's provenance record is not
+	// signed.
+	ErrNotSigned = errors.New("image provenance record is not signed")
+)
+
+// Record is the provenance record of an image published by a
+// VirtualMachinePublishRequest.
+type Record struct {
+	// Version is the version of the record's schema.
+	Version string `json:"version"`
+
+	// PublishRequest is the VirtualMachinePublishRequest that published the
+	// image.
+	PublishRequest ObjectRef `json:"publishRequest"`
+
+	// RequestedBy is the name of the user that created the
+	// VirtualMachinePublishRequest.
+	RequestedBy string `json:"requestedBy,omitempty"`
+
+	// PublishTime is the time the image was published.
+	PublishTime time.Time `json:"publishTime"`
+
+	// Source describes the VM from which the image was published.
+	Source Source `json:"source"`
+
+	// Image describes the published image.
+	Image Image `json:"image"`
+
+	// Disks are the content digests of the image's disks.
+	Disks []Disk `json:"disks,omitempty"`
+}
+
+// ObjectRef refers to a Kubernetes object.
+type ObjectRef struct {
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	UID       string `json:"uid,omitempty"`
+}
+
+// Source describes the VM from which an image was published.
+type Source struct {
+	// VirtualMachine is the VirtualMachine from which the image was published.
+	VirtualMachine ObjectRef `json:"virtualMachine"`
+
+	// Snapshot is the VirtualMachineSnapshot from which the image was
+	// published, if the image was published from a snapshot.
+	Snapshot *ObjectRef `json:"snapshot,omitempty"`
+
+	// UniqueID is the managed object ID of the vSphere VM.
+	UniqueID string `json:"uniqueID,omitempty"`
+
+	// InstanceUUID is the instance UUID of the vSphere VM.
+	InstanceUUID string `json:"instanceUUID,omitempty"`
+
+	// Image is the image from which the VM was deployed.
+	Image *vmopv1.VirtualMachineImageRef `json:"image,omitempty"`
+
+	// ClassName is the name of the VM's class.
+	ClassName string `json:"className,omitempty"`
+}
+
+// Image describes a published image.
+type Image struct {
+	// Name is the name of the VirtualMachineImage.
+	Name string `json:"name"`
+
+	// ProviderItemID is the ID of the image's provider item, ex. a content
+	// library item ID or a reference to an OCI artifact by digest.
+	ProviderItemID string `json:"providerItemID"`
+
+	// ProviderContentVersion is the content version of the image's provider
+	// item when the image was published.
+	ProviderContentVersion string `json:"providerContentVersion,omitempty"`
+}
+
+// Disk is the content digest of one of an image's disks.
+type Disk struct {
+	// Name is the name of the disk's file.
+	Name string `json:"name"`
+
+	// Digest is the digest of the disk's content in the form
+	// <algorithm>:<encoded>, ex. sha256:0123....
+	Digest string `json:"digest"`
+}
+
+// SetOnImage records the provenance record and its optional signature in the
+// annotations of the image.
+func SetOnImage(obj ctrlclient.Object, record []byte, signature string) {
+	annotations := obj.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[pkgconst.ImageProvenanceAnnotationKey] = string(record)
+	if signature != "" {
+		annotations[pkgconst.ImageProvenanceSignatureAnnotationKey] = signature
+	} else {
+		delete(annotations, pkgconst.ImageProvenanceSignatureAnnotationKey)
+	}
+	obj.SetAnnotations(annotations)
+}
+
+// GetFromImage returns the provenance record from the annotations of the
+// image. ErrNoRecord is returned if the image does not have a record.
+func GetFromImage(obj ctrlclient.Object) (Record, error) {
+	data, ok := obj.GetAnnotations()[pkgconst.ImageProvenanceAnnotationKey]
+	if !ok {
+		return Record{}, ErrNoRecord
+	}
+	var record Record
+	if err := json.Unmarshal([]byte(data), &record); err != nil {
+		return Record{}, fmt.Errorf(
+			"failed to unmarshal image provenance record: %w", err)
+	}
+	return record, nil
+}
+
+// VerifyImage verifies the image's provenance record is signed by one of the
+// provided keys and describes the image's current content. The verified
+// record is returned.
+func VerifyImage(
+	obj ctrlclient.Object,
+	status vmopv1.VirtualMachineImageStatus,
+	keys []PublicKey) (Record, error) {
+
+	record, err := GetFromImage(obj)
+	if err != nil {
+		return Record{}, err
+	}
+
+	signature, ok := obj.GetAnnotations()[pkgconst.ImageProvenanceSignatureAnnotationKey]
+	if !ok || signature == "" {
+		return Record{}, ErrNotSigned
+	}
+
+	data := obj.GetAnnotations()[pkgconst.ImageProvenanceAnnotationKey]
+	if err := Verify(keys, []byte(data), signature); err != nil {
+		return Record{}, err
+	}
+
+	// The signature only proves the record is authentic. The record must also
+	// describe the image's content, otherwise a signed record could be copied
+	// to another image, or the image's provider item could be updated after
+	// the record was signed.
+	if record.Image.ProviderItemID != status.ProviderItemID {
+		return Record{}, fmt.Errorf(
+			"image provenance record is for provider item %q, not %q",
+			record.Image.ProviderItemID, status.ProviderItemID)
+	}
+	if record.Image.ProviderContentVersion != status.ProviderContentVersion {
+		return Record{}, fmt.Errorf(
+			"image provenance record is for content version %q, not %q",
+			record.Image.ProviderContentVersion, status.ProviderContentVersion)
+	}
+
+	return record, nil
+}
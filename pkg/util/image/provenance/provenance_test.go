@@ -0,0 +1,137 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package provenance_test
+
+import (
+	"crypto/ecdsa"
+	"encoding/json"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	pkgconst "github.com/vmware-tanzu/vm-operator/pkg/constants"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/image/provenance"
+)
+
+var _ = Describe("VerifyImage", func() {
+	var (
+		key    *ecdsa.PrivateKey
+		keys   []provenance.PublicKey
+		record provenance.Record
+		vmi    *vmopv1.VirtualMachineImage
+		sign   bool
+	)
+
+	BeforeEach(func() {
+		key = newKey()
+		keys = []provenance.PublicKey{{Name: "trusted", Key: &key.PublicKey}}
+		sign = true
+
+		record = provenance.Record{
+			Version: provenance.RecordVersion,
+			PublishRequest: provenance.ObjectRef{
+				Namespace: "my-namespace",
+				Name:      "my-vmpub",
+				UID:       "my-vmpub-uid",
+			},
+			RequestedBy: "my-user",
+			PublishTime: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC),
+			Source: provenance.Source{
+				VirtualMachine: provenance.ObjectRef{
+					Namespace: "my-namespace",
+					Name:      "my-vm",
+				},
+				ClassName: "my-class",
+			},
+			Image: provenance.Image{
+				Name:                   "vmi-123",
+				ProviderItemID:         "my-item-id",
+				ProviderContentVersion: "2",
+			},
+			Disks: []provenance.Disk{
+				{Name: "my-vm-disk-0.vmdk", Digest: "sha256:abc"},
+			},
+		}
+
+		vmi = &vmopv1.VirtualMachineImage{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "my-namespace",
+				Name:      "vmi-123",
+			},
+			Status: vmopv1.VirtualMachineImageStatus{
+				ProviderItemID:         "my-item-id",
+				ProviderContentVersion: "2",
+			},
+		}
+	})
+
+	JustBeforeEach(func() {
+		data, err := json.Marshal(record)
+		Expect(err).ToNot(HaveOccurred())
+
+		var sig string
+		if sign {
+			sig, err = provenance.Sign(key, data)
+			Expect(err).ToNot(HaveOccurred())
+		}
+		provenance.SetOnImage(vmi, data, sig)
+	})
+
+	It("should return the verified record", func() {
+		r, err := provenance.VerifyImage(vmi, vmi.Status, keys)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(r).To(Equal(record))
+	})
+
+	When("the record is not signed", func() {
+		BeforeEach(func() {
+			sign = false
+		})
+		It("should return ErrNotSigned", func() {
+			_, err := provenance.VerifyImage(vmi, vmi.Status, keys)
+			Expect(err).To(MatchError(provenance.ErrNotSigned))
+			Expect(vmi.Annotations).ToNot(HaveKey(pkgconst.ImageProvenanceSignatureAnnotationKey))
+		})
+	})
+
+	When("the image does not have a record", func() {
+		It("should return ErrNoRecord", func() {
+			vmi.Annotations = nil
+			_, err := provenance.VerifyImage(vmi, vmi.Status, keys)
+			Expect(err).To(MatchError(provenance.ErrNoRecord))
+		})
+	})
+
+	When("the record is modified after it was signed", func() {
+		It("should return an error", func() {
+			vmi.Annotations[pkgconst.ImageProvenanceAnnotationKey] += " "
+			_, err := provenance.VerifyImage(vmi, vmi.Status, keys)
+			Expect(err).To(MatchError(
+				"signature is not valid for any of the trusted public keys"))
+		})
+	})
+
+	When("the record is for another provider item", func() {
+		It("should return an error", func() {
+			vmi.Status.ProviderItemID = "other-item-id"
+			_, err := provenance.VerifyImage(vmi, vmi.Status, keys)
+			Expect(err).To(MatchError(
+				`image provenance record is for provider item "my-item-id", not "other-item-id"`))
+		})
+	})
+
+	When("the provider item was updated after the record was signed", func() {
+		It("should return an error", func() {
+			vmi.Status.ProviderContentVersion = "3"
+			_, err := provenance.VerifyImage(vmi, vmi.Status, keys)
+			Expect(err).To(MatchError(
+				`image provenance record is for content version "2", not "3"`))
+		})
+	})
+})
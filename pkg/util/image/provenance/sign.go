@@ -0,0 +1,186 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package provenance
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// SigningKeySecretKey is the field in a Secret that contains the private key
+// used to sign provenance records.
+const SigningKeySecretKey = "key.pem"
+
+// PublicKey is a public key trusted to sign provenance records.
+type PublicKey struct {
+	// Name identifies the key, ex. the field in the Secret with the key.
+	Name string
+
+	Key *ecdsa.PublicKey
+}
+
+// ParsePrivateKey parses a PEM-encoded, unencrypted ECDSA private key in
+// either the SEC 1 or PKCS #8 form. Encrypted keys, such as the cosign.key
+// file generated by cosign, are not supported.
+func ParsePrivateKey(data []byte) (*ecdsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("failed to decode PEM private key")
+	}
+
+	switch block.Type {
+	case "EC PRIVATE KEY":
+		return x509.ParseECPrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+		ecKey, ok := key.(*ecdsa.PrivateKey)
+		if !ok {
+			return nil, fmt.Errorf("unsupported private key type %T", key)
+		}
+		return ecKey, nil
+	default:
+		return nil, fmt.Errorf("unsupported PEM block type %q", block.Type)
+	}
+}
+
+// ParsePublicKey parses a PEM-encoded ECDSA public key, ex. the output of
+// "openssl ec -pubout".
+func ParsePublicKey(data []byte) (*ecdsa.PublicKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("failed to decode PEM public key")
+	}
+	if block.Type != "PUBLIC KEY" {
+		return nil, fmt.Errorf("unsupported PEM block type %q", block.Type)
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	ecKey, ok := key.(*ecdsa.PublicKey)
+	if !ok {
+		return nil, fmt.Errorf("unsupported public key type %T", key)
+	}
+	return ecKey, nil
+}
+
+// Sign returns the base64-encoded, ASN.1 ECDSA signature of the SHA-256
+// digest of the payload. This is the same signature produced by
+// "cosign sign-blob", so it may be verified with "cosign verify-blob".
+func Sign(key *ecdsa.PrivateKey, payload []byte) (string, error) {
+	digest := sha256.Sum256(payload)
+	sig, err := ecdsa.SignASN1(rand.Reader, key, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign payload: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(sig), nil
+}
+
+// Verify returns nil if the signature of the payload was produced by one of
+// the provided keys.
+func Verify(keys []PublicKey, payload []byte, signature string) error {
+	if len(keys) == 0 {
+		return errors.New("no public keys are trusted to verify signatures")
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return fmt.Errorf("failed to decode signature: %w", err)
+	}
+
+	digest := sha256.Sum256(payload)
+	for _, k := range keys {
+		if ecdsa.VerifyASN1(k.Key, digest[:], sig) {
+			return nil
+		}
+	}
+
+	return errors.New("signature is not valid for any of the trusted public keys")
+}
+
+// GetSigningKey returns the private key from the key.pem field of the Secret.
+func GetSigningKey(
+	ctx context.Context,
+	k8sClient ctrlclient.Client,
+	namespace, secretName string) (*ecdsa.PrivateKey, error) {
+
+	var secret corev1.Secret
+	if err := k8sClient.Get(
+		ctx,
+		ctrlclient.ObjectKey{Namespace: namespace, Name: secretName},
+		&secret); err != nil {
+
+		return nil, fmt.Errorf(
+			"failed to get signing key secret %s/%s: %w",
+			namespace, secretName, err)
+	}
+
+	data, ok := secret.Data[SigningKeySecretKey]
+	if !ok {
+		return nil, fmt.Errorf(
+			"signing key secret %s/%s does not have the field %s",
+			namespace, secretName, SigningKeySecretKey)
+	}
+
+	key, err := ParsePrivateKey(data)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"failed to parse signing key from secret %s/%s: %w",
+			namespace, secretName, err)
+	}
+
+	return key, nil
+}
+
+// GetPublicKeys returns the public keys from each field of the Secret.
+func GetPublicKeys(
+	ctx context.Context,
+	k8sClient ctrlclient.Client,
+	namespace, secretName string) ([]PublicKey, error) {
+
+	if secretName == "" {
+		return nil, errors.New("no secret with public keys is configured")
+	}
+
+	var secret corev1.Secret
+	if err := k8sClient.Get(
+		ctx,
+		ctrlclient.ObjectKey{Namespace: namespace, Name: secretName},
+		&secret); err != nil {
+
+		return nil, fmt.Errorf(
+			"failed to get public keys secret %s/%s: %w",
+			namespace, secretName, err)
+	}
+
+	keys := make([]PublicKey, 0, len(secret.Data))
+	for name, data := range secret.Data {
+		key, err := ParsePublicKey(data)
+		if err != nil {
+			return nil, fmt.Errorf(
+				"failed to parse public key %s from secret %s/%s: %w",
+				name, namespace, secretName, err)
+		}
+		keys = append(keys, PublicKey{Name: name, Key: key})
+	}
+
+	sort.Slice(keys, func(i, j int) bool { return keys[i].Name < keys[j].Name })
+
+	return keys, nil
+}
@@ -0,0 +1,193 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package provenance_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/pem"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware-tanzu/vm-operator/pkg/util/image/provenance"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func newKey() *ecdsa.PrivateKey {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	Expect(err).ToNot(HaveOccurred())
+	return key
+}
+
+func encodePrivateKey(key *ecdsa.PrivateKey) []byte {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	Expect(err).ToNot(HaveOccurred())
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+}
+
+func encodePublicKey(key *ecdsa.PrivateKey) []byte {
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	Expect(err).ToNot(HaveOccurred())
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})
+}
+
+var _ = Describe("ParsePrivateKey", func() {
+	var key *ecdsa.PrivateKey
+
+	BeforeEach(func() {
+		key = newKey()
+	})
+
+	It("should parse a PKCS #8 key", func() {
+		k, err := provenance.ParsePrivateKey(encodePrivateKey(key))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(k.Equal(key)).To(BeTrue())
+	})
+
+	It("should parse a SEC 1 key", func() {
+		der, err := x509.MarshalECPrivateKey(key)
+		Expect(err).ToNot(HaveOccurred())
+		k, err := provenance.ParsePrivateKey(
+			pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(k.Equal(key)).To(BeTrue())
+	})
+
+	It("should return an error for an encrypted key", func() {
+		_, err := provenance.ParsePrivateKey(
+			pem.EncodeToMemory(&pem.Block{Type: "ENCRYPTED SIGSTORE PRIVATE KEY", Bytes: []byte("x")}))
+		Expect(err).To(MatchError(`unsupported PEM block type "ENCRYPTED SIGSTORE PRIVATE KEY"`))
+	})
+
+	It("should return an error for invalid PEM", func() {
+		_, err := provenance.ParsePrivateKey([]byte("invalid"))
+		Expect(err).To(MatchError("failed to decode PEM private key"))
+	})
+})
+
+var _ = Describe("Sign and Verify", func() {
+	var (
+		key     *ecdsa.PrivateKey
+		payload []byte
+	)
+
+	BeforeEach(func() {
+		key = newKey()
+		payload = []byte(`{"version":"v1"}`)
+	})
+
+	It("should verify a signature from a trusted key", func() {
+		sig, err := provenance.Sign(key, payload)
+		Expect(err).ToNot(HaveOccurred())
+
+		pub, err := provenance.ParsePublicKey(encodePublicKey(key))
+		Expect(err).ToNot(HaveOccurred())
+
+		keys := []provenance.PublicKey{
+			{Name: "other", Key: &newKey().PublicKey},
+			{Name: "trusted", Key: pub},
+		}
+		Expect(provenance.Verify(keys, payload, sig)).To(Succeed())
+	})
+
+	It("should not verify a signature from an untrusted key", func() {
+		sig, err := provenance.Sign(key, payload)
+		Expect(err).ToNot(HaveOccurred())
+
+		keys := []provenance.PublicKey{{Name: "other", Key: &newKey().PublicKey}}
+		Expect(provenance.Verify(keys, payload, sig)).To(MatchError(
+			"signature is not valid for any of the trusted public keys"))
+	})
+
+	It("should not verify a signature of a modified payload", func() {
+		sig, err := provenance.Sign(key, payload)
+		Expect(err).ToNot(HaveOccurred())
+
+		keys := []provenance.PublicKey{{Name: "trusted", Key: &key.PublicKey}}
+		Expect(provenance.Verify(keys, []byte(`{"version":"v2"}`), sig)).ToNot(Succeed())
+	})
+
+	It("should return an error when there are no keys", func() {
+		Expect(provenance.Verify(nil, payload, "")).To(MatchError(
+			"no public keys are trusted to verify signatures"))
+	})
+})
+
+var _ = Describe("GetSigningKey and GetPublicKeys", func() {
+	const (
+		namespace = "vmop-system"
+	)
+
+	var (
+		key    *ecdsa.PrivateKey
+		secret *corev1.Secret
+	)
+
+	BeforeEach(func() {
+		key = newKey()
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      "my-secret",
+			},
+		}
+	})
+
+	It("should return the signing key", func() {
+		secret.Data = map[string][]byte{
+			provenance.SigningKeySecretKey: encodePrivateKey(key),
+		}
+		k, err := provenance.GetSigningKey(
+			context.Background(), builder.NewFakeClient(secret), namespace, secret.Name)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(k.Equal(key)).To(BeTrue())
+	})
+
+	It("should return an error when the signing key field is missing", func() {
+		_, err := provenance.GetSigningKey(
+			context.Background(), builder.NewFakeClient(secret), namespace, secret.Name)
+		Expect(err).To(MatchError(
+			"signing key secret vmop-system/my-secret does not have the field key.pem"))
+	})
+
+	It("should return the public keys sorted by name", func() {
+		other := newKey()
+		secret.Data = map[string][]byte{
+			"b.pub": encodePublicKey(key),
+			"a.pub": encodePublicKey(other),
+		}
+		keys, err := provenance.GetPublicKeys(
+			context.Background(), builder.NewFakeClient(secret), namespace, secret.Name)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(keys).To(HaveLen(2))
+		Expect(keys[0].Name).To(Equal("a.pub"))
+		Expect(keys[0].Key.Equal(&other.PublicKey)).To(BeTrue())
+		Expect(keys[1].Name).To(Equal("b.pub"))
+		Expect(keys[1].Key.Equal(&key.PublicKey)).To(BeTrue())
+	})
+
+	It("should return an error when a public key is invalid", func() {
+		secret.Data = map[string][]byte{
+			"a.pub": []byte("invalid"),
+		}
+		_, err := provenance.GetPublicKeys(
+			context.Background(), builder.NewFakeClient(secret), namespace, secret.Name)
+		Expect(err).To(MatchError(
+			"failed to parse public key a.pub from secret vmop-system/my-secret: failed to decode PEM public key"))
+	})
+
+	It("should return an error when the secret name is empty", func() {
+		_, err := provenance.GetPublicKeys(
+			context.Background(), builder.NewFakeClient(), namespace, "")
+		Expect(err).To(MatchError("no secret with public keys is configured"))
+	})
+})
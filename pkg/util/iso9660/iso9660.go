@@ -0,0 +1,370 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package iso9660 writes small ISO 9660 images with Joliet extensions, such as
+// the seed images read by the cloud-init NoCloud datasource.
+package iso9660
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// SectorSize is the size of a logical sector in an ISO 9660 image.
+const SectorSize = 2048
+
+const (
+	// systemAreaSectors is the number of sectors at the start of the image
+	// that are reserved for the system area.
+	systemAreaSectors = 16
+
+	// maxJolietNameLen is the maximum number of characters in a Joliet file
+	// identifier.
+	maxJolietNameLen = 64
+
+	// maxVolumeIDLen is the maximum number of characters in a Joliet volume
+	// identifier.
+	maxVolumeIDLen = 16
+
+	volumeDescriptorPrimary       = 1
+	volumeDescriptorSupplementary = 2
+	volumeDescriptorTerminator    = 255
+
+	fileFlagDirectory = 0x02
+)
+
+// jolietEscapeSequence indicates the supplementary volume descriptor uses
+// UCS-2 Level 3.
+var jolietEscapeSequence = []byte{'%', '/', 'E'}
+
+type file struct {
+	name        string
+	primaryName []byte
+	jolietName  []byte
+	data        []byte
+}
+
+// Image is an ISO 9660 image with all of its files in the root directory.
+// The image does not include timestamps, so the same files always result in
+// the same image.
+type Image struct {
+	volumeID string
+	files    []file
+}
+
+// NewImage returns a new, empty image with the provided volume identifier.
+func NewImage(volumeID string) (*Image, error) {
+	if volumeID == "" {
+		return nil, fmt.Errorf("volume identifier is empty")
+	}
+	if len(volumeID) > maxVolumeIDLen {
+		return nil, fmt.Errorf(
+			"volume identifier %q exceeds %d characters", volumeID, maxVolumeIDLen)
+	}
+	return &Image{volumeID: volumeID}, nil
+}
+
+// AddFile adds a file with the provided name and data to the image's root
+// directory.
+func (i *Image) AddFile(name string, data []byte) error {
+	if !isValidFileName(name) {
+		return fmt.Errorf("invalid file name %q", name)
+	}
+	if len(utf16.Encode([]rune(name))) > maxJolietNameLen {
+		return fmt.Errorf("file name %q exceeds %d characters", name, maxJolietNameLen)
+	}
+
+	f := file{
+		name:        name,
+		primaryName: primaryFileName(name),
+		jolietName:  ucs2(name),
+		data:        data,
+	}
+
+	for _, ef := range i.files {
+		if ef.name == name {
+			return fmt.Errorf("file %q already exists", name)
+		}
+		if bytes.Equal(ef.primaryName, f.primaryName) {
+			return fmt.Errorf(
+				"file %q has the same ISO 9660 name as file %q", name, ef.name)
+		}
+	}
+
+	i.files = append(i.files, f)
+	return nil
+}
+
+// isValidFileName returns true if the name is a valid Joliet file identifier.
+// Joliet file identifiers may not include control characters or any of the
+// characters *, /, :, ;, ?, or \.
+func isValidFileName(name string) bool {
+	if name == "" || name == "." || name == ".." || !utf8.ValidString(name) {
+		return false
+	}
+	for _, r := range name {
+		if unicode.IsControl(r) || strings.ContainsRune("*/:;?\\", r) {
+			return false
+		}
+	}
+	return true
+}
+
+// Bytes returns the image.
+func (i *Image) Bytes() []byte {
+	primaryFiles := make([]*file, len(i.files))
+	jolietFiles := make([]*file, len(i.files))
+	for j := range i.files {
+		primaryFiles[j] = &i.files[j]
+		jolietFiles[j] = &i.files[j]
+	}
+	sort.Slice(primaryFiles, func(a, b int) bool {
+		return bytes.Compare(primaryFiles[a].primaryName, primaryFiles[b].primaryName) < 0
+	})
+	sort.Slice(jolietFiles, func(a, b int) bool {
+		return bytes.Compare(jolietFiles[a].jolietName, jolietFiles[b].jolietName) < 0
+	})
+
+	var (
+		// The volume descriptors are followed by the little and big-endian
+		// path tables for the primary and supplementary descriptors.
+		primaryLPathTableLBA = uint32(systemAreaSectors + 3)
+		primaryMPathTableLBA = primaryLPathTableLBA + 1
+		jolietLPathTableLBA  = primaryMPathTableLBA + 1
+		jolietMPathTableLBA  = jolietLPathTableLBA + 1
+		primaryRootLBA       = jolietMPathTableLBA + 1
+		primaryRootSize      = directorySize(primaryFiles, func(f *file) []byte { return f.primaryName })
+		jolietRootLBA        = primaryRootLBA + primaryRootSize/SectorSize
+		jolietRootSize       = directorySize(jolietFiles, func(f *file) []byte { return f.jolietName })
+		nextLBA              = jolietRootLBA + jolietRootSize/SectorSize
+		fileLBAs             = map[string]uint32{}
+	)
+
+	for _, f := range i.files {
+		if len(f.data) == 0 {
+			continue
+		}
+		fileLBAs[f.name] = nextLBA
+		nextLBA += sectors(uint32(len(f.data)))
+	}
+
+	totalSectors := nextLBA
+	img := make([]byte, int(totalSectors)*SectorSize)
+	sector := func(lba uint32) []byte {
+		return img[int(lba)*SectorSize : int(lba+1)*SectorSize]
+	}
+
+	copy(sector(systemAreaSectors), volumeDescriptor(
+		volumeDescriptorPrimary,
+		paddedString(strings.ToUpper(i.volumeID), 32),
+		nil,
+		totalSectors,
+		primaryLPathTableLBA,
+		primaryMPathTableLBA,
+		directoryRecord(primaryRootLBA, primaryRootSize, fileFlagDirectory, []byte{0}),
+		paddedString),
+	)
+	copy(sector(systemAreaSectors+1), volumeDescriptor(
+		volumeDescriptorSupplementary,
+		paddedUCS2(i.volumeID, 32),
+		jolietEscapeSequence,
+		totalSectors,
+		jolietLPathTableLBA,
+		jolietMPathTableLBA,
+		directoryRecord(jolietRootLBA, jolietRootSize, fileFlagDirectory, []byte{0}),
+		paddedUCS2),
+	)
+	terminator := sector(systemAreaSectors + 2)
+	terminator[0] = volumeDescriptorTerminator
+	copy(terminator[1:6], "CD001")
+	terminator[6] = 1
+
+	copy(sector(primaryLPathTableLBA), pathTable(primaryRootLBA, binary.LittleEndian))
+	copy(sector(primaryMPathTableLBA), pathTable(primaryRootLBA, binary.BigEndian))
+	copy(sector(jolietLPathTableLBA), pathTable(jolietRootLBA, binary.LittleEndian))
+	copy(sector(jolietMPathTableLBA), pathTable(jolietRootLBA, binary.BigEndian))
+
+	copy(img[int(primaryRootLBA)*SectorSize:], directory(
+		primaryRootLBA, primaryRootSize, primaryFiles, fileLBAs,
+		func(f *file) []byte { return f.primaryName }))
+	copy(img[int(jolietRootLBA)*SectorSize:], directory(
+		jolietRootLBA, jolietRootSize, jolietFiles, fileLBAs,
+		func(f *file) []byte { return f.jolietName }))
+
+	for _, f := range i.files {
+		if lba, ok := fileLBAs[f.name]; ok {
+			copy(img[int(lba)*SectorSize:], f.data)
+		}
+	}
+
+	return img
+}
+
+// volumeDescriptor returns a primary or supplementary volume descriptor.
+func volumeDescriptor(
+	descriptorType byte,
+	volumeID, escapeSequence []byte,
+	totalSectors, lPathTableLBA, mPathTableLBA uint32,
+	rootRecord []byte,
+	pad func(string, int) []byte) []byte {
+
+	d := make([]byte, SectorSize)
+	d[0] = descriptorType
+	copy(d[1:6], "CD001")
+	d[6] = 1
+	copy(d[8:40], pad("", 32))
+	copy(d[40:72], volumeID)
+	putBothEndian32(d[80:88], totalSectors)
+	copy(d[88:120], escapeSequence)
+	putBothEndian16(d[120:124], 1)
+	putBothEndian16(d[124:128], 1)
+	putBothEndian16(d[128:132], SectorSize)
+	putBothEndian32(d[132:140], uint32(len(pathTable(0, binary.LittleEndian))))
+	binary.LittleEndian.PutUint32(d[140:144], lPathTableLBA)
+	binary.BigEndian.PutUint32(d[148:152], mPathTableLBA)
+	copy(d[156:190], rootRecord)
+
+	// The volume set, publisher, data preparer and application identifiers,
+	// followed by the copyright, abstract and bibliographic file identifiers.
+	copy(d[190:702], pad("", 512))
+	copy(d[702:813], pad("", 111))
+
+	// The creation, modification, expiration and effective dates are not
+	// specified.
+	for off := 813; off < 881; off += 17 {
+		copy(d[off:off+16], "0000000000000000")
+	}
+
+	d[881] = 1
+	return d
+}
+
+// pathTable returns a path table that only contains the root directory.
+func pathTable(rootLBA uint32, order binary.ByteOrder) []byte {
+	t := make([]byte, 10)
+	t[0] = 1
+	order.PutUint32(t[2:6], rootLBA)
+	order.PutUint16(t[6:8], 1)
+	return t
+}
+
+// directory returns the records of the root directory, starting with the
+// records for itself and its parent.
+func directory(
+	lba, size uint32,
+	files []*file,
+	fileLBAs map[string]uint32,
+	name func(*file) []byte) []byte {
+
+	records := [][]byte{
+		directoryRecord(lba, size, fileFlagDirectory, []byte{0}),
+		directoryRecord(lba, size, fileFlagDirectory, []byte{1}),
+	}
+	for _, f := range files {
+		records = append(records,
+			directoryRecord(fileLBAs[f.name], uint32(len(f.data)), 0, name(f)))
+	}
+
+	var buf []byte
+	for _, r := range records {
+		// Directory records may not span sectors.
+		if free := SectorSize - len(buf)%SectorSize; len(r) > free {
+			buf = append(buf, make([]byte, free)...)
+		}
+		buf = append(buf, r...)
+	}
+	return buf
+}
+
+// directorySize returns the size of the root directory rounded up to a whole
+// number of sectors.
+func directorySize(files []*file, name func(*file) []byte) uint32 {
+	return sectors(uint32(len(directory(0, 0, files, nil, name)))) * SectorSize
+}
+
+// directoryRecord returns a directory record. The recording date is not
+// specified.
+func directoryRecord(lba, size uint32, flags byte, identifier []byte) []byte {
+	n := 33 + len(identifier)
+	if n%2 != 0 {
+		n++
+	}
+	r := make([]byte, n)
+	r[0] = byte(n)
+	putBothEndian32(r[2:10], lba)
+	putBothEndian32(r[10:18], size)
+	r[25] = flags
+	putBothEndian16(r[28:32], 1)
+	r[32] = byte(len(identifier))
+	copy(r[33:], identifier)
+	return r
+}
+
+// primaryFileName returns the ISO 9660 Level 1 file identifier for the
+// provided name, ex. "user-data" becomes "USER_DAT.;1".
+func primaryFileName(name string) []byte {
+	base, ext := name, ""
+	if idx := strings.LastIndex(name, "."); idx > 0 {
+		base, ext = name[:idx], name[idx+1:]
+	}
+	return []byte(dCharacters(base, 8) + "." + dCharacters(ext, 3) + ";1")
+}
+
+// dCharacters returns at most n characters of s, converted to the set of
+// characters allowed in ISO 9660 file identifiers.
+func dCharacters(s string, n int) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(s) {
+		if b.Len() == n {
+			break
+		}
+		if (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			b.WriteRune(r)
+		} else {
+			b.WriteByte('_')
+		}
+	}
+	return b.String()
+}
+
+func ucs2(s string) []byte {
+	u := utf16.Encode([]rune(s))
+	b := make([]byte, 2*len(u))
+	for i, c := range u {
+		binary.BigEndian.PutUint16(b[2*i:], c)
+	}
+	return b
+}
+
+func paddedString(s string, n int) []byte {
+	return []byte(s + strings.Repeat(" ", n-len(s)))
+}
+
+func paddedUCS2(s string, n int) []byte {
+	b := ucs2(s)
+	for len(b) < n {
+		b = append(b, 0, ' ')
+	}
+	return b
+}
+
+func sectors(size uint32) uint32 {
+	return (size + SectorSize - 1) / SectorSize
+}
+
+func putBothEndian16(b []byte, v uint16) {
+	binary.LittleEndian.PutUint16(b[0:2], v)
+	binary.BigEndian.PutUint16(b[2:4], v)
+}
+
+func putBothEndian32(b []byte, v uint32) {
+	binary.LittleEndian.PutUint32(b[0:4], v)
+	binary.BigEndian.PutUint32(b[4:8], v)
+}
@@ -0,0 +1,40 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package iso9660_test
+
+import (
+	"maps"
+	"testing"
+
+	"github.com/vmware-tanzu/vm-operator/pkg/util/iso9660"
+)
+
+// FuzzImage asserts the files added to an image can be read back from its
+// Joliet volume.
+func FuzzImage(f *testing.F) {
+	f.Add("user-data", []byte("#cloud-config"), "meta-data", []byte("instance-id: my-vm"))
+	f.Add("network-config", []byte{}, "vendor-data", make([]byte, 2*iso9660.SectorSize+1))
+	f.Add("ünïcödé", []byte("a"), "😀", []byte("b"))
+
+	f.Fuzz(func(t *testing.T, name1 string, data1 []byte, name2 string, data2 []byte) {
+		img, err := iso9660.NewImage("cidata")
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected := map[string]string{}
+		if err := img.AddFile(name1, data1); err == nil {
+			expected[name1] = string(data1)
+		}
+		if err := img.AddFile(name2, data2); err == nil {
+			expected[name2] = string(data2)
+		}
+
+		actual := readRootDirectory(img.Bytes(), 17, true)
+		if !maps.Equal(expected, actual) {
+			t.Fatalf("expected files %q, got %q", expected, actual)
+		}
+	})
+}
@@ -0,0 +1,221 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package iso9660_test
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"unicode/utf16"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware-tanzu/vm-operator/pkg/util/iso9660"
+)
+
+// readRootDirectory returns the files in the root directory of the volume
+// described by the volume descriptor in the given sector.
+func readRootDirectory(img []byte, sector int, joliet bool) map[string]string {
+	vd := img[sector*iso9660.SectorSize:]
+	rootLBA := binary.LittleEndian.Uint32(vd[156+2:])
+	rootSize := binary.LittleEndian.Uint32(vd[156+10:])
+
+	files := map[string]string{}
+	dir := img[int(rootLBA)*iso9660.SectorSize : int(rootLBA)*iso9660.SectorSize+int(rootSize)]
+	for off := 0; off < len(dir); {
+		n := int(dir[off])
+		if n == 0 {
+			// Skip the padding at the end of the sector.
+			off = (off/iso9660.SectorSize + 1) * iso9660.SectorSize
+			continue
+		}
+		r := dir[off : off+n]
+		off += n
+
+		ident := r[33 : 33+int(r[32])]
+		if len(ident) == 1 && (ident[0] == 0 || ident[0] == 1) {
+			continue
+		}
+
+		var name string
+		if joliet {
+			u := make([]uint16, len(ident)/2)
+			for i := range u {
+				u[i] = binary.BigEndian.Uint16(ident[2*i:])
+			}
+			name = string(utf16.Decode(u))
+		} else {
+			name = string(ident)
+		}
+
+		lba := binary.LittleEndian.Uint32(r[2:])
+		size := binary.LittleEndian.Uint32(r[10:])
+		files[name] = string(img[int(lba)*iso9660.SectorSize : int(lba)*iso9660.SectorSize+int(size)])
+	}
+
+	return files
+}
+
+var _ = Describe("Image", func() {
+
+	var (
+		img *iso9660.Image
+		err error
+	)
+
+	BeforeEach(func() {
+		img, err = iso9660.NewImage("cidata")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	Context("NewImage", func() {
+		It("returns an error for an empty volume identifier", func() {
+			_, err := iso9660.NewImage("")
+			Expect(err).To(MatchError("volume identifier is empty"))
+		})
+
+		It("returns an error for a long volume identifier", func() {
+			_, err := iso9660.NewImage(strings.Repeat("a", 17))
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Context("AddFile", func() {
+		It("returns an error for an invalid name", func() {
+			Expect(img.AddFile("", nil)).To(MatchError(`invalid file name ""`))
+			Expect(img.AddFile("a/b", nil)).To(MatchError(`invalid file name "a/b"`))
+		})
+
+		It("returns an error for a name that is not a valid Joliet name", func() {
+			Expect(img.AddFile("a:b", nil)).To(MatchError(`invalid file name "a:b"`))
+			Expect(img.AddFile("a\x00b", nil)).To(MatchError(`invalid file name "a\x00b"`))
+			Expect(img.AddFile("\xf2", nil)).To(MatchError(`invalid file name "\xf2"`))
+		})
+
+		It("returns an error for a duplicate name", func() {
+			Expect(img.AddFile("user-data", nil)).To(Succeed())
+			Expect(img.AddFile("user-data", nil)).To(MatchError(`file "user-data" already exists`))
+		})
+
+		It("returns an error for names that are the same in ISO 9660", func() {
+			Expect(img.AddFile("user-data", nil)).To(Succeed())
+			Expect(img.AddFile("user_data", nil)).To(MatchError(
+				`file "user_data" has the same ISO 9660 name as file "user-data"`))
+		})
+	})
+
+	Context("Bytes", func() {
+		var data []byte
+
+		BeforeEach(func() {
+			Expect(img.AddFile("user-data", []byte(strings.Repeat("u", 5000)))).To(Succeed())
+			Expect(img.AddFile("meta-data", []byte("instance-id: my-vm"))).To(Succeed())
+			Expect(img.AddFile("empty", nil)).To(Succeed())
+		})
+
+		JustBeforeEach(func() {
+			data = img.Bytes()
+		})
+
+		It("returns a whole number of sectors", func() {
+			Expect(len(data) % iso9660.SectorSize).To(BeZero())
+			Expect(binary.LittleEndian.Uint32(data[16*iso9660.SectorSize+80:])).To(
+				BeEquivalentTo(len(data) / iso9660.SectorSize))
+		})
+
+		It("has the volume descriptors", func() {
+			pvd := data[16*iso9660.SectorSize:]
+			Expect(pvd[0]).To(BeEquivalentTo(1))
+			Expect(string(pvd[1:6])).To(Equal("CD001"))
+			Expect(string(pvd[40:72])).To(Equal("CIDATA" + strings.Repeat(" ", 26)))
+
+			svd := data[17*iso9660.SectorSize:]
+			Expect(svd[0]).To(BeEquivalentTo(2))
+			Expect(string(svd[1:6])).To(Equal("CD001"))
+			Expect(string(svd[88:91])).To(Equal("%/E"))
+			Expect(svd[40:52]).To(Equal([]byte{0, 'c', 0, 'i', 0, 'd', 0, 'a', 0, 't', 0, 'a'}))
+
+			term := data[18*iso9660.SectorSize:]
+			Expect(term[0]).To(BeEquivalentTo(255))
+			Expect(string(term[1:6])).To(Equal("CD001"))
+		})
+
+		It("has the files in the primary volume", func() {
+			Expect(readRootDirectory(data, 16, false)).To(Equal(map[string]string{
+				"USER_DAT.;1": strings.Repeat("u", 5000),
+				"META_DAT.;1": "instance-id: my-vm",
+				"EMPTY.;1":    "",
+			}))
+		})
+
+		It("has the files in the Joliet volume", func() {
+			Expect(readRootDirectory(data, 17, true)).To(Equal(map[string]string{
+				"user-data": strings.Repeat("u", 5000),
+				"meta-data": "instance-id: my-vm",
+				"empty":     "",
+			}))
+		})
+
+		It("returns the same image for the same files", func() {
+			Expect(img.Bytes()).To(Equal(data))
+		})
+
+		When("the root directory spans multiple sectors", func() {
+			BeforeEach(func() {
+				for i := range 100 {
+					Expect(img.AddFile(fmt.Sprintf("file-%03d", i), []byte{byte(i)})).To(Succeed())
+				}
+			})
+
+			It("has the files in the Joliet volume", func() {
+				files := readRootDirectory(data, 17, true)
+				Expect(files).To(HaveLen(103))
+				Expect(files).To(HaveKeyWithValue("file-099", string([]byte{99})))
+			})
+		})
+
+		When("the image is read with bsdtar", func() {
+			var (
+				bsdtar string
+				dir    string
+			)
+
+			BeforeEach(func() {
+				var err error
+				if bsdtar, err = exec.LookPath("bsdtar"); err != nil {
+					Skip("bsdtar is not installed")
+				}
+				dir = GinkgoT().TempDir()
+
+				for i := range 100 {
+					Expect(img.AddFile(fmt.Sprintf("file-%03d.txt", i), []byte{byte(i)})).To(Succeed())
+				}
+			})
+
+			It("has the files", func() {
+				isoPath := filepath.Join(dir, "seed.iso")
+				Expect(os.WriteFile(isoPath, data, 0600)).To(Succeed())
+
+				out := filepath.Join(dir, "out")
+				Expect(os.Mkdir(out, 0700)).To(Succeed())
+				output, err := exec.Command(bsdtar, "-xf", isoPath, "-C", out).CombinedOutput()
+				Expect(err).ToNot(HaveOccurred(), string(output))
+
+				entries, err := os.ReadDir(out)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(entries).To(HaveLen(103))
+
+				Expect(os.ReadFile(filepath.Join(out, "user-data"))).To(BeEquivalentTo(strings.Repeat("u", 5000)))
+				Expect(os.ReadFile(filepath.Join(out, "meta-data"))).To(BeEquivalentTo("instance-id: my-vm"))
+				Expect(os.ReadFile(filepath.Join(out, "empty"))).To(BeEmpty())
+				Expect(os.ReadFile(filepath.Join(out, "file-099.txt"))).To(Equal([]byte{99}))
+			})
+		})
+	})
+})
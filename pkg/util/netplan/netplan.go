@@ -18,6 +18,18 @@ type Network = schema.NetworkConfig
 
 type Ethernet = schema.EthernetConfig
 
+type Bond = schema.BondConfig
+
+type BondParameters = schema.BondParameters
+
+type BondMode = schema.BondMode
+
+type Bridge = schema.BridgeConfig
+
+type BridgeParameters = schema.BridgeParameters
+
+type VLAN = schema.VLANConfig
+
 type Match = schema.MatchConfig
 
 type Nameserver = schema.NameserverConfig
@@ -0,0 +1,499 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// ErrNotFound is returned when a manifest or blob does not exist.
+var ErrNotFound = errors.New("not found")
+
+// IsNotFound returns true if the error indicates a manifest or blob does not
+// exist.
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// Credentials are the credentials used to authenticate to a registry. Empty
+// credentials mean the registry is accessed anonymously.
+type Credentials struct {
+	Username string
+	Password string
+}
+
+// Client is a client for the OCI distribution API.
+type Client struct {
+	httpClient  *http.Client
+	credentials Credentials
+	insecure    bool
+
+	mu     sync.Mutex
+	tokens map[string]string
+}
+
+// NewClient returns a new client. If insecure is true then the registry is
+// accessed over plain HTTP.
+func NewClient(credentials Credentials, insecure bool) *Client {
+	return &Client{
+		httpClient:  &http.Client{CheckRedirect: checkRedirect},
+		credentials: credentials,
+		insecure:    insecure,
+		tokens:      map[string]string{},
+	}
+}
+
+// Digest returns the digest of the provided data.
+func Digest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// PushBlob streams a blob to the repository of the provided reference and
+// returns its descriptor.
+func (c *Client) PushBlob(
+	ctx context.Context,
+	ref Reference,
+	mediaType string,
+	r io.Reader) (Descriptor, error) {
+
+	// Start the upload.
+	res, err := c.do(ctx, ref, true, http.MethodPost,
+		c.url(ref, "blobs/uploads/"), nil, nil)
+	if err != nil {
+		return Descriptor{}, err
+	}
+	if err := checkStatus(res, http.StatusAccepted, "start blob upload"); err != nil {
+		return Descriptor{}, err
+	}
+	location, err := c.location(res)
+	if err != nil {
+		return Descriptor{}, err
+	}
+
+	// Stream the blob while computing its digest and size.
+	w := &digestWriter{Hash: sha256.New()}
+	res, err = c.do(ctx, ref, true, http.MethodPatch,
+		location, io.TeeReader(r, w), map[string]string{
+			"Content-Type": "application/octet-stream",
+		})
+	if err != nil {
+		return Descriptor{}, err
+	}
+	if err := checkStatus(res, http.StatusAccepted, "upload blob"); err != nil {
+		return Descriptor{}, err
+	}
+	if location, err = c.location(res); err != nil {
+		return Descriptor{}, err
+	}
+
+	// Complete the upload.
+	desc := Descriptor{
+		MediaType: mediaType,
+		Digest:    "sha256:" + hex.EncodeToString(w.Sum(nil)),
+		Size:      w.size,
+	}
+	u, err := url.Parse(location)
+	if err != nil {
+		return Descriptor{}, err
+	}
+	q := u.Query()
+	q.Set("digest", desc.Digest)
+	u.RawQuery = q.Encode()
+
+	res, err = c.do(ctx, ref, true, http.MethodPut, u.String(), nil, nil)
+	if err != nil {
+		return Descriptor{}, err
+	}
+	if err := checkStatus(res, http.StatusCreated, "complete blob upload"); err != nil {
+		return Descriptor{}, err
+	}
+
+	return desc, nil
+}
+
+// GetBlob returns the content of a blob from the repository of the provided
+// reference. The caller must close the returned reader.
+func (c *Client) GetBlob(
+	ctx context.Context,
+	ref Reference,
+	digest string) (io.ReadCloser, error) {
+
+	res, err := c.do(ctx, ref, false, http.MethodGet,
+		c.url(ref, "blobs/"+digest), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	switch res.StatusCode {
+	case http.StatusOK:
+		return res.Body, nil
+	case http.StatusNotFound:
+		_ = res.Body.Close()
+		return nil, fmt.Errorf("blob %s: %w", digest, ErrNotFound)
+	default:
+		return nil, checkStatus(res, http.StatusOK, "get blob")
+	}
+}
+
+// PushManifest pushes a manifest to the provided reference and returns the
+// manifest's descriptor.
+func (c *Client) PushManifest(
+	ctx context.Context,
+	ref Reference,
+	manifest Manifest) (Descriptor, error) {
+
+	if manifest.SchemaVersion == 0 {
+		manifest.SchemaVersion = 2
+	}
+	if manifest.MediaType == "" {
+		manifest.MediaType = MediaTypeImageManifest
+	}
+
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return Descriptor{}, err
+	}
+
+	res, err := c.do(ctx, ref, true, http.MethodPut,
+		c.url(ref, "manifests/"+ref.manifestRef()),
+		bytes.NewReader(data), map[string]string{
+			"Content-Type": manifest.MediaType,
+		})
+	if err != nil {
+		return Descriptor{}, err
+	}
+	if err := checkStatus(res, http.StatusCreated, "push manifest"); err != nil {
+		return Descriptor{}, err
+	}
+
+	return Descriptor{
+		MediaType:    manifest.MediaType,
+		ArtifactType: manifest.ArtifactType,
+		Digest:       Digest(data),
+		Size:         int64(len(data)),
+		Annotations:  manifest.Annotations,
+	}, nil
+}
+
+// GetManifest returns the manifest for the provided reference and the
+// manifest's descriptor. ErrNotFound is returned if the manifest does not
+// exist.
+func (c *Client) GetManifest(
+	ctx context.Context,
+	ref Reference) (Manifest, Descriptor, error) {
+
+	res, err := c.do(ctx, ref, false, http.MethodGet,
+		c.url(ref, "manifests/"+ref.manifestRef()), nil, map[string]string{
+			"Accept": MediaTypeImageManifest,
+		})
+	if err != nil {
+		return Manifest{}, Descriptor{}, err
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+
+	switch res.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return Manifest{}, Descriptor{}, fmt.Errorf(
+			"manifest %s: %w", ref, ErrNotFound)
+	default:
+		return Manifest{}, Descriptor{}, newStatusError("get manifest", res)
+	}
+
+	data, err := io.ReadAll(res.Body)
+	if err != nil {
+		return Manifest{}, Descriptor{}, err
+	}
+
+	desc := Descriptor{
+		MediaType: res.Header.Get("Content-Type"),
+		Digest:    Digest(data),
+		Size:      int64(len(data)),
+	}
+	if ref.Digest != "" && ref.Digest != desc.Digest {
+		return Manifest{}, Descriptor{}, fmt.Errorf(
+			"manifest digest %s does not match reference %s",
+			desc.Digest, ref)
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return Manifest{}, Descriptor{}, fmt.Errorf(
+			"failed to unmarshal manifest %s: %w", ref, err)
+	}
+	desc.ArtifactType = manifest.ArtifactType
+	desc.Annotations = manifest.Annotations
+
+	return manifest, desc, nil
+}
+
+func (c *Client) url(ref Reference, path string) string {
+	scheme := "https"
+	if c.insecure {
+		scheme = "http"
+	}
+	return fmt.Sprintf("%s://%s/v2/%s/%s",
+		scheme, ref.Registry, ref.Repository, path)
+}
+
+// location returns the absolute URL from the Location header of a response.
+func (c *Client) location(res *http.Response) (string, error) {
+	loc := res.Header.Get("Location")
+	if loc == "" {
+		return "", fmt.Errorf(
+			"%s %s: missing Location header",
+			res.Request.Method, res.Request.URL)
+	}
+	u, err := res.Request.URL.Parse(loc)
+	if err != nil {
+		return "", err
+	}
+	return u.String(), nil
+}
+
+// do sends a request, authenticating to the registry if it responds with a
+// challenge. A request with a body that cannot be replayed is only sent once,
+// so the first request to a repository should not have a body.
+func (c *Client) do(
+	ctx context.Context,
+	ref Reference,
+	push bool,
+	method, rawURL string,
+	body io.Reader,
+	headers map[string]string) (*http.Response, error) {
+
+	scope := "repository:" + ref.Repository + ":pull"
+	if push {
+		scope += ",push"
+	}
+	tokenKey := ref.Registry + "/" + scope
+
+	newRequest := func() (*http.Request, error) {
+		req, err := http.NewRequestWithContext(ctx, method, rawURL, body)
+		if err != nil {
+			return nil, err
+		}
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		if req.URL.Host != ref.Registry {
+			// The credentials are only for the registry, so they are not
+			// sent to another host, ex. an upload Location on blob storage.
+			return req, nil
+		}
+		c.mu.Lock()
+		token := c.tokens[tokenKey]
+		c.mu.Unlock()
+		switch {
+		case token != "":
+			req.Header.Set("Authorization", "Bearer "+token)
+		case c.credentials.Username != "":
+			req.SetBasicAuth(c.credentials.Username, c.credentials.Password)
+		}
+		return req, nil
+	}
+
+	req, err := newRequest()
+	if err != nil {
+		return nil, err
+	}
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusUnauthorized || req.URL.Host != ref.Registry {
+		return res, nil
+	}
+
+	challenge := res.Header.Get("WWW-Authenticate")
+	if (body != nil && req.GetBody == nil) ||
+		!strings.HasPrefix(strings.ToLower(challenge), "bearer ") {
+
+		return res, nil
+	}
+	_ = res.Body.Close()
+
+	token, err := c.getToken(ctx, challenge, scope)
+	if err != nil {
+		return nil, err
+	}
+	c.mu.Lock()
+	c.tokens[tokenKey] = token
+	c.mu.Unlock()
+
+	if req.GetBody != nil {
+		if body, err = req.GetBody(); err != nil {
+			return nil, err
+		}
+	}
+	if req, err = newRequest(); err != nil {
+		return nil, err
+	}
+	return c.httpClient.Do(req)
+}
+
+// checkRedirect removes the credentials from a redirect to a host other than
+// the one of the original request, ex. when a registry redirects a blob
+// download to blob storage.
+func checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= 10 {
+		return errors.New("stopped after 10 redirects")
+	}
+	if req.URL.Host != via[0].URL.Host {
+		req.Header.Del("Authorization")
+	}
+	return nil
+}
+
+// getToken gets a bearer token from the realm in a challenge.
+func (c *Client) getToken(
+	ctx context.Context,
+	challenge, scope string) (string, error) {
+
+	params := parseChallenge(challenge[len("bearer "):])
+	realm := params["realm"]
+	if realm == "" {
+		return "", fmt.Errorf("invalid challenge %q", challenge)
+	}
+	u, err := url.Parse(realm)
+	if err != nil {
+		return "", err
+	}
+	q := u.Query()
+	if v := params["service"]; v != "" {
+		q.Set("service", v)
+	}
+	if v := params["scope"]; v != "" {
+		scope = v
+	}
+	q.Set("scope", scope)
+	u.RawQuery = q.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return "", err
+	}
+	if c.credentials.Username != "" {
+		req.SetBasicAuth(c.credentials.Username, c.credentials.Password)
+	}
+	res, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+	if res.StatusCode != http.StatusOK {
+		return "", newStatusError("get token", res)
+	}
+
+	var obj struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&obj); err != nil {
+		return "", fmt.Errorf("failed to decode token: %w", err)
+	}
+	if obj.Token != "" {
+		return obj.Token, nil
+	}
+	if obj.AccessToken != "" {
+		return obj.AccessToken, nil
+	}
+	return "", errors.New("token response does not include a token")
+}
+
+// parseChallenge parses the comma-separated key="value" parameters of a
+// challenge.
+func parseChallenge(s string) map[string]string {
+	params := map[string]string{}
+	for len(s) > 0 {
+		s = strings.TrimLeft(s, ", ")
+		i := strings.Index(s, "=")
+		if i < 0 {
+			break
+		}
+		k := strings.ToLower(strings.TrimSpace(s[:i]))
+		s = s[i+1:]
+
+		var v string
+		if strings.HasPrefix(s, `"`) {
+			j := strings.Index(s[1:], `"`)
+			if j < 0 {
+				v, s = s[1:], ""
+			} else {
+				v, s = s[1:j+1], s[j+2:]
+			}
+		} else if j := strings.Index(s, ","); j >= 0 {
+			v, s = s[:j], s[j:]
+		} else {
+			v, s = s, ""
+		}
+		params[k] = v
+	}
+	return params
+}
+
+type digestWriter struct {
+	hash.Hash
+	size int64
+}
+
+func (w *digestWriter) Write(p []byte) (int, error) {
+	w.size += int64(len(p))
+	return w.Hash.Write(p)
+}
+
+// StatusError is returned when the registry responds with an unexpected
+// status code.
+type StatusError struct {
+	Op         string
+	StatusCode int
+	Message    string
+}
+
+func (e StatusError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("failed to %s: %d %s",
+			e.Op, e.StatusCode, http.StatusText(e.StatusCode))
+	}
+	return fmt.Sprintf("failed to %s: %d %s: %s",
+		e.Op, e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+}
+
+// checkStatus closes the body of a response and returns an error if the
+// response does not have the expected status code.
+func checkStatus(res *http.Response, statusCode int, op string) error {
+	defer func() {
+		_ = res.Body.Close()
+	}()
+	if res.StatusCode != statusCode {
+		return newStatusError(op, res)
+	}
+	return nil
+}
+
+func newStatusError(op string, res *http.Response) error {
+	data, _ := io.ReadAll(io.LimitReader(res.Body, 1024))
+	return StatusError{
+		Op:         op,
+		StatusCode: res.StatusCode,
+		Message:    strings.TrimSpace(string(data)),
+	}
+}
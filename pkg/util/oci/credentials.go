@@ -0,0 +1,99 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// GetCredentials returns the credentials for the provided registry from a
+// Secret of type kubernetes.io/dockerconfigjson or kubernetes.io/basic-auth.
+// Empty credentials are returned if secretName is empty.
+func GetCredentials(
+	ctx context.Context,
+	k8sClient ctrlclient.Client,
+	namespace, secretName, registry string) (Credentials, error) {
+
+	if secretName == "" {
+		return Credentials{}, nil
+	}
+
+	var secret corev1.Secret
+	if err := k8sClient.Get(
+		ctx,
+		ctrlclient.ObjectKey{Namespace: namespace, Name: secretName},
+		&secret); err != nil {
+
+		return Credentials{}, fmt.Errorf(
+			"failed to get credentials secret %s/%s: %w",
+			namespace, secretName, err)
+	}
+
+	switch secret.Type {
+	case corev1.SecretTypeBasicAuth:
+		return Credentials{
+			Username: string(secret.Data[corev1.BasicAuthUsernameKey]),
+			Password: string(secret.Data[corev1.BasicAuthPasswordKey]),
+		}, nil
+	case corev1.SecretTypeDockerConfigJson:
+		return getDockerConfigCredentials(
+			secret.Data[corev1.DockerConfigJsonKey], registry)
+	default:
+		return Credentials{}, fmt.Errorf(
+			"credentials secret %s/%s has unsupported type %q",
+			namespace, secretName, secret.Type)
+	}
+}
+
+func getDockerConfigCredentials(
+	data []byte,
+	registry string) (Credentials, error) {
+
+	var config struct {
+		Auths map[string]struct {
+			Username string `json:"username"`
+			Password string `json:"password"`
+			Auth     string `json:"auth"`
+		} `json:"auths"`
+	}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return Credentials{}, fmt.Errorf(
+			"failed to unmarshal docker config: %w", err)
+	}
+
+	for k, v := range config.Auths {
+		// The key may be a URL, ex. https://registry.example.com/v1/.
+		host := strings.TrimPrefix(strings.TrimPrefix(k, "https://"), "http://")
+		if i := strings.Index(host, "/"); i >= 0 {
+			host = host[:i]
+		}
+		if host != registry {
+			continue
+		}
+
+		if v.Auth == "" {
+			return Credentials{Username: v.Username, Password: v.Password}, nil
+		}
+		auth, err := base64.StdEncoding.DecodeString(v.Auth)
+		if err != nil {
+			return Credentials{}, fmt.Errorf(
+				"failed to decode auth for registry %s: %w", registry, err)
+		}
+		username, password, _ := strings.Cut(string(auth), ":")
+		return Credentials{Username: username, Password: password}, nil
+	}
+
+	return Credentials{}, fmt.Errorf(
+		"docker config does not include credentials for registry %s",
+		registry)
+}
@@ -0,0 +1,129 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package oci_test
+
+import (
+	"context"
+	"encoding/base64"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware-tanzu/vm-operator/pkg/util/oci"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+var _ = Describe("GetCredentials", func() {
+
+	const (
+		namespace = "my-namespace"
+		name      = "my-secret"
+		registry  = "registry.example.com"
+	)
+
+	var (
+		secret *corev1.Secret
+	)
+
+	BeforeEach(func() {
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: namespace,
+				Name:      name,
+			},
+		}
+	})
+
+	getCredentials := func() (oci.Credentials, error) {
+		return oci.GetCredentials(
+			context.Background(),
+			builder.NewFakeClient(secret),
+			namespace, name, registry)
+	}
+
+	When("the secret name is empty", func() {
+		It("should return empty credentials", func() {
+			creds, err := oci.GetCredentials(
+				context.Background(),
+				builder.NewFakeClient(),
+				namespace, "", registry)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(creds).To(BeZero())
+		})
+	})
+
+	When("the secret is basic-auth", func() {
+		BeforeEach(func() {
+			secret.Type = corev1.SecretTypeBasicAuth
+			secret.Data = map[string][]byte{
+				corev1.BasicAuthUsernameKey: []byte("my-user"),
+				corev1.BasicAuthPasswordKey: []byte("my-pass"),
+			}
+		})
+		It("should return the credentials", func() {
+			creds, err := getCredentials()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(creds).To(Equal(oci.Credentials{Username: "my-user", Password: "my-pass"}))
+		})
+	})
+
+	When("the secret is a docker config", func() {
+		BeforeEach(func() {
+			secret.Type = corev1.SecretTypeDockerConfigJson
+		})
+		When("the registry has an auth value", func() {
+			BeforeEach(func() {
+				auth := base64.StdEncoding.EncodeToString([]byte("my-user:my:pass"))
+				secret.Data = map[string][]byte{
+					corev1.DockerConfigJsonKey: []byte(
+						`{"auths":{"other.example.com":{"auth":"b3RoZXI6b3RoZXI="},` +
+							`"https://` + registry + `/v1/":{"auth":"` + auth + `"}}}`),
+				}
+			})
+			It("should return the credentials", func() {
+				creds, err := getCredentials()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(creds).To(Equal(oci.Credentials{Username: "my-user", Password: "my:pass"}))
+			})
+		})
+		When("the registry has a username and password", func() {
+			BeforeEach(func() {
+				secret.Data = map[string][]byte{
+					corev1.DockerConfigJsonKey: []byte(
+						`{"auths":{"` + registry + `":{"username":"my-user","password":"my-pass"}}}`),
+				}
+			})
+			It("should return the credentials", func() {
+				creds, err := getCredentials()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(creds).To(Equal(oci.Credentials{Username: "my-user", Password: "my-pass"}))
+			})
+		})
+		When("the registry is missing", func() {
+			BeforeEach(func() {
+				secret.Data = map[string][]byte{
+					corev1.DockerConfigJsonKey: []byte(`{"auths":{}}`),
+				}
+			})
+			It("should return an error", func() {
+				_, err := getCredentials()
+				Expect(err).To(MatchError(ContainSubstring("does not include credentials")))
+			})
+		})
+	})
+
+	When("the secret has an unsupported type", func() {
+		BeforeEach(func() {
+			secret.Type = corev1.SecretTypeOpaque
+		})
+		It("should return an error", func() {
+			_, err := getCredentials()
+			Expect(err).To(MatchError(ContainSubstring("unsupported type")))
+		})
+	})
+})
@@ -0,0 +1,87 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+const (
+	// MediaTypeImageManifest is the media type of an OCI image manifest.
+	MediaTypeImageManifest = "application/vnd.oci.image.manifest.v1+json"
+
+	// MediaTypeEmptyJSON is the media type of the empty config used by
+	// artifacts that do not have a config.
+	MediaTypeEmptyJSON = "application/vnd.oci.empty.v1+json"
+
+	// ArtifactTypeOVF is the artifact type of a VM exported as an OVF.
+	ArtifactTypeOVF = "application/vnd.vmware.vm-operator.ovf.v1"
+
+	// MediaTypeOVFDescriptor is the media type of the layer that contains the
+	// OVF descriptor.
+	MediaTypeOVFDescriptor = "application/vnd.vmware.vm-operator.ovf.descriptor.v1+xml"
+
+	// MediaTypeOVFDisk is the media type of a layer that contains a
+	// stream-optimized VMDK referenced by the OVF descriptor.
+	MediaTypeOVFDisk = "application/vnd.vmware.vm-operator.ovf.disk.v1+vmdk"
+
+	// MediaTypeOVFFile is the media type of a layer that contains any other
+	// file referenced by the OVF descriptor, ex. NVRAM.
+	MediaTypeOVFFile = "application/vnd.vmware.vm-operator.ovf.file.v1"
+)
+
+const (
+	// AnnotationTitle is the pre-defined OCI annotation for the file name of a
+	// layer.
+	AnnotationTitle = "org.opencontainers.image.title"
+
+	// AnnotationCreated is the pre-defined OCI annotation for the time an
+	// artifact was created.
+	AnnotationCreated = "org.opencontainers.image.created"
+
+	// AnnotationDescription is the pre-defined OCI annotation for the
+	// description of an artifact.
+	AnnotationDescription = "org.opencontainers.image.description"
+
+	ovfAnnotationPrefix = "ovf.image.vmoperator.vmware.com/"
+
+	// AnnotationOVFName is the annotation for the name of the OVF's virtual
+	// system.
+	AnnotationOVFName = ovfAnnotationPrefix + "name"
+
+	// AnnotationOVFOSType is the annotation for the operating system type of
+	// the OVF's virtual system, ex. ubuntu64Guest.
+	AnnotationOVFOSType = ovfAnnotationPrefix + "os-type"
+
+	// AnnotationOVFHardwareVersion is the annotation for the virtual hardware
+	// version of the OVF's virtual system, ex. vmx-21.
+	AnnotationOVFHardwareVersion = ovfAnnotationPrefix + "hardware-version"
+
+	// AnnotationOVFDiskCount is the annotation for the number of disks in the
+	// OVF.
+	AnnotationOVFDiskCount = ovfAnnotationPrefix + "disk-count"
+
+	// AnnotationPublishRequestUID is the annotation for the UID of the
+	// VirtualMachinePublishRequest that pushed an artifact.
+	AnnotationPublishRequestUID = "virtualmachinepublishrequest.vmoperator.vmware.com/uid"
+)
+
+// emptyJSON is the content of the empty config.
+var emptyJSON = []byte("{}")
+
+// Descriptor describes the content of a blob or manifest.
+type Descriptor struct {
+	MediaType    string            `json:"mediaType"`
+	ArtifactType string            `json:"artifactType,omitempty"`
+	Digest       string            `json:"digest"`
+	Size         int64             `json:"size"`
+	Annotations  map[string]string `json:"annotations,omitempty"`
+}
+
+// Manifest is an OCI image manifest.
+type Manifest struct {
+	SchemaVersion int               `json:"schemaVersion"`
+	MediaType     string            `json:"mediaType,omitempty"`
+	ArtifactType  string            `json:"artifactType,omitempty"`
+	Config        Descriptor        `json:"config"`
+	Layers        []Descriptor      `json:"layers"`
+	Annotations   map[string]string `json:"annotations,omitempty"`
+}
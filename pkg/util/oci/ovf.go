@@ -0,0 +1,219 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"strconv"
+	"time"
+
+	"github.com/vmware/govmomi/ovf"
+)
+
+// OVFFile is a file referenced by an OVF descriptor, ex. a disk.
+type OVFFile struct {
+	// Name is the name of the file in the OVF descriptor, ex. disk-0.vmdk.
+	Name string
+
+	// MediaType is the media type of the file's layer, ex. MediaTypeOVFDisk.
+	MediaType string
+
+	// Open returns a reader for the file's content. The reader is closed
+	// after the file is pushed.
+	Open func(context.Context) (io.ReadCloser, error)
+}
+
+// PushOVF pushes an OVF descriptor and the files it references to the
+// provided reference as an artifact of type ArtifactTypeOVF and returns the
+// descriptor of the artifact's manifest.
+//
+// The manifest is annotated with information from the OVF descriptor, ex.
+// AnnotationOVFName, and the provided annotations.
+func PushOVF(
+	ctx context.Context,
+	c *Client,
+	ref Reference,
+	descriptor []byte,
+	files []OVFFile,
+	annotations map[string]string) (Descriptor, error) {
+
+	layers := make([]Descriptor, 0, len(files))
+	for i := range files {
+		layer, err := PushOVFFile(ctx, c, ref, files[i])
+		if err != nil {
+			return Descriptor{}, err
+		}
+		layers = append(layers, layer)
+	}
+
+	return PushOVFManifest(ctx, c, ref, descriptor, layers, annotations)
+}
+
+// PushOVFFile pushes a file referenced by an OVF descriptor and returns the
+// descriptor of the file's layer. The layers of the files are passed to
+// PushOVFManifest once all of an OVF's files are pushed.
+//
+// Pushing the files before the OVF descriptor allows the OVF descriptor to be
+// created from the size of the files, ex. when exporting a VM.
+func PushOVFFile(
+	ctx context.Context,
+	c *Client,
+	ref Reference,
+	file OVFFile) (Descriptor, error) {
+
+	r, err := file.Open(ctx)
+	if err != nil {
+		return Descriptor{}, fmt.Errorf(
+			"failed to open ovf file %q: %w", file.Name, err)
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+
+	desc, err := c.PushBlob(ctx, ref, file.MediaType, r)
+	if err != nil {
+		return Descriptor{}, fmt.Errorf(
+			"failed to push ovf file %q: %w", file.Name, err)
+	}
+	desc.Annotations = map[string]string{
+		AnnotationTitle: file.Name,
+	}
+	return desc, nil
+}
+
+// PushOVFManifest pushes an OVF descriptor and a manifest with the
+// descriptor and the provided file layers, and returns the descriptor of
+// the manifest.
+func PushOVFManifest(
+	ctx context.Context,
+	c *Client,
+	ref Reference,
+	descriptor []byte,
+	files []Descriptor,
+	annotations map[string]string) (Descriptor, error) {
+
+	env, err := ovf.Unmarshal(bytes.NewReader(descriptor))
+	if err != nil {
+		return Descriptor{}, fmt.Errorf(
+			"failed to unmarshal ovf descriptor: %w", err)
+	}
+
+	config, err := c.PushBlob(
+		ctx, ref, MediaTypeEmptyJSON, bytes.NewReader(emptyJSON))
+	if err != nil {
+		return Descriptor{}, fmt.Errorf("failed to push config: %w", err)
+	}
+
+	layer, err := c.PushBlob(
+		ctx, ref, MediaTypeOVFDescriptor, bytes.NewReader(descriptor))
+	if err != nil {
+		return Descriptor{}, fmt.Errorf(
+			"failed to push ovf descriptor: %w", err)
+	}
+	name := "descriptor"
+	if env.VirtualSystem != nil && env.VirtualSystem.ID != "" {
+		name = env.VirtualSystem.ID
+	}
+	layer.Annotations = map[string]string{
+		AnnotationTitle: name + ".ovf",
+	}
+
+	manifest := Manifest{
+		ArtifactType: ArtifactTypeOVF,
+		Config:       config,
+		Layers:       append([]Descriptor{layer}, files...),
+		Annotations: map[string]string{
+			AnnotationCreated: time.Now().UTC().Format(time.RFC3339),
+		},
+	}
+	maps.Copy(manifest.Annotations, OVFAnnotations(env))
+	maps.Copy(manifest.Annotations, annotations)
+
+	return c.PushManifest(ctx, ref, manifest)
+}
+
+// GetOVF returns the OVF envelope from the artifact at the provided reference
+// along with the artifact's manifest and the manifest's descriptor.
+func GetOVF(
+	ctx context.Context,
+	c *Client,
+	ref Reference) (*ovf.Envelope, Manifest, Descriptor, error) {
+
+	manifest, desc, err := c.GetManifest(ctx, ref)
+	if err != nil {
+		return nil, Manifest{}, Descriptor{}, err
+	}
+
+	if manifest.ArtifactType != ArtifactTypeOVF {
+		return nil, Manifest{}, Descriptor{}, fmt.Errorf(
+			"artifact %s has type %q, expected %q",
+			ref, manifest.ArtifactType, ArtifactTypeOVF)
+	}
+
+	var layer *Descriptor
+	for i := range manifest.Layers {
+		if manifest.Layers[i].MediaType == MediaTypeOVFDescriptor {
+			layer = &manifest.Layers[i]
+			break
+		}
+	}
+	if layer == nil {
+		return nil, Manifest{}, Descriptor{}, fmt.Errorf(
+			"artifact %s does not have an ovf descriptor", ref)
+	}
+
+	r, err := c.GetBlob(ctx, ref, layer.Digest)
+	if err != nil {
+		return nil, Manifest{}, Descriptor{}, err
+	}
+	defer func() {
+		_ = r.Close()
+	}()
+
+	env, err := ovf.Unmarshal(r)
+	if err != nil {
+		return nil, Manifest{}, Descriptor{}, fmt.Errorf(
+			"failed to unmarshal ovf descriptor: %w", err)
+	}
+
+	return env, manifest, desc, nil
+}
+
+// OVFAnnotations returns the manifest annotations that describe an OVF.
+func OVFAnnotations(env *ovf.Envelope) map[string]string {
+	out := map[string]string{}
+
+	if env.Disk != nil {
+		out[AnnotationOVFDiskCount] = strconv.Itoa(len(env.Disk.Disks))
+	}
+
+	vs := env.VirtualSystem
+	if vs == nil {
+		return out
+	}
+
+	if vs.ID != "" {
+		out[AnnotationOVFName] = vs.ID
+	}
+	if vs.Annotation != nil && vs.Annotation.Annotation != "" {
+		out[AnnotationDescription] = vs.Annotation.Annotation
+	}
+	if os := vs.OperatingSystem; os != nil && os.OSType != nil {
+		out[AnnotationOVFOSType] = *os.OSType
+	}
+	if len(vs.VirtualHardware) > 0 {
+		if sys := vs.VirtualHardware[0].System; sys != nil &&
+			sys.VirtualSystemType != nil {
+
+			out[AnnotationOVFHardwareVersion] = *sys.VirtualSystemType
+		}
+	}
+
+	return out
+}
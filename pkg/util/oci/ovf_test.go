@@ -0,0 +1,216 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package oci_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware-tanzu/vm-operator/pkg/util/oci"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+const ovfDescriptor = `<?xml version="1.0" encoding="UTF-8"?>
+<Envelope xmlns="http://schemas.dmtf.org/ovf/envelope/1" xmlns:ovf="http://schemas.dmtf.org/ovf/envelope/1" xmlns:vmw="http://www.vmware.com/schema/ovf" xmlns:vssd="http://schemas.dmtf.org/wbem/wscim/1/cim-schema/2/CIM_VirtualSystemSettingData">
+  <References>
+    <File ovf:href="my-vm-disk-0.vmdk" ovf:id="file1"/>
+  </References>
+  <DiskSection>
+    <Info>Virtual disk information</Info>
+    <Disk ovf:capacity="10" ovf:capacityAllocationUnits="byte * 2^30" ovf:diskId="vmdisk1" ovf:fileRef="file1"/>
+  </DiskSection>
+  <VirtualSystem ovf:id="my-vm">
+    <Info>A virtual machine</Info>
+    <AnnotationSection>
+      <Info>A human-readable annotation</Info>
+      <Annotation>my description</Annotation>
+    </AnnotationSection>
+    <OperatingSystemSection ovf:id="94" vmw:osType="ubuntu64Guest">
+      <Info>The kind of installed guest operating system</Info>
+    </OperatingSystemSection>
+    <VirtualHardwareSection>
+      <Info>Virtual hardware requirements</Info>
+      <System>
+        <vssd:ElementName>Virtual Hardware Family</vssd:ElementName>
+        <vssd:InstanceID>0</vssd:InstanceID>
+        <vssd:VirtualSystemType>vmx-21</vssd:VirtualSystemType>
+      </System>
+    </VirtualHardwareSection>
+  </VirtualSystem>
+</Envelope>
+`
+
+var _ = Describe("PushOVF", func() {
+
+	var (
+		ctx      context.Context
+		registry *builder.OCIRegistry
+		creds    oci.Credentials
+		ref      oci.Reference
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		registry = builder.NewOCIRegistry()
+		creds = oci.Credentials{}
+	})
+
+	JustBeforeEach(func() {
+		var err error
+		ref, err = oci.ParseReference(registry.Host() + "/my-project/my-vm:v1")
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		registry.Close()
+	})
+
+	push := func() (oci.Descriptor, error) {
+		return oci.PushOVF(
+			ctx,
+			oci.NewClient(creds, true),
+			ref,
+			[]byte(ovfDescriptor),
+			[]oci.OVFFile{
+				{
+					Name:      "my-vm-disk-0.vmdk",
+					MediaType: oci.MediaTypeOVFDisk,
+					Open: func(context.Context) (io.ReadCloser, error) {
+						return io.NopCloser(strings.NewReader("disk-data")), nil
+					},
+				},
+			},
+			map[string]string{
+				oci.AnnotationPublishRequestUID: "my-uid",
+			})
+	}
+
+	assertPushed := func() {
+		desc, err := push()
+		ExpectWithOffset(1, err).ToNot(HaveOccurred())
+
+		data, ok := registry.GetManifest("my-project/my-vm", "v1")
+		ExpectWithOffset(1, ok).To(BeTrue())
+		ExpectWithOffset(1, desc.Digest).To(Equal(oci.Digest(data)))
+
+		var manifest oci.Manifest
+		ExpectWithOffset(1, json.Unmarshal(data, &manifest)).To(Succeed())
+		ExpectWithOffset(1, manifest.ArtifactType).To(Equal(oci.ArtifactTypeOVF))
+		ExpectWithOffset(1, manifest.Config.MediaType).To(Equal(oci.MediaTypeEmptyJSON))
+		ExpectWithOffset(1, manifest.Layers).To(HaveLen(2))
+		ExpectWithOffset(1, manifest.Layers[0].MediaType).To(Equal(oci.MediaTypeOVFDescriptor))
+		ExpectWithOffset(1, manifest.Layers[0].Annotations).To(HaveKeyWithValue(oci.AnnotationTitle, "my-vm.ovf"))
+		ExpectWithOffset(1, manifest.Layers[1].MediaType).To(Equal(oci.MediaTypeOVFDisk))
+		ExpectWithOffset(1, manifest.Layers[1].Annotations).To(HaveKeyWithValue(oci.AnnotationTitle, "my-vm-disk-0.vmdk"))
+		ExpectWithOffset(1, manifest.Layers[1].Size).To(BeEquivalentTo(len("disk-data")))
+		ExpectWithOffset(1, manifest.Annotations).To(And(
+			HaveKeyWithValue(oci.AnnotationOVFName, "my-vm"),
+			HaveKeyWithValue(oci.AnnotationOVFOSType, "ubuntu64Guest"),
+			HaveKeyWithValue(oci.AnnotationOVFHardwareVersion, "vmx-21"),
+			HaveKeyWithValue(oci.AnnotationOVFDiskCount, "1"),
+			HaveKeyWithValue(oci.AnnotationDescription, "my description"),
+			HaveKeyWithValue(oci.AnnotationPublishRequestUID, "my-uid"),
+			HaveKey(oci.AnnotationCreated),
+		))
+
+		disk, ok := registry.GetBlob(manifest.Layers[1].Digest)
+		ExpectWithOffset(1, ok).To(BeTrue())
+		ExpectWithOffset(1, string(disk)).To(Equal("disk-data"))
+
+		env, _, getDesc, err := oci.GetOVF(ctx, oci.NewClient(creds, true), ref)
+		ExpectWithOffset(1, err).ToNot(HaveOccurred())
+		ExpectWithOffset(1, getDesc.Digest).To(Equal(desc.Digest))
+		ExpectWithOffset(1, env.VirtualSystem).ToNot(BeNil())
+		ExpectWithOffset(1, env.VirtualSystem.ID).To(Equal("my-vm"))
+
+		env, _, _, err = oci.GetOVF(ctx, oci.NewClient(creds, true), ref.WithDigest(desc.Digest))
+		ExpectWithOffset(1, err).ToNot(HaveOccurred())
+		ExpectWithOffset(1, env.VirtualSystem.ID).To(Equal("my-vm"))
+	}
+
+	When("the registry allows anonymous access", func() {
+		It("should push the ovf as an artifact", func() {
+			assertPushed()
+		})
+	})
+
+	When("the registry requires basic authentication", func() {
+		BeforeEach(func() {
+			registry.Username, registry.Password = "my-user", "my-pass"
+		})
+		When("the credentials are valid", func() {
+			BeforeEach(func() {
+				creds = oci.Credentials{Username: "my-user", Password: "my-pass"}
+			})
+			It("should push the ovf as an artifact", func() {
+				assertPushed()
+			})
+		})
+		When("the credentials are invalid", func() {
+			BeforeEach(func() {
+				creds = oci.Credentials{Username: "my-user", Password: "wrong"}
+			})
+			It("should return an error", func() {
+				_, err := push()
+				var statusErr oci.StatusError
+				Expect(errors.As(err, &statusErr)).To(BeTrue())
+				Expect(statusErr.StatusCode).To(Equal(401))
+			})
+		})
+	})
+
+	When("the registry redirects blob downloads to another host", func() {
+		var (
+			blobServer *httptest.Server
+			blobAuth   []string
+		)
+		BeforeEach(func() {
+			registry.Username, registry.Password = "my-user", "my-pass"
+			creds = oci.Credentials{Username: "my-user", Password: "my-pass"}
+			blobAuth = nil
+			blobServer = httptest.NewServer(http.HandlerFunc(
+				func(w http.ResponseWriter, req *http.Request) {
+					blobAuth = append(blobAuth, req.Header.Get("Authorization"))
+					data, _ := registry.GetBlob(strings.TrimPrefix(req.URL.Path, "/"))
+					_, _ = w.Write(data)
+				}))
+			registry.BlobRedirectURL = blobServer.URL
+		})
+		AfterEach(func() {
+			blobServer.Close()
+		})
+		It("should not send the credentials to the other host", func() {
+			assertPushed()
+			Expect(blobAuth).ToNot(BeEmpty())
+			Expect(blobAuth).To(HaveEach(BeEmpty()))
+		})
+	})
+
+	When("the registry requires token authentication", func() {
+		BeforeEach(func() {
+			registry.Username, registry.Password = "my-user", "my-pass"
+			registry.BearerAuth = true
+			creds = oci.Credentials{Username: "my-user", Password: "my-pass"}
+		})
+		It("should push the ovf as an artifact", func() {
+			assertPushed()
+		})
+	})
+
+	When("the artifact does not exist", func() {
+		It("should return a not found error", func() {
+			_, _, _, err := oci.GetOVF(ctx, oci.NewClient(creds, true), ref)
+			Expect(oci.IsNotFound(err)).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,127 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package oci
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var (
+	tagRx    = regexp.MustCompile(`^[a-zA-Z0-9_][a-zA-Z0-9._-]{0,127}$`)
+	digestRx = regexp.MustCompile(`^sha256:[a-f0-9]{64}$`)
+	repoRx   = regexp.MustCompile(`^[a-z0-9]+([._-][a-z0-9]+)*(/[a-z0-9]+([._-][a-z0-9]+)*)*$`)
+)
+
+// Reference is a reference to an artifact in an OCI registry, ex.
+// registry.example.com/my-project/my-image:v1.
+type Reference struct {
+	// Registry is the host and optional port of the registry, ex.
+	// registry.example.com:5000.
+	Registry string
+
+	// Repository is the path of the repository in the registry, ex.
+	// my-project/my-image.
+	Repository string
+
+	// Tag is the tag of the artifact.
+	Tag string
+
+	// Digest is the digest of the artifact's manifest. If both the tag and
+	// digest are set, the digest takes precedence.
+	Digest string
+}
+
+// ParseReference parses a reference to an artifact. The reference must
+// include the registry, ex. registry.example.com/my-image:v1 or
+// localhost:5000/my-image@sha256:...
+func ParseReference(s string) (Reference, error) {
+	var ref Reference
+
+	name := s
+	if i := strings.Index(name, "@"); i >= 0 {
+		ref.Digest = name[i+1:]
+		name = name[:i]
+		if !digestRx.MatchString(ref.Digest) {
+			return Reference{}, fmt.Errorf(
+				"invalid digest %q in reference %q", ref.Digest, s)
+		}
+	}
+
+	// The tag is after the last colon, unless the colon is part of the
+	// registry's port.
+	if i := strings.LastIndex(name, ":"); i > strings.LastIndex(name, "/") {
+		ref.Tag = name[i+1:]
+		name = name[:i]
+		if !tagRx.MatchString(ref.Tag) {
+			return Reference{}, fmt.Errorf(
+				"invalid tag %q in reference %q", ref.Tag, s)
+		}
+	}
+
+	i := strings.Index(name, "/")
+	if i <= 0 {
+		return Reference{}, fmt.Errorf(
+			"reference %q does not include a registry", s)
+	}
+	ref.Registry, ref.Repository = name[:i], name[i+1:]
+
+	if !repoRx.MatchString(ref.Repository) {
+		return Reference{}, fmt.Errorf(
+			"invalid repository %q in reference %q", ref.Repository, s)
+	}
+
+	return ref, nil
+}
+
+// ValidTag returns true if the provided value is a valid tag.
+func ValidTag(s string) bool {
+	return tagRx.MatchString(s)
+}
+
+// Name returns the registry and repository of the reference, ex.
+// registry.example.com/my-project/my-image.
+func (r Reference) Name() string {
+	return r.Registry + "/" + r.Repository
+}
+
+// WithTag returns a copy of the reference with the provided tag and no
+// digest.
+func (r Reference) WithTag(tag string) Reference {
+	r.Tag, r.Digest = tag, ""
+	return r
+}
+
+// WithDigest returns a copy of the reference with the provided digest and no
+// tag.
+func (r Reference) WithDigest(digest string) Reference {
+	r.Tag, r.Digest = "", digest
+	return r
+}
+
+// String returns the reference, ex. registry.example.com/my-image:v1 or
+// registry.example.com/my-image@sha256:...
+func (r Reference) String() string {
+	s := r.Name()
+	if r.Tag != "" {
+		s += ":" + r.Tag
+	}
+	if r.Digest != "" {
+		s += "@" + r.Digest
+	}
+	return s
+}
+
+// manifestRef returns the digest or tag used to get or put the manifest.
+func (r Reference) manifestRef() string {
+	if r.Digest != "" {
+		return r.Digest
+	}
+	if r.Tag != "" {
+		return r.Tag
+	}
+	return "latest"
+}
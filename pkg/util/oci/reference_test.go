@@ -0,0 +1,48 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package oci_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware-tanzu/vm-operator/pkg/util/oci"
+)
+
+var _ = Describe("ParseReference", func() {
+	const digest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+	DescribeTable("valid references",
+		func(s string, expected oci.Reference) {
+			ref, err := oci.ParseReference(s)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(ref).To(Equal(expected))
+			Expect(ref.String()).To(Equal(s))
+		},
+		Entry("no tag",
+			"registry.example.com/my-image",
+			oci.Reference{Registry: "registry.example.com", Repository: "my-image"}),
+		Entry("tag",
+			"registry.example.com/my-project/my-image:v1.0",
+			oci.Reference{Registry: "registry.example.com", Repository: "my-project/my-image", Tag: "v1.0"}),
+		Entry("port and tag",
+			"localhost:5000/my-image:latest",
+			oci.Reference{Registry: "localhost:5000", Repository: "my-image", Tag: "latest"}),
+		Entry("port and digest",
+			"localhost:5000/my-image@"+digest,
+			oci.Reference{Registry: "localhost:5000", Repository: "my-image", Digest: digest}),
+	)
+
+	DescribeTable("invalid references",
+		func(s string) {
+			_, err := oci.ParseReference(s)
+			Expect(err).To(HaveOccurred())
+		},
+		Entry("no registry", "my-image:v1"),
+		Entry("uppercase repository", "registry.example.com/My-Image"),
+		Entry("invalid tag", "registry.example.com/my-image:-v1"),
+		Entry("invalid digest", "registry.example.com/my-image@sha256:abc"),
+	)
+})
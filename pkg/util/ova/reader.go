@@ -0,0 +1,304 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package ova
+
+import (
+	"archive/tar"
+	"bytes"
+	"crypto/sha1" //nolint:gosec // OVA manifests may use SHA1 checksums
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/vmware/govmomi/vapi/library"
+)
+
+var (
+	// ErrInvalid is wrapped by each of the errors that are returned when the
+	// OVA cannot be read or does not match its manifest.
+	ErrInvalid = errors.New("invalid ova")
+
+	// ErrMissingDescriptor is returned when the first file in the OVA is not
+	// an OVF descriptor.
+	ErrMissingDescriptor = fmt.Errorf("%w: first file is not an ovf descriptor", ErrInvalid)
+
+	// ErrMissingManifest is returned when the OVF descriptor is not followed
+	// by a manifest.
+	ErrMissingManifest = fmt.Errorf("%w: ovf descriptor is not followed by a manifest", ErrInvalid)
+
+	// ErrNotInManifest is returned when a file in the OVA is not listed in the
+	// OVA's manifest.
+	ErrNotInManifest = fmt.Errorf("%w: file is not in the manifest", ErrInvalid)
+
+	// ErrMissingFile is returned when a file listed in the OVA's manifest is
+	// not in the OVA.
+	ErrMissingFile = fmt.Errorf("%w: file in the manifest is missing", ErrInvalid)
+
+	// ErrChecksumMismatch is returned when a file's checksum does not match
+	// the checksum in the OVA's manifest.
+	ErrChecksumMismatch = fmt.Errorf("%w: checksum does not match the manifest", ErrInvalid)
+)
+
+// maxHeadFileSize is the maximum size of the OVF descriptor and manifest,
+// which are read into memory.
+const maxHeadFileSize = 16 * 1024 * 1024
+
+// File is a file read from an OVA.
+type File struct {
+	io.Reader
+
+	// Name is the name of the file.
+	Name string
+
+	// Size is the size of the file in bytes.
+	Size int64
+
+	// Checksum is the file's checksum from the OVA's manifest. It is nil for
+	// the manifest and certificate.
+	Checksum *library.Checksum
+}
+
+// Reader reads the files from an OVA stream and verifies them against the
+// checksums in the OVA's manifest.
+//
+// The OVA must begin with an OVF descriptor followed by a manifest, as
+// described by the OVF specification, and each of the other files in the OVA,
+// except for a certificate, must be listed in the manifest.
+type Reader struct {
+	tr       *tar.Reader
+	manifest map[string]*library.Checksum
+	pending  []*File
+	seen     map[string]struct{}
+	cur      *currentFile
+}
+
+type currentFile struct {
+	name string
+	sum  *library.Checksum
+	hash hash.Hash
+}
+
+// NewReader returns a new Reader that reads the OVA from r.
+func NewReader(r io.Reader) *Reader {
+	return &Reader{
+		tr:   tar.NewReader(r),
+		seen: map[string]struct{}{},
+	}
+}
+
+// Next returns the next file in the OVA, or io.EOF when there are no more
+// files. The contents of the previous file are verified before the next file
+// is returned, so a file that does not match its checksum causes the call to
+// Next after it to return ErrChecksumMismatch. Callers must read files until
+// Next returns io.EOF for the OVA to be completely verified.
+func (r *Reader) Next() (*File, error) {
+	if err := r.verifyCurrent(); err != nil {
+		return nil, err
+	}
+
+	if r.manifest == nil {
+		if err := r.readHead(); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(r.pending) > 0 {
+		f := r.pending[0]
+		r.pending = r.pending[1:]
+		return f, nil
+	}
+
+	hdr, name, err := r.nextHeader()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			for name := range r.manifest {
+				if _, ok := r.seen[name]; !ok {
+					return nil, fmt.Errorf("%w: %s", ErrMissingFile, name)
+				}
+			}
+		}
+		return nil, err
+	}
+
+	if _, ok := r.seen[name]; ok {
+		return nil, fmt.Errorf("%w: duplicate file %s", ErrInvalid, name)
+	}
+	r.seen[name] = struct{}{}
+
+	switch path.Ext(name) {
+	case ".mf":
+		return nil, fmt.Errorf("%w: unexpected manifest %s", ErrInvalid, name)
+	case ".cert":
+		return &File{Reader: r.tr, Name: name, Size: hdr.Size}, nil
+	}
+
+	sum, ok := r.manifest[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrNotInManifest, name)
+	}
+
+	h, err := newHash(sum.Algorithm)
+	if err != nil {
+		return nil, err
+	}
+	r.cur = &currentFile{name: name, sum: sum, hash: h}
+
+	return &File{
+		Reader:   io.TeeReader(r.tr, h),
+		Name:     name,
+		Size:     hdr.Size,
+		Checksum: sum,
+	}, nil
+}
+
+// readHead reads the OVF descriptor and manifest from the beginning of the
+// OVA and verifies the descriptor against the manifest.
+func (r *Reader) readHead() error {
+	ovfHdr, ovfName, err := r.nextHeader()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return ErrMissingDescriptor
+		}
+		return err
+	}
+	if path.Ext(ovfName) != ".ovf" {
+		return ErrMissingDescriptor
+	}
+	ovfData, err := readHeadFile(r.tr, ovfHdr)
+	if err != nil {
+		return err
+	}
+
+	mfHdr, mfName, err := r.nextHeader()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return ErrMissingManifest
+		}
+		return err
+	}
+	if path.Ext(mfName) != ".mf" {
+		return ErrMissingManifest
+	}
+	mfData, err := readHeadFile(r.tr, mfHdr)
+	if err != nil {
+		return err
+	}
+
+	manifest, err := library.ReadManifest(bytes.NewReader(mfData))
+	if err != nil {
+		return fmt.Errorf("%w: failed to read manifest: %w", ErrInvalid, err)
+	}
+
+	ovfSum, ok := manifest[ovfName]
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrNotInManifest, ovfName)
+	}
+	h, err := newHash(ovfSum.Algorithm)
+	if err != nil {
+		return err
+	}
+	_, _ = h.Write(ovfData)
+	if err := verify(ovfName, ovfSum, h); err != nil {
+		return err
+	}
+
+	r.manifest = manifest
+	r.seen[ovfName] = struct{}{}
+	r.seen[mfName] = struct{}{}
+	r.pending = []*File{
+		{
+			Reader:   bytes.NewReader(ovfData),
+			Name:     ovfName,
+			Size:     int64(len(ovfData)),
+			Checksum: ovfSum,
+		},
+		{
+			Reader: bytes.NewReader(mfData),
+			Name:   mfName,
+			Size:   int64(len(mfData)),
+		},
+	}
+
+	return nil
+}
+
+// verifyCurrent reads the rest of the current file and verifies it against
+// its checksum.
+func (r *Reader) verifyCurrent() error {
+	cur := r.cur
+	if cur == nil {
+		return nil
+	}
+	r.cur = nil
+
+	if _, err := io.Copy(cur.hash, r.tr); err != nil {
+		return fmt.Errorf("%w: failed to read %s: %w", ErrInvalid, cur.name, err)
+	}
+	return verify(cur.name, cur.sum, cur.hash)
+}
+
+// nextHeader returns the header and name of the next regular file in the
+// OVA.
+func (r *Reader) nextHeader() (*tar.Header, string, error) {
+	for {
+		hdr, err := r.tr.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, "", io.EOF
+			}
+			return nil, "", fmt.Errorf("%w: %w", ErrInvalid, err)
+		}
+
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		name := path.Clean(hdr.Name)
+		if strings.Contains(name, "/") || name == "." || name == ".." {
+			return nil, "", fmt.Errorf("%w: invalid file name %s", ErrInvalid, hdr.Name)
+		}
+
+		return hdr, name, nil
+	}
+}
+
+func readHeadFile(r io.Reader, hdr *tar.Header) ([]byte, error) {
+	if hdr.Size > maxHeadFileSize {
+		return nil, fmt.Errorf(
+			"%w: %s exceeds the maximum size of %d bytes", ErrInvalid, hdr.Name, maxHeadFileSize)
+	}
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to read %s: %w", ErrInvalid, hdr.Name, err)
+	}
+	return data, nil
+}
+
+func newHash(algorithm string) (hash.Hash, error) {
+	switch strings.ToUpper(algorithm) {
+	case "SHA1":
+		return sha1.New(), nil //nolint:gosec // OVA manifests may use SHA1 checksums
+	case "SHA256":
+		return sha256.New(), nil
+	case "SHA512":
+		return sha512.New(), nil
+	default:
+		return nil, fmt.Errorf("%w: unsupported checksum algorithm %s", ErrInvalid, algorithm)
+	}
+}
+
+func verify(name string, sum *library.Checksum, h hash.Hash) error {
+	if actual := hex.EncodeToString(h.Sum(nil)); !strings.EqualFold(actual, sum.Checksum) {
+		return fmt.Errorf("%w: %s: expected %s, got %s",
+			ErrChecksumMismatch, name, sum.Checksum, actual)
+	}
+	return nil
+}
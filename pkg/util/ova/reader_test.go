@@ -0,0 +1,193 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package ova_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware-tanzu/vm-operator/pkg/util/ova"
+)
+
+type ovaFile struct {
+	name string
+	data string
+}
+
+func newOVA(files ...ovaFile) io.Reader {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, f := range files {
+		ExpectWithOffset(1, tw.WriteHeader(&tar.Header{
+			Name:     f.name,
+			Mode:     0600,
+			Size:     int64(len(f.data)),
+			Typeflag: tar.TypeReg,
+		})).To(Succeed())
+		_, err := tw.Write([]byte(f.data))
+		ExpectWithOffset(1, err).ToNot(HaveOccurred())
+	}
+	ExpectWithOffset(1, tw.Close()).To(Succeed())
+	return &buf
+}
+
+func sha256Line(name, data string) string {
+	sum := sha256.Sum256([]byte(data))
+	return fmt.Sprintf("SHA256(%s)= %s\n", name, hex.EncodeToString(sum[:]))
+}
+
+// readAll reads each of the files in the OVA and returns the names and
+// contents of the files that were read before the error returned by Next.
+func readAll(r *ova.Reader) (map[string]string, []string, error) {
+	var (
+		names    []string
+		contents = map[string]string{}
+	)
+	for {
+		f, err := r.Next()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return contents, names, nil
+			}
+			return contents, names, err
+		}
+		data, err := io.ReadAll(f)
+		if err != nil {
+			return contents, names, err
+		}
+		names = append(names, f.Name)
+		contents[f.Name] = string(data)
+	}
+}
+
+var _ = Describe("Reader", func() {
+	const (
+		ovfData  = "<Envelope/>"
+		diskData = "disk-data"
+	)
+
+	var (
+		files []ovaFile
+	)
+
+	BeforeEach(func() {
+		files = []ovaFile{
+			{name: "my-image.ovf", data: ovfData},
+			{name: "my-image.mf", data: sha256Line("my-image.ovf", ovfData) +
+				sha256Line("my-image-disk-0.vmdk", diskData)},
+			{name: "my-image-disk-0.vmdk", data: diskData},
+		}
+	})
+
+	When("the OVA is valid", func() {
+		It("returns each file", func() {
+			contents, names, err := readAll(ova.NewReader(newOVA(files...)))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(names).To(Equal([]string{
+				"my-image.ovf",
+				"my-image.mf",
+				"my-image-disk-0.vmdk",
+			}))
+			Expect(contents["my-image.ovf"]).To(Equal(ovfData))
+			Expect(contents["my-image-disk-0.vmdk"]).To(Equal(diskData))
+		})
+
+		It("returns the checksums from the manifest", func() {
+			r := ova.NewReader(newOVA(files...))
+
+			f, err := r.Next()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(f.Checksum).ToNot(BeNil())
+			Expect(f.Checksum.Algorithm).To(Equal("SHA256"))
+
+			f, err = r.Next()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(f.Name).To(Equal("my-image.mf"))
+			Expect(f.Checksum).To(BeNil())
+		})
+
+		It("verifies files that are not read by the caller", func() {
+			r := ova.NewReader(newOVA(files...))
+			for range files {
+				_, err := r.Next()
+				Expect(err).ToNot(HaveOccurred())
+			}
+			_, err := r.Next()
+			Expect(err).To(MatchError(io.EOF))
+		})
+
+		It("returns a certificate without verifying it", func() {
+			files = append(files, ovaFile{name: "my-image.cert", data: "cert"})
+			_, names, err := readAll(ova.NewReader(newOVA(files...)))
+			Expect(err).ToNot(HaveOccurred())
+			Expect(names).To(ContainElement("my-image.cert"))
+		})
+	})
+
+	When("the OVA does not begin with an OVF descriptor", func() {
+		It("returns an error", func() {
+			files[0], files[2] = files[2], files[0]
+			_, _, err := readAll(ova.NewReader(newOVA(files...)))
+			Expect(err).To(MatchError(ova.ErrMissingDescriptor))
+		})
+	})
+
+	When("the OVF descriptor is not followed by a manifest", func() {
+		It("returns an error", func() {
+			files[1], files[2] = files[2], files[1]
+			_, _, err := readAll(ova.NewReader(newOVA(files...)))
+			Expect(err).To(MatchError(ova.ErrMissingManifest))
+		})
+	})
+
+	When("the OVF descriptor does not match its checksum", func() {
+		It("returns an error", func() {
+			files[0].data = "<Envelope></Envelope>"
+			_, _, err := readAll(ova.NewReader(newOVA(files...)))
+			Expect(err).To(MatchError(ova.ErrChecksumMismatch))
+		})
+	})
+
+	When("a disk does not match its checksum", func() {
+		It("returns an error after the disk is read", func() {
+			files[2].data = "bad-data"
+			_, names, err := readAll(ova.NewReader(newOVA(files...)))
+			Expect(err).To(MatchError(ova.ErrChecksumMismatch))
+			Expect(names).To(ContainElement("my-image-disk-0.vmdk"))
+		})
+	})
+
+	When("a file is not in the manifest", func() {
+		It("returns an error", func() {
+			files = append(files, ovaFile{name: "my-image-disk-1.vmdk", data: diskData})
+			_, _, err := readAll(ova.NewReader(newOVA(files...)))
+			Expect(err).To(MatchError(ova.ErrNotInManifest))
+		})
+	})
+
+	When("a file in the manifest is not in the OVA", func() {
+		It("returns an error", func() {
+			files = files[:2]
+			_, _, err := readAll(ova.NewReader(newOVA(files...)))
+			Expect(err).To(MatchError(ova.ErrMissingFile))
+		})
+	})
+
+	When("a file name is a path", func() {
+		It("returns an error", func() {
+			files[2].name = "../my-image-disk-0.vmdk"
+			_, _, err := readAll(ova.NewReader(newOVA(files...)))
+			Expect(err).To(MatchError(ova.ErrInvalid))
+		})
+	})
+})
@@ -263,6 +263,26 @@ func MatchVirtualEthernetCardOpaqueNetworkBackingInfo(
 		expectedBacking.OpaqueNetworkType == curBacking.OpaqueNetworkType
 }
 
+func MatchVirtualSriovEthernetCardSriovBackingInfo(
+	expectedBacking *vimtypes.VirtualSriovEthernetCardSriovBackingInfo,
+	baseBacking vimtypes.BaseVirtualDeviceBackingInfo) bool {
+
+	curBacking, ok := baseBacking.(*vimtypes.VirtualSriovEthernetCardSriovBackingInfo)
+	if !ok {
+		return false
+	}
+
+	// The virtual function is assigned by the host so only the physical
+	// function is compared, and only when it was not automatically assigned.
+	if pf := expectedBacking.PhysicalFunctionBacking; pf != nil && pf.Id != "" {
+		if curBacking.PhysicalFunctionBacking == nil || curBacking.PhysicalFunctionBacking.Id != pf.Id {
+			return false
+		}
+	}
+
+	return true
+}
+
 func MatchVirtualPCIPassthroughVmiopBackingInfo(
 	expectedBacking *vimtypes.VirtualPCIPassthroughVmiopBackingInfo,
 	baseBacking vimtypes.BaseVirtualDeviceBackingInfo) bool {
@@ -677,6 +677,66 @@ var _ = Describe("Backings", func() {
 		),
 	)
 
+	DescribeTable("MatchVirtualSriovEthernetCardSriovBackingInfo",
+		func(expected *vimtypes.VirtualSriovEthernetCardSriovBackingInfo, current vimtypes.BaseVirtualDeviceBackingInfo, match bool) {
+			m := resize.MatchVirtualSriovEthernetCardSriovBackingInfo(expected, current)
+			Expect(m).To(Equal(match), cmp.Diff(current, expected))
+		},
+
+		Entry("#1",
+			&vimtypes.VirtualSriovEthernetCardSriovBackingInfo{},
+			&vimtypes.VirtualNVDIMMBackingInfo{},
+			false,
+		),
+		Entry("#2",
+			&vimtypes.VirtualSriovEthernetCardSriovBackingInfo{},
+			&vimtypes.VirtualSriovEthernetCardSriovBackingInfo{
+				PhysicalFunctionBacking: &vimtypes.VirtualPCIPassthroughDeviceBackingInfo{
+					Id: "0000:3b:00.1",
+				},
+			},
+			true,
+		),
+		Entry("#3",
+			&vimtypes.VirtualSriovEthernetCardSriovBackingInfo{
+				PhysicalFunctionBacking: &vimtypes.VirtualPCIPassthroughDeviceBackingInfo{
+					Id: "0000:3b:00.1",
+				},
+			},
+			&vimtypes.VirtualSriovEthernetCardSriovBackingInfo{
+				PhysicalFunctionBacking: &vimtypes.VirtualPCIPassthroughDeviceBackingInfo{
+					Id: "0000:3b:00.1",
+				},
+				VirtualFunctionBacking: &vimtypes.VirtualPCIPassthroughDeviceBackingInfo{
+					Id: "0000:3b:02.0",
+				},
+			},
+			true,
+		),
+		Entry("#4",
+			&vimtypes.VirtualSriovEthernetCardSriovBackingInfo{
+				PhysicalFunctionBacking: &vimtypes.VirtualPCIPassthroughDeviceBackingInfo{
+					Id: "0000:3b:00.1",
+				},
+			},
+			&vimtypes.VirtualSriovEthernetCardSriovBackingInfo{
+				PhysicalFunctionBacking: &vimtypes.VirtualPCIPassthroughDeviceBackingInfo{
+					Id: "0000:3b:00.0",
+				},
+			},
+			false,
+		),
+		Entry("#5",
+			&vimtypes.VirtualSriovEthernetCardSriovBackingInfo{
+				PhysicalFunctionBacking: &vimtypes.VirtualPCIPassthroughDeviceBackingInfo{
+					Id: "0000:3b:00.1",
+				},
+			},
+			&vimtypes.VirtualSriovEthernetCardSriovBackingInfo{},
+			false,
+		),
+	)
+
 	DescribeTable("MatchVirtualPCIPassthroughVmiopBackingInfo",
 		func(expected *vimtypes.VirtualPCIPassthroughVmiopBackingInfo, current vimtypes.BaseVirtualDeviceBackingInfo, match bool) {
 			m := resize.MatchVirtualPCIPassthroughVmiopBackingInfo(expected, current)
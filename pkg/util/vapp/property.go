@@ -0,0 +1,268 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+// Package vapp validates the values of vApp properties against the types
+// defined by an OVF descriptor or a VM's vApp configuration.
+package vapp
+
+import (
+	"fmt"
+	"math"
+	"net/netip"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+type propertyKind int
+
+const (
+	// kindAny is used for types that are not validated, ex. expression.
+	kindAny propertyKind = iota
+	kindString
+	kindPassword
+	kindInt
+	kindReal
+	kindBoolean
+	kindIP
+	kindEnum
+)
+
+// PropertyType is a parsed vApp property type. The zero value accepts any
+// value.
+type PropertyType struct {
+	kind    propertyKind
+	min     *float64
+	max     *float64
+	choices []string
+}
+
+// ovfIntRanges are the ranges of the OVF integer types, which vSphere converts
+// to the int(min..max) type.
+var ovfIntRanges = map[string][2]float64{
+	"uint8":  {0, math.MaxUint8},
+	"sint8":  {math.MinInt8, math.MaxInt8},
+	"uint16": {0, math.MaxUint16},
+	"sint16": {math.MinInt16, math.MaxInt16},
+	"uint32": {0, math.MaxUint32},
+	"sint32": {math.MinInt32, math.MaxInt32},
+	"uint64": {0, math.MaxUint64},
+	"sint64": {math.MinInt64, math.MaxInt64},
+}
+
+// ParsePropertyType parses a vApp property type. The supported types are
+// those used by vSphere, ex. string, string(min..max), string["a","b"], int,
+// int(min..max), real, real(min..max), boolean, password,
+// password(min..max), ip, ip:network and expression, as well as the OVF types
+// uint8 through sint64, real32 and real64.
+//
+// An empty type or an expression results in a type that accepts any value.
+func ParsePropertyType(s string) (PropertyType, error) {
+	s = strings.TrimSpace(s)
+
+	if r, ok := ovfIntRanges[s]; ok {
+		return PropertyType{kind: kindInt, min: &r[0], max: &r[1]}, nil
+	}
+
+	name, qualifier := s, ""
+	if idx := strings.IndexAny(s, "([:"); idx >= 0 {
+		name, qualifier = s[:idx], s[idx:]
+	}
+
+	var t PropertyType
+
+	switch name {
+	case "", "expression":
+		return PropertyType{}, nil
+	case "string":
+		t.kind = kindString
+	case "password":
+		t.kind = kindPassword
+	case "int":
+		t.kind = kindInt
+	case "real", "real32", "real64":
+		t.kind = kindReal
+	case "boolean":
+		t.kind = kindBoolean
+	case "ip":
+		t.kind = kindIP
+	default:
+		return PropertyType{}, fmt.Errorf("unsupported type %q", s)
+	}
+
+	switch {
+	case qualifier == "":
+	case t.kind == kindIP && strings.HasPrefix(qualifier, ":"):
+		// The network qualifier refers to a vSphere network and cannot be
+		// validated.
+	case t.kind == kindString && strings.HasPrefix(qualifier, "["):
+		choices, err := parseChoices(qualifier)
+		if err != nil {
+			return PropertyType{}, fmt.Errorf("invalid type %q: %w", s, err)
+		}
+		t.kind = kindEnum
+		t.choices = choices
+	case t.kind != kindBoolean && t.kind != kindIP && strings.HasPrefix(qualifier, "("):
+		minVal, maxVal, err := parseRange(qualifier)
+		if err != nil {
+			return PropertyType{}, fmt.Errorf("invalid type %q: %w", s, err)
+		}
+		t.min, t.max = minVal, maxVal
+	default:
+		return PropertyType{}, fmt.Errorf("invalid type %q", s)
+	}
+
+	return t, nil
+}
+
+// parseRange parses a qualifier in the form (min..max), where either of min
+// or max may be omitted.
+func parseRange(s string) (*float64, *float64, error) {
+	if !strings.HasSuffix(s, ")") {
+		return nil, nil, fmt.Errorf("invalid range %q", s)
+	}
+	lhs, rhs, ok := strings.Cut(s[1:len(s)-1], "..")
+	if !ok {
+		return nil, nil, fmt.Errorf("invalid range %q", s)
+	}
+
+	parse := func(v string) (*float64, error) {
+		v = strings.TrimSpace(v)
+		if v == "" {
+			return nil, nil
+		}
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid range %q", s)
+		}
+		return &f, nil
+	}
+
+	minVal, err := parse(lhs)
+	if err != nil {
+		return nil, nil, err
+	}
+	maxVal, err := parse(rhs)
+	if err != nil {
+		return nil, nil, err
+	}
+	if minVal != nil && maxVal != nil && *minVal > *maxVal {
+		return nil, nil, fmt.Errorf("invalid range %q", s)
+	}
+	return minVal, maxVal, nil
+}
+
+// parseChoices parses a qualifier in the form ["a", "b"].
+func parseChoices(s string) ([]string, error) {
+	if !strings.HasSuffix(s, "]") {
+		return nil, fmt.Errorf("invalid choices %q", s)
+	}
+
+	var choices []string
+	for _, c := range strings.Split(s[1:len(s)-1], ",") {
+		c = strings.TrimSpace(c)
+		if uq, err := strconv.Unquote(c); err == nil {
+			c = uq
+		} else if len(c) >= 2 && c[0] == '\'' && c[len(c)-1] == '\'' {
+			c = c[1 : len(c)-1]
+		}
+		choices = append(choices, c)
+	}
+	return choices, nil
+}
+
+// AllowsEmptyValue returns true if an empty string is a valid value for the
+// type.
+func (t PropertyType) AllowsEmptyValue() bool {
+	return t.Validate("") == nil
+}
+
+// IsPassword returns true if the type is a password, in which case the value
+// should not be logged or included in error messages.
+func (t PropertyType) IsPassword() bool {
+	return t.kind == kindPassword
+}
+
+// Validate returns an error if the provided value is not valid for the type.
+func (t PropertyType) Validate(value string) error {
+	switch t.kind {
+	case kindString, kindPassword:
+		n := float64(utf8.RuneCountInString(value))
+		if t.min != nil && n < *t.min {
+			return fmt.Errorf("must be at least %s characters", formatFloat(*t.min))
+		}
+		if t.max != nil && n > *t.max {
+			return fmt.Errorf("must be at most %s characters", formatFloat(*t.max))
+		}
+
+	case kindInt:
+		if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+			if _, err := strconv.ParseUint(value, 10, 64); err != nil {
+				return fmt.Errorf("must be an integer")
+			}
+		}
+		f, _ := strconv.ParseFloat(value, 64)
+		if err := t.validateRange(f); err != nil {
+			return err
+		}
+
+	case kindReal:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+			return fmt.Errorf("must be a real number")
+		}
+		if err := t.validateRange(f); err != nil {
+			return err
+		}
+
+	case kindBoolean:
+		if value != "True" && value != "False" &&
+			value != "true" && value != "false" {
+
+			return fmt.Errorf("must be one of True, False")
+		}
+
+	case kindIP:
+		// vSphere allows an empty IP address, ex. when DHCP is used.
+		if value != "" {
+			if _, err := netip.ParseAddr(value); err != nil {
+				return fmt.Errorf("must be an IPv4 or IPv6 address")
+			}
+		}
+
+	case kindEnum:
+		for _, c := range t.choices {
+			if value == c {
+				return nil
+			}
+		}
+		return fmt.Errorf("must be one of %s", strings.Join(t.choices, ", "))
+	}
+
+	return nil
+}
+
+func (t PropertyType) validateRange(f float64) error {
+	if t.min != nil && f < *t.min {
+		return fmt.Errorf("must be greater than or equal to %s", formatFloat(*t.min))
+	}
+	if t.max != nil && f > *t.max {
+		return fmt.Errorf("must be less than or equal to %s", formatFloat(*t.max))
+	}
+	return nil
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
+
+// ValidatePropertyValue returns an error if the provided value is not valid
+// for the provided vApp property type.
+func ValidatePropertyValue(propType, value string) error {
+	t, err := ParsePropertyType(propType)
+	if err != nil {
+		return err
+	}
+	return t.Validate(value)
+}
@@ -0,0 +1,97 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package vapp_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"github.com/vmware-tanzu/vm-operator/pkg/util/vapp"
+)
+
+var _ = Describe("ValidatePropertyValue", func() {
+	DescribeTable("valid values",
+		func(propType, value string) {
+			Expect(vapp.ValidatePropertyValue(propType, value)).To(Succeed())
+		},
+		Entry("no type", "", "anything"),
+		Entry("expression", "expression", "${foo}"),
+		Entry("string", "string", ""),
+		Entry("string with range", "string(1..3)", "abc"),
+		Entry("string with min", "string(2..)", "abcdef"),
+		Entry("password", "password", "secret"),
+		Entry("password with range", "password(8..64)", "password"),
+		Entry("int", "int", "-42"),
+		Entry("int with range", "int(1..65535)", "443"),
+		Entry("uint8", "uint8", "255"),
+		Entry("uint64", "uint64", "18446744073709551615"),
+		Entry("real", "real", "3.14"),
+		Entry("real with range", "real(-1.5..1.5)", "-1.5"),
+		Entry("boolean True", "boolean", "True"),
+		Entry("boolean false", "boolean", "false"),
+		Entry("ip empty", "ip", ""),
+		Entry("ipv4", "ip", "192.168.1.10"),
+		Entry("ipv6", "ip", "fd00::10"),
+		Entry("ip with network", "ip:VM Network", "10.0.0.1"),
+		Entry("choice", `string["a", "b"]`, "b"),
+		Entry("choice with single quotes", `string['small','large']`, "large"),
+		Entry("empty choice", `string["", "a"]`, ""),
+	)
+
+	DescribeTable("invalid values",
+		func(propType, value, expectedErr string) {
+			Expect(vapp.ValidatePropertyValue(propType, value)).To(MatchError(expectedErr))
+		},
+		Entry("string too short", "string(2..)", "a", "must be at least 2 characters"),
+		Entry("string too long", "string(..3)", "abcd", "must be at most 3 characters"),
+		Entry("password too short", "password(8..)", "secret", "must be at least 8 characters"),
+		Entry("int not a number", "int", "forty-two", "must be an integer"),
+		Entry("int is real", "int", "4.2", "must be an integer"),
+		Entry("int empty", "int", "", "must be an integer"),
+		Entry("int below range", "int(1..65535)", "0", "must be greater than or equal to 1"),
+		Entry("int above range", "int(1..65535)", "65536", "must be less than or equal to 65535"),
+		Entry("uint8 above range", "uint8", "256", "must be less than or equal to 255"),
+		Entry("real", "real", "pi", "must be a real number"),
+		Entry("real above range", "real(..1.5)", "2", "must be less than or equal to 1.5"),
+		Entry("boolean", "boolean", "yes", "must be one of True, False"),
+		Entry("ip", "ip", "192.168.1.300", "must be an IPv4 or IPv6 address"),
+		Entry("ip with prefix", "ip", "192.168.1.10/24", "must be an IPv4 or IPv6 address"),
+		Entry("choice", `string["a", "b"]`, "c", "must be one of a, b"),
+		Entry("unsupported type", "date", "today", `unsupported type "date"`),
+		Entry("invalid range", "int(a..b)", "1", `invalid type "int(a..b)": invalid range "(a..b)"`),
+		Entry("inverted range", "int(5..1)", "1", `invalid type "int(5..1)": invalid range "(5..1)"`),
+		Entry("boolean with range", "boolean(1..2)", "True", `invalid type "boolean(1..2)"`),
+	)
+})
+
+var _ = Describe("PropertyType", func() {
+	DescribeTable("AllowsEmptyValue",
+		func(propType string, expected bool) {
+			t, err := vapp.ParsePropertyType(propType)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(t.AllowsEmptyValue()).To(Equal(expected))
+		},
+		Entry("no type", "", true),
+		Entry("string", "string", true),
+		Entry("string with min", "string(1..)", false),
+		Entry("password", "password", true),
+		Entry("ip", "ip", true),
+		Entry("int", "int", false),
+		Entry("real", "real", false),
+		Entry("boolean", "boolean", false),
+		Entry("choice", `string["a", "b"]`, false),
+	)
+
+	DescribeTable("IsPassword",
+		func(propType string, expected bool) {
+			t, err := vapp.ParsePropertyType(propType)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(t.IsPassword()).To(Equal(expected))
+		},
+		Entry("string", "string", false),
+		Entry("password", "password", true),
+		Entry("password with range", "password(8..)", true),
+	)
+})
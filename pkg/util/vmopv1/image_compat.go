@@ -0,0 +1,314 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package vmopv1
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/constants"
+	pkgutil "github.com/vmware-tanzu/vm-operator/pkg/util"
+)
+
+// ImageCapabilityNvidiaGPU is the image capability that indicates the image
+// supports NVIDIA vGPU devices.
+const ImageCapabilityNvidiaGPU = "nvidia-gpu"
+
+// ImageCompatibilityIssue describes why a VM's image may not be compatible
+// with the VM's class, boot options, or the hosts on which the VM is placed.
+type ImageCompatibilityIssue struct {
+	// Reason is the reason for the issue, ex.
+	// vmopv1.VirtualMachineImageFirmwareMismatchReason.
+	Reason string
+
+	// Message describes the issue.
+	Message string
+
+	// Fatal is true if the VM cannot be created or would fail to power on
+	// because of the issue. Otherwise the issue is a warning.
+	Fatal bool
+}
+
+func (i ImageCompatibilityIssue) String() string {
+	return fmt.Sprintf("%s: %s", i.Reason, i.Message)
+}
+
+// ImageCompatibilityIssues is a list of ImageCompatibilityIssue.
+type ImageCompatibilityIssues []ImageCompatibilityIssue
+
+// Fatal returns the fatal issues.
+func (l ImageCompatibilityIssues) Fatal() ImageCompatibilityIssues {
+	var fatal ImageCompatibilityIssues
+	for _, i := range l {
+		if i.Fatal {
+			fatal = append(fatal, i)
+		}
+	}
+	return fatal
+}
+
+// Warnings returns the issues that are not fatal.
+func (l ImageCompatibilityIssues) Warnings() ImageCompatibilityIssues {
+	var warnings ImageCompatibilityIssues
+	for _, i := range l {
+		if !i.Fatal {
+			warnings = append(warnings, i)
+		}
+	}
+	return warnings
+}
+
+// GuestOSDescriptorFn returns the descriptor of the guest OS with the provided
+// ID, or nil if it is not known.
+type GuestOSDescriptorFn func(guestID string) *vimtypes.GuestOsDescriptor
+
+// CheckImageCompatibility returns the issues that may prevent the provided VM
+// from being deployed from an image with the provided status when it uses the
+// provided class.
+//
+// The maxHardwareVersion is the highest hardware version supported by the
+// hosts on which the VM is placed. If it is zero then the image's hardware
+// version is only checked against the one with which the VM is created.
+//
+// The getGuestOS function is used to get the descriptor of the VM's guest OS
+// when it is needed. It may be nil, in which case the guest OS is only checked
+// using its ID.
+func CheckImageCompatibility(
+	vm vmopv1.VirtualMachine,
+	classSpec vmopv1.VirtualMachineClassSpec,
+	imgStatus vmopv1.VirtualMachineImageStatus,
+	maxHardwareVersion vimtypes.HardwareVersion,
+	getGuestOS GuestOSDescriptorFn) ImageCompatibilityIssues {
+
+	var configSpec vimtypes.VirtualMachineConfigSpec
+	if len(classSpec.ConfigSpec) > 0 {
+		// An invalid ConfigSpec is reported by the class, so it is treated as
+		// if there were none.
+		configSpec, _ = pkgutil.UnmarshalConfigSpecFromJSON(classSpec.ConfigSpec)
+	}
+
+	var issues ImageCompatibilityIssues
+	issues = append(issues, checkImageHardwareVersion(vm, configSpec, imgStatus, maxHardwareVersion)...)
+	issues = append(issues, checkImageFirmware(vm, configSpec, imgStatus)...)
+	issues = append(issues, checkImageVGPU(vm, classSpec, configSpec, imgStatus, getGuestOS)...)
+
+	return issues
+}
+
+func checkImageHardwareVersion(
+	vm vmopv1.VirtualMachine,
+	configSpec vimtypes.VirtualMachineConfigSpec,
+	imgStatus vmopv1.VirtualMachineImageStatus,
+	maxHardwareVersion vimtypes.HardwareVersion) ImageCompatibilityIssues {
+
+	if imgStatus.HardwareVersion == nil {
+		return nil
+	}
+	imageVersion := vimtypes.HardwareVersion(*imgStatus.HardwareVersion) //nolint:gosec // disable G115
+
+	var issues ImageCompatibilityIssues
+
+	if maxHardwareVersion.IsValid() && imageVersion > maxHardwareVersion {
+		issues = append(issues, ImageCompatibilityIssue{
+			Reason: vmopv1.VirtualMachineImageHardwareVersionNotSupportedReason,
+			Message: fmt.Sprintf(
+				"image hardware version %s is higher than %s, the highest version supported by the hosts",
+				imageVersion, maxHardwareVersion),
+			Fatal: true,
+		})
+	}
+
+	// A VM cannot be created from an image with a hardware version that is
+	// higher than the one with which the VM is created, since a VM's hardware
+	// version cannot be downgraded.
+	if vmVersion := DetermineHardwareVersion(vm, configSpec, imgStatus); vmVersion.IsValid() &&
+		imageVersion > vmVersion {
+
+		issues = append(issues, ImageCompatibilityIssue{
+			Reason: vmopv1.VirtualMachineImageHardwareVersionNotSupportedReason,
+			Message: fmt.Sprintf(
+				"image hardware version %s is higher than %s, the version with which the VM is created",
+				imageVersion, vmVersion),
+			Fatal: true,
+		})
+	}
+
+	return issues
+}
+
+func checkImageFirmware(
+	vm vmopv1.VirtualMachine,
+	configSpec vimtypes.VirtualMachineConfigSpec,
+	imgStatus vmopv1.VirtualMachineImageStatus) ImageCompatibilityIssues {
+
+	var issues ImageCompatibilityIssues
+
+	// The VM's firmware is determined the same way as when the VM is created:
+	// the firmware override annotation takes precedence over the image's
+	// firmware, which takes precedence over the class's ConfigSpec.
+	firmware := configSpec.Firmware
+	if imgStatus.Firmware != "" {
+		firmware = imgStatus.Firmware
+	}
+	if override := vm.Annotations[constants.FirmwareOverrideAnnotation]; override == "efi" || override == "bios" {
+		if imgStatus.Firmware != "" && !strings.EqualFold(override, imgStatus.Firmware) {
+			issues = append(issues, ImageCompatibilityIssue{
+				Reason: vmopv1.VirtualMachineImageFirmwareMismatchReason,
+				Message: fmt.Sprintf(
+					"firmware %s is not the image's firmware %s and the guest may not boot",
+					strings.ToUpper(override), strings.ToUpper(imgStatus.Firmware)),
+			})
+		}
+		firmware = override
+	}
+
+	// The VM's boot options take precedence over the class's ConfigSpec, the
+	// same way as when they are reconciled.
+	var secureBoot bool
+	if bo := configSpec.BootOptions; bo != nil && bo.EfiSecureBootEnabled != nil {
+		secureBoot = *bo.EfiSecureBootEnabled
+	}
+	if bo := vm.Spec.BootOptions; bo != nil && bo.EFISecureBoot != "" {
+		secureBoot = bo.EFISecureBoot == vmopv1.VirtualMachineBootOptionsEFISecureBootEnabled
+	}
+
+	if secureBoot && strings.EqualFold(firmware, string(vimtypes.GuestOsDescriptorFirmwareTypeBios)) {
+		issues = append(issues, ImageCompatibilityIssue{
+			Reason:  vmopv1.VirtualMachineImageSecureBootRequiresEFIReason,
+			Message: "secure boot is enabled but the firmware is BIOS",
+			Fatal:   true,
+		})
+	}
+
+	return issues
+}
+
+func checkImageVGPU(
+	vm vmopv1.VirtualMachine,
+	classSpec vmopv1.VirtualMachineClassSpec,
+	configSpec vimtypes.VirtualMachineConfigSpec,
+	imgStatus vmopv1.VirtualMachineImageStatus,
+	getGuestOS GuestOSDescriptorFn) ImageCompatibilityIssues {
+
+	hasVGPU := len(classSpec.Hardware.Devices.VGPUDevices) > 0
+	for i := range configSpec.DeviceChange {
+		if dc := configSpec.DeviceChange[i].GetVirtualDeviceConfigSpec(); dc != nil &&
+			pkgutil.IsDeviceNvidiaVgpu(dc.Device) {
+
+			hasVGPU = true
+			break
+		}
+	}
+	if !hasVGPU {
+		return nil
+	}
+
+	var issues ImageCompatibilityIssues
+
+	// The VM's guest ID is determined the same way as when the VM is created:
+	// the VM's guest ID takes precedence over the image's.
+	guestID := vm.Spec.GuestID
+	if guestID == "" {
+		guestID = imgStatus.OSInfo.Type
+	}
+	if guestID != "" {
+		var guestOS *vimtypes.GuestOsDescriptor
+		if getGuestOS != nil {
+			guestOS = getGuestOS(guestID)
+		}
+		issues = append(issues, checkGuestSupportsVGPU(guestID, guestOS)...)
+	}
+
+	if !slices.Contains(imgStatus.Capabilities, ImageCapabilityNvidiaGPU) {
+		issues = append(issues, ImageCompatibilityIssue{
+			Reason: vmopv1.VirtualMachineImageVGPUCapabilityMissingReason,
+			Message: fmt.Sprintf(
+				"the class has vGPU devices, but the image does not have the %s capability",
+				ImageCapabilityNvidiaGPU),
+		})
+	}
+
+	return issues
+}
+
+// guestIDs64Bit are the IDs of 64-bit guests that do not include "64".
+var guestIDs64Bit = []string{
+	string(vimtypes.VirtualMachineGuestOsIdentifierWindowsHyperVGuest),
+	string(vimtypes.VirtualMachineGuestOsIdentifierVmkernel5Guest),
+	string(vimtypes.VirtualMachineGuestOsIdentifierVmkernel6Guest),
+	string(vimtypes.VirtualMachineGuestOsIdentifierVmkernel65Guest),
+	string(vimtypes.VirtualMachineGuestOsIdentifierVmkernel7Guest),
+	string(vimtypes.VirtualMachineGuestOsIdentifierVmkernel8Guest),
+	string(vimtypes.VirtualMachineGuestOsIdentifierVmkernel9Guest),
+	string(vimtypes.VirtualMachineGuestOsIdentifierCrxPod1Guest),
+	string(vimtypes.VirtualMachineGuestOsIdentifierCrxSys1Guest),
+}
+
+// guestFamiliesNoVGPU are the guest families for which there are no NVIDIA
+// vGPU guest drivers.
+var guestFamiliesNoVGPU = []string{
+	string(vimtypes.VirtualMachineGuestOsFamilyDarwinGuestFamily),
+	string(vimtypes.VirtualMachineGuestOsFamilyNetwareGuest),
+	string(vimtypes.VirtualMachineGuestOsFamilySolarisGuest),
+}
+
+// checkGuestSupportsVGPU checks the guest supports NVIDIA vGPU devices, which
+// require a 64-bit Linux or Windows guest. The guest's family and bitness are
+// taken from its descriptor, if any, or its ID. If they cannot be determined
+// then a warning is returned.
+func checkGuestSupportsVGPU(
+	guestID string,
+	guestOS *vimtypes.GuestOsDescriptor) ImageCompatibilityIssues {
+
+	if guestOS != nil && slices.Contains(guestFamiliesNoVGPU, guestOS.Family) {
+		return ImageCompatibilityIssues{
+			{
+				Reason: vmopv1.VirtualMachineImageGuestNotSupportedForVGPUReason,
+				Message: fmt.Sprintf(
+					"the class has vGPU devices, which are not supported by the guest %s of family %s",
+					guestID, guestOS.Family),
+				Fatal: true,
+			},
+		}
+	}
+
+	// The descriptor's full name includes the guest's bitness, ex.
+	// "Ubuntu Linux (32-bit)", as do the IDs of most 64-bit guests, ex.
+	// ubuntu64Guest.
+	var fullName string
+	if guestOS != nil {
+		fullName = guestOS.FullName
+	}
+	switch {
+	case strings.Contains(fullName, "64-bit"),
+		strings.Contains(guestID, "64"),
+		slices.Contains(guestIDs64Bit, guestID):
+
+		return nil
+	case strings.Contains(fullName, "32-bit"):
+		return ImageCompatibilityIssues{
+			{
+				Reason: vmopv1.VirtualMachineImageGuestNotSupportedForVGPUReason,
+				Message: fmt.Sprintf(
+					"the class has vGPU devices, which are not supported by the 32-bit guest %s",
+					guestID),
+				Fatal: true,
+			},
+		}
+	}
+
+	return ImageCompatibilityIssues{
+		{
+			Reason: vmopv1.VirtualMachineImageGuestNotSupportedForVGPUReason,
+			Message: fmt.Sprintf(
+				"the class has vGPU devices, which require a 64-bit guest, but it is not known if the guest %s is 64-bit",
+				guestID),
+		},
+	}
+}
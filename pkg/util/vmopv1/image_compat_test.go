@@ -0,0 +1,370 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package vmopv1_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+	"k8s.io/utils/ptr"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/constants"
+	pkgutil "github.com/vmware-tanzu/vm-operator/pkg/util"
+	vmopv1util "github.com/vmware-tanzu/vm-operator/pkg/util/vmopv1"
+)
+
+var _ = Describe("CheckImageCompatibility", func() {
+
+	var (
+		vm                 vmopv1.VirtualMachine
+		classSpec          vmopv1.VirtualMachineClassSpec
+		configSpec         *vimtypes.VirtualMachineConfigSpec
+		imgStatus          vmopv1.VirtualMachineImageStatus
+		maxHardwareVersion vimtypes.HardwareVersion
+		guestOS            *vimtypes.GuestOsDescriptor
+		issues             vmopv1util.ImageCompatibilityIssues
+	)
+
+	BeforeEach(func() {
+		vm = vmopv1.VirtualMachine{}
+		classSpec = vmopv1.VirtualMachineClassSpec{}
+		configSpec = nil
+		imgStatus = vmopv1.VirtualMachineImageStatus{
+			Firmware:        "efi",
+			HardwareVersion: ptr.To[int32](19),
+			OSInfo: vmopv1.VirtualMachineImageOSInfo{
+				Type: "ubuntu64Guest",
+			},
+		}
+		maxHardwareVersion = 0
+		guestOS = nil
+	})
+
+	JustBeforeEach(func() {
+		if configSpec != nil {
+			var err error
+			classSpec.ConfigSpec, err = pkgutil.MarshalConfigSpecToJSON(*configSpec)
+			Expect(err).ToNot(HaveOccurred())
+		}
+		issues = vmopv1util.CheckImageCompatibility(
+			vm,
+			classSpec,
+			imgStatus,
+			maxHardwareVersion,
+			func(guestID string) *vimtypes.GuestOsDescriptor {
+				if guestOS != nil && guestOS.Id == guestID {
+					return guestOS
+				}
+				return nil
+			})
+	})
+
+	reasons := func(l vmopv1util.ImageCompatibilityIssues) []string {
+		var r []string
+		for _, i := range l {
+			r = append(r, i.Reason)
+		}
+		return r
+	}
+
+	When("the image is compatible", func() {
+		It("returns no issues", func() {
+			Expect(issues).To(BeEmpty())
+		})
+	})
+
+	When("the image's hardware version is higher than the max supported by the hosts", func() {
+		BeforeEach(func() {
+			maxHardwareVersion = vimtypes.VMX17
+		})
+
+		It("returns a fatal issue", func() {
+			Expect(reasons(issues.Fatal())).To(ConsistOf(
+				vmopv1.VirtualMachineImageHardwareVersionNotSupportedReason))
+		})
+	})
+
+	When("the image's hardware version is not higher than the max supported by the hosts", func() {
+		BeforeEach(func() {
+			maxHardwareVersion = vimtypes.VMX19
+		})
+
+		It("returns no issues", func() {
+			Expect(issues).To(BeEmpty())
+		})
+	})
+
+	When("the image's hardware version is higher than the class's", func() {
+		BeforeEach(func() {
+			configSpec = &vimtypes.VirtualMachineConfigSpec{Version: "vmx-17"}
+		})
+
+		It("returns a fatal issue", func() {
+			Expect(reasons(issues.Fatal())).To(ConsistOf(
+				vmopv1.VirtualMachineImageHardwareVersionNotSupportedReason))
+		})
+
+		When("the VM's min hardware version is not lower than the image's", func() {
+			BeforeEach(func() {
+				vm.Spec.MinHardwareVersion = 19
+			})
+
+			It("returns no issues", func() {
+				Expect(issues).To(BeEmpty())
+			})
+		})
+	})
+
+	When("the VM's min hardware version is lower than the image's", func() {
+		BeforeEach(func() {
+			vm.Spec.MinHardwareVersion = 17
+		})
+
+		It("returns a fatal issue", func() {
+			Expect(reasons(issues.Fatal())).To(ConsistOf(
+				vmopv1.VirtualMachineImageHardwareVersionNotSupportedReason))
+		})
+	})
+
+	When("the class's firmware is not the image's", func() {
+		BeforeEach(func() {
+			configSpec = &vimtypes.VirtualMachineConfigSpec{Firmware: "bios"}
+		})
+
+		It("returns no issues since the image's firmware is used", func() {
+			Expect(issues).To(BeEmpty())
+		})
+	})
+
+	When("the firmware override is not the image's firmware", func() {
+		BeforeEach(func() {
+			vm.Annotations = map[string]string{
+				constants.FirmwareOverrideAnnotation: "bios",
+			}
+		})
+
+		It("returns a warning", func() {
+			Expect(issues.Fatal()).To(BeEmpty())
+			Expect(reasons(issues.Warnings())).To(ConsistOf(
+				vmopv1.VirtualMachineImageFirmwareMismatchReason))
+		})
+	})
+
+	When("the firmware override is invalid", func() {
+		BeforeEach(func() {
+			vm.Annotations = map[string]string{
+				constants.FirmwareOverrideAnnotation: "uefi",
+			}
+		})
+
+		It("returns no issues since the override is ignored", func() {
+			Expect(issues).To(BeEmpty())
+		})
+	})
+
+	When("secure boot is enabled", func() {
+		BeforeEach(func() {
+			vm.Spec.BootOptions = &vmopv1.VirtualMachineBootOptions{
+				EFISecureBoot: vmopv1.VirtualMachineBootOptionsEFISecureBootEnabled,
+			}
+		})
+
+		It("returns no issues", func() {
+			Expect(issues).To(BeEmpty())
+		})
+
+		When("the image's firmware is BIOS", func() {
+			BeforeEach(func() {
+				imgStatus.Firmware = "bios"
+			})
+
+			It("returns a fatal issue", func() {
+				Expect(reasons(issues.Fatal())).To(ConsistOf(
+					vmopv1.VirtualMachineImageSecureBootRequiresEFIReason))
+			})
+		})
+
+		When("the image does not specify a firmware and the class's firmware is BIOS", func() {
+			BeforeEach(func() {
+				imgStatus.Firmware = ""
+				configSpec = &vimtypes.VirtualMachineConfigSpec{Firmware: "bios"}
+			})
+
+			It("returns a fatal issue", func() {
+				Expect(reasons(issues.Fatal())).To(ConsistOf(
+					vmopv1.VirtualMachineImageSecureBootRequiresEFIReason))
+			})
+		})
+
+		When("the firmware override is BIOS", func() {
+			BeforeEach(func() {
+				vm.Annotations = map[string]string{
+					constants.FirmwareOverrideAnnotation: "bios",
+				}
+			})
+
+			It("returns a fatal issue and a warning", func() {
+				Expect(reasons(issues.Fatal())).To(ConsistOf(
+					vmopv1.VirtualMachineImageSecureBootRequiresEFIReason))
+				Expect(reasons(issues.Warnings())).To(ConsistOf(
+					vmopv1.VirtualMachineImageFirmwareMismatchReason))
+			})
+		})
+	})
+
+	When("secure boot is enabled by the class and disabled by the VM", func() {
+		BeforeEach(func() {
+			imgStatus.Firmware = "bios"
+			configSpec = &vimtypes.VirtualMachineConfigSpec{
+				BootOptions: &vimtypes.VirtualMachineBootOptions{
+					EfiSecureBootEnabled: ptr.To(true),
+				},
+			}
+			vm.Spec.BootOptions = &vmopv1.VirtualMachineBootOptions{
+				EFISecureBoot: vmopv1.VirtualMachineBootOptionsEFISecureBootDisabled,
+			}
+		})
+
+		It("returns no issues", func() {
+			Expect(issues).To(BeEmpty())
+		})
+	})
+
+	When("the class has vGPU devices", func() {
+		BeforeEach(func() {
+			classSpec.Hardware.Devices.VGPUDevices = []vmopv1.VGPUDevice{
+				{ProfileName: "grid_v100-4q"},
+			}
+			imgStatus.Capabilities = []string{vmopv1util.ImageCapabilityNvidiaGPU}
+		})
+
+		It("returns no issues", func() {
+			Expect(issues).To(BeEmpty())
+		})
+
+		When("the image's guest is 32-bit", func() {
+			BeforeEach(func() {
+				imgStatus.OSInfo.Type = "ubuntuGuest"
+				guestOS = &vimtypes.GuestOsDescriptor{
+					Id:       "ubuntuGuest",
+					Family:   string(vimtypes.VirtualMachineGuestOsFamilyLinuxGuest),
+					FullName: "Ubuntu Linux (32-bit)",
+				}
+			})
+
+			It("returns a fatal issue", func() {
+				Expect(reasons(issues.Fatal())).To(ConsistOf(
+					vmopv1.VirtualMachineImageGuestNotSupportedForVGPUReason))
+			})
+
+			When("the VM's guest ID is 64-bit", func() {
+				BeforeEach(func() {
+					vm.Spec.GuestID = "ubuntu64Guest"
+				})
+
+				It("returns no issues", func() {
+					Expect(issues).To(BeEmpty())
+				})
+			})
+		})
+
+		When("the guest's bitness cannot be determined", func() {
+			BeforeEach(func() {
+				imgStatus.OSInfo.Type = "centosGuest"
+			})
+
+			It("returns a warning", func() {
+				Expect(issues.Fatal()).To(BeEmpty())
+				Expect(reasons(issues.Warnings())).To(ConsistOf(
+					vmopv1.VirtualMachineImageGuestNotSupportedForVGPUReason))
+			})
+		})
+
+		DescribeTable("64-bit guests whose IDs do not include 64",
+			func(guestID string) {
+				imgStatus.OSInfo.Type = guestID
+				Expect(vmopv1util.CheckImageCompatibility(
+					vm, classSpec, imgStatus, maxHardwareVersion, nil)).To(BeEmpty())
+			},
+			Entry("crxPod1Guest", "crxPod1Guest"),
+			Entry("windowsHyperVGuest", "windowsHyperVGuest"),
+			Entry("vmkernel8Guest", "vmkernel8Guest"),
+		)
+
+		When("the guest's descriptor shows it is 64-bit", func() {
+			BeforeEach(func() {
+				imgStatus.OSInfo.Type = "centosGuest"
+				guestOS = &vimtypes.GuestOsDescriptor{
+					Id:       "centosGuest",
+					Family:   string(vimtypes.VirtualMachineGuestOsFamilyLinuxGuest),
+					FullName: "CentOS 4/5 (64-bit)",
+				}
+			})
+
+			It("returns no issues", func() {
+				Expect(issues).To(BeEmpty())
+			})
+		})
+
+		When("the guest's family does not support vGPU", func() {
+			BeforeEach(func() {
+				imgStatus.OSInfo.Type = "darwin20_64Guest"
+				guestOS = &vimtypes.GuestOsDescriptor{
+					Id:     "darwin20_64Guest",
+					Family: string(vimtypes.VirtualMachineGuestOsFamilyDarwinGuestFamily),
+				}
+			})
+
+			It("returns a fatal issue", func() {
+				Expect(reasons(issues.Fatal())).To(ConsistOf(
+					vmopv1.VirtualMachineImageGuestNotSupportedForVGPUReason))
+			})
+		})
+
+		When("the image does not have the nvidia-gpu capability", func() {
+			BeforeEach(func() {
+				imgStatus.Capabilities = nil
+			})
+
+			It("returns a warning", func() {
+				Expect(issues.Fatal()).To(BeEmpty())
+				Expect(reasons(issues.Warnings())).To(ConsistOf(
+					vmopv1.VirtualMachineImageVGPUCapabilityMissingReason))
+			})
+		})
+	})
+
+	When("the class's ConfigSpec has vGPU devices", func() {
+		BeforeEach(func() {
+			imgStatus.OSInfo.Type = "otherGuest"
+			guestOS = &vimtypes.GuestOsDescriptor{
+				Id:       "otherGuest",
+				Family:   string(vimtypes.VirtualMachineGuestOsFamilyOtherGuestFamily),
+				FullName: "Other (32-bit)",
+			}
+			configSpec = &vimtypes.VirtualMachineConfigSpec{
+				DeviceChange: []vimtypes.BaseVirtualDeviceConfigSpec{
+					&vimtypes.VirtualDeviceConfigSpec{
+						Operation: vimtypes.VirtualDeviceConfigSpecOperationAdd,
+						Device: &vimtypes.VirtualPCIPassthrough{
+							VirtualDevice: vimtypes.VirtualDevice{
+								Backing: &vimtypes.VirtualPCIPassthroughVmiopBackingInfo{
+									Vgpu: "grid_v100-4q",
+								},
+							},
+						},
+					},
+				},
+			}
+		})
+
+		It("returns a fatal issue", func() {
+			Expect(reasons(issues.Fatal())).To(ConsistOf(
+				vmopv1.VirtualMachineImageGuestNotSupportedForVGPUReason))
+		})
+	})
+})
@@ -22,6 +22,7 @@ import (
 
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
 	byokv1 "github.com/vmware-tanzu/vm-operator/external/byok/api/v1alpha1"
+	pkgcnd "github.com/vmware-tanzu/vm-operator/pkg/conditions"
 	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
 	"github.com/vmware-tanzu/vm-operator/pkg/constants"
 	pkgutil "github.com/vmware-tanzu/vm-operator/pkg/util"
@@ -98,8 +99,6 @@ func ResolveImageName(
 		return obj, nil
 	}
 
-	var obj client.Object
-
 	// Check if a single namespace scope image exists by the status name.
 	var vmiList vmopv1.VirtualMachineImageList
 	if err := k8sClient.List(ctx, &vmiList, client.InNamespace(namespace),
@@ -109,6 +108,24 @@ func ResolveImageName(
 	); err != nil {
 		return nil, err
 	}
+
+	// Check if a single cluster scope image exists by the status name.
+	var cvmiList vmopv1.ClusterVirtualMachineImageList
+	if err := k8sClient.List(ctx, &cvmiList, client.MatchingFields{
+		"status.name": imgName,
+	}); err != nil {
+		return nil, err
+	}
+
+	// If every image with the display name has the same content, such as when
+	// the same OVF is in multiple libraries, then the images are duplicates of
+	// one another and the name resolves to one of them.
+	if obj := selectDuplicateImage(vmiList.Items, cvmiList.Items); obj != nil {
+		return obj, nil
+	}
+
+	var obj client.Object
+
 	switch len(vmiList.Items) {
 	case 0:
 		break
@@ -119,13 +136,6 @@ func ResolveImageName(
 			"multiple VM images exist for %q in namespace scope", imgName)
 	}
 
-	// Check if a single cluster scope image exists by the status name.
-	var cvmiList vmopv1.ClusterVirtualMachineImageList
-	if err := k8sClient.List(ctx, &cvmiList, client.MatchingFields{
-		"status.name": imgName,
-	}); err != nil {
-		return nil, err
-	}
 	switch len(cvmiList.Items) {
 	case 0:
 		break
@@ -149,6 +159,73 @@ func ResolveImageName(
 	return obj, nil
 }
 
+// selectDuplicateImage returns the preferred image from the provided images if
+// there is more than one image, they are all in the same scope, and they all
+// have the same fingerprint. Otherwise nil is returned.
+//
+// Images in different scopes are never selected, since a namespace scoped
+// image with the same display name as a cluster scoped image could otherwise
+// be used in its place, even though the fingerprint of an image in the
+// namespace is not more trustworthy than its name.
+//
+// Ready images are preferred to those that are not. Otherwise the image with
+// the lowest name is selected so the result is stable.
+func selectDuplicateImage(
+	vmis []vmopv1.VirtualMachineImage,
+	cvmis []vmopv1.ClusterVirtualMachineImage) client.Object {
+
+	if len(vmis) > 0 && len(cvmis) > 0 {
+		return nil
+	}
+	if len(vmis)+len(cvmis) < 2 {
+		return nil
+	}
+
+	var (
+		fingerprint string
+		objs        = make([]client.Object, 0, len(vmis)+len(cvmis))
+		statuses    = make([]vmopv1.VirtualMachineImageStatus, 0, cap(objs))
+	)
+	for i := range vmis {
+		objs = append(objs, &vmis[i])
+		statuses = append(statuses, vmis[i].Status)
+	}
+	for i := range cvmis {
+		objs = append(objs, &cvmis[i])
+		statuses = append(statuses, cvmis[i].Status)
+	}
+
+	for i := range statuses {
+		f := statuses[i].Fingerprint
+		if f == "" || (fingerprint != "" && f != fingerprint) {
+			return nil
+		}
+		fingerprint = f
+	}
+
+	best := -1
+	for i := range objs {
+		if best == -1 {
+			best = i
+			continue
+		}
+		var (
+			iReady    = pkgcnd.IsTrue(statuses[i], vmopv1.ReadyConditionType)
+			bestReady = pkgcnd.IsTrue(statuses[best], vmopv1.ReadyConditionType)
+		)
+		switch {
+		case iReady != bestReady:
+			if iReady {
+				best = i
+			}
+		case objs[i].GetName() < objs[best].GetName():
+			best = i
+		}
+	}
+
+	return objs[best]
+}
+
 // DetermineHardwareVersion returns the hardware version recommended for the
 // provided VirtualMachine based on its own spec.minHardwareVersion, as well as
 // the hardware in the provided ConfigSpec and requirements of the given
@@ -25,6 +25,7 @@ import (
 
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
 	byokv1 "github.com/vmware-tanzu/vm-operator/external/byok/api/v1alpha1"
+	pkgcnd "github.com/vmware-tanzu/vm-operator/pkg/conditions"
 	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
 	pkgconst "github.com/vmware-tanzu/vm-operator/pkg/constants"
 	"github.com/vmware-tanzu/vm-operator/pkg/util/kube/cource"
@@ -219,6 +220,116 @@ var _ = Describe("ResolveImageName", func() {
 		})
 	})
 
+	When("name matches duplicate images", func() {
+		const (
+			dupImgName  = "image-f"
+			fingerprint = "sha256:fingerprint"
+		)
+
+		var dupImgs []ctrlclient.Object
+
+		newDupImgFn := func(id, namespace string, ready bool) ctrlclient.Object {
+			var (
+				obj    ctrlclient.Object
+				status *vmopv1.VirtualMachineImageStatus
+			)
+			if namespace != "" {
+				img := builder.DummyVirtualMachineImage(id)
+				img.Namespace = namespace
+				obj, status = img, &img.Status
+			} else {
+				img := builder.DummyClusterVirtualMachineImage(id)
+				obj, status = img, &img.Status
+			}
+			status.Name = dupImgName
+			status.Fingerprint = fingerprint
+			if ready {
+				pkgcnd.MarkTrue(status, vmopv1.ReadyConditionType)
+			}
+			return obj
+		}
+
+		BeforeEach(func() {
+			name = dupImgName
+			dupImgs = []ctrlclient.Object{
+				newDupImgFn("vmi-10", actualNamespace, true),
+				newDupImgFn("vmi-11", actualNamespace, true),
+				newDupImgFn("vmi-12", actualNamespace, false),
+			}
+		})
+
+		JustBeforeEach(func() {
+			for _, o := range dupImgs {
+				Expect(client.Create(context.Background(), o)).To(Succeed())
+			}
+			obj, err = vmopv1util.ResolveImageName(
+				context.Background(), client, namespace, name)
+		})
+
+		It("should return the ready image with the lowest name", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(obj).To(BeAssignableToTypeOf(&vmopv1.VirtualMachineImage{}))
+			Expect(obj.GetName()).To(Equal("vmi-10"))
+		})
+
+		When("the images are cluster-scoped", func() {
+			BeforeEach(func() {
+				dupImgs = []ctrlclient.Object{
+					newDupImgFn("vmi-14", "", false),
+					newDupImgFn("vmi-15", "", true),
+				}
+			})
+			It("should return the ready image", func() {
+				Expect(err).ToNot(HaveOccurred())
+				Expect(obj).To(BeAssignableToTypeOf(&vmopv1.ClusterVirtualMachineImage{}))
+				Expect(obj.GetName()).To(Equal("vmi-15"))
+			})
+		})
+
+		When("the images are in different scopes", func() {
+			BeforeEach(func() {
+				dupImgs = []ctrlclient.Object{
+					newDupImgFn("vmi-9", "", true),
+					newDupImgFn("vmi-10", actualNamespace, true),
+				}
+			})
+			It("should return an error", func() {
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal(fmt.Sprintf("multiple VM images exist for %q in namespace and cluster scope", dupImgName)))
+				Expect(obj).To(BeNil())
+			})
+		})
+
+		When("one of the images has a different fingerprint", func() {
+			BeforeEach(func() {
+				img := newDupImgFn("vmi-13", actualNamespace, true).(*vmopv1.VirtualMachineImage)
+				img.Status.Fingerprint = "sha256:other"
+				dupImgs = append(dupImgs, img)
+			})
+			It("should return an error", func() {
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal(fmt.Sprintf("multiple VM images exist for %q in namespace scope", dupImgName)))
+				Expect(obj).To(BeNil())
+			})
+		})
+
+		When("one of the images does not have a fingerprint", func() {
+			BeforeEach(func() {
+				img := newDupImgFn("vmi-13", "", true).(*vmopv1.ClusterVirtualMachineImage)
+				img.Status.Fingerprint = ""
+				dupImgs = []ctrlclient.Object{
+					newDupImgFn("vmi-9", "", true),
+					img,
+				}
+			})
+			It("should return an error", func() {
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(Equal(fmt.Sprintf("multiple VM images exist for %q in cluster scope", dupImgName)))
+				Expect(obj).To(BeNil())
+			})
+		})
+	})
+
 	When("name does not match any namespace or cluster-scoped images", func() {
 		const invalidImageID = "invalid"
 		BeforeEach(func() {
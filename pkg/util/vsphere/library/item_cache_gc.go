@@ -0,0 +1,309 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package library
+
+import (
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/vmware/govmomi/object"
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+)
+
+// ItemCacheEvictionReason describes why a cache directory was selected for
+// eviction.
+type ItemCacheEvictionReason string
+
+const (
+	// ItemCacheEvictionReasonSuperseded indicates the cache directory is not
+	// for the current version of a cached library item.
+	ItemCacheEvictionReasonSuperseded ItemCacheEvictionReason = "Superseded"
+
+	// ItemCacheEvictionReasonUnused indicates the cache directory has not been
+	// used for longer than the maximum unused age.
+	ItemCacheEvictionReasonUnused ItemCacheEvictionReason = "Unused"
+
+	// ItemCacheEvictionReasonCount indicates the cache directory was evicted
+	// because the datastore has more than the maximum number of cached items.
+	ItemCacheEvictionReasonCount ItemCacheEvictionReason = "Count"
+
+	// ItemCacheEvictionReasonFreeSpace indicates the cache directory was
+	// evicted because the datastore's free space is below the watermark.
+	ItemCacheEvictionReasonFreeSpace ItemCacheEvictionReason = "FreeSpace"
+)
+
+// ItemCacheEntry describes a cache directory for a single version of a library
+// item on a datastore.
+type ItemCacheEntry struct {
+	// Dir is the datastore path to the cache directory, ex.
+	// [my-datastore] .contentlib-cache/item-id/version-hash.
+	Dir string
+
+	// ItemID is the ID of the library item.
+	ItemID string
+
+	// Current is true if the directory is for the current version of the
+	// library item.
+	Current bool
+
+	// LastUsed is the last time the directory was used. A zero value means
+	// the last use is unknown, and the directory is not evicted due to age.
+	LastUsed time.Time
+
+	// Size is the total size of the files in the directory in bytes.
+	Size int64
+
+	// References is the number of virtual disks that use a file in the
+	// directory as a parent, ex. linked clones.
+	References int
+
+	// Pinned is true if the directory should remain cached, ex. it is
+	// pre-warmed by a policy. A pinned directory is only evicted if it is not
+	// current.
+	Pinned bool
+}
+
+// ItemCacheGCPolicy describes the policy used to evict cache directories from
+// a datastore.
+type ItemCacheGCPolicy struct {
+	// MaxUnusedAge is how long a directory may go unused before it is evicted.
+	// A value of zero disables eviction by age.
+	MaxUnusedAge time.Duration
+
+	// MinUnusedAge is how long a directory must go unused before it may be
+	// evicted for any reason.
+	MinUnusedAge time.Duration
+
+	// MaxItems is the maximum number of current directories on the datastore.
+	// A value of zero disables eviction by count.
+	MaxItems int
+
+	// MinFreeSpacePercent is the percentage of the datastore's capacity that
+	// should remain free. A value of zero disables eviction by free space.
+	MinFreeSpacePercent float64
+}
+
+// DatastoreSpace describes the capacity and free space of a datastore in
+// bytes.
+type DatastoreSpace struct {
+	Capacity  int64
+	FreeSpace int64
+}
+
+// ItemCacheEviction is a cache directory selected for eviction.
+type ItemCacheEviction struct {
+	ItemCacheEntry
+	Reason ItemCacheEvictionReason
+}
+
+// SelectItemCacheEvictions returns the cache directories from a single
+// datastore that should be evicted according to the provided policy.
+//
+// A directory that is referenced by a virtual disk is never evicted, nor is a
+// directory that was used more recently than policy.MinUnusedAge. Otherwise,
+// directories that are not current are always evicted, current directories
+// that are not pinned are evicted once they have been unused for longer than
+// policy.MaxUnusedAge, and then the least recently used directories that are
+// not pinned are evicted until the number of current directories and the
+// datastore's free space satisfy the policy.
+func SelectItemCacheEvictions(
+	now time.Time,
+	policy ItemCacheGCPolicy,
+	entries []ItemCacheEntry,
+	space DatastoreSpace) []ItemCacheEviction {
+
+	var (
+		evictions  []ItemCacheEviction
+		candidates []ItemCacheEntry
+		numCurrent int
+		freeSpace  = space.FreeSpace
+	)
+
+	evict := func(e ItemCacheEntry, r ItemCacheEvictionReason) {
+		evictions = append(evictions, ItemCacheEviction{
+			ItemCacheEntry: e,
+			Reason:         r,
+		})
+		if e.Current {
+			numCurrent--
+		}
+		freeSpace += e.Size
+	}
+
+	for i := range entries {
+		if entries[i].Current {
+			numCurrent++
+		}
+	}
+
+	for i := range entries {
+		e := entries[i]
+		if e.References > 0 {
+			continue
+		}
+		if !e.LastUsed.IsZero() && now.Sub(e.LastUsed) < policy.MinUnusedAge {
+			continue
+		}
+		switch {
+		case !e.Current:
+			evict(e, ItemCacheEvictionReasonSuperseded)
+		case e.Pinned:
+			// Pinned directories are only evicted when superseded.
+		case policy.MaxUnusedAge > 0 &&
+			!e.LastUsed.IsZero() &&
+			now.Sub(e.LastUsed) > policy.MaxUnusedAge:
+
+			evict(e, ItemCacheEvictionReasonUnused)
+		case !e.LastUsed.IsZero():
+			candidates = append(candidates, e)
+		}
+	}
+
+	// Evict the least recently used directories first.
+	slices.SortStableFunc(candidates, func(a, b ItemCacheEntry) int {
+		return a.LastUsed.Compare(b.LastUsed)
+	})
+
+	belowWatermark := func() bool {
+		if policy.MinFreeSpacePercent <= 0 || space.Capacity <= 0 {
+			return false
+		}
+		pct := float64(freeSpace) / float64(space.Capacity) * 100
+		return pct < policy.MinFreeSpacePercent
+	}
+
+	for i := range candidates {
+		switch {
+		case policy.MaxItems > 0 && numCurrent > policy.MaxItems:
+			evict(candidates[i], ItemCacheEvictionReasonCount)
+		case belowWatermark():
+			evict(candidates[i], ItemCacheEvictionReasonFreeSpace)
+		}
+	}
+
+	return evictions
+}
+
+// GetItemCacheEntries returns the cache directories found in the results of a
+// recursive search of a top-level cache directory, ex. the results of
+// HostDatastoreBrowser.SearchDatastoreSubFolders. The LastUsed field of each
+// entry is set to the most recent modification time of the directory or its
+// files.
+func GetItemCacheEntries(
+	topLevelCacheDir string,
+	results []vimtypes.HostDatastoreBrowserSearchResults) []ItemCacheEntry {
+
+	var (
+		tld     object.DatastorePath
+		entries []ItemCacheEntry
+		indices = map[string]int{}
+	)
+
+	if !tld.FromString(topLevelCacheDir) {
+		return nil
+	}
+
+	getEntry := func(itemID, version string) *ItemCacheEntry {
+		dir := object.DatastorePath{
+			Datastore: tld.Datastore,
+			Path:      path.Join(tld.Path, itemID, version),
+		}
+		k := dir.String()
+		if i, ok := indices[k]; ok {
+			return &entries[i]
+		}
+		indices[k] = len(entries)
+		entries = append(entries, ItemCacheEntry{
+			Dir:    k,
+			ItemID: itemID,
+		})
+		return &entries[len(entries)-1]
+	}
+
+	updateLastUsed := func(e *ItemCacheEntry, t *time.Time) {
+		if t != nil && t.After(e.LastUsed) {
+			e.LastUsed = *t
+		}
+	}
+
+	for i := range results {
+		var folder object.DatastorePath
+		if !folder.FromString(results[i].FolderPath) ||
+			folder.Datastore != tld.Datastore {
+
+			continue
+		}
+
+		rel := strings.Trim(
+			strings.TrimPrefix(
+				path.Clean(folder.Path), path.Clean(tld.Path)), "/")
+		if rel == "" {
+			continue
+		}
+
+		parts := strings.Split(rel, "/")
+		switch len(parts) {
+		case 1:
+			// The results for an item directory include its version
+			// directories.
+			for _, bfi := range results[i].File {
+				fi := bfi.GetFileInfo()
+				if _, ok := bfi.(*vimtypes.FolderFileInfo); ok {
+					updateLastUsed(getEntry(parts[0], fi.Path), fi.Modification)
+				}
+			}
+		case 2:
+			// The results for a version directory include its files.
+			e := getEntry(parts[0], parts[1])
+			for _, bfi := range results[i].File {
+				fi := bfi.GetFileInfo()
+				e.Size += fi.FileSize
+				updateLastUsed(e, fi.Modification)
+			}
+		}
+	}
+
+	return entries
+}
+
+// GetParentDiskDirs returns the directories of the parent disks of the
+// provided virtual devices, ex. the cache directories used by linked clones,
+// and the number of times each directory is referenced.
+func GetParentDiskDirs(devices []vimtypes.BaseVirtualDevice) map[string]int {
+	dirs := map[string]int{}
+
+	add := func(fileName string) {
+		var p object.DatastorePath
+		if p.FromString(fileName) {
+			p.Path = path.Dir(p.Path)
+			dirs[p.String()]++
+		}
+	}
+
+	for i := range devices {
+		d, ok := devices[i].(*vimtypes.VirtualDisk)
+		if !ok {
+			continue
+		}
+		switch tBack := d.Backing.(type) {
+		case *vimtypes.VirtualDiskFlatVer2BackingInfo:
+			for p := tBack.Parent; p != nil; p = p.Parent {
+				add(p.FileName)
+			}
+		case *vimtypes.VirtualDiskSeSparseBackingInfo:
+			for p := tBack.Parent; p != nil; p = p.Parent {
+				add(p.FileName)
+			}
+		case *vimtypes.VirtualDiskSparseVer2BackingInfo:
+			for p := tBack.Parent; p != nil; p = p.Parent {
+				add(p.FileName)
+			}
+		}
+	}
+
+	return dirs
+}
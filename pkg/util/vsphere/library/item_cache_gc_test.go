@@ -0,0 +1,264 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package library_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	vimtypes "github.com/vmware/govmomi/vim25/types"
+
+	clsutil "github.com/vmware-tanzu/vm-operator/pkg/util/vsphere/library"
+)
+
+var _ = Describe("SelectItemCacheEvictions", func() {
+
+	var (
+		now     time.Time
+		policy  clsutil.ItemCacheGCPolicy
+		entries []clsutil.ItemCacheEntry
+		space   clsutil.DatastoreSpace
+	)
+
+	BeforeEach(func() {
+		now = time.Now()
+		policy = clsutil.ItemCacheGCPolicy{
+			MaxUnusedAge: 7 * 24 * time.Hour,
+			MinUnusedAge: time.Hour,
+		}
+		entries = nil
+		space = clsutil.DatastoreSpace{
+			Capacity:  1000,
+			FreeSpace: 500,
+		}
+	})
+
+	reasons := func() map[string]clsutil.ItemCacheEvictionReason {
+		out := map[string]clsutil.ItemCacheEvictionReason{}
+		for _, e := range clsutil.SelectItemCacheEvictions(
+			now, policy, entries, space) {
+
+			out[e.Dir] = e.Reason
+		}
+		return out
+	}
+
+	entry := func(
+		dir string,
+		current bool,
+		lastUsed time.Duration,
+		size int64) clsutil.ItemCacheEntry {
+
+		return clsutil.ItemCacheEntry{
+			Dir:      dir,
+			ItemID:   dir,
+			Current:  current,
+			LastUsed: now.Add(-lastUsed),
+			Size:     size,
+		}
+	}
+
+	When("there are superseded directories", func() {
+		BeforeEach(func() {
+			entries = []clsutil.ItemCacheEntry{
+				entry("current", true, 2*time.Hour, 10),
+				entry("old", false, 2*time.Hour, 10),
+				entry("recent", false, time.Minute, 10),
+			}
+		})
+		It("should evict the superseded directories that were not recently modified", func() {
+			Expect(reasons()).To(Equal(map[string]clsutil.ItemCacheEvictionReason{
+				"old": clsutil.ItemCacheEvictionReasonSuperseded,
+			}))
+		})
+	})
+
+	When("there are unused directories", func() {
+		BeforeEach(func() {
+			entries = []clsutil.ItemCacheEntry{
+				entry("unused", true, 8*24*time.Hour, 10),
+				entry("used", true, 2*time.Hour, 10),
+				{Dir: "unknown", Current: true},
+			}
+		})
+		It("should evict the directories unused for longer than the max age", func() {
+			Expect(reasons()).To(Equal(map[string]clsutil.ItemCacheEvictionReason{
+				"unused": clsutil.ItemCacheEvictionReasonUnused,
+			}))
+		})
+		When("the max age is zero", func() {
+			BeforeEach(func() {
+				policy.MaxUnusedAge = 0
+			})
+			It("should not evict any directories", func() {
+				Expect(reasons()).To(BeEmpty())
+			})
+		})
+	})
+
+	When("a directory is referenced", func() {
+		BeforeEach(func() {
+			e1 := entry("superseded", false, 2*time.Hour, 10)
+			e1.References = 1
+			e2 := entry("unused", true, 8*24*time.Hour, 10)
+			e2.References = 2
+			entries = []clsutil.ItemCacheEntry{e1, e2}
+			policy.MaxItems = 1
+		})
+		It("should never be evicted", func() {
+			Expect(reasons()).To(BeEmpty())
+		})
+	})
+
+	When("a directory is pinned", func() {
+		BeforeEach(func() {
+			e1 := entry("superseded", false, 2*time.Hour, 10)
+			e1.Pinned = true
+			e2 := entry("unused", true, 8*24*time.Hour, 10)
+			e2.Pinned = true
+			e3 := entry("lru", true, 5*time.Hour, 10)
+			e3.Pinned = true
+			entries = []clsutil.ItemCacheEntry{e1, e2, e3}
+			policy.MaxItems = 1
+		})
+		It("should only be evicted if superseded", func() {
+			Expect(reasons()).To(Equal(map[string]clsutil.ItemCacheEvictionReason{
+				"superseded": clsutil.ItemCacheEvictionReasonSuperseded,
+			}))
+		})
+	})
+
+	When("there are more directories than the max count", func() {
+		BeforeEach(func() {
+			policy.MaxItems = 2
+			entries = []clsutil.ItemCacheEntry{
+				entry("a", true, 3*time.Hour, 10),
+				entry("b", true, 5*time.Hour, 10),
+				entry("c", true, 4*time.Hour, 10),
+				entry("d", true, 30*time.Minute, 10),
+			}
+		})
+		It("should evict the least recently used directories", func() {
+			Expect(reasons()).To(Equal(map[string]clsutil.ItemCacheEvictionReason{
+				"b": clsutil.ItemCacheEvictionReasonCount,
+				"c": clsutil.ItemCacheEvictionReasonCount,
+			}))
+		})
+	})
+
+	When("the free space is below the watermark", func() {
+		BeforeEach(func() {
+			policy.MinFreeSpacePercent = 60
+			entries = []clsutil.ItemCacheEntry{
+				entry("a", true, 3*time.Hour, 60),
+				entry("b", true, 5*time.Hour, 60),
+				entry("c", true, 4*time.Hour, 60),
+				entry("d", true, 30*time.Minute, 500),
+				entry("old", false, 2*time.Hour, 20),
+			}
+		})
+		It("should evict the least recently used directories until the watermark is met", func() {
+			Expect(reasons()).To(Equal(map[string]clsutil.ItemCacheEvictionReason{
+				"old": clsutil.ItemCacheEvictionReasonSuperseded,
+				"b":   clsutil.ItemCacheEvictionReasonFreeSpace,
+				"c":   clsutil.ItemCacheEvictionReasonFreeSpace,
+			}))
+		})
+	})
+})
+
+var _ = Describe("GetItemCacheEntries", func() {
+	It("should return the version directories with their size and modification time", func() {
+		t1 := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		t2 := t1.Add(time.Hour)
+		t3 := t1.Add(2 * time.Hour)
+
+		results := []vimtypes.HostDatastoreBrowserSearchResults{
+			{
+				FolderPath: "[my-datastore] .contentlib-cache",
+				File: []vimtypes.BaseFileInfo{
+					&vimtypes.FolderFileInfo{FileInfo: vimtypes.FileInfo{Path: "item-1"}},
+				},
+			},
+			{
+				FolderPath: "[my-datastore] .contentlib-cache/item-1/",
+				File: []vimtypes.BaseFileInfo{
+					&vimtypes.FolderFileInfo{FileInfo: vimtypes.FileInfo{Path: "v1", Modification: &t1}},
+					&vimtypes.FolderFileInfo{FileInfo: vimtypes.FileInfo{Path: "v2", Modification: &t1}},
+				},
+			},
+			{
+				FolderPath: "[my-datastore] .contentlib-cache/item-1/v1",
+				File: []vimtypes.BaseFileInfo{
+					&vimtypes.VmDiskFileInfo{FileInfo: vimtypes.FileInfo{Path: "a.vmdk", FileSize: 10, Modification: &t2}},
+					&vimtypes.FileInfo{Path: "b.nvram", FileSize: 5, Modification: &t3},
+				},
+			},
+			{
+				FolderPath: "[other-datastore] .contentlib-cache/item-1/v3",
+				File: []vimtypes.BaseFileInfo{
+					&vimtypes.FileInfo{Path: "c.vmdk", FileSize: 1},
+				},
+			},
+		}
+
+		Expect(clsutil.GetItemCacheEntries(
+			"[my-datastore] .contentlib-cache", results)).To(ConsistOf(
+			clsutil.ItemCacheEntry{
+				Dir:      "[my-datastore] .contentlib-cache/item-1/v1",
+				ItemID:   "item-1",
+				LastUsed: t3,
+				Size:     15,
+			},
+			clsutil.ItemCacheEntry{
+				Dir:      "[my-datastore] .contentlib-cache/item-1/v2",
+				ItemID:   "item-1",
+				LastUsed: t1,
+			},
+		))
+	})
+})
+
+var _ = Describe("GetParentDiskDirs", func() {
+	It("should count the directories of each disk's parent chain", func() {
+		devices := []vimtypes.BaseVirtualDevice{
+			&vimtypes.VirtualDisk{
+				VirtualDevice: vimtypes.VirtualDevice{
+					Backing: &vimtypes.VirtualDiskFlatVer2BackingInfo{
+						VirtualDeviceFileBackingInfo: vimtypes.VirtualDeviceFileBackingInfo{
+							FileName: "[my-datastore] vm-1/disk-delta.vmdk",
+						},
+						Parent: &vimtypes.VirtualDiskFlatVer2BackingInfo{
+							VirtualDeviceFileBackingInfo: vimtypes.VirtualDeviceFileBackingInfo{
+								FileName: "[my-datastore] .contentlib-cache/item-1/v1/a.vmdk",
+							},
+						},
+					},
+				},
+			},
+			&vimtypes.VirtualDisk{
+				VirtualDevice: vimtypes.VirtualDevice{
+					Backing: &vimtypes.VirtualDiskSeSparseBackingInfo{
+						VirtualDeviceFileBackingInfo: vimtypes.VirtualDeviceFileBackingInfo{
+							FileName: "[my-datastore] vm-1/disk2-delta.vmdk",
+						},
+						Parent: &vimtypes.VirtualDiskSeSparseBackingInfo{
+							VirtualDeviceFileBackingInfo: vimtypes.VirtualDeviceFileBackingInfo{
+								FileName: "[my-datastore] .contentlib-cache/item-1/v1/b.vmdk",
+							},
+						},
+					},
+				},
+			},
+			&vimtypes.VirtualCdrom{},
+		}
+
+		Expect(clsutil.GetParentDiskDirs(devices)).To(Equal(map[string]int{
+			"[my-datastore] .contentlib-cache/item-1/v1": 2,
+		}))
+	})
+})
@@ -9,6 +9,7 @@ import (
 
 	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
 	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/imageupload"
 	vmwatcher "github.com/vmware-tanzu/vm-operator/services/vm-watcher"
 )
 
@@ -23,5 +24,13 @@ func AddToManager(
 		}
 	}
 
+	// The image upload server is only started if its external URL is
+	// configured, since the upload URLs may not be issued otherwise.
+	if pkgcfg.FromContext(ctx).ImageUpload.BaseURL != "" {
+		if err := imageupload.AddToManager(ctx, mgr); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
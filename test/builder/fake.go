@@ -19,11 +19,13 @@ import (
 	appv1a1 "github.com/vmware-tanzu/vm-operator/external/appplatform/api/v1alpha1"
 	byokv1 "github.com/vmware-tanzu/vm-operator/external/byok/api/v1alpha1"
 	capv1 "github.com/vmware-tanzu/vm-operator/external/capabilities/api/v1alpha1"
+	ipamv1 "github.com/vmware-tanzu/vm-operator/external/ipam/api/v1beta1"
 	ncpv1alpha1 "github.com/vmware-tanzu/vm-operator/external/ncp/api/v1alpha1"
 	spqv1 "github.com/vmware-tanzu/vm-operator/external/storage-policy-quota/api/v1alpha2"
 	topologyv1 "github.com/vmware-tanzu/vm-operator/external/tanzu-topology/api/v1alpha1"
 	cnsapis "github.com/vmware-tanzu/vm-operator/external/vsphere-csi-driver/pkg/syncer/cnsoperator/apis"
 	cnsv1alpha1 "github.com/vmware-tanzu/vm-operator/external/vsphere-csi-driver/pkg/syncer/cnsoperator/apis/cnsnodevmattachment/v1alpha1"
+	cnsregv1alpha1 "github.com/vmware-tanzu/vm-operator/external/vsphere-csi-driver/pkg/syncer/cnsoperator/apis/cnsregistervolume/v1alpha1"
 
 	vmopapi "github.com/vmware-tanzu/vm-operator/api"
 	vmopv1a1 "github.com/vmware-tanzu/vm-operator/api/v1alpha1"
@@ -63,10 +65,16 @@ func KnownObjectTypes() []client.Object {
 		&vmopv1.ClusterVirtualMachineImage{},
 		&vmopv1.VirtualMachineImage{},
 		&vmopv1.VirtualMachineImageCache{},
+		&vmopv1.VirtualMachineImageCachePolicy{},
+		&vmopv1.ClusterVirtualMachineImageCachePolicy{},
+		&vmopv1.VirtualMachineImageChannel{},
+		&vmopv1.VirtualMachineImportRequest{},
+		&vmopv1.VirtualMachineImageUpload{},
 		&vmopv1.VirtualMachineWebConsoleRequest{},
 		&vmopv1.VirtualMachineSnapshot{},
 		&vmopv1a1.WebConsoleRequest{},
 		&cnsv1alpha1.CnsNodeVmAttachment{},
+		&cnsregv1alpha1.CnsRegisterVolume{},
 		&spqv1.StoragePolicyQuota{},
 		&spqv1.StoragePolicyUsage{},
 		&spqv1.StorageQuota{},
@@ -77,6 +85,7 @@ func KnownObjectTypes() []client.Object {
 		&vpcv1alpha1.SubnetPort{},
 		&byokv1.EncryptionClass{},
 		&capv1.Capabilities{},
+		&ipamv1.IPAddressClaim{},
 		&appv1a1.SupervisorProperties{},
 	}
 }
@@ -93,6 +102,7 @@ func NewScheme() *runtime.Scheme {
 	_ = vmopapi.AddToScheme(scheme)
 	_ = capv1.AddToScheme(scheme)
 	_ = byokv1.AddToScheme(scheme)
+	_ = ipamv1.AddToScheme(scheme)
 	_ = appv1a1.AddToScheme(scheme)
 	_ = ncpv1alpha1.AddToScheme(scheme)
 	_ = cnsapis.AddToScheme(scheme)
@@ -0,0 +1,238 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package builder
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const ociRegistryToken = "fake-token"
+
+// OCIRegistry is an in-process OCI registry that implements the parts of
+// the distribution API used to push and pull artifacts.
+type OCIRegistry struct {
+	*httptest.Server
+
+	// Username and Password are the credentials required to access the
+	// registry. The registry allows anonymous access when Username is empty.
+	Username string
+	Password string
+
+	// BearerAuth indicates the registry uses token authentication instead of
+	// basic authentication.
+	BearerAuth bool
+
+	// BlobRedirectURL is the URL to which blob downloads are redirected, ex.
+	// to emulate a registry that serves blobs from blob storage.
+	BlobRedirectURL string
+
+	mu        sync.Mutex
+	blobs     map[string][]byte
+	manifests map[string][]byte
+	uploads   map[string][]byte
+	nextID    int
+}
+
+// NewOCIRegistry starts and returns a new OCIRegistry. The caller must call
+// Close when the registry is no longer needed.
+func NewOCIRegistry() *OCIRegistry {
+	r := &OCIRegistry{
+		blobs:     map[string][]byte{},
+		manifests: map[string][]byte{},
+		uploads:   map[string][]byte{},
+	}
+	r.Server = httptest.NewServer(http.HandlerFunc(r.serveHTTP))
+	return r
+}
+
+// Host returns the host and port of the registry.
+func (r *OCIRegistry) Host() string {
+	return strings.TrimPrefix(r.URL, "http://")
+}
+
+// GetManifest returns the manifest for the provided repository and tag or
+// digest.
+func (r *OCIRegistry) GetManifest(repo, ref string) ([]byte, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	data, ok := r.manifests[repo+":"+ref]
+	return data, ok
+}
+
+// GetBlob returns the blob with the provided digest.
+func (r *OCIRegistry) GetBlob(digest string) ([]byte, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	data, ok := r.blobs[digest]
+	return data, ok
+}
+
+func (r *OCIRegistry) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.URL.Path == "/token" {
+		r.serveToken(w, req)
+		return
+	}
+
+	if !r.authorized(req) {
+		if r.BearerAuth {
+			w.Header().Set("WWW-Authenticate", fmt.Sprintf(
+				`Bearer realm="%s/token",service="fake"`, r.URL))
+		} else {
+			w.Header().Set("WWW-Authenticate", `Basic realm="fake"`)
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	path := strings.TrimPrefix(req.URL.Path, "/v2/")
+	switch {
+	case strings.Contains(path, "/blobs/uploads/"):
+		i := strings.LastIndex(path, "/blobs/uploads/")
+		r.serveUpload(w, req, path[:i], path[i+len("/blobs/uploads/"):])
+	case strings.Contains(path, "/blobs/"):
+		i := strings.LastIndex(path, "/blobs/")
+		r.serveBlob(w, req, path[i+len("/blobs/"):])
+	case strings.Contains(path, "/manifests/"):
+		i := strings.LastIndex(path, "/manifests/")
+		r.serveManifest(w, req, path[:i], path[i+len("/manifests/"):])
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (r *OCIRegistry) authorized(req *http.Request) bool {
+	if r.Username == "" {
+		return true
+	}
+	if r.BearerAuth {
+		return req.Header.Get("Authorization") == "Bearer "+ociRegistryToken
+	}
+	u, p, ok := req.BasicAuth()
+	return ok && u == r.Username && p == r.Password
+}
+
+func (r *OCIRegistry) serveToken(w http.ResponseWriter, req *http.Request) {
+	u, p, ok := req.BasicAuth()
+	if !ok || u != r.Username || p != r.Password {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = fmt.Fprintf(w, `{"token":%q}`, ociRegistryToken)
+}
+
+func (r *OCIRegistry) serveUpload(
+	w http.ResponseWriter,
+	req *http.Request,
+	repo, id string) {
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	switch req.Method {
+	case http.MethodPost:
+		r.nextID++
+		id = strconv.Itoa(r.nextID)
+		r.uploads[id] = nil
+		w.Header().Set("Location", "/v2/"+repo+"/blobs/uploads/"+id)
+		w.WriteHeader(http.StatusAccepted)
+	case http.MethodPatch:
+		if _, ok := r.uploads[id]; !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		data, err := io.ReadAll(req.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		r.uploads[id] = append(r.uploads[id], data...)
+		w.Header().Set("Location", "/v2/"+repo+"/blobs/uploads/"+id)
+		w.WriteHeader(http.StatusAccepted)
+	case http.MethodPut:
+		data, ok := r.uploads[id]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		digest := req.URL.Query().Get("digest")
+		if digest != ociDigest(data) {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		delete(r.uploads, id)
+		r.blobs[digest] = data
+		w.WriteHeader(http.StatusCreated)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func (r *OCIRegistry) serveBlob(
+	w http.ResponseWriter,
+	req *http.Request,
+	digest string) {
+
+	data, ok := r.GetBlob(digest)
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if req.Method == http.MethodGet && r.BlobRedirectURL != "" {
+		http.Redirect(w, req, r.BlobRedirectURL+"/"+digest, http.StatusTemporaryRedirect)
+		return
+	}
+	if req.Method == http.MethodGet {
+		_, _ = w.Write(data)
+	}
+}
+
+func (r *OCIRegistry) serveManifest(
+	w http.ResponseWriter,
+	req *http.Request,
+	repo, ref string) {
+
+	switch req.Method {
+	case http.MethodGet, http.MethodHead:
+		data, ok := r.GetManifest(repo, ref)
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/vnd.oci.image.manifest.v1+json")
+		w.Header().Set("Docker-Content-Digest", ociDigest(data))
+		if req.Method == http.MethodGet {
+			_, _ = w.Write(data)
+		}
+	case http.MethodPut:
+		data, err := io.ReadAll(req.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		digest := ociDigest(data)
+		r.mu.Lock()
+		r.manifests[repo+":"+ref] = data
+		r.manifests[repo+":"+digest] = data
+		r.mu.Unlock()
+		w.Header().Set("Docker-Content-Digest", digest)
+		w.WriteHeader(http.StatusCreated)
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+func ociDigest(data []byte) string {
+	sum := sha256.Sum256(data)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
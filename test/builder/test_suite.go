@@ -148,6 +148,9 @@ func (s *TestSuite) GetEnvTestConfig() *rest.Config {
 func (s *TestSuite) GetLogger() logr.Logger {
 	logger, err := logr.FromContext(s.Context)
 	if err != nil {
+		if s.manager == nil {
+			return logr.Discard()
+		}
 		return s.manager.GetLogger()
 	}
 	return logger
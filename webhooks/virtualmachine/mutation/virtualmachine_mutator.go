@@ -17,6 +17,7 @@ import (
 	"github.com/google/uuid"
 	admissionv1 "k8s.io/api/admission/v1"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -55,6 +56,7 @@ const (
 // +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineimages/status,verbs=get;list;watch
 // +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=clustervirtualmachineimages,verbs=get;list;watch
 // +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=clustervirtualmachineimages/status,verbs=get;list;watch
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineimagechannels,verbs=get;list;watch
 
 // AddToManager adds the webhook to the provided manager.
 func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr ctrlmgr.Manager) error {
@@ -130,6 +132,9 @@ func (m mutator) Mutate(ctx *pkgctx.WebhookRequestContext) admission.Response {
 		if _, err := SetDefaultBiosUUID(ctx, m.client, modified); err != nil {
 			return admission.Denied(err.Error())
 		}
+		if _, err := ResolveImageChannelOnCreate(ctx, m.client, modified); err != nil {
+			return admission.Denied(err.Error())
+		}
 		if _, err := ResolveImageNameOnCreate(ctx, m.client, modified); err != nil {
 			return admission.Denied(err.Error())
 		}
@@ -479,8 +484,51 @@ const (
 	vmiKind            = "VirtualMachineImage"
 	cvmiKind           = "Cluster" + vmiKind
 	imgNameNotMatchRef = "must refer to the same resource as spec.image"
+	imgChannelNotReady = "image channel %q has not resolved an image"
 )
 
+// ResolveImageChannelOnCreate sets vm.spec.image to the image currently
+// resolved by the channel in vm.spec.imageChannel. The channel is only
+// consulted when the VM is created; an explicit spec.image is left as is.
+func ResolveImageChannelOnCreate(
+	ctx *pkgctx.WebhookRequestContext,
+	c ctrlclient.Client,
+	vm *vmopv1.VirtualMachine) (bool, error) {
+
+	chanName := vm.Spec.ImageChannel
+	if chanName == "" {
+		return false, nil
+	}
+
+	chanPath := field.NewPath("spec", "imageChannel")
+
+	if vm.Spec.ImageName != "" {
+		return false, field.Forbidden(chanPath, "may not be used with spec.imageName")
+	}
+	if vm.Spec.Image != nil {
+		return false, nil
+	}
+
+	var channel vmopv1.VirtualMachineImageChannel
+	if err := c.Get(
+		ctx,
+		ctrlclient.ObjectKey{Namespace: vm.Namespace, Name: chanName},
+		&channel); err != nil {
+
+		if apierrors.IsNotFound(err) {
+			return false, field.NotFound(chanPath, chanName)
+		}
+		return false, err
+	}
+
+	if channel.Status.Image == nil {
+		return false, field.Invalid(chanPath, chanName, fmt.Sprintf(imgChannelNotReady, chanName))
+	}
+
+	vm.Spec.Image = channel.Status.Image.DeepCopy()
+	return true, nil
+}
+
 // ResolveImageNameOnCreate ensures vm.spec.image is set to a non-empty value if
 // vm.spec.imageName is also non-empty.
 func ResolveImageNameOnCreate(
@@ -1122,6 +1122,118 @@ func unitTestsMutating() {
 		})
 	})
 
+	Describe("ResolveImageChannelOnCreate", func() {
+		const channelName = "photon"
+
+		var (
+			mutatedErr  error
+			wasMutated  bool
+			initObjects []client.Object
+			channel     *vmopv1.VirtualMachineImageChannel
+		)
+
+		BeforeEach(func() {
+			channel = &vmopv1.VirtualMachineImageChannel{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      channelName,
+					Namespace: ctx.vm.Namespace,
+				},
+				Status: vmopv1.VirtualMachineImageChannelStatus{
+					Image: &vmopv1.VirtualMachineImageRef{
+						Kind: "VirtualMachineImage",
+						Name: "vmi-2",
+					},
+				},
+			}
+			initObjects = []client.Object{channel}
+
+			ctx.vm.Spec.Image = nil
+			ctx.vm.Spec.ImageName = ""
+			ctx.vm.Spec.ImageChannel = channelName
+		})
+
+		JustBeforeEach(func() {
+			ctx.Client = fake.NewClientBuilder().WithScheme(builder.NewScheme()).
+				WithObjects(initObjects...).
+				Build()
+			wasMutated, mutatedErr = mutation.ResolveImageChannelOnCreate(
+				&ctx.WebhookRequestContext, ctx.Client, ctx.vm)
+		})
+
+		When("spec.imageChannel is empty", func() {
+			BeforeEach(func() {
+				ctx.vm.Spec.ImageChannel = ""
+			})
+			It("Should not mutate Image", func() {
+				Expect(mutatedErr).ToNot(HaveOccurred())
+				Expect(wasMutated).To(BeFalse())
+				Expect(ctx.vm.Spec.Image).To(BeNil())
+			})
+		})
+
+		When("the channel has resolved an image", func() {
+			It("Should set Image from the channel", func() {
+				Expect(mutatedErr).ToNot(HaveOccurred())
+				Expect(wasMutated).To(BeTrue())
+				Expect(ctx.vm.Spec.Image).To(Equal(&vmopv1.VirtualMachineImageRef{
+					Kind: "VirtualMachineImage",
+					Name: "vmi-2",
+				}))
+			})
+		})
+
+		When("spec.image is already set", func() {
+			BeforeEach(func() {
+				ctx.vm.Spec.Image = &vmopv1.VirtualMachineImageRef{
+					Kind: "VirtualMachineImage",
+					Name: "vmi-1",
+				}
+			})
+			It("Should not mutate Image", func() {
+				Expect(mutatedErr).ToNot(HaveOccurred())
+				Expect(wasMutated).To(BeFalse())
+				Expect(ctx.vm.Spec.Image.Name).To(Equal("vmi-1"))
+			})
+		})
+
+		When("spec.imageName is also set", func() {
+			BeforeEach(func() {
+				ctx.vm.Spec.ImageName = "vmi-1"
+			})
+			It("Should return an error", func() {
+				Expect(mutatedErr).To(MatchError(field.Forbidden(
+					field.NewPath("spec", "imageChannel"),
+					"may not be used with spec.imageName").Error()))
+				Expect(wasMutated).To(BeFalse())
+			})
+		})
+
+		When("the channel does not exist", func() {
+			BeforeEach(func() {
+				initObjects = nil
+			})
+			It("Should return an error", func() {
+				Expect(mutatedErr).To(MatchError(field.NotFound(
+					field.NewPath("spec", "imageChannel"),
+					channelName).Error()))
+				Expect(wasMutated).To(BeFalse())
+				Expect(ctx.vm.Spec.Image).To(BeNil())
+			})
+		})
+
+		When("the channel has not resolved an image", func() {
+			BeforeEach(func() {
+				channel.Status.Image = nil
+			})
+			It("Should return an error", func() {
+				Expect(mutatedErr).To(HaveOccurred())
+				Expect(mutatedErr.Error()).To(ContainSubstring("has not resolved an image"))
+				Expect(wasMutated).To(BeFalse())
+				Expect(ctx.vm.Spec.Image).To(BeNil())
+			})
+		})
+	})
+
 	Describe("SetNextRestartTime", func() {
 
 		var (
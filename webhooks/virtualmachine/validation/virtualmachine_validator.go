@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"reflect"
 	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -40,10 +41,15 @@ import (
 	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/config"
 	"github.com/vmware-tanzu/vm-operator/pkg/topology"
 	"github.com/vmware-tanzu/vm-operator/pkg/util"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/bootstraptemplate"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/cloudinit"
 	cloudinitvalidate "github.com/vmware-tanzu/vm-operator/pkg/util/cloudinit/validate"
+	ignitionutil "github.com/vmware-tanzu/vm-operator/pkg/util/ignition"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/image/provenance"
 	kubeutil "github.com/vmware-tanzu/vm-operator/pkg/util/kube"
 	spqutil "github.com/vmware-tanzu/vm-operator/pkg/util/kube/spq"
 	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/vapp"
 	vmopv1util "github.com/vmware-tanzu/vm-operator/pkg/util/vmopv1"
 	"github.com/vmware-tanzu/vm-operator/webhooks/common"
 )
@@ -59,6 +65,7 @@ const (
 	readinessProbeOnlyOneAction              = "only one action can be specified"
 	tcpReadinessProbeNotAllowedVPC           = "VPC networking doesn't allow TCP readiness probe to be specified"
 	updatesNotAllowedWhenPowerOn             = "updates to this field is not allowed when VM power is on"
+	customizationGenerationDecreasedFmt      = "may not be less than the previous value %d"
 	storageClassNotFoundFmt                  = "Storage policy %s does not exist"
 	storageClassNotAssignedFmt               = "Storage policy is not associated with the namespace %s"
 	vSphereVolumeSizeNotMBMultiple           = "value must be a multiple of MB"
@@ -76,6 +83,8 @@ const (
 	invalidMinHardwareVersionDowngrade       = "cannot downgrade hardware version"
 	invalidMinHardwareVersionPowerState      = "cannot upgrade hardware version unless powered off"
 	invalidImageKind                         = "supported: " + vmiKind + "; " + cvmiKind
+	invalidImageOCI                          = "deploying a VM from an image backed by an OCI artifact is not supported"
+	invalidImageProvenanceFmt                = "failed to verify image provenance: %s"
 	invalidZone                              = "cannot use zone that is being deleted"
 	restrictedToPrivUsers                    = "restricted to privileged users"
 	addRestrictedAnnotation                  = "adding this annotation is restricted to privileged users"
@@ -145,7 +154,9 @@ func (v validator) ValidateCreate(ctx *pkgctx.WebhookRequestContext) admission.R
 	fieldErrs = append(fieldErrs, v.validateStorageClass(ctx, vm)...)
 	fieldErrs = append(fieldErrs, v.validateCrypto(ctx, vm)...)
 	fieldErrs = append(fieldErrs, v.validateBootstrap(ctx, vm)...)
+	fieldErrs = append(fieldErrs, v.validateVAppConfigProperties(ctx, vm, nil)...)
 	fieldErrs = append(fieldErrs, v.validateNetwork(ctx, vm)...)
+	fieldErrs = append(fieldErrs, v.validateNetworkInterfaceMacAddresses(ctx, vm, nil)...)
 	fieldErrs = append(fieldErrs, v.validateVolumes(ctx, vm)...)
 	fieldErrs = append(fieldErrs, v.validateInstanceStorageVolumes(ctx, vm, nil)...)
 	fieldErrs = append(fieldErrs, v.validateReadinessProbe(ctx, vm)...)
@@ -161,12 +172,19 @@ func (v validator) ValidateCreate(ctx *pkgctx.WebhookRequestContext) admission.R
 	fieldErrs = append(fieldErrs, v.validateNextPowerStateChangeTimeFormat(ctx, vm)...)
 	fieldErrs = append(fieldErrs, v.validateBootOptions(ctx, vm)...)
 
+	imageCompatErrs, imageCompatWarnings := v.validateImageCompatibility(ctx, vm)
+	fieldErrs = append(fieldErrs, imageCompatErrs...)
+
 	validationErrs := make([]string, 0, len(fieldErrs))
 	for _, fieldErr := range fieldErrs {
 		validationErrs = append(validationErrs, fieldErr.Error())
 	}
 
-	return common.BuildValidationResponse(ctx, nil, validationErrs, nil)
+	warnings := v.bootstrapTemplateWarnings(vm, nil)
+	warnings = append(warnings, v.cloudbaseInitWarnings(ctx, vm, nil)...)
+	warnings = append(warnings, imageCompatWarnings...)
+
+	return common.BuildValidationResponse(ctx, warnings, validationErrs, nil)
 }
 
 func (v validator) ValidateDelete(*pkgctx.WebhookRequestContext) admission.Response {
@@ -195,6 +213,8 @@ func (v validator) validateImageOnUpdate(ctx *pkgctx.WebhookRequestContext, vm,
 
 	allErrs = append(allErrs,
 		validation.ValidateImmutableField(vm.Spec.ImageName, oldVM.Spec.ImageName, field.NewPath("spec", "imageName"))...)
+	allErrs = append(allErrs,
+		validation.ValidateImmutableField(vm.Spec.ImageChannel, oldVM.Spec.ImageChannel, field.NewPath("spec", "imageChannel"))...)
 	allErrs = append(allErrs,
 		validation.ValidateImmutableField(vm.Spec.Image, oldVM.Spec.Image, field.NewPath("spec", "image"))...)
 
@@ -205,6 +225,7 @@ func (v validator) validateImageOnUpdate(ctx *pkgctx.WebhookRequestContext, vm,
 // Changes to following fields are not allowed:
 //   - Image
 //   - ImageName
+//   - ImageChannel
 //   - StorageClass
 //   - ResourcePolicyName
 //   - Minimum VM Hardware Version
@@ -246,7 +267,10 @@ func (v validator) ValidateUpdate(ctx *pkgctx.WebhookRequestContext) admission.R
 	fieldErrs = append(fieldErrs, v.validateCrypto(ctx, vm)...)
 	fieldErrs = append(fieldErrs, v.validateAvailabilityZone(ctx, vm, oldVM)...)
 	fieldErrs = append(fieldErrs, v.validateBootstrap(ctx, vm)...)
+	fieldErrs = append(fieldErrs, v.validateVAppConfigProperties(ctx, vm, oldVM)...)
+	fieldErrs = append(fieldErrs, v.validateCustomizationGeneration(ctx, vm, oldVM)...)
 	fieldErrs = append(fieldErrs, v.validateNetwork(ctx, vm)...)
+	fieldErrs = append(fieldErrs, v.validateNetworkInterfaceMacAddresses(ctx, vm, oldVM)...)
 	fieldErrs = append(fieldErrs, v.validateVolumes(ctx, vm)...)
 	fieldErrs = append(fieldErrs, v.validateInstanceStorageVolumes(ctx, vm, oldVM)...)
 	fieldErrs = append(fieldErrs, v.validateReadinessProbe(ctx, vm)...)
@@ -266,7 +290,106 @@ func (v validator) ValidateUpdate(ctx *pkgctx.WebhookRequestContext) admission.R
 		validationErrs = append(validationErrs, fieldErr.Error())
 	}
 
-	return common.BuildValidationResponse(ctx, nil, validationErrs, nil)
+	warnings := v.bootstrapTemplateWarnings(vm, oldVM)
+	warnings = append(warnings, v.cloudbaseInitWarnings(ctx, vm, oldVM)...)
+
+	return common.BuildValidationResponse(ctx, warnings, validationErrs, nil)
+}
+
+// bootstrapTemplateWarnings returns a warning for each of the VM's inline vApp
+// property values that fails to render as a template. The templates are
+// rendered with placeholder data so errors, such as an unknown function or an
+// out of range NIC index, are found before the VM is bootstrapped.
+func (v validator) bootstrapTemplateWarnings(
+	vm, oldVM *vmopv1.VirtualMachine) admission.Warnings {
+
+	bs := vm.Spec.Bootstrap
+	if bs == nil || bs.VAppConfig == nil {
+		return nil
+	}
+	if oldVM != nil && equality.Semantic.DeepEqual(bs, oldVM.Spec.Bootstrap) {
+		return nil
+	}
+
+	var (
+		warnings  admission.Warnings
+		render    func(string, string) error
+		propsPath = field.NewPath("spec", "bootstrap", "vAppConfig", "properties")
+	)
+
+	for i, p := range bs.VAppConfig.Properties {
+		if p.Value.Value == nil || !strings.Contains(*p.Value.Value, "{{") {
+			continue
+		}
+		if render == nil {
+			render = bootstraptemplate.NewDryRunRenderFunc(vm)
+		}
+		if err := render(p.Key, *p.Value.Value); err != nil {
+			warnings = append(warnings, fmt.Sprintf("%s: %s",
+				propsPath.Index(i).Child("value", "value"), err))
+		}
+	}
+
+	return warnings
+}
+
+// cloudbaseInitWarnings returns a warning if the VM's raw Cloud-Init
+// user-data is in a format that is only supported by Cloudbase-Init, but the
+// VM's guest ID is not a Windows guest ID. This is a warning rather than an
+// error since the guest ID may not reflect the image's actual guest OS.
+func (v validator) cloudbaseInitWarnings(
+	ctx *pkgctx.WebhookRequestContext,
+	vm, oldVM *vmopv1.VirtualMachine) admission.Warnings {
+
+	bs := vm.Spec.Bootstrap
+	if bs == nil || bs.CloudInit == nil || bs.CloudInit.RawCloudConfig == nil {
+		return nil
+	}
+	if oldVM != nil &&
+		equality.Semantic.DeepEqual(bs, oldVM.Spec.Bootstrap) &&
+		vm.Spec.GuestID == oldVM.Spec.GuestID {
+		return nil
+	}
+
+	raw := bs.CloudInit.RawCloudConfig
+	if raw.Name == "" {
+		return nil
+	}
+
+	var secret corev1.Secret
+	key := ctrlclient.ObjectKey{Namespace: vm.Namespace, Name: raw.Name}
+	if err := v.client.Get(ctx, key, &secret); err != nil {
+		// The Secret may not exist yet.
+		return nil
+	}
+
+	var userdata string
+	for _, k := range append([]string{raw.Key}, cloudinit.UserDataSecretKeys...) {
+		if data := secret.Data[k]; len(data) > 0 {
+			userdata = string(data)
+			break
+		}
+	}
+
+	if ok, _ := cloudinit.IsCloudbaseInitUserData(userdata); !ok {
+		return nil
+	}
+
+	guestID := vm.Spec.GuestID
+	if guestID == "" && vm.Spec.Image != nil && vm.Spec.Image.Name != "" {
+		if img, err := vmopv1util.GetImage(ctx, v.client, *vm.Spec.Image, vm.Namespace); err == nil {
+			guestID = img.Status.OSInfo.ID
+		}
+	}
+	if guestID == "" || strings.HasPrefix(guestID, "win") {
+		return nil
+	}
+
+	return admission.Warnings{fmt.Sprintf(
+		"%s: user-data format is only supported by Cloudbase-Init on "+
+			"Windows guests, guest ID is %q",
+		field.NewPath("spec", "bootstrap", "cloudInit", "rawCloudConfig"),
+		guestID)}
 }
 
 func (v validator) validateBootstrap(
@@ -281,6 +404,7 @@ func (v validator) validateBootstrap(
 		linuxPrep  *vmopv1.VirtualMachineBootstrapLinuxPrepSpec
 		sysPrep    *vmopv1.VirtualMachineBootstrapSysprepSpec
 		vAppConfig *vmopv1.VirtualMachineBootstrapVAppConfigSpec
+		ignition   *vmopv1.VirtualMachineBootstrapIgnitionSpec
 	)
 
 	if vm.Spec.Bootstrap != nil {
@@ -288,6 +412,7 @@ func (v validator) validateBootstrap(
 		linuxPrep = vm.Spec.Bootstrap.LinuxPrep
 		sysPrep = vm.Spec.Bootstrap.Sysprep
 		vAppConfig = vm.Spec.Bootstrap.VAppConfig
+		ignition = vm.Spec.Bootstrap.Ignition
 	}
 
 	if cloudInit != nil {
@@ -364,6 +489,29 @@ func (v validator) validateBootstrap(
 
 	}
 
+	if ignition != nil {
+		p := bootstrapPath.Child("ignition")
+
+		if cloudInit != nil || linuxPrep != nil || sysPrep != nil || vAppConfig != nil {
+			allErrs = append(allErrs, field.Forbidden(p,
+				"Ignition may not be used with any other bootstrap provider"))
+		}
+
+		if ignition.Config != "" && ignition.RawConfig != nil {
+			allErrs = append(allErrs, field.Invalid(p, "ignition",
+				"config and rawConfig are mutually exclusive"))
+		} else if ignition.Config == "" && ignition.RawConfig == nil {
+			allErrs = append(allErrs, field.Invalid(p, "ignition",
+				"either config or rawConfig must be provided"))
+		}
+
+		if ignition.Config != "" {
+			if err := ignitionutil.Validate(ignition.Config); err != nil {
+				allErrs = append(allErrs, field.Invalid(p.Child("config"), "config", err.Error()))
+			}
+		}
+	}
+
 	return allErrs
 }
 
@@ -457,11 +605,98 @@ func (v validator) validateImageOnCreate(
 		allErrs = append(allErrs, field.Required(f.Child("kind"), invalidImageKind))
 	case vm.Spec.Image.Kind != vmiKind && vm.Spec.Image.Kind != cvmiKind:
 		allErrs = append(allErrs, field.Invalid(f.Child("kind"), vm.Spec.Image.Kind, invalidImageKind))
+	case vm.Spec.Image.Name != "":
+		// The image is validated elsewhere, so only an image that exists
+		// is checked.
+		img, err := vmopv1util.GetImage(ctx, v.client, *vm.Spec.Image, vm.Namespace)
+		if err != nil {
+			break
+		}
+		if img.Spec.OCI != nil {
+			allErrs = append(allErrs, field.Invalid(f.Child("name"), vm.Spec.Image.Name, invalidImageOCI))
+		}
+		allErrs = append(allErrs, v.validateImageProvenance(ctx, f.Child("name"), &img)...)
 	}
 
 	return allErrs
 }
 
+// validateImageProvenance ensures the image's provenance record is signed by
+// a trusted key when only verified images may be deployed. The same check is
+// done when the VM is created, but failing here surfaces the error to the
+// user instead of only in the VM's conditions.
+func (v validator) validateImageProvenance(
+	ctx *pkgctx.WebhookRequestContext,
+	f *field.Path,
+	img *vmopv1.VirtualMachineImage) field.ErrorList {
+
+	cfg := pkgcfg.FromContext(ctx)
+	if !cfg.ImageProvenance.RequireVerifiedImages {
+		return nil
+	}
+
+	keys, err := provenance.GetPublicKeys(
+		ctx,
+		v.client,
+		cfg.PodNamespace,
+		cfg.ImageProvenance.PublicKeysSecretName)
+	if err != nil {
+		return field.ErrorList{field.InternalError(f, err)}
+	}
+
+	if _, err := provenance.VerifyImage(img, img.Status, keys); err != nil {
+		return field.ErrorList{field.Invalid(f, img.Name, fmt.Sprintf(invalidImageProvenanceFmt, err))}
+	}
+
+	return nil
+}
+
+// validateImageCompatibility returns an error for each incompatibility
+// between the VM's image and its class and boot options that would prevent
+// the VM from being created or powered on, and a warning for each of the
+// others. The hosts on which the VM is placed are not known yet, so the
+// image's hardware version is only checked against the class.
+func (v validator) validateImageCompatibility(
+	ctx *pkgctx.WebhookRequestContext,
+	vm *vmopv1.VirtualMachine) (field.ErrorList, admission.Warnings) {
+
+	if vm.Spec.Image == nil || vm.Spec.Image.Name == "" {
+		return nil, nil
+	}
+
+	img, err := vmopv1util.GetImage(ctx, v.client, *vm.Spec.Image, vm.Namespace)
+	if err != nil {
+		// The image is validated elsewhere, and without it there is nothing
+		// to check.
+		return nil, nil
+	}
+
+	var vmClass vmopv1.VirtualMachineClass
+	if vm.Spec.ClassName != "" {
+		key := ctrlclient.ObjectKey{Namespace: vm.Namespace, Name: vm.Spec.ClassName}
+		if err := v.client.Get(ctx, key, &vmClass); err != nil {
+			// The boot options may still be checked against the image.
+			vmClass = vmopv1.VirtualMachineClass{}
+		}
+	}
+
+	var (
+		allErrs  field.ErrorList
+		warnings admission.Warnings
+		f        = field.NewPath("spec", "image")
+		issues   = vmopv1util.CheckImageCompatibility(*vm, vmClass.Spec, img.Status, 0, nil)
+	)
+
+	for _, i := range issues.Fatal() {
+		allErrs = append(allErrs, field.Invalid(f, vm.Spec.Image.Name, i.String()))
+	}
+	for _, i := range issues.Warnings() {
+		warnings = append(warnings, fmt.Sprintf("%s: %s", f, i))
+	}
+
+	return allErrs, warnings
+}
+
 func (v validator) validateClassOnCreate(
 	ctx *pkgctx.WebhookRequestContext,
 	vm *vmopv1.VirtualMachine) field.ErrorList {
@@ -596,6 +831,68 @@ func (v validator) validateCrypto(
 	return allErrs
 }
 
+// validateVAppConfigProperties validates the inline vApp property values
+// against the types of the OVF properties recorded in the status of the VM's
+// image. Values that are templates are not validated since they are not
+// rendered until the VM is bootstrapped.
+func (v validator) validateVAppConfigProperties(
+	ctx *pkgctx.WebhookRequestContext,
+	vm, oldVM *vmopv1.VirtualMachine) field.ErrorList {
+
+	var allErrs field.ErrorList
+
+	bs := vm.Spec.Bootstrap
+	if bs == nil || bs.VAppConfig == nil || len(bs.VAppConfig.Properties) == 0 {
+		return allErrs
+	}
+	if vm.Spec.Image == nil || vm.Spec.Image.Name == "" {
+		return allErrs
+	}
+	if oldVM != nil && equality.Semantic.DeepEqual(bs, oldVM.Spec.Bootstrap) {
+		return allErrs
+	}
+
+	img, err := vmopv1util.GetImage(ctx, v.client, *vm.Spec.Image, vm.Namespace)
+	if err != nil {
+		// The image is validated elsewhere, and without it there is nothing
+		// to validate the properties against.
+		return allErrs
+	}
+
+	ovfProps := make(map[string]vmopv1.OVFProperty, len(img.Status.OVFProperties))
+	for _, p := range img.Status.OVFProperties {
+		ovfProps[p.Key] = p
+	}
+
+	propsPath := field.NewPath("spec", "bootstrap", "vAppConfig", "properties")
+
+	for i, p := range bs.VAppConfig.Properties {
+		if p.Value.Value == nil || strings.Contains(*p.Value.Value, "{{") {
+			continue
+		}
+		ovfProp, ok := ovfProps[p.Key]
+		if !ok {
+			continue
+		}
+		propType, err := vapp.ParsePropertyType(ovfProp.Type)
+		if err != nil {
+			continue
+		}
+		if err := propType.Validate(*p.Value.Value); err != nil {
+			value := *p.Value.Value
+			if propType.IsPassword() {
+				value = "<redacted>"
+			}
+			allErrs = append(allErrs, field.Invalid(
+				propsPath.Index(i).Child("value", "value"),
+				value,
+				fmt.Sprintf("%s: %s", ovfProp.Type, err)))
+		}
+	}
+
+	return allErrs
+}
+
 func (v validator) validateNetwork(
 	ctx *pkgctx.WebhookRequestContext,
 	vm *vmopv1.VirtualMachine) field.ErrorList {
@@ -624,10 +921,15 @@ func (v validator) validateNetwork(
 
 		for i, interfaceSpec := range networkSpec.Interfaces {
 			allErrs = append(allErrs, v.validateNetworkInterfaceSpec(p.Index(i), interfaceSpec, vm.Name)...)
+			allErrs = append(allErrs, v.validateNetworkInterfaceAddressesFromPools(ctx, p.Index(i), interfaceSpec)...)
+			allErrs = append(allErrs, v.validateNetworkInterfaceType(p.Index(i), interfaceSpec)...)
+			allErrs = append(allErrs, v.validateNetworkInterfaceQoS(ctx, p.Index(i), interfaceSpec)...)
 			allErrs = append(allErrs, v.validateNetworkInterfaceSpecWithBootstrap(ctx, p.Index(i), interfaceSpec, vm)...)
 		}
 	}
 
+	allErrs = append(allErrs, v.validateNetworkGuestDevices(networkPath, networkSpec)...)
+
 	return allErrs
 }
 
@@ -656,11 +958,200 @@ func (v validator) validateNetworkInterfaceSpec(
 		allErrs = append(allErrs, field.Invalid(interfacePath.Child("name"), networkIfCRName, "is the resulting network interface name: "+msg))
 	}
 
+	allErrs = append(allErrs, validateNetworkIPConfig(
+		interfacePath,
+		vmopv1.VirtualMachineNetworkGuestDeviceIPSpec{
+			Addresses:   interfaceSpec.Addresses,
+			DHCP4:       interfaceSpec.DHCP4,
+			DHCP6:       interfaceSpec.DHCP6,
+			Gateway4:    interfaceSpec.Gateway4,
+			Gateway6:    interfaceSpec.Gateway6,
+			Nameservers: interfaceSpec.Nameservers,
+			Routes:      interfaceSpec.Routes,
+		})...)
+
+	return allErrs
+}
+
+func (v validator) validateNetworkInterfaceAddressesFromPools(
+	ctx *pkgctx.WebhookRequestContext,
+	interfacePath *field.Path,
+	interfaceSpec vmopv1.VirtualMachineNetworkInterfaceSpec) field.ErrorList {
+
+	var allErrs field.ErrorList
+
+	if len(interfaceSpec.AddressesFromPools) == 0 {
+		return allErrs
+	}
+
+	p := interfacePath.Child("addressesFromPools")
+
+	switch networkType := pkgcfg.FromContext(ctx).NetworkProviderType; networkType {
+	case pkgcfg.NetworkProviderTypeVDS, pkgcfg.NetworkProviderTypeNamed:
+	default:
+		allErrs = append(allErrs, field.Forbidden(p,
+			fmt.Sprintf("not supported with network provider %q", networkType)))
+	}
+
+	if len(interfaceSpec.Addresses) > 0 {
+		allErrs = append(allErrs, field.Invalid(interfacePath.Child("addresses"),
+			strings.Join(interfaceSpec.Addresses, ","), "addresses is mutually exclusive with addressesFromPools"))
+	}
+	if interfaceSpec.DHCP4 {
+		allErrs = append(allErrs, field.Invalid(interfacePath.Child("dhcp4"),
+			interfaceSpec.DHCP4, "dhcp4 is mutually exclusive with addressesFromPools"))
+	}
+	if interfaceSpec.DHCP6 {
+		allErrs = append(allErrs, field.Invalid(interfacePath.Child("dhcp6"),
+			interfaceSpec.DHCP6, "dhcp6 is mutually exclusive with addressesFromPools"))
+	}
+
+	for i, poolRef := range interfaceSpec.AddressesFromPools {
+		if poolRef.Name == "" {
+			allErrs = append(allErrs, field.Required(p.Index(i).Child("name"), ""))
+		}
+		if poolRef.Kind == "" {
+			allErrs = append(allErrs, field.Required(p.Index(i).Child("kind"), ""))
+		}
+		if poolRef.APIGroup == nil || *poolRef.APIGroup == "" {
+			allErrs = append(allErrs, field.Required(p.Index(i).Child("apiGroup"), ""))
+		}
+	}
+
+	return allErrs
+}
+
+func (v validator) validateNetworkInterfaceType(
+	interfacePath *field.Path,
+	interfaceSpec vmopv1.VirtualMachineNetworkInterfaceSpec) field.ErrorList {
+
+	var allErrs field.ErrorList
+
+	if interfaceSpec.SRIOV != nil && interfaceSpec.Type != vmopv1.VirtualMachineNetworkInterfaceTypeSRIOV {
+		allErrs = append(allErrs, field.Forbidden(interfacePath.Child("sriov"),
+			fmt.Sprintf("may only be set when type is %q", vmopv1.VirtualMachineNetworkInterfaceTypeSRIOV)))
+	}
+
+	if interfaceSpec.Type == vmopv1.VirtualMachineNetworkInterfaceTypeDVX {
+		p := interfacePath.Child("dvx")
+		if interfaceSpec.DVX == nil {
+			allErrs = append(allErrs, field.Required(p, ""))
+		} else if interfaceSpec.DVX.DeviceClass == "" {
+			allErrs = append(allErrs, field.Required(p.Child("deviceClass"), ""))
+		}
+	} else if interfaceSpec.DVX != nil {
+		allErrs = append(allErrs, field.Forbidden(interfacePath.Child("dvx"),
+			fmt.Sprintf("may only be set when type is %q", vmopv1.VirtualMachineNetworkInterfaceTypeDVX)))
+	}
+
+	return allErrs
+}
+
+// validateNetworkInterfaceMacAddresses validates the MAC addresses of the VM's
+// network interfaces that are set or changed. Only privileged users may set
+// them.
+func (v validator) validateNetworkInterfaceMacAddresses(
+	ctx *pkgctx.WebhookRequestContext,
+	vm, oldVM *vmopv1.VirtualMachine) field.ErrorList {
+
+	var allErrs field.ErrorList
+
+	if vm.Spec.Network == nil {
+		return allErrs
+	}
+
+	oldMacAddresses := map[string]string{}
+	if oldVM != nil && oldVM.Spec.Network != nil {
+		for _, interfaceSpec := range oldVM.Spec.Network.Interfaces {
+			oldMacAddresses[interfaceSpec.Name] = interfaceSpec.MacAddress
+		}
+	}
+
+	p := field.NewPath("spec", "network", "interfaces")
+
+	for i, interfaceSpec := range vm.Spec.Network.Interfaces {
+		macAddress := interfaceSpec.MacAddress
+		if macAddress == "" || macAddress == oldMacAddresses[interfaceSpec.Name] {
+			continue
+		}
+
+		f := p.Index(i).Child("macAddress")
+
+		if !ctx.IsPrivilegedAccount {
+			allErrs = append(allErrs, field.Forbidden(f, restrictedToPrivUsers))
+		}
+
+		if hw, err := net.ParseMAC(macAddress); err != nil || len(hw) != 6 {
+			allErrs = append(allErrs, field.Invalid(f, macAddress, "must be a 48-bit MAC address"))
+		}
+	}
+
+	return allErrs
+}
+
+func (v validator) validateNetworkInterfaceQoS(
+	ctx *pkgctx.WebhookRequestContext,
+	interfacePath *field.Path,
+	interfaceSpec vmopv1.VirtualMachineNetworkInterfaceSpec) field.ErrorList {
+
+	var allErrs field.ErrorList
+
+	if qos := interfaceSpec.QoS; qos != nil && qos.Reservation != nil && qos.Limit != nil {
+		if *qos.Reservation > *qos.Limit {
+			allErrs = append(allErrs, field.Invalid(interfacePath.Child("qos", "reservation"),
+				*qos.Reservation, "must be less than or equal to limit"))
+		}
+	}
+
+	if len(interfaceSpec.TrafficRules) == 0 {
+		return allErrs
+	}
+
+	p := interfacePath.Child("trafficRules")
+
+	if networkType := pkgcfg.FromContext(ctx).NetworkProviderType; networkType != pkgcfg.NetworkProviderTypeVDS {
+		allErrs = append(allErrs, field.Forbidden(p,
+			fmt.Sprintf("not supported with network provider %q", networkType)))
+	}
+
+	for i, rule := range interfaceSpec.TrafficRules {
+		if cidr := rule.SourceCIDR; cidr != "" {
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				allErrs = append(allErrs, field.Invalid(p.Index(i).Child("sourceCIDR"), cidr, err.Error()))
+			}
+		}
+		if cidr := rule.DestinationCIDR; cidr != "" {
+			if _, _, err := net.ParseCIDR(cidr); err != nil {
+				allErrs = append(allErrs, field.Invalid(p.Index(i).Child("destinationCIDR"), cidr, err.Error()))
+			}
+		}
+		if rule.DestinationPort != nil {
+			switch rule.Protocol {
+			case vmopv1.VirtualMachineNetworkInterfaceTrafficRuleProtocolTCP,
+				vmopv1.VirtualMachineNetworkInterfaceTrafficRuleProtocolUDP:
+			default:
+				allErrs = append(allErrs, field.Invalid(p.Index(i).Child("destinationPort"),
+					*rule.DestinationPort, "requires protocol TCP or UDP"))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// validateNetworkIPConfig validates the IP configuration of a network
+// interface or guest-side device.
+func validateNetworkIPConfig(
+	path *field.Path,
+	ipSpec vmopv1.VirtualMachineNetworkGuestDeviceIPSpec) field.ErrorList {
+
+	var allErrs field.ErrorList
+
 	var ipv4Addrs, ipv6Addrs []string
-	for i, ipCIDR := range interfaceSpec.Addresses {
+	for i, ipCIDR := range ipSpec.Addresses {
 		ip, _, err := net.ParseCIDR(ipCIDR)
 		if err != nil {
-			p := interfacePath.Child("addresses").Index(i)
+			p := path.Child("addresses").Index(i)
 			allErrs = append(allErrs, field.Invalid(p, ipCIDR, err.Error()))
 			continue
 		}
@@ -672,8 +1163,8 @@ func (v validator) validateNetworkInterfaceSpec(
 		}
 	}
 
-	if ipv4 := interfaceSpec.Gateway4; ipv4 != "" && ipv4 != "None" {
-		p := interfacePath.Child("gateway4")
+	if ipv4 := ipSpec.Gateway4; ipv4 != "" && ipv4 != "None" {
+		p := path.Child("gateway4")
 
 		if len(ipv4Addrs) == 0 {
 			allErrs = append(allErrs, field.Invalid(p, ipv4, "gateway4 must have an IPv4 address in the addresses field"))
@@ -684,8 +1175,8 @@ func (v validator) validateNetworkInterfaceSpec(
 		}
 	}
 
-	if ipv6 := interfaceSpec.Gateway6; ipv6 != "" && ipv6 != "None" {
-		p := interfacePath.Child("gateway6")
+	if ipv6 := ipSpec.Gateway6; ipv6 != "" && ipv6 != "None" {
+		p := path.Child("gateway6")
 
 		if len(ipv6Addrs) == 0 {
 			allErrs = append(allErrs, field.Invalid(p, ipv6, "gateway6 must have an IPv6 address in the addresses field"))
@@ -696,43 +1187,43 @@ func (v validator) validateNetworkInterfaceSpec(
 		}
 	}
 
-	if interfaceSpec.DHCP4 {
+	if ipSpec.DHCP4 {
 		if len(ipv4Addrs) > 0 {
-			p := interfacePath.Child("dhcp4")
+			p := path.Child("dhcp4")
 			allErrs = append(allErrs, field.Invalid(p, strings.Join(ipv4Addrs, ","),
 				"dhcp4 cannot be used with IPv4 addresses in addresses field"))
 		}
 
-		if gw := interfaceSpec.Gateway4; gw != "" {
-			p := interfacePath.Child("gateway4")
+		if gw := ipSpec.Gateway4; gw != "" {
+			p := path.Child("gateway4")
 			allErrs = append(allErrs, field.Invalid(p, gw, "gateway4 is mutually exclusive with dhcp4"))
 		}
 	}
 
-	if interfaceSpec.DHCP6 {
+	if ipSpec.DHCP6 {
 		if len(ipv6Addrs) > 0 {
-			p := interfacePath.Child("dhcp6")
+			p := path.Child("dhcp6")
 			allErrs = append(allErrs, field.Invalid(p, strings.Join(ipv6Addrs, ","),
 				"dhcp6 cannot be used with IPv6 addresses in addresses field"))
 		}
 
-		if gw := interfaceSpec.Gateway6; gw != "" {
-			p := interfacePath.Child("gateway6")
+		if gw := ipSpec.Gateway6; gw != "" {
+			p := path.Child("gateway6")
 			allErrs = append(allErrs, field.Invalid(p, gw, "gateway6 is mutually exclusive with dhcp6"))
 		}
 	}
 
-	for i, n := range interfaceSpec.Nameservers {
+	for i, n := range ipSpec.Nameservers {
 		if net.ParseIP(n) == nil {
 			allErrs = append(allErrs,
-				field.Invalid(interfacePath.Child("nameservers").Index(i), n, "must be an IPv4 or IPv6 address"))
+				field.Invalid(path.Child("nameservers").Index(i), n, "must be an IPv4 or IPv6 address"))
 		}
 	}
 
-	if len(interfaceSpec.Routes) > 0 {
-		p := interfacePath.Child("routes")
+	if len(ipSpec.Routes) > 0 {
+		p := path.Child("routes")
 
-		for i, r := range interfaceSpec.Routes {
+		for i, r := range ipSpec.Routes {
 			var toIP net.IP
 			if r.To != "default" {
 				ip, _, err := net.ParseCIDR(r.To)
@@ -815,10 +1306,129 @@ func (v validator) validateNetworkSpecWithBootStrap(
 		}
 	}
 
+	// Guest-side bonds, VLANs, and bridges are rendered into the netplan
+	// network config, which GOSC cannot express.
+	if cloudInit == nil {
+		var bondNames, vlanNames, bridgeNames []string
+		for _, b := range networkSpec.Bonds {
+			bondNames = append(bondNames, b.Name)
+		}
+		for _, vl := range networkSpec.VLANs {
+			vlanNames = append(vlanNames, vl.Name)
+		}
+		for _, b := range networkSpec.Bridges {
+			bridgeNames = append(bridgeNames, b.Name)
+		}
+
+		if len(bondNames) > 0 {
+			allErrs = append(allErrs, field.Invalid(
+				networkPath.Child("bonds"),
+				strings.Join(bondNames, ","),
+				"bonds is available only with the following bootstrap providers: CloudInit",
+			))
+		}
+		if len(vlanNames) > 0 {
+			allErrs = append(allErrs, field.Invalid(
+				networkPath.Child("vlans"),
+				strings.Join(vlanNames, ","),
+				"vlans is available only with the following bootstrap providers: CloudInit",
+			))
+		}
+		if len(bridgeNames) > 0 {
+			allErrs = append(allErrs, field.Invalid(
+				networkPath.Child("bridges"),
+				strings.Join(bridgeNames, ","),
+				"bridges is available only with the following bootstrap providers: CloudInit",
+			))
+		}
+	}
+
 	return allErrs
 }
 
-// MTU, routes, and searchDomains are available only with CloudInit.
+// validateNetworkGuestDevices validates the guest-side bonds, VLANs, and
+// bridges, ensuring their names are unique and they only refer to existing
+// interfaces and devices.
+func (v validator) validateNetworkGuestDevices(
+	networkPath *field.Path,
+	networkSpec *vmopv1.VirtualMachineNetworkSpec) field.ErrorList {
+
+	var allErrs field.ErrorList
+
+	const (
+		kindInterface = "interface"
+		kindBond      = "bond"
+		kindVLAN      = "vlan"
+		kindBridge    = "bridge"
+	)
+
+	// The kind of each named device, used to validate references.
+	kinds := map[string]string{}
+	for _, iface := range networkSpec.Interfaces {
+		kinds[iface.Name] = kindInterface
+	}
+
+	// The bond or bridge each device is a member of.
+	memberOf := map[string]string{}
+
+	checkName := func(p *field.Path, name, kind string) {
+		if _, ok := kinds[name]; ok {
+			allErrs = append(allErrs, field.Duplicate(p, name))
+			return
+		}
+		kinds[name] = kind
+	}
+
+	checkMembers := func(p *field.Path, owner string, members []string, msg string, allowedKinds ...string) {
+		for i, name := range members {
+			if !slices.Contains(allowedKinds, kinds[name]) {
+				allErrs = append(allErrs, field.Invalid(p.Index(i), name, msg))
+				continue
+			}
+			if other, ok := memberOf[name]; ok {
+				allErrs = append(allErrs, field.Invalid(p.Index(i), name,
+					fmt.Sprintf("is already a member of %s", other)))
+				continue
+			}
+			memberOf[name] = owner
+		}
+	}
+
+	for i, b := range networkSpec.Bonds {
+		p := networkPath.Child("bonds").Index(i)
+		checkName(p.Child("name"), b.Name, kindBond)
+		checkMembers(p.Child("interfaces"), b.Name, b.Interfaces,
+			"must be the name of an interface", kindInterface)
+		if b.Primary != "" && !slices.Contains(b.Interfaces, b.Primary) {
+			allErrs = append(allErrs, field.Invalid(p.Child("primary"), b.Primary,
+				"must be one of the bond's interfaces"))
+		}
+		allErrs = append(allErrs, validateNetworkIPConfig(p, b.VirtualMachineNetworkGuestDeviceIPSpec)...)
+	}
+
+	for i, vl := range networkSpec.VLANs {
+		p := networkPath.Child("vlans").Index(i)
+		checkName(p.Child("name"), vl.Name, kindVLAN)
+		if k := kinds[vl.Link]; k != kindInterface && k != kindBond {
+			allErrs = append(allErrs, field.Invalid(p.Child("link"), vl.Link,
+				"must be the name of an interface or bond"))
+		}
+		allErrs = append(allErrs, validateNetworkIPConfig(p, vl.VirtualMachineNetworkGuestDeviceIPSpec)...)
+	}
+
+	for i, b := range networkSpec.Bridges {
+		p := networkPath.Child("bridges").Index(i)
+		checkName(p.Child("name"), b.Name, kindBridge)
+		checkMembers(p.Child("interfaces"), b.Name, b.Interfaces,
+			"must be the name of an interface, bond, or VLAN", kindInterface, kindBond, kindVLAN)
+		allErrs = append(allErrs, validateNetworkIPConfig(p, b.VirtualMachineNetworkGuestDeviceIPSpec)...)
+	}
+
+	return allErrs
+}
+
+// MTU and guestDeviceName are available only with CloudInit and Ignition.
+// Routes and searchDomains are available only with CloudInit.
 // Nameservers is available only with CloudInit and Sysprep.
 func (v validator) validateNetworkInterfaceSpecWithBootstrap(
 	_ *pkgctx.WebhookRequestContext,
@@ -830,30 +1440,32 @@ func (v validator) validateNetworkInterfaceSpecWithBootstrap(
 
 	var (
 		cloudInit *vmopv1.VirtualMachineBootstrapCloudInitSpec
+		ignition  *vmopv1.VirtualMachineBootstrapIgnitionSpec
 		sysPrep   *vmopv1.VirtualMachineBootstrapSysprepSpec
 	)
 
 	if vm.Spec.Bootstrap != nil {
 		cloudInit = vm.Spec.Bootstrap.CloudInit
+		ignition = vm.Spec.Bootstrap.Ignition
 		sysPrep = vm.Spec.Bootstrap.Sysprep
 	}
 
 	if guestDeviceName := interfaceSpec.GuestDeviceName; guestDeviceName != "" {
-		if cloudInit == nil {
+		if cloudInit == nil && ignition == nil {
 			allErrs = append(allErrs, field.Invalid(
 				interfacePath.Child("guestDeviceName"),
 				guestDeviceName,
-				"guestDeviceName is available only with the following bootstrap providers: CloudInit",
+				"guestDeviceName is available only with the following bootstrap providers: CloudInit and Ignition",
 			))
 		}
 	}
 
 	if mtu := interfaceSpec.MTU; mtu != nil {
-		if cloudInit == nil {
+		if cloudInit == nil && ignition == nil {
 			allErrs = append(allErrs, field.Invalid(
 				interfacePath.Child("mtu"),
 				mtu,
-				"mtu is available only with the following bootstrap providers: CloudInit",
+				"mtu is available only with the following bootstrap providers: CloudInit and Ignition",
 			))
 		}
 	}
@@ -1182,6 +1794,31 @@ func (v validator) validatePowerStateOnUpdate(
 	return allErrs
 }
 
+func customizationGeneration(vm *vmopv1.VirtualMachine) int64 {
+	if vm.Spec.Bootstrap == nil {
+		return 0
+	}
+	return vm.Spec.Bootstrap.CustomizationGeneration
+}
+
+// validateCustomizationGeneration ensures the value of
+// spec.bootstrap.customizationGeneration is not decreased.
+func (v validator) validateCustomizationGeneration(
+	_ *pkgctx.WebhookRequestContext,
+	vm, oldVM *vmopv1.VirtualMachine) field.ErrorList {
+
+	var allErrs field.ErrorList
+
+	if newGen, oldGen := customizationGeneration(vm), customizationGeneration(oldVM); newGen < oldGen {
+		allErrs = append(allErrs, field.Invalid(
+			field.NewPath("spec", "bootstrap", "customizationGeneration"),
+			newGen,
+			fmt.Sprintf(customizationGenerationDecreasedFmt, oldGen)))
+	}
+
+	return allErrs
+}
+
 func isBootstrapCloudInit(vm *vmopv1.VirtualMachine) bool {
 	if vm.Spec.Bootstrap == nil {
 		return false
@@ -1203,7 +1840,11 @@ func (v validator) validateUpdatesWhenPoweredOn(
 		}
 	}
 
-	if !equality.Semantic.DeepEqual(vm.Spec.Bootstrap, oldVM.Spec.Bootstrap) {
+	// Bootstrap changes are allowed if the guest is re-customized as part of
+	// the same update, since the VM is powered off before it is customized.
+	if !equality.Semantic.DeepEqual(vm.Spec.Bootstrap, oldVM.Spec.Bootstrap) &&
+		customizationGeneration(vm) <= customizationGeneration(oldVM) {
+
 		allErrs = append(allErrs, field.Forbidden(specPath.Child("bootstrap"), updatesNotAllowedWhenPowerOn))
 	}
 
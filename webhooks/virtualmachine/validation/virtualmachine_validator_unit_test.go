@@ -6,6 +6,12 @@ package validation_test
 
 import (
 	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
 	"fmt"
 	"strings"
 	"time"
@@ -34,7 +40,9 @@ import (
 	pkgconst "github.com/vmware-tanzu/vm-operator/pkg/constants"
 	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
 	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/config"
+	"github.com/vmware-tanzu/vm-operator/pkg/providers/vsphere/constants"
 	"github.com/vmware-tanzu/vm-operator/pkg/topology"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/image/provenance"
 	kubeutil "github.com/vmware-tanzu/vm-operator/pkg/util/kube"
 	"github.com/vmware-tanzu/vm-operator/pkg/util/ptr"
 	vmopv1util "github.com/vmware-tanzu/vm-operator/pkg/util/vmopv1"
@@ -59,6 +67,7 @@ const (
 	cvmiKind                       = "Cluster" + vmiKind
 	invalidKind                    = "InvalidKind"
 	invalidImageKindMsg            = "supported: " + vmiKind + "; " + cvmiKind
+	invalidImageOCIMsg             = "deploying a VM from an image backed by an OCI artifact is not supported"
 )
 
 type testParams struct {
@@ -158,6 +167,95 @@ func newUnitTestContextForValidatingWebhook(isUpdate bool) *unitValidatingWebhoo
 	}
 }
 
+func createOVFPropertiesImage(ctx *unitValidatingWebhookContext) {
+	img := builder.DummyVirtualMachineImage(builder.DummyVMIName)
+	img.Namespace = ctx.vm.Namespace
+	img.Status.OVFProperties = []vmopv1.OVFProperty{
+		{Key: "port", Type: "int(1..65535)"},
+		{Key: "enabled", Type: "boolean"},
+		{Key: "size", Type: `string["small","large"]`, Default: ptr.To("small")},
+		{Key: "ip", Type: "ip"},
+		{Key: "password", Type: "password(8..)"},
+	}
+	ExpectWithOffset(1, ctx.Client.Create(ctx, img)).To(Succeed())
+}
+
+func createFirmwareImage(ctx *unitValidatingWebhookContext, firmware string) {
+	img := builder.DummyVirtualMachineImage(builder.DummyVMIName)
+	img.Namespace = ctx.vm.Namespace
+	img.Status.Firmware = firmware
+	ExpectWithOffset(1, ctx.Client.Create(ctx, img)).To(Succeed())
+}
+
+// createProvenanceImage creates an image with a provenance record and a
+// Secret with the public key trusted to verify it. The record is only signed
+// if signed is true.
+func createProvenanceImage(ctx *unitValidatingWebhookContext, signed bool) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	ExpectWithOffset(1, err).ToNot(HaveOccurred())
+	der, err := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	ExpectWithOffset(1, err).ToNot(HaveOccurred())
+
+	pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+		config.ImageProvenance.RequireVerifiedImages = true
+		config.ImageProvenance.PublicKeysSecretName = "image-provenance-public-keys"
+	})
+
+	ExpectWithOffset(1, ctx.Client.Create(ctx, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: pkgcfg.FromContext(ctx).PodNamespace,
+			Name:      "image-provenance-public-keys",
+		},
+		Data: map[string][]byte{
+			"pub.pem": pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der}),
+		},
+	})).To(Succeed())
+
+	img := builder.DummyVirtualMachineImage(builder.DummyVMIName)
+	img.Namespace = ctx.vm.Namespace
+	img.Status.ProviderItemID = "my-item-id"
+
+	data, err := json.Marshal(provenance.Record{
+		Version: provenance.RecordVersion,
+		Image: provenance.Image{
+			Name:           img.Name,
+			ProviderItemID: "my-item-id",
+		},
+	})
+	ExpectWithOffset(1, err).ToNot(HaveOccurred())
+	var sig string
+	if signed {
+		sig, err = provenance.Sign(key, data)
+		ExpectWithOffset(1, err).ToNot(HaveOccurred())
+	}
+	provenance.SetOnImage(img, data, sig)
+
+	ExpectWithOffset(1, ctx.Client.Create(ctx, img)).To(Succeed())
+	img.Status.ProviderItemID = "my-item-id"
+	ExpectWithOffset(1, ctx.Client.Status().Update(ctx, img)).To(Succeed())
+}
+
+func createUserDataSecret(ctx *unitValidatingWebhookContext, userdata string) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "my-userdata",
+			Namespace: ctx.vm.Namespace,
+		},
+		Data: map[string][]byte{
+			"user-data": []byte(userdata),
+		},
+	}
+	ExpectWithOffset(1, ctx.Client.Create(ctx, secret)).To(Succeed())
+	ctx.vm.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{
+		CloudInit: &vmopv1.VirtualMachineBootstrapCloudInitSpec{
+			RawCloudConfig: &common.SecretKeySelector{
+				Name: secret.Name,
+				Key:  "user-data",
+			},
+		},
+	}
+}
+
 func unitTestsValidateCreate() {
 
 	var (
@@ -616,6 +714,40 @@ func unitTestsValidateCreate() {
 				),
 			},
 		),
+		Entry("disallow an image backed by an OCI artifact",
+			testParams{
+				setup: func(ctx *unitValidatingWebhookContext) {
+					img := builder.DummyVirtualMachineImage(ctx.vm.Spec.Image.Name)
+					img.Namespace = ctx.vm.Namespace
+					img.Spec.OCI = &vmopv1.VirtualMachineImageOCISource{
+						Reference: "registry.example.com/my-project/my-image:v1",
+					}
+					Expect(ctx.Client.Create(ctx, img)).To(Succeed())
+				},
+				validate: doValidateWithMsg(
+					field.Invalid(field.NewPath("spec", "image", "name"), builder.DummyVMIName, invalidImageOCIMsg).Error(),
+				),
+			},
+		),
+		Entry("allow an image with a signed provenance record when verified images are required",
+			testParams{
+				setup: func(ctx *unitValidatingWebhookContext) {
+					createProvenanceImage(ctx, true)
+				},
+				expectAllowed: true,
+			},
+		),
+		Entry("disallow an image with an unsigned provenance record when verified images are required",
+			testParams{
+				setup: func(ctx *unitValidatingWebhookContext) {
+					createProvenanceImage(ctx, false)
+				},
+				validate: doValidateWithMsg(
+					field.Invalid(field.NewPath("spec", "image", "name"), builder.DummyVMIName,
+						"failed to verify image provenance: "+provenance.ErrNotSigned.Error()).Error(),
+				),
+			},
+		),
 
 		// FSS_WCP_VMSERVICE_INCREMENTAL_RESTORE is enabled
 
@@ -1598,6 +1730,300 @@ func unitTestsValidateCreate() {
 					),
 				},
 			),
+			Entry("allow vAppConfig with a valid template",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{
+							VAppConfig: &vmopv1.VirtualMachineBootstrapVAppConfigSpec{
+								Properties: []common.KeyValueOrSecretKeySelectorPair{
+									{
+										Key: "ip",
+										Value: common.ValueOrSecretKeySelector{
+											Value: ptr.To("{{ V1alpha4_FirstIP }}"),
+										},
+									},
+								},
+							},
+						}
+					},
+					validate: func(response admission.Response) {
+						Expect(response.Warnings).To(BeEmpty())
+					},
+					expectAllowed: true,
+				},
+			),
+			Entry("allow vAppConfig with a zone template without a warning",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						delete(ctx.vm.Labels, topology.KubernetesTopologyZoneLabelKey)
+						ctx.vm.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{
+							VAppConfig: &vmopv1.VirtualMachineBootstrapVAppConfigSpec{
+								Properties: []common.KeyValueOrSecretKeySelectorPair{
+									{
+										Key: "zone",
+										Value: common.ValueOrSecretKeySelector{
+											Value: ptr.To("{{ V1alpha4_ZoneName }}"),
+										},
+									},
+								},
+							},
+						}
+					},
+					validate: func(response admission.Response) {
+						Expect(response.Warnings).To(BeEmpty())
+					},
+					expectAllowed: true,
+				},
+			),
+			Entry("allow vAppConfig with an invalid template with a warning",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{
+							VAppConfig: &vmopv1.VirtualMachineBootstrapVAppConfigSpec{
+								Properties: []common.KeyValueOrSecretKeySelectorPair{
+									{
+										Key: "ip",
+										Value: common.ValueOrSecretKeySelector{
+											Value: ptr.To("{{ V1alpha4_FirstIP }}"),
+										},
+									},
+									{
+										Key: "label",
+										Value: common.ValueOrSecretKeySelector{
+											Value: ptr.To(`{{ V1alpha4_Label "not-a-label" }}`),
+										},
+									},
+								},
+							},
+						}
+					},
+					validate: func(response admission.Response) {
+						Expect(response.Warnings).To(HaveLen(1))
+						Expect(response.Warnings[0]).To(HavePrefix("spec.bootstrap.vAppConfig.properties[1].value.value: "))
+						Expect(response.Warnings[0]).To(ContainSubstring(`no label "not-a-label"`))
+					},
+					expectAllowed: true,
+				},
+			),
+			Entry("allow Cloudbase-Init user-data for a non-Windows guest with a warning",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						createUserDataSecret(ctx, "#ps1_sysnative\nWrite-Host hello")
+						ctx.vm.Spec.GuestID = "ubuntu64Guest"
+					},
+					validate: func(response admission.Response) {
+						Expect(response.Warnings).To(ConsistOf(
+							`spec.bootstrap.cloudInit.rawCloudConfig: user-data format is only supported by ` +
+								`Cloudbase-Init on Windows guests, guest ID is "ubuntu64Guest"`))
+					},
+					expectAllowed: true,
+				},
+			),
+			Entry("allow Cloudbase-Init user-data for a non-Windows image with a warning",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						createUserDataSecret(ctx, "#ps1_sysnative\nWrite-Host hello")
+					},
+					validate: func(response admission.Response) {
+						Expect(response.Warnings).To(ConsistOf(
+							`spec.bootstrap.cloudInit.rawCloudConfig: user-data format is only supported by ` +
+								`Cloudbase-Init on Windows guests, guest ID is "` + builder.DummyOSType + `"`))
+					},
+					expectAllowed: true,
+				},
+			),
+			Entry("allow Cloudbase-Init user-data for a Windows guest",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						createUserDataSecret(ctx, "#ps1_sysnative\nWrite-Host hello")
+						ctx.vm.Spec.GuestID = "windows2019srv_64Guest"
+					},
+					validate: func(response admission.Response) {
+						Expect(response.Warnings).To(BeEmpty())
+					},
+					expectAllowed: true,
+				},
+			),
+			Entry("allow Cloud-Init user-data for a non-Windows guest",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						createUserDataSecret(ctx, "#cloud-config\nhostname: my-vm")
+						ctx.vm.Spec.GuestID = "ubuntu64Guest"
+					},
+					validate: func(response admission.Response) {
+						Expect(response.Warnings).To(BeEmpty())
+					},
+					expectAllowed: true,
+				},
+			),
+			Entry("allow vAppConfig with properties that match the image's OVF property types",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						createOVFPropertiesImage(ctx)
+						ctx.vm.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{
+							VAppConfig: &vmopv1.VirtualMachineBootstrapVAppConfigSpec{
+								Properties: []common.KeyValueOrSecretKeySelectorPair{
+									{
+										Key:   "port",
+										Value: common.ValueOrSecretKeySelector{Value: ptr.To("443")},
+									},
+									{
+										Key:   "size",
+										Value: common.ValueOrSecretKeySelector{Value: ptr.To("large")},
+									},
+									{
+										Key:   "ip",
+										Value: common.ValueOrSecretKeySelector{Value: ptr.To("{{ V1alpha4_FirstIP }}")},
+									},
+									{
+										Key:   "unknown",
+										Value: common.ValueOrSecretKeySelector{Value: ptr.To("anything")},
+									},
+								},
+							},
+						}
+					},
+					expectAllowed: true,
+				},
+			),
+			Entry("disallow vAppConfig with properties that do not match the image's OVF property types",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						createOVFPropertiesImage(ctx)
+						ctx.vm.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{
+							VAppConfig: &vmopv1.VirtualMachineBootstrapVAppConfigSpec{
+								Properties: []common.KeyValueOrSecretKeySelectorPair{
+									{
+										Key:   "port",
+										Value: common.ValueOrSecretKeySelector{Value: ptr.To("65536")},
+									},
+									{
+										Key:   "enabled",
+										Value: common.ValueOrSecretKeySelector{Value: ptr.To("yes")},
+									},
+									{
+										Key:   "size",
+										Value: common.ValueOrSecretKeySelector{Value: ptr.To("medium")},
+									},
+									{
+										Key:   "ip",
+										Value: common.ValueOrSecretKeySelector{Value: ptr.To("192.168.1.300")},
+									},
+									{
+										Key:   "password",
+										Value: common.ValueOrSecretKeySelector{Value: ptr.To("secret")},
+									},
+								},
+							},
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.bootstrap.vAppConfig.properties[0].value.value: Invalid value: "65536": int(1..65535): must be less than or equal to 65535`,
+						`spec.bootstrap.vAppConfig.properties[1].value.value: Invalid value: "yes": boolean: must be one of True, False`,
+						`spec.bootstrap.vAppConfig.properties[2].value.value: Invalid value: "medium": string["small","large"]: must be one of small, large`,
+						`spec.bootstrap.vAppConfig.properties[3].value.value: Invalid value: "192.168.1.300": ip: must be an IPv4 or IPv6 address`,
+						`spec.bootstrap.vAppConfig.properties[4].value.value: Invalid value: "<redacted>": password(8..): must be at least 8 characters`,
+					),
+				},
+			),
+			Entry("allow Ignition with inline config",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{
+							Ignition: &vmopv1.VirtualMachineBootstrapIgnitionSpec{
+								Config: `{"ignition":{"version":"3.4.0"}}`,
+							},
+						}
+					},
+					expectAllowed: true,
+				},
+			),
+			Entry("allow Ignition with raw config",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{
+							Ignition: &vmopv1.VirtualMachineBootstrapIgnitionSpec{
+								RawConfig: &common.SecretKeySelector{
+									Name: "my-ignition",
+									Key:  "config.ign",
+								},
+							},
+						}
+					},
+					expectAllowed: true,
+				},
+			),
+			Entry("disallow Ignition and CloudInit specified at the same time",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{
+							CloudInit: &vmopv1.VirtualMachineBootstrapCloudInitSpec{},
+							Ignition: &vmopv1.VirtualMachineBootstrapIgnitionSpec{
+								Config: `{"ignition":{"version":"3.4.0"}}`,
+							},
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.bootstrap.ignition: Forbidden: Ignition may not be used with any other bootstrap provider`,
+					),
+				},
+			),
+			Entry("disallow Ignition mixing Config and RawConfig",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{
+							Ignition: &vmopv1.VirtualMachineBootstrapIgnitionSpec{
+								Config: `{"ignition":{"version":"3.4.0"}}`,
+								RawConfig: &common.SecretKeySelector{
+									Name: "my-ignition",
+									Key:  "config.ign",
+								},
+							},
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.bootstrap.ignition: Invalid value: "ignition": config and rawConfig are mutually exclusive`,
+					),
+				},
+			),
+			Entry("disallow Ignition without Config or RawConfig",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{
+							Ignition: &vmopv1.VirtualMachineBootstrapIgnitionSpec{},
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.bootstrap.ignition: Invalid value: "ignition": either config or rawConfig must be provided`,
+					),
+				},
+			),
+			Entry("allow Ignition with Butane config",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{
+							Ignition: &vmopv1.VirtualMachineBootstrapIgnitionSpec{
+								Config: "variant: flatcar\nversion: 1.0.0\n",
+							},
+						}
+					},
+					expectAllowed: true,
+				},
+			),
+			Entry("disallow Ignition with Butane config that uses local files",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{
+							Ignition: &vmopv1.VirtualMachineBootstrapIgnitionSpec{
+								Config: "variant: flatcar\nversion: 1.0.0\nstorage:\n  trees:\n  - local: etc\n",
+							},
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.bootstrap.ignition.config: Invalid value: "config": failed to translate butane config: butane field storage.trees is not supported`,
+					),
+				},
+			),
 
 			Entry("disallow vAppConfig mixing Properties Value From Secret and direct String pointer",
 				testParams{
@@ -1900,35 +2326,267 @@ func unitTestsValidateCreate() {
 				},
 			),
 
-			Entry("disallows guestDeviceName without CloudInit",
+			Entry("disallows guestDeviceName without CloudInit",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Network = &vmopv1.VirtualMachineNetworkSpec{
+							Interfaces: []vmopv1.VirtualMachineNetworkInterfaceSpec{
+								{
+									Name:            "eth0",
+									GuestDeviceName: "mydev",
+								},
+							},
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.network.interfaces[0].guestDeviceName: Invalid value: "mydev": guestDeviceName is available only with the following bootstrap providers: CloudInit and Ignition`,
+					),
+				},
+			),
+
+			Entry("allow dhcp",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Network = &vmopv1.VirtualMachineNetworkSpec{
+							HostName: "my-vm",
+							Interfaces: []vmopv1.VirtualMachineNetworkInterfaceSpec{
+								{
+									Name:  "eth0",
+									DHCP4: true,
+									DHCP6: true,
+								},
+							},
+						}
+					},
+					expectAllowed: true,
+				},
+			),
+
+			Entry("disallow mixing static and dhcp",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Network = &vmopv1.VirtualMachineNetworkSpec{
+							HostName: "my-vm",
+							Interfaces: []vmopv1.VirtualMachineNetworkInterfaceSpec{
+								{
+									Name: "eth0",
+									Addresses: []string{
+										"192.168.1.100/24",
+										"2605:a601:a0ba:720:2ce6:776d:8be4:2496/48",
+									},
+									DHCP4:    true,
+									DHCP6:    true,
+									Gateway4: "192.168.1.1",
+									Gateway6: "2605:a601:a0ba:720:2ce6::1",
+								},
+							},
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.network.interfaces[0].dhcp4: Invalid value: "192.168.1.100/24": dhcp4 cannot be used with IPv4 addresses in addresses field`,
+						`spec.network.interfaces[0].gateway4: Invalid value: "192.168.1.1": gateway4 is mutually exclusive with dhcp4`,
+						`spec.network.interfaces[0].dhcp6: Invalid value: "2605:a601:a0ba:720:2ce6:776d:8be4:2496/48": dhcp6 cannot be used with IPv6 addresses in addresses field`,
+						`spec.network.interfaces[0].gateway6: Invalid value: "2605:a601:a0ba:720:2ce6::1": gateway6 is mutually exclusive with dhcp6`,
+					),
+				},
+			),
+
+			Entry("validate addresses",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Network.Interfaces[0].Addresses = []string{
+							"1.1.",
+							"1.1.1.1",
+							"not-an-ip",
+							"7936:39e1:d51b:39d2:05f8:1fb2:35cc:1072",
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.network.interfaces[0].addresses[0]: Invalid value: "1.1.": invalid CIDR address: 1.1.`,
+						`spec.network.interfaces[0].addresses[1]: Invalid value: "1.1.1.1": invalid CIDR address: 1.1.1.1`,
+						`spec.network.interfaces[0].addresses[2]: Invalid value: "not-an-ip": invalid CIDR address: not-an-ip`,
+						`spec.network.interfaces[0].addresses[3]: Invalid value: "7936:39e1:d51b:39d2:05f8:1fb2:35cc:1072": invalid CIDR address: 7936:39e1:d51b:39d2:05f8:1fb2:35cc:1072`,
+					),
+				},
+			),
+
+			Entry("validate gateway4",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Network.Interfaces[0].Gateway4 = "7936:39e1:d51b:39d2:05f8:1fb2:35cc:1072"
+					},
+					validate: doValidateWithMsg(
+						`spec.network.interfaces[0].gateway4: Invalid value: "7936:39e1:d51b:39d2:05f8:1fb2:35cc:1072": gateway4 must have an IPv4 address in the addresses field`,
+						`spec.network.interfaces[0].gateway4: Invalid value: "7936:39e1:d51b:39d2:05f8:1fb2:35cc:1072": must be a valid IPv4 address`,
+					),
+				},
+			),
+
+			Entry("validate gateway6",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Network.Interfaces[0].Gateway6 = "192.168.1.1"
+					},
+					validate: doValidateWithMsg(
+						`spec.network.interfaces[0].gateway6: Invalid value: "192.168.1.1": gateway6 must have an IPv6 address in the addresses field`,
+						`spec.network.interfaces[0].gateway6: Invalid value: "192.168.1.1": must be a valid IPv6 address`,
+					),
+				},
+			),
+
+			Entry("allow addressesFromPools",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+							config.NetworkProviderType = pkgcfg.NetworkProviderTypeVDS
+						})
+						ctx.vm.Spec.Network.Interfaces[0].AddressesFromPools = []corev1.TypedLocalObjectReference{
+							{
+								APIGroup: ptr.To("ipam.cluster.x-k8s.io"),
+								Kind:     "InClusterIPPool",
+								Name:     "my-pool",
+							},
+						}
+					},
+					expectAllowed: true,
+				},
+			),
+
+			Entry("disallow addressesFromPools with addresses and dhcp",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+							config.NetworkProviderType = pkgcfg.NetworkProviderTypeNamed
+						})
+						ctx.vm.Spec.Network.Interfaces[0].Addresses = []string{"192.168.1.100/24"}
+						ctx.vm.Spec.Network.Interfaces[0].DHCP6 = true
+						ctx.vm.Spec.Network.Interfaces[0].AddressesFromPools = []corev1.TypedLocalObjectReference{
+							{
+								APIGroup: ptr.To("ipam.cluster.x-k8s.io"),
+								Kind:     "InClusterIPPool",
+								Name:     "my-pool",
+							},
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.network.interfaces[0].addresses: Invalid value: "192.168.1.100/24": addresses is mutually exclusive with addressesFromPools`,
+						`spec.network.interfaces[0].dhcp6: Invalid value: true: dhcp6 is mutually exclusive with addressesFromPools`,
+					),
+				},
+			),
+
+			Entry("disallow addressesFromPools with incomplete pool reference",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+							config.NetworkProviderType = pkgcfg.NetworkProviderTypeVDS
+						})
+						ctx.vm.Spec.Network.Interfaces[0].AddressesFromPools = []corev1.TypedLocalObjectReference{
+							{},
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.network.interfaces[0].addressesFromPools[0].name: Required value`,
+						`spec.network.interfaces[0].addressesFromPools[0].kind: Required value`,
+						`spec.network.interfaces[0].addressesFromPools[0].apiGroup: Required value`,
+					),
+				},
+			),
+
+			Entry("disallow addressesFromPools with VPC network provider",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+							config.NetworkProviderType = pkgcfg.NetworkProviderTypeVPC
+						})
+						ctx.vm.Spec.Network.Interfaces[0].AddressesFromPools = []corev1.TypedLocalObjectReference{
+							{
+								APIGroup: ptr.To("ipam.cluster.x-k8s.io"),
+								Kind:     "InClusterIPPool",
+								Name:     "my-pool",
+							},
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.network.interfaces[0].addressesFromPools: Forbidden: not supported with network provider "NSXT_VPC"`,
+					),
+				},
+			),
+
+			Entry("allow sriov interface type",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Network.Interfaces[0].Type = vmopv1.VirtualMachineNetworkInterfaceTypeSRIOV
+						ctx.vm.Spec.Network.Interfaces[0].SRIOV = &vmopv1.VirtualMachineNetworkInterfaceSRIOVSpec{
+							PhysicalFunction:      "0000:3b:00.1",
+							AllowGuestOSMTUChange: ptr.To(true),
+						}
+					},
+					expectAllowed: true,
+				},
+			),
+
+			Entry("allow dvx interface type",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Network.Interfaces[0].Type = vmopv1.VirtualMachineNetworkInterfaceTypeDVX
+						ctx.vm.Spec.Network.Interfaces[0].DVX = &vmopv1.VirtualMachineNetworkInterfaceDVXSpec{
+							DeviceClass: "com.vmware.dvx.nic",
+						}
+					},
+					expectAllowed: true,
+				},
+			),
+
+			Entry("disallow sriov and dvx with mismatched interface type",
 				testParams{
 					setup: func(ctx *unitValidatingWebhookContext) {
-						ctx.vm.Spec.Network = &vmopv1.VirtualMachineNetworkSpec{
-							Interfaces: []vmopv1.VirtualMachineNetworkInterfaceSpec{
-								{
-									Name:            "eth0",
-									GuestDeviceName: "mydev",
-								},
-							},
+						ctx.vm.Spec.Network.Interfaces[0].Type = vmopv1.VirtualMachineNetworkInterfaceTypeE1000e
+						ctx.vm.Spec.Network.Interfaces[0].SRIOV = &vmopv1.VirtualMachineNetworkInterfaceSRIOVSpec{}
+						ctx.vm.Spec.Network.Interfaces[0].DVX = &vmopv1.VirtualMachineNetworkInterfaceDVXSpec{
+							DeviceClass: "com.vmware.dvx.nic",
 						}
 					},
 					validate: doValidateWithMsg(
-						`spec.network.interfaces[0].guestDeviceName: Invalid value: "mydev": guestDeviceName is available only with the following bootstrap providers: CloudInit`,
+						`spec.network.interfaces[0].sriov: Forbidden: may only be set when type is "sriov"`,
+						`spec.network.interfaces[0].dvx: Forbidden: may only be set when type is "dvx"`,
 					),
 				},
 			),
 
-			Entry("allow dhcp",
+			Entry("disallow dvx interface type without device class",
 				testParams{
 					setup: func(ctx *unitValidatingWebhookContext) {
-						ctx.vm.Spec.Network = &vmopv1.VirtualMachineNetworkSpec{
-							HostName: "my-vm",
-							Interfaces: []vmopv1.VirtualMachineNetworkInterfaceSpec{
-								{
-									Name:  "eth0",
-									DHCP4: true,
-									DHCP6: true,
-								},
+						ctx.vm.Spec.Network.Interfaces[0].Type = vmopv1.VirtualMachineNetworkInterfaceTypeDVX
+						ctx.vm.Spec.Network.Interfaces[0].DVX = &vmopv1.VirtualMachineNetworkInterfaceDVXSpec{}
+					},
+					validate: doValidateWithMsg(
+						`spec.network.interfaces[0].dvx.deviceClass: Required value`,
+					),
+				},
+			),
+
+			Entry("allow qos and traffic rules",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+							config.NetworkProviderType = pkgcfg.NetworkProviderTypeVDS
+						})
+						ctx.vm.Spec.Network.Interfaces[0].QoS = &vmopv1.VirtualMachineNetworkInterfaceQoS{
+							Reservation: ptr.To[int64](100),
+							Limit:       ptr.To[int64](1000),
+							Shares:      ptr.To[int32](80),
+						}
+						ctx.vm.Spec.Network.Interfaces[0].TrafficRules = []vmopv1.VirtualMachineNetworkInterfaceTrafficRule{
+							{
+								Action:          vmopv1.VirtualMachineNetworkInterfaceTrafficRuleActionAllow,
+								Direction:       vmopv1.VirtualMachineNetworkInterfaceTrafficRuleDirectionIngress,
+								SourceCIDR:      "192.168.1.0/24",
+								Protocol:        vmopv1.VirtualMachineNetworkInterfaceTrafficRuleProtocolTCP,
+								DestinationPort: ptr.To[int32](443),
+							},
+							{
+								Action: vmopv1.VirtualMachineNetworkInterfaceTrafficRuleActionDeny,
 							},
 						}
 					},
@@ -1936,79 +2594,85 @@ func unitTestsValidateCreate() {
 				},
 			),
 
-			Entry("disallow mixing static and dhcp",
+			Entry("disallow invalid qos and traffic rules",
 				testParams{
 					setup: func(ctx *unitValidatingWebhookContext) {
-						ctx.vm.Spec.Network = &vmopv1.VirtualMachineNetworkSpec{
-							HostName: "my-vm",
-							Interfaces: []vmopv1.VirtualMachineNetworkInterfaceSpec{
-								{
-									Name: "eth0",
-									Addresses: []string{
-										"192.168.1.100/24",
-										"2605:a601:a0ba:720:2ce6:776d:8be4:2496/48",
-									},
-									DHCP4:    true,
-									DHCP6:    true,
-									Gateway4: "192.168.1.1",
-									Gateway6: "2605:a601:a0ba:720:2ce6::1",
-								},
+						pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+							config.NetworkProviderType = pkgcfg.NetworkProviderTypeVDS
+						})
+						ctx.vm.Spec.Network.Interfaces[0].QoS = &vmopv1.VirtualMachineNetworkInterfaceQoS{
+							Reservation: ptr.To[int64](1000),
+							Limit:       ptr.To[int64](100),
+						}
+						ctx.vm.Spec.Network.Interfaces[0].TrafficRules = []vmopv1.VirtualMachineNetworkInterfaceTrafficRule{
+							{
+								Action:          vmopv1.VirtualMachineNetworkInterfaceTrafficRuleActionAllow,
+								DestinationCIDR: "192.168.1.1",
+								Protocol:        vmopv1.VirtualMachineNetworkInterfaceTrafficRuleProtocolICMP,
+								DestinationPort: ptr.To[int32](443),
 							},
 						}
 					},
 					validate: doValidateWithMsg(
-						`spec.network.interfaces[0].dhcp4: Invalid value: "192.168.1.100/24": dhcp4 cannot be used with IPv4 addresses in addresses field`,
-						`spec.network.interfaces[0].gateway4: Invalid value: "192.168.1.1": gateway4 is mutually exclusive with dhcp4`,
-						`spec.network.interfaces[0].dhcp6: Invalid value: "2605:a601:a0ba:720:2ce6:776d:8be4:2496/48": dhcp6 cannot be used with IPv6 addresses in addresses field`,
-						`spec.network.interfaces[0].gateway6: Invalid value: "2605:a601:a0ba:720:2ce6::1": gateway6 is mutually exclusive with dhcp6`,
+						`spec.network.interfaces[0].qos.reservation: Invalid value: 1000: must be less than or equal to limit`,
+						`spec.network.interfaces[0].trafficRules[0].destinationCIDR: Invalid value: "192.168.1.1": invalid CIDR address: 192.168.1.1`,
+						`spec.network.interfaces[0].trafficRules[0].destinationPort: Invalid value: 443: requires protocol TCP or UDP`,
 					),
 				},
 			),
 
-			Entry("validate addresses",
+			Entry("allow mac address set by privileged user",
 				testParams{
 					setup: func(ctx *unitValidatingWebhookContext) {
-						ctx.vm.Spec.Network.Interfaces[0].Addresses = []string{
-							"1.1.",
-							"1.1.1.1",
-							"not-an-ip",
-							"7936:39e1:d51b:39d2:05f8:1fb2:35cc:1072",
-						}
+						ctx.IsPrivilegedAccount = true
+						ctx.vm.Spec.Network.Interfaces[0].MacAddress = "00:50:56:00:00:01"
+					},
+					expectAllowed: true,
+				},
+			),
+
+			Entry("disallow mac address set by unprivileged user",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Network.Interfaces[0].MacAddress = "00:50:56:00:00:01"
 					},
 					validate: doValidateWithMsg(
-						`spec.network.interfaces[0].addresses[0]: Invalid value: "1.1.": invalid CIDR address: 1.1.`,
-						`spec.network.interfaces[0].addresses[1]: Invalid value: "1.1.1.1": invalid CIDR address: 1.1.1.1`,
-						`spec.network.interfaces[0].addresses[2]: Invalid value: "not-an-ip": invalid CIDR address: not-an-ip`,
-						`spec.network.interfaces[0].addresses[3]: Invalid value: "7936:39e1:d51b:39d2:05f8:1fb2:35cc:1072": invalid CIDR address: 7936:39e1:d51b:39d2:05f8:1fb2:35cc:1072`,
+						`spec.network.interfaces[0].macAddress: Forbidden: restricted to privileged users`,
 					),
 				},
 			),
 
-			Entry("validate gateway4",
+			Entry("disallow invalid mac address",
 				testParams{
 					setup: func(ctx *unitValidatingWebhookContext) {
-						ctx.vm.Spec.Network.Interfaces[0].Gateway4 = "7936:39e1:d51b:39d2:05f8:1fb2:35cc:1072"
+						ctx.IsPrivilegedAccount = true
+						ctx.vm.Spec.Network.Interfaces[0].MacAddress = "00:50:56:00:00"
 					},
 					validate: doValidateWithMsg(
-						`spec.network.interfaces[0].gateway4: Invalid value: "7936:39e1:d51b:39d2:05f8:1fb2:35cc:1072": gateway4 must have an IPv4 address in the addresses field`,
-						`spec.network.interfaces[0].gateway4: Invalid value: "7936:39e1:d51b:39d2:05f8:1fb2:35cc:1072": must be a valid IPv4 address`,
+						`spec.network.interfaces[0].macAddress: Invalid value: "00:50:56:00:00": must be a 48-bit MAC address`,
 					),
 				},
 			),
 
-			Entry("validate gateway6",
+			Entry("disallow traffic rules with NSX-T network provider",
 				testParams{
 					setup: func(ctx *unitValidatingWebhookContext) {
-						ctx.vm.Spec.Network.Interfaces[0].Gateway6 = "192.168.1.1"
+						pkgcfg.SetContext(ctx, func(config *pkgcfg.Config) {
+							config.NetworkProviderType = pkgcfg.NetworkProviderTypeNSXT
+						})
+						ctx.vm.Spec.Network.Interfaces[0].TrafficRules = []vmopv1.VirtualMachineNetworkInterfaceTrafficRule{
+							{
+								Action: vmopv1.VirtualMachineNetworkInterfaceTrafficRuleActionDeny,
+							},
+						}
 					},
 					validate: doValidateWithMsg(
-						`spec.network.interfaces[0].gateway6: Invalid value: "192.168.1.1": gateway6 must have an IPv6 address in the addresses field`,
-						`spec.network.interfaces[0].gateway6: Invalid value: "192.168.1.1": must be a valid IPv6 address`,
+						`spec.network.interfaces[0].trafficRules: Forbidden: not supported with network provider "NSXT"`,
 					),
 				},
 			),
 
-			// Please note mtu is available only with the following bootstrap providers: CloudInit
+			// Please note mtu is available only with the following bootstrap providers: CloudInit and Ignition
 			Entry("validate mtu when bootstrap doesn't support mtu",
 				testParams{
 					setup: func(ctx *unitValidatingWebhookContext) {
@@ -2026,7 +2690,7 @@ func unitTestsValidateCreate() {
 						}
 					},
 					validate: doValidateWithMsg(
-						`spec.network.interfaces[0].mtu: Invalid value: 9000: mtu is available only with the following bootstrap providers: CloudInit`,
+						`spec.network.interfaces[0].mtu: Invalid value: 9000: mtu is available only with the following bootstrap providers: CloudInit and Ignition`,
 					),
 				},
 			),
@@ -2210,6 +2874,126 @@ func unitTestsValidateCreate() {
 				},
 			),
 
+			Entry("allows bonds, VLANs, and bridges with CloudInit",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{
+							CloudInit: &vmopv1.VirtualMachineBootstrapCloudInitSpec{},
+						}
+						ctx.vm.Spec.Network.Interfaces = append(ctx.vm.Spec.Network.Interfaces,
+							vmopv1.VirtualMachineNetworkInterfaceSpec{Name: "eth1"})
+						ctx.vm.Spec.Network.Bonds = []vmopv1.VirtualMachineNetworkBondSpec{
+							{
+								Name:       "bond0",
+								Interfaces: []string{"eth0", "eth1"},
+								Mode:       vmopv1.VirtualMachineNetworkBondModeActiveBackup,
+								Primary:    "eth0",
+							},
+						}
+						ctx.vm.Spec.Network.VLANs = []vmopv1.VirtualMachineNetworkVLANSpec{
+							{
+								Name: "bond0.100",
+								ID:   100,
+								Link: "bond0",
+							},
+						}
+						ctx.vm.Spec.Network.Bridges = []vmopv1.VirtualMachineNetworkBridgeSpec{
+							{
+								Name:       "br0",
+								Interfaces: []string{"bond0.100"},
+								VirtualMachineNetworkGuestDeviceIPSpec: vmopv1.VirtualMachineNetworkGuestDeviceIPSpec{
+									Addresses: []string{"192.168.1.10/24"},
+									Gateway4:  "192.168.1.1",
+								},
+							},
+						}
+					},
+					expectAllowed: true,
+				},
+			),
+
+			Entry("disallow bonds, VLANs, and bridges when bootstrap is not CloudInit",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{
+							LinuxPrep: &vmopv1.VirtualMachineBootstrapLinuxPrepSpec{},
+						}
+						ctx.vm.Spec.Network.Bonds = []vmopv1.VirtualMachineNetworkBondSpec{
+							{
+								Name:       "bond0",
+								Interfaces: []string{"eth0"},
+							},
+						}
+						ctx.vm.Spec.Network.VLANs = []vmopv1.VirtualMachineNetworkVLANSpec{
+							{
+								Name: "eth0.100",
+								ID:   100,
+								Link: "eth0",
+							},
+						}
+						ctx.vm.Spec.Network.Bridges = []vmopv1.VirtualMachineNetworkBridgeSpec{
+							{
+								Name:       "br0",
+								Interfaces: []string{"bond0"},
+							},
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.network.bonds: Invalid value: "bond0": bonds is available only with the following bootstrap providers: CloudInit`,
+						`spec.network.vlans: Invalid value: "eth0.100": vlans is available only with the following bootstrap providers: CloudInit`,
+						`spec.network.bridges: Invalid value: "br0": bridges is available only with the following bootstrap providers: CloudInit`,
+					),
+				},
+			),
+
+			Entry("disallow bonds, VLANs, and bridges with invalid references",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.vm.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{
+							CloudInit: &vmopv1.VirtualMachineBootstrapCloudInitSpec{},
+						}
+						ctx.vm.Spec.Network.Bonds = []vmopv1.VirtualMachineNetworkBondSpec{
+							{
+								Name:       "bond0",
+								Interfaces: []string{"eth0", "eth9"},
+								Primary:    "eth1",
+							},
+							{
+								Name:       "eth0",
+								Interfaces: []string{"eth0"},
+							},
+						}
+						ctx.vm.Spec.Network.VLANs = []vmopv1.VirtualMachineNetworkVLANSpec{
+							{
+								Name: "br0.100",
+								ID:   100,
+								Link: "br0",
+							},
+						}
+						ctx.vm.Spec.Network.Bridges = []vmopv1.VirtualMachineNetworkBridgeSpec{
+							{
+								Name:       "br0",
+								Interfaces: []string{"eth0"},
+								VirtualMachineNetworkGuestDeviceIPSpec: vmopv1.VirtualMachineNetworkGuestDeviceIPSpec{
+									DHCP4:    true,
+									Gateway4: "192.168.1.1",
+								},
+							},
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.network.bonds[0].interfaces[1]: Invalid value: "eth9": must be the name of an interface`,
+						`spec.network.bonds[0].primary: Invalid value: "eth1": must be one of the bond's interfaces`,
+						`spec.network.bonds[1].name: Duplicate value: "eth0"`,
+						`spec.network.bonds[1].interfaces[0]: Invalid value: "eth0": is already a member of bond0`,
+						`spec.network.vlans[0].link: Invalid value: "br0": must be the name of an interface or bond`,
+						`spec.network.bridges[0].interfaces[0]: Invalid value: "eth0": is already a member of bond0`,
+						`spec.network.bridges[0].gateway4: Invalid value: "192.168.1.1": gateway4 must have an IPv4 address in the addresses field`,
+						`spec.network.bridges[0].gateway4: Invalid value: "192.168.1.1": gateway4 is mutually exclusive with dhcp4`,
+					),
+				},
+			),
+
 			Entry("disallow creating VM with network interfaces resulting in a non-DNS1123 combined network interface CR name/label (`vmName-networkName-interfaceName`)",
 				testParams{
 					setup: func(ctx *unitValidatingWebhookContext) {
@@ -2759,6 +3543,40 @@ func unitTestsValidateCreate() {
 					expectAllowed: true,
 				},
 			),
+
+			Entry("disallow setting efiSecureBoot when the image firmware is BIOS",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						createFirmwareImage(ctx, "bios")
+						ctx.vm.Spec.BootOptions = &vmopv1.VirtualMachineBootOptions{
+							EFISecureBoot: vmopv1.VirtualMachineBootOptionsEFISecureBootEnabled,
+						}
+					},
+					expectAllowed: false,
+					validate: doValidateWithMsg(
+						"spec.image: Invalid value:",
+						vmopv1.VirtualMachineImageSecureBootRequiresEFIReason,
+					),
+				},
+			),
+
+			Entry("warn when the firmware override is not the image's firmware",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						createFirmwareImage(ctx, "efi")
+						if ctx.vm.Annotations == nil {
+							ctx.vm.Annotations = map[string]string{}
+						}
+						ctx.vm.Annotations[constants.FirmwareOverrideAnnotation] = "bios"
+					},
+					validate: func(response admission.Response) {
+						Expect(response.Warnings).To(HaveLen(1))
+						Expect(response.Warnings[0]).To(HavePrefix(
+							"spec.image: " + vmopv1.VirtualMachineImageFirmwareMismatchReason + ": "))
+					},
+					expectAllowed: true,
+				},
+			),
 		)
 	})
 
@@ -2834,6 +3652,7 @@ func unitTestsValidateUpdate() {
 		changeBiosUUID              bool
 		changeImageRef              bool
 		changeImageName             bool
+		changeImageChannel          bool
 		changeStorageClass          bool
 		changeResourcePolicy        bool
 		assignZoneName              bool
@@ -2876,6 +3695,9 @@ func unitTestsValidateUpdate() {
 		if args.changeImageName {
 			ctx.vm.Spec.ImageName += updateSuffix
 		}
+		if args.changeImageChannel {
+			ctx.vm.Spec.ImageChannel += updateSuffix
+		}
 		if args.changeInstanceUUID {
 			ctx.vm.Spec.InstanceUUID += updateSuffix
 		}
@@ -2969,6 +3791,7 @@ func unitTestsValidateUpdate() {
 
 		Entry("should deny image ref change", updateArgs{changeImageRef: true}, false, msg, nil),
 		Entry("should deny image name change", updateArgs{changeImageName: true}, false, msg, nil),
+		Entry("should deny image channel change", updateArgs{changeImageChannel: true}, false, msg, nil),
 		Entry("should deny instance uuid change", updateArgs{changeInstanceUUID: true, oldInstanceUUID: "uuid"}, false, msg, nil),
 		Entry("should deny bios uuid change", updateArgs{changeBiosUUID: true, oldBiosUUID: "uuid"}, false, msg, nil),
 		Entry("should deny storageClass change", updateArgs{changeStorageClass: true}, false, msg, nil),
@@ -3669,6 +4492,44 @@ func unitTestsValidateUpdate() {
 					expectAllowed: true,
 				},
 			),
+
+			Entry("allow unchanged mac address by unprivileged user",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.oldVM.Spec.Network = &vmopv1.VirtualMachineNetworkSpec{
+							Interfaces: []vmopv1.VirtualMachineNetworkInterfaceSpec{
+								{
+									Name:       "eth0",
+									MacAddress: "00:50:56:00:00:01",
+								},
+							},
+						}
+
+						ctx.vm = ctx.oldVM.DeepCopy()
+					},
+					expectAllowed: true,
+				},
+			),
+
+			Entry("disallow changing mac address by unprivileged user",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.oldVM.Spec.Network = &vmopv1.VirtualMachineNetworkSpec{
+							Interfaces: []vmopv1.VirtualMachineNetworkInterfaceSpec{
+								{
+									Name:       "eth0",
+									MacAddress: "00:50:56:00:00:01",
+								},
+							},
+						}
+
+						ctx.vm = ctx.oldVM.DeepCopy()
+						ctx.vm.Spec.Network.Interfaces[0].MacAddress = "00:50:56:00:00:02"
+					},
+					validate: doValidateWithMsg(
+						`spec.network.interfaces[0].macAddress: Forbidden: restricted to privileged users`),
+				},
+			),
 		)
 
 		DescribeTable("update network - host and domain names", doTest,
@@ -3886,6 +4747,71 @@ func unitTestsValidateUpdate() {
 		)
 	})
 
+	Context("CustomizationGeneration", func() {
+
+		DescribeTable("Bootstrap update with different VM power states", doTest,
+
+			Entry("disallow bootstrap change if powered on",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.oldVM.Spec.PowerState = vmopv1.VirtualMachinePowerStateOn
+						ctx.oldVM.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{
+							LinuxPrep: &vmopv1.VirtualMachineBootstrapLinuxPrepSpec{},
+						}
+						ctx.vm.Spec.PowerState = vmopv1.VirtualMachinePowerStateOn
+						ctx.vm.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{
+							LinuxPrep: &vmopv1.VirtualMachineBootstrapLinuxPrepSpec{
+								HardwareClockIsUTC: ptr.To(true),
+							},
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.bootstrap: Forbidden: updates to this field is not allowed when VM power is on`,
+					),
+					expectAllowed: false,
+				},
+			),
+
+			Entry("allow bootstrap change if powered on and customizationGeneration is incremented",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.oldVM.Spec.PowerState = vmopv1.VirtualMachinePowerStateOn
+						ctx.oldVM.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{
+							LinuxPrep: &vmopv1.VirtualMachineBootstrapLinuxPrepSpec{},
+						}
+						ctx.vm.Spec.PowerState = vmopv1.VirtualMachinePowerStateOn
+						ctx.vm.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{
+							CustomizationGeneration: 1,
+							LinuxPrep: &vmopv1.VirtualMachineBootstrapLinuxPrepSpec{
+								HardwareClockIsUTC: ptr.To(true),
+							},
+						}
+					},
+					expectAllowed: true,
+				},
+			),
+
+			Entry("disallow decreasing customizationGeneration",
+				testParams{
+					setup: func(ctx *unitValidatingWebhookContext) {
+						ctx.oldVM.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{
+							CustomizationGeneration: 2,
+							LinuxPrep:               &vmopv1.VirtualMachineBootstrapLinuxPrepSpec{},
+						}
+						ctx.vm.Spec.Bootstrap = &vmopv1.VirtualMachineBootstrapSpec{
+							CustomizationGeneration: 1,
+							LinuxPrep:               &vmopv1.VirtualMachineBootstrapLinuxPrepSpec{},
+						}
+					},
+					validate: doValidateWithMsg(
+						`spec.bootstrap.customizationGeneration: Invalid value: 1: may not be less than the previous value 2`,
+					),
+					expectAllowed: false,
+				},
+			),
+		)
+	})
+
 	Context("GuestID", func() {
 		const (
 			guestID      = "vmwarePhoton64Guest"
@@ -0,0 +1,130 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlmgr "sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/pkg/builder"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	cloudinitvalidate "github.com/vmware-tanzu/vm-operator/pkg/util/cloudinit/validate"
+	"github.com/vmware-tanzu/vm-operator/webhooks/common"
+)
+
+const (
+	webHookName = "default"
+
+	cloudConfigMutuallyExclusive = "cloudConfig and rawCloudConfig are mutually exclusive"
+)
+
+// +kubebuilder:webhook:verbs=create;update,path=/default-validate-vmoperator-vmware-com-v1alpha4-virtualmachinebootstrapdefaults,mutating=false,failurePolicy=fail,groups=vmoperator.vmware.com,resources=virtualmachinebootstrapdefaults,versions=v1alpha4,name=default.validating.virtualmachinebootstrapdefaults.v1alpha4.vmoperator.vmware.com,sideEffects=None,admissionReviewVersions=v1;v1beta1
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachinebootstrapdefaults,verbs=get;list
+
+// AddToManager adds the webhook to the provided manager.
+func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr ctrlmgr.Manager) error {
+	hook, err := builder.NewValidatingWebhook(ctx, mgr, webHookName, NewValidator(mgr.GetClient()))
+	if err != nil {
+		return fmt.Errorf("failed to create VirtualMachineBootstrapDefaults validation webhook: %w", err)
+	}
+	mgr.GetWebhookServer().Register(hook.Path, hook)
+
+	return nil
+}
+
+// NewValidator returns the package's Validator.
+func NewValidator(_ client.Client) builder.Validator {
+	return validator{
+		converter: runtime.DefaultUnstructuredConverter,
+	}
+}
+
+type validator struct {
+	converter runtime.UnstructuredConverter
+}
+
+func (v validator) For() schema.GroupVersionKind {
+	return vmopv1.GroupVersion.WithKind(reflect.TypeOf(vmopv1.VirtualMachineBootstrapDefaults{}).Name())
+}
+
+func (v validator) ValidateCreate(ctx *pkgctx.WebhookRequestContext) admission.Response {
+	bsd, err := v.bootstrapDefaultsFromUnstructured(ctx.Obj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	return v.validate(ctx, bsd)
+}
+
+func (v validator) ValidateDelete(*pkgctx.WebhookRequestContext) admission.Response {
+	return admission.Allowed("")
+}
+
+func (v validator) ValidateUpdate(ctx *pkgctx.WebhookRequestContext) admission.Response {
+	bsd, err := v.bootstrapDefaultsFromUnstructured(ctx.Obj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	// The defaults are read when a VM is bootstrapped, so the spec may be
+	// changed as long as it remains valid.
+	return v.validate(ctx, bsd)
+}
+
+func (v validator) validate(
+	ctx *pkgctx.WebhookRequestContext,
+	bsd *vmopv1.VirtualMachineBootstrapDefaults) admission.Response {
+
+	fieldErrs := v.validateCloudInit(bsd)
+
+	validationErrs := make([]string, 0, len(fieldErrs))
+	for _, fieldErr := range fieldErrs {
+		validationErrs = append(validationErrs, fieldErr.Error())
+	}
+
+	return common.BuildValidationResponse(ctx, nil, validationErrs, nil)
+}
+
+func (v validator) validateCloudInit(bsd *vmopv1.VirtualMachineBootstrapDefaults) field.ErrorList {
+	var allErrs field.ErrorList
+
+	cloudInit := bsd.Spec.CloudInit
+	if cloudInit == nil {
+		return allErrs
+	}
+
+	p := field.NewPath("spec", "cloudInit")
+
+	if cloudInit.CloudConfig != nil {
+		if cloudInit.RawCloudConfig != nil {
+			allErrs = append(allErrs, field.Invalid(p, "cloudInit", cloudConfigMutuallyExclusive))
+		}
+		allErrs = append(allErrs, cloudinitvalidate.CloudConfigJSONRawMessage(p, *cloudInit.CloudConfig)...)
+	}
+
+	return allErrs
+}
+
+// bootstrapDefaultsFromUnstructured returns the
+// VirtualMachineBootstrapDefaults from the unstructured object.
+func (v validator) bootstrapDefaultsFromUnstructured(
+	obj runtime.Unstructured) (*vmopv1.VirtualMachineBootstrapDefaults, error) {
+
+	bsd := &vmopv1.VirtualMachineBootstrapDefaults{}
+	if err := v.converter.FromUnstructured(obj.UnstructuredContent(), bsd); err != nil {
+		return nil, err
+	}
+	return bsd, nil
+}
@@ -0,0 +1,104 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	vmopv1common "github.com/vmware-tanzu/vm-operator/api/v1alpha4/common"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func intgTests() {
+	Describe(
+		"Create",
+		Label(
+			testlabels.Create,
+			testlabels.EnvTest,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		intgTestsValidateCreate,
+	)
+	Describe(
+		"Update",
+		Label(
+			testlabels.Update,
+			testlabels.EnvTest,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		intgTestsValidateUpdate,
+	)
+}
+
+type intgValidatingWebhookContext struct {
+	builder.IntegrationTestContext
+	bsd *vmopv1.VirtualMachineBootstrapDefaults
+}
+
+func newIntgValidatingWebhookContext() *intgValidatingWebhookContext {
+	ctx := &intgValidatingWebhookContext{
+		IntegrationTestContext: *suite.NewIntegrationTestContext(),
+	}
+
+	ctx.bsd = newBootstrapDefaults(ctx.Namespace, "dummy-bsd")
+	return ctx
+}
+
+func intgTestsValidateCreate() {
+	var (
+		ctx *intgValidatingWebhookContext
+	)
+
+	BeforeEach(func() {
+		ctx = newIntgValidatingWebhookContext()
+	})
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+	})
+
+	It("should allow a valid request", func() {
+		Expect(ctx.Client.Create(ctx, ctx.bsd)).To(Succeed())
+	})
+
+	It("should deny a request with both cloudConfig and rawCloudConfig", func() {
+		ctx.bsd.Spec.CloudInit.RawCloudConfig = &vmopv1common.SecretKeySelector{
+			Name: "my-vendor-data",
+			Key:  "vendor-data",
+		}
+		err := ctx.Client.Create(ctx, ctx.bsd)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("cloudConfig and rawCloudConfig are mutually exclusive"))
+	})
+}
+
+func intgTestsValidateUpdate() {
+	var (
+		ctx *intgValidatingWebhookContext
+	)
+
+	BeforeEach(func() {
+		ctx = newIntgValidatingWebhookContext()
+		Expect(ctx.Client.Create(ctx, ctx.bsd)).To(Succeed())
+	})
+	AfterEach(func() {
+		ctx.AfterEach()
+		ctx = nil
+	})
+
+	It("should deny an invalid runcmd", func() {
+		ctx.bsd.Spec.CloudInit.CloudConfig.RunCmd = []byte(`{"ls": "/"}`)
+		err := ctx.Client.Update(ctx, ctx.bsd)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("spec.cloudInit.cloudConfig.runcmds"))
+	})
+}
@@ -0,0 +1,53 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	vmopv1cloudinit "github.com/vmware-tanzu/vm-operator/api/v1alpha4/cloudinit"
+	pkgcfg "github.com/vmware-tanzu/vm-operator/pkg/config"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachinebootstrapdefaults/validation"
+)
+
+// suite is used for unit and integration testing this webhook.
+var suite = builder.NewTestSuiteForValidatingWebhookWithContext(
+	pkgcfg.NewContext(),
+	validation.AddToManager,
+	validation.NewValidator,
+	"default.validating.virtualmachinebootstrapdefaults.v1alpha4.vmoperator.vmware.com")
+
+func TestWebhook(t *testing.T) {
+	suite.Register(t, "Validation webhook suite", intgTests, unitTests)
+}
+
+var _ = BeforeSuite(suite.BeforeSuite)
+
+var _ = AfterSuite(suite.AfterSuite)
+
+func newBootstrapDefaults(namespace, name string) *vmopv1.VirtualMachineBootstrapDefaults {
+	return &vmopv1.VirtualMachineBootstrapDefaults{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: vmopv1.GroupVersion.String(),
+			Kind:       "VirtualMachineBootstrapDefaults",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: namespace,
+		},
+		Spec: vmopv1.VirtualMachineBootstrapDefaultsSpec{
+			CloudInit: &vmopv1.VirtualMachineBootstrapDefaultsCloudInitSpec{
+				CloudConfig: &vmopv1cloudinit.CloudConfig{
+					RunCmd: []byte(`["ls /"]`),
+				},
+			},
+		},
+	}
+}
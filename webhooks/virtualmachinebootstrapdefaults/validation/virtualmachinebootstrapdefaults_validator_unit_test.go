@@ -0,0 +1,210 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	vmopv1cloudinit "github.com/vmware-tanzu/vm-operator/api/v1alpha4/cloudinit"
+	vmopv1common "github.com/vmware-tanzu/vm-operator/api/v1alpha4/common"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func unitTests() {
+	Describe(
+		"Create",
+		Label(
+			testlabels.Create,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateCreate,
+	)
+	Describe(
+		"Update",
+		Label(
+			testlabels.Update,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateUpdate,
+	)
+	Describe(
+		"Delete",
+		Label(
+			testlabels.Delete,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateDelete,
+	)
+}
+
+type unitValidatingWebhookContext struct {
+	builder.UnitTestContextForValidatingWebhook
+	bsd    *vmopv1.VirtualMachineBootstrapDefaults
+	oldBSD *vmopv1.VirtualMachineBootstrapDefaults
+}
+
+func newUnitTestContextForValidatingWebhook(isUpdate bool) *unitValidatingWebhookContext {
+	bsd := newBootstrapDefaults("dummy-ns", "dummy-bsd")
+	obj, err := builder.ToUnstructured(bsd)
+	Expect(err).ToNot(HaveOccurred())
+
+	var (
+		oldBSD *vmopv1.VirtualMachineBootstrapDefaults
+		oldObj *unstructured.Unstructured
+	)
+
+	if isUpdate {
+		oldBSD = bsd.DeepCopy()
+		oldObj, err = builder.ToUnstructured(oldBSD)
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	return &unitValidatingWebhookContext{
+		UnitTestContextForValidatingWebhook: *suite.NewUnitTestContextForValidatingWebhook(obj, oldObj),
+		bsd:                                 bsd,
+		oldBSD:                              oldBSD,
+	}
+}
+
+type testArgs struct {
+	noCloudInit       bool
+	rawCloudConfig    bool
+	bothCloudConfigs  bool
+	invalidRunCmd     bool
+	invalidWriteFiles bool
+}
+
+func applyTestArgs(ctx *unitValidatingWebhookContext, args testArgs) {
+	cloudInit := ctx.bsd.Spec.CloudInit
+	rawCloudConfig := &vmopv1common.SecretKeySelector{
+		Name: "my-vendor-data",
+		Key:  "vendor-data",
+	}
+
+	switch {
+	case args.noCloudInit:
+		ctx.bsd.Spec.CloudInit = nil
+	case args.rawCloudConfig:
+		cloudInit.CloudConfig = nil
+		cloudInit.RawCloudConfig = rawCloudConfig
+	case args.bothCloudConfigs:
+		cloudInit.RawCloudConfig = rawCloudConfig
+	case args.invalidRunCmd:
+		cloudInit.CloudConfig.RunCmd = []byte(`{"ls": "/"}`)
+	case args.invalidWriteFiles:
+		cloudInit.CloudConfig.RunCmd = nil
+		cloudInit.CloudConfig.WriteFiles = []vmopv1cloudinit.WriteFile{
+			{
+				Path:    "/etc/motd",
+				Content: []byte(`[1]`),
+			},
+		}
+	}
+}
+
+func unitTestsValidateCreate() {
+	var (
+		ctx *unitValidatingWebhookContext
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	validateCreate := func(args testArgs, expectedAllowed bool, expectedReason string) {
+		var err error
+
+		applyTestArgs(ctx, args)
+
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.bsd)
+		Expect(err).ToNot(HaveOccurred())
+
+		response := ctx.ValidateCreate(&ctx.WebhookRequestContext)
+		Expect(response.Allowed).To(Equal(expectedAllowed))
+		if expectedReason != "" {
+			Expect(string(response.Result.Reason)).To(ContainSubstring(expectedReason))
+		}
+	}
+
+	DescribeTable("create table", validateCreate,
+		Entry("should allow cloudConfig", testArgs{}, true, ""),
+		Entry("should allow rawCloudConfig", testArgs{rawCloudConfig: true}, true, ""),
+		Entry("should allow no cloudInit", testArgs{noCloudInit: true}, true, ""),
+		Entry("should deny cloudConfig and rawCloudConfig", testArgs{bothCloudConfigs: true}, false,
+			"spec.cloudInit: Invalid value: \"cloudInit\": cloudConfig and rawCloudConfig are mutually exclusive"),
+		Entry("should deny invalid runcmd", testArgs{invalidRunCmd: true}, false,
+			"spec.cloudInit.cloudConfig.runcmds: Invalid value"),
+		Entry("should deny invalid write_files content", testArgs{invalidWriteFiles: true}, false,
+			"spec.cloudInit.cloudConfig.write_files[/etc/motd]: Invalid value"),
+	)
+}
+
+func unitTestsValidateUpdate() {
+	var (
+		ctx *unitValidatingWebhookContext
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(true)
+	})
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	validateUpdate := func(args testArgs, expectedAllowed bool, expectedReason string) {
+		var err error
+
+		applyTestArgs(ctx, args)
+
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.bsd)
+		Expect(err).ToNot(HaveOccurred())
+
+		response := ctx.ValidateUpdate(&ctx.WebhookRequestContext)
+		Expect(response.Allowed).To(Equal(expectedAllowed))
+		if expectedReason != "" {
+			Expect(string(response.Result.Reason)).To(ContainSubstring(expectedReason))
+		}
+	}
+
+	DescribeTable("update table", validateUpdate,
+		Entry("should allow changing to rawCloudConfig", testArgs{rawCloudConfig: true}, true, ""),
+		Entry("should deny cloudConfig and rawCloudConfig", testArgs{bothCloudConfigs: true}, false,
+			"cloudConfig and rawCloudConfig are mutually exclusive"),
+		Entry("should deny invalid runcmd", testArgs{invalidRunCmd: true}, false,
+			"spec.cloudInit.cloudConfig.runcmds: Invalid value"),
+	)
+}
+
+func unitTestsValidateDelete() {
+	var (
+		ctx *unitValidatingWebhookContext
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	It("should allow the request", func() {
+		response := ctx.ValidateDelete(&ctx.WebhookRequestContext)
+		Expect(response.Allowed).To(BeTrue())
+	})
+}
@@ -0,0 +1,181 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+	"slices"
+
+	storagev1 "k8s.io/api/storage/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlmgr "sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/pkg/builder"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/topology"
+	spqutil "github.com/vmware-tanzu/vm-operator/pkg/util/kube/spq"
+	"github.com/vmware-tanzu/vm-operator/webhooks/common"
+)
+
+const (
+	webHookName = "default"
+
+	datastoresNotAllowed       = "datastores may only be targeted by a ClusterVirtualMachineImageCachePolicy"
+	storageClassRequired       = "at least one storage class is required"
+	storageClassNotFoundFmt    = "Storage policy %s does not exist"
+	storageClassNotAssignedFmt = "Storage policy is not associated with the namespace %s"
+	zoneNotAssignedFmt         = "Zone is not available to the namespace %s"
+)
+
+// +kubebuilder:webhook:verbs=create;update,path=/default-validate-vmoperator-vmware-com-v1alpha4-virtualmachineimagecachepolicy,mutating=false,failurePolicy=fail,groups=vmoperator.vmware.com,resources=virtualmachineimagecachepolicies,versions=v1alpha4,name=default.validating.virtualmachineimagecachepolicy.v1alpha4.vmoperator.vmware.com,sideEffects=None,admissionReviewVersions=v1;v1beta1
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineimagecachepolicies,verbs=get;list
+
+// AddToManager adds the webhook to the provided manager.
+func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr ctrlmgr.Manager) error {
+	hook, err := builder.NewValidatingWebhook(ctx, mgr, webHookName, NewValidator(mgr.GetClient()))
+	if err != nil {
+		return fmt.Errorf("failed to create VirtualMachineImageCachePolicy validation webhook: %w", err)
+	}
+	mgr.GetWebhookServer().Register(hook.Path, hook)
+
+	return nil
+}
+
+// NewValidator returns the package's Validator.
+func NewValidator(client ctrlclient.Client) builder.Validator {
+	return validator{
+		client:    client,
+		converter: runtime.DefaultUnstructuredConverter,
+	}
+}
+
+type validator struct {
+	client    ctrlclient.Client
+	converter runtime.UnstructuredConverter
+}
+
+func (v validator) For() schema.GroupVersionKind {
+	return vmopv1.GroupVersion.WithKind(reflect.TypeOf(vmopv1.VirtualMachineImageCachePolicy{}).Name())
+}
+
+func (v validator) ValidateCreate(ctx *pkgctx.WebhookRequestContext) admission.Response {
+	policy, err := v.policyFromUnstructured(ctx.Obj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	return v.validate(ctx, policy)
+}
+
+func (v validator) ValidateDelete(*pkgctx.WebhookRequestContext) admission.Response {
+	return admission.Allowed("")
+}
+
+func (v validator) ValidateUpdate(ctx *pkgctx.WebhookRequestContext) admission.Response {
+	policy, err := v.policyFromUnstructured(ctx.Obj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	// The policy's targets are resolved each time it is reconciled, so the
+	// spec may be changed as long as it remains valid.
+	return v.validate(ctx, policy)
+}
+
+func (v validator) validate(
+	ctx *pkgctx.WebhookRequestContext,
+	policy *vmopv1.VirtualMachineImageCachePolicy) admission.Response {
+
+	fieldErrs := v.validateTarget(ctx, policy)
+
+	validationErrs := make([]string, 0, len(fieldErrs))
+	for _, fieldErr := range fieldErrs {
+		validationErrs = append(validationErrs, fieldErr.Error())
+	}
+
+	return common.BuildValidationResponse(ctx, nil, validationErrs, nil)
+}
+
+// validateTarget ensures a namespaced policy only targets the datastores
+// available to its namespace, i.e. the datastores compatible with the
+// namespace's storage classes in the namespace's zones.
+func (v validator) validateTarget(
+	ctx *pkgctx.WebhookRequestContext,
+	policy *vmopv1.VirtualMachineImageCachePolicy) field.ErrorList {
+
+	var (
+		allErrs    field.ErrorList
+		target     = policy.Spec.Target
+		targetPath = field.NewPath("spec", "target")
+	)
+
+	if len(target.Datastores) > 0 {
+		allErrs = append(allErrs, field.Forbidden(
+			targetPath.Child("datastores"), datastoresNotAllowed))
+	}
+
+	if len(target.StorageClasses) == 0 {
+		allErrs = append(allErrs, field.Required(
+			targetPath.Child("storageClasses"), storageClassRequired))
+	}
+
+	for i, name := range target.StorageClasses {
+		p := targetPath.Child("storageClasses").Index(i)
+
+		var sc storagev1.StorageClass
+		if err := v.client.Get(ctx, ctrlclient.ObjectKey{Name: name}, &sc); err != nil {
+			if apierrors.IsNotFound(err) {
+				allErrs = append(allErrs, field.Invalid(p, name, fmt.Sprintf(storageClassNotFoundFmt, name)))
+			} else {
+				allErrs = append(allErrs, field.Invalid(p, name, err.Error()))
+			}
+			continue
+		}
+
+		ok, err := spqutil.IsStorageClassInNamespace(ctx, v.client, &sc, policy.Namespace)
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(p, name, err.Error()))
+		} else if !ok {
+			allErrs = append(allErrs, field.Invalid(p, name, fmt.Sprintf(storageClassNotAssignedFmt, policy.Namespace)))
+		}
+	}
+
+	if len(target.Zones) > 0 {
+		zoneNames, err := topology.GetNamespaceZoneNames(ctx, v.client, policy.Namespace)
+		if err != nil {
+			allErrs = append(allErrs, field.InternalError(targetPath.Child("zones"), err))
+		} else {
+			for i, name := range target.Zones {
+				if !slices.Contains(zoneNames, name) {
+					allErrs = append(allErrs, field.Invalid(
+						targetPath.Child("zones").Index(i), name, fmt.Sprintf(zoneNotAssignedFmt, policy.Namespace)))
+				}
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// policyFromUnstructured returns the VirtualMachineImageCachePolicy from the
+// unstructured object.
+func (v validator) policyFromUnstructured(
+	obj runtime.Unstructured) (*vmopv1.VirtualMachineImageCachePolicy, error) {
+
+	policy := &vmopv1.VirtualMachineImageCachePolicy{}
+	if err := v.converter.FromUnstructured(obj.UnstructuredContent(), policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
@@ -0,0 +1,111 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	storagev1 "k8s.io/api/storage/v1"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func intgTests() {
+	Describe(
+		"Create",
+		Label(
+			testlabels.Create,
+			testlabels.EnvTest,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		intgTestsValidateCreate,
+	)
+	Describe(
+		"Update",
+		Label(
+			testlabels.Update,
+			testlabels.EnvTest,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		intgTestsValidateUpdate,
+	)
+}
+
+type intgValidatingWebhookContext struct {
+	builder.IntegrationTestContext
+	policy *vmopv1.VirtualMachineImageCachePolicy
+	sc     *storagev1.StorageClass
+}
+
+func newIntgValidatingWebhookContext() *intgValidatingWebhookContext {
+	ctx := &intgValidatingWebhookContext{
+		IntegrationTestContext: *suite.NewIntegrationTestContext(),
+	}
+
+	ctx.sc = builder.DummyStorageClass()
+	Expect(ctx.Client.Create(ctx, ctx.sc)).To(Succeed())
+	Expect(ctx.Client.Create(ctx, builder.DummyResourceQuota(
+		ctx.Namespace,
+		ctx.sc.Name+".storageclass.storage.k8s.io/persistentvolumeclaims"))).To(Succeed())
+
+	ctx.policy = newPolicy(ctx.Namespace, "dummy-policy")
+	return ctx
+}
+
+func intgTestsValidateCreate() {
+	var (
+		ctx *intgValidatingWebhookContext
+	)
+
+	BeforeEach(func() {
+		ctx = newIntgValidatingWebhookContext()
+	})
+	AfterEach(func() {
+		Expect(ctx.Client.Delete(ctx, ctx.sc)).To(Succeed())
+		ctx.AfterEach()
+		ctx = nil
+	})
+
+	It("should allow a valid request", func() {
+		Expect(ctx.Client.Create(ctx, ctx.policy)).To(Succeed())
+	})
+
+	It("should deny a request with datastores", func() {
+		ctx.policy.Spec.Target.Datastores = []string{"datastore-1"}
+		err := ctx.Client.Create(ctx, ctx.policy)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("spec.target.datastores: Forbidden"))
+	})
+}
+
+func intgTestsValidateUpdate() {
+	var (
+		ctx *intgValidatingWebhookContext
+	)
+
+	BeforeEach(func() {
+		ctx = newIntgValidatingWebhookContext()
+		Expect(ctx.Client.Create(ctx, ctx.policy)).To(Succeed())
+	})
+	AfterEach(func() {
+		Expect(ctx.Client.Delete(ctx, ctx.sc)).To(Succeed())
+		ctx.AfterEach()
+		ctx = nil
+	})
+
+	It("should deny a storage class that does not exist", func() {
+		ctx.policy.Spec.Target.StorageClasses = []string{"does-not-exist"}
+		err := ctx.Client.Update(ctx, ctx.policy)
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("spec.target.storageClasses[0]"))
+	})
+}
@@ -0,0 +1,228 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	topologyv1 "github.com/vmware-tanzu/vm-operator/external/tanzu-topology/api/v1alpha1"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+const (
+	dummyNamespaceName = "dummy-ns"
+	otherZoneName      = "other-zone"
+)
+
+func unitTests() {
+	Describe(
+		"Create",
+		Label(
+			testlabels.Create,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateCreate,
+	)
+	Describe(
+		"Update",
+		Label(
+			testlabels.Update,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateUpdate,
+	)
+	Describe(
+		"Delete",
+		Label(
+			testlabels.Delete,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateDelete,
+	)
+}
+
+type unitValidatingWebhookContext struct {
+	builder.UnitTestContextForValidatingWebhook
+	policy    *vmopv1.VirtualMachineImageCachePolicy
+	oldPolicy *vmopv1.VirtualMachineImageCachePolicy
+}
+
+func newUnitTestContextForValidatingWebhook(isUpdate bool) *unitValidatingWebhookContext {
+	policy := newPolicy(dummyNamespaceName, "dummy-policy")
+	obj, err := builder.ToUnstructured(policy)
+	Expect(err).ToNot(HaveOccurred())
+
+	var (
+		oldPolicy *vmopv1.VirtualMachineImageCachePolicy
+		oldObj    *unstructured.Unstructured
+	)
+
+	if isUpdate {
+		oldPolicy = policy.DeepCopy()
+		oldObj, err = builder.ToUnstructured(oldPolicy)
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	// The dummy zone is available to the namespace and the other zone is not.
+	az := builder.DummyAvailabilityZone()
+	az.Spec.Namespaces[dummyNamespaceName] = topologyv1.NamespaceInfo{}
+	otherAZ := builder.DummyNamedAvailabilityZone(otherZoneName)
+
+	// The dummy storage class is assigned to the namespace.
+	sc := builder.DummyStorageClass()
+	rq := builder.DummyResourceQuota(
+		dummyNamespaceName,
+		sc.Name+".storageclass.storage.k8s.io/persistentvolumeclaims")
+
+	initObjects := []client.Object{az, otherAZ, sc, rq}
+
+	return &unitValidatingWebhookContext{
+		UnitTestContextForValidatingWebhook: *suite.NewUnitTestContextForValidatingWebhook(obj, oldObj, initObjects...),
+		policy:                              policy,
+		oldPolicy:                           oldPolicy,
+	}
+}
+
+type testArgs struct {
+	datastores                 bool
+	noStorageClasses           bool
+	storageClassNotFound       bool
+	storageClassNotInNamespace bool
+	zone                       bool
+	zoneNotFound               bool
+	zoneNotInNamespace         bool
+}
+
+func applyTestArgs(ctx *unitValidatingWebhookContext, args testArgs) {
+	target := &ctx.policy.Spec.Target
+
+	switch {
+	case args.datastores:
+		target.Datastores = []string{"datastore-1"}
+	case args.noStorageClasses:
+		target.StorageClasses = nil
+	case args.storageClassNotFound:
+		target.StorageClasses = []string{"does-not-exist"}
+	case args.storageClassNotInNamespace:
+		Expect(ctx.Client.Delete(ctx, builder.DummyResourceQuota(dummyNamespaceName))).To(Succeed())
+	case args.zone:
+		target.Zones = []string{builder.DummyZoneName}
+	case args.zoneNotFound:
+		target.Zones = []string{"does-not-exist"}
+	case args.zoneNotInNamespace:
+		target.Zones = []string{otherZoneName}
+	}
+}
+
+func unitTestsValidateCreate() {
+	var (
+		ctx *unitValidatingWebhookContext
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	validateCreate := func(args testArgs, expectedAllowed bool, expectedReason string) {
+		var err error
+
+		applyTestArgs(ctx, args)
+
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.policy)
+		Expect(err).ToNot(HaveOccurred())
+
+		response := ctx.ValidateCreate(&ctx.WebhookRequestContext)
+		Expect(response.Allowed).To(Equal(expectedAllowed))
+		if expectedReason != "" {
+			Expect(string(response.Result.Reason)).To(ContainSubstring(expectedReason))
+		}
+	}
+
+	DescribeTable("create table", validateCreate,
+		Entry("should allow a storage class in the namespace", testArgs{}, true, ""),
+		Entry("should allow a zone in the namespace", testArgs{zone: true}, true, ""),
+		Entry("should deny datastores", testArgs{datastores: true}, false,
+			"spec.target.datastores: Forbidden: datastores may only be targeted by a ClusterVirtualMachineImageCachePolicy"),
+		Entry("should deny no storage classes", testArgs{noStorageClasses: true}, false,
+			"spec.target.storageClasses: Required value: at least one storage class is required"),
+		Entry("should deny a storage class that does not exist", testArgs{storageClassNotFound: true}, false,
+			`spec.target.storageClasses[0]: Invalid value: "does-not-exist": Storage policy does-not-exist does not exist`),
+		Entry("should deny a storage class not in the namespace", testArgs{storageClassNotInNamespace: true}, false,
+			`spec.target.storageClasses[0]: Invalid value: "dummy-storage-class": Storage policy is not associated with the namespace dummy-ns`),
+		Entry("should deny a zone that does not exist", testArgs{zoneNotFound: true}, false,
+			`spec.target.zones[0]: Invalid value: "does-not-exist": Zone is not available to the namespace dummy-ns`),
+		Entry("should deny a zone not in the namespace", testArgs{zoneNotInNamespace: true}, false,
+			`spec.target.zones[0]: Invalid value: "other-zone": Zone is not available to the namespace dummy-ns`),
+	)
+}
+
+func unitTestsValidateUpdate() {
+	var (
+		ctx *unitValidatingWebhookContext
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(true)
+	})
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	validateUpdate := func(args testArgs, expectedAllowed bool, expectedReason string) {
+		var err error
+
+		applyTestArgs(ctx, args)
+
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.policy)
+		Expect(err).ToNot(HaveOccurred())
+
+		response := ctx.ValidateUpdate(&ctx.WebhookRequestContext)
+		Expect(response.Allowed).To(Equal(expectedAllowed))
+		if expectedReason != "" {
+			Expect(string(response.Result.Reason)).To(ContainSubstring(expectedReason))
+		}
+	}
+
+	DescribeTable("update table", validateUpdate,
+		Entry("should allow adding a zone in the namespace", testArgs{zone: true}, true, ""),
+		Entry("should deny adding datastores", testArgs{datastores: true}, false,
+			"spec.target.datastores: Forbidden"),
+		Entry("should deny adding a zone not in the namespace", testArgs{zoneNotInNamespace: true}, false,
+			`spec.target.zones[0]: Invalid value: "other-zone"`),
+	)
+}
+
+func unitTestsValidateDelete() {
+	var (
+		ctx *unitValidatingWebhookContext
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	It("should allow the request", func() {
+		response := ctx.ValidateDelete(&ctx.WebhookRequestContext)
+		Expect(response.Allowed).To(BeTrue())
+	})
+}
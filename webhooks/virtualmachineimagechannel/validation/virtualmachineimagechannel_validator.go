@@ -0,0 +1,143 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"github.com/blang/semver/v4"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlmgr "sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/pkg/builder"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/webhooks/common"
+)
+
+const (
+	webHookName = "default"
+
+	selectorRequired = "at least one selector field is required"
+)
+
+// +kubebuilder:webhook:verbs=create;update,path=/default-validate-vmoperator-vmware-com-v1alpha4-virtualmachineimagechannel,mutating=false,failurePolicy=fail,groups=vmoperator.vmware.com,resources=virtualmachineimagechannels,versions=v1alpha4,name=default.validating.virtualmachineimagechannel.v1alpha4.vmoperator.vmware.com,sideEffects=None,admissionReviewVersions=v1;v1beta1
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineimagechannels,verbs=get;list
+
+// AddToManager adds the webhook to the provided manager.
+func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr ctrlmgr.Manager) error {
+	hook, err := builder.NewValidatingWebhook(ctx, mgr, webHookName, NewValidator(mgr.GetClient()))
+	if err != nil {
+		return fmt.Errorf("failed to create VirtualMachineImageChannel validation webhook: %w", err)
+	}
+	mgr.GetWebhookServer().Register(hook.Path, hook)
+
+	return nil
+}
+
+// NewValidator returns the package's Validator.
+func NewValidator(_ ctrlclient.Client) builder.Validator {
+	return validator{
+		converter: runtime.DefaultUnstructuredConverter,
+	}
+}
+
+type validator struct {
+	converter runtime.UnstructuredConverter
+}
+
+func (v validator) For() schema.GroupVersionKind {
+	return vmopv1.GroupVersion.WithKind(reflect.TypeOf(vmopv1.VirtualMachineImageChannel{}).Name())
+}
+
+func (v validator) ValidateCreate(ctx *pkgctx.WebhookRequestContext) admission.Response {
+	channel, err := v.channelFromUnstructured(ctx.Obj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	return v.validate(ctx, channel)
+}
+
+func (v validator) ValidateDelete(*pkgctx.WebhookRequestContext) admission.Response {
+	return admission.Allowed("")
+}
+
+func (v validator) ValidateUpdate(ctx *pkgctx.WebhookRequestContext) admission.Response {
+	channel, err := v.channelFromUnstructured(ctx.Obj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	// The channel is resolved again when its selector changes, so the
+	// selector may be changed as long as it remains valid.
+	return v.validate(ctx, channel)
+}
+
+func (v validator) validate(
+	ctx *pkgctx.WebhookRequestContext,
+	channel *vmopv1.VirtualMachineImageChannel) admission.Response {
+
+	fieldErrs := v.validateSelector(channel)
+
+	validationErrs := make([]string, 0, len(fieldErrs))
+	for _, fieldErr := range fieldErrs {
+		validationErrs = append(validationErrs, fieldErr.Error())
+	}
+
+	return common.BuildValidationResponse(ctx, nil, validationErrs, nil)
+}
+
+// validateSelector ensures the selector does not match every image and that
+// the version range and label selector may be parsed.
+func (v validator) validateSelector(
+	channel *vmopv1.VirtualMachineImageChannel) field.ErrorList {
+
+	var (
+		allErrs      field.ErrorList
+		selector     = channel.Spec.Selector
+		selectorPath = field.NewPath("spec", "selector")
+	)
+
+	if reflect.DeepEqual(selector, vmopv1.VirtualMachineImageChannelSelector{}) {
+		allErrs = append(allErrs, field.Required(selectorPath, selectorRequired))
+	}
+
+	if selector.Version != "" {
+		if _, err := semver.ParseRange(selector.Version); err != nil {
+			allErrs = append(allErrs, field.Invalid(
+				selectorPath.Child("version"), selector.Version, err.Error()))
+		}
+	}
+
+	if selector.LabelSelector != nil {
+		if _, err := metav1.LabelSelectorAsSelector(selector.LabelSelector); err != nil {
+			allErrs = append(allErrs, field.Invalid(
+				selectorPath.Child("labelSelector"), selector.LabelSelector, err.Error()))
+		}
+	}
+
+	return allErrs
+}
+
+// channelFromUnstructured returns the VirtualMachineImageChannel from the
+// unstructured object.
+func (v validator) channelFromUnstructured(
+	obj runtime.Unstructured) (*vmopv1.VirtualMachineImageChannel, error) {
+
+	channel := &vmopv1.VirtualMachineImageChannel{}
+	if err := v.converter.FromUnstructured(obj.UnstructuredContent(), channel); err != nil {
+		return nil, err
+	}
+	return channel, nil
+}
@@ -0,0 +1,209 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+const (
+	dummyNamespaceName = "dummy-ns"
+)
+
+func unitTests() {
+	Describe(
+		"Create",
+		Label(
+			testlabels.Create,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateCreate,
+	)
+	Describe(
+		"Update",
+		Label(
+			testlabels.Update,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateUpdate,
+	)
+	Describe(
+		"Delete",
+		Label(
+			testlabels.Delete,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateDelete,
+	)
+}
+
+type unitValidatingWebhookContext struct {
+	builder.UnitTestContextForValidatingWebhook
+	channel    *vmopv1.VirtualMachineImageChannel
+	oldChannel *vmopv1.VirtualMachineImageChannel
+}
+
+func newUnitTestContextForValidatingWebhook(isUpdate bool) *unitValidatingWebhookContext {
+	channel := newChannel(dummyNamespaceName, "dummy-channel")
+	obj, err := builder.ToUnstructured(channel)
+	Expect(err).ToNot(HaveOccurred())
+
+	var (
+		oldChannel *vmopv1.VirtualMachineImageChannel
+		oldObj     *unstructured.Unstructured
+	)
+
+	if isUpdate {
+		oldChannel = channel.DeepCopy()
+		oldObj, err = builder.ToUnstructured(oldChannel)
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	return &unitValidatingWebhookContext{
+		UnitTestContextForValidatingWebhook: *suite.NewUnitTestContextForValidatingWebhook(obj, oldObj),
+		channel:                             channel,
+		oldChannel:                          oldChannel,
+	}
+}
+
+type testArgs struct {
+	emptySelector        bool
+	labelSelectorOnly    bool
+	invalidVersion       bool
+	invalidLabelSelector bool
+}
+
+func applyTestArgs(ctx *unitValidatingWebhookContext, args testArgs) {
+	selector := &ctx.channel.Spec.Selector
+
+	switch {
+	case args.emptySelector:
+		*selector = vmopv1.VirtualMachineImageChannelSelector{}
+	case args.labelSelectorOnly:
+		*selector = vmopv1.VirtualMachineImageChannelSelector{
+			LabelSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"foo": "bar"},
+			},
+		}
+	case args.invalidVersion:
+		selector.Version = "not-a-range"
+	case args.invalidLabelSelector:
+		selector.LabelSelector = &metav1.LabelSelector{
+			MatchExpressions: []metav1.LabelSelectorRequirement{
+				{
+					Key:      "foo",
+					Operator: "invalid",
+				},
+			},
+		}
+	}
+}
+
+func unitTestsValidateCreate() {
+	var (
+		ctx *unitValidatingWebhookContext
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	validateCreate := func(args testArgs, expectedAllowed bool, expectedReason string) {
+		var err error
+
+		applyTestArgs(ctx, args)
+
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.channel)
+		Expect(err).ToNot(HaveOccurred())
+
+		response := ctx.ValidateCreate(&ctx.WebhookRequestContext)
+		Expect(response.Allowed).To(Equal(expectedAllowed))
+		if expectedReason != "" {
+			Expect(string(response.Result.Reason)).To(ContainSubstring(expectedReason))
+		}
+	}
+
+	DescribeTable("create table", validateCreate,
+		Entry("should allow a valid selector", testArgs{}, true, ""),
+		Entry("should allow only a label selector", testArgs{labelSelectorOnly: true}, true, ""),
+		Entry("should deny an empty selector", testArgs{emptySelector: true}, false,
+			"spec.selector: Required value: at least one selector field is required"),
+		Entry("should deny an invalid version range", testArgs{invalidVersion: true}, false,
+			`spec.selector.version: Invalid value: "not-a-range"`),
+		Entry("should deny an invalid label selector", testArgs{invalidLabelSelector: true}, false,
+			`spec.selector.labelSelector: Invalid value`),
+	)
+}
+
+func unitTestsValidateUpdate() {
+	var (
+		ctx *unitValidatingWebhookContext
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(true)
+	})
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	validateUpdate := func(args testArgs, expectedAllowed bool, expectedReason string) {
+		var err error
+
+		applyTestArgs(ctx, args)
+
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.channel)
+		Expect(err).ToNot(HaveOccurred())
+
+		response := ctx.ValidateUpdate(&ctx.WebhookRequestContext)
+		Expect(response.Allowed).To(Equal(expectedAllowed))
+		if expectedReason != "" {
+			Expect(string(response.Result.Reason)).To(ContainSubstring(expectedReason))
+		}
+	}
+
+	DescribeTable("update table", validateUpdate,
+		Entry("should allow changing to a label selector", testArgs{labelSelectorOnly: true}, true, ""),
+		Entry("should deny changing to an empty selector", testArgs{emptySelector: true}, false,
+			"spec.selector: Required value"),
+		Entry("should deny changing to an invalid version range", testArgs{invalidVersion: true}, false,
+			`spec.selector.version: Invalid value: "not-a-range"`),
+	)
+}
+
+func unitTestsValidateDelete() {
+	var (
+		ctx *unitValidatingWebhookContext
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	It("should allow the request", func() {
+		response := ctx.ValidateDelete(&ctx.WebhookRequestContext)
+		Expect(response.Allowed).To(BeTrue())
+	})
+}
@@ -0,0 +1,163 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation
+
+import (
+	"fmt"
+	"net/http"
+	"reflect"
+
+	"k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlmgr "sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/pkg/builder"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/webhooks/common"
+)
+
+const (
+	webHookName = "default"
+
+	restrictedToPrivUsers = "restricted to privileged users"
+	oneOfIDOrPath         = "exactly one of id or inventoryPath must be specified"
+)
+
+// +kubebuilder:webhook:verbs=create;update,path=/default-validate-vmoperator-vmware-com-v1alpha4-virtualmachineimportrequest,mutating=false,failurePolicy=fail,groups=vmoperator.vmware.com,resources=virtualmachineimportrequests,versions=v1alpha4,name=default.validating.virtualmachineimportrequest.v1alpha4.vmoperator.vmware.com,sideEffects=None,admissionReviewVersions=v1;v1beta1
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineimportrequests,verbs=get;list
+// +kubebuilder:rbac:groups=vmoperator.vmware.com,resources=virtualmachineimportrequests/status,verbs=get
+
+// AddToManager adds the webhook to the provided manager.
+func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr ctrlmgr.Manager) error {
+	hook, err := builder.NewValidatingWebhook(ctx, mgr, webHookName, NewValidator(mgr.GetClient()))
+	if err != nil {
+		return fmt.Errorf("failed to create VirtualMachineImportRequest validation webhook: %w", err)
+	}
+	mgr.GetWebhookServer().Register(hook.Path, hook)
+
+	return nil
+}
+
+// NewValidator returns the package's Validator.
+func NewValidator(client client.Client) builder.Validator {
+	return validator{
+		client:    client,
+		converter: runtime.DefaultUnstructuredConverter,
+	}
+}
+
+type validator struct {
+	client    client.Client
+	converter runtime.UnstructuredConverter
+}
+
+func (v validator) For() schema.GroupVersionKind {
+	return vmopv1.GroupVersion.WithKind(reflect.TypeOf(vmopv1.VirtualMachineImportRequest{}).Name())
+}
+
+func (v validator) ValidateCreate(ctx *pkgctx.WebhookRequestContext) admission.Response {
+	vmImport, err := v.vmImportRequestFromUnstructured(ctx.Obj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	var fieldErrs field.ErrorList
+
+	// Importing a VM creates a VirtualMachine without a class or an image,
+	// which is restricted to privileged users.
+	if !ctx.IsPrivilegedAccount {
+		fieldErrs = append(fieldErrs, field.Forbidden(field.NewPath("spec"), restrictedToPrivUsers))
+	}
+
+	fieldErrs = append(fieldErrs, v.validateSource(vmImport)...)
+	fieldErrs = append(fieldErrs, v.validateTargetName(vmImport)...)
+	fieldErrs = append(fieldErrs, v.validateNetworkMappings(vmImport)...)
+
+	validationErrs := make([]string, 0, len(fieldErrs))
+	for _, fieldErr := range fieldErrs {
+		validationErrs = append(validationErrs, fieldErr.Error())
+	}
+
+	return common.BuildValidationResponse(ctx, nil, validationErrs, nil)
+}
+
+func (v validator) ValidateDelete(*pkgctx.WebhookRequestContext) admission.Response {
+	return admission.Allowed("")
+}
+
+func (v validator) ValidateUpdate(ctx *pkgctx.WebhookRequestContext) admission.Response {
+	vmImport, err := v.vmImportRequestFromUnstructured(ctx.Obj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	oldVMImport, err := v.vmImportRequestFromUnstructured(ctx.OldObj)
+	if err != nil {
+		return webhook.Errored(http.StatusBadRequest, err)
+	}
+
+	// The VM may have been relocated and its disks registered based on the
+	// spec, so no part of the spec may be changed.
+	fieldErrs := validation.ValidateImmutableField(vmImport.Spec, oldVMImport.Spec, field.NewPath("spec"))
+
+	validationErrs := make([]string, 0, len(fieldErrs))
+	for _, fieldErr := range fieldErrs {
+		validationErrs = append(validationErrs, fieldErr.Error())
+	}
+
+	return common.BuildValidationResponse(ctx, nil, validationErrs, nil)
+}
+
+func (v validator) validateSource(vmImport *vmopv1.VirtualMachineImportRequest) field.ErrorList {
+	var allErrs field.ErrorList
+
+	sourcePath := field.NewPath("spec", "source")
+	if src := vmImport.Spec.Source; (src.ID == "") == (src.InventoryPath == "") {
+		allErrs = append(allErrs, field.Invalid(sourcePath, src, oneOfIDOrPath))
+	}
+
+	return allErrs
+}
+
+func (v validator) validateTargetName(vmImport *vmopv1.VirtualMachineImportRequest) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if name := vmImport.Spec.TargetName; name != "" {
+		for _, msg := range validation.NameIsDNSSubdomain(name, false) {
+			allErrs = append(allErrs, field.Invalid(field.NewPath("spec", "targetName"), name, msg))
+		}
+	}
+
+	return allErrs
+}
+
+func (v validator) validateNetworkMappings(vmImport *vmopv1.VirtualMachineImportRequest) field.ErrorList {
+	var allErrs field.ErrorList
+
+	mappingsPath := field.NewPath("spec", "networkMappings")
+	for i, m := range vmImport.Spec.NetworkMappings {
+		if m.Source == "" {
+			allErrs = append(allErrs, field.Required(mappingsPath.Index(i).Child("source"), ""))
+		}
+	}
+
+	return allErrs
+}
+
+// vmImportRequestFromUnstructured returns the VirtualMachineImportRequest from
+// the unstructured object.
+func (v validator) vmImportRequestFromUnstructured(obj runtime.Unstructured) (*vmopv1.VirtualMachineImportRequest, error) {
+	vmImport := &vmopv1.VirtualMachineImportRequest{}
+	if err := v.converter.FromUnstructured(obj.UnstructuredContent(), vmImport); err != nil {
+		return nil, err
+	}
+	return vmImport, nil
+}
@@ -0,0 +1,189 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package validation_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func unitTests() {
+	Describe(
+		"Create",
+		Label(
+			testlabels.Create,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateCreate,
+	)
+	Describe(
+		"Update",
+		Label(
+			testlabels.Update,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateUpdate,
+	)
+	Describe(
+		"Delete",
+		Label(
+			testlabels.Delete,
+			testlabels.API,
+			testlabels.Validation,
+			testlabels.Webhook,
+		),
+		unitTestsValidateDelete,
+	)
+}
+
+type unitValidatingWebhookContext struct {
+	builder.UnitTestContextForValidatingWebhook
+	vmImport    *vmopv1.VirtualMachineImportRequest
+	oldVMImport *vmopv1.VirtualMachineImportRequest
+}
+
+func newUnitTestContextForValidatingWebhook(isUpdate bool) *unitValidatingWebhookContext {
+	vmImport := newImportRequest("dummy-ns", "dummy-import")
+	obj, err := builder.ToUnstructured(vmImport)
+	Expect(err).ToNot(HaveOccurred())
+
+	var (
+		oldVMImport *vmopv1.VirtualMachineImportRequest
+		oldObj      *unstructured.Unstructured
+	)
+
+	if isUpdate {
+		oldVMImport = vmImport.DeepCopy()
+		oldObj, err = builder.ToUnstructured(oldVMImport)
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	return &unitValidatingWebhookContext{
+		UnitTestContextForValidatingWebhook: *suite.NewUnitTestContextForValidatingWebhook(obj, oldObj),
+		vmImport:                            vmImport,
+		oldVMImport:                         oldVMImport,
+	}
+}
+
+func unitTestsValidateCreate() {
+	var (
+		ctx *unitValidatingWebhookContext
+	)
+
+	type createArgs struct {
+		notPrivileged     bool
+		noSource          bool
+		bothSources       bool
+		invalidTargetName bool
+		emptyMapping      bool
+	}
+
+	validateCreate := func(args createArgs, expectedAllowed bool, expectedReason string) {
+		var err error
+
+		ctx.IsPrivilegedAccount = !args.notPrivileged
+
+		if args.noSource {
+			ctx.vmImport.Spec.Source = vmopv1.VirtualMachineImportRequestSource{}
+		}
+		if args.bothSources {
+			ctx.vmImport.Spec.Source.InventoryPath = "/dc0/vm/my-vm"
+		}
+		if args.invalidTargetName {
+			ctx.vmImport.Spec.TargetName = "Not_Valid"
+		}
+		if args.emptyMapping {
+			ctx.vmImport.Spec.NetworkMappings = []vmopv1.VirtualMachineImportRequestNetworkMapping{{}}
+		}
+
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.vmImport)
+		Expect(err).ToNot(HaveOccurred())
+
+		response := ctx.ValidateCreate(&ctx.WebhookRequestContext)
+		Expect(response.Allowed).To(Equal(expectedAllowed))
+		if expectedReason != "" {
+			Expect(string(response.Result.Reason)).To(ContainSubstring(expectedReason))
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	DescribeTable("create table", validateCreate,
+		Entry("should allow valid", createArgs{}, true, ""),
+		Entry("should deny unprivileged users", createArgs{notPrivileged: true}, false, "spec: Forbidden: restricted to privileged users"),
+		Entry("should deny missing source", createArgs{noSource: true}, false, "exactly one of id or inventoryPath must be specified"),
+		Entry("should deny both source id and inventoryPath", createArgs{bothSources: true}, false, "exactly one of id or inventoryPath must be specified"),
+		Entry("should deny invalid target name", createArgs{invalidTargetName: true}, false, "spec.targetName: Invalid value"),
+		Entry("should deny network mapping without source", createArgs{emptyMapping: true}, false, "spec.networkMappings[0].source: Required value"),
+	)
+}
+
+func unitTestsValidateUpdate() {
+	var (
+		ctx *unitValidatingWebhookContext
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(true)
+	})
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	It("should allow updates that do not change the spec", func() {
+		ctx.vmImport.Labels = map[string]string{"foo": "bar"}
+		var err error
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.vmImport)
+		Expect(err).ToNot(HaveOccurred())
+
+		response := ctx.ValidateUpdate(&ctx.WebhookRequestContext)
+		Expect(response.Allowed).To(BeTrue())
+	})
+
+	It("should deny changes to the spec", func() {
+		ctx.vmImport.Spec.TargetName = "other-vm"
+		var err error
+		ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.vmImport)
+		Expect(err).ToNot(HaveOccurred())
+
+		response := ctx.ValidateUpdate(&ctx.WebhookRequestContext)
+		Expect(response.Allowed).To(BeFalse())
+		Expect(string(response.Result.Reason)).To(ContainSubstring("spec: Invalid value"))
+		Expect(string(response.Result.Reason)).To(ContainSubstring("field is immutable"))
+	})
+}
+
+func unitTestsValidateDelete() {
+	var (
+		ctx *unitValidatingWebhookContext
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForValidatingWebhook(false)
+	})
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	It("should allow the request", func() {
+		response := ctx.ValidateDelete(&ctx.WebhookRequestContext)
+		Expect(response.Allowed).To(BeTrue())
+	})
+}
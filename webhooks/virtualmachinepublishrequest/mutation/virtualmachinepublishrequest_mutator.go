@@ -0,0 +1,117 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package mutation
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	ctrlmgr "sigs.k8s.io/controller-runtime/pkg/manager"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	"github.com/vmware-tanzu/vm-operator/pkg/builder"
+	pkgconst "github.com/vmware-tanzu/vm-operator/pkg/constants"
+	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+)
+
+const (
+	webHookName = "default"
+)
+
+// +kubebuilder:webhook:path=/default-mutate-vmoperator-vmware-com-v1alpha4-virtualmachinepublishrequest,mutating=true,failurePolicy=fail,groups=vmoperator.vmware.com,resources=virtualmachinepublishrequests,verbs=create,versions=v1alpha4,name=default.mutating.virtualmachinepublishrequest.v1alpha4.vmoperator.vmware.com,sideEffects=None,admissionReviewVersions=v1;v1beta1
+
+// AddToManager adds the webhook to the provided manager.
+func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr ctrlmgr.Manager) error {
+	hook, err := builder.NewMutatingWebhook(ctx, mgr, webHookName, NewMutator(mgr.GetClient()))
+	if err != nil {
+		return fmt.Errorf("failed to create mutation webhook: %w", err)
+	}
+	mgr.GetWebhookServer().Register(hook.Path, hook)
+
+	return nil
+}
+
+// NewMutator returns the package's Mutator.
+func NewMutator(_ ctrlclient.Client) builder.Mutator {
+	return mutator{
+		converter: runtime.DefaultUnstructuredConverter,
+	}
+}
+
+type mutator struct {
+	converter runtime.UnstructuredConverter
+}
+
+func (m mutator) Mutate(ctx *pkgctx.WebhookRequestContext) admission.Response {
+	if ctx.Op != admissionv1.Create {
+		return admission.Allowed("")
+	}
+
+	modified, err := m.vmPublishRequestFromUnstructured(ctx.Obj)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+
+	if !SetRequestedBy(ctx, modified) {
+		return admission.Allowed("")
+	}
+
+	rawModified, err := json.Marshal(modified)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	return admission.PatchResponseFromRaw(ctx.RawObj, rawModified)
+}
+
+func (m mutator) For() schema.GroupVersionKind {
+	return vmopv1.GroupVersion.WithKind(reflect.TypeOf(vmopv1.VirtualMachinePublishRequest{}).Name())
+}
+
+// vmPublishRequestFromUnstructured returns the VirtualMachinePublishRequest
+// from the unstructured object.
+func (m mutator) vmPublishRequestFromUnstructured(
+	obj runtime.Unstructured) (*vmopv1.VirtualMachinePublishRequest, error) {
+
+	vmPubReq := &vmopv1.VirtualMachinePublishRequest{}
+	if err := m.converter.FromUnstructured(obj.UnstructuredContent(), vmPubReq); err != nil {
+		return nil, err
+	}
+	return vmPubReq, nil
+}
+
+// SetRequestedBy sets the requested-by annotation to the name of the user
+// that is creating the object. Any value provided by the user is overwritten,
+// except when the user is privileged, since the annotation is recorded in the
+// provenance of the published image.
+// Return true if the annotation was set, otherwise false.
+func SetRequestedBy(
+	ctx *pkgctx.WebhookRequestContext,
+	vmPubReq *vmopv1.VirtualMachinePublishRequest) bool {
+
+	if ctx.IsPrivilegedAccount {
+		if _, ok := vmPubReq.Annotations[pkgconst.RequestedByAnnotationKey]; ok {
+			return false
+		}
+	}
+
+	username := ctx.UserInfo.Username
+	if v, ok := vmPubReq.Annotations[pkgconst.RequestedByAnnotationKey]; ok && v == username {
+		return false
+	}
+
+	if vmPubReq.Annotations == nil {
+		vmPubReq.Annotations = map[string]string{}
+	}
+	vmPubReq.Annotations[pkgconst.RequestedByAnnotationKey] = username
+
+	return true
+}
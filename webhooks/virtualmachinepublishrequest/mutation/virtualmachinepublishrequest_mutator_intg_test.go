@@ -0,0 +1,55 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package mutation_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	pkgconst "github.com/vmware-tanzu/vm-operator/pkg/constants"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+)
+
+func intgTests() {
+	Describe(
+		"Mutate",
+		Label(
+			testlabels.Create,
+			testlabels.EnvTest,
+			testlabels.API,
+			testlabels.Mutation,
+			testlabels.Webhook,
+		),
+		intgTestsMutating,
+	)
+}
+
+func intgTestsMutating() {
+	var (
+		ctx   *builder.IntegrationTestContext
+		vmPub *vmopv1.VirtualMachinePublishRequest
+	)
+
+	BeforeEach(func() {
+		ctx = suite.NewIntegrationTestContext()
+		vmPub = builder.DummyVirtualMachinePublishRequest(
+			"dummy-vmpub", ctx.Namespace, "dummy-vm", "dummy-item", "dummy-cl")
+	})
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	When("a VirtualMachinePublishRequest is created", func() {
+		It("should set the requested-by annotation", func() {
+			Expect(ctx.Client.Create(ctx, vmPub)).To(Succeed())
+			Expect(ctx.Client.Get(ctx, client.ObjectKeyFromObject(vmPub), vmPub)).To(Succeed())
+			Expect(vmPub.Annotations).To(HaveKey(pkgconst.RequestedByAnnotationKey))
+		})
+	})
+}
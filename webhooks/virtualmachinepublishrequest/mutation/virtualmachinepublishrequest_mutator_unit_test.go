@@ -0,0 +1,118 @@
+// © Broadcom. All Rights Reserved.
+// The term “Broadcom” refers to Broadcom Inc. and/or its subsidiaries.
+// SPDX-License-Identifier: Apache-2.0
+
+package mutation_test
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	admissionv1 "k8s.io/api/admission/v1"
+
+	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
+	pkgconst "github.com/vmware-tanzu/vm-operator/pkg/constants"
+	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
+	"github.com/vmware-tanzu/vm-operator/test/builder"
+	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachinepublishrequest/mutation"
+)
+
+func unitTests() {
+	Describe(
+		"Mutate",
+		Label(
+			testlabels.Create,
+			testlabels.Update,
+			testlabels.API,
+			testlabels.Mutation,
+			testlabels.Webhook,
+		),
+		unitTestsMutating,
+	)
+}
+
+type unitMutationWebhookContext struct {
+	builder.UnitTestContextForMutatingWebhook
+	vmPub *vmopv1.VirtualMachinePublishRequest
+}
+
+func newUnitTestContextForMutatingWebhook() *unitMutationWebhookContext {
+	vmPub := builder.DummyVirtualMachinePublishRequest(
+		"dummy-vmpub", "dummy-ns", "dummy-vm", "dummy-item", "dummy-cl")
+	obj, err := builder.ToUnstructured(vmPub)
+	Expect(err).ToNot(HaveOccurred())
+
+	return &unitMutationWebhookContext{
+		UnitTestContextForMutatingWebhook: *suite.NewUnitTestContextForMutatingWebhook(obj),
+		vmPub:                             vmPub,
+	}
+}
+
+func unitTestsMutating() {
+	var (
+		ctx *unitMutationWebhookContext
+	)
+
+	BeforeEach(func() {
+		ctx = newUnitTestContextForMutatingWebhook()
+		ctx.UserInfo.Username = "my-user"
+	})
+	AfterEach(func() {
+		ctx = nil
+	})
+
+	Describe("Mutate", func() {
+		It("should set the requested-by annotation on create", func() {
+			var err error
+			ctx.Op = admissionv1.Create
+			ctx.RawObj, err = json.Marshal(ctx.vmPub)
+			Expect(err).ToNot(HaveOccurred())
+			response := ctx.Mutate(&ctx.WebhookRequestContext)
+			Expect(response.Allowed).To(BeTrue())
+			Expect(response.Patches).ToNot(BeEmpty())
+		})
+
+		It("should not mutate an update", func() {
+			ctx.Op = admissionv1.Update
+			response := ctx.Mutate(&ctx.WebhookRequestContext)
+			Expect(response.Allowed).To(BeTrue())
+			Expect(response.Patches).To(BeEmpty())
+		})
+	})
+
+	Describe("SetRequestedBy", func() {
+		It("should set the annotation to the name of the user", func() {
+			Expect(mutation.SetRequestedBy(&ctx.WebhookRequestContext, ctx.vmPub)).To(BeTrue())
+			Expect(ctx.vmPub.Annotations).To(HaveKeyWithValue(
+				pkgconst.RequestedByAnnotationKey, "my-user"))
+		})
+
+		When("the user provides the annotation", func() {
+			BeforeEach(func() {
+				ctx.vmPub.Annotations = map[string]string{
+					pkgconst.RequestedByAnnotationKey: "another-user",
+				}
+			})
+
+			It("should overwrite the annotation", func() {
+				Expect(mutation.SetRequestedBy(&ctx.WebhookRequestContext, ctx.vmPub)).To(BeTrue())
+				Expect(ctx.vmPub.Annotations).To(HaveKeyWithValue(
+					pkgconst.RequestedByAnnotationKey, "my-user"))
+			})
+
+			When("the user is privileged", func() {
+				BeforeEach(func() {
+					ctx.IsPrivilegedAccount = true
+				})
+
+				It("should preserve the annotation", func() {
+					Expect(mutation.SetRequestedBy(&ctx.WebhookRequestContext, ctx.vmPub)).To(BeFalse())
+					Expect(ctx.vmPub.Annotations).To(HaveKeyWithValue(
+						pkgconst.RequestedByAnnotationKey, "another-user"))
+				})
+			})
+		})
+	})
+}
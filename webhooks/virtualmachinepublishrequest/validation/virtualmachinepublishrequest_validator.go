@@ -25,12 +25,16 @@ import (
 	vmopv1a3 "github.com/vmware-tanzu/vm-operator/api/v1alpha3"
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
 	"github.com/vmware-tanzu/vm-operator/pkg/builder"
+	pkgconst "github.com/vmware-tanzu/vm-operator/pkg/constants"
 	pkgctx "github.com/vmware-tanzu/vm-operator/pkg/context"
+	"github.com/vmware-tanzu/vm-operator/pkg/util/oci"
 	"github.com/vmware-tanzu/vm-operator/webhooks/common"
 )
 
 const (
 	webHookName = "default"
+
+	modifyAnnotationNotAllowedForNonAdmin = "modifying this annotation is not allowed for non-admin users"
 )
 
 // +kubebuilder:webhook:verbs=create;update,path=/default-validate-vmoperator-vmware-com-v1alpha4-virtualmachinepublishrequest,mutating=false,failurePolicy=fail,groups=vmoperator.vmware.com,resources=virtualmachinepublishrequests,versions=v1alpha4,name=default.validating.virtualmachinepublishrequest.v1alpha4.vmoperator.vmware.com,sideEffects=None,admissionReviewVersions=v1;v1beta1
@@ -104,6 +108,7 @@ func (v validator) ValidateUpdate(ctx *pkgctx.WebhookRequestContext) admission.R
 
 	// Check if an immutable field has been modified.
 	fieldErrs = append(fieldErrs, v.validateImmutableFields(vmpub, oldVMpub)...)
+	fieldErrs = append(fieldErrs, v.validateAnnotation(ctx, vmpub, oldVMpub)...)
 
 	validationErrs := make([]string, 0, len(fieldErrs))
 	for _, fieldErr := range fieldErrs {
@@ -133,9 +138,17 @@ func (v validator) validateSource(ctx *pkgctx.WebhookRequestContext, vmpub *vmop
 		}
 	}
 
-	if kind := vmpub.Spec.Source.Kind; kind != reflect.TypeOf(vmopv1.VirtualMachine{}).Name() && kind != "" {
+	switch kind := vmpub.Spec.Source.Kind; kind {
+	case vmopv1.VirtualMachinePublishRequestSourceKindVirtualMachine,
+		vmopv1.VirtualMachinePublishRequestSourceKindVirtualMachineSnapshot,
+		"":
+	default:
 		allErrs = append(allErrs, field.NotSupported(sourcePath.Child("kind"),
-			vmpub.Spec.Source.Kind, []string{reflect.TypeOf(vmopv1.VirtualMachine{}).Name(), ""}))
+			kind, []string{
+				vmopv1.VirtualMachinePublishRequestSourceKindVirtualMachine,
+				vmopv1.VirtualMachinePublishRequestSourceKindVirtualMachineSnapshot,
+				"",
+			}))
 	}
 
 	return allErrs
@@ -146,6 +159,11 @@ func (v validator) validateTargetLocation(ctx *pkgctx.WebhookRequestContext, vmp
 
 	targetLocationPath := field.NewPath("spec").Child("target").
 		Child("location")
+
+	if vmpub.Spec.Target.Location.Kind == vmopv1.VirtualMachinePublishRequestTargetLocationKindOCIRegistry {
+		return v.validateTargetOCIRegistry(vmpub, targetLocationPath.Child("oci"))
+	}
+
 	targetLocationName := vmpub.Spec.Target.Location.Name
 	targetLocationNamePath := targetLocationPath.Child("name")
 	if targetLocationName == "" {
@@ -159,7 +177,52 @@ func (v validator) validateTargetLocation(ctx *pkgctx.WebhookRequestContext, vmp
 
 	if vmpub.Spec.Target.Location.Kind != reflect.TypeOf(imgregv1a1.ContentLibrary{}).Name() {
 		allErrs = append(allErrs, field.NotSupported(targetLocationPath.Child("kind"),
-			vmpub.Spec.Target.Location.Kind, []string{reflect.TypeOf(imgregv1a1.ContentLibrary{}).Name(), ""}))
+			vmpub.Spec.Target.Location.Kind, []string{
+				reflect.TypeOf(imgregv1a1.ContentLibrary{}).Name(),
+				vmopv1.VirtualMachinePublishRequestTargetLocationKindOCIRegistry,
+				"",
+			}))
+	}
+
+	if vmpub.Spec.Target.Location.OCI != nil {
+		allErrs = append(allErrs, field.Forbidden(targetLocationPath.Child("oci"),
+			fmt.Sprintf("only allowed when kind is %s",
+				vmopv1.VirtualMachinePublishRequestTargetLocationKindOCIRegistry)))
+	}
+
+	return allErrs
+}
+
+func (v validator) validateTargetOCIRegistry(
+	vmpub *vmopv1.VirtualMachinePublishRequest,
+	ociPath *field.Path) field.ErrorList {
+
+	var allErrs field.ErrorList
+
+	ociSpec := vmpub.Spec.Target.Location.OCI
+	if ociSpec == nil {
+		return append(allErrs, field.Required(ociPath, ""))
+	}
+
+	repoPath := ociPath.Child("repository")
+	ref, err := oci.ParseReference(ociSpec.Repository)
+	switch {
+	case err != nil:
+		allErrs = append(allErrs, field.Invalid(repoPath, ociSpec.Repository, err.Error()))
+	case ref.Tag != "" || ref.Digest != "":
+		allErrs = append(allErrs, field.Invalid(repoPath, ociSpec.Repository,
+			"must not include a tag or digest"))
+	}
+
+	if tag := ociSpec.Tag; tag == "" {
+		// The tag defaults to the name of the target item.
+		if name := vmpub.Spec.Target.Item.Name; name != "" && !oci.ValidTag(name) {
+			allErrs = append(allErrs, field.Invalid(
+				field.NewPath("spec", "target", "item", "name"), name,
+				"must be a valid tag when spec.target.location.oci.tag is omitted"))
+		}
+	} else if !oci.ValidTag(tag) {
+		allErrs = append(allErrs, field.Invalid(ociPath.Child("tag"), tag, "must be a valid tag"))
 	}
 
 	return allErrs
@@ -177,6 +240,30 @@ func (v validator) validateImmutableFields(vmpub, oldvmpub *vmopv1.VirtualMachin
 	return allErrs
 }
 
+// validateAnnotation denies changes to the requested-by annotation by
+// unprivileged users, since it is recorded in the provenance of the published
+// image. The annotation is set by the mutation webhook when the object is
+// created.
+func (v validator) validateAnnotation(
+	ctx *pkgctx.WebhookRequestContext,
+	vmpub, oldvmpub *vmopv1.VirtualMachinePublishRequest) field.ErrorList {
+
+	var allErrs field.ErrorList
+
+	if ctx.IsPrivilegedAccount {
+		return allErrs
+	}
+
+	annotationPath := field.NewPath("metadata", "annotations")
+
+	if vmpub.Annotations[pkgconst.RequestedByAnnotationKey] != oldvmpub.Annotations[pkgconst.RequestedByAnnotationKey] {
+		allErrs = append(allErrs, field.Forbidden(
+			annotationPath.Key(pkgconst.RequestedByAnnotationKey), modifyAnnotationNotAllowedForNonAdmin))
+	}
+
+	return allErrs
+}
+
 // vmPublishRequestFromUnstructured returns the VirtualMachineService from the unstructured object.
 func (v validator) vmPublishRequestFromUnstructured(obj runtime.Unstructured) (*vmopv1.VirtualMachinePublishRequest, error) {
 	vmPubReq := &vmopv1.VirtualMachinePublishRequest{}
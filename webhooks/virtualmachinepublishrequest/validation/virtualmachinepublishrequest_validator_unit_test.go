@@ -16,6 +16,7 @@ import (
 
 	vmopv1 "github.com/vmware-tanzu/vm-operator/api/v1alpha4"
 	"github.com/vmware-tanzu/vm-operator/controllers/contentlibrary/utils"
+	pkgconst "github.com/vmware-tanzu/vm-operator/pkg/constants"
 	"github.com/vmware-tanzu/vm-operator/pkg/constants/testlabels"
 	"github.com/vmware-tanzu/vm-operator/test/builder"
 )
@@ -101,6 +102,7 @@ func unitTestsValidateCreate() {
 	type createArgs struct {
 		invalidSourceAPIVersion         bool
 		invalidSourceKind               bool
+		snapshotSource                  bool
 		invalidTargetLocationAPIVersion bool
 		invalidTargetLocationKind       bool
 		sourceNotFound                  bool
@@ -109,6 +111,11 @@ func unitTestsValidateCreate() {
 		targetLocationNameEmpty         bool
 		targetLocationNotFound          bool
 		targetItemAlreadyExists         bool
+		ociTarget                       bool
+		ociTargetMissing                bool
+		ociRepositoryWithTag            bool
+		ociInvalidTag                   bool
+		ociWithContentLibrary           bool
 	}
 
 	validateCreate := func(args createArgs, expectedAllowed bool, expectedReason string, expectedErr error) {
@@ -120,6 +127,10 @@ func unitTestsValidateCreate() {
 			ctx.vmPub.Spec.Source.Kind = "Machine"
 		}
 
+		if args.snapshotSource {
+			ctx.vmPub.Spec.Source.Kind = vmopv1.VirtualMachinePublishRequestSourceKindVirtualMachineSnapshot
+		}
+
 		if args.invalidTargetLocationAPIVersion {
 			ctx.vmPub.Spec.Target.Location.APIVersion = invalidAPIVersion
 		}
@@ -128,6 +139,33 @@ func unitTestsValidateCreate() {
 			ctx.vmPub.Spec.Target.Location.Kind = "ClusterContentLibrary"
 		}
 
+		if args.ociTarget {
+			ctx.vmPub.Spec.Target.Location = vmopv1.VirtualMachinePublishRequestTargetLocation{
+				Kind: vmopv1.VirtualMachinePublishRequestTargetLocationKindOCIRegistry,
+				OCI: &vmopv1.VirtualMachinePublishRequestTargetOCIRegistry{
+					Repository: "registry.example.com/my-project/my-image",
+				},
+			}
+		}
+
+		if args.ociTargetMissing {
+			ctx.vmPub.Spec.Target.Location.OCI = nil
+		}
+
+		if args.ociRepositoryWithTag {
+			ctx.vmPub.Spec.Target.Location.OCI.Repository += ":v1"
+		}
+
+		if args.ociInvalidTag {
+			ctx.vmPub.Spec.Target.Location.OCI.Tag = "-v1"
+		}
+
+		if args.ociWithContentLibrary {
+			ctx.vmPub.Spec.Target.Location.OCI = &vmopv1.VirtualMachinePublishRequestTargetOCIRegistry{
+				Repository: "registry.example.com/my-project/my-image",
+			}
+		}
+
 		if args.sourceNotFound {
 			Expect(ctx.Client.Delete(ctx, ctx.vm)).To(Succeed())
 		}
@@ -186,15 +224,26 @@ func unitTestsValidateCreate() {
 				[]string{"vmoperator.vmware.com/v1alpha1", "vmoperator.vmware.com/v1alpha2", "vmoperator.vmware.com/v1alpha3", "vmoperator.vmware.com/v1alpha4", ""}).Error(), nil),
 		Entry("should deny invalid source kind", createArgs{invalidSourceKind: true}, false,
 			field.NotSupported(sourcePath.Child("kind"), "Machine",
-				[]string{"VirtualMachine", ""}).Error(), nil),
+				[]string{"VirtualMachine", "VirtualMachineSnapshot", ""}).Error(), nil),
+		Entry("should allow snapshot source kind", createArgs{snapshotSource: true}, true, nil, nil),
 		Entry("should deny invalid target location API version", createArgs{invalidTargetLocationAPIVersion: true}, false,
 			field.NotSupported(targetLocationPath.Child("apiVersion"), invalidAPIVersion,
 				[]string{"imageregistry.vmware.com/v1alpha1", ""}).Error(), nil),
 		Entry("should deny invalid target location kind", createArgs{invalidTargetLocationKind: true}, false,
 			field.NotSupported(targetLocationPath.Child("kind"), "ClusterContentLibrary",
-				[]string{"ContentLibrary", ""}).Error(), nil),
+				[]string{"ContentLibrary", "OCIRegistry", ""}).Error(), nil),
 		Entry("should deny if target location name is empty", createArgs{targetLocationNameEmpty: true}, false,
 			field.Required(targetLocationPath.Child("name"), "").Error(), nil),
+		Entry("should deny oci when target location kind is ContentLibrary", createArgs{ociWithContentLibrary: true}, false,
+			field.Forbidden(targetLocationPath.Child("oci"), "only allowed when kind is OCIRegistry").Error(), nil),
+		Entry("should allow valid oci registry target", createArgs{ociTarget: true}, true, nil, nil),
+		Entry("should deny oci registry target without oci", createArgs{ociTarget: true, ociTargetMissing: true}, false,
+			field.Required(targetLocationPath.Child("oci"), "").Error(), nil),
+		Entry("should deny oci registry target with a tag in the repository", createArgs{ociTarget: true, ociRepositoryWithTag: true}, false,
+			field.Invalid(targetLocationPath.Child("oci", "repository"), "registry.example.com/my-project/my-image:v1",
+				"must not include a tag or digest").Error(), nil),
+		Entry("should deny oci registry target with an invalid tag", createArgs{ociTarget: true, ociInvalidTag: true}, false,
+			field.Invalid(targetLocationPath.Child("oci", "tag"), "-v1", "must be a valid tag").Error(), nil),
 	)
 }
 
@@ -232,6 +281,35 @@ func unitTestsValidateUpdate() {
 			Expect(string(response.Result.Reason)).To(ContainSubstring("field is immutable"))
 		})
 	})
+
+	Context("Requested-by annotation is updated", func() {
+		var err error
+
+		BeforeEach(func() {
+			ctx.vmPub.Annotations = map[string]string{
+				pkgconst.RequestedByAnnotationKey: "another-user",
+			}
+			ctx.WebhookRequestContext.Obj, err = builder.ToUnstructured(ctx.vmPub)
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("should not allow the request", func() {
+			Expect(response.Allowed).To(BeFalse())
+			Expect(response.Result).ToNot(BeNil())
+			Expect(string(response.Result.Reason)).To(ContainSubstring(
+				"metadata.annotations[vmoperator.vmware.com.protected/requested-by]: Forbidden"))
+		})
+
+		When("the request is from a privileged account", func() {
+			BeforeEach(func() {
+				ctx.IsPrivilegedAccount = true
+			})
+
+			It("should allow the request", func() {
+				Expect(response.Allowed).To(BeTrue())
+			})
+		})
+	})
 }
 
 func unitTestsValidateDelete() {
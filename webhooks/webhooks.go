@@ -15,8 +15,12 @@ import (
 	"github.com/vmware-tanzu/vm-operator/webhooks/persistentvolumeclaim"
 	"github.com/vmware-tanzu/vm-operator/webhooks/unifiedstoragequota"
 	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachine"
+	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachinebootstrapdefaults"
 	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachineclass"
 	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachinegroup"
+	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachineimagecachepolicy"
+	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachineimagechannel"
+	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachineimportrequest"
 	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachinepublishrequest"
 	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachinereplicaset"
 	"github.com/vmware-tanzu/vm-operator/webhooks/virtualmachineservice"
@@ -35,9 +39,15 @@ func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr ctrlmgr.Manager) err
 	if err := virtualmachine.AddToManager(ctx, mgr); err != nil {
 		return fmt.Errorf("failed to initialize VirtualMachine webhooks: %w", err)
 	}
+	if err := virtualmachinebootstrapdefaults.AddToManager(ctx, mgr); err != nil {
+		return fmt.Errorf("failed to initialize VirtualMachineBootstrapDefaults webhooks: %w", err)
+	}
 	if err := virtualmachineclass.AddToManager(ctx, mgr); err != nil {
 		return fmt.Errorf("failed to initialize VirtualMachineClass webhooks: %w", err)
 	}
+	if err := virtualmachineimagechannel.AddToManager(ctx, mgr); err != nil {
+		return fmt.Errorf("failed to initialize VirtualMachineImageChannel webhooks: %w", err)
+	}
 	if err := virtualmachinepublishrequest.AddToManager(ctx, mgr); err != nil {
 		return fmt.Errorf("failed to initialize VirtualMachinePublishRequest webhooks: %w", err)
 	}
@@ -61,6 +71,18 @@ func AddToManager(ctx *pkgctx.ControllerManagerContext, mgr ctrlmgr.Manager) err
 		return fmt.Errorf("failed to initialize UnifiedStorageQuota webhooks: %w", err)
 	}
 
+	if pkgcfg.FromContext(ctx).Features.VMImportNewNet {
+		if err := virtualmachineimportrequest.AddToManager(ctx, mgr); err != nil {
+			return fmt.Errorf("failed to initialize VirtualMachineImportRequest webhooks: %w", err)
+		}
+	}
+
+	if pkgcfg.FromContext(ctx).Features.FastDeploy {
+		if err := virtualmachineimagecachepolicy.AddToManager(ctx, mgr); err != nil {
+			return fmt.Errorf("failed to initialize VirtualMachineImageCachePolicy webhooks: %w", err)
+		}
+	}
+
 	if pkgcfg.FromContext(ctx).Features.VMGroups {
 		if err := virtualmachinegroup.AddToManager(ctx, mgr); err != nil {
 			return fmt.Errorf("failed to initialize VirtualMachineGroup webhooks: %w", err)